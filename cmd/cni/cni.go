@@ -3,7 +3,9 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
+	"os"
 	"runtime"
 	"strings"
 
@@ -24,8 +26,19 @@ func main() {
 	// must ensure that the goroutine does not jump from OS thread to thread
 	runtime.LockOSThread()
 
+	// STATUS is introduced in CNI spec 1.1.0 which is not supported by skel yet
+	if os.Getenv("CNI_COMMAND") == "STATUS" {
+		if err := cmdStatus(os.Stdin); err != nil {
+			if e := err.Print(); e != nil {
+				fmt.Fprintf(os.Stderr, "failed to print error: %v\n", e)
+			}
+			os.Exit(1)
+		}
+		return
+	}
+
 	about := fmt.Sprintf("CNI kube-ovn plugin %s", versions.VERSION)
-	skel.PluginMain(cmdAdd, nil, cmdDel, version.All, about)
+	skel.PluginMain(cmdAdd, cmdCheck, cmdDel, version.All, about)
 }
 
 func cmdAdd(args *skel.CmdArgs) error {
@@ -169,6 +182,67 @@ func cmdDel(args *skel.CmdArgs) error {
 	return nil
 }
 
+func cmdCheck(args *skel.CmdArgs) error {
+	netConf, _, err := loadNetConf(args.StdinData)
+	if err != nil {
+		return err
+	}
+	podName, err := parseValueFromArgs("K8S_POD_NAME", args.Args)
+	if err != nil {
+		return err
+	}
+	podNamespace, err := parseValueFromArgs("K8S_POD_NAMESPACE", args.Args)
+	if err != nil {
+		return err
+	}
+	if netConf.Provider == "" && netConf.Type == util.CniTypeName && args.IfName == "eth0" {
+		netConf.Provider = util.OvnProvider
+	}
+
+	client := request.NewCniServerClient(netConf.ServerSocket)
+	err = client.Check(request.CniRequest{
+		CniType:                    netConf.Type,
+		PodName:                    podName,
+		PodNamespace:               podNamespace,
+		ContainerID:                args.ContainerID,
+		NetNs:                      args.Netns,
+		IfName:                     args.IfName,
+		Provider:                   netConf.Provider,
+		Routes:                     netConf.Routes,
+		DeviceID:                   netConf.DeviceID,
+		VhostUserSocketVolumeName:  netConf.VhostUserSocketVolumeName,
+		VhostUserSocketConsumption: netConf.VhostUserSocketConsumption,
+	})
+	if err != nil {
+		return types.NewError(types.ErrInternal, "container network check failed", err.Error())
+	}
+	return nil
+}
+
+// error code of the STATUS verb defined by CNI spec 1.1.0
+const errPluginNotAvailable uint = 50
+
+func cmdStatus(stdin io.Reader) *types.Error {
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return types.NewError(types.ErrIOFailure, "failed to read network configuration", err.Error())
+	}
+	netConf, _, err := loadNetConf(data)
+	if err != nil {
+		if e, ok := err.(*types.Error); ok {
+			return e
+		}
+		return types.NewError(types.ErrDecodingFailure, "failed to load netconf", err.Error())
+	}
+
+	client := request.NewCniServerClient(netConf.ServerSocket)
+	if err = client.Status(); err != nil {
+		// the plugin can not serve add requests whether kube-ovn-cni is unreachable or not ready yet
+		return types.NewError(errPluginNotAvailable, "kube-ovn-cni is not available", err.Error())
+	}
+	return nil
+}
+
 type ipamConf struct {
 	ServerSocket string `json:"server_socket"`
 	Provider     string `json:"provider"`
@@ -89,6 +89,18 @@ func CmdMain() {
 		util.LogFatalAndExit(server.ListenAndServe(), "failed to listen and server on %s", server.Addr)
 	}()
 
+	go func() {
+		// the policy verdict api is not exposed out of the pod, kubectl-ko calls it through kubectl exec
+		mux := http.NewServeMux()
+		mux.HandleFunc(controller.PolicyVerdictPath, controller.PolicyVerdictHandler)
+		server := &http.Server{
+			Addr:              fmt.Sprintf("127.0.0.1:%d", config.PolicyVerdictPort),
+			ReadHeaderTimeout: 3 * time.Second,
+			Handler:           mux,
+		}
+		util.LogFatalAndExit(server.ListenAndServe(), "failed to listen and server on %s", server.Addr)
+	}()
+
 	//	ctx, cancel := context.WithCancel(context.Background())
 	recorder := record.NewBroadcaster().NewRecorder(scheme.Scheme, apiv1.EventSource{
 		Component: ovnLeaderResource,
@@ -23,6 +23,7 @@ func CmdMain() {
 		util.LogFatalAndExit(err, "failed to parse config")
 	}
 
+	speaker.InitMetrics()
 	stopCh := signals.SetupSignalHandler().Done()
 	ctl := speaker.NewController(config)
 
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+
+	"github.com/kubeovn/kube-ovn/pkg/natgw"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+	"github.com/kubeovn/kube-ovn/versions"
+)
+
+func main() {
+	klog.Infof(versions.String())
+
+	// the agent is reached through port forwarding, so listening on the loopback address is enough
+	address := pflag.String("address", "127.0.0.1", "The address the agent listens on.")
+	port := pflag.Int32("port", natgw.DefaultPort, "The port the agent listens on.")
+	script := pflag.String("script", "/kube-ovn/nat-gateway.sh", "The script applying rules in the nat gateway.")
+	stateFile := pflag.String("state-file", "/var/run/kube-ovn/nat-gw-agent.json", "The file saving the applied rules, which should live as long as the pod.")
+	haRoleFile := pflag.String("ha-role-file", "/var/run/kube-ovn/ha-role", "The file where keepalived writes the role of the instance in active/standby mode.")
+
+	klogFlags := flag.NewFlagSet("klog", flag.ExitOnError)
+	klog.InitFlags(klogFlags)
+	pflag.CommandLine.AddGoFlagSet(klogFlags)
+	pflag.Parse()
+
+	token := os.Getenv(natgw.TokenEnv)
+	if token == "" {
+		util.LogFatalAndExit(nil, "environment variable %s is not set", natgw.TokenEnv)
+	}
+	if err := os.MkdirAll(filepath.Dir(*stateFile), 0o700); err != nil {
+		util.LogFatalAndExit(err, "failed to create directory of state file %s", *stateFile)
+	}
+
+	agent, err := natgw.NewAgent(natgw.NewScriptExecutor(*script), *stateFile, *haRoleFile)
+	if err != nil {
+		util.LogFatalAndExit(err, "failed to create nat gateway agent")
+	}
+
+	server := &http.Server{
+		Addr:              util.JoinHostPort(*address, *port),
+		Handler:           natgw.NewHandler(agent, token),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			klog.Errorf("failed to shutdown nat gateway agent: %v", err)
+		}
+	}()
+
+	klog.Infof("nat gateway agent listens on %s", server.Addr)
+	if err = server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		util.LogFatalAndExit(err, "failed to run nat gateway agent")
+	}
+}
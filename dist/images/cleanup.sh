@@ -14,6 +14,10 @@ for gw in $(kubectl get vpc-nat-gw -o name); do
   kubectl delete --ignore-not-found $gw
 done
 
+for gw in $(kubectl get vpc-egress-gw -o name); do
+  kubectl delete --ignore-not-found $gw
+done
+
 for vd in $(kubectl  get vpc-dns -o name); do
   kubectl delete --ignore-not-found $vd
 done
@@ -26,6 +30,30 @@ for vip in $(kubectl get vip -o name); do
    kubectl delete --ignore-not-found $vip
 done
 
+for vpchub in $(kubectl get vpchub -o name); do
+   kubectl delete --ignore-not-found $vpchub
+done
+
+for vpcpeer in $(kubectl get vpcpeer -A -o name); do
+   kubectl delete --ignore-not-found $vpcpeer
+done
+
+for vsnat in $(kubectl get vsnat -o name); do
+   kubectl delete --ignore-not-found $vsnat
+done
+
+for vdnat in $(kubectl get vdnat -o name); do
+   kubectl delete --ignore-not-found $vdnat
+done
+
+for vfip in $(kubectl get vfip -o name); do
+   kubectl delete --ignore-not-found $vfip
+done
+
+for veip in $(kubectl get veip -o name); do
+   kubectl delete --ignore-not-found $veip
+done
+
 for snat in $(kubectl get snat -o name); do
    kubectl delete --ignore-not-found $snat
 done
@@ -113,7 +141,7 @@ kubectl delete --ignore-not-found svc ovn-nb ovn-sb ovn-northd -n kube-system
 kubectl delete --ignore-not-found deploy ovn-central -n kube-system
 kubectl delete --ignore-not-found ds ovs-ovn -n kube-system
 kubectl delete --ignore-not-found ds ovs-ovn-dpdk -n kube-system
-kubectl delete --ignore-not-found secret kube-ovn-tls -n kube-system
+kubectl delete --ignore-not-found secret kube-ovn-tls vpc-nat-gw-agent -n kube-system
 kubectl delete --ignore-not-found sa ovn -n kube-system
 kubectl delete --ignore-not-found clusterrole system:ovn
 kubectl delete --ignore-not-found clusterrolebinding ovn
@@ -145,7 +173,18 @@ kubectl delete --ignore-not-found crd \
   ovn-snat-rules.kubeovn.io \
   ovn-fips.kubeovn.io \
   ovn-eips.kubeovn.io \
-  qos-policies.kubeovn.io
+  qos-policies.kubeovn.io \
+  ip-quotas.kubeovn.io \
+  bgp-peers.kubeovn.io \
+  fqdn-caches.kubeovn.io \
+  address-groups.kubeovn.io \
+  vpc-egress-gateways.kubeovn.io \
+  vpc-eips.kubeovn.io \
+  vpc-fips.kubeovn.io \
+  vpc-dnat-rules.kubeovn.io \
+  vpc-snat-rules.kubeovn.io \
+  vpc-peering-connections.kubeovn.io \
+  vpc-transit-hubs.kubeovn.io
 
 # Remove annotations/labels in namespaces and nodes
 kubectl annotate no --all ovn.kubernetes.io/cidr-
@@ -13,6 +13,7 @@ VLAN_NIC=${VLAN_NIC:-}
 HW_OFFLOAD=${HW_OFFLOAD:-false}
 ENABLE_LB=${ENABLE_LB:-true}
 ENABLE_NP=${ENABLE_NP:-true}
+ENABLE_ANP=${ENABLE_ANP:-false}
 ENABLE_EIP_SNAT=${ENABLE_EIP_SNAT:-true}
 LS_DNAT_MOD_DL_DST=${LS_DNAT_MOD_DL_DST:-true}
 ENABLE_EXTERNAL_VPC=${ENABLE_EXTERNAL_VPC:-true}
@@ -33,6 +34,8 @@ IFACE=${IFACE:-}
 DPDK_TUNNEL_IFACE=${DPDK_TUNNEL_IFACE:-br-phy}
 ENABLE_BIND_LOCAL_IP=${ENABLE_BIND_LOCAL_IP:-true}
 ENABLE_TPROXY=${ENABLE_TPROXY:-false}
+ENABLE_FQDN_SNOOPING=${ENABLE_FQDN_SNOOPING:-false}
+ENABLE_ACL_AUDIT_LOG=${ENABLE_ACL_AUDIT_LOG:-false}
 OVS_VSCTL_CONCURRENCY=${OVS_VSCTL_CONCURRENCY:-100}
 ENABLE_COMPACT=${ENABLE_COMPACT:-false}
 
@@ -183,6 +186,7 @@ echo "Default Subnet CIDR:  $POD_CIDR"
 echo "Join Subnet CIDR:     $JOIN_CIDR"
 echo "Enable SVC LB:        $ENABLE_LB"
 echo "Enable Networkpolicy: $ENABLE_NP"
+echo "Enable AdminNetworkPolicy: $ENABLE_ANP"
 echo "Enable EIP and SNAT:  $ENABLE_EIP_SNAT"
 echo "Enable Mirror:        $ENABLE_MIRROR"
 echo "-------------------------------"
@@ -397,6 +401,12 @@ spec:
         - jsonPath: .spec.lanIp
           name: LanIP
           type: string
+        - jsonPath: .spec.replicas
+          name: Replicas
+          type: integer
+        - jsonPath: .status.activeInstance
+          name: Active
+          type: string
       name: v1
       served: true
       storage: true
@@ -419,6 +429,40 @@ spec:
                     type: string
                 qosPolicy:
                   type: string
+                activeInstance:
+                  type: string
+                vrid:
+                  type: integer
+                conditions:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      type:
+                        type: string
+                      status:
+                        type: string
+                      reason:
+                        type: string
+                      message:
+                        type: string
+                      lastUpdateTime:
+                        type: string
+                      lastTransitionTime:
+                        type: string
+                instances:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      name:
+                        type: string
+                      node:
+                        type: string
+                      ip:
+                        type: string
+                      role:
+                        type: string
                 tolerations:
                   type: array
                   items:
@@ -716,6 +760,9 @@ spec:
               properties:
                 lanIp:
                   type: string
+                replicas:
+                  type: integer
+                  minimum: 1
                 subnet:
                   type: string
                 externalSubnets:
@@ -1148,6 +1195,9 @@ spec:
       - jsonPath: .status.natGwDp
         name: NatGwDp
         type: string
+      - jsonPath: .status.activeSessions
+        name: Sessions
+        type: integer
       schema:
         openAPIV3Schema:
           type: object
@@ -1167,6 +1217,8 @@ spec:
                   type: string
                 internalIp:
                   type: string
+                activeSessions:
+                  type: integer
                 conditions:
                   type: array
                   items:
@@ -1191,6 +1243,9 @@ spec:
                   type: string
                 internalIp:
                   type: string
+                drainPeriodSeconds:
+                  type: integer
+                  minimum: 0
 ---
 apiVersion: apiextensions.k8s.io/v1
 kind: CustomResourceDefinition
@@ -1240,6 +1295,9 @@ spec:
       - jsonPath: .status.ready
         name: Ready
         type: boolean
+      - jsonPath: .status.activeSessions
+        name: Sessions
+        type: integer
       schema:
         openAPIV3Schema:
           type: object
@@ -1265,6 +1323,8 @@ spec:
                   type: string
                 externalPort:
                   type: string
+                activeSessions:
+                  type: integer
                 conditions:
                   type: array
                   items:
@@ -1295,6 +1355,9 @@ spec:
                   type: string
                 internalPort:
                   type: string
+                drainPeriodSeconds:
+                  type: integer
+                  minimum: 0
 ---
 apiVersion: apiextensions.k8s.io/v1
 kind: CustomResourceDefinition
@@ -1354,6 +1417,10 @@ spec:
                   type: string
                 internalCIDR:
                   type: string
+                portBlockSize:
+                  type: integer
+                portBlockStart:
+                  type: integer
                 conditions:
                   type: array
                   items:
@@ -1378,6 +1445,10 @@ spec:
                   type: string
                 internalCIDR:
                   type: string
+                portBlockSize:
+                  type: integer
+                  minimum: 0
+                  maximum: 64512
 ---
 apiVersion: apiextensions.k8s.io/v1
 kind: CustomResourceDefinition
@@ -1601,6 +1672,10 @@ spec:
                   type: string
                 vpc:
                   type: string
+                portBlockSize:
+                  type: integer
+                portBlockStart:
+                  type: integer
                 conditions:
                   type: array
                   items:
@@ -1631,6 +1706,10 @@ spec:
                   type: string
                 v4IpCidr:
                   type: string
+                portBlockSize:
+                  type: integer
+                  minimum: 0
+                  maximum: 64512
 ---
 apiVersion: apiextensions.k8s.io/v1
 kind: CustomResourceDefinition
@@ -1776,6 +1855,11 @@ spec:
                   type: boolean
                 enableBfd:
                   type: boolean
+                natBackend:
+                  type: string
+                  enum:
+                    - iptables
+                    - ovn
                 namespaces:
                   items:
                     type: string
@@ -2188,6 +2272,21 @@ spec:
                             type: string
                           dstIPs:
                             type: string
+                cidrs:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      cidr:
+                        type: string
+                      gateway:
+                        type: string
+                      secondary:
+                        type: boolean
+                      availableIPs:
+                        type: number
+                      usingIPs:
+                        type: number
                 conditions:
                   type: array
                   items:
@@ -2220,6 +2319,10 @@ spec:
                     - Dual
                 cidrBlock:
                   type: string
+                secondaryCIDRBlocks:
+                  type: array
+                  items:
+                    type: string
                 namespaces:
                   type: array
                   items:
@@ -2341,6 +2444,15 @@ spec:
                   type: boolean
                 routeTable:
                   type: string
+                ipAllocationStrategy:
+                  type: string
+                  enum:
+                    - sequential
+                    - random
+                    - least-recently-released
+                ipReleaseCooldown:
+                  type: integer
+                  minimum: 0
   scope: Cluster
   names:
     plural: subnets
@@ -2656,12 +2768,42 @@ spec:
                         type: string
                       remoteSecurityGroup:
                         type: string
+                      remoteAddressGroup:
+                        type: string
+                      portRanges:
+                        type: array
+                        items:
+                          type: object
+                          properties:
+                            portRangeMin:
+                              type: integer
+                              minimum: 1
+                              maximum: 65535
+                            portRangeMax:
+                              type: integer
+                              minimum: 1
+                              maximum: 65535
+                          required:
+                            - portRangeMin
+                            - portRangeMax
+                      icmpType:
+                        type: integer
+                        minimum: 0
+                        maximum: 255
+                      icmpCode:
+                        type: integer
+                        minimum: 0
+                        maximum: 255
                       portRangeMin:
                         type: integer
                       portRangeMax:
                         type: integer
                       policy:
                         type: string
+                      stateless:
+                        type: boolean
+                      description:
+                        type: string
                 egressRules:
                   type: array
                   items:
@@ -2679,14 +2821,46 @@ spec:
                         type: string
                       remoteSecurityGroup:
                         type: string
+                      remoteAddressGroup:
+                        type: string
+                      portRanges:
+                        type: array
+                        items:
+                          type: object
+                          properties:
+                            portRangeMin:
+                              type: integer
+                              minimum: 1
+                              maximum: 65535
+                            portRangeMax:
+                              type: integer
+                              minimum: 1
+                              maximum: 65535
+                          required:
+                            - portRangeMin
+                            - portRangeMax
+                      icmpType:
+                        type: integer
+                        minimum: 0
+                        maximum: 255
+                      icmpCode:
+                        type: integer
+                        minimum: 0
+                        maximum: 255
                       portRangeMin:
                         type: integer
                       portRangeMax:
                         type: integer
                       policy:
                         type: string
+                      stateless:
+                        type: boolean
+                      description:
+                        type: string
                 allowSameGroupTraffic:
                   type: boolean
+                audit:
+                  type: boolean
             status:
               type: object
               properties:
@@ -2694,6 +2868,8 @@ spec:
                   type: string
                 allowSameGroupTraffic:
                   type: boolean
+                audit:
+                  type: boolean
                 ingressMd5:
                   type: string
                 egressMd5:
@@ -2816,49 +2992,1155 @@ spec:
                   x-kubernetes-list-map-keys:
                     - name
                   x-kubernetes-list-type: map
-EOF
-
-cat <<EOF > ovn-ovs-sa.yaml
 ---
-apiVersion: v1
-kind: ServiceAccount
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
 metadata:
-  name: ovn-ovs
-  namespace: kube-system
+  name: ip-quotas.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: ip-quotas
+    singular: ip-quota
+    shortNames:
+      - ipquota
+    kind: IPQuota
+    listKind: IPQuotaList
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      subresources:
+        status: {}
+      additionalPrinterColumns:
+      - name: Subnet
+        type: string
+        jsonPath: .spec.subnet
+      - name: V4Used
+        type: number
+        jsonPath: .status.v4UsingIPs
+      - name: V4Limit
+        type: number
+        jsonPath: .spec.v4IPs
+      - name: V6Used
+        type: number
+        jsonPath: .status.v6UsingIPs
+      - name: V6Limit
+        type: number
+        jsonPath: .spec.v6IPs
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              properties:
+                subnet:
+                  type: string
+                  x-kubernetes-validations:
+                    - rule: "self == oldSelf"
+                      message: "This field is immutable."
+                namespaces:
+                  type: array
+                  x-kubernetes-list-type: set
+                  items:
+                    type: string
+                selector:
+                  type: object
+                  properties:
+                    matchLabels:
+                      type: object
+                      additionalProperties:
+                        type: string
+                    matchExpressions:
+                      type: array
+                      items:
+                        type: object
+                        properties:
+                          key:
+                            type: string
+                          operator:
+                            type: string
+                          values:
+                            type: array
+                            items:
+                              type: string
+                        required:
+                          - key
+                          - operator
+                v4IPs:
+                  type: integer
+                  minimum: 0
+                v6IPs:
+                  type: integer
+                  minimum: 0
+              required:
+                - subnet
+            status:
+              type: object
+              properties:
+                v4UsingIPs:
+                  type: integer
+                v6UsingIPs:
+                  type: integer
+          required:
+            - spec
 ---
-apiVersion: rbac.authorization.k8s.io/v1
-kind: ClusterRole
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
 metadata:
-  annotations:
-    rbac.authorization.k8s.io/system-only: "true"
-  name: system:ovn-ovs
-rules:
-  - apiGroups:
-      - ""
-    resources:
-      - pods
-    verbs:
-      - get
-      - patch
-  - apiGroups:
-      - ""
-      - networking.k8s.io
-      - apps
-    resources:
-      - services
-      - endpoints
-    verbs:
-      - get
-  - apiGroups:
-      - apps
-    resources:
-      - controllerrevisions
-    verbs:
-      - get
-      - list
+  name: bgp-peers.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: bgp-peers
+    singular: bgp-peer
+    shortNames:
+      - bgppeer
+    kind: BgpPeer
+    listKind: BgpPeerList
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      additionalPrinterColumns:
+      - name: NeighborAddress
+        type: string
+        jsonPath: .spec.neighborAddress
+      - name: NeighborAS
+        type: integer
+        jsonPath: .spec.neighborAs
+      - name: LocalAS
+        type: integer
+        jsonPath: .spec.localAs
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              properties:
+                nodeSelector:
+                  type: object
+                  properties:
+                    matchLabels:
+                      type: object
+                      additionalProperties:
+                        type: string
+                    matchExpressions:
+                      type: array
+                      items:
+                        type: object
+                        properties:
+                          key:
+                            type: string
+                          operator:
+                            type: string
+                          values:
+                            type: array
+                            items:
+                              type: string
+                        required:
+                          - key
+                          - operator
+                neighborAddress:
+                  type: string
+                neighborAs:
+                  type: integer
+                  minimum: 1
+                  maximum: 4294967295
+                localAs:
+                  type: integer
+                  minimum: 0
+                  maximum: 4294967295
+                authPasswordSecretRef:
+                  type: object
+                  properties:
+                    namespace:
+                      type: string
+                    name:
+                      type: string
+                    key:
+                      type: string
+                  required:
+                    - namespace
+                    - name
+                    - key
+                holdTime:
+                  type: string
+                keepaliveInterval:
+                  type: string
+                connectRetry:
+                  type: string
+                ebgpMultihopTTL:
+                  type: integer
+                  minimum: 0
+                  maximum: 255
+                passiveMode:
+                  type: boolean
+                gracefulRestart:
+                  type: boolean
+                addressFamilies:
+                  type: array
+                  x-kubernetes-list-type: set
+                  items:
+                    type: string
+                    enum:
+                      - IPv4
+                      - IPv6
+                importPolicy:
+                  type: object
+                  properties:
+                    rules:
+                      type: array
+                      items:
+                        type: object
+                        properties:
+                          prefix:
+                            type: string
+                          maskLengthMin:
+                            type: integer
+                            minimum: 0
+                            maximum: 128
+                          maskLengthMax:
+                            type: integer
+                            minimum: 0
+                            maximum: 128
+                          action:
+                            type: string
+                            enum:
+                              - accept
+                              - reject
+                        required:
+                          - prefix
+                          - action
+                    defaultAction:
+                      type: string
+                      enum:
+                        - accept
+                        - reject
+                exportPolicy:
+                  type: object
+                  properties:
+                    rules:
+                      type: array
+                      items:
+                        type: object
+                        properties:
+                          prefix:
+                            type: string
+                          maskLengthMin:
+                            type: integer
+                            minimum: 0
+                            maximum: 128
+                          maskLengthMax:
+                            type: integer
+                            minimum: 0
+                            maximum: 128
+                          action:
+                            type: string
+                            enum:
+                              - accept
+                              - reject
+                        required:
+                          - prefix
+                          - action
+                    defaultAction:
+                      type: string
+                      enum:
+                        - accept
+                        - reject
+                routeImport:
+                  type: object
+                  properties:
+                    prefixes:
+                      type: array
+                      items:
+                        type: string
+                    table:
+                      type: integer
+                      minimum: 0
+                    vpc:
+                      type: string
+                bfd:
+                  type: object
+                  properties:
+                    minTxInterval:
+                      type: string
+                    minRxInterval:
+                      type: string
+                    detectMultiplier:
+                      type: integer
+                      minimum: 0
+                      maximum: 255
+                    multihop:
+                      type: boolean
+              required:
+                - neighborAddress
+                - neighborAs
 ---
-apiVersion: rbac.authorization.k8s.io/v1
-kind: ClusterRoleBinding
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: fqdn-caches.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: fqdn-caches
+    singular: fqdn-cache
+    shortNames:
+      - fqdncache
+    kind: FqdnCache
+    listKind: FqdnCacheList
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      additionalPrinterColumns:
+      - name: Age
+        type: date
+        jsonPath: .metadata.creationTimestamp
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              properties:
+                entries:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      name:
+                        type: string
+                      ip:
+                        type: string
+                      expireTime:
+                        type: string
+                        format: date-time
+                    required:
+                      - name
+                      - ip
+                      - expireTime
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: address-groups.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: address-groups
+    singular: address-group
+    shortNames:
+      - ag
+    kind: AddressGroup
+    listKind: AddressGroupList
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      additionalPrinterColumns:
+      - name: Age
+        type: date
+        jsonPath: .metadata.creationTimestamp
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              properties:
+                addresses:
+                  type: array
+                  items:
+                    type: string
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: vpc-egress-gateways.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: vpc-egress-gateways
+    singular: vpc-egress-gateway
+    shortNames:
+      - vpc-egress-gw
+      - veg
+    kind: VpcEgressGateway
+    listKind: VpcEgressGatewayList
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      subresources:
+        status: {}
+      additionalPrinterColumns:
+      - name: Vpc
+        type: string
+        jsonPath: .spec.vpc
+      - name: Replicas
+        type: integer
+        jsonPath: .spec.replicas
+      - name: BFD
+        type: boolean
+        jsonPath: .spec.bfd.enabled
+      - name: Active
+        type: integer
+        jsonPath: .status.activeInstances
+      - name: Ready
+        type: boolean
+        jsonPath: .status.ready
+      - name: Age
+        type: date
+        jsonPath: .metadata.creationTimestamp
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            status:
+              type: object
+              properties:
+                ready:
+                  type: boolean
+                message:
+                  type: string
+                activeInstances:
+                  type: integer
+                instances:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      name:
+                        type: string
+                      node:
+                        type: string
+                      internalIP:
+                        type: string
+                      externalIP:
+                        type: string
+                      active:
+                        type: boolean
+            spec:
+              type: object
+              required:
+                - internalSubnet
+                - externalSubnet
+              properties:
+                vpc:
+                  type: string
+                replicas:
+                  type: integer
+                  format: int32
+                  minimum: 0
+                image:
+                  type: string
+                internalSubnet:
+                  type: string
+                externalSubnet:
+                  type: string
+                internalIPs:
+                  type: array
+                  items:
+                    type: string
+                externalIPs:
+                  type: array
+                  items:
+                    type: string
+                bfd:
+                  type: object
+                  properties:
+                    enabled:
+                      type: boolean
+                    minRX:
+                      type: integer
+                      format: int32
+                    minTX:
+                      type: integer
+                      format: int32
+                    multiplier:
+                      type: integer
+                      format: int32
+                selectors:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      namespaceSelector:
+                        type: object
+                        properties:
+                          matchLabels:
+                            type: object
+                            additionalProperties:
+                              type: string
+                          matchExpressions:
+                            type: array
+                            items:
+                              type: object
+                              required:
+                                - key
+                                - operator
+                              properties:
+                                key:
+                                  type: string
+                                operator:
+                                  type: string
+                                values:
+                                  type: array
+                                  items:
+                                    type: string
+                      podSelector:
+                        type: object
+                        properties:
+                          matchLabels:
+                            type: object
+                            additionalProperties:
+                              type: string
+                          matchExpressions:
+                            type: array
+                            items:
+                              type: object
+                              required:
+                                - key
+                                - operator
+                              properties:
+                                key:
+                                  type: string
+                                operator:
+                                  type: string
+                                values:
+                                  type: array
+                                  items:
+                                    type: string
+                nodeSelector:
+                  type: array
+                  items:
+                    type: string
+                tolerations:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      key:
+                        type: string
+                      operator:
+                        type: string
+                        enum:
+                          - Equal
+                          - Exists
+                      value:
+                        type: string
+                      effect:
+                        type: string
+                        enum:
+                          - NoExecute
+                          - NoSchedule
+                          - PreferNoSchedule
+                      tolerationSeconds:
+                        type: integer
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: vpc-eips.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: vpc-eips
+    singular: vpc-eip
+    shortNames:
+      - veip
+    kind: VpcEip
+    listKind: VpcEipList
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      subresources:
+        status: {}
+      additionalPrinterColumns:
+      - jsonPath: .spec.vpc
+        name: Vpc
+        type: string
+      - jsonPath: .status.v4Ip
+        name: V4IP
+        type: string
+      - jsonPath: .status.backend
+        name: Backend
+        type: string
+      - jsonPath: .status.natGateway
+        name: NatGateway
+        type: string
+      - jsonPath: .status.ready
+        name: Ready
+        type: boolean
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            status:
+              type: object
+              properties:
+                backend:
+                  type: string
+                natGateway:
+                  type: string
+                v4Ip:
+                  type: string
+                ready:
+                  type: boolean
+                message:
+                  type: string
+                targetBackend:
+                  type: string
+                targetNatGateway:
+                  type: string
+                targetReady:
+                  type: boolean
+            spec:
+              type: object
+              required:
+                - vpc
+              properties:
+                vpc:
+                  type: string
+                externalSubnet:
+                  type: string
+                v4Ip:
+                  type: string
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: vpc-fips.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: vpc-fips
+    singular: vpc-fip
+    shortNames:
+      - vfip
+    kind: VpcFip
+    listKind: VpcFipList
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      subresources:
+        status: {}
+      additionalPrinterColumns:
+      - jsonPath: .spec.eip
+        name: Eip
+        type: string
+      - jsonPath: .status.v4Ip
+        name: V4IP
+        type: string
+      - jsonPath: .spec.internalIp
+        name: InternalIP
+        type: string
+      - jsonPath: .status.backend
+        name: Backend
+        type: string
+      - jsonPath: .status.ready
+        name: Ready
+        type: boolean
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            status:
+              type: object
+              properties:
+                backend:
+                  type: string
+                v4Ip:
+                  type: string
+                ready:
+                  type: boolean
+                message:
+                  type: string
+                targetBackend:
+                  type: string
+                targetReady:
+                  type: boolean
+            spec:
+              type: object
+              required:
+                - eip
+                - internalIp
+              properties:
+                eip:
+                  type: string
+                internalIp:
+                  type: string
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: vpc-dnat-rules.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: vpc-dnat-rules
+    singular: vpc-dnat-rule
+    shortNames:
+      - vdnat
+    kind: VpcDnatRule
+    listKind: VpcDnatRuleList
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      subresources:
+        status: {}
+      additionalPrinterColumns:
+      - jsonPath: .spec.eip
+        name: Eip
+        type: string
+      - jsonPath: .status.v4Ip
+        name: V4IP
+        type: string
+      - jsonPath: .spec.protocol
+        name: Protocol
+        type: string
+      - jsonPath: .spec.externalPort
+        name: ExternalPort
+        type: string
+      - jsonPath: .spec.internalIp
+        name: InternalIP
+        type: string
+      - jsonPath: .spec.internalPort
+        name: InternalPort
+        type: string
+      - jsonPath: .status.backend
+        name: Backend
+        type: string
+      - jsonPath: .status.ready
+        name: Ready
+        type: boolean
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            status:
+              type: object
+              properties:
+                backend:
+                  type: string
+                v4Ip:
+                  type: string
+                ready:
+                  type: boolean
+                message:
+                  type: string
+                targetBackend:
+                  type: string
+                targetReady:
+                  type: boolean
+            spec:
+              type: object
+              required:
+                - eip
+                - externalPort
+                - internalIp
+                - internalPort
+              properties:
+                eip:
+                  type: string
+                externalPort:
+                  type: string
+                protocol:
+                  type: string
+                internalIp:
+                  type: string
+                internalPort:
+                  type: string
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: vpc-snat-rules.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: vpc-snat-rules
+    singular: vpc-snat-rule
+    shortNames:
+      - vsnat
+    kind: VpcSnatRule
+    listKind: VpcSnatRuleList
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      subresources:
+        status: {}
+      additionalPrinterColumns:
+      - jsonPath: .spec.eip
+        name: Eip
+        type: string
+      - jsonPath: .status.v4Ip
+        name: V4IP
+        type: string
+      - jsonPath: .spec.internalCIDR
+        name: InternalCIDR
+        type: string
+      - jsonPath: .status.backend
+        name: Backend
+        type: string
+      - jsonPath: .status.ready
+        name: Ready
+        type: boolean
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            status:
+              type: object
+              properties:
+                backend:
+                  type: string
+                v4Ip:
+                  type: string
+                ready:
+                  type: boolean
+                message:
+                  type: string
+                targetBackend:
+                  type: string
+                targetReady:
+                  type: boolean
+            spec:
+              type: object
+              required:
+                - eip
+                - internalCIDR
+              properties:
+                eip:
+                  type: string
+                internalCIDR:
+                  type: string
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: vpc-peering-connections.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: vpc-peering-connections
+    singular: vpc-peering-connection
+    shortNames:
+      - vpcpeer
+    kind: VpcPeeringConnection
+    listKind: VpcPeeringConnectionList
+  scope: Namespaced
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      subresources:
+        status: {}
+      additionalPrinterColumns:
+      - jsonPath: .spec.vpc
+        name: Vpc
+        type: string
+      - jsonPath: .status.remoteVpc
+        name: RemoteVpc
+        type: string
+      - jsonPath: .status.role
+        name: Role
+        type: string
+      - jsonPath: .status.localConnectIP
+        name: LocalConnectIP
+        type: string
+      - jsonPath: .status.phase
+        name: Phase
+        type: string
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            status:
+              type: object
+              properties:
+                role:
+                  type: string
+                phase:
+                  type: string
+                peer:
+                  type: object
+                  nullable: true
+                  properties:
+                    namespace:
+                      type: string
+                    name:
+                      type: string
+                remoteVpc:
+                  type: string
+                localConnectIP:
+                  type: string
+                remoteConnectIP:
+                  type: string
+                routes:
+                  type: array
+                  nullable: true
+                  items:
+                    type: object
+                    properties:
+                      policy:
+                        type: string
+                      cidr:
+                        type: string
+                      nextHopIP:
+                        type: string
+                      ecmpMode:
+                        type: string
+                      bfdId:
+                        type: string
+                      routeTable:
+                        type: string
+                conditions:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      type:
+                        type: string
+                      status:
+                        type: string
+                      reason:
+                        type: string
+                      message:
+                        type: string
+                      lastUpdateTime:
+                        type: string
+                      lastTransitionTime:
+                        type: string
+            spec:
+              type: object
+              required:
+                - vpc
+              properties:
+                vpc:
+                  type: string
+                subnets:
+                  type: array
+                  items:
+                    type: string
+                remoteVpc:
+                  type: string
+                requester:
+                  type: object
+                  required:
+                    - namespace
+                    - name
+                  properties:
+                    namespace:
+                      type: string
+                    name:
+                      type: string
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: vpc-transit-hubs.kubeovn.io
+spec:
+  group: kubeovn.io
+  names:
+    plural: vpc-transit-hubs
+    singular: vpc-transit-hub
+    shortNames:
+      - vpchub
+    kind: VpcTransitHub
+    listKind: VpcTransitHubList
+  scope: Cluster
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      subresources:
+        status: {}
+      additionalPrinterColumns:
+      - jsonPath: .spec.vpc
+        name: Vpc
+        type: string
+      - jsonPath: .status.conditions[?(@.type=="Ready")].status
+        name: Ready
+        type: string
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            status:
+              type: object
+              properties:
+                attachments:
+                  type: array
+                  nullable: true
+                  items:
+                    type: object
+                    properties:
+                      vpc:
+                        type: string
+                      phase:
+                        type: string
+                      message:
+                        type: string
+                      hubConnectIP:
+                        type: string
+                      spokeConnectIP:
+                        type: string
+                      routeTable:
+                        type: string
+                      routes:
+                        type: array
+                        items:
+                          type: object
+                          properties:
+                            policy:
+                              type: string
+                            cidr:
+                              type: string
+                            nextHopIP:
+                              type: string
+                            ecmpMode:
+                              type: string
+                            bfdId:
+                              type: string
+                            routeTable:
+                              type: string
+                routeTables:
+                  type: array
+                  nullable: true
+                  items:
+                    type: object
+                    properties:
+                      name:
+                        type: string
+                      associations:
+                        type: array
+                        items:
+                          type: string
+                      propagations:
+                        type: array
+                        items:
+                          type: string
+                      routes:
+                        type: array
+                        items:
+                          type: object
+                          properties:
+                            policy:
+                              type: string
+                            cidr:
+                              type: string
+                            nextHopIP:
+                              type: string
+                            ecmpMode:
+                              type: string
+                            bfdId:
+                              type: string
+                            routeTable:
+                              type: string
+                conditions:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      type:
+                        type: string
+                      status:
+                        type: string
+                      reason:
+                        type: string
+                      message:
+                        type: string
+                      lastUpdateTime:
+                        type: string
+                      lastTransitionTime:
+                        type: string
+            spec:
+              type: object
+              required:
+                - vpc
+              properties:
+                vpc:
+                  type: string
+                attachments:
+                  type: array
+                  items:
+                    type: object
+                    required:
+                      - vpc
+                    properties:
+                      vpc:
+                        type: string
+                      subnets:
+                        type: array
+                        items:
+                          type: string
+                      routeTable:
+                        type: string
+                      propagations:
+                        type: array
+                        items:
+                          type: string
+EOF
+
+cat <<EOF > ovn-ovs-sa.yaml
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: ovn-ovs
+  namespace: kube-system
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  annotations:
+    rbac.authorization.k8s.io/system-only: "true"
+  name: system:ovn-ovs
+rules:
+  - apiGroups:
+      - ""
+    resources:
+      - pods
+    verbs:
+      - get
+      - patch
+  - apiGroups:
+      - ""
+      - networking.k8s.io
+      - apps
+    resources:
+      - services
+      - endpoints
+    verbs:
+      - get
+  - apiGroups:
+      - apps
+    resources:
+      - controllerrevisions
+    verbs:
+      - get
+      - list
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
 metadata:
   name: ovn-ovs
 roleRef:
@@ -2928,6 +4210,25 @@ rules:
       - vpc-dnses/status
       - qos-policies
       - qos-policies/status
+      - ip-quotas
+      - ip-quotas/status
+      - bgp-peers
+      - fqdn-caches
+      - address-groups
+      - vpc-egress-gateways
+      - vpc-egress-gateways/status
+      - vpc-eips
+      - vpc-eips/status
+      - vpc-fips
+      - vpc-fips/status
+      - vpc-dnat-rules
+      - vpc-dnat-rules/status
+      - vpc-snat-rules
+      - vpc-snat-rules/status
+      - vpc-peering-connections
+      - vpc-peering-connections/status
+      - vpc-transit-hubs
+      - vpc-transit-hubs/status
     verbs:
       - "*"
   - apiGroups:
@@ -2935,6 +4236,7 @@ rules:
     resources:
       - pods
       - pods/exec
+      - pods/portforward
       - namespaces
       - nodes
       - configmaps
@@ -2945,12 +4247,30 @@ rules:
       - watch
       - patch
       - update
+  - apiGroups:
+      - ""
+    resources:
+      - secrets
+    verbs:
+      - create
+      - get
+      - list
+      - watch
   - apiGroups:
       - "k8s.cni.cncf.io"
     resources:
       - network-attachment-definitions
     verbs:
       - get
+  - apiGroups:
+      - policy.networking.k8s.io
+    resources:
+      - adminnetworkpolicies
+      - baselineadminnetworkpolicies
+    verbs:
+      - get
+      - list
+      - watch
   - apiGroups:
       - ""
       - networking.k8s.io
@@ -3010,6 +4330,22 @@ rules:
     verbs:
       - get
       - list
+  - apiGroups:
+      - authentication.k8s.io
+    resources:
+      - tokenreviews
+    verbs:
+      - create
+  - apiGroups:
+      - authorization.k8s.io
+    resources:
+      - subjectaccessreviews
+    verbs:
+      - create
+  - nonResourceURLs:
+      - /api/v1/policy-verdict
+    verbs:
+      - get
 ---
 apiVersion: rbac.authorization.k8s.io/v1
 kind: ClusterRoleBinding
@@ -3054,6 +4390,43 @@ rules:
       - patch
       - update
       - watch
+  - apiGroups:
+      - "kubeovn.io"
+    resources:
+      - fqdn-caches
+    verbs:
+      - get
+      - list
+      - watch
+      - create
+      - update
+      - patch
+      - delete
+  - apiGroups:
+      - "kubeovn.io"
+    resources:
+      - security-groups
+    verbs:
+      - get
+      - list
+      - watch
+  - apiGroups:
+      - "networking.k8s.io"
+    resources:
+      - networkpolicies
+    verbs:
+      - get
+      - list
+      - watch
+  - apiGroups:
+      - ""
+    resources:
+      - services
+      - endpoints
+    verbs:
+      - get
+      - list
+      - watch
   - apiGroups:
       - ""
     resources:
@@ -3973,6 +5346,7 @@ spec:
           - --pod-nic-type=$POD_NIC_TYPE
           - --enable-lb=$ENABLE_LB
           - --enable-np=$ENABLE_NP
+          - --enable-anp=$ENABLE_ANP
           - --enable-eip-snat=$ENABLE_EIP_SNAT
           - --enable-external-vpc=$ENABLE_EXTERNAL_VPC
           - --logtostderr=false
@@ -4124,6 +5498,8 @@ spec:
           - --log_file_max_size=0
           - --kubelet-dir=$KUBELET_DIR
           - --enable-tproxy=$ENABLE_TPROXY
+          - --enable-fqdn-snooping=$ENABLE_FQDN_SNOOPING
+          - --enable-acl-audit-log=$ENABLE_ACL_AUDIT_LOG
           - --ovs-vsctl-concurrency=$OVS_VSCTL_CONCURRENCY
         securityContext:
           runAsUser: 0
@@ -0,0 +1,87 @@
+#!/usr/bin/env bash
+
+function exec_cmd() {
+    cmd=${@:1:${#}}
+    $cmd
+    ret=$?
+    if [ $ret -ne 0 ]; then
+        echo "failed to exec \"$cmd\""
+        exit $ret
+    fi
+}
+
+function init() {
+    # the first argument is the comma separated gateways of the external subnet
+    # and the others are the routes to the vpc in the format of cidr,nexthop
+    exec_cmd "sysctl -w net.ipv4.ip_forward=1"
+    exec_cmd "sysctl -w net.ipv6.conf.all.forwarding=1"
+
+    ip link set net1 up
+    for gw in ${1//,/ }
+    do
+        if [[ $gw =~ : ]]; then
+            exec_cmd "ip -6 route replace default via $gw dev net1"
+        else
+            exec_cmd "ip route replace default via $gw dev net1"
+        fi
+    done
+    shift
+
+    for rule in $@
+    do
+        arr=(${rule//,/ })
+        cidr=${arr[0]}
+        nextHop=${arr[1]}
+
+        if [[ $cidr =~ : ]]; then
+            exec_cmd "ip -6 route replace $cidr via $nextHop dev eth0"
+        else
+            exec_cmd "ip route replace $cidr via $nextHop dev eth0"
+        fi
+    done
+
+    # forwarded traffic leaves with the egress ip of the instance
+    iptables -t nat -C POSTROUTING -o net1 -j MASQUERADE 2>/dev/null || exec_cmd "iptables -t nat -A POSTROUTING -o net1 -j MASQUERADE"
+    ip6tables -t nat -C POSTROUTING -o net1 -j MASQUERADE 2>/dev/null || exec_cmd "ip6tables -t nat -A POSTROUTING -o net1 -j MASQUERADE"
+}
+
+function bfd() {
+    # the arguments are the ip addresses of the vpc router port in the internal subnet
+    exec_cmd "/usr/local/bin/bfdd-beacon --listen=0.0.0.0"
+    for peer in $@
+    do
+        exec_cmd "bfdd-control allow $peer"
+    done
+
+    # exit to restart the container once the bfd daemon is gone
+    while pgrep -x bfdd-beacon > /dev/null
+    do
+        sleep 5
+    done
+    echo "bfdd-beacon exited"
+    exit 1
+}
+
+function serve() {
+    while true; do sleep 10000; done
+}
+
+rules=${@:2:${#}}
+opt=$1
+case $opt in
+ init)
+        echo "init $rules"
+        init $rules
+        ;;
+ bfd)
+        echo "bfd $rules"
+        bfd $rules
+        ;;
+ serve)
+        serve
+        ;;
+ *)
+        echo "Usage: $0 [init|bfd|serve] ..."
+        exit 1
+        ;;
+esac
@@ -1,5 +1,13 @@
 #!/usr/bin/env bash
 
+# active/standby mode is enabled by the controller with NAT_GW_HA=true,
+# in which the eips are only configured on the instance elected as master by keepalived
+HA_DIR=/var/run/kube-ovn
+HA_ROLE_FILE=$HA_DIR/ha-role
+HA_EIPS_FILE=$HA_DIR/ha-eips
+KEEPALIVED_CONF=$HA_DIR/keepalived.conf
+CONNTRACKD_CONF=$HA_DIR/conntrackd.conf
+
 function exec_cmd() {
     cmd=${@:1:${#}}
     $cmd
@@ -53,6 +61,122 @@ function init() {
 }
 
 
+function ha_enabled() {
+    [ "$NAT_GW_HA" == "true" ]
+}
+
+function ha_is_master() {
+    [ "$(cat $HA_ROLE_FILE 2>/dev/null)" == "master" ]
+}
+
+function ha_run() {
+    # vip is the lan ip in cidr format, vrid is the vrrp virtual router id
+    vip=$1
+    vrid=$2
+    mkdir -p $HA_DIR
+    lanIp=$(ip -4 -o addr show dev eth0 | awk '{print $4}' | head -n 1 | cut -d '/' -f 1)
+
+    cat > $CONNTRACKD_CONF <<EOF
+Sync {
+    Mode FTFW {
+        DisableExternalCache Off
+    }
+    Multicast {
+        IPv4_address 225.0.0.$vrid
+        Group 3780
+        IPv4_interface $lanIp
+        Interface eth0
+        SndSocketBuffer 1249280
+        RcvSocketBuffer 1249280
+        Checksum on
+    }
+}
+General {
+    HashSize 32768
+    HashLimit 131072
+    LogFile off
+    Syslog off
+    LockFile $HA_DIR/conntrackd.lock
+    UNIX {
+        Path $HA_DIR/conntrackd.ctl
+    }
+    NetlinkBufferSize 2097152
+    NetlinkBufferSizeMaxGrowth 8388608
+    Filter From Userspace {
+        Address Ignore {
+            IPv4_address 127.0.0.1
+            IPv4_address $lanIp
+        }
+    }
+}
+EOF
+
+    # all instances start as backup without preemption, so a recovered instance never takes over the active one
+    cat > $KEEPALIVED_CONF <<EOF
+global_defs {
+    script_user root
+    enable_script_security
+}
+vrrp_instance nat_gw {
+    state BACKUP
+    nopreempt
+    interface eth0
+    virtual_router_id $vrid
+    priority 100
+    advert_int 1
+    track_interface {
+        net1
+    }
+    virtual_ipaddress {
+        $vip dev eth0
+    }
+    notify_master "/bin/bash /kube-ovn/nat-gateway.sh ha-notify master"
+    notify_backup "/bin/bash /kube-ovn/nat-gateway.sh ha-notify backup"
+    notify_fault "/bin/bash /kube-ovn/nat-gateway.sh ha-notify fault"
+}
+EOF
+
+    rm -f $HA_DIR/conntrackd.lock
+    exec_cmd "conntrackd -C $CONNTRACKD_CONF -d"
+    exec keepalived -n -l -D -f $KEEPALIVED_CONF -p $HA_DIR/keepalived.pid -r $HA_DIR/vrrp.pid
+}
+
+function ha_notify() {
+    role=$1
+    echo $role > $HA_ROLE_FILE
+    case $role in
+    master)
+        # commit the connections synced from the previous master and take over the eips
+        conntrackd -C $CONNTRACKD_CONF -c
+        conntrackd -C $CONNTRACKD_CONF -f
+        conntrackd -C $CONNTRACKD_CONF -R
+        conntrackd -C $CONNTRACKD_CONF -B
+        for rule in $(cat $HA_EIPS_FILE 2>/dev/null)
+        do
+            arr=(${rule//,/ })
+            eip=${arr[0]}
+            eip_without_prefix=(${eip//\// })
+            gateway=${arr[1]}
+            ip addr replace $eip dev net1
+            ip link set dev net1 arp on
+            ip route replace default via $gateway dev net1
+            # refresh the arp cache of the external network
+            arping -I net1 -c 3 -U $eip_without_prefix
+        done
+        ;;
+    backup|fault)
+        for rule in $(cat $HA_EIPS_FILE 2>/dev/null)
+        do
+            arr=(${rule//,/ })
+            eip=${arr[0]}
+            ip addr del $eip dev net1 2>/dev/null || true
+        done
+        conntrackd -C $CONNTRACKD_CONF -n
+        conntrackd -C $CONNTRACKD_CONF -t
+        ;;
+    esac
+}
+
 function get_iptables_version() {
   exec_cmd "iptables --version"
 }
@@ -130,6 +254,15 @@ function add_eip() {
         eip_prefix=$(ipcalc -p $eip | awk -F '=' '{print $2}')
         gateway=${arr[1]}
 
+        if ha_enabled; then
+            # record the eip so that it is taken over on failover
+            grep -qxF "$rule" $HA_EIPS_FILE 2>/dev/null || echo "$rule" >> $HA_EIPS_FILE
+            if ! ha_is_master; then
+                exec_cmd "ip route replace default via $gateway dev net1"
+                continue
+            fi
+        fi
+
         exec_cmd "ip addr replace $eip dev net1"
         ip link set dev net1 arp on
         # gw may lost, even if add_vpc_external_route add route successfully
@@ -146,6 +279,10 @@ function del_eip() {
     do
         arr=(${rule//,/ })
         eip=${arr[0]}
+        if ha_enabled && [ -f $HA_EIPS_FILE ]; then
+            grep -v "^$eip," $HA_EIPS_FILE > $HA_EIPS_FILE.tmp
+            mv $HA_EIPS_FILE.tmp $HA_EIPS_FILE
+        fi
         ipCidr=`ip addr show net1 | grep $eip | awk '{print $2 }'`
         if [ -n "$ipCidr" ]; then
             exec_cmd "ip addr del $ipCidr dev net1"
@@ -162,9 +299,11 @@ function add_floating_ip() {
         eip=(${arr[0]//\// })
         internalIp=${arr[1]}
         # check if already exist
-        iptables-save  | grep "EXCLUSIVE_DNAT" | grep -w "\-d $eip/32" | grep  "destination" && exit 0
+        iptables-save  | grep "EXCLUSIVE_DNAT" | grep -w "\-d $eip/32" | grep  "destination" && continue
         exec_cmd "iptables -t nat -A EXCLUSIVE_DNAT -d $eip -j DNAT --to-destination $internalIp"
         exec_cmd "iptables -t nat -A EXCLUSIVE_SNAT -s $internalIp -j SNAT --to-source $eip"
+        # sessions to the eip created before the rule are not translated
+        conntrack -D -d $eip -r $eip >/dev/null 2>&1 || true
     done
 }
 
@@ -181,11 +320,43 @@ function del_floating_ip() {
         if [ "$?" -eq 0 ];then
             exec_cmd "iptables -t nat -D EXCLUSIVE_DNAT -d $eip -j DNAT --to-destination $internalIp"
             exec_cmd "iptables -t nat -D EXCLUSIVE_SNAT -s $internalIp -j SNAT --to-source $eip"
-            conntrack -D -d $eip 2>/dev/nul || true
         fi
     done
 }
 
+# flush the conntrack entries translated by the rule only, which is done by the agent
+# after the rule is deleted or the drain period ends
+function flush_floating_ip() {
+    for rule in $@
+    do
+        arr=(${rule//,/ })
+        eip=(${arr[0]//\// })
+        internalIp=${arr[1]}
+        conntrack -D -d $eip -r $internalIp >/dev/null 2>&1 || true
+        conntrack -D -s $internalIp -q $eip >/dev/null 2>&1 || true
+    done
+}
+
+# a snat rule is eip,internalCIDR or eip:portRange,internalCIDR,protocol in port block mode,
+# optionally followed by --random-fully
+function snat_protocol() {
+    arr=(${1//,/ })
+    [ "${arr[2]}" != "--random-fully" ] && echo ${arr[2]}
+}
+
+# snat_rule prints the snat rule in SHARED_SNAT, iptables only translates the ports of tcp and udp
+# so the port block rules match the protocol and the rules without protocol match the others
+function snat_rule() {
+    eip=$1
+    internalCIDR=$2
+    protocol=$3
+    if [ -n "$protocol" ]; then
+        iptables-save -t nat | grep "^-A SHARED_SNAT " | grep -- "-s $internalCIDR " | grep -- "-p $protocol " | grep -E -- "--to-source $eip( |$)"
+    else
+        iptables-save -t nat | grep "^-A SHARED_SNAT " | grep -- "-s $internalCIDR " | grep -v -- "-p " | grep -E -- "--to-source $eip( |$)"
+    fi
+}
+
 function add_snat() {
     # make sure inited
     check_inited
@@ -193,12 +364,19 @@ function add_snat() {
     for rule in $@
     do
         arr=(${rule//,/ })
-        eip=(${arr[0]//\// })
+        eip=${arr[0]}
         internalCIDR=${arr[1]}
-        randomFullyOption=${arr[2]}
+        protocol=$(snat_protocol $rule)
+        randomFullyOption=${arr[-1]}
+        [ "$randomFullyOption" != "--random-fully" ] && randomFullyOption=""
         # check if already exist
-        iptables-save  | grep "SHARED_SNAT" | grep "\-s $internalCIDR" | grep "source $eip" && exit 0
-        exec_cmd "iptables -t nat -A SHARED_SNAT -o net1 -s $internalCIDR -j SNAT --to-source $eip $randomFullyOption"
+        snat_rule $eip $internalCIDR $protocol && continue
+        if [ -n "$protocol" ]; then
+            # the port block rules are inserted before the rules without protocol of the same internal ip
+            exec_cmd "iptables -t nat -I SHARED_SNAT -o net1 -s $internalCIDR -p $protocol -j SNAT --to-source $eip $randomFullyOption"
+        else
+            exec_cmd "iptables -t nat -A SHARED_SNAT -o net1 -s $internalCIDR -j SNAT --to-source $eip $randomFullyOption"
+        fi
     done
 }
 function del_snat() {
@@ -208,10 +386,11 @@ function del_snat() {
     for rule in $@
     do
         arr=(${rule//,/ })
-        eip=(${arr[0]//\// })
+        eip=${arr[0]}
         internalCIDR=${arr[1]}
+        protocol=$(snat_protocol $rule)
         # check if already exist
-        ruleMatch=$(iptables-save  | grep "SHARED_SNAT" | grep "\-s $internalCIDR" | grep "source $eip")
+        ruleMatch=$(snat_rule $eip $internalCIDR $protocol)
         if [ "$?" -eq 0 ];then
           ruleMatch=$(echo $ruleMatch | sed 's/-A //')
           exec_cmd "iptables -t nat -D $ruleMatch"
@@ -220,6 +399,25 @@ function del_snat() {
 }
 
 
+# the ports of a dnat rule are a port or a port range like 8000-8100 mapped one to one,
+# e.g. 8000-8100 to 9000-9100 is --dport 8000:8100 --to-destination ip:9000-9100/8000
+function dnat_dport() {
+    echo ${1/-/:}
+}
+
+function dnat_destination() {
+    internalIp=$1
+    dport=$2
+    internalPort=$3
+    if [[ "$dport" != *-* ]]; then
+        echo "$internalIp:$internalPort"
+    elif [ "$dport" = "$internalPort" ]; then
+        echo "$internalIp"
+    else
+        echo "$internalIp:$internalPort/${dport%-*}"
+    fi
+}
+
 function add_dnat() {
     # make sure inited
     check_inited
@@ -231,9 +429,16 @@ function add_dnat() {
         protocol=${arr[2]}
         internalIp=${arr[3]}
         internalPort=${arr[4]}
+        destination=$(dnat_destination $internalIp $dport $internalPort)
+        dportMatch=$(dnat_dport $dport)
         # check if already exist
-        iptables-save  | grep "SHARED_DNAT" | grep -w "\-d $eip/32" | grep "p $protocol" | grep -w "dport $dport"| grep  -w "destination $internalIp:$internalPort"  && exit 0
-        exec_cmd "iptables -t nat -A SHARED_DNAT -p $protocol -d $eip --dport $dport -j DNAT --to-destination $internalIp:$internalPort"
+        iptables-save  | grep "SHARED_DNAT" | grep -w "\-d $eip/32" | grep "p $protocol" | grep -w "dport $dportMatch"| grep  -w "destination $destination"  && continue
+        exec_cmd "iptables -t nat -A SHARED_DNAT -p $protocol -d $eip --dport $dportMatch -j DNAT --to-destination $destination"
+        # sessions to the ports created before the rule are not translated
+        for ((port=${dport%-*}; port<=${dport#*-}; port++))
+        do
+            conntrack -D -p $protocol -d $eip --dport $port -r $eip >/dev/null 2>&1 || true
+        done
     done
 }
 
@@ -249,14 +454,38 @@ function del_dnat() {
         protocol=${arr[2]}
         internalIp=${arr[3]}
         internalPort=${arr[4]}
+        destination=$(dnat_destination $internalIp $dport $internalPort)
+        dportMatch=$(dnat_dport $dport)
         # check if already exist
-        iptables-save  | grep "SHARED_DNAT" | grep -w "\-d $eip/32" | grep "p $protocol" | grep -w "dport $dport"| grep  -w "destination $internalIp:$internalPort"
+        iptables-save  | grep "SHARED_DNAT" | grep -w "\-d $eip/32" | grep "p $protocol" | grep -w "dport $dportMatch"| grep  -w "destination $destination"
         if [ "$?" -eq 0 ];then
-          exec_cmd "iptables -t nat -D SHARED_DNAT -p $protocol -d $eip --dport $dport -j DNAT --to-destination $internalIp:$internalPort"
+          exec_cmd "iptables -t nat -D SHARED_DNAT -p $protocol -d $eip --dport $dportMatch -j DNAT --to-destination $destination"
         fi
     done
 }
 
+function flush_dnat() {
+    for rule in $@
+    do
+        arr=(${rule//,/ })
+        eip=(${arr[0]//\// })
+        dport=${arr[1]}
+        protocol=${arr[2]}
+        internalIp=${arr[3]}
+        internalPort=${arr[4]}
+        start=${dport%-*}
+        for ((port=start; port<=${dport#*-}; port++))
+        do
+            conntrack -D -p $protocol -d $eip --dport $port -r $internalIp --reply-port-src $((${internalPort%-*}+port-start)) >/dev/null 2>&1 || true
+        done
+    done
+}
+
+function list_conntrack() {
+    conntrack -L -f ipv4 2>/dev/null
+    return 0
+}
+
 
 # example usage:
 # delete_tc_u32_filter "net1" "1:0" "192.168.1.1" "src"
@@ -496,6 +725,25 @@ case $opt in
         echo "floating-ip-del $rules"
         del_floating_ip $rules
         ;;
+ floating-ip-flush)
+        echo "floating-ip-flush $rules"
+        flush_floating_ip $rules
+        ;;
+ dnat-flush)
+        echo "dnat-flush $rules"
+        flush_dnat $rules
+        ;;
+ conntrack-list)
+        list_conntrack
+        ;;
+ ha-run)
+        echo "ha-run $rules"
+        ha_run $rules
+        ;;
+ ha-notify)
+        echo "ha-notify $rules"
+        ha_notify $rules
+        ;;
  get-iptables-version)
         echo "get-iptables-version $rules"
         get_iptables_version $rules
@@ -37,6 +37,7 @@ require (
 	github.com/vishvananda/netlink v1.2.1-beta.2
 	golang.org/x/exp v0.0.0-20231110203233-9a3e6036ecaa
 	golang.org/x/mod v0.14.0
+	golang.org/x/net v0.18.0
 	golang.org/x/sys v0.15.0
 	golang.org/x/time v0.5.0
 	google.golang.org/grpc v1.59.0
@@ -53,6 +54,7 @@ require (
 	k8s.io/utils v0.0.0-20230726121419-3b25d923346b
 	kubevirt.io/client-go v1.0.0
 	sigs.k8s.io/controller-runtime v0.16.3
+	sigs.k8s.io/network-policy-api v0.1.1
 )
 
 require (
@@ -230,7 +232,6 @@ require (
 	go.uber.org/multierr v1.11.0 // indirect
 	go.uber.org/zap v1.25.0 // indirect
 	golang.org/x/crypto v0.15.0 // indirect
-	golang.org/x/net v0.18.0 // indirect
 	golang.org/x/oauth2 v0.14.0 // indirect
 	golang.org/x/sync v0.5.0 // indirect
 	golang.org/x/term v0.14.0 // indirect
@@ -15,6 +15,7 @@ import (
 	util "github.com/kubeovn/kube-ovn/pkg/util"
 	ovsdb "github.com/ovn-org/libovsdb/ovsdb"
 	v10 "k8s.io/api/networking/v1"
+	v1alpha1 "sigs.k8s.io/network-policy-api/apis/v1alpha1"
 )
 
 // MockNBGlobal is a mock of NBGlobal interface.
@@ -412,6 +413,72 @@ func (mr *MockLogicalRouterPortMockRecorder) UpdateLogicalRouterPortRA(lrpName,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLogicalRouterPortRA", reflect.TypeOf((*MockLogicalRouterPort)(nil).UpdateLogicalRouterPortRA), lrpName, ipv6RAConfigsStr, enableIPv6RA)
 }
 
+// MockMeter is a mock of Meter interface.
+type MockMeter struct {
+	ctrl     *gomock.Controller
+	recorder *MockMeterMockRecorder
+}
+
+// MockMeterMockRecorder is the mock recorder for MockMeter.
+type MockMeterMockRecorder struct {
+	mock *MockMeter
+}
+
+// NewMockMeter creates a new mock instance.
+func NewMockMeter(ctrl *gomock.Controller) *MockMeter {
+	mock := &MockMeter{ctrl: ctrl}
+	mock.recorder = &MockMeterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMeter) EXPECT() *MockMeterMockRecorder {
+	return m.recorder
+}
+
+// CreateOrUpdateMeter mocks base method.
+func (m *MockMeter) CreateOrUpdateMeter(name string, unit ovnnb.MeterUnit, rate int, externalIDs map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdateMeter", name, unit, rate, externalIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrUpdateMeter indicates an expected call of CreateOrUpdateMeter.
+func (mr *MockMeterMockRecorder) CreateOrUpdateMeter(name, unit, rate, externalIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateMeter", reflect.TypeOf((*MockMeter)(nil).CreateOrUpdateMeter), name, unit, rate, externalIDs)
+}
+
+// DeleteMeter mocks base method.
+func (m *MockMeter) DeleteMeter(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMeter", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMeter indicates an expected call of DeleteMeter.
+func (mr *MockMeterMockRecorder) DeleteMeter(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMeter", reflect.TypeOf((*MockMeter)(nil).DeleteMeter), name)
+}
+
+// GetMeter mocks base method.
+func (m *MockMeter) GetMeter(name string, ignoreNotFound bool) (*ovnnb.Meter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMeter", name, ignoreNotFound)
+	ret0, _ := ret[0].(*ovnnb.Meter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMeter indicates an expected call of GetMeter.
+func (mr *MockMeterMockRecorder) GetMeter(name, ignoreNotFound interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeter", reflect.TypeOf((*MockMeter)(nil).GetMeter), name, ignoreNotFound)
+}
+
 // MockBFD is a mock of BFD interface.
 type MockBFD struct {
 	ctrl     *gomock.Controller
@@ -464,6 +531,18 @@ func (mr *MockBFDMockRecorder) DeleteBFD(lrpName, dstIP interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBFD", reflect.TypeOf((*MockBFD)(nil).DeleteBFD), lrpName, dstIP)
 }
 
+// MonitorBFD mocks base method.
+func (m *MockBFD) MonitorBFD(onStatusChange func(*ovnnb.BFD)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MonitorBFD", onStatusChange)
+}
+
+// MonitorBFD indicates an expected call of MonitorBFD.
+func (mr *MockBFDMockRecorder) MonitorBFD(onStatusChange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonitorBFD", reflect.TypeOf((*MockBFD)(nil).MonitorBFD), onStatusChange)
+}
+
 // MockLogicalSwitch is a mock of LogicalSwitch interface.
 type MockLogicalSwitch struct {
 	ctrl     *gomock.Controller
@@ -1038,6 +1117,20 @@ func (mr *MockLoadBalancerMockRecorder) LoadBalancerAddVip(lbName, vip interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBalancerAddVip", reflect.TypeOf((*MockLoadBalancer)(nil).LoadBalancerAddVip), varargs...)
 }
 
+// LoadBalancerAddVips mocks base method.
+func (m *MockLoadBalancer) LoadBalancerAddVips(lbName string, vips map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadBalancerAddVips", lbName, vips)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadBalancerAddVips indicates an expected call of LoadBalancerAddVips.
+func (mr *MockLoadBalancerMockRecorder) LoadBalancerAddVips(lbName, vips interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBalancerAddVips", reflect.TypeOf((*MockLoadBalancer)(nil).LoadBalancerAddVips), lbName, vips)
+}
+
 // LoadBalancerDeleteHealthCheck mocks base method.
 func (m *MockLoadBalancer) LoadBalancerDeleteHealthCheck(lbName, uuid string) error {
 	m.ctrl.T.Helper()
@@ -1080,6 +1173,20 @@ func (mr *MockLoadBalancerMockRecorder) LoadBalancerDeleteVip(lbName, vip, ignor
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBalancerDeleteVip", reflect.TypeOf((*MockLoadBalancer)(nil).LoadBalancerDeleteVip), lbName, vip, ignoreHealthCheck)
 }
 
+// LoadBalancerDeleteVips mocks base method.
+func (m *MockLoadBalancer) LoadBalancerDeleteVips(lbName string, vips []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadBalancerDeleteVips", lbName, vips)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadBalancerDeleteVips indicates an expected call of LoadBalancerDeleteVips.
+func (mr *MockLoadBalancerMockRecorder) LoadBalancerDeleteVips(lbName, vips interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBalancerDeleteVips", reflect.TypeOf((*MockLoadBalancer)(nil).LoadBalancerDeleteVips), lbName, vips)
+}
+
 // LoadBalancerExists mocks base method.
 func (m *MockLoadBalancer) LoadBalancerExists(lbName string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -1532,34 +1639,79 @@ func (mr *MockACLMockRecorder) SetLogicalSwitchPrivate(lsName, cidrBlock, nodeSw
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLogicalSwitchPrivate", reflect.TypeOf((*MockACL)(nil).SetLogicalSwitchPrivate), lsName, cidrBlock, nodeSwitchCIDR, allowSubnets)
 }
 
+// UpdateAnpRuleACLOps mocks base method.
+func (m *MockACL) UpdateAnpRuleACLOps(pgName, asName, protocol string, priority int, aclAction ovnnb.ACLAction, logEnable, isIngress bool, rulePorts []v1alpha1.AdminNetworkPolicyPort, namedPortMap map[string]*util.NamedPortInfo, excludes []string) ([]ovsdb.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnpRuleACLOps", pgName, asName, protocol, priority, aclAction, logEnable, isIngress, rulePorts, namedPortMap, excludes)
+	ret0, _ := ret[0].([]ovsdb.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAnpRuleACLOps indicates an expected call of UpdateAnpRuleACLOps.
+func (mr *MockACLMockRecorder) UpdateAnpRuleACLOps(pgName, asName, protocol, priority, aclAction, logEnable, isIngress, rulePorts, namedPortMap, excludes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnpRuleACLOps", reflect.TypeOf((*MockACL)(nil).UpdateAnpRuleACLOps), pgName, asName, protocol, priority, aclAction, logEnable, isIngress, rulePorts, namedPortMap, excludes)
+}
+
 // UpdateEgressACLOps mocks base method.
-func (m *MockACL) UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol string, npp []v10.NetworkPolicyPort, logEnable bool, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
+func (m *MockACL) UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol string, npp []v10.NetworkPolicyPort, logConfig *ovs.ACLLogConfig, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateEgressACLOps", pgName, asEgressName, asExceptName, protocol, npp, logEnable, namedPortMap)
+	ret := m.ctrl.Call(m, "UpdateEgressACLOps", pgName, asEgressName, asExceptName, protocol, npp, logConfig, namedPortMap)
 	ret0, _ := ret[0].([]ovsdb.Operation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateEgressACLOps indicates an expected call of UpdateEgressACLOps.
-func (mr *MockACLMockRecorder) UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol, npp, logEnable, namedPortMap interface{}) *gomock.Call {
+func (mr *MockACLMockRecorder) UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol, npp, logConfig, namedPortMap interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEgressACLOps", reflect.TypeOf((*MockACL)(nil).UpdateEgressACLOps), pgName, asEgressName, asExceptName, protocol, npp, logEnable, namedPortMap)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEgressACLOps", reflect.TypeOf((*MockACL)(nil).UpdateEgressACLOps), pgName, asEgressName, asExceptName, protocol, npp, logConfig, namedPortMap)
+}
+
+// UpdateEgressFqdnACLOps mocks base method.
+func (m *MockACL) UpdateEgressFqdnACLOps(pgName, protocol string, fqdns []string) ([]ovsdb.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEgressFqdnACLOps", pgName, protocol, fqdns)
+	ret0, _ := ret[0].([]ovsdb.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEgressFqdnACLOps indicates an expected call of UpdateEgressFqdnACLOps.
+func (mr *MockACLMockRecorder) UpdateEgressFqdnACLOps(pgName, protocol, fqdns interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEgressFqdnACLOps", reflect.TypeOf((*MockACL)(nil).UpdateEgressFqdnACLOps), pgName, protocol, fqdns)
+}
+
+// UpdateGatewayACLOps mocks base method.
+func (m *MockACL) UpdateGatewayACLOps(pgName, gateway string) ([]ovsdb.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGatewayACLOps", pgName, gateway)
+	ret0, _ := ret[0].([]ovsdb.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGatewayACLOps indicates an expected call of UpdateGatewayACLOps.
+func (mr *MockACLMockRecorder) UpdateGatewayACLOps(pgName, gateway interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGatewayACLOps", reflect.TypeOf((*MockACL)(nil).UpdateGatewayACLOps), pgName, gateway)
 }
 
 // UpdateIngressACLOps mocks base method.
-func (m *MockACL) UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol string, npp []v10.NetworkPolicyPort, logEnable bool, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
+func (m *MockACL) UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol string, npp []v10.NetworkPolicyPort, logConfig *ovs.ACLLogConfig, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateIngressACLOps", pgName, asIngressName, asExceptName, protocol, npp, logEnable, namedPortMap)
+	ret := m.ctrl.Call(m, "UpdateIngressACLOps", pgName, asIngressName, asExceptName, protocol, npp, logConfig, namedPortMap)
 	ret0, _ := ret[0].([]ovsdb.Operation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateIngressACLOps indicates an expected call of UpdateIngressACLOps.
-func (mr *MockACLMockRecorder) UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol, npp, logEnable, namedPortMap interface{}) *gomock.Call {
+func (mr *MockACLMockRecorder) UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol, npp, logConfig, namedPortMap interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIngressACLOps", reflect.TypeOf((*MockACL)(nil).UpdateIngressACLOps), pgName, asIngressName, asExceptName, protocol, npp, logEnable, namedPortMap)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIngressACLOps", reflect.TypeOf((*MockACL)(nil).UpdateIngressACLOps), pgName, asIngressName, asExceptName, protocol, npp, logConfig, namedPortMap)
 }
 
 // UpdateLogicalSwitchACL mocks base method.
@@ -2021,6 +2173,20 @@ func (mr *MockNATMockRecorder) DeleteNats(lrName, natType, logicalIP interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNats", reflect.TypeOf((*MockNAT)(nil).DeleteNats), lrName, natType, logicalIP)
 }
 
+// DeleteSnats mocks base method.
+func (m *MockNAT) DeleteSnats(lrName, externalIP string, logicalIPs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSnats", lrName, externalIP, logicalIPs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSnats indicates an expected call of DeleteSnats.
+func (mr *MockNATMockRecorder) DeleteSnats(lrName, externalIP, logicalIPs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSnats", reflect.TypeOf((*MockNAT)(nil).DeleteSnats), lrName, externalIP, logicalIPs)
+}
+
 // GetNATByUUID mocks base method.
 func (m *MockNAT) GetNATByUUID(uuid string) (*ovnnb.NAT, error) {
 	m.ctrl.T.Helper()
@@ -2094,6 +2260,20 @@ func (mr *MockNATMockRecorder) UpdateSnat(lrName, externalIP, logicalIP interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSnat", reflect.TypeOf((*MockNAT)(nil).UpdateSnat), lrName, externalIP, logicalIP)
 }
 
+// UpdateSnatPortRanges mocks base method.
+func (m *MockNAT) UpdateSnatPortRanges(lrName, externalIP string, portRanges map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSnatPortRanges", lrName, externalIP, portRanges)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSnatPortRanges indicates an expected call of UpdateSnatPortRanges.
+func (mr *MockNATMockRecorder) UpdateSnatPortRanges(lrName, externalIP, portRanges interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSnatPortRanges", reflect.TypeOf((*MockNAT)(nil).UpdateSnatPortRanges), lrName, externalIP, portRanges)
+}
+
 // MockDHCPOptions is a mock of DHCPOptions interface.
 type MockDHCPOptions struct {
 	ctrl     *gomock.Controller
@@ -2531,6 +2711,20 @@ func (mr *MockNbClientMockRecorder) CreateNodeACL(pgName, nodeIPStr, joinIPStr i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNodeACL", reflect.TypeOf((*MockNbClient)(nil).CreateNodeACL), pgName, nodeIPStr, joinIPStr)
 }
 
+// CreateOrUpdateMeter mocks base method.
+func (m *MockNbClient) CreateOrUpdateMeter(name string, unit ovnnb.MeterUnit, rate int, externalIDs map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrUpdateMeter", name, unit, rate, externalIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrUpdateMeter indicates an expected call of CreateOrUpdateMeter.
+func (mr *MockNbClientMockRecorder) CreateOrUpdateMeter(name, unit, rate, externalIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrUpdateMeter", reflect.TypeOf((*MockNbClient)(nil).CreateOrUpdateMeter), name, unit, rate, externalIDs)
+}
+
 // CreatePeerRouterPort mocks base method.
 func (m *MockNbClient) CreatePeerRouterPort(localRouter, remoteRouter, localRouterPortIP string) error {
 	m.ctrl.T.Helper()
@@ -2933,6 +3127,20 @@ func (mr *MockNbClientMockRecorder) DeleteLogicalSwitchPorts(externalIDs, filter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLogicalSwitchPorts", reflect.TypeOf((*MockNbClient)(nil).DeleteLogicalSwitchPorts), externalIDs, filter)
 }
 
+// DeleteMeter mocks base method.
+func (m *MockNbClient) DeleteMeter(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMeter", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMeter indicates an expected call of DeleteMeter.
+func (mr *MockNbClientMockRecorder) DeleteMeter(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMeter", reflect.TypeOf((*MockNbClient)(nil).DeleteMeter), name)
+}
+
 // DeleteNat mocks base method.
 func (m *MockNbClient) DeleteNat(lrName, natType, externalIP, logicalIP string) error {
 	m.ctrl.T.Helper()
@@ -2961,6 +3169,20 @@ func (mr *MockNbClientMockRecorder) DeleteNats(lrName, natType, logicalIP interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNats", reflect.TypeOf((*MockNbClient)(nil).DeleteNats), lrName, natType, logicalIP)
 }
 
+// DeleteSnats mocks base method.
+func (m *MockNbClient) DeleteSnats(lrName, externalIP string, logicalIPs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSnats", lrName, externalIP, logicalIPs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSnats indicates an expected call of DeleteSnats.
+func (mr *MockNbClientMockRecorder) DeleteSnats(lrName, externalIP, logicalIPs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSnats", reflect.TypeOf((*MockNbClient)(nil).DeleteSnats), lrName, externalIP, logicalIPs)
+}
+
 // DeletePortGroup mocks base method.
 func (m *MockNbClient) DeletePortGroup(pgName string) error {
 	m.ctrl.T.Helper()
@@ -3138,6 +3360,21 @@ func (mr *MockNbClientMockRecorder) GetLogicalSwitchPort(lspName, ignoreNotFound
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogicalSwitchPort", reflect.TypeOf((*MockNbClient)(nil).GetLogicalSwitchPort), lspName, ignoreNotFound)
 }
 
+// GetMeter mocks base method.
+func (m *MockNbClient) GetMeter(name string, ignoreNotFound bool) (*ovnnb.Meter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMeter", name, ignoreNotFound)
+	ret0, _ := ret[0].(*ovnnb.Meter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMeter indicates an expected call of GetMeter.
+func (mr *MockNbClientMockRecorder) GetMeter(name, ignoreNotFound interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeter", reflect.TypeOf((*MockNbClient)(nil).GetMeter), name, ignoreNotFound)
+}
+
 // GetNATByUUID mocks base method.
 func (m *MockNbClient) GetNATByUUID(uuid string) (*ovnnb.NAT, error) {
 	m.ctrl.T.Helper()
@@ -3455,6 +3692,20 @@ func (mr *MockNbClientMockRecorder) LoadBalancerAddVip(lbName, vip interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBalancerAddVip", reflect.TypeOf((*MockNbClient)(nil).LoadBalancerAddVip), varargs...)
 }
 
+// LoadBalancerAddVips mocks base method.
+func (m *MockNbClient) LoadBalancerAddVips(lbName string, vips map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadBalancerAddVips", lbName, vips)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadBalancerAddVips indicates an expected call of LoadBalancerAddVips.
+func (mr *MockNbClientMockRecorder) LoadBalancerAddVips(lbName, vips interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBalancerAddVips", reflect.TypeOf((*MockNbClient)(nil).LoadBalancerAddVips), lbName, vips)
+}
+
 // LoadBalancerDeleteHealthCheck mocks base method.
 func (m *MockNbClient) LoadBalancerDeleteHealthCheck(lbName, uuid string) error {
 	m.ctrl.T.Helper()
@@ -3497,6 +3748,20 @@ func (mr *MockNbClientMockRecorder) LoadBalancerDeleteVip(lbName, vip, ignoreHea
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBalancerDeleteVip", reflect.TypeOf((*MockNbClient)(nil).LoadBalancerDeleteVip), lbName, vip, ignoreHealthCheck)
 }
 
+// LoadBalancerDeleteVips mocks base method.
+func (m *MockNbClient) LoadBalancerDeleteVips(lbName string, vips []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadBalancerDeleteVips", lbName, vips)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadBalancerDeleteVips indicates an expected call of LoadBalancerDeleteVips.
+func (mr *MockNbClientMockRecorder) LoadBalancerDeleteVips(lbName, vips interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBalancerDeleteVips", reflect.TypeOf((*MockNbClient)(nil).LoadBalancerDeleteVips), lbName, vips)
+}
+
 // LoadBalancerExists mocks base method.
 func (m *MockNbClient) LoadBalancerExists(lbName string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -3668,6 +3933,18 @@ func (mr *MockNbClientMockRecorder) LogicalSwitchUpdateOtherConfig(lsName, op, o
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogicalSwitchUpdateOtherConfig", reflect.TypeOf((*MockNbClient)(nil).LogicalSwitchUpdateOtherConfig), lsName, op, otherConfig)
 }
 
+// MonitorBFD mocks base method.
+func (m *MockNbClient) MonitorBFD(onStatusChange func(*ovnnb.BFD)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MonitorBFD", onStatusChange)
+}
+
+// MonitorBFD indicates an expected call of MonitorBFD.
+func (mr *MockNbClientMockRecorder) MonitorBFD(onStatusChange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonitorBFD", reflect.TypeOf((*MockNbClient)(nil).MonitorBFD), onStatusChange)
+}
+
 // NatExists mocks base method.
 func (m *MockNbClient) NatExists(lrName, natType, externalIP, logicalIP string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -3979,6 +4256,21 @@ func (mr *MockNbClientMockRecorder) Transact(method, operations interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transact", reflect.TypeOf((*MockNbClient)(nil).Transact), method, operations)
 }
 
+// UpdateAnpRuleACLOps mocks base method.
+func (m *MockNbClient) UpdateAnpRuleACLOps(pgName, asName, protocol string, priority int, aclAction ovnnb.ACLAction, logEnable, isIngress bool, rulePorts []v1alpha1.AdminNetworkPolicyPort, namedPortMap map[string]*util.NamedPortInfo, excludes []string) ([]ovsdb.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnpRuleACLOps", pgName, asName, protocol, priority, aclAction, logEnable, isIngress, rulePorts, namedPortMap, excludes)
+	ret0, _ := ret[0].([]ovsdb.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAnpRuleACLOps indicates an expected call of UpdateAnpRuleACLOps.
+func (mr *MockNbClientMockRecorder) UpdateAnpRuleACLOps(pgName, asName, protocol, priority, aclAction, logEnable, isIngress, rulePorts, namedPortMap, excludes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnpRuleACLOps", reflect.TypeOf((*MockNbClient)(nil).UpdateAnpRuleACLOps), pgName, asName, protocol, priority, aclAction, logEnable, isIngress, rulePorts, namedPortMap, excludes)
+}
+
 // UpdateDHCPOptions mocks base method.
 func (m *MockNbClient) UpdateDHCPOptions(subnet *v1.Subnet, mtu int) (*ovs.DHCPOptionsUUIDs, error) {
 	m.ctrl.T.Helper()
@@ -4009,33 +4301,63 @@ func (mr *MockNbClientMockRecorder) UpdateDnatAndSnat(lrName, externalIP, logica
 }
 
 // UpdateEgressACLOps mocks base method.
-func (m *MockNbClient) UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol string, npp []v10.NetworkPolicyPort, logEnable bool, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
+func (m *MockNbClient) UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol string, npp []v10.NetworkPolicyPort, logConfig *ovs.ACLLogConfig, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateEgressACLOps", pgName, asEgressName, asExceptName, protocol, npp, logEnable, namedPortMap)
+	ret := m.ctrl.Call(m, "UpdateEgressACLOps", pgName, asEgressName, asExceptName, protocol, npp, logConfig, namedPortMap)
 	ret0, _ := ret[0].([]ovsdb.Operation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateEgressACLOps indicates an expected call of UpdateEgressACLOps.
-func (mr *MockNbClientMockRecorder) UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol, npp, logEnable, namedPortMap interface{}) *gomock.Call {
+func (mr *MockNbClientMockRecorder) UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol, npp, logConfig, namedPortMap interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEgressACLOps", reflect.TypeOf((*MockNbClient)(nil).UpdateEgressACLOps), pgName, asEgressName, asExceptName, protocol, npp, logEnable, namedPortMap)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEgressACLOps", reflect.TypeOf((*MockNbClient)(nil).UpdateEgressACLOps), pgName, asEgressName, asExceptName, protocol, npp, logConfig, namedPortMap)
+}
+
+// UpdateEgressFqdnACLOps mocks base method.
+func (m *MockNbClient) UpdateEgressFqdnACLOps(pgName, protocol string, fqdns []string) ([]ovsdb.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEgressFqdnACLOps", pgName, protocol, fqdns)
+	ret0, _ := ret[0].([]ovsdb.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEgressFqdnACLOps indicates an expected call of UpdateEgressFqdnACLOps.
+func (mr *MockNbClientMockRecorder) UpdateEgressFqdnACLOps(pgName, protocol, fqdns interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEgressFqdnACLOps", reflect.TypeOf((*MockNbClient)(nil).UpdateEgressFqdnACLOps), pgName, protocol, fqdns)
+}
+
+// UpdateGatewayACLOps mocks base method.
+func (m *MockNbClient) UpdateGatewayACLOps(pgName, gateway string) ([]ovsdb.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGatewayACLOps", pgName, gateway)
+	ret0, _ := ret[0].([]ovsdb.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGatewayACLOps indicates an expected call of UpdateGatewayACLOps.
+func (mr *MockNbClientMockRecorder) UpdateGatewayACLOps(pgName, gateway interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGatewayACLOps", reflect.TypeOf((*MockNbClient)(nil).UpdateGatewayACLOps), pgName, gateway)
 }
 
 // UpdateIngressACLOps mocks base method.
-func (m *MockNbClient) UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol string, npp []v10.NetworkPolicyPort, logEnable bool, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
+func (m *MockNbClient) UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol string, npp []v10.NetworkPolicyPort, logConfig *ovs.ACLLogConfig, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateIngressACLOps", pgName, asIngressName, asExceptName, protocol, npp, logEnable, namedPortMap)
+	ret := m.ctrl.Call(m, "UpdateIngressACLOps", pgName, asIngressName, asExceptName, protocol, npp, logConfig, namedPortMap)
 	ret0, _ := ret[0].([]ovsdb.Operation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateIngressACLOps indicates an expected call of UpdateIngressACLOps.
-func (mr *MockNbClientMockRecorder) UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol, npp, logEnable, namedPortMap interface{}) *gomock.Call {
+func (mr *MockNbClientMockRecorder) UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol, npp, logConfig, namedPortMap interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIngressACLOps", reflect.TypeOf((*MockNbClient)(nil).UpdateIngressACLOps), pgName, asIngressName, asExceptName, protocol, npp, logEnable, namedPortMap)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIngressACLOps", reflect.TypeOf((*MockNbClient)(nil).UpdateIngressACLOps), pgName, asIngressName, asExceptName, protocol, npp, logConfig, namedPortMap)
 }
 
 // UpdateLogicalRouterPortOptions mocks base method.
@@ -4127,6 +4449,20 @@ func (mr *MockNbClientMockRecorder) UpdateSnat(lrName, externalIP, logicalIP int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSnat", reflect.TypeOf((*MockNbClient)(nil).UpdateSnat), lrName, externalIP, logicalIP)
 }
 
+// UpdateSnatPortRanges mocks base method.
+func (m *MockNbClient) UpdateSnatPortRanges(lrName, externalIP string, portRanges map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSnatPortRanges", lrName, externalIP, portRanges)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSnatPortRanges indicates an expected call of UpdateSnatPortRanges.
+func (mr *MockNbClientMockRecorder) UpdateSnatPortRanges(lrName, externalIP, portRanges interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSnatPortRanges", reflect.TypeOf((*MockNbClient)(nil).UpdateSnatPortRanges), lrName, externalIP, portRanges)
+}
+
 // MockSbClient is a mock of SbClient interface.
 type MockSbClient struct {
 	ctrl     *gomock.Controller
@@ -459,3 +459,120 @@ func (s *ProviderNetworkStatus) RemoveNodeConditions(node string) bool {
 	}
 	return changed
 }
+
+type statusCondition interface {
+	IptablesEIPCondition | IptablesFIPRuleCondition | IptablesDnatRuleCondition | IptablesSnatRuleCondition |
+		VpcNatGatewayCondition | VpcPeeringConnectionCondition | VpcTransitHubCondition
+}
+
+// setStatusConditionValue updates or creates a new condition and returns whether the conditions are changed
+func setStatusConditionValue[T statusCondition](conditions *[]T, ctype ConditionType, status corev1.ConditionStatus, reason, message string) bool {
+	now := metav1.Now()
+	for i := range *conditions {
+		c := Condition((*conditions)[i])
+		if c.Type != ctype {
+			continue
+		}
+		if c.Status == status && c.Reason == reason && c.Message == message {
+			return false
+		}
+		c.LastUpdateTime = now
+		if c.Status != status {
+			c.LastTransitionTime = now
+		}
+		c.Status = status
+		c.Reason = reason
+		c.Message = message
+		(*conditions)[i] = T(c)
+		return true
+	}
+	*conditions = append(*conditions, T(Condition{
+		Type:               ctype,
+		LastUpdateTime:     now,
+		LastTransitionTime: now,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+	}))
+	return true
+}
+
+// SetCondition updates or creates a new condition with status true
+func (s *IptablesEipStatus) SetCondition(ctype ConditionType, reason, message string) bool {
+	return setStatusConditionValue(&s.Conditions, ctype, corev1.ConditionTrue, reason, message)
+}
+
+// ClearCondition updates or creates a new condition with status false
+func (s *IptablesEipStatus) ClearCondition(ctype ConditionType, reason, message string) bool {
+	return setStatusConditionValue(&s.Conditions, ctype, corev1.ConditionFalse, reason, message)
+}
+
+// SetCondition updates or creates a new condition with status true
+func (s *IptablesFIPRuleStatus) SetCondition(ctype ConditionType, reason, message string) bool {
+	return setStatusConditionValue(&s.Conditions, ctype, corev1.ConditionTrue, reason, message)
+}
+
+// ClearCondition updates or creates a new condition with status false
+func (s *IptablesFIPRuleStatus) ClearCondition(ctype ConditionType, reason, message string) bool {
+	return setStatusConditionValue(&s.Conditions, ctype, corev1.ConditionFalse, reason, message)
+}
+
+// SetCondition updates or creates a new condition with status true
+func (s *IptablesDnatRuleStatus) SetCondition(ctype ConditionType, reason, message string) bool {
+	return setStatusConditionValue(&s.Conditions, ctype, corev1.ConditionTrue, reason, message)
+}
+
+// ClearCondition updates or creates a new condition with status false
+func (s *IptablesDnatRuleStatus) ClearCondition(ctype ConditionType, reason, message string) bool {
+	return setStatusConditionValue(&s.Conditions, ctype, corev1.ConditionFalse, reason, message)
+}
+
+// SetCondition updates or creates a new condition with status true
+func (s *IptablesSnatRuleStatus) SetCondition(ctype ConditionType, reason, message string) bool {
+	return setStatusConditionValue(&s.Conditions, ctype, corev1.ConditionTrue, reason, message)
+}
+
+// ClearCondition updates or creates a new condition with status false
+func (s *IptablesSnatRuleStatus) ClearCondition(ctype ConditionType, reason, message string) bool {
+	return setStatusConditionValue(&s.Conditions, ctype, corev1.ConditionFalse, reason, message)
+}
+
+// SetCondition updates or creates a new condition with status true
+func (s *VpcNatStatus) SetCondition(ctype ConditionType, reason, message string) bool {
+	return setStatusConditionValue(&s.Conditions, ctype, corev1.ConditionTrue, reason, message)
+}
+
+// ClearCondition updates or creates a new condition with status false
+func (s *VpcNatStatus) ClearCondition(ctype ConditionType, reason, message string) bool {
+	return setStatusConditionValue(&s.Conditions, ctype, corev1.ConditionFalse, reason, message)
+}
+
+// SetCondition updates or creates a new condition with status true
+func (s *VpcPeeringConnectionStatus) SetCondition(ctype ConditionType, reason, message string) bool {
+	return setStatusConditionValue(&s.Conditions, ctype, corev1.ConditionTrue, reason, message)
+}
+
+// ClearCondition updates or creates a new condition with status false
+func (s *VpcPeeringConnectionStatus) ClearCondition(ctype ConditionType, reason, message string) bool {
+	return setStatusConditionValue(&s.Conditions, ctype, corev1.ConditionFalse, reason, message)
+}
+
+// IsConditionTrue returns whether the condition is true
+func (s *VpcPeeringConnectionStatus) IsConditionTrue(ctype ConditionType) bool {
+	for _, c := range s.Conditions {
+		if c.Type == ctype {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// SetCondition updates or creates a new condition with status true
+func (s *VpcTransitHubStatus) SetCondition(ctype ConditionType, reason, message string) bool {
+	return setStatusConditionValue(&s.Conditions, ctype, corev1.ConditionTrue, reason, message)
+}
+
+// ClearCondition updates or creates a new condition with status false
+func (s *VpcTransitHubStatus) ClearCondition(ctype ConditionType, reason, message string) bool {
+	return setStatusConditionValue(&s.Conditions, ctype, corev1.ConditionFalse, reason, message)
+}
@@ -71,6 +71,28 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&VpcDnsList{},
 		&QoSPolicy{},
 		&QoSPolicyList{},
+		&IPQuota{},
+		&IPQuotaList{},
+		&BgpPeer{},
+		&BgpPeerList{},
+		&FqdnCache{},
+		&FqdnCacheList{},
+		&AddressGroup{},
+		&AddressGroupList{},
+		&VpcEgressGateway{},
+		&VpcEgressGatewayList{},
+		&VpcEip{},
+		&VpcEipList{},
+		&VpcFip{},
+		&VpcFipList{},
+		&VpcDnatRule{},
+		&VpcDnatRuleList{},
+		&VpcSnatRule{},
+		&VpcSnatRuleList{},
+		&VpcPeeringConnection{},
+		&VpcPeeringConnectionList{},
+		&VpcTransitHub{},
+		&VpcTransitHubList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -148,6 +148,16 @@ func (qoss *QoSPolicyStatus) Bytes() ([]byte, error) {
 	return []byte(newStr), nil
 }
 
+func (iqs *IPQuotaStatus) Bytes() ([]byte, error) {
+	bytes, err := json.Marshal(iqs)
+	if err != nil {
+		return nil, err
+	}
+	newStr := fmt.Sprintf(`{"status": %s}`, string(bytes))
+	klog.V(5).Info("status body", newStr)
+	return []byte(newStr), nil
+}
+
 func (vns *VpcNatStatus) Bytes() ([]byte, error) {
 	bytes, err := json.Marshal(vns)
 	if err != nil {
@@ -157,3 +167,63 @@ func (vns *VpcNatStatus) Bytes() ([]byte, error) {
 	klog.V(5).Info("status body", newStr)
 	return []byte(newStr), nil
 }
+
+func (veips *VpcEipStatus) Bytes() ([]byte, error) {
+	bytes, err := json.Marshal(veips)
+	if err != nil {
+		return nil, err
+	}
+	newStr := fmt.Sprintf(`{"status": %s}`, string(bytes))
+	klog.V(5).Info("status body", newStr)
+	return []byte(newStr), nil
+}
+
+func (vfs *VpcFipStatus) Bytes() ([]byte, error) {
+	bytes, err := json.Marshal(vfs)
+	if err != nil {
+		return nil, err
+	}
+	newStr := fmt.Sprintf(`{"status": %s}`, string(bytes))
+	klog.V(5).Info("status body", newStr)
+	return []byte(newStr), nil
+}
+
+func (vdrs *VpcDnatRuleStatus) Bytes() ([]byte, error) {
+	bytes, err := json.Marshal(vdrs)
+	if err != nil {
+		return nil, err
+	}
+	newStr := fmt.Sprintf(`{"status": %s}`, string(bytes))
+	klog.V(5).Info("status body", newStr)
+	return []byte(newStr), nil
+}
+
+func (vsrs *VpcSnatRuleStatus) Bytes() ([]byte, error) {
+	bytes, err := json.Marshal(vsrs)
+	if err != nil {
+		return nil, err
+	}
+	newStr := fmt.Sprintf(`{"status": %s}`, string(bytes))
+	klog.V(5).Info("status body", newStr)
+	return []byte(newStr), nil
+}
+
+func (vpcs *VpcPeeringConnectionStatus) Bytes() ([]byte, error) {
+	bytes, err := json.Marshal(vpcs)
+	if err != nil {
+		return nil, err
+	}
+	newStr := fmt.Sprintf(`{"status": %s}`, string(bytes))
+	klog.V(5).Info("status body", newStr)
+	return []byte(newStr), nil
+}
+
+func (vths *VpcTransitHubStatus) Bytes() ([]byte, error) {
+	bytes, err := json.Marshal(vths)
+	if err != nil {
+		return nil, err
+	}
+	newStr := fmt.Sprintf(`{"status": %s}`, string(bytes))
+	klog.V(5).Info("status body", newStr)
+	return []byte(newStr), nil
+}
@@ -17,13 +17,19 @@ const (
 
 	GWDistributedType = "distributed"
 	GWCentralizedType = "centralized"
+
+	IPAllocationStrategySequential            = "sequential"
+	IPAllocationStrategyRandom                = "random"
+	IPAllocationStrategyLeastRecentlyReleased = "least-recently-released"
 )
 
 type SgRemoteType string
 
 const (
-	SgRemoteTypeAddress SgRemoteType = "address"
-	SgRemoteTypeSg      SgRemoteType = "securityGroup"
+	SgRemoteTypeAddress      SgRemoteType = "address"
+	SgRemoteTypeSg           SgRemoteType = "securityGroup"
+	SgRemoteTypeFqdn         SgRemoteType = "fqdn"
+	SgRemoteTypeAddressGroup SgRemoteType = "addressGroup"
 )
 
 type SgProtocol string
@@ -70,6 +76,10 @@ const (
 	Validated = "Validated"
 	// Error => last recorded error
 	Error = "Error"
+	// Applied => rules of the resource have been applied in the gateway
+	Applied = "Applied"
+	// Accepted => the vpc peering connection has been accepted by the owner of the remote vpc
+	Accepted = "Accepted"
 
 	ReasonInit = "Init"
 )
@@ -133,6 +143,9 @@ type SubnetSpec struct {
 	ExcludeIps []string `json:"excludeIps,omitempty"`
 	Provider   string   `json:"provider,omitempty"`
 
+	// additional CIDR blocks of the subnet, the first address of each block is used as its gateway
+	SecondaryCIDRBlocks []string `json:"secondaryCIDRBlocks,omitempty"`
+
 	GatewayType string `json:"gatewayType,omitempty"`
 	GatewayNode string `json:"gatewayNode"`
 	NatOutgoing bool   `json:"natOutgoing"`
@@ -171,6 +184,10 @@ type SubnetSpec struct {
 	EnableMulicastSnoop  bool   `json:"enableMulticastSnoop,omitempty"`
 
 	RouteTable string `json:"routeTable,omitempty"`
+
+	IPAllocationStrategy string `json:"ipAllocationStrategy,omitempty"`
+	// seconds for which a released address is not allocated again
+	IPReleaseCooldown int `json:"ipReleaseCooldown,omitempty"`
 }
 
 type ACL struct {
@@ -244,6 +261,16 @@ type SubnetStatus struct {
 	U2OInterconnectionIP   string                        `json:"u2oInterconnectionIP"`
 	U2OInterconnectionVPC  string                        `json:"u2oInterconnectionVPC"`
 	NatOutgoingPolicyRules []NatOutgoingPolicyRuleStatus `json:"natOutgoingPolicyRules"`
+	CIDRs                  []SubnetCIDRStatus            `json:"cidrs,omitempty"`
+}
+
+// SubnetCIDRStatus reports the capacity of a single CIDR block of the subnet
+type SubnetCIDRStatus struct {
+	CIDR         string  `json:"cidr"`
+	Gateway      string  `json:"gateway"`
+	Secondary    bool    `json:"secondary,omitempty"`
+	AvailableIPs float64 `json:"availableIPs"`
+	UsingIPs     float64 `json:"usingIPs"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -424,6 +451,11 @@ type VpcSpec struct {
 	EnableExternal       bool           `json:"enableExternal,omitempty"`
 	ExtraExternalSubnets []string       `json:"extraExternalSubnets,omitempty"`
 	EnableBfd            bool           `json:"enableBfd,omitempty"`
+	// NatBackend is the backend implementing the vpc eips and nat rules of the vpc, iptables or ovn.
+	// If not set, the iptables backend is used if the vpc has a nat gateway, otherwise the ovn backend is used.
+	// Changing it migrates the vpc eips and nat rules to the new backend, they are removed from the old backend
+	// before they are created in the new one, so they are unavailable during the migration.
+	NatBackend string `json:"natBackend,omitempty"`
 }
 
 type VpcPeering struct {
@@ -523,6 +555,9 @@ type VpcNatSpec struct {
 	Tolerations     []corev1.Toleration `json:"tolerations"`
 	Affinity        corev1.Affinity     `json:"affinity"`
 	QoSPolicy       string              `json:"qosPolicy"`
+	// Replicas is the number of nat gateway instances, more than one enables active/standby mode,
+	// in which the lan ip and eips float to the active instance by vrrp and conntrack is synced
+	Replicas int32 `json:"replicas,omitempty"`
 }
 
 type VpcNatStatus struct {
@@ -531,6 +566,29 @@ type VpcNatStatus struct {
 	Selector        []string            `json:"selector" patchStrategy:"merge"`
 	Tolerations     []corev1.Toleration `json:"tolerations" patchStrategy:"merge"`
 	Affinity        corev1.Affinity     `json:"affinity" patchStrategy:"merge"`
+	// ActiveInstance is the name of the pod holding the lan ip and eips
+	ActiveInstance string                  `json:"activeInstance,omitempty" patchStrategy:"merge"`
+	Instances      []VpcNatGatewayInstance `json:"instances,omitempty" patchStrategy:"merge"`
+	// Vrid is the vrrp virtual router id allocated in active/standby mode, which is unique in the subnet
+	Vrid int `json:"vrid,omitempty" patchStrategy:"merge"`
+	// Conditions represents the latest state of the routes and qos rules of the gateway
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions []VpcNatGatewayCondition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// VpcNatGatewayCondition describes the state of an object at a certain point.
+// +k8s:deepcopy-gen=true
+type VpcNatGatewayCondition Condition
+
+// VpcNatGatewayInstance is a pod of the nat gateway
+type VpcNatGatewayInstance struct {
+	Name string `json:"name"`
+	Node string `json:"node"`
+	IP   string `json:"ip"`
+	// Role is master, backup or fault in active/standby mode, and master otherwise
+	Role string `json:"role"`
 }
 
 // +genclient
@@ -597,6 +655,9 @@ type IptablesFIPRule struct {
 type IptablesFIPRuleSpec struct {
 	EIP        string `json:"eip"`
 	InternalIP string `json:"internalIp"`
+	// DrainPeriodSeconds keeps the existing sessions of the rule working for the period
+	// after the rule is changed or deleted, the sessions are flushed at once if not set
+	DrainPeriodSeconds int32 `json:"drainPeriodSeconds,omitempty"`
 }
 
 // IptablesFIPRuleCondition describes the state of an object at a certain point.
@@ -612,6 +673,8 @@ type IptablesFIPRuleStatus struct {
 	NatGwDp    string `json:"natGwDp" patchStrategy:"merge"`
 	Redo       string `json:"redo" patchStrategy:"merge"`
 	InternalIP string `json:"internalIp"  patchStrategy:"merge"`
+	// ActiveSessions is the number of active conntrack entries of the rule in the active instance
+	ActiveSessions int64 `json:"activeSessions" patchStrategy:"merge"`
 
 	// Conditions represents the latest state of the object
 	// +optional
@@ -644,6 +707,9 @@ type IptablesSnatRule struct {
 type IptablesSnatRuleSpec struct {
 	EIP          string `json:"eip"`
 	InternalCIDR string `json:"internalCIDR"`
+	// PortBlockSize enables the port block mode, each internal ip is translated to
+	// a fixed range of PortBlockSize ports of the eip
+	PortBlockSize int32 `json:"portBlockSize,omitempty"`
 }
 
 // IptablesSnatRuleCondition describes the state of an object at a certain point.
@@ -659,6 +725,10 @@ type IptablesSnatRuleStatus struct {
 	NatGwDp      string `json:"natGwDp" patchStrategy:"merge"`
 	Redo         string `json:"redo" patchStrategy:"merge"`
 	InternalCIDR string `json:"internalCIDR" patchStrategy:"merge"`
+	// PortBlockSize is the port block size applied, 0 if the port block mode is disabled
+	PortBlockSize int32 `json:"portBlockSize" patchStrategy:"merge"`
+	// PortBlockStart is the first eip port of the port blocks, the port blocks of the snats on the same eip never overlap
+	PortBlockStart int32 `json:"portBlockStart,omitempty" patchStrategy:"merge"`
 
 	// Conditions represents the latest state of the object
 	// +optional
@@ -694,6 +764,9 @@ type IptablesDnatRuleSpec struct {
 	Protocol     string `json:"protocol,omitempty"`
 	InternalIP   string `json:"internalIp"`
 	InternalPort string `json:"internalPort"`
+	// DrainPeriodSeconds keeps the existing sessions of the rule working for the period
+	// after the rule is changed or deleted, the sessions are flushed at once if not set
+	DrainPeriodSeconds int32 `json:"drainPeriodSeconds,omitempty"`
 }
 
 // IptablesDnatRuleCondition describes the state of an object at a certain point.
@@ -712,6 +785,8 @@ type IptablesDnatRuleStatus struct {
 	InternalIP   string `json:"internalIp"  patchStrategy:"merge"`
 	InternalPort string `json:"internalPort"  patchStrategy:"merge"`
 	ExternalPort string `json:"externalPort"  patchStrategy:"merge"`
+	// ActiveSessions is the number of active conntrack entries of the rule in the active instance
+	ActiveSessions int64 `json:"activeSessions" patchStrategy:"merge"`
 
 	// Conditions represents the latest state of the object
 	// +optional
@@ -755,11 +830,13 @@ type SecurityGroupSpec struct {
 	IngressRules          []*SgRule `json:"ingressRules,omitempty"`
 	EgressRules           []*SgRule `json:"egressRules,omitempty"`
 	AllowSameGroupTraffic bool      `json:"allowSameGroupTraffic,omitempty"`
+	Audit                 bool      `json:"audit,omitempty"`
 }
 
 type SecurityGroupStatus struct {
 	PortGroup              string `json:"portGroup"`
 	AllowSameGroupTraffic  bool   `json:"allowSameGroupTraffic"`
+	Audit                  bool   `json:"audit"`
 	IngressMd5             string `json:"ingressMd5"`
 	EgressMd5              string `json:"egressMd5"`
 	IngressLastSyncSuccess bool   `json:"ingressLastSyncSuccess"`
@@ -767,15 +844,26 @@ type SecurityGroupStatus struct {
 }
 
 type SgRule struct {
-	IPVersion           string       `json:"ipVersion"`
-	Protocol            SgProtocol   `json:"protocol,omitempty"`
-	Priority            int          `json:"priority,omitempty"`
-	RemoteType          SgRemoteType `json:"remoteType"`
-	RemoteAddress       string       `json:"remoteAddress,omitempty"`
-	RemoteSecurityGroup string       `json:"remoteSecurityGroup,omitempty"`
-	PortRangeMin        int          `json:"portRangeMin,omitempty"`
-	PortRangeMax        int          `json:"portRangeMax,omitempty"`
-	Policy              SgPolicy     `json:"policy"`
+	IPVersion           string        `json:"ipVersion"`
+	Protocol            SgProtocol    `json:"protocol,omitempty"`
+	Priority            int           `json:"priority,omitempty"`
+	RemoteType          SgRemoteType  `json:"remoteType"`
+	RemoteAddress       string        `json:"remoteAddress,omitempty"`
+	RemoteSecurityGroup string        `json:"remoteSecurityGroup,omitempty"`
+	RemoteAddressGroup  string        `json:"remoteAddressGroup,omitempty"`
+	PortRangeMin        int           `json:"portRangeMin,omitempty"`
+	PortRangeMax        int           `json:"portRangeMax,omitempty"`
+	PortRanges          []SgPortRange `json:"portRanges,omitempty"`
+	IcmpType            *int          `json:"icmpType,omitempty"`
+	IcmpCode            *int          `json:"icmpCode,omitempty"`
+	Policy              SgPolicy      `json:"policy"`
+	Stateless           bool          `json:"stateless,omitempty"`
+	Description         string        `json:"description,omitempty"`
+}
+
+type SgPortRange struct {
+	PortRangeMin int `json:"portRangeMin"`
+	PortRangeMax int `json:"portRangeMax"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -1057,6 +1145,9 @@ type OvnSnatRuleSpec struct {
 	IPName    string `json:"ipName"`
 	Vpc       string `json:"vpc"`
 	V4IpCidr  string `json:"v4IpCidr"` // subnet cidr or pod ip address
+	// PortBlockSize enables the port block mode, each internal ip is translated to
+	// a fixed range of PortBlockSize ports of the eip
+	PortBlockSize int32 `json:"portBlockSize,omitempty"`
 }
 
 // OvnSnatRuleCondition describes the state of an object at a certain point.
@@ -1070,6 +1161,10 @@ type OvnSnatRuleStatus struct {
 	V4Eip    string `json:"v4Eip" patchStrategy:"merge"`
 	V4IpCidr string `json:"v4IpCidr" patchStrategy:"merge"`
 	Ready    bool   `json:"ready" patchStrategy:"merge"`
+	// PortBlockSize is the port block size applied, 0 if the port block mode is disabled
+	PortBlockSize int32 `json:"portBlockSize" patchStrategy:"merge"`
+	// PortBlockStart is the first eip port of the port blocks, the port blocks of the snats on the same eip never overlap
+	PortBlockStart int32 `json:"portBlockStart,omitempty" patchStrategy:"merge"`
 
 	// Conditions represents the latest state of the object
 	// +optional
@@ -1208,3 +1303,632 @@ type QoSPolicyList struct {
 
 	Items []QoSPolicy `json:"items"`
 }
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+// +resourceName=ip-quotas
+
+type IPQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IPQuotaSpec   `json:"spec"`
+	Status IPQuotaStatus `json:"status,omitempty"`
+}
+
+type IPQuotaSpec struct {
+	Subnet string `json:"subnet"`
+	// Namespaces and Selector select the pods counted against the quota,
+	// pods must match both of them and an empty field matches all pods
+	Namespaces []string              `json:"namespaces,omitempty"`
+	Selector   *metav1.LabelSelector `json:"selector,omitempty"`
+	// V4IPs and V6IPs are the max number of addresses all the selected pods
+	// may hold in the subnet, 0 means unlimited
+	V4IPs int `json:"v4IPs,omitempty"`
+	V6IPs int `json:"v6IPs,omitempty"`
+}
+
+type IPQuotaStatus struct {
+	V4UsingIPs int `json:"v4UsingIPs"`
+	V6UsingIPs int `json:"v6UsingIPs"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type IPQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []IPQuota `json:"items"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+// +resourceName=bgp-peers
+
+type BgpPeer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BgpPeerSpec `json:"spec"`
+}
+
+type BgpPeerSpec struct {
+	// NodeSelector selects the nodes whose speakers peer with the neighbor, nil selects all nodes
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	NeighborAddress string `json:"neighborAddress"`
+	NeighborAs      uint32 `json:"neighborAs"`
+	// LocalAs overrides the cluster AS number of the speaker for this session
+	LocalAs uint32 `json:"localAs,omitempty"`
+	// AuthPasswordSecretRef refers to the key of the Secret holding the TCP MD5 password of the session
+	AuthPasswordSecretRef *BgpSecretKeyRef `json:"authPasswordSecretRef,omitempty"`
+
+	HoldTime          metav1.Duration `json:"holdTime,omitempty"`
+	KeepaliveInterval metav1.Duration `json:"keepaliveInterval,omitempty"`
+	ConnectRetry      metav1.Duration `json:"connectRetry,omitempty"`
+	EbgpMultihopTTL   uint8           `json:"ebgpMultihopTTL,omitempty"`
+	PassiveMode       bool            `json:"passiveMode,omitempty"`
+	GracefulRestart   bool            `json:"gracefulRestart,omitempty"`
+
+	// AddressFamilies are the unicast families exchanged with the neighbor, IPv4 and/or IPv6,
+	// defaults to the family of the neighbor address
+	AddressFamilies []string `json:"addressFamilies,omitempty"`
+
+	ImportPolicy *BgpPolicy `json:"importPolicy,omitempty"`
+	ExportPolicy *BgpPolicy `json:"exportPolicy,omitempty"`
+
+	// RouteImport installs the routes learned from the neighbor and accepted by the import policy
+	RouteImport *BgpRouteImport `json:"routeImport,omitempty"`
+
+	// Bfd runs a BFD session with the neighbor, the BGP session is torn down once BFD detects the neighbor down
+	Bfd *BgpBfd `json:"bfd,omitempty"`
+}
+
+type BgpBfd struct {
+	// MinTxInterval is the desired minimum interval between the transmitted control packets, defaults to 300ms
+	MinTxInterval metav1.Duration `json:"minTxInterval,omitempty"`
+	// MinRxInterval is the required minimum interval between the received control packets, defaults to 300ms
+	MinRxInterval metav1.Duration `json:"minRxInterval,omitempty"`
+	// DetectMultiplier is the number of missed control packets declaring the session down, defaults to 3
+	DetectMultiplier uint8 `json:"detectMultiplier,omitempty"`
+	// Multihop runs the session over UDP port 4784 as described in RFC 5883, required by multihop eBGP neighbors
+	Multihop bool `json:"multihop,omitempty"`
+}
+
+// BgpSecretKeyRef selects a key of a Secret
+type BgpSecretKeyRef struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+}
+
+type BgpRouteImport struct {
+	// Prefixes restricts the installed routes to the ones within any of the prefixes,
+	// all accepted routes are installed if empty
+	Prefixes []string `json:"prefixes,omitempty"`
+	// Table is the id of the node routing table the routes are installed into, defaults to the main table
+	Table int `json:"table,omitempty"`
+	// Vpc additionally installs the routes as static routes of the logical router of the vpc
+	Vpc string `json:"vpc,omitempty"`
+}
+
+// BgpPolicy filters the routes exchanged with a neighbor by prefix, the first matched rule applies
+type BgpPolicy struct {
+	Rules []BgpPolicyRule `json:"rules,omitempty"`
+	// DefaultAction applies to the routes matching no rule, defaults to accept
+	DefaultAction BgpPolicyAction `json:"defaultAction,omitempty"`
+}
+
+type BgpPolicyRule struct {
+	Prefix string `json:"prefix"`
+	// MaskLengthMin and MaskLengthMax match the more specific routes within the prefix,
+	// both default to the length of the prefix
+	MaskLengthMin uint32          `json:"maskLengthMin,omitempty"`
+	MaskLengthMax uint32          `json:"maskLengthMax,omitempty"`
+	Action        BgpPolicyAction `json:"action"`
+}
+
+type BgpPolicyAction string
+
+const (
+	BgpPolicyActionAccept BgpPolicyAction = "accept"
+	BgpPolicyActionReject BgpPolicyAction = "reject"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type BgpPeerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []BgpPeer `json:"items"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+// +resourceName=fqdn-caches
+
+// FqdnCache records the addresses of domain names learned by snooping the dns responses
+// received by the pods on a node, the name of a FqdnCache is the name of the node
+type FqdnCache struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec FqdnCacheSpec `json:"spec"`
+}
+
+type FqdnCacheSpec struct {
+	Entries []FqdnCacheEntry `json:"entries,omitempty"`
+}
+
+type FqdnCacheEntry struct {
+	// Name is the lower case domain name without the trailing dot
+	Name       string      `json:"name"`
+	IP         string      `json:"ip"`
+	ExpireTime metav1.Time `json:"expireTime"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type FqdnCacheList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []FqdnCache `json:"items"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+// +resourceName=address-groups
+
+// AddressGroup is a named set of ip addresses and cidrs which can be referenced by the rules of security groups
+type AddressGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AddressGroupSpec `json:"spec"`
+}
+
+type AddressGroupSpec struct {
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type AddressGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []AddressGroup `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+// +resourceName=vpc-egress-gateways
+
+// VpcEgressGateway runs a replicated set of gateway instances which forward the egress traffic
+// of the selected pods to the external network with stable source ip addresses
+type VpcEgressGateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VpcEgressGatewaySpec   `json:"spec"`
+	Status VpcEgressGatewayStatus `json:"status,omitempty"`
+}
+
+type VpcEgressGatewaySpec struct {
+	// Vpc is the vpc of the selected pods, the default vpc is used if not specified
+	Vpc string `json:"vpc,omitempty"`
+	// Replicas is the number of the gateway instances
+	Replicas int32 `json:"replicas,omitempty"`
+	// Image is the image of the gateway instances, the image of kube-ovn is used if not specified
+	Image string `json:"image,omitempty"`
+	// InternalSubnet is the subnet of the vpc which the gateway instances are attached to
+	InternalSubnet string `json:"internalSubnet"`
+	// ExternalSubnet is the subnet attached to the gateway instances by multus to reach the external network
+	ExternalSubnet string `json:"externalSubnet"`
+	// InternalIPs are the ip addresses of the gateway instances in the internal subnet, one for each instance
+	InternalIPs []string `json:"internalIPs,omitempty"`
+	// ExternalIPs are the egress ip addresses of the gateway instances in the external subnet, one for each instance
+	ExternalIPs []string `json:"externalIPs,omitempty"`
+	// BFD configures the bfd sessions between the vpc router and the gateway instances
+	BFD VpcEgressGatewayBFDConfig `json:"bfd"`
+	// Selectors select the pods whose egress traffic is forwarded by the gateway
+	Selectors []VpcEgressGatewaySelector `json:"selectors,omitempty"`
+	// NodeSelector is the node selector of the gateway instances in the format of "key: value"
+	NodeSelector []string            `json:"nodeSelector,omitempty"`
+	Tolerations  []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+type VpcEgressGatewayBFDConfig struct {
+	Enabled    bool  `json:"enabled"`
+	MinRX      int32 `json:"minRX,omitempty"`
+	MinTX      int32 `json:"minTX,omitempty"`
+	Multiplier int32 `json:"multiplier,omitempty"`
+}
+
+// VpcEgressGatewaySelector selects the pods matching the pod selector in the namespaces matching the namespace selector,
+// a nil namespace selector selects all namespaces
+type VpcEgressGatewaySelector struct {
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	PodSelector       *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+type VpcEgressGatewayInstance struct {
+	Name       string `json:"name"`
+	Node       string `json:"node"`
+	InternalIP string `json:"internalIP"`
+	ExternalIP string `json:"externalIP"`
+	// Active means the instance is one of the next hops of the policy routes
+	Active bool `json:"active"`
+}
+
+type VpcEgressGatewayStatus struct {
+	Ready           bool                       `json:"ready"`
+	Message         string                     `json:"message,omitempty"`
+	ActiveInstances int                        `json:"activeInstances"`
+	Instances       []VpcEgressGatewayInstance `json:"instances,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VpcEgressGatewayList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VpcEgressGateway `json:"items"`
+}
+
+// nat backends of the vpc eips and nat rules
+const (
+	NatBackendIptables = "iptables"
+	NatBackendOvn      = "ovn"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+// +resourceName=vpc-eips
+
+// VpcEip is a backend neutral eip of a vpc, it is implemented by an IptablesEIP in the vpc nat gateway
+// or an OvnEip of the vpc router with the same name according to the nat backend of the vpc
+type VpcEip struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VpcEipSpec   `json:"spec"`
+	Status VpcEipStatus `json:"status,omitempty"`
+}
+
+type VpcEipSpec struct {
+	Vpc string `json:"vpc"`
+	// ExternalSubnet is the subnet the eip is allocated from, the default external subnet of the backend
+	// is used if not set. The address is kept when the eip is migrated to another backend only if it or
+	// V4Ip is set, otherwise the migration is rejected and the eip stays in the old backend.
+	ExternalSubnet string `json:"externalSubnet,omitempty"`
+	V4Ip           string `json:"v4Ip,omitempty"`
+}
+
+type VpcEipStatus struct {
+	// Backend is the nat backend implementing the eip
+	Backend string `json:"backend"`
+	// NatGateway is the vpc nat gateway of the iptables backend
+	NatGateway string `json:"natGateway"`
+	V4Ip       string `json:"v4Ip"`
+	Ready      bool   `json:"ready"`
+	Message    string `json:"message"`
+	// TargetBackend and TargetNatGateway are the nat backend the eip is migrated to. The eip and its nat rules
+	// are created in the target backend with the same address while Backend keeps serving them, and they are
+	// removed from Backend once all of them are ready in the target backend, i.e. the migration is make before break.
+	TargetBackend    string `json:"targetBackend"`
+	TargetNatGateway string `json:"targetNatGateway"`
+	// TargetReady is whether the eip is ready in the target backend, the nat rules are created there after it is
+	TargetReady bool `json:"targetReady"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VpcEipList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VpcEip `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+// +resourceName=vpc-fips
+
+// VpcFip is a backend neutral floating ip, it is implemented by an IptablesFIPRule
+// or an OvnFip with the same name in the backend of the eip
+type VpcFip struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VpcFipSpec   `json:"spec"`
+	Status VpcFipStatus `json:"status,omitempty"`
+}
+
+type VpcFipSpec struct {
+	// Eip is the name of the vpc eip
+	Eip        string `json:"eip"`
+	InternalIP string `json:"internalIp"`
+}
+
+type VpcFipStatus struct {
+	Backend string `json:"backend"`
+	V4Ip    string `json:"v4Ip"`
+	Ready   bool   `json:"ready"`
+	Message string `json:"message"`
+	// TargetBackend is the backend the eip of the rule is migrated to, in which the rule is created as well
+	TargetBackend string `json:"targetBackend"`
+	TargetReady   bool   `json:"targetReady"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VpcFipList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VpcFip `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+// +resourceName=vpc-dnat-rules
+
+// VpcDnatRule is a backend neutral dnat rule, it is implemented by an IptablesDnatRule
+// or an OvnDnatRule with the same name in the backend of the eip
+type VpcDnatRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VpcDnatRuleSpec   `json:"spec"`
+	Status VpcDnatRuleStatus `json:"status,omitempty"`
+}
+
+type VpcDnatRuleSpec struct {
+	// Eip is the name of the vpc eip
+	Eip          string `json:"eip"`
+	ExternalPort string `json:"externalPort"`
+	Protocol     string `json:"protocol,omitempty"`
+	InternalIP   string `json:"internalIp"`
+	InternalPort string `json:"internalPort"`
+}
+
+type VpcDnatRuleStatus struct {
+	Backend string `json:"backend"`
+	V4Ip    string `json:"v4Ip"`
+	Ready   bool   `json:"ready"`
+	Message string `json:"message"`
+	// TargetBackend is the backend the eip of the rule is migrated to, in which the rule is created as well
+	TargetBackend string `json:"targetBackend"`
+	TargetReady   bool   `json:"targetReady"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VpcDnatRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VpcDnatRule `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +genclient:nonNamespaced
+// +resourceName=vpc-snat-rules
+
+// VpcSnatRule is a backend neutral snat rule, it is implemented by an IptablesSnatRule
+// or an OvnSnatRule with the same name in the backend of the eip
+type VpcSnatRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VpcSnatRuleSpec   `json:"spec"`
+	Status VpcSnatRuleStatus `json:"status,omitempty"`
+}
+
+type VpcSnatRuleSpec struct {
+	// Eip is the name of the vpc eip
+	Eip          string `json:"eip"`
+	InternalCIDR string `json:"internalCIDR"`
+}
+
+type VpcSnatRuleStatus struct {
+	Backend string `json:"backend"`
+	V4Ip    string `json:"v4Ip"`
+	Ready   bool   `json:"ready"`
+	Message string `json:"message"`
+	// TargetBackend is the backend the eip of the rule is migrated to, in which the rule is created as well
+	TargetBackend string `json:"targetBackend"`
+	TargetReady   bool   `json:"targetReady"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VpcSnatRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VpcSnatRule `json:"items"`
+}
+
+// roles and phases of the vpc peering connections
+const (
+	VpcPeeringRoleRequester = "requester"
+	VpcPeeringRoleAccepter  = "accepter"
+
+	VpcPeeringPhasePending = "Pending"
+	VpcPeeringPhaseActive  = "Active"
+	VpcPeeringPhaseFailed  = "Failed"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +resourceName=vpc-peering-connections
+
+// VpcPeeringConnection peers the vpc owned by its namespace with a vpc owned by another namespace.
+// The requester sets the remote vpc and stays pending until the owner of the remote vpc accepts it
+// by creating a VpcPeeringConnection which references the requester.
+type VpcPeeringConnection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VpcPeeringConnectionSpec   `json:"spec"`
+	Status VpcPeeringConnectionStatus `json:"status,omitempty"`
+}
+
+type VpcPeeringConnectionSpec struct {
+	// Vpc is the local vpc, the namespace of the connection must be one of the namespaces of the vpc
+	Vpc string `json:"vpc"`
+	// Subnets are the local subnets whose cidrs are propagated to the remote vpc
+	Subnets []string `json:"subnets,omitempty"`
+	// RemoteVpc is the vpc to peer with, it is set by the requester
+	RemoteVpc string `json:"remoteVpc,omitempty"`
+	// Requester is the connection to accept, it is set by the accepter
+	Requester *VpcPeeringConnectionReference `json:"requester,omitempty"`
+}
+
+type VpcPeeringConnectionReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+func (r VpcPeeringConnectionReference) String() string {
+	return r.Namespace + "/" + r.Name
+}
+
+type VpcPeeringConnectionStatus struct {
+	Role  string `json:"role"`
+	Phase string `json:"phase"`
+	// Peer is the accepter of the requester or the requester of the accepter
+	Peer      *VpcPeeringConnectionReference `json:"peer"`
+	RemoteVpc string                         `json:"remoteVpc"`
+	// LocalConnectIP and RemoteConnectIP are the addresses of the interconnect router ports,
+	// allocated from the vpc peering cidr when the connection is accepted
+	LocalConnectIP  string `json:"localConnectIP"`
+	RemoteConnectIP string `json:"remoteConnectIP"`
+	// Routes are the static routes of the local vpc to the propagated subnets of the remote vpc
+	Routes []*StaticRoute `json:"routes"`
+
+	// Conditions represents the latest state of the object
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions []VpcPeeringConnectionCondition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// VpcPeeringConnectionCondition describes the state of an object at a certain point.
+// +k8s:deepcopy-gen=true
+type VpcPeeringConnectionCondition Condition
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VpcPeeringConnectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VpcPeeringConnection `json:"items"`
+}
+
+// the main route table and the phases of the attachments of the vpc transit hubs
+const (
+	VpcTransitHubMainRouteTable = "main"
+
+	VpcTransitHubAttachmentActive = "Active"
+	VpcTransitHubAttachmentFailed = "Failed"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +resourceName=vpc-transit-hubs
+
+// VpcTransitHub routes between the spoke vpcs attached to the router of the hub vpc.
+// Each attachment is associated with a route table of the hub which routes the traffic from the spoke,
+// and propagates the cidrs of its subnets to the route tables of the hub.
+type VpcTransitHub struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VpcTransitHubSpec   `json:"spec"`
+	Status VpcTransitHubStatus `json:"status,omitempty"`
+}
+
+type VpcTransitHubSpec struct {
+	// Vpc is the hub vpc, it can neither be attached to nor be the hub of another transit hub
+	Vpc         string                    `json:"vpc"`
+	Attachments []VpcTransitHubAttachment `json:"attachments,omitempty"`
+}
+
+type VpcTransitHubAttachment struct {
+	// Vpc is the spoke vpc, it can only be attached to one transit hub
+	Vpc string `json:"vpc"`
+	// Subnets are the spoke subnets whose cidrs are propagated
+	Subnets []string `json:"subnets,omitempty"`
+	// RouteTable is the route table associated with the attachment, default: main.
+	// The routes of the main route table are also used by the attachments associated with other route tables.
+	RouteTable string `json:"routeTable,omitempty"`
+	// Propagations are the route tables the cidrs of the subnets are propagated to,
+	// default: the associated route table
+	Propagations []string `json:"propagations,omitempty"`
+}
+
+type VpcTransitHubStatus struct {
+	Attachments []VpcTransitHubAttachmentStatus `json:"attachments"`
+	RouteTables []VpcTransitHubRouteTable       `json:"routeTables"`
+
+	// Conditions represents the latest state of the object
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions []VpcTransitHubCondition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+type VpcTransitHubAttachmentStatus struct {
+	Vpc     string `json:"vpc"`
+	Phase   string `json:"phase"`
+	Message string `json:"message,omitempty"`
+	// HubConnectIP and SpokeConnectIP are the addresses of the router ports between the hub and the spoke,
+	// allocated from the vpc transit hub cidr
+	HubConnectIP   string `json:"hubConnectIP,omitempty"`
+	SpokeConnectIP string `json:"spokeConnectIP,omitempty"`
+	RouteTable     string `json:"routeTable"`
+	// Routes are the static routes of the spoke vpc learned from the associated route table
+	Routes []*StaticRoute `json:"routes,omitempty"`
+}
+
+type VpcTransitHubRouteTable struct {
+	Name         string   `json:"name"`
+	Associations []string `json:"associations,omitempty"`
+	Propagations []string `json:"propagations,omitempty"`
+	// Routes are the static routes of the hub vpc learned from the propagations
+	Routes []*StaticRoute `json:"routes,omitempty"`
+}
+
+// VpcTransitHubCondition describes the state of an object at a certain point.
+// +k8s:deepcopy-gen=true
+type VpcTransitHubCondition Condition
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VpcTransitHubList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VpcTransitHub `json:"items"`
+}
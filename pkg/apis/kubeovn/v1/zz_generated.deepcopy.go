@@ -23,6 +23,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -43,46 +44,7 @@ func (in *ACL) DeepCopy() *ACL {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Condition) DeepCopyInto(out *Condition) {
-	*out = *in
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
-func (in *Condition) DeepCopy() *Condition {
-	if in == nil {
-		return nil
-	}
-	out := new(Condition)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomInterface) DeepCopyInto(out *CustomInterface) {
-	*out = *in
-	if in.Nodes != nil {
-		in, out := &in.Nodes, &out.Nodes
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomInterface.
-func (in *CustomInterface) DeepCopy() *CustomInterface {
-	if in == nil {
-		return nil
-	}
-	out := new(CustomInterface)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IP) DeepCopyInto(out *IP) {
+func (in *AddressGroup) DeepCopyInto(out *AddressGroup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -90,18 +52,18 @@ func (in *IP) DeepCopyInto(out *IP) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IP.
-func (in *IP) DeepCopy() *IP {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddressGroup.
+func (in *AddressGroup) DeepCopy() *AddressGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(IP)
+	out := new(AddressGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IP) DeepCopyObject() runtime.Object {
+func (in *AddressGroup) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -109,13 +71,13 @@ func (in *IP) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPList) DeepCopyInto(out *IPList) {
+func (in *AddressGroupList) DeepCopyInto(out *AddressGroupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]IP, len(*in))
+		*out = make([]AddressGroup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -123,18 +85,18 @@ func (in *IPList) DeepCopyInto(out *IPList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPList.
-func (in *IPList) DeepCopy() *IPList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddressGroupList.
+func (in *AddressGroupList) DeepCopy() *AddressGroupList {
 	if in == nil {
 		return nil
 	}
-	out := new(IPList)
+	out := new(AddressGroupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IPList) DeepCopyObject() runtime.Object {
+func (in *AddressGroupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -142,59 +104,79 @@ func (in *IPList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPPool) DeepCopyInto(out *IPPool) {
+func (in *AddressGroupSpec) DeepCopyInto(out *AddressGroupSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPool.
-func (in *IPPool) DeepCopy() *IPPool {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddressGroupSpec.
+func (in *AddressGroupSpec) DeepCopy() *AddressGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IPPool)
+	out := new(AddressGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IPPool) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BgpBfd) DeepCopyInto(out *BgpBfd) {
+	*out = *in
+	out.MinTxInterval = in.MinTxInterval
+	out.MinRxInterval = in.MinRxInterval
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BgpBfd.
+func (in *BgpBfd) DeepCopy() *BgpBfd {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(BgpBfd)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPPoolCondition) DeepCopyInto(out *IPPoolCondition) {
+func (in *BgpPeer) DeepCopyInto(out *BgpPeer) {
 	*out = *in
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolCondition.
-func (in *IPPoolCondition) DeepCopy() *IPPoolCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BgpPeer.
+func (in *BgpPeer) DeepCopy() *BgpPeer {
 	if in == nil {
 		return nil
 	}
-	out := new(IPPoolCondition)
+	out := new(BgpPeer)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BgpPeer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPPoolList) DeepCopyInto(out *IPPoolList) {
+func (in *BgpPeerList) DeepCopyInto(out *BgpPeerList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]IPPool, len(*in))
+		*out = make([]BgpPeer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -202,18 +184,18 @@ func (in *IPPoolList) DeepCopyInto(out *IPPoolList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolList.
-func (in *IPPoolList) DeepCopy() *IPPoolList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BgpPeerList.
+func (in *BgpPeerList) DeepCopy() *BgpPeerList {
 	if in == nil {
 		return nil
 	}
-	out := new(IPPoolList)
+	out := new(BgpPeerList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IPPoolList) DeepCopyObject() runtime.Object {
+func (in *BgpPeerList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -221,229 +203,193 @@ func (in *IPPoolList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPPoolSpec) DeepCopyInto(out *IPPoolSpec) {
+func (in *BgpPeerSpec) DeepCopyInto(out *BgpPeerSpec) {
 	*out = *in
-	if in.Namespaces != nil {
-		in, out := &in.Namespaces, &out.Namespaces
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuthPasswordSecretRef != nil {
+		in, out := &in.AuthPasswordSecretRef, &out.AuthPasswordSecretRef
+		*out = new(BgpSecretKeyRef)
+		**out = **in
 	}
-	if in.IPs != nil {
-		in, out := &in.IPs, &out.IPs
+	out.HoldTime = in.HoldTime
+	out.KeepaliveInterval = in.KeepaliveInterval
+	out.ConnectRetry = in.ConnectRetry
+	if in.AddressFamilies != nil {
+		in, out := &in.AddressFamilies, &out.AddressFamilies
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolSpec.
-func (in *IPPoolSpec) DeepCopy() *IPPoolSpec {
-	if in == nil {
-		return nil
+	if in.ImportPolicy != nil {
+		in, out := &in.ImportPolicy, &out.ImportPolicy
+		*out = new(BgpPolicy)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(IPPoolSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPPoolStatus) DeepCopyInto(out *IPPoolStatus) {
-	*out = *in
-	in.V4AvailableIPs.DeepCopyInto(&out.V4AvailableIPs)
-	in.V4UsingIPs.DeepCopyInto(&out.V4UsingIPs)
-	in.V6AvailableIPs.DeepCopyInto(&out.V6AvailableIPs)
-	in.V6UsingIPs.DeepCopyInto(&out.V6UsingIPs)
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]IPPoolCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ExportPolicy != nil {
+		in, out := &in.ExportPolicy, &out.ExportPolicy
+		*out = new(BgpPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RouteImport != nil {
+		in, out := &in.RouteImport, &out.RouteImport
+		*out = new(BgpRouteImport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bfd != nil {
+		in, out := &in.Bfd, &out.Bfd
+		*out = new(BgpBfd)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolStatus.
-func (in *IPPoolStatus) DeepCopy() *IPPoolStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BgpPeerSpec.
+func (in *BgpPeerSpec) DeepCopy() *BgpPeerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IPPoolStatus)
+	out := new(BgpPeerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPSpec) DeepCopyInto(out *IPSpec) {
+func (in *BgpPolicy) DeepCopyInto(out *BgpPolicy) {
 	*out = *in
-	if in.AttachSubnets != nil {
-		in, out := &in.AttachSubnets, &out.AttachSubnets
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.AttachIPs != nil {
-		in, out := &in.AttachIPs, &out.AttachIPs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.AttachMacs != nil {
-		in, out := &in.AttachMacs, &out.AttachMacs
-		*out = make([]string, len(*in))
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]BgpPolicyRule, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPSpec.
-func (in *IPSpec) DeepCopy() *IPSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BgpPolicy.
+func (in *BgpPolicy) DeepCopy() *BgpPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(IPSpec)
+	out := new(BgpPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesDnatRule) DeepCopyInto(out *IptablesDnatRule) {
+func (in *BgpPolicyRule) DeepCopyInto(out *BgpPolicyRule) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesDnatRule.
-func (in *IptablesDnatRule) DeepCopy() *IptablesDnatRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BgpPolicyRule.
+func (in *BgpPolicyRule) DeepCopy() *BgpPolicyRule {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesDnatRule)
+	out := new(BgpPolicyRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IptablesDnatRule) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesDnatRuleCondition) DeepCopyInto(out *IptablesDnatRuleCondition) {
+func (in *BgpRouteImport) DeepCopyInto(out *BgpRouteImport) {
 	*out = *in
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesDnatRuleCondition.
-func (in *IptablesDnatRuleCondition) DeepCopy() *IptablesDnatRuleCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BgpRouteImport.
+func (in *BgpRouteImport) DeepCopy() *BgpRouteImport {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesDnatRuleCondition)
+	out := new(BgpRouteImport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesDnatRuleList) DeepCopyInto(out *IptablesDnatRuleList) {
+func (in *BgpSecretKeyRef) DeepCopyInto(out *BgpSecretKeyRef) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]IptablesDnatRule, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesDnatRuleList.
-func (in *IptablesDnatRuleList) DeepCopy() *IptablesDnatRuleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BgpSecretKeyRef.
+func (in *BgpSecretKeyRef) DeepCopy() *BgpSecretKeyRef {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesDnatRuleList)
+	out := new(BgpSecretKeyRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IptablesDnatRuleList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesDnatRuleSpec) DeepCopyInto(out *IptablesDnatRuleSpec) {
+func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesDnatRuleSpec.
-func (in *IptablesDnatRuleSpec) DeepCopy() *IptablesDnatRuleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesDnatRuleSpec)
+	out := new(Condition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesDnatRuleStatus) DeepCopyInto(out *IptablesDnatRuleStatus) {
+func (in *CustomInterface) DeepCopyInto(out *CustomInterface) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]IptablesDnatRuleCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesDnatRuleStatus.
-func (in *IptablesDnatRuleStatus) DeepCopy() *IptablesDnatRuleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomInterface.
+func (in *CustomInterface) DeepCopy() *CustomInterface {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesDnatRuleStatus)
+	out := new(CustomInterface)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesEIP) DeepCopyInto(out *IptablesEIP) {
+func (in *FqdnCache) DeepCopyInto(out *FqdnCache) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesEIP.
-func (in *IptablesEIP) DeepCopy() *IptablesEIP {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FqdnCache.
+func (in *FqdnCache) DeepCopy() *FqdnCache {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesEIP)
+	out := new(FqdnCache)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IptablesEIP) DeepCopyObject() runtime.Object {
+func (in *FqdnCache) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -451,31 +397,30 @@ func (in *IptablesEIP) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesEIPCondition) DeepCopyInto(out *IptablesEIPCondition) {
+func (in *FqdnCacheEntry) DeepCopyInto(out *FqdnCacheEntry) {
 	*out = *in
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.ExpireTime.DeepCopyInto(&out.ExpireTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesEIPCondition.
-func (in *IptablesEIPCondition) DeepCopy() *IptablesEIPCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FqdnCacheEntry.
+func (in *FqdnCacheEntry) DeepCopy() *FqdnCacheEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesEIPCondition)
+	out := new(FqdnCacheEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesEIPList) DeepCopyInto(out *IptablesEIPList) {
+func (in *FqdnCacheList) DeepCopyInto(out *FqdnCacheList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]IptablesEIP, len(*in))
+		*out = make([]FqdnCache, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -483,18 +428,18 @@ func (in *IptablesEIPList) DeepCopyInto(out *IptablesEIPList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesEIPList.
-func (in *IptablesEIPList) DeepCopy() *IptablesEIPList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FqdnCacheList.
+func (in *FqdnCacheList) DeepCopy() *FqdnCacheList {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesEIPList)
+	out := new(FqdnCacheList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IptablesEIPList) DeepCopyObject() runtime.Object {
+func (in *FqdnCacheList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -502,27 +447,63 @@ func (in *IptablesEIPList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesEipSpec) DeepCopyInto(out *IptablesEipSpec) {
+func (in *FqdnCacheSpec) DeepCopyInto(out *FqdnCacheSpec) {
 	*out = *in
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]FqdnCacheEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesEipSpec.
-func (in *IptablesEipSpec) DeepCopy() *IptablesEipSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FqdnCacheSpec.
+func (in *FqdnCacheSpec) DeepCopy() *FqdnCacheSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesEipSpec)
+	out := new(FqdnCacheSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesEipStatus) DeepCopyInto(out *IptablesEipStatus) {
+func (in *IP) DeepCopyInto(out *IP) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]IptablesEIPCondition, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IP.
+func (in *IP) DeepCopy() *IP {
+	if in == nil {
+		return nil
+	}
+	out := new(IP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IP) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPList) DeepCopyInto(out *IPList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IP, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -530,38 +511,46 @@ func (in *IptablesEipStatus) DeepCopyInto(out *IptablesEipStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesEipStatus.
-func (in *IptablesEipStatus) DeepCopy() *IptablesEipStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPList.
+func (in *IPList) DeepCopy() *IPList {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesEipStatus)
+	out := new(IPList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IPList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesFIPRule) DeepCopyInto(out *IptablesFIPRule) {
+func (in *IPPool) DeepCopyInto(out *IPPool) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesFIPRule.
-func (in *IptablesFIPRule) DeepCopy() *IptablesFIPRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPool.
+func (in *IPPool) DeepCopy() *IPPool {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesFIPRule)
+	out := new(IPPool)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IptablesFIPRule) DeepCopyObject() runtime.Object {
+func (in *IPPool) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -569,31 +558,31 @@ func (in *IptablesFIPRule) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesFIPRuleCondition) DeepCopyInto(out *IptablesFIPRuleCondition) {
+func (in *IPPoolCondition) DeepCopyInto(out *IPPoolCondition) {
 	*out = *in
 	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesFIPRuleCondition.
-func (in *IptablesFIPRuleCondition) DeepCopy() *IptablesFIPRuleCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolCondition.
+func (in *IPPoolCondition) DeepCopy() *IPPoolCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesFIPRuleCondition)
+	out := new(IPPoolCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesFIPRuleList) DeepCopyInto(out *IptablesFIPRuleList) {
+func (in *IPPoolList) DeepCopyInto(out *IPPoolList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]IptablesFIPRule, len(*in))
+		*out = make([]IPPool, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -601,18 +590,18 @@ func (in *IptablesFIPRuleList) DeepCopyInto(out *IptablesFIPRuleList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesFIPRuleList.
-func (in *IptablesFIPRuleList) DeepCopy() *IptablesFIPRuleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolList.
+func (in *IPPoolList) DeepCopy() *IPPoolList {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesFIPRuleList)
+	out := new(IPPoolList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IptablesFIPRuleList) DeepCopyObject() runtime.Object {
+func (in *IPPoolList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -620,27 +609,41 @@ func (in *IptablesFIPRuleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesFIPRuleSpec) DeepCopyInto(out *IptablesFIPRuleSpec) {
+func (in *IPPoolSpec) DeepCopyInto(out *IPPoolSpec) {
 	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesFIPRuleSpec.
-func (in *IptablesFIPRuleSpec) DeepCopy() *IptablesFIPRuleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolSpec.
+func (in *IPPoolSpec) DeepCopy() *IPPoolSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesFIPRuleSpec)
+	out := new(IPPoolSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesFIPRuleStatus) DeepCopyInto(out *IptablesFIPRuleStatus) {
+func (in *IPPoolStatus) DeepCopyInto(out *IPPoolStatus) {
 	*out = *in
+	in.V4AvailableIPs.DeepCopyInto(&out.V4AvailableIPs)
+	in.V4UsingIPs.DeepCopyInto(&out.V4UsingIPs)
+	in.V6AvailableIPs.DeepCopyInto(&out.V6AvailableIPs)
+	in.V6UsingIPs.DeepCopyInto(&out.V6UsingIPs)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]IptablesFIPRuleCondition, len(*in))
+		*out = make([]IPPoolCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -648,38 +651,38 @@ func (in *IptablesFIPRuleStatus) DeepCopyInto(out *IptablesFIPRuleStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesFIPRuleStatus.
-func (in *IptablesFIPRuleStatus) DeepCopy() *IptablesFIPRuleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPPoolStatus.
+func (in *IPPoolStatus) DeepCopy() *IPPoolStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesFIPRuleStatus)
+	out := new(IPPoolStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesSnatRule) DeepCopyInto(out *IptablesSnatRule) {
+func (in *IPQuota) DeepCopyInto(out *IPQuota) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesSnatRule.
-func (in *IptablesSnatRule) DeepCopy() *IptablesSnatRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPQuota.
+func (in *IPQuota) DeepCopy() *IPQuota {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesSnatRule)
+	out := new(IPQuota)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IptablesSnatRule) DeepCopyObject() runtime.Object {
+func (in *IPQuota) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -687,31 +690,13 @@ func (in *IptablesSnatRule) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesSnatRuleCondition) DeepCopyInto(out *IptablesSnatRuleCondition) {
-	*out = *in
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesSnatRuleCondition.
-func (in *IptablesSnatRuleCondition) DeepCopy() *IptablesSnatRuleCondition {
-	if in == nil {
-		return nil
-	}
-	out := new(IptablesSnatRuleCondition)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesSnatRuleList) DeepCopyInto(out *IptablesSnatRuleList) {
+func (in *IPQuotaList) DeepCopyInto(out *IPQuotaList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]IptablesSnatRule, len(*in))
+		*out = make([]IPQuota, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -719,18 +704,18 @@ func (in *IptablesSnatRuleList) DeepCopyInto(out *IptablesSnatRuleList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesSnatRuleList.
-func (in *IptablesSnatRuleList) DeepCopy() *IptablesSnatRuleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPQuotaList.
+func (in *IPQuotaList) DeepCopy() *IPQuotaList {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesSnatRuleList)
+	out := new(IPQuotaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IptablesSnatRuleList) DeepCopyObject() runtime.Object {
+func (in *IPQuotaList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -738,96 +723,80 @@ func (in *IptablesSnatRuleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesSnatRuleSpec) DeepCopyInto(out *IptablesSnatRuleSpec) {
+func (in *IPQuotaSpec) DeepCopyInto(out *IPQuotaSpec) {
 	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesSnatRuleSpec.
-func (in *IptablesSnatRuleSpec) DeepCopy() *IptablesSnatRuleSpec {
-	if in == nil {
-		return nil
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	out := new(IptablesSnatRuleSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IptablesSnatRuleStatus) DeepCopyInto(out *IptablesSnatRuleStatus) {
-	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]IptablesSnatRuleCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesSnatRuleStatus.
-func (in *IptablesSnatRuleStatus) DeepCopy() *IptablesSnatRuleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPQuotaSpec.
+func (in *IPQuotaSpec) DeepCopy() *IPQuotaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IptablesSnatRuleStatus)
+	out := new(IPQuotaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatOutGoingPolicyMatch) DeepCopyInto(out *NatOutGoingPolicyMatch) {
+func (in *IPQuotaStatus) DeepCopyInto(out *IPQuotaStatus) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatOutGoingPolicyMatch.
-func (in *NatOutGoingPolicyMatch) DeepCopy() *NatOutGoingPolicyMatch {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPQuotaStatus.
+func (in *IPQuotaStatus) DeepCopy() *IPQuotaStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NatOutGoingPolicyMatch)
+	out := new(IPQuotaStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatOutgoingPolicyRule) DeepCopyInto(out *NatOutgoingPolicyRule) {
+func (in *IPSpec) DeepCopyInto(out *IPSpec) {
 	*out = *in
-	out.Match = in.Match
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatOutgoingPolicyRule.
-func (in *NatOutgoingPolicyRule) DeepCopy() *NatOutgoingPolicyRule {
-	if in == nil {
-		return nil
+	if in.AttachSubnets != nil {
+		in, out := &in.AttachSubnets, &out.AttachSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AttachIPs != nil {
+		in, out := &in.AttachIPs, &out.AttachIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AttachMacs != nil {
+		in, out := &in.AttachMacs, &out.AttachMacs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	out := new(NatOutgoingPolicyRule)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NatOutgoingPolicyRuleStatus) DeepCopyInto(out *NatOutgoingPolicyRuleStatus) {
-	*out = *in
-	out.NatOutgoingPolicyRule = in.NatOutgoingPolicyRule
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatOutgoingPolicyRuleStatus.
-func (in *NatOutgoingPolicyRuleStatus) DeepCopy() *NatOutgoingPolicyRuleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPSpec.
+func (in *IPSpec) DeepCopy() *IPSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NatOutgoingPolicyRuleStatus)
+	out := new(IPSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnDnatRule) DeepCopyInto(out *OvnDnatRule) {
+func (in *IptablesDnatRule) DeepCopyInto(out *IptablesDnatRule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -836,18 +805,18 @@ func (in *OvnDnatRule) DeepCopyInto(out *OvnDnatRule) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnDnatRule.
-func (in *OvnDnatRule) DeepCopy() *OvnDnatRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesDnatRule.
+func (in *IptablesDnatRule) DeepCopy() *IptablesDnatRule {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnDnatRule)
+	out := new(IptablesDnatRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OvnDnatRule) DeepCopyObject() runtime.Object {
+func (in *IptablesDnatRule) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -855,31 +824,31 @@ func (in *OvnDnatRule) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnDnatRuleCondition) DeepCopyInto(out *OvnDnatRuleCondition) {
+func (in *IptablesDnatRuleCondition) DeepCopyInto(out *IptablesDnatRuleCondition) {
 	*out = *in
 	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnDnatRuleCondition.
-func (in *OvnDnatRuleCondition) DeepCopy() *OvnDnatRuleCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesDnatRuleCondition.
+func (in *IptablesDnatRuleCondition) DeepCopy() *IptablesDnatRuleCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnDnatRuleCondition)
+	out := new(IptablesDnatRuleCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnDnatRuleList) DeepCopyInto(out *OvnDnatRuleList) {
+func (in *IptablesDnatRuleList) DeepCopyInto(out *IptablesDnatRuleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]OvnDnatRule, len(*in))
+		*out = make([]IptablesDnatRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -887,18 +856,18 @@ func (in *OvnDnatRuleList) DeepCopyInto(out *OvnDnatRuleList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnDnatRuleList.
-func (in *OvnDnatRuleList) DeepCopy() *OvnDnatRuleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesDnatRuleList.
+func (in *IptablesDnatRuleList) DeepCopy() *IptablesDnatRuleList {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnDnatRuleList)
+	out := new(IptablesDnatRuleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OvnDnatRuleList) DeepCopyObject() runtime.Object {
+func (in *IptablesDnatRuleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -906,27 +875,27 @@ func (in *OvnDnatRuleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnDnatRuleSpec) DeepCopyInto(out *OvnDnatRuleSpec) {
+func (in *IptablesDnatRuleSpec) DeepCopyInto(out *IptablesDnatRuleSpec) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnDnatRuleSpec.
-func (in *OvnDnatRuleSpec) DeepCopy() *OvnDnatRuleSpec {
-	if in == nil {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesDnatRuleSpec.
+func (in *IptablesDnatRuleSpec) DeepCopy() *IptablesDnatRuleSpec {
+	if in == nil {
 		return nil
 	}
-	out := new(OvnDnatRuleSpec)
+	out := new(IptablesDnatRuleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnDnatRuleStatus) DeepCopyInto(out *OvnDnatRuleStatus) {
+func (in *IptablesDnatRuleStatus) DeepCopyInto(out *IptablesDnatRuleStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]OvnDnatRuleCondition, len(*in))
+		*out = make([]IptablesDnatRuleCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -934,18 +903,18 @@ func (in *OvnDnatRuleStatus) DeepCopyInto(out *OvnDnatRuleStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnDnatRuleStatus.
-func (in *OvnDnatRuleStatus) DeepCopy() *OvnDnatRuleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesDnatRuleStatus.
+func (in *IptablesDnatRuleStatus) DeepCopy() *IptablesDnatRuleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnDnatRuleStatus)
+	out := new(IptablesDnatRuleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnEip) DeepCopyInto(out *OvnEip) {
+func (in *IptablesEIP) DeepCopyInto(out *IptablesEIP) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -954,18 +923,18 @@ func (in *OvnEip) DeepCopyInto(out *OvnEip) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnEip.
-func (in *OvnEip) DeepCopy() *OvnEip {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesEIP.
+func (in *IptablesEIP) DeepCopy() *IptablesEIP {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnEip)
+	out := new(IptablesEIP)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OvnEip) DeepCopyObject() runtime.Object {
+func (in *IptablesEIP) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -973,31 +942,31 @@ func (in *OvnEip) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnEipCondition) DeepCopyInto(out *OvnEipCondition) {
+func (in *IptablesEIPCondition) DeepCopyInto(out *IptablesEIPCondition) {
 	*out = *in
 	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnEipCondition.
-func (in *OvnEipCondition) DeepCopy() *OvnEipCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesEIPCondition.
+func (in *IptablesEIPCondition) DeepCopy() *IptablesEIPCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnEipCondition)
+	out := new(IptablesEIPCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnEipList) DeepCopyInto(out *OvnEipList) {
+func (in *IptablesEIPList) DeepCopyInto(out *IptablesEIPList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]OvnEip, len(*in))
+		*out = make([]IptablesEIP, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1005,18 +974,18 @@ func (in *OvnEipList) DeepCopyInto(out *OvnEipList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnEipList.
-func (in *OvnEipList) DeepCopy() *OvnEipList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesEIPList.
+func (in *IptablesEIPList) DeepCopy() *IptablesEIPList {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnEipList)
+	out := new(IptablesEIPList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OvnEipList) DeepCopyObject() runtime.Object {
+func (in *IptablesEIPList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1024,27 +993,27 @@ func (in *OvnEipList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnEipSpec) DeepCopyInto(out *OvnEipSpec) {
+func (in *IptablesEipSpec) DeepCopyInto(out *IptablesEipSpec) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnEipSpec.
-func (in *OvnEipSpec) DeepCopy() *OvnEipSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesEipSpec.
+func (in *IptablesEipSpec) DeepCopy() *IptablesEipSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnEipSpec)
+	out := new(IptablesEipSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnEipStatus) DeepCopyInto(out *OvnEipStatus) {
+func (in *IptablesEipStatus) DeepCopyInto(out *IptablesEipStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]OvnEipCondition, len(*in))
+		*out = make([]IptablesEIPCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1052,18 +1021,18 @@ func (in *OvnEipStatus) DeepCopyInto(out *OvnEipStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnEipStatus.
-func (in *OvnEipStatus) DeepCopy() *OvnEipStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesEipStatus.
+func (in *IptablesEipStatus) DeepCopy() *IptablesEipStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnEipStatus)
+	out := new(IptablesEipStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnFip) DeepCopyInto(out *OvnFip) {
+func (in *IptablesFIPRule) DeepCopyInto(out *IptablesFIPRule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1072,18 +1041,18 @@ func (in *OvnFip) DeepCopyInto(out *OvnFip) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnFip.
-func (in *OvnFip) DeepCopy() *OvnFip {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesFIPRule.
+func (in *IptablesFIPRule) DeepCopy() *IptablesFIPRule {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnFip)
+	out := new(IptablesFIPRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OvnFip) DeepCopyObject() runtime.Object {
+func (in *IptablesFIPRule) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1091,31 +1060,31 @@ func (in *OvnFip) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnFipCondition) DeepCopyInto(out *OvnFipCondition) {
+func (in *IptablesFIPRuleCondition) DeepCopyInto(out *IptablesFIPRuleCondition) {
 	*out = *in
 	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnFipCondition.
-func (in *OvnFipCondition) DeepCopy() *OvnFipCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesFIPRuleCondition.
+func (in *IptablesFIPRuleCondition) DeepCopy() *IptablesFIPRuleCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnFipCondition)
+	out := new(IptablesFIPRuleCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnFipList) DeepCopyInto(out *OvnFipList) {
+func (in *IptablesFIPRuleList) DeepCopyInto(out *IptablesFIPRuleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]OvnFip, len(*in))
+		*out = make([]IptablesFIPRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1123,18 +1092,18 @@ func (in *OvnFipList) DeepCopyInto(out *OvnFipList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnFipList.
-func (in *OvnFipList) DeepCopy() *OvnFipList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesFIPRuleList.
+func (in *IptablesFIPRuleList) DeepCopy() *IptablesFIPRuleList {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnFipList)
+	out := new(IptablesFIPRuleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OvnFipList) DeepCopyObject() runtime.Object {
+func (in *IptablesFIPRuleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1142,27 +1111,27 @@ func (in *OvnFipList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnFipSpec) DeepCopyInto(out *OvnFipSpec) {
+func (in *IptablesFIPRuleSpec) DeepCopyInto(out *IptablesFIPRuleSpec) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnFipSpec.
-func (in *OvnFipSpec) DeepCopy() *OvnFipSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesFIPRuleSpec.
+func (in *IptablesFIPRuleSpec) DeepCopy() *IptablesFIPRuleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnFipSpec)
+	out := new(IptablesFIPRuleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnFipStatus) DeepCopyInto(out *OvnFipStatus) {
+func (in *IptablesFIPRuleStatus) DeepCopyInto(out *IptablesFIPRuleStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]OvnFipCondition, len(*in))
+		*out = make([]IptablesFIPRuleCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1170,18 +1139,18 @@ func (in *OvnFipStatus) DeepCopyInto(out *OvnFipStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnFipStatus.
-func (in *OvnFipStatus) DeepCopy() *OvnFipStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesFIPRuleStatus.
+func (in *IptablesFIPRuleStatus) DeepCopy() *IptablesFIPRuleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnFipStatus)
+	out := new(IptablesFIPRuleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnSnatRule) DeepCopyInto(out *OvnSnatRule) {
+func (in *IptablesSnatRule) DeepCopyInto(out *IptablesSnatRule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1190,18 +1159,18 @@ func (in *OvnSnatRule) DeepCopyInto(out *OvnSnatRule) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnSnatRule.
-func (in *OvnSnatRule) DeepCopy() *OvnSnatRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesSnatRule.
+func (in *IptablesSnatRule) DeepCopy() *IptablesSnatRule {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnSnatRule)
+	out := new(IptablesSnatRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OvnSnatRule) DeepCopyObject() runtime.Object {
+func (in *IptablesSnatRule) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1209,31 +1178,31 @@ func (in *OvnSnatRule) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnSnatRuleCondition) DeepCopyInto(out *OvnSnatRuleCondition) {
+func (in *IptablesSnatRuleCondition) DeepCopyInto(out *IptablesSnatRuleCondition) {
 	*out = *in
 	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnSnatRuleCondition.
-func (in *OvnSnatRuleCondition) DeepCopy() *OvnSnatRuleCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesSnatRuleCondition.
+func (in *IptablesSnatRuleCondition) DeepCopy() *IptablesSnatRuleCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnSnatRuleCondition)
+	out := new(IptablesSnatRuleCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnSnatRuleList) DeepCopyInto(out *OvnSnatRuleList) {
+func (in *IptablesSnatRuleList) DeepCopyInto(out *IptablesSnatRuleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]OvnSnatRule, len(*in))
+		*out = make([]IptablesSnatRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1241,18 +1210,18 @@ func (in *OvnSnatRuleList) DeepCopyInto(out *OvnSnatRuleList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnSnatRuleList.
-func (in *OvnSnatRuleList) DeepCopy() *OvnSnatRuleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesSnatRuleList.
+func (in *IptablesSnatRuleList) DeepCopy() *IptablesSnatRuleList {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnSnatRuleList)
+	out := new(IptablesSnatRuleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OvnSnatRuleList) DeepCopyObject() runtime.Object {
+func (in *IptablesSnatRuleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1260,27 +1229,27 @@ func (in *OvnSnatRuleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnSnatRuleSpec) DeepCopyInto(out *OvnSnatRuleSpec) {
+func (in *IptablesSnatRuleSpec) DeepCopyInto(out *IptablesSnatRuleSpec) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnSnatRuleSpec.
-func (in *OvnSnatRuleSpec) DeepCopy() *OvnSnatRuleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesSnatRuleSpec.
+func (in *IptablesSnatRuleSpec) DeepCopy() *IptablesSnatRuleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnSnatRuleSpec)
+	out := new(IptablesSnatRuleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OvnSnatRuleStatus) DeepCopyInto(out *OvnSnatRuleStatus) {
+func (in *IptablesSnatRuleStatus) DeepCopyInto(out *IptablesSnatRuleStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]OvnSnatRuleCondition, len(*in))
+		*out = make([]IptablesSnatRuleCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1288,54 +1257,88 @@ func (in *OvnSnatRuleStatus) DeepCopyInto(out *OvnSnatRuleStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnSnatRuleStatus.
-func (in *OvnSnatRuleStatus) DeepCopy() *OvnSnatRuleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IptablesSnatRuleStatus.
+func (in *IptablesSnatRuleStatus) DeepCopy() *IptablesSnatRuleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OvnSnatRuleStatus)
+	out := new(IptablesSnatRuleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicyRoute) DeepCopyInto(out *PolicyRoute) {
+func (in *NatOutGoingPolicyMatch) DeepCopyInto(out *NatOutGoingPolicyMatch) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRoute.
-func (in *PolicyRoute) DeepCopy() *PolicyRoute {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatOutGoingPolicyMatch.
+func (in *NatOutGoingPolicyMatch) DeepCopy() *NatOutGoingPolicyMatch {
 	if in == nil {
 		return nil
 	}
-	out := new(PolicyRoute)
+	out := new(NatOutGoingPolicyMatch)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderNetwork) DeepCopyInto(out *ProviderNetwork) {
+func (in *NatOutgoingPolicyRule) DeepCopyInto(out *NatOutgoingPolicyRule) {
+	*out = *in
+	out.Match = in.Match
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatOutgoingPolicyRule.
+func (in *NatOutgoingPolicyRule) DeepCopy() *NatOutgoingPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NatOutgoingPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NatOutgoingPolicyRuleStatus) DeepCopyInto(out *NatOutgoingPolicyRuleStatus) {
+	*out = *in
+	out.NatOutgoingPolicyRule = in.NatOutgoingPolicyRule
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NatOutgoingPolicyRuleStatus.
+func (in *NatOutgoingPolicyRuleStatus) DeepCopy() *NatOutgoingPolicyRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NatOutgoingPolicyRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OvnDnatRule) DeepCopyInto(out *OvnDnatRule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderNetwork.
-func (in *ProviderNetwork) DeepCopy() *ProviderNetwork {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnDnatRule.
+func (in *OvnDnatRule) DeepCopy() *OvnDnatRule {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderNetwork)
+	out := new(OvnDnatRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProviderNetwork) DeepCopyObject() runtime.Object {
+func (in *OvnDnatRule) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1343,30 +1346,31 @@ func (in *ProviderNetwork) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderNetworkCondition) DeepCopyInto(out *ProviderNetworkCondition) {
+func (in *OvnDnatRuleCondition) DeepCopyInto(out *OvnDnatRuleCondition) {
 	*out = *in
-	in.Condition.DeepCopyInto(&out.Condition)
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderNetworkCondition.
-func (in *ProviderNetworkCondition) DeepCopy() *ProviderNetworkCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnDnatRuleCondition.
+func (in *OvnDnatRuleCondition) DeepCopy() *OvnDnatRuleCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderNetworkCondition)
+	out := new(OvnDnatRuleCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderNetworkList) DeepCopyInto(out *ProviderNetworkList) {
+func (in *OvnDnatRuleList) DeepCopyInto(out *OvnDnatRuleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ProviderNetwork, len(*in))
+		*out = make([]OvnDnatRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1374,18 +1378,18 @@ func (in *ProviderNetworkList) DeepCopyInto(out *ProviderNetworkList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderNetworkList.
-func (in *ProviderNetworkList) DeepCopy() *ProviderNetworkList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnDnatRuleList.
+func (in *OvnDnatRuleList) DeepCopy() *OvnDnatRuleList {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderNetworkList)
+	out := new(OvnDnatRuleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProviderNetworkList) DeepCopyObject() runtime.Object {
+func (in *OvnDnatRuleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1393,54 +1397,27 @@ func (in *ProviderNetworkList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderNetworkSpec) DeepCopyInto(out *ProviderNetworkSpec) {
+func (in *OvnDnatRuleSpec) DeepCopyInto(out *OvnDnatRuleSpec) {
 	*out = *in
-	if in.CustomInterfaces != nil {
-		in, out := &in.CustomInterfaces, &out.CustomInterfaces
-		*out = make([]CustomInterface, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ExcludeNodes != nil {
-		in, out := &in.ExcludeNodes, &out.ExcludeNodes
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderNetworkSpec.
-func (in *ProviderNetworkSpec) DeepCopy() *ProviderNetworkSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnDnatRuleSpec.
+func (in *OvnDnatRuleSpec) DeepCopy() *OvnDnatRuleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderNetworkSpec)
+	out := new(OvnDnatRuleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderNetworkStatus) DeepCopyInto(out *ProviderNetworkStatus) {
+func (in *OvnDnatRuleStatus) DeepCopyInto(out *OvnDnatRuleStatus) {
 	*out = *in
-	if in.ReadyNodes != nil {
-		in, out := &in.ReadyNodes, &out.ReadyNodes
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.NotReadyNodes != nil {
-		in, out := &in.NotReadyNodes, &out.NotReadyNodes
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Vlans != nil {
-		in, out := &in.Vlans, &out.Vlans
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ProviderNetworkCondition, len(*in))
+		*out = make([]OvnDnatRuleCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1448,38 +1425,38 @@ func (in *ProviderNetworkStatus) DeepCopyInto(out *ProviderNetworkStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderNetworkStatus.
-func (in *ProviderNetworkStatus) DeepCopy() *ProviderNetworkStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnDnatRuleStatus.
+func (in *OvnDnatRuleStatus) DeepCopy() *OvnDnatRuleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderNetworkStatus)
+	out := new(OvnDnatRuleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QoSPolicy) DeepCopyInto(out *QoSPolicy) {
+func (in *OvnEip) DeepCopyInto(out *OvnEip) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicy.
-func (in *QoSPolicy) DeepCopy() *QoSPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnEip.
+func (in *OvnEip) DeepCopy() *OvnEip {
 	if in == nil {
 		return nil
 	}
-	out := new(QoSPolicy)
+	out := new(OvnEip)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *QoSPolicy) DeepCopyObject() runtime.Object {
+func (in *OvnEip) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1487,73 +1464,31 @@ func (in *QoSPolicy) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QoSPolicyBandwidthLimitRule) DeepCopyInto(out *QoSPolicyBandwidthLimitRule) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicyBandwidthLimitRule.
-func (in *QoSPolicyBandwidthLimitRule) DeepCopy() *QoSPolicyBandwidthLimitRule {
-	if in == nil {
-		return nil
-	}
-	out := new(QoSPolicyBandwidthLimitRule)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in QoSPolicyBandwidthLimitRules) DeepCopyInto(out *QoSPolicyBandwidthLimitRules) {
-	{
-		in := &in
-		*out = make(QoSPolicyBandwidthLimitRules, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(QoSPolicyBandwidthLimitRule)
-				**out = **in
-			}
-		}
-		return
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicyBandwidthLimitRules.
-func (in QoSPolicyBandwidthLimitRules) DeepCopy() QoSPolicyBandwidthLimitRules {
-	if in == nil {
-		return nil
-	}
-	out := new(QoSPolicyBandwidthLimitRules)
-	in.DeepCopyInto(out)
-	return *out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QoSPolicyCondition) DeepCopyInto(out *QoSPolicyCondition) {
+func (in *OvnEipCondition) DeepCopyInto(out *OvnEipCondition) {
 	*out = *in
 	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicyCondition.
-func (in *QoSPolicyCondition) DeepCopy() *QoSPolicyCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnEipCondition.
+func (in *OvnEipCondition) DeepCopy() *OvnEipCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(QoSPolicyCondition)
+	out := new(OvnEipCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QoSPolicyList) DeepCopyInto(out *QoSPolicyList) {
+func (in *OvnEipList) DeepCopyInto(out *OvnEipList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]QoSPolicy, len(*in))
+		*out = make([]OvnEip, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1561,18 +1496,18 @@ func (in *QoSPolicyList) DeepCopyInto(out *QoSPolicyList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicyList.
-func (in *QoSPolicyList) DeepCopy() *QoSPolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnEipList.
+func (in *OvnEipList) DeepCopy() *OvnEipList {
 	if in == nil {
 		return nil
 	}
-	out := new(QoSPolicyList)
+	out := new(OvnEipList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *QoSPolicyList) DeepCopyObject() runtime.Object {
+func (in *OvnEipList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1580,49 +1515,27 @@ func (in *QoSPolicyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QoSPolicySpec) DeepCopyInto(out *QoSPolicySpec) {
+func (in *OvnEipSpec) DeepCopyInto(out *OvnEipSpec) {
 	*out = *in
-	if in.BandwidthLimitRules != nil {
-		in, out := &in.BandwidthLimitRules, &out.BandwidthLimitRules
-		*out = make(QoSPolicyBandwidthLimitRules, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(QoSPolicyBandwidthLimitRule)
-				**out = **in
-			}
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicySpec.
-func (in *QoSPolicySpec) DeepCopy() *QoSPolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnEipSpec.
+func (in *OvnEipSpec) DeepCopy() *OvnEipSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(QoSPolicySpec)
+	out := new(OvnEipSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QoSPolicyStatus) DeepCopyInto(out *QoSPolicyStatus) {
+func (in *OvnEipStatus) DeepCopyInto(out *OvnEipStatus) {
 	*out = *in
-	if in.BandwidthLimitRules != nil {
-		in, out := &in.BandwidthLimitRules, &out.BandwidthLimitRules
-		*out = make(QoSPolicyBandwidthLimitRules, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(QoSPolicyBandwidthLimitRule)
-				**out = **in
-			}
-		}
-	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]QoSPolicyCondition, len(*in))
+		*out = make([]OvnEipCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1630,38 +1543,38 @@ func (in *QoSPolicyStatus) DeepCopyInto(out *QoSPolicyStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicyStatus.
-func (in *QoSPolicyStatus) DeepCopy() *QoSPolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnEipStatus.
+func (in *OvnEipStatus) DeepCopy() *OvnEipStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(QoSPolicyStatus)
+	out := new(OvnEipStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
+func (in *OvnFip) DeepCopyInto(out *OvnFip) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroup.
-func (in *SecurityGroup) DeepCopy() *SecurityGroup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnFip.
+func (in *OvnFip) DeepCopy() *OvnFip {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityGroup)
+	out := new(OvnFip)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SecurityGroup) DeepCopyObject() runtime.Object {
+func (in *OvnFip) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1669,13 +1582,31 @@ func (in *SecurityGroup) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityGroupList) DeepCopyInto(out *SecurityGroupList) {
+func (in *OvnFipCondition) DeepCopyInto(out *OvnFipCondition) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnFipCondition.
+func (in *OvnFipCondition) DeepCopy() *OvnFipCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(OvnFipCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OvnFipList) DeepCopyInto(out *OvnFipList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SecurityGroup, len(*in))
+		*out = make([]OvnFip, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1683,18 +1614,18 @@ func (in *SecurityGroupList) DeepCopyInto(out *SecurityGroupList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupList.
-func (in *SecurityGroupList) DeepCopy() *SecurityGroupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnFipList.
+func (in *OvnFipList) DeepCopy() *OvnFipList {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityGroupList)
+	out := new(OvnFipList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SecurityGroupList) DeepCopyObject() runtime.Object {
+func (in *OvnFipList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1702,129 +1633,117 @@ func (in *SecurityGroupList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityGroupSpec) DeepCopyInto(out *SecurityGroupSpec) {
+func (in *OvnFipSpec) DeepCopyInto(out *OvnFipSpec) {
 	*out = *in
-	if in.IngressRules != nil {
-		in, out := &in.IngressRules, &out.IngressRules
-		*out = make([]*SgRule, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(SgRule)
-				**out = **in
-			}
-		}
-	}
-	if in.EgressRules != nil {
-		in, out := &in.EgressRules, &out.EgressRules
-		*out = make([]*SgRule, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(SgRule)
-				**out = **in
-			}
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupSpec.
-func (in *SecurityGroupSpec) DeepCopy() *SecurityGroupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnFipSpec.
+func (in *OvnFipSpec) DeepCopy() *OvnFipSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityGroupSpec)
+	out := new(OvnFipSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityGroupStatus) DeepCopyInto(out *SecurityGroupStatus) {
+func (in *OvnFipStatus) DeepCopyInto(out *OvnFipStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]OvnFipCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupStatus.
-func (in *SecurityGroupStatus) DeepCopy() *SecurityGroupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnFipStatus.
+func (in *OvnFipStatus) DeepCopy() *OvnFipStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityGroupStatus)
+	out := new(OvnFipStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SgRule) DeepCopyInto(out *SgRule) {
+func (in *OvnSnatRule) DeepCopyInto(out *OvnSnatRule) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SgRule.
-func (in *SgRule) DeepCopy() *SgRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnSnatRule.
+func (in *OvnSnatRule) DeepCopy() *OvnSnatRule {
 	if in == nil {
 		return nil
 	}
-	out := new(SgRule)
+	out := new(OvnSnatRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OvnSnatRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SlrPort) DeepCopyInto(out *SlrPort) {
+func (in *OvnSnatRuleCondition) DeepCopyInto(out *OvnSnatRuleCondition) {
 	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlrPort.
-func (in *SlrPort) DeepCopy() *SlrPort {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnSnatRuleCondition.
+func (in *OvnSnatRuleCondition) DeepCopy() *OvnSnatRuleCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(SlrPort)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StaticRoute) DeepCopyInto(out *StaticRoute) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticRoute.
-func (in *StaticRoute) DeepCopy() *StaticRoute {
-	if in == nil {
-		return nil
-	}
-	out := new(StaticRoute)
+	out := new(OvnSnatRuleCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Subnet) DeepCopyInto(out *Subnet) {
+func (in *OvnSnatRuleList) DeepCopyInto(out *OvnSnatRuleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OvnSnatRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subnet.
-func (in *Subnet) DeepCopy() *Subnet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnSnatRuleList.
+func (in *OvnSnatRuleList) DeepCopy() *OvnSnatRuleList {
 	if in == nil {
 		return nil
 	}
-	out := new(Subnet)
+	out := new(OvnSnatRuleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Subnet) DeepCopyObject() runtime.Object {
+func (in *OvnSnatRuleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1832,31 +1751,27 @@ func (in *Subnet) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SubnetCondition) DeepCopyInto(out *SubnetCondition) {
+func (in *OvnSnatRuleSpec) DeepCopyInto(out *OvnSnatRuleSpec) {
 	*out = *in
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetCondition.
-func (in *SubnetCondition) DeepCopy() *SubnetCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnSnatRuleSpec.
+func (in *OvnSnatRuleSpec) DeepCopy() *OvnSnatRuleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SubnetCondition)
+	out := new(OvnSnatRuleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SubnetList) DeepCopyInto(out *SubnetList) {
+func (in *OvnSnatRuleStatus) DeepCopyInto(out *OvnSnatRuleStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Subnet, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]OvnSnatRuleCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1864,105 +1779,34 @@ func (in *SubnetList) DeepCopyInto(out *SubnetList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetList.
-func (in *SubnetList) DeepCopy() *SubnetList {
-	if in == nil {
-		return nil
-	}
-	out := new(SubnetList)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SubnetList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
-	*out = *in
-	if in.Namespaces != nil {
-		in, out := &in.Namespaces, &out.Namespaces
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ExcludeIps != nil {
-		in, out := &in.ExcludeIps, &out.ExcludeIps
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.AllowSubnets != nil {
-		in, out := &in.AllowSubnets, &out.AllowSubnets
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Vips != nil {
-		in, out := &in.Vips, &out.Vips
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Acls != nil {
-		in, out := &in.Acls, &out.Acls
-		*out = make([]ACL, len(*in))
-		copy(*out, *in)
-	}
-	if in.NatOutgoingPolicyRules != nil {
-		in, out := &in.NatOutgoingPolicyRules, &out.NatOutgoingPolicyRules
-		*out = make([]NatOutgoingPolicyRule, len(*in))
-		copy(*out, *in)
-	}
-	if in.EnableLb != nil {
-		in, out := &in.EnableLb, &out.EnableLb
-		*out = new(bool)
-		**out = **in
-	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSpec.
-func (in *SubnetSpec) DeepCopy() *SubnetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnSnatRuleStatus.
+func (in *OvnSnatRuleStatus) DeepCopy() *OvnSnatRuleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SubnetSpec)
+	out := new(OvnSnatRuleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SubnetStatus) DeepCopyInto(out *SubnetStatus) {
+func (in *PolicyRoute) DeepCopyInto(out *PolicyRoute) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]SubnetCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.NatOutgoingPolicyRules != nil {
-		in, out := &in.NatOutgoingPolicyRules, &out.NatOutgoingPolicyRules
-		*out = make([]NatOutgoingPolicyRuleStatus, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetStatus.
-func (in *SubnetStatus) DeepCopy() *SubnetStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyRoute.
+func (in *PolicyRoute) DeepCopy() *PolicyRoute {
 	if in == nil {
 		return nil
 	}
-	out := new(SubnetStatus)
+	out := new(PolicyRoute)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwitchLBRule) DeepCopyInto(out *SwitchLBRule) {
+func (in *ProviderNetwork) DeepCopyInto(out *ProviderNetwork) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1971,18 +1815,18 @@ func (in *SwitchLBRule) DeepCopyInto(out *SwitchLBRule) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchLBRule.
-func (in *SwitchLBRule) DeepCopy() *SwitchLBRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderNetwork.
+func (in *ProviderNetwork) DeepCopy() *ProviderNetwork {
 	if in == nil {
 		return nil
 	}
-	out := new(SwitchLBRule)
+	out := new(ProviderNetwork)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SwitchLBRule) DeepCopyObject() runtime.Object {
+func (in *ProviderNetwork) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1990,31 +1834,30 @@ func (in *SwitchLBRule) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwitchLBRuleCondition) DeepCopyInto(out *SwitchLBRuleCondition) {
+func (in *ProviderNetworkCondition) DeepCopyInto(out *ProviderNetworkCondition) {
 	*out = *in
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.Condition.DeepCopyInto(&out.Condition)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchLBRuleCondition.
-func (in *SwitchLBRuleCondition) DeepCopy() *SwitchLBRuleCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderNetworkCondition.
+func (in *ProviderNetworkCondition) DeepCopy() *ProviderNetworkCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(SwitchLBRuleCondition)
+	out := new(ProviderNetworkCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwitchLBRuleList) DeepCopyInto(out *SwitchLBRuleList) {
+func (in *ProviderNetworkList) DeepCopyInto(out *ProviderNetworkList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SwitchLBRule, len(*in))
+		*out = make([]ProviderNetwork, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2022,18 +1865,18 @@ func (in *SwitchLBRuleList) DeepCopyInto(out *SwitchLBRuleList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchLBRuleList.
-func (in *SwitchLBRuleList) DeepCopy() *SwitchLBRuleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderNetworkList.
+func (in *ProviderNetworkList) DeepCopy() *ProviderNetworkList {
 	if in == nil {
 		return nil
 	}
-	out := new(SwitchLBRuleList)
+	out := new(ProviderNetworkList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SwitchLBRuleList) DeepCopyObject() runtime.Object {
+func (in *ProviderNetworkList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2041,42 +1884,54 @@ func (in *SwitchLBRuleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwitchLBRuleSpec) DeepCopyInto(out *SwitchLBRuleSpec) {
+func (in *ProviderNetworkSpec) DeepCopyInto(out *ProviderNetworkSpec) {
 	*out = *in
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.CustomInterfaces != nil {
+		in, out := &in.CustomInterfaces, &out.CustomInterfaces
+		*out = make([]CustomInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Endpoints != nil {
-		in, out := &in.Endpoints, &out.Endpoints
+	if in.ExcludeNodes != nil {
+		in, out := &in.ExcludeNodes, &out.ExcludeNodes
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Ports != nil {
-		in, out := &in.Ports, &out.Ports
-		*out = make([]SlrPort, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchLBRuleSpec.
-func (in *SwitchLBRuleSpec) DeepCopy() *SwitchLBRuleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderNetworkSpec.
+func (in *ProviderNetworkSpec) DeepCopy() *ProviderNetworkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SwitchLBRuleSpec)
+	out := new(ProviderNetworkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SwitchLBRuleStatus) DeepCopyInto(out *SwitchLBRuleStatus) {
+func (in *ProviderNetworkStatus) DeepCopyInto(out *ProviderNetworkStatus) {
 	*out = *in
+	if in.ReadyNodes != nil {
+		in, out := &in.ReadyNodes, &out.ReadyNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotReadyNodes != nil {
+		in, out := &in.NotReadyNodes, &out.NotReadyNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Vlans != nil {
+		in, out := &in.Vlans, &out.Vlans
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]SwitchLBRuleCondition, len(*in))
+		*out = make([]ProviderNetworkCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2084,18 +1939,18 @@ func (in *SwitchLBRuleStatus) DeepCopyInto(out *SwitchLBRuleStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchLBRuleStatus.
-func (in *SwitchLBRuleStatus) DeepCopy() *SwitchLBRuleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderNetworkStatus.
+func (in *ProviderNetworkStatus) DeepCopy() *ProviderNetworkStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SwitchLBRuleStatus)
+	out := new(ProviderNetworkStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Vip) DeepCopyInto(out *Vip) {
+func (in *QoSPolicy) DeepCopyInto(out *QoSPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -2104,18 +1959,18 @@ func (in *Vip) DeepCopyInto(out *Vip) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vip.
-func (in *Vip) DeepCopy() *Vip {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicy.
+func (in *QoSPolicy) DeepCopy() *QoSPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(Vip)
+	out := new(QoSPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Vip) DeepCopyObject() runtime.Object {
+func (in *QoSPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2123,31 +1978,73 @@ func (in *Vip) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VipCondition) DeepCopyInto(out *VipCondition) {
+func (in *QoSPolicyBandwidthLimitRule) DeepCopyInto(out *QoSPolicyBandwidthLimitRule) {
 	*out = *in
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VipCondition.
-func (in *VipCondition) DeepCopy() *VipCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicyBandwidthLimitRule.
+func (in *QoSPolicyBandwidthLimitRule) DeepCopy() *QoSPolicyBandwidthLimitRule {
 	if in == nil {
 		return nil
 	}
-	out := new(VipCondition)
+	out := new(QoSPolicyBandwidthLimitRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VipList) DeepCopyInto(out *VipList) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Vip, len(*in))
+func (in QoSPolicyBandwidthLimitRules) DeepCopyInto(out *QoSPolicyBandwidthLimitRules) {
+	{
+		in := &in
+		*out = make(QoSPolicyBandwidthLimitRules, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(QoSPolicyBandwidthLimitRule)
+				**out = **in
+			}
+		}
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicyBandwidthLimitRules.
+func (in QoSPolicyBandwidthLimitRules) DeepCopy() QoSPolicyBandwidthLimitRules {
+	if in == nil {
+		return nil
+	}
+	out := new(QoSPolicyBandwidthLimitRules)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QoSPolicyCondition) DeepCopyInto(out *QoSPolicyCondition) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicyCondition.
+func (in *QoSPolicyCondition) DeepCopy() *QoSPolicyCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(QoSPolicyCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QoSPolicyList) DeepCopyInto(out *QoSPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]QoSPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2155,18 +2052,18 @@ func (in *VipList) DeepCopyInto(out *VipList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VipList.
-func (in *VipList) DeepCopy() *VipList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicyList.
+func (in *QoSPolicyList) DeepCopy() *QoSPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(VipList)
+	out := new(QoSPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VipList) DeepCopyObject() runtime.Object {
+func (in *QoSPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2174,32 +2071,49 @@ func (in *VipList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VipSpec) DeepCopyInto(out *VipSpec) {
+func (in *QoSPolicySpec) DeepCopyInto(out *QoSPolicySpec) {
 	*out = *in
-	if in.AttachSubnets != nil {
-		in, out := &in.AttachSubnets, &out.AttachSubnets
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.BandwidthLimitRules != nil {
+		in, out := &in.BandwidthLimitRules, &out.BandwidthLimitRules
+		*out = make(QoSPolicyBandwidthLimitRules, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(QoSPolicyBandwidthLimitRule)
+				**out = **in
+			}
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VipSpec.
-func (in *VipSpec) DeepCopy() *VipSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicySpec.
+func (in *QoSPolicySpec) DeepCopy() *QoSPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VipSpec)
+	out := new(QoSPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VipStatus) DeepCopyInto(out *VipStatus) {
+func (in *QoSPolicyStatus) DeepCopyInto(out *QoSPolicyStatus) {
 	*out = *in
+	if in.BandwidthLimitRules != nil {
+		in, out := &in.BandwidthLimitRules, &out.BandwidthLimitRules
+		*out = make(QoSPolicyBandwidthLimitRules, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(QoSPolicyBandwidthLimitRule)
+				**out = **in
+			}
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]VipCondition, len(*in))
+		*out = make([]QoSPolicyCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2207,38 +2121,38 @@ func (in *VipStatus) DeepCopyInto(out *VipStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VipStatus.
-func (in *VipStatus) DeepCopy() *VipStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QoSPolicyStatus.
+func (in *QoSPolicyStatus) DeepCopy() *QoSPolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VipStatus)
+	out := new(QoSPolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Vlan) DeepCopyInto(out *Vlan) {
+func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vlan.
-func (in *Vlan) DeepCopy() *Vlan {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroup.
+func (in *SecurityGroup) DeepCopy() *SecurityGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(Vlan)
+	out := new(SecurityGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Vlan) DeepCopyObject() runtime.Object {
+func (in *SecurityGroup) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2246,31 +2160,13 @@ func (in *Vlan) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VlanCondition) DeepCopyInto(out *VlanCondition) {
-	*out = *in
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VlanCondition.
-func (in *VlanCondition) DeepCopy() *VlanCondition {
-	if in == nil {
-		return nil
-	}
-	out := new(VlanCondition)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VlanList) DeepCopyInto(out *VlanList) {
+func (in *SecurityGroupList) DeepCopyInto(out *SecurityGroupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Vlan, len(*in))
+		*out = make([]SecurityGroup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2278,18 +2174,18 @@ func (in *VlanList) DeepCopyInto(out *VlanList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VlanList.
-func (in *VlanList) DeepCopy() *VlanList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupList.
+func (in *SecurityGroupList) DeepCopy() *SecurityGroupList {
 	if in == nil {
 		return nil
 	}
-	out := new(VlanList)
+	out := new(SecurityGroupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VlanList) DeepCopyObject() runtime.Object {
+func (in *SecurityGroupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2297,168 +2193,160 @@ func (in *VlanList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VlanSpec) DeepCopyInto(out *VlanSpec) {
+func (in *SecurityGroupSpec) DeepCopyInto(out *SecurityGroupSpec) {
 	*out = *in
+	if in.IngressRules != nil {
+		in, out := &in.IngressRules, &out.IngressRules
+		*out = make([]*SgRule, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(SgRule)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.EgressRules != nil {
+		in, out := &in.EgressRules, &out.EgressRules
+		*out = make([]*SgRule, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(SgRule)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VlanSpec.
-func (in *VlanSpec) DeepCopy() *VlanSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupSpec.
+func (in *SecurityGroupSpec) DeepCopy() *SecurityGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VlanSpec)
+	out := new(SecurityGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VlanStatus) DeepCopyInto(out *VlanStatus) {
+func (in *SecurityGroupStatus) DeepCopyInto(out *SecurityGroupStatus) {
 	*out = *in
-	if in.Subnets != nil {
-		in, out := &in.Subnets, &out.Subnets
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]VlanCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VlanStatus.
-func (in *VlanStatus) DeepCopy() *VlanStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupStatus.
+func (in *SecurityGroupStatus) DeepCopy() *SecurityGroupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VlanStatus)
+	out := new(SecurityGroupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Vpc) DeepCopyInto(out *Vpc) {
+func (in *SgPortRange) DeepCopyInto(out *SgPortRange) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vpc.
-func (in *Vpc) DeepCopy() *Vpc {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SgPortRange.
+func (in *SgPortRange) DeepCopy() *SgPortRange {
 	if in == nil {
 		return nil
 	}
-	out := new(Vpc)
+	out := new(SgPortRange)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Vpc) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VpcCondition) DeepCopyInto(out *VpcCondition) {
+func (in *SgRule) DeepCopyInto(out *SgRule) {
 	*out = *in
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.PortRanges != nil {
+		in, out := &in.PortRanges, &out.PortRanges
+		*out = make([]SgPortRange, len(*in))
+		copy(*out, *in)
+	}
+	if in.IcmpType != nil {
+		in, out := &in.IcmpType, &out.IcmpType
+		*out = new(int)
+		**out = **in
+	}
+	if in.IcmpCode != nil {
+		in, out := &in.IcmpCode, &out.IcmpCode
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcCondition.
-func (in *VpcCondition) DeepCopy() *VpcCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SgRule.
+func (in *SgRule) DeepCopy() *SgRule {
 	if in == nil {
 		return nil
 	}
-	out := new(VpcCondition)
+	out := new(SgRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VpcDns) DeepCopyInto(out *VpcDns) {
+func (in *SlrPort) DeepCopyInto(out *SlrPort) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcDns.
-func (in *VpcDns) DeepCopy() *VpcDns {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlrPort.
+func (in *SlrPort) DeepCopy() *SlrPort {
 	if in == nil {
 		return nil
 	}
-	out := new(VpcDns)
+	out := new(SlrPort)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VpcDns) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VpcDNSCondition) DeepCopyInto(out *VpcDNSCondition) {
+func (in *StaticRoute) DeepCopyInto(out *StaticRoute) {
 	*out = *in
-	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcDNSCondition.
-func (in *VpcDNSCondition) DeepCopy() *VpcDNSCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticRoute.
+func (in *StaticRoute) DeepCopy() *StaticRoute {
 	if in == nil {
 		return nil
 	}
-	out := new(VpcDNSCondition)
+	out := new(StaticRoute)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VpcDnsList) DeepCopyInto(out *VpcDnsList) {
+func (in *Subnet) DeepCopyInto(out *Subnet) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]VpcDns, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcDnsList.
-func (in *VpcDnsList) DeepCopy() *VpcDnsList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subnet.
+func (in *Subnet) DeepCopy() *Subnet {
 	if in == nil {
 		return nil
 	}
-	out := new(VpcDnsList)
+	out := new(Subnet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VpcDnsList) DeepCopyObject() runtime.Object {
+func (in *Subnet) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2466,52 +2354,47 @@ func (in *VpcDnsList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VpcDNSSpec) DeepCopyInto(out *VpcDNSSpec) {
+func (in *SubnetCIDRStatus) DeepCopyInto(out *SubnetCIDRStatus) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcDNSSpec.
-func (in *VpcDNSSpec) DeepCopy() *VpcDNSSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetCIDRStatus.
+func (in *SubnetCIDRStatus) DeepCopy() *SubnetCIDRStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VpcDNSSpec)
+	out := new(SubnetCIDRStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VpcDNSStatus) DeepCopyInto(out *VpcDNSStatus) {
+func (in *SubnetCondition) DeepCopyInto(out *SubnetCondition) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]VpcDNSCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcDNSStatus.
-func (in *VpcDNSStatus) DeepCopy() *VpcDNSStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetCondition.
+func (in *SubnetCondition) DeepCopy() *SubnetCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(VpcDNSStatus)
+	out := new(SubnetCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VpcList) DeepCopyInto(out *VpcList) {
+func (in *SubnetList) DeepCopyInto(out *SubnetList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Vpc, len(*in))
+		*out = make([]Subnet, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2519,18 +2402,18 @@ func (in *VpcList) DeepCopyInto(out *VpcList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcList.
-func (in *VpcList) DeepCopy() *VpcList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetList.
+func (in *SubnetList) DeepCopy() *SubnetList {
 	if in == nil {
 		return nil
 	}
-	out := new(VpcList)
+	out := new(SubnetList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VpcList) DeepCopyObject() runtime.Object {
+func (in *SubnetList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2538,7 +2421,96 @@ func (in *VpcList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VpcNatGateway) DeepCopyInto(out *VpcNatGateway) {
+func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeIps != nil {
+		in, out := &in.ExcludeIps, &out.ExcludeIps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecondaryCIDRBlocks != nil {
+		in, out := &in.SecondaryCIDRBlocks, &out.SecondaryCIDRBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowSubnets != nil {
+		in, out := &in.AllowSubnets, &out.AllowSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Vips != nil {
+		in, out := &in.Vips, &out.Vips
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Acls != nil {
+		in, out := &in.Acls, &out.Acls
+		*out = make([]ACL, len(*in))
+		copy(*out, *in)
+	}
+	if in.NatOutgoingPolicyRules != nil {
+		in, out := &in.NatOutgoingPolicyRules, &out.NatOutgoingPolicyRules
+		*out = make([]NatOutgoingPolicyRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnableLb != nil {
+		in, out := &in.EnableLb, &out.EnableLb
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSpec.
+func (in *SubnetSpec) DeepCopy() *SubnetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetStatus) DeepCopyInto(out *SubnetStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]SubnetCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NatOutgoingPolicyRules != nil {
+		in, out := &in.NatOutgoingPolicyRules, &out.NatOutgoingPolicyRules
+		*out = make([]NatOutgoingPolicyRuleStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.CIDRs != nil {
+		in, out := &in.CIDRs, &out.CIDRs
+		*out = make([]SubnetCIDRStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetStatus.
+func (in *SubnetStatus) DeepCopy() *SubnetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchLBRule) DeepCopyInto(out *SwitchLBRule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -2547,18 +2519,18 @@ func (in *VpcNatGateway) DeepCopyInto(out *VpcNatGateway) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcNatGateway.
-func (in *VpcNatGateway) DeepCopy() *VpcNatGateway {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchLBRule.
+func (in *SwitchLBRule) DeepCopy() *SwitchLBRule {
 	if in == nil {
 		return nil
 	}
-	out := new(VpcNatGateway)
+	out := new(SwitchLBRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VpcNatGateway) DeepCopyObject() runtime.Object {
+func (in *SwitchLBRule) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2566,13 +2538,31 @@ func (in *VpcNatGateway) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VpcNatGatewayList) DeepCopyInto(out *VpcNatGatewayList) {
+func (in *SwitchLBRuleCondition) DeepCopyInto(out *SwitchLBRuleCondition) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchLBRuleCondition.
+func (in *SwitchLBRuleCondition) DeepCopy() *SwitchLBRuleCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchLBRuleCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchLBRuleList) DeepCopyInto(out *SwitchLBRuleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VpcNatGateway, len(*in))
+		*out = make([]SwitchLBRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -2580,18 +2570,18 @@ func (in *VpcNatGatewayList) DeepCopyInto(out *VpcNatGatewayList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcNatGatewayList.
-func (in *VpcNatGatewayList) DeepCopy() *VpcNatGatewayList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchLBRuleList.
+func (in *SwitchLBRuleList) DeepCopy() *SwitchLBRuleList {
 	if in == nil {
 		return nil
 	}
-	out := new(VpcNatGatewayList)
+	out := new(SwitchLBRuleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VpcNatGatewayList) DeepCopyObject() runtime.Object {
+func (in *SwitchLBRuleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2599,96 +2589,1418 @@ func (in *VpcNatGatewayList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VpcNatSpec) DeepCopyInto(out *VpcNatSpec) {
+func (in *SwitchLBRuleSpec) DeepCopyInto(out *SwitchLBRuleSpec) {
 	*out = *in
-	if in.ExternalSubnets != nil {
-		in, out := &in.ExternalSubnets, &out.ExternalSubnets
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]SlrPort, len(*in))
+		copy(*out, *in)
 	}
-	in.Affinity.DeepCopyInto(&out.Affinity)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcNatSpec.
-func (in *VpcNatSpec) DeepCopy() *VpcNatSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchLBRuleSpec.
+func (in *SwitchLBRuleSpec) DeepCopy() *SwitchLBRuleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VpcNatSpec)
+	out := new(SwitchLBRuleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VpcNatStatus) DeepCopyInto(out *VpcNatStatus) {
+func (in *SwitchLBRuleStatus) DeepCopyInto(out *SwitchLBRuleStatus) {
 	*out = *in
-	if in.ExternalSubnets != nil {
-		in, out := &in.ExternalSubnets, &out.ExternalSubnets
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]SwitchLBRuleCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	in.Affinity.DeepCopyInto(&out.Affinity)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcNatStatus.
-func (in *VpcNatStatus) DeepCopy() *VpcNatStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchLBRuleStatus.
+func (in *SwitchLBRuleStatus) DeepCopy() *SwitchLBRuleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VpcNatStatus)
+	out := new(SwitchLBRuleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VpcPeering) DeepCopyInto(out *VpcPeering) {
+func (in *Vip) DeepCopyInto(out *Vip) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcPeering.
-func (in *VpcPeering) DeepCopy() *VpcPeering {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vip.
+func (in *Vip) DeepCopy() *Vip {
 	if in == nil {
 		return nil
 	}
-	out := new(VpcPeering)
+	out := new(Vip)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Vip) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VpcSpec) DeepCopyInto(out *VpcSpec) {
+func (in *VipCondition) DeepCopyInto(out *VipCondition) {
 	*out = *in
-	if in.Namespaces != nil {
-		in, out := &in.Namespaces, &out.Namespaces
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VipCondition.
+func (in *VipCondition) DeepCopy() *VipCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(VipCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VipList) DeepCopyInto(out *VipList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Vip, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VipList.
+func (in *VipList) DeepCopy() *VipList {
+	if in == nil {
+		return nil
+	}
+	out := new(VipList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VipList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VipSpec) DeepCopyInto(out *VipSpec) {
+	*out = *in
+	if in.AttachSubnets != nil {
+		in, out := &in.AttachSubnets, &out.AttachSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VipSpec.
+func (in *VipSpec) DeepCopy() *VipSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VipSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VipStatus) DeepCopyInto(out *VipStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]VipCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VipStatus.
+func (in *VipStatus) DeepCopy() *VipStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VipStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Vlan) DeepCopyInto(out *Vlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vlan.
+func (in *Vlan) DeepCopy() *Vlan {
+	if in == nil {
+		return nil
+	}
+	out := new(Vlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Vlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VlanCondition) DeepCopyInto(out *VlanCondition) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VlanCondition.
+func (in *VlanCondition) DeepCopy() *VlanCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(VlanCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VlanList) DeepCopyInto(out *VlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Vlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VlanList.
+func (in *VlanList) DeepCopy() *VlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(VlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VlanSpec) DeepCopyInto(out *VlanSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VlanSpec.
+func (in *VlanSpec) DeepCopy() *VlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VlanStatus) DeepCopyInto(out *VlanStatus) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]VlanCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VlanStatus.
+func (in *VlanStatus) DeepCopy() *VlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Vpc) DeepCopyInto(out *Vpc) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vpc.
+func (in *Vpc) DeepCopy() *Vpc {
+	if in == nil {
+		return nil
+	}
+	out := new(Vpc)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Vpc) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcCondition) DeepCopyInto(out *VpcCondition) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcCondition.
+func (in *VpcCondition) DeepCopy() *VpcCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcDnatRule) DeepCopyInto(out *VpcDnatRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcDnatRule.
+func (in *VpcDnatRule) DeepCopy() *VpcDnatRule {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcDnatRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcDnatRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcDnatRuleList) DeepCopyInto(out *VpcDnatRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VpcDnatRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcDnatRuleList.
+func (in *VpcDnatRuleList) DeepCopy() *VpcDnatRuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcDnatRuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcDnatRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcDnatRuleSpec) DeepCopyInto(out *VpcDnatRuleSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcDnatRuleSpec.
+func (in *VpcDnatRuleSpec) DeepCopy() *VpcDnatRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcDnatRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcDnatRuleStatus) DeepCopyInto(out *VpcDnatRuleStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcDnatRuleStatus.
+func (in *VpcDnatRuleStatus) DeepCopy() *VpcDnatRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcDnatRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcDns) DeepCopyInto(out *VpcDns) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcDns.
+func (in *VpcDns) DeepCopy() *VpcDns {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcDns)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcDns) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcDNSCondition) DeepCopyInto(out *VpcDNSCondition) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcDNSCondition.
+func (in *VpcDNSCondition) DeepCopy() *VpcDNSCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcDNSCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcDnsList) DeepCopyInto(out *VpcDnsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VpcDns, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcDnsList.
+func (in *VpcDnsList) DeepCopy() *VpcDnsList {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcDnsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcDnsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcDNSSpec) DeepCopyInto(out *VpcDNSSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcDNSSpec.
+func (in *VpcDNSSpec) DeepCopy() *VpcDNSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcDNSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcDNSStatus) DeepCopyInto(out *VpcDNSStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]VpcDNSCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcDNSStatus.
+func (in *VpcDNSStatus) DeepCopy() *VpcDNSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcDNSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcEgressGateway) DeepCopyInto(out *VpcEgressGateway) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEgressGateway.
+func (in *VpcEgressGateway) DeepCopy() *VpcEgressGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcEgressGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcEgressGateway) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcEgressGatewayBFDConfig) DeepCopyInto(out *VpcEgressGatewayBFDConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEgressGatewayBFDConfig.
+func (in *VpcEgressGatewayBFDConfig) DeepCopy() *VpcEgressGatewayBFDConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcEgressGatewayBFDConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcEgressGatewayInstance) DeepCopyInto(out *VpcEgressGatewayInstance) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEgressGatewayInstance.
+func (in *VpcEgressGatewayInstance) DeepCopy() *VpcEgressGatewayInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcEgressGatewayInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcEgressGatewayList) DeepCopyInto(out *VpcEgressGatewayList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VpcEgressGateway, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEgressGatewayList.
+func (in *VpcEgressGatewayList) DeepCopy() *VpcEgressGatewayList {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcEgressGatewayList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcEgressGatewayList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcEgressGatewaySelector) DeepCopyInto(out *VpcEgressGatewaySelector) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEgressGatewaySelector.
+func (in *VpcEgressGatewaySelector) DeepCopy() *VpcEgressGatewaySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcEgressGatewaySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcEgressGatewaySpec) DeepCopyInto(out *VpcEgressGatewaySpec) {
+	*out = *in
+	if in.InternalIPs != nil {
+		in, out := &in.InternalIPs, &out.InternalIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExternalIPs != nil {
+		in, out := &in.ExternalIPs, &out.ExternalIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.BFD = in.BFD
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]VpcEgressGatewaySelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEgressGatewaySpec.
+func (in *VpcEgressGatewaySpec) DeepCopy() *VpcEgressGatewaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcEgressGatewaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcEgressGatewayStatus) DeepCopyInto(out *VpcEgressGatewayStatus) {
+	*out = *in
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]VpcEgressGatewayInstance, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEgressGatewayStatus.
+func (in *VpcEgressGatewayStatus) DeepCopy() *VpcEgressGatewayStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcEgressGatewayStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcEip) DeepCopyInto(out *VpcEip) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEip.
+func (in *VpcEip) DeepCopy() *VpcEip {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcEip)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcEip) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcEipList) DeepCopyInto(out *VpcEipList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VpcEip, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEipList.
+func (in *VpcEipList) DeepCopy() *VpcEipList {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcEipList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcEipList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcEipSpec) DeepCopyInto(out *VpcEipSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEipSpec.
+func (in *VpcEipSpec) DeepCopy() *VpcEipSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcEipSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcEipStatus) DeepCopyInto(out *VpcEipStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcEipStatus.
+func (in *VpcEipStatus) DeepCopy() *VpcEipStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcEipStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcFip) DeepCopyInto(out *VpcFip) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcFip.
+func (in *VpcFip) DeepCopy() *VpcFip {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcFip)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcFip) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcFipList) DeepCopyInto(out *VpcFipList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VpcFip, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcFipList.
+func (in *VpcFipList) DeepCopy() *VpcFipList {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcFipList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcFipList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcFipSpec) DeepCopyInto(out *VpcFipSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcFipSpec.
+func (in *VpcFipSpec) DeepCopy() *VpcFipSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcFipSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcFipStatus) DeepCopyInto(out *VpcFipStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcFipStatus.
+func (in *VpcFipStatus) DeepCopy() *VpcFipStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcFipStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcList) DeepCopyInto(out *VpcList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Vpc, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcList.
+func (in *VpcList) DeepCopy() *VpcList {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcNatGateway) DeepCopyInto(out *VpcNatGateway) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcNatGateway.
+func (in *VpcNatGateway) DeepCopy() *VpcNatGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcNatGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcNatGateway) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcNatGatewayCondition) DeepCopyInto(out *VpcNatGatewayCondition) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcNatGatewayCondition.
+func (in *VpcNatGatewayCondition) DeepCopy() *VpcNatGatewayCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcNatGatewayCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcNatGatewayInstance) DeepCopyInto(out *VpcNatGatewayInstance) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcNatGatewayInstance.
+func (in *VpcNatGatewayInstance) DeepCopy() *VpcNatGatewayInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcNatGatewayInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcNatGatewayList) DeepCopyInto(out *VpcNatGatewayList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VpcNatGateway, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcNatGatewayList.
+func (in *VpcNatGatewayList) DeepCopy() *VpcNatGatewayList {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcNatGatewayList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcNatGatewayList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcNatSpec) DeepCopyInto(out *VpcNatSpec) {
+	*out = *in
+	if in.ExternalSubnets != nil {
+		in, out := &in.ExternalSubnets, &out.ExternalSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Affinity.DeepCopyInto(&out.Affinity)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcNatSpec.
+func (in *VpcNatSpec) DeepCopy() *VpcNatSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcNatSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcNatStatus) DeepCopyInto(out *VpcNatStatus) {
+	*out = *in
+	if in.ExternalSubnets != nil {
+		in, out := &in.ExternalSubnets, &out.ExternalSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Affinity.DeepCopyInto(&out.Affinity)
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]VpcNatGatewayInstance, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]VpcNatGatewayCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcNatStatus.
+func (in *VpcNatStatus) DeepCopy() *VpcNatStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcNatStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcPeering) DeepCopyInto(out *VpcPeering) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcPeering.
+func (in *VpcPeering) DeepCopy() *VpcPeering {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcPeering)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcPeeringConnection) DeepCopyInto(out *VpcPeeringConnection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcPeeringConnection.
+func (in *VpcPeeringConnection) DeepCopy() *VpcPeeringConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcPeeringConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcPeeringConnection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcPeeringConnectionCondition) DeepCopyInto(out *VpcPeeringConnectionCondition) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcPeeringConnectionCondition.
+func (in *VpcPeeringConnectionCondition) DeepCopy() *VpcPeeringConnectionCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcPeeringConnectionCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcPeeringConnectionList) DeepCopyInto(out *VpcPeeringConnectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VpcPeeringConnection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcPeeringConnectionList.
+func (in *VpcPeeringConnectionList) DeepCopy() *VpcPeeringConnectionList {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcPeeringConnectionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcPeeringConnectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcPeeringConnectionReference) DeepCopyInto(out *VpcPeeringConnectionReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcPeeringConnectionReference.
+func (in *VpcPeeringConnectionReference) DeepCopy() *VpcPeeringConnectionReference {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcPeeringConnectionReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcPeeringConnectionSpec) DeepCopyInto(out *VpcPeeringConnectionSpec) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Requester != nil {
+		in, out := &in.Requester, &out.Requester
+		*out = new(VpcPeeringConnectionReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcPeeringConnectionSpec.
+func (in *VpcPeeringConnectionSpec) DeepCopy() *VpcPeeringConnectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcPeeringConnectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcPeeringConnectionStatus) DeepCopyInto(out *VpcPeeringConnectionStatus) {
+	*out = *in
+	if in.Peer != nil {
+		in, out := &in.Peer, &out.Peer
+		*out = new(VpcPeeringConnectionReference)
+		**out = **in
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]*StaticRoute, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(StaticRoute)
+				**out = **in
+			}
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]VpcPeeringConnectionCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcPeeringConnectionStatus.
+func (in *VpcPeeringConnectionStatus) DeepCopy() *VpcPeeringConnectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcPeeringConnectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcSnatRule) DeepCopyInto(out *VpcSnatRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcSnatRule.
+func (in *VpcSnatRule) DeepCopy() *VpcSnatRule {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcSnatRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcSnatRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcSnatRuleList) DeepCopyInto(out *VpcSnatRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VpcSnatRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcSnatRuleList.
+func (in *VpcSnatRuleList) DeepCopy() *VpcSnatRuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcSnatRuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcSnatRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcSnatRuleSpec) DeepCopyInto(out *VpcSnatRuleSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcSnatRuleSpec.
+func (in *VpcSnatRuleSpec) DeepCopy() *VpcSnatRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcSnatRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcSnatRuleStatus) DeepCopyInto(out *VpcSnatRuleStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcSnatRuleStatus.
+func (in *VpcSnatRuleStatus) DeepCopy() *VpcSnatRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcSnatRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcSpec) DeepCopyInto(out *VpcSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	if in.StaticRoutes != nil {
 		in, out := &in.StaticRoutes, &out.StaticRoutes
@@ -2768,3 +4080,232 @@ func (in *VpcStatus) DeepCopy() *VpcStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcTransitHub) DeepCopyInto(out *VpcTransitHub) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcTransitHub.
+func (in *VpcTransitHub) DeepCopy() *VpcTransitHub {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcTransitHub)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcTransitHub) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcTransitHubAttachment) DeepCopyInto(out *VpcTransitHubAttachment) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Propagations != nil {
+		in, out := &in.Propagations, &out.Propagations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcTransitHubAttachment.
+func (in *VpcTransitHubAttachment) DeepCopy() *VpcTransitHubAttachment {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcTransitHubAttachment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcTransitHubAttachmentStatus) DeepCopyInto(out *VpcTransitHubAttachmentStatus) {
+	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]*StaticRoute, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(StaticRoute)
+				**out = **in
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcTransitHubAttachmentStatus.
+func (in *VpcTransitHubAttachmentStatus) DeepCopy() *VpcTransitHubAttachmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcTransitHubAttachmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcTransitHubCondition) DeepCopyInto(out *VpcTransitHubCondition) {
+	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcTransitHubCondition.
+func (in *VpcTransitHubCondition) DeepCopy() *VpcTransitHubCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcTransitHubCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcTransitHubList) DeepCopyInto(out *VpcTransitHubList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VpcTransitHub, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcTransitHubList.
+func (in *VpcTransitHubList) DeepCopy() *VpcTransitHubList {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcTransitHubList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VpcTransitHubList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcTransitHubRouteTable) DeepCopyInto(out *VpcTransitHubRouteTable) {
+	*out = *in
+	if in.Associations != nil {
+		in, out := &in.Associations, &out.Associations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Propagations != nil {
+		in, out := &in.Propagations, &out.Propagations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]*StaticRoute, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(StaticRoute)
+				**out = **in
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcTransitHubRouteTable.
+func (in *VpcTransitHubRouteTable) DeepCopy() *VpcTransitHubRouteTable {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcTransitHubRouteTable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcTransitHubSpec) DeepCopyInto(out *VpcTransitHubSpec) {
+	*out = *in
+	if in.Attachments != nil {
+		in, out := &in.Attachments, &out.Attachments
+		*out = make([]VpcTransitHubAttachment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcTransitHubSpec.
+func (in *VpcTransitHubSpec) DeepCopy() *VpcTransitHubSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcTransitHubSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VpcTransitHubStatus) DeepCopyInto(out *VpcTransitHubStatus) {
+	*out = *in
+	if in.Attachments != nil {
+		in, out := &in.Attachments, &out.Attachments
+		*out = make([]VpcTransitHubAttachmentStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RouteTables != nil {
+		in, out := &in.RouteTables, &out.RouteTables
+		*out = make([]VpcTransitHubRouteTable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]VpcTransitHubCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcTransitHubStatus.
+func (in *VpcTransitHubStatus) DeepCopy() *VpcTransitHubStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VpcTransitHubStatus)
+	in.DeepCopyInto(out)
+	return out
+}
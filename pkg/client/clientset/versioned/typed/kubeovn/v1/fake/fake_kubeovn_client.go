@@ -28,6 +28,18 @@ type FakeKubeovnV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeKubeovnV1) AddressGroups() v1.AddressGroupInterface {
+	return &FakeAddressGroups{c}
+}
+
+func (c *FakeKubeovnV1) BgpPeers() v1.BgpPeerInterface {
+	return &FakeBgpPeers{c}
+}
+
+func (c *FakeKubeovnV1) FqdnCaches() v1.FqdnCacheInterface {
+	return &FakeFqdnCaches{c}
+}
+
 func (c *FakeKubeovnV1) IPs() v1.IPInterface {
 	return &FakeIPs{c}
 }
@@ -36,6 +48,10 @@ func (c *FakeKubeovnV1) IPPools() v1.IPPoolInterface {
 	return &FakeIPPools{c}
 }
 
+func (c *FakeKubeovnV1) IPQuotas() v1.IPQuotaInterface {
+	return &FakeIPQuotas{c}
+}
+
 func (c *FakeKubeovnV1) IptablesDnatRules() v1.IptablesDnatRuleInterface {
 	return &FakeIptablesDnatRules{c}
 }
@@ -56,18 +72,42 @@ func (c *FakeKubeovnV1) OvnDnatRules() v1.OvnDnatRuleInterface {
 	return &FakeOvnDnatRules{c}
 }
 
+func (c *FakeKubeovnV1) VpcDnatRules() v1.VpcDnatRuleInterface {
+	return &FakeVpcDnatRules{c}
+}
+
 func (c *FakeKubeovnV1) OvnEips() v1.OvnEipInterface {
 	return &FakeOvnEips{c}
 }
 
+func (c *FakeKubeovnV1) VpcEips() v1.VpcEipInterface {
+	return &FakeVpcEips{c}
+}
+
 func (c *FakeKubeovnV1) OvnFips() v1.OvnFipInterface {
 	return &FakeOvnFips{c}
 }
 
+func (c *FakeKubeovnV1) VpcFips() v1.VpcFipInterface {
+	return &FakeVpcFips{c}
+}
+
 func (c *FakeKubeovnV1) OvnSnatRules() v1.OvnSnatRuleInterface {
 	return &FakeOvnSnatRules{c}
 }
 
+func (c *FakeKubeovnV1) VpcSnatRules() v1.VpcSnatRuleInterface {
+	return &FakeVpcSnatRules{c}
+}
+
+func (c *FakeKubeovnV1) VpcPeeringConnections(namespace string) v1.VpcPeeringConnectionInterface {
+	return &FakeVpcPeeringConnections{c, namespace}
+}
+
+func (c *FakeKubeovnV1) VpcTransitHubs() v1.VpcTransitHubInterface {
+	return &FakeVpcTransitHubs{c}
+}
+
 func (c *FakeKubeovnV1) ProviderNetworks() v1.ProviderNetworkInterface {
 	return &FakeProviderNetworks{c}
 }
@@ -104,6 +144,10 @@ func (c *FakeKubeovnV1) VpcDnses() v1.VpcDnsInterface {
 	return &FakeVpcDnses{c}
 }
 
+func (c *FakeKubeovnV1) VpcEgressGateways() v1.VpcEgressGatewayInterface {
+	return &FakeVpcEgressGateways{c}
+}
+
 func (c *FakeKubeovnV1) VpcNatGateways() v1.VpcNatGatewayInterface {
 	return &FakeVpcNatGateways{c}
 }
@@ -18,10 +18,18 @@ limitations under the License.
 
 package v1
 
+type AddressGroupExpansion interface{}
+
+type BgpPeerExpansion interface{}
+
+type FqdnCacheExpansion interface{}
+
 type IPExpansion interface{}
 
 type IPPoolExpansion interface{}
 
+type IPQuotaExpansion interface{}
+
 type IptablesDnatRuleExpansion interface{}
 
 type IptablesEIPExpansion interface{}
@@ -32,12 +40,24 @@ type IptablesSnatRuleExpansion interface{}
 
 type OvnDnatRuleExpansion interface{}
 
+type VpcDnatRuleExpansion interface{}
+
 type OvnEipExpansion interface{}
 
+type VpcEipExpansion interface{}
+
 type OvnFipExpansion interface{}
 
+type VpcFipExpansion interface{}
+
 type OvnSnatRuleExpansion interface{}
 
+type VpcSnatRuleExpansion interface{}
+
+type VpcPeeringConnectionExpansion interface{}
+
+type VpcTransitHubExpansion interface{}
+
 type ProviderNetworkExpansion interface{}
 
 type QoSPolicyExpansion interface{}
@@ -56,4 +76,6 @@ type VpcExpansion interface{}
 
 type VpcDnsExpansion interface{}
 
+type VpcEgressGatewayExpansion interface{}
+
 type VpcNatGatewayExpansion interface{}
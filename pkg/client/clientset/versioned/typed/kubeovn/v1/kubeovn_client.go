@@ -28,16 +28,26 @@ import (
 
 type KubeovnV1Interface interface {
 	RESTClient() rest.Interface
+	AddressGroupsGetter
+	BgpPeersGetter
+	FqdnCachesGetter
 	IPsGetter
 	IPPoolsGetter
+	IPQuotasGetter
 	IptablesDnatRulesGetter
 	IptablesEIPsGetter
 	IptablesFIPRulesGetter
 	IptablesSnatRulesGetter
 	OvnDnatRulesGetter
+	VpcDnatRulesGetter
 	OvnEipsGetter
+	VpcEipsGetter
 	OvnFipsGetter
+	VpcFipsGetter
 	OvnSnatRulesGetter
+	VpcSnatRulesGetter
+	VpcPeeringConnectionsGetter
+	VpcTransitHubsGetter
 	ProviderNetworksGetter
 	QoSPoliciesGetter
 	SecurityGroupsGetter
@@ -47,6 +57,7 @@ type KubeovnV1Interface interface {
 	VlansGetter
 	VpcsGetter
 	VpcDnsesGetter
+	VpcEgressGatewaysGetter
 	VpcNatGatewaysGetter
 }
 
@@ -55,6 +66,18 @@ type KubeovnV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *KubeovnV1Client) AddressGroups() AddressGroupInterface {
+	return newAddressGroups(c)
+}
+
+func (c *KubeovnV1Client) BgpPeers() BgpPeerInterface {
+	return newBgpPeers(c)
+}
+
+func (c *KubeovnV1Client) FqdnCaches() FqdnCacheInterface {
+	return newFqdnCaches(c)
+}
+
 func (c *KubeovnV1Client) IPs() IPInterface {
 	return newIPs(c)
 }
@@ -63,6 +86,10 @@ func (c *KubeovnV1Client) IPPools() IPPoolInterface {
 	return newIPPools(c)
 }
 
+func (c *KubeovnV1Client) IPQuotas() IPQuotaInterface {
+	return newIPQuotas(c)
+}
+
 func (c *KubeovnV1Client) IptablesDnatRules() IptablesDnatRuleInterface {
 	return newIptablesDnatRules(c)
 }
@@ -83,18 +110,42 @@ func (c *KubeovnV1Client) OvnDnatRules() OvnDnatRuleInterface {
 	return newOvnDnatRules(c)
 }
 
+func (c *KubeovnV1Client) VpcDnatRules() VpcDnatRuleInterface {
+	return newVpcDnatRules(c)
+}
+
 func (c *KubeovnV1Client) OvnEips() OvnEipInterface {
 	return newOvnEips(c)
 }
 
+func (c *KubeovnV1Client) VpcEips() VpcEipInterface {
+	return newVpcEips(c)
+}
+
 func (c *KubeovnV1Client) OvnFips() OvnFipInterface {
 	return newOvnFips(c)
 }
 
+func (c *KubeovnV1Client) VpcFips() VpcFipInterface {
+	return newVpcFips(c)
+}
+
 func (c *KubeovnV1Client) OvnSnatRules() OvnSnatRuleInterface {
 	return newOvnSnatRules(c)
 }
 
+func (c *KubeovnV1Client) VpcSnatRules() VpcSnatRuleInterface {
+	return newVpcSnatRules(c)
+}
+
+func (c *KubeovnV1Client) VpcPeeringConnections(namespace string) VpcPeeringConnectionInterface {
+	return newVpcPeeringConnections(c, namespace)
+}
+
+func (c *KubeovnV1Client) VpcTransitHubs() VpcTransitHubInterface {
+	return newVpcTransitHubs(c)
+}
+
 func (c *KubeovnV1Client) ProviderNetworks() ProviderNetworkInterface {
 	return newProviderNetworks(c)
 }
@@ -131,6 +182,10 @@ func (c *KubeovnV1Client) VpcDnses() VpcDnsInterface {
 	return newVpcDnses(c)
 }
 
+func (c *KubeovnV1Client) VpcEgressGateways() VpcEgressGatewayInterface {
+	return newVpcEgressGateways(c)
+}
+
 func (c *KubeovnV1Client) VpcNatGateways() VpcNatGatewayInterface {
 	return newVpcNatGateways(c)
 }
@@ -53,10 +53,18 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=kubeovn.io, Version=v1
+	case v1.SchemeGroupVersion.WithResource("address-groups"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().AddressGroups().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("bgp-peers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().BgpPeers().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("fqdn-caches"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().FqdnCaches().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("ips"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().IPs().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("ippools"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().IPPools().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("ip-quotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().IPQuotas().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("iptables-dnat-rules"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().IptablesDnatRules().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("iptables-eips"):
@@ -67,12 +75,24 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().IptablesSnatRules().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("ovn-dnat-rules"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().OvnDnatRules().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("vpc-dnat-rules"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().VpcDnatRules().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("ovn-eips"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().OvnEips().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("vpc-eips"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().VpcEips().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("ovn-fips"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().OvnFips().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("vpc-fips"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().VpcFips().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("ovn-snat-rules"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().OvnSnatRules().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("vpc-snat-rules"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().VpcSnatRules().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("vpc-peering-connections"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().VpcPeeringConnections().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("vpc-transit-hubs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().VpcTransitHubs().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("provider-networks"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().ProviderNetworks().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("qos-policies"):
@@ -91,6 +111,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().Vpcs().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("vpc-dnses"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().VpcDnses().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("vpc-egress-gateways"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().VpcEgressGateways().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("vpc-nat-gateways"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubeovn().V1().VpcNatGateways().Informer()}, nil
 
@@ -24,10 +24,18 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// AddressGroups returns a AddressGroupInformer.
+	AddressGroups() AddressGroupInformer
+	// BgpPeers returns a BgpPeerInformer.
+	BgpPeers() BgpPeerInformer
+	// FqdnCaches returns a FqdnCacheInformer.
+	FqdnCaches() FqdnCacheInformer
 	// IPs returns a IPInformer.
 	IPs() IPInformer
 	// IPPools returns a IPPoolInformer.
 	IPPools() IPPoolInformer
+	// IPQuotas returns a IPQuotaInformer.
+	IPQuotas() IPQuotaInformer
 	// IptablesDnatRules returns a IptablesDnatRuleInformer.
 	IptablesDnatRules() IptablesDnatRuleInformer
 	// IptablesEIPs returns a IptablesEIPInformer.
@@ -38,12 +46,24 @@ type Interface interface {
 	IptablesSnatRules() IptablesSnatRuleInformer
 	// OvnDnatRules returns a OvnDnatRuleInformer.
 	OvnDnatRules() OvnDnatRuleInformer
+	// VpcDnatRules returns a VpcDnatRuleInformer.
+	VpcDnatRules() VpcDnatRuleInformer
 	// OvnEips returns a OvnEipInformer.
 	OvnEips() OvnEipInformer
+	// VpcEips returns a VpcEipInformer.
+	VpcEips() VpcEipInformer
 	// OvnFips returns a OvnFipInformer.
 	OvnFips() OvnFipInformer
+	// VpcFips returns a VpcFipInformer.
+	VpcFips() VpcFipInformer
 	// OvnSnatRules returns a OvnSnatRuleInformer.
 	OvnSnatRules() OvnSnatRuleInformer
+	// VpcSnatRules returns a VpcSnatRuleInformer.
+	VpcSnatRules() VpcSnatRuleInformer
+	// VpcPeeringConnections returns a VpcPeeringConnectionInformer.
+	VpcPeeringConnections() VpcPeeringConnectionInformer
+	// VpcTransitHubs returns a VpcTransitHubInformer.
+	VpcTransitHubs() VpcTransitHubInformer
 	// ProviderNetworks returns a ProviderNetworkInformer.
 	ProviderNetworks() ProviderNetworkInformer
 	// QoSPolicies returns a QoSPolicyInformer.
@@ -62,6 +82,8 @@ type Interface interface {
 	Vpcs() VpcInformer
 	// VpcDnses returns a VpcDnsInformer.
 	VpcDnses() VpcDnsInformer
+	// VpcEgressGateways returns a VpcEgressGatewayInformer.
+	VpcEgressGateways() VpcEgressGatewayInformer
 	// VpcNatGateways returns a VpcNatGatewayInformer.
 	VpcNatGateways() VpcNatGatewayInformer
 }
@@ -77,6 +99,21 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// AddressGroups returns a AddressGroupInformer.
+func (v *version) AddressGroups() AddressGroupInformer {
+	return &addressGroupInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// BgpPeers returns a BgpPeerInformer.
+func (v *version) BgpPeers() BgpPeerInformer {
+	return &bgpPeerInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// FqdnCaches returns a FqdnCacheInformer.
+func (v *version) FqdnCaches() FqdnCacheInformer {
+	return &fqdnCacheInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // IPs returns a IPInformer.
 func (v *version) IPs() IPInformer {
 	return &iPInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -87,6 +124,11 @@ func (v *version) IPPools() IPPoolInformer {
 	return &iPPoolInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// IPQuotas returns a IPQuotaInformer.
+func (v *version) IPQuotas() IPQuotaInformer {
+	return &iPQuotaInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // IptablesDnatRules returns a IptablesDnatRuleInformer.
 func (v *version) IptablesDnatRules() IptablesDnatRuleInformer {
 	return &iptablesDnatRuleInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -112,21 +154,51 @@ func (v *version) OvnDnatRules() OvnDnatRuleInformer {
 	return &ovnDnatRuleInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// VpcDnatRules returns a VpcDnatRuleInformer.
+func (v *version) VpcDnatRules() VpcDnatRuleInformer {
+	return &vpcDnatRuleInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // OvnEips returns a OvnEipInformer.
 func (v *version) OvnEips() OvnEipInformer {
 	return &ovnEipInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// VpcEips returns a VpcEipInformer.
+func (v *version) VpcEips() VpcEipInformer {
+	return &vpcEipInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // OvnFips returns a OvnFipInformer.
 func (v *version) OvnFips() OvnFipInformer {
 	return &ovnFipInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// VpcFips returns a VpcFipInformer.
+func (v *version) VpcFips() VpcFipInformer {
+	return &vpcFipInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // OvnSnatRules returns a OvnSnatRuleInformer.
 func (v *version) OvnSnatRules() OvnSnatRuleInformer {
 	return &ovnSnatRuleInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// VpcSnatRules returns a VpcSnatRuleInformer.
+func (v *version) VpcSnatRules() VpcSnatRuleInformer {
+	return &vpcSnatRuleInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// VpcPeeringConnections returns a VpcPeeringConnectionInformer.
+func (v *version) VpcPeeringConnections() VpcPeeringConnectionInformer {
+	return &vpcPeeringConnectionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VpcTransitHubs returns a VpcTransitHubInformer.
+func (v *version) VpcTransitHubs() VpcTransitHubInformer {
+	return &vpcTransitHubInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ProviderNetworks returns a ProviderNetworkInformer.
 func (v *version) ProviderNetworks() ProviderNetworkInformer {
 	return &providerNetworkInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -172,6 +244,11 @@ func (v *version) VpcDnses() VpcDnsInformer {
 	return &vpcDNSInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// VpcEgressGateways returns a VpcEgressGatewayInformer.
+func (v *version) VpcEgressGateways() VpcEgressGatewayInformer {
+	return &vpcEgressGatewayInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // VpcNatGateways returns a VpcNatGatewayInformer.
 func (v *version) VpcNatGateways() VpcNatGatewayInformer {
 	return &vpcNatGatewayInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -18,6 +18,18 @@ limitations under the License.
 
 package v1
 
+// AddressGroupListerExpansion allows custom methods to be added to
+// AddressGroupLister.
+type AddressGroupListerExpansion interface{}
+
+// BgpPeerListerExpansion allows custom methods to be added to
+// BgpPeerLister.
+type BgpPeerListerExpansion interface{}
+
+// FqdnCacheListerExpansion allows custom methods to be added to
+// FqdnCacheLister.
+type FqdnCacheListerExpansion interface{}
+
 // IPListerExpansion allows custom methods to be added to
 // IPLister.
 type IPListerExpansion interface{}
@@ -26,6 +38,10 @@ type IPListerExpansion interface{}
 // IPPoolLister.
 type IPPoolListerExpansion interface{}
 
+// IPQuotaListerExpansion allows custom methods to be added to
+// IPQuotaLister.
+type IPQuotaListerExpansion interface{}
+
 // IptablesDnatRuleListerExpansion allows custom methods to be added to
 // IptablesDnatRuleLister.
 type IptablesDnatRuleListerExpansion interface{}
@@ -46,18 +62,46 @@ type IptablesSnatRuleListerExpansion interface{}
 // OvnDnatRuleLister.
 type OvnDnatRuleListerExpansion interface{}
 
+// VpcDnatRuleListerExpansion allows custom methods to be added to
+// VpcDnatRuleLister.
+type VpcDnatRuleListerExpansion interface{}
+
 // OvnEipListerExpansion allows custom methods to be added to
 // OvnEipLister.
 type OvnEipListerExpansion interface{}
 
+// VpcEipListerExpansion allows custom methods to be added to
+// VpcEipLister.
+type VpcEipListerExpansion interface{}
+
 // OvnFipListerExpansion allows custom methods to be added to
 // OvnFipLister.
 type OvnFipListerExpansion interface{}
 
+// VpcFipListerExpansion allows custom methods to be added to
+// VpcFipLister.
+type VpcFipListerExpansion interface{}
+
 // OvnSnatRuleListerExpansion allows custom methods to be added to
 // OvnSnatRuleLister.
 type OvnSnatRuleListerExpansion interface{}
 
+// VpcSnatRuleListerExpansion allows custom methods to be added to
+// VpcSnatRuleLister.
+type VpcSnatRuleListerExpansion interface{}
+
+// VpcPeeringConnectionListerExpansion allows custom methods to be added to
+// VpcPeeringConnectionLister.
+type VpcPeeringConnectionListerExpansion interface{}
+
+// VpcPeeringConnectionNamespaceListerExpansion allows custom methods to be added to
+// VpcPeeringConnectionNamespaceLister.
+type VpcPeeringConnectionNamespaceListerExpansion interface{}
+
+// VpcTransitHubListerExpansion allows custom methods to be added to
+// VpcTransitHubLister.
+type VpcTransitHubListerExpansion interface{}
+
 // ProviderNetworkListerExpansion allows custom methods to be added to
 // ProviderNetworkLister.
 type ProviderNetworkListerExpansion interface{}
@@ -94,6 +138,10 @@ type VpcListerExpansion interface{}
 // VpcDnsLister.
 type VpcDnsListerExpansion interface{}
 
+// VpcEgressGatewayListerExpansion allows custom methods to be added to
+// VpcEgressGatewayLister.
+type VpcEgressGatewayListerExpansion interface{}
+
 // VpcNatGatewayListerExpansion allows custom methods to be added to
 // VpcNatGatewayLister.
 type VpcNatGatewayListerExpansion interface{}
@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/scylladb/go-set/strset"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovs"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// sgAddressGroups returns the address groups referenced by the rules of a security group
+func sgAddressGroups(sg *kubeovnv1.SecurityGroup) []string {
+	var ags []string
+	for _, rule := range append(sg.Spec.IngressRules, sg.Spec.EgressRules...) {
+		if rule.RemoteType == kubeovnv1.SgRemoteTypeAddressGroup {
+			ags = append(ags, rule.RemoteAddressGroup)
+		}
+	}
+	return util.UniqString(ags)
+}
+
+func (c *Controller) enqueueAddressGroups(ags ...string) {
+	for _, ag := range ags {
+		klog.V(3).Infof("enqueue update address group %s", ag)
+		c.updateAddressGroupQueue.Add(ag)
+	}
+}
+
+func (c *Controller) enqueueAddAddressGroup(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.enqueueAddressGroups(key)
+}
+
+func (c *Controller) enqueueUpdateAddressGroup(oldObj, newObj interface{}) {
+	oldAg := oldObj.(*kubeovnv1.AddressGroup)
+	newAg := newObj.(*kubeovnv1.AddressGroup)
+	if oldAg.ResourceVersion == newAg.ResourceVersion {
+		return
+	}
+	c.enqueueAddressGroups(newAg.Name)
+}
+
+func (c *Controller) enqueueDeleteAddressGroup(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.enqueueAddressGroups(key)
+}
+
+func (c *Controller) runUpdateAddressGroupWorker() {
+	for c.processNextUpdateAddressGroupWorkItem() {
+	}
+}
+
+func (c *Controller) processNextUpdateAddressGroupWorkItem() bool {
+	obj, shutdown := c.updateAddressGroupQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.updateAddressGroupQueue.Done(obj)
+		var key string
+		var ok bool
+		if key, ok = obj.(string); !ok {
+			c.updateAddressGroupQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleUpdateAddressGroup(key); err != nil {
+			c.updateAddressGroupQueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing address group %s: %v, requeuing", key, err)
+		}
+		c.updateAddressGroupQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+// referencedAddressGroups returns all the address groups referenced by security groups
+func (c *Controller) referencedAddressGroups() (*strset.Set, error) {
+	sgs, err := c.sgsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list security groups: %v", err)
+		return nil, err
+	}
+	ags := strset.New()
+	for _, sg := range sgs {
+		ags.Add(sgAddressGroups(sg)...)
+	}
+	return ags, nil
+}
+
+// createAddressGroupAddressSets makes sure the address sets of the address groups exist before acls referencing them are created
+func (c *Controller) createAddressGroupAddressSets(ags ...string) error {
+	for _, ag := range ags {
+		externalIDs := map[string]string{addressGroupKey: ag}
+		for _, asName := range []string{ovs.GetAddressGroupV4AddressSetName(ag), ovs.GetAddressGroupV6AddressSetName(ag)} {
+			if err := c.OVNNbClient.CreateAddressSet(asName, externalIDs); err != nil {
+				klog.Errorf("failed to create address set %s for address group %s: %v", asName, ag, err)
+				return err
+			}
+		}
+		c.enqueueAddressGroups(ag)
+	}
+	return nil
+}
+
+// splitAddressGroupAddresses returns the ipv4 and ipv6 addresses of an address group
+func splitAddressGroupAddresses(addresses []string) ([]string, []string, error) {
+	var v4s, v6s []string
+	for _, address := range addresses {
+		address = strings.TrimSpace(address)
+		var ip net.IP
+		if strings.Contains(address, "/") {
+			var err error
+			if ip, _, err = net.ParseCIDR(address); err != nil {
+				return nil, nil, fmt.Errorf("invalid CIDR '%s'", address)
+			}
+		} else if ip = net.ParseIP(address); ip == nil {
+			return nil, nil, fmt.Errorf("invalid ip address '%s'", address)
+		}
+		if ip.To4() != nil {
+			v4s = append(v4s, address)
+		} else {
+			v6s = append(v6s, address)
+		}
+	}
+	return v4s, v6s, nil
+}
+
+func (c *Controller) handleUpdateAddressGroup(key string) error {
+	klog.Infof("handle update address group %s", key)
+
+	var v4s, v6s []string
+	ag, err := c.addressGroupsLister.Get(key)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to get address group %s: %v", key, err)
+			return err
+		}
+
+		ags, err := c.referencedAddressGroups()
+		if err != nil {
+			return err
+		}
+		if !ags.Has(key) {
+			klog.Infof("address group %s is deleted and no longer referenced, delete its address sets", key)
+			if err = c.OVNNbClient.DeleteAddressSets(map[string]string{addressGroupKey: key}); err != nil {
+				klog.Errorf("failed to delete address sets of address group %s: %v", key, err)
+				return err
+			}
+			return nil
+		}
+		// keep the address sets referenced by acls of security groups, but match nothing
+		klog.Infof("address group %s is deleted but still referenced by security groups, clear its address sets", key)
+	} else if v4s, v6s, err = splitAddressGroupAddresses(ag.Spec.Addresses); err != nil {
+		klog.Errorf("invalid addresses of address group %s: %v", key, err)
+		return err
+	}
+
+	externalIDs := map[string]string{addressGroupKey: key}
+	for asName, addresses := range map[string][]string{
+		ovs.GetAddressGroupV4AddressSetName(key): v4s,
+		ovs.GetAddressGroupV6AddressSetName(key): v6s,
+	} {
+		if err = c.OVNNbClient.CreateAddressSet(asName, externalIDs); err != nil {
+			klog.Errorf("failed to create address set %s for address group %s: %v", asName, key, err)
+			return err
+		}
+		if err = c.OVNNbClient.AddressSetUpdateAddress(asName, addresses...); err != nil {
+			klog.Errorf("failed to update addresses of address set %s for address group %s: %v", asName, key, err)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+func Test_sgAddressGroups(t *testing.T) {
+	sg := &kubeovnv1.SecurityGroup{
+		Spec: kubeovnv1.SecurityGroupSpec{
+			IngressRules: []*kubeovnv1.SgRule{
+				{RemoteType: kubeovnv1.SgRemoteTypeAddressGroup, RemoteAddressGroup: "office"},
+				{RemoteType: kubeovnv1.SgRemoteTypeAddress, RemoteAddress: "10.0.0.0/8"},
+			},
+			EgressRules: []*kubeovnv1.SgRule{
+				{RemoteType: kubeovnv1.SgRemoteTypeAddressGroup, RemoteAddressGroup: "office"},
+				{RemoteType: kubeovnv1.SgRemoteTypeAddressGroup, RemoteAddressGroup: "backup"},
+			},
+		},
+	}
+	require.ElementsMatch(t, []string{"office", "backup"}, sgAddressGroups(sg))
+}
+
+func Test_splitAddressGroupAddresses(t *testing.T) {
+	v4s, v6s, err := splitAddressGroupAddresses([]string{"10.0.0.1", " 192.168.0.0/16", "fd00::1", "fd00:10::/64"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.1", "192.168.0.0/16"}, v4s)
+	require.Equal(t, []string{"fd00::1", "fd00:10::/64"}, v6s)
+
+	_, _, err = splitAddressGroupAddresses([]string{"10.0.0.256"})
+	require.Error(t, err)
+	_, _, err = splitAddressGroupAddresses([]string{"10.0.0.0/33"})
+	require.Error(t, err)
+}
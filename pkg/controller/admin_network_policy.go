@@ -0,0 +1,630 @@
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	anpv1alpha1 "sigs.k8s.io/network-policy-api/apis/v1alpha1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovs"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// adminPolicyRule is the common form of the ingress and egress rules of
+// admin network policies and baseline admin network policies
+type adminPolicyRule struct {
+	action anpv1alpha1.AdminNetworkPolicyRuleAction
+	peers  []anpv1alpha1.AdminNetworkPolicyPeer
+	ports  []anpv1alpha1.AdminNetworkPolicyPort
+}
+
+func anpPortGroupName(name string) string {
+	return strings.ReplaceAll("anp."+name, "-", ".")
+}
+
+func adminPolicyAddressSetName(pgName, direction string, idx int, protocol string) string {
+	return fmt.Sprintf("%s.%s.%d.%s", pgName, direction, idx, protocol)
+}
+
+func anpIngressRules(anp *anpv1alpha1.AdminNetworkPolicy) []adminPolicyRule {
+	rules := make([]adminPolicyRule, 0, len(anp.Spec.Ingress))
+	for _, r := range anp.Spec.Ingress {
+		rule := adminPolicyRule{action: r.Action, peers: r.From}
+		if r.Ports != nil {
+			rule.ports = *r.Ports
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func anpEgressRules(anp *anpv1alpha1.AdminNetworkPolicy) []adminPolicyRule {
+	rules := make([]adminPolicyRule, 0, len(anp.Spec.Egress))
+	for _, r := range anp.Spec.Egress {
+		rule := adminPolicyRule{action: r.Action, peers: r.To}
+		if r.Ports != nil {
+			rule.ports = *r.Ports
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func anpHasPassRule(anp *anpv1alpha1.AdminNetworkPolicy) bool {
+	for _, rule := range append(anpIngressRules(anp), anpEgressRules(anp)...) {
+		if rule.action == anpv1alpha1.AdminNetworkPolicyRuleActionPass {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Controller) enqueueAddAnp(obj interface{}) {
+	anp := obj.(*anpv1alpha1.AdminNetworkPolicy)
+	klog.V(3).Infof("enqueue add anp %s", anp.Name)
+	c.updateAnpQueue.Add(anp.Name)
+	if anpHasPassRule(anp) {
+		c.enqueueAnpsAfter(anp.Spec.Priority)
+	}
+}
+
+func (c *Controller) enqueueDeleteAnp(obj interface{}) {
+	var anp *anpv1alpha1.AdminNetworkPolicy
+	switch t := obj.(type) {
+	case *anpv1alpha1.AdminNetworkPolicy:
+		anp = t
+	case cache.DeletedFinalStateUnknown:
+		a, ok := t.Obj.(*anpv1alpha1.AdminNetworkPolicy)
+		if !ok {
+			klog.Warningf("unexpected object type: %T", t.Obj)
+			return
+		}
+		anp = a
+	default:
+		klog.Warningf("unexpected type: %T", obj)
+		return
+	}
+
+	klog.V(3).Infof("enqueue delete anp %s", anp.Name)
+	c.deleteAnpQueue.Add(anp.Name)
+	if anpHasPassRule(anp) {
+		c.enqueueAnpsAfter(anp.Spec.Priority)
+	}
+}
+
+func (c *Controller) enqueueUpdateAnp(oldObj, newObj interface{}) {
+	oldAnp := oldObj.(*anpv1alpha1.AdminNetworkPolicy)
+	newAnp := newObj.(*anpv1alpha1.AdminNetworkPolicy)
+	if reflect.DeepEqual(oldAnp.Spec, newAnp.Spec) &&
+		reflect.DeepEqual(oldAnp.Annotations, newAnp.Annotations) {
+		return
+	}
+
+	klog.V(3).Infof("enqueue update anp %s", newAnp.Name)
+	c.updateAnpQueue.Add(newAnp.Name)
+	// acls of the policies evaluated later exclude the traffic passed by this one
+	if anpHasPassRule(oldAnp) || anpHasPassRule(newAnp) || oldAnp.Spec.Priority != newAnp.Spec.Priority {
+		priority := oldAnp.Spec.Priority
+		if newAnp.Spec.Priority < priority {
+			priority = newAnp.Spec.Priority
+		}
+		c.enqueueAnpsAfter(priority)
+	}
+}
+
+// enqueueAnpsAfter enqueues the admin network policies evaluated after the given priority
+func (c *Controller) enqueueAnpsAfter(priority int32) {
+	anps, err := c.anpsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list admin network policies: %v", err)
+		return
+	}
+	for _, anp := range anps {
+		if anp.Spec.Priority > priority {
+			klog.V(3).Infof("enqueue update anp %s", anp.Name)
+			c.updateAnpQueue.Add(anp.Name)
+		}
+	}
+}
+
+func (c *Controller) runUpdateAnpWorker() {
+	for c.processNextUpdateAnpWorkItem() {
+	}
+}
+
+func (c *Controller) runDeleteAnpWorker() {
+	for c.processNextDeleteAnpWorkItem() {
+	}
+}
+
+func (c *Controller) processNextUpdateAnpWorkItem() bool {
+	obj, shutdown := c.updateAnpQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.updateAnpQueue.Done(obj)
+		var key string
+		var ok bool
+		if key, ok = obj.(string); !ok {
+			c.updateAnpQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleUpdateAnp(key); err != nil {
+			c.updateAnpQueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing admin network policy %s: %v, requeuing", key, err)
+		}
+		c.updateAnpQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+func (c *Controller) processNextDeleteAnpWorkItem() bool {
+	obj, shutdown := c.deleteAnpQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.deleteAnpQueue.Done(obj)
+		var key string
+		var ok bool
+		if key, ok = obj.(string); !ok {
+			c.deleteAnpQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleDeleteAnp(key); err != nil {
+			c.deleteAnpQueue.AddRateLimited(key)
+			return fmt.Errorf("error deleting admin network policy %s: %v, requeuing", key, err)
+		}
+		c.deleteAnpQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+func (c *Controller) handleUpdateAnp(key string) error {
+	c.anpKeyMutex.LockKey(key)
+	defer func() { _ = c.anpKeyMutex.UnlockKey(key) }()
+	klog.Infof("handle add/update admin network policy %s", key)
+
+	anp, err := c.anpsLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+
+	if err = util.ValidateAdminNetworkPolicy(anp); err != nil {
+		klog.Errorf("invalid admin network policy %s: %v", key, err)
+		c.recorder.Eventf(anp, corev1.EventTypeWarning, "ValidateAnpFailed", err.Error())
+		return nil
+	}
+
+	defer func() {
+		if err != nil {
+			c.recorder.Eventf(anp, corev1.EventTypeWarning, "CreateACLFailed", err.Error())
+		}
+	}()
+
+	// traffic passed by the policies evaluated before must not be matched by this one
+	ingressExcludes, egressExcludes, err := c.anpPassMatches(anp.Spec.Priority)
+	if err != nil {
+		klog.Errorf("failed to get the traffic passed before admin network policy %s: %v", key, err)
+		return err
+	}
+
+	pgName := anpPortGroupName(anp.Name)
+	maxPriority := util.AnpACLMaxPriority - int(anp.Spec.Priority)*util.AnpMaxRules
+	logEnable := anp.Annotations[util.NetworkPolicyLogAnnotation] == "true"
+	if err = c.syncAdminPolicy(anpKey, anp.Name, pgName, anp.Spec.Subject, anpIngressRules(anp), anpEgressRules(anp), maxPriority, logEnable, ingressExcludes, egressExcludes); err != nil {
+		klog.Errorf("failed to sync admin network policy %s: %v", key, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) handleDeleteAnp(key string) error {
+	c.anpKeyMutex.LockKey(key)
+	defer func() { _ = c.anpKeyMutex.UnlockKey(key) }()
+	klog.Infof("handle delete admin network policy %s", key)
+
+	return c.deleteAdminPolicy(anpKey, key, anpPortGroupName(key))
+}
+
+// anpPassMatches returns the acl matches, keyed by ip protocol, of the Pass rules of the admin network
+// policies evaluated before the given priority
+func (c *Controller) anpPassMatches(priority int32) (map[string][]string, map[string][]string, error) {
+	anps, err := c.anpsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list admin network policies: %v", err)
+		return nil, nil, err
+	}
+
+	ingressMatches, egressMatches := make(map[string][]string), make(map[string][]string)
+	for _, anp := range anps {
+		if anp.Spec.Priority >= priority || !anpHasPassRule(anp) {
+			continue
+		}
+
+		pgName := anpPortGroupName(anp.Name)
+		subjectNamespaces, err := c.selectAdminPolicySubjectNamespaces(anp.Spec.Subject)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, protocol := range c.adminPolicyProtocols() {
+			for idx, rule := range anpIngressRules(anp) {
+				if rule.action != anpv1alpha1.AdminNetworkPolicyRuleActionPass {
+					continue
+				}
+				asName := adminPolicyAddressSetName(pgName, "ingress", idx, protocol)
+				ingressMatches[protocol] = append(ingressMatches[protocol], ovs.NewAnpACLMatch(pgName, asName, protocol, ovnnb.ACLDirectionToLport, rule.ports, c.namedPortMapOfNamespaces(subjectNamespaces))...)
+			}
+			for idx, rule := range anpEgressRules(anp) {
+				if rule.action != anpv1alpha1.AdminNetworkPolicyRuleActionPass {
+					continue
+				}
+				_, peerNamespaces, err := c.fetchAdminPolicyPeerAddresses(rule.peers, protocol)
+				if err != nil {
+					return nil, nil, err
+				}
+				asName := adminPolicyAddressSetName(pgName, "egress", idx, protocol)
+				egressMatches[protocol] = append(egressMatches[protocol], ovs.NewAnpACLMatch(pgName, asName, protocol, ovnnb.ACLDirectionFromLport, rule.ports, c.namedPortMapOfNamespaces(peerNamespaces))...)
+			}
+		}
+	}
+	return ingressMatches, egressMatches, nil
+}
+
+// syncAdminPolicy creates the port group, address sets and acls of an admin network policy or a baseline
+// admin network policy, the rules are evaluated in order starting from maxPriority
+func (c *Controller) syncAdminPolicy(policyKey, name, pgName string, subject anpv1alpha1.AdminNetworkPolicySubject, ingressRules, egressRules []adminPolicyRule, maxPriority int, logEnable bool, ingressExcludes, egressExcludes map[string][]string) error {
+	externalIDs := map[string]string{policyKey: name}
+	if err := c.OVNNbClient.CreatePortGroup(pgName, externalIDs); err != nil {
+		klog.Errorf("create port group for %s %s: %v", policyKey, name, err)
+		return err
+	}
+
+	subjectNamespaces, err := c.selectAdminPolicySubjectNamespaces(subject)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	podSelector := &metav1.LabelSelector{}
+	if subject.Pods != nil {
+		podSelector = &subject.Pods.PodSelector
+	}
+	var ports []string
+	for _, ns := range subjectNamespaces {
+		nsPorts, _, err := c.fetchSelectedPorts(ns, podSelector)
+		if err != nil {
+			klog.Errorf("fetch ports belongs to %s %s: %v", policyKey, name, err)
+			return err
+		}
+		ports = append(ports, nsPorts...)
+	}
+	if err = c.OVNNbClient.PortGroupSetPorts(pgName, ports); err != nil {
+		klog.Errorf("failed to set ports of port group %s to %v: %v", pgName, ports, err)
+		return err
+	}
+
+	asNames := make(map[string]bool)
+	for _, isIngress := range []bool{true, false} {
+		direction, aclDirection, rules, excludes := "ingress", ovnnb.ACLDirectionToLport, ingressRules, ingressExcludes
+		if !isIngress {
+			direction, aclDirection, rules, excludes = "egress", ovnnb.ACLDirectionFromLport, egressRules, egressExcludes
+		}
+
+		// put clear acl and update acl in a single transaction to imitate update acl
+		ops, err := c.OVNNbClient.DeleteAclsOps(pgName, portGroupKey, aclDirection, nil)
+		if err != nil {
+			klog.Errorf("generate operations that clear %s %s %s acls: %v", policyKey, name, direction, err)
+			return err
+		}
+
+		for _, protocol := range c.adminPolicyProtocols() {
+			// do not modify the excludes of the other policies
+			excludes := append([]string{}, excludes[protocol]...)
+			for idx, rule := range rules {
+				addresses, peerNamespaces, err := c.fetchAdminPolicyPeerAddresses(rule.peers, protocol)
+				if err != nil {
+					klog.Errorf("failed to fetch peer addresses of %s %s: %v", policyKey, name, err)
+					return err
+				}
+
+				asName := adminPolicyAddressSetName(pgName, direction, idx, protocol)
+				asNames[asName] = true
+				if err = c.OVNNbClient.CreateAddressSet(asName, externalIDs); err != nil {
+					klog.Errorf("create address set %s for %s %s: %v", asName, policyKey, name, err)
+					return err
+				}
+				if err = c.OVNNbClient.AddressSetUpdateAddress(asName, addresses...); err != nil {
+					klog.Errorf("set %s addresses to address set %s: %v", direction, asName, err)
+					return err
+				}
+
+				// named ports of ingress rules are the ones of the subject pods
+				namedPortMap := c.namedPortMapOfNamespaces(subjectNamespaces)
+				if !isIngress {
+					namedPortMap = c.namedPortMapOfNamespaces(peerNamespaces)
+				}
+
+				var aclAction ovnnb.ACLAction
+				switch rule.action {
+				case anpv1alpha1.AdminNetworkPolicyRuleActionAllow:
+					aclAction = ovnnb.ACLActionAllowRelated
+				case anpv1alpha1.AdminNetworkPolicyRuleActionDeny:
+					aclAction = ovnnb.ACLActionDrop
+				case anpv1alpha1.AdminNetworkPolicyRuleActionPass:
+					// ovn acls have no tiers, traffic passed to network policies is excluded from all the rules evaluated later
+					excludes = append(excludes, ovs.NewAnpACLMatch(pgName, asName, protocol, aclDirection, rule.ports, namedPortMap)...)
+					continue
+				}
+
+				ruleOps, err := c.OVNNbClient.UpdateAnpRuleACLOps(pgName, asName, protocol, maxPriority-idx, aclAction, logEnable, isIngress, rule.ports, namedPortMap, excludes)
+				if err != nil {
+					klog.Errorf("generate operations that add %s acls to %s %s: %v", direction, policyKey, name, err)
+					return err
+				}
+				ops = append(ops, ruleOps...)
+			}
+		}
+
+		if err = c.OVNNbClient.Transact("add-"+direction+"-acls", ops); err != nil {
+			return fmt.Errorf("add %s acls to %s: %v", direction, pgName, err)
+		}
+	}
+
+	ass, err := c.OVNNbClient.ListAddressSets(externalIDs)
+	if err != nil {
+		klog.Errorf("list address sets of %s %s: %v", policyKey, name, err)
+		return err
+	}
+	for _, as := range ass {
+		if asNames[as.Name] {
+			continue
+		}
+		if err = c.OVNNbClient.DeleteAddressSet(as.Name); err != nil {
+			klog.Errorf("failed to delete address set %s of %s %s: %v", as.Name, policyKey, name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Controller) deleteAdminPolicy(policyKey, name, pgName string) error {
+	if err := c.OVNNbClient.DeletePortGroup(pgName); err != nil {
+		klog.Errorf("delete %s %s port group: %v", policyKey, name, err)
+		return err
+	}
+	if err := c.OVNNbClient.DeleteAddressSets(map[string]string{policyKey: name}); err != nil {
+		klog.Errorf("delete %s %s address sets: %v", policyKey, name, err)
+		return err
+	}
+	return nil
+}
+
+// adminPolicyProtocols returns the ip protocols acls of admin policies are created for
+func (c *Controller) adminPolicyProtocols() []string {
+	if protocol := util.CheckProtocol(c.config.DefaultCIDR); protocol != kubeovnv1.ProtocolDual {
+		return []string{protocol}
+	}
+	return []string{kubeovnv1.ProtocolIPv4, kubeovnv1.ProtocolIPv6}
+}
+
+func (c *Controller) namedPortMapOfNamespaces(namespaces []string) map[string]*util.NamedPortInfo {
+	namedPortMap := make(map[string]*util.NamedPortInfo)
+	for _, ns := range namespaces {
+		for portName, info := range c.namedPort.GetNamedPortByNs(ns) {
+			namedPortMap[portName] = info
+		}
+	}
+	return namedPortMap
+}
+
+func (c *Controller) selectNamespaces(selector *metav1.LabelSelector) ([]string, error) {
+	// a nil selector selects nothing
+	if selector == nil {
+		return nil, nil
+	}
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("error creating label selector, %v", err)
+	}
+	nss, err := c.namespacesLister.List(sel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ns, %v", err)
+	}
+	names := make([]string, 0, len(nss))
+	for _, ns := range nss {
+		names = append(names, ns.Name)
+	}
+	return names, nil
+}
+
+func (c *Controller) selectAdminPolicySubjectNamespaces(subject anpv1alpha1.AdminNetworkPolicySubject) ([]string, error) {
+	if subject.Pods != nil {
+		return c.selectNamespaces(&subject.Pods.NamespaceSelector)
+	}
+	return c.selectNamespaces(subject.Namespaces)
+}
+
+// fetchAdminPolicyPeerAddresses returns the pod addresses of the given protocol and the namespaces selected by the peers
+func (c *Controller) fetchAdminPolicyPeerAddresses(peers []anpv1alpha1.AdminNetworkPolicyPeer, protocol string) ([]string, []string, error) {
+	var addresses, selectedNs []string
+	for _, peer := range peers {
+		var nsSelector *metav1.LabelSelector
+		podSelector := &metav1.LabelSelector{}
+		switch {
+		case peer.Namespaces != nil:
+			nsSelector = peer.Namespaces.NamespaceSelector
+		case peer.Pods != nil:
+			nsSelector = peer.Pods.Namespaces.NamespaceSelector
+			podSelector = &peer.Pods.PodSelector
+		}
+
+		namespaces, err := c.selectNamespaces(nsSelector)
+		if err != nil {
+			return nil, nil, err
+		}
+		sel, err := metav1.LabelSelectorAsSelector(podSelector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error creating label selector, %v", err)
+		}
+
+		for _, ns := range namespaces {
+			pods, err := c.podsLister.Pods(ns).List(sel)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to list pod, %v", err)
+			}
+			for _, pod := range pods {
+				if pod.Spec.HostNetwork {
+					continue
+				}
+				podNets, err := c.getPodKubeovnNets(pod)
+				if err != nil {
+					klog.Errorf("failed to get pod nets %v", err)
+					return nil, nil, err
+				}
+				for _, podNet := range podNets {
+					podIPAnnotation := pod.Annotations[fmt.Sprintf(util.IPAddressAnnotationTemplate, podNet.ProviderName)]
+					for _, podIP := range strings.Split(podIPAnnotation, ",") {
+						if podIP != "" && util.CheckProtocol(podIP) == protocol {
+							addresses = append(addresses, podIP)
+						}
+					}
+				}
+			}
+		}
+		selectedNs = append(selectedNs, namespaces...)
+	}
+	return util.UniqString(addresses), util.UniqString(selectedNs), nil
+}
+
+func isPodMatchAdminPolicy(pod *corev1.Pod, podNs *corev1.Namespace, subject anpv1alpha1.AdminNetworkPolicySubject, ingressRules, egressRules []adminPolicyRule) bool {
+	if subject.Pods != nil {
+		if labelSelectorMatches(&subject.Pods.NamespaceSelector, podNs.Labels) && labelSelectorMatches(&subject.Pods.PodSelector, pod.Labels) {
+			return true
+		}
+	} else if labelSelectorMatches(subject.Namespaces, podNs.Labels) {
+		return true
+	}
+
+	for _, rule := range append(ingressRules, egressRules...) {
+		for _, peer := range rule.peers {
+			switch {
+			case peer.Namespaces != nil:
+				if labelSelectorMatches(peer.Namespaces.NamespaceSelector, podNs.Labels) {
+					return true
+				}
+			case peer.Pods != nil:
+				if labelSelectorMatches(peer.Pods.Namespaces.NamespaceSelector, podNs.Labels) && labelSelectorMatches(&peer.Pods.PodSelector, pod.Labels) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func isNamespaceMatchAdminPolicy(ns *corev1.Namespace, subject anpv1alpha1.AdminNetworkPolicySubject, ingressRules, egressRules []adminPolicyRule) bool {
+	if subject.Pods != nil {
+		if labelSelectorMatches(&subject.Pods.NamespaceSelector, ns.Labels) {
+			return true
+		}
+	} else if labelSelectorMatches(subject.Namespaces, ns.Labels) {
+		return true
+	}
+
+	for _, rule := range append(ingressRules, egressRules...) {
+		for _, peer := range rule.peers {
+			switch {
+			case peer.Namespaces != nil:
+				if labelSelectorMatches(peer.Namespaces.NamespaceSelector, ns.Labels) {
+					return true
+				}
+			case peer.Pods != nil:
+				if labelSelectorMatches(peer.Pods.Namespaces.NamespaceSelector, ns.Labels) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func labelSelectorMatches(selector *metav1.LabelSelector, objLabels map[string]string) bool {
+	if selector == nil {
+		return false
+	}
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return sel.Matches(labels.Set(objLabels))
+}
+
+func (c *Controller) podMatchAdminNetworkPolicies(pod *corev1.Pod) []string {
+	podNs, err := c.namespacesLister.Get(pod.Namespace)
+	if err != nil {
+		klog.Errorf("failed to get namespace %s: %v", pod.Namespace, err)
+		utilruntime.HandleError(err)
+		return nil
+	}
+
+	anps, err := c.anpsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list admin network policies: %v", err)
+		utilruntime.HandleError(err)
+		return nil
+	}
+
+	match := []string{}
+	for _, anp := range anps {
+		if isPodMatchAdminPolicy(pod, podNs, anp.Spec.Subject, anpIngressRules(anp), anpEgressRules(anp)) {
+			match = append(match, anp.Name)
+		}
+	}
+	return match
+}
+
+func (c *Controller) namespaceMatchAdminNetworkPolicies(ns *corev1.Namespace) []string {
+	anps, err := c.anpsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list admin network policies: %v", err)
+		utilruntime.HandleError(err)
+		return nil
+	}
+
+	match := []string{}
+	for _, anp := range anps {
+		if isNamespaceMatchAdminPolicy(ns, anp.Spec.Subject, anpIngressRules(anp), anpEgressRules(anp)) {
+			match = append(match, anp.Name)
+		}
+	}
+	return match
+}
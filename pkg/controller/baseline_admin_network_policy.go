@@ -0,0 +1,223 @@
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	anpv1alpha1 "sigs.k8s.io/network-policy-api/apis/v1alpha1"
+
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func banpPortGroupName(name string) string {
+	return strings.ReplaceAll("banp."+name, "-", ".")
+}
+
+func banpIngressRules(banp *anpv1alpha1.BaselineAdminNetworkPolicy) []adminPolicyRule {
+	rules := make([]adminPolicyRule, 0, len(banp.Spec.Ingress))
+	for _, r := range banp.Spec.Ingress {
+		rule := adminPolicyRule{action: anpv1alpha1.AdminNetworkPolicyRuleAction(r.Action), peers: r.From}
+		if r.Ports != nil {
+			rule.ports = *r.Ports
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func banpEgressRules(banp *anpv1alpha1.BaselineAdminNetworkPolicy) []adminPolicyRule {
+	rules := make([]adminPolicyRule, 0, len(banp.Spec.Egress))
+	for _, r := range banp.Spec.Egress {
+		rule := adminPolicyRule{action: anpv1alpha1.AdminNetworkPolicyRuleAction(r.Action), peers: r.To}
+		if r.Ports != nil {
+			rule.ports = *r.Ports
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func (c *Controller) enqueueAddBanp(obj interface{}) {
+	banp := obj.(*anpv1alpha1.BaselineAdminNetworkPolicy)
+	klog.V(3).Infof("enqueue add banp %s", banp.Name)
+	c.updateBanpQueue.Add(banp.Name)
+}
+
+func (c *Controller) enqueueDeleteBanp(obj interface{}) {
+	var key string
+	var err error
+	if key, err = cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue delete banp %s", key)
+	c.deleteBanpQueue.Add(key)
+}
+
+func (c *Controller) enqueueUpdateBanp(oldObj, newObj interface{}) {
+	oldBanp := oldObj.(*anpv1alpha1.BaselineAdminNetworkPolicy)
+	newBanp := newObj.(*anpv1alpha1.BaselineAdminNetworkPolicy)
+	if !reflect.DeepEqual(oldBanp.Spec, newBanp.Spec) ||
+		!reflect.DeepEqual(oldBanp.Annotations, newBanp.Annotations) {
+		klog.V(3).Infof("enqueue update banp %s", newBanp.Name)
+		c.updateBanpQueue.Add(newBanp.Name)
+	}
+}
+
+func (c *Controller) runUpdateBanpWorker() {
+	for c.processNextUpdateBanpWorkItem() {
+	}
+}
+
+func (c *Controller) runDeleteBanpWorker() {
+	for c.processNextDeleteBanpWorkItem() {
+	}
+}
+
+func (c *Controller) processNextUpdateBanpWorkItem() bool {
+	obj, shutdown := c.updateBanpQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.updateBanpQueue.Done(obj)
+		var key string
+		var ok bool
+		if key, ok = obj.(string); !ok {
+			c.updateBanpQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleUpdateBanp(key); err != nil {
+			c.updateBanpQueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing baseline admin network policy %s: %v, requeuing", key, err)
+		}
+		c.updateBanpQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+func (c *Controller) processNextDeleteBanpWorkItem() bool {
+	obj, shutdown := c.deleteBanpQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.deleteBanpQueue.Done(obj)
+		var key string
+		var ok bool
+		if key, ok = obj.(string); !ok {
+			c.deleteBanpQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleDeleteBanp(key); err != nil {
+			c.deleteBanpQueue.AddRateLimited(key)
+			return fmt.Errorf("error deleting baseline admin network policy %s: %v, requeuing", key, err)
+		}
+		c.deleteBanpQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+func (c *Controller) handleUpdateBanp(key string) error {
+	c.banpKeyMutex.LockKey(key)
+	defer func() { _ = c.banpKeyMutex.UnlockKey(key) }()
+	klog.Infof("handle add/update baseline admin network policy %s", key)
+
+	banp, err := c.banpsLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+
+	if err = util.ValidateBaselineAdminNetworkPolicy(banp); err != nil {
+		klog.Errorf("invalid baseline admin network policy %s: %v", key, err)
+		c.recorder.Eventf(banp, corev1.EventTypeWarning, "ValidateBanpFailed", err.Error())
+		return nil
+	}
+
+	defer func() {
+		if err != nil {
+			c.recorder.Eventf(banp, corev1.EventTypeWarning, "CreateACLFailed", err.Error())
+		}
+	}()
+
+	logEnable := banp.Annotations[util.NetworkPolicyLogAnnotation] == "true"
+	if err = c.syncAdminPolicy(banpKey, banp.Name, banpPortGroupName(banp.Name), banp.Spec.Subject, banpIngressRules(banp), banpEgressRules(banp), util.BanpACLMaxPriority, logEnable, nil, nil); err != nil {
+		klog.Errorf("failed to sync baseline admin network policy %s: %v", key, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) handleDeleteBanp(key string) error {
+	c.banpKeyMutex.LockKey(key)
+	defer func() { _ = c.banpKeyMutex.UnlockKey(key) }()
+	klog.Infof("handle delete baseline admin network policy %s", key)
+
+	return c.deleteAdminPolicy(banpKey, key, banpPortGroupName(key))
+}
+
+func (c *Controller) podMatchBaselineAdminNetworkPolicies(pod *corev1.Pod) []string {
+	podNs, err := c.namespacesLister.Get(pod.Namespace)
+	if err != nil {
+		klog.Errorf("failed to get namespace %s: %v", pod.Namespace, err)
+		utilruntime.HandleError(err)
+		return nil
+	}
+
+	banps, err := c.banpsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list baseline admin network policies: %v", err)
+		utilruntime.HandleError(err)
+		return nil
+	}
+
+	match := []string{}
+	for _, banp := range banps {
+		if isPodMatchAdminPolicy(pod, podNs, banp.Spec.Subject, banpIngressRules(banp), banpEgressRules(banp)) {
+			match = append(match, banp.Name)
+		}
+	}
+	return match
+}
+
+func (c *Controller) namespaceMatchBaselineAdminNetworkPolicies(ns *corev1.Namespace) []string {
+	banps, err := c.banpsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list baseline admin network policies: %v", err)
+		utilruntime.HandleError(err)
+		return nil
+	}
+
+	match := []string{}
+	for _, banp := range banps {
+		if isNamespaceMatchAdminPolicy(ns, banp.Spec.Subject, banpIngressRules(banp), banpEgressRules(banp)) {
+			match = append(match, banp.Name)
+		}
+	}
+	return match
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"flag"
 	"fmt"
+	"net"
 	"os"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog/v2"
 	"kubevirt.io/client-go/kubecli"
+	anpclientset "sigs.k8s.io/network-policy-api/pkg/client/clientset/versioned"
 
 	clientset "github.com/kubeovn/kube-ovn/pkg/client/clientset/versioned"
 	"github.com/kubeovn/kube-ovn/pkg/util"
@@ -37,6 +39,7 @@ type Configuration struct {
 	// with no timeout
 	KubeFactoryClient    kubernetes.Interface
 	KubeOvnFactoryClient clientset.Interface
+	AnpClient            anpclientset.Interface
 
 	DefaultLogicalSwitch      string
 	DefaultCIDR               string
@@ -66,10 +69,12 @@ type Configuration struct {
 
 	PodDefaultFipType string
 
-	WorkerNum       int
-	PprofPort       int
-	EnablePprof     bool
-	NodePgProbeTime int
+	WorkerNum   int
+	PprofPort   int
+	EnablePprof bool
+	// PolicyVerdictPort is the port of the policy verdict api served on the loopback address
+	PolicyVerdictPort int
+	NodePgProbeTime   int
 
 	NetworkType             string
 	DefaultProviderName     string
@@ -81,6 +86,7 @@ type Configuration struct {
 
 	EnableLb          bool
 	EnableNP          bool
+	EnableANP         bool
 	EnableEipSnat     bool
 	EnableExternalVpc bool
 	EnableEcmp        bool
@@ -101,6 +107,12 @@ type Configuration struct {
 	BfdDetectMult int
 
 	NodeLocalDNSIP string
+
+	IPAMCheckpointPath     string
+	IPAMCheckpointInterval int
+
+	VpcPeeringCIDR    string
+	VpcTransitHubCIDR string
 }
 
 // ParseFlags parses cmd args then init kubeclient and conf
@@ -137,10 +149,11 @@ func ParseFlags() (*Configuration, error) {
 		argClusterUDPSessionLoadBalancer  = pflag.String("cluster-udp-session-loadbalancer", "cluster-udp-session-loadbalancer", "The name for cluster udp session loadbalancer")
 		argClusterSctpSessionLoadBalancer = pflag.String("cluster-sctp-session-loadbalancer", "cluster-sctp-session-loadbalancer", "The name for cluster sctp session loadbalancer")
 
-		argWorkerNum       = pflag.Int("worker-num", 3, "The parallelism of each worker")
-		argEnablePprof     = pflag.Bool("enable-pprof", false, "Enable pprof")
-		argPprofPort       = pflag.Int("pprof-port", 10660, "The port to get profiling data")
-		argNodePgProbeTime = pflag.Int("nodepg-probe-time", 1, "The probe interval for node port-group, the unit is minute")
+		argWorkerNum         = pflag.Int("worker-num", 3, "The parallelism of each worker")
+		argEnablePprof       = pflag.Bool("enable-pprof", false, "Enable pprof")
+		argPprofPort         = pflag.Int("pprof-port", 10660, "The port to get profiling data")
+		argPolicyVerdictPort = pflag.Int("policy-verdict-port", 10666, "The port of the policy verdict api, which is only served on the loopback address")
+		argNodePgProbeTime   = pflag.Int("nodepg-probe-time", 1, "The probe interval for node port-group, the unit is minute")
 
 		argNetworkType             = pflag.String("network-type", util.NetworkTypeGeneve, "The ovn network type")
 		argDefaultProviderName     = pflag.String("default-provider-name", "provider", "The vlan or vxlan type default provider interface name")
@@ -153,6 +166,7 @@ func ParseFlags() (*Configuration, error) {
 		argPodDefaultFipType       = pflag.String("pod-default-fip-type", "", "The type of fip bind to pod automatically: iptables")
 		argEnableLb                = pflag.Bool("enable-lb", true, "Enable load balancer")
 		argEnableNP                = pflag.Bool("enable-np", true, "Enable network policy support")
+		argEnableANP               = pflag.Bool("enable-anp", false, "Enable admin network policy and baseline admin network policy support")
 		argEnableEipSnat           = pflag.Bool("enable-eip-snat", true, "Enable EIP and SNAT")
 		argEnableExternalVpc       = pflag.Bool("enable-external-vpc", true, "Enable external vpc support")
 		argEnableEcmp              = pflag.Bool("enable-ecmp", false, "Enable ecmp route for centralized subnet")
@@ -172,6 +186,12 @@ func ParseFlags() (*Configuration, error) {
 		argBfdMinTx      = pflag.Int("bfd-min-tx", 100, "This is the minimum interval, in milliseconds, ovn would like to use when transmitting BFD Control packets")
 		argBfdMinRx      = pflag.Int("bfd-min-rx", 100, "This is the minimum interval, in milliseconds, between received BFD Control packets")
 		argBfdDetectMult = pflag.Int("detect-mult", 3, "The negotiated transmit interval, multiplied by this value, provides the Detection Time for the receiving system in Asynchronous mode.")
+
+		argIPAMCheckpointPath     = pflag.String("ipam-checkpoint-path", "", "The file to persist IPAM state to, so that only changes since the last checkpoint are replayed on startup. Disabled if empty")
+		argIPAMCheckpointInterval = pflag.Int("ipam-checkpoint-interval", 60, "The interval between IPAM checkpoints, default 60 seconds")
+
+		argVpcPeeringCIDR    = pflag.String("vpc-peering-cidr", "100.127.0.0/16", "The ipv4 cidr the interconnect addresses of vpc peering connections are allocated from, default: 100.127.0.0/16")
+		argVpcTransitHubCIDR = pflag.String("vpc-transit-hub-cidr", "100.126.0.0/16", "The ipv4 cidr the interconnect addresses of vpc transit hub attachments are allocated from, default: 100.126.0.0/16")
 	)
 
 	klogFlags := flag.NewFlagSet("klog", flag.ExitOnError)
@@ -220,6 +240,7 @@ func ParseFlags() (*Configuration, error) {
 		WorkerNum:                      *argWorkerNum,
 		EnablePprof:                    *argEnablePprof,
 		PprofPort:                      *argPprofPort,
+		PolicyVerdictPort:              *argPolicyVerdictPort,
 		NetworkType:                    *argNetworkType,
 		DefaultVlanID:                  *argDefaultVlanID,
 		LsDnatModDlDst:                 *argLsDnatModDlDst,
@@ -233,6 +254,7 @@ func ParseFlags() (*Configuration, error) {
 		PodDefaultFipType:              *argPodDefaultFipType,
 		EnableLb:                       *argEnableLb,
 		EnableNP:                       *argEnableNP,
+		EnableANP:                      *argEnableANP,
 		EnableEipSnat:                  *argEnableEipSnat,
 		EnableExternalVpc:              *argEnableExternalVpc,
 		ExternalGatewayConfigNS:        *argExternalGatewayConfigNS,
@@ -250,12 +272,34 @@ func ParseFlags() (*Configuration, error) {
 		BfdMinRx:                       *argBfdMinRx,
 		BfdDetectMult:                  *argBfdDetectMult,
 		NodeLocalDNSIP:                 *argNodeLocalDNSIP,
+		IPAMCheckpointPath:             *argIPAMCheckpointPath,
+		IPAMCheckpointInterval:         *argIPAMCheckpointInterval,
+		VpcPeeringCIDR:                 *argVpcPeeringCIDR,
+		VpcTransitHubCIDR:              *argVpcTransitHubCIDR,
 	}
 
 	if config.NetworkType == util.NetworkTypeVlan && config.DefaultHostInterface == "" {
 		return nil, fmt.Errorf("no host nic for vlan")
 	}
 
+	_, peeringCIDR, err := net.ParseCIDR(config.VpcPeeringCIDR)
+	if err != nil || peeringCIDR.IP.To4() == nil {
+		return nil, fmt.Errorf("invalid vpc peering cidr %s, an ipv4 cidr is required", config.VpcPeeringCIDR)
+	}
+	if ones, _ := peeringCIDR.Mask.Size(); ones > 30 {
+		return nil, fmt.Errorf("vpc peering cidr %s is too small, the prefix length must not exceed 30", config.VpcPeeringCIDR)
+	}
+	_, transitHubCIDR, err := net.ParseCIDR(config.VpcTransitHubCIDR)
+	if err != nil || transitHubCIDR.IP.To4() == nil {
+		return nil, fmt.Errorf("invalid vpc transit hub cidr %s, an ipv4 cidr is required", config.VpcTransitHubCIDR)
+	}
+	if ones, _ := transitHubCIDR.Mask.Size(); ones > 30 {
+		return nil, fmt.Errorf("vpc transit hub cidr %s is too small, the prefix length must not exceed 30", config.VpcTransitHubCIDR)
+	}
+	if util.CIDROverlap(config.VpcPeeringCIDR, config.VpcTransitHubCIDR) {
+		return nil, fmt.Errorf("vpc transit hub cidr %s overlaps with vpc peering cidr %s", config.VpcTransitHubCIDR, config.VpcPeeringCIDR)
+	}
+
 	if config.DefaultGateway == "" {
 		gw, err := util.GetGwByCidr(config.DefaultCIDR)
 		if err != nil {
@@ -377,6 +421,13 @@ func (config *Configuration) initKubeFactoryClient() error {
 	}
 	config.KubeOvnFactoryClient = kubeOvnClient
 
+	anpClient, err := anpclientset.NewForConfig(cfg)
+	if err != nil {
+		klog.Errorf("init admin network policy client failed %v", err)
+		return err
+	}
+	config.AnpClient = anpClient
+
 	cfg.ContentType = "application/vnd.kubernetes.protobuf"
 	cfg.AcceptContentTypes = "application/vnd.kubernetes.protobuf,application/json"
 	kubeClient, err := kubernetes.NewForConfig(cfg)
@@ -23,6 +23,9 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/keymutex"
+	anpv1alpha1 "sigs.k8s.io/network-policy-api/apis/v1alpha1"
+	anpinformer "sigs.k8s.io/network-policy-api/pkg/client/informers/externalversions"
+	anplister "sigs.k8s.io/network-policy-api/pkg/client/listers/apis/v1alpha1"
 
 	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
 	kubeovninformer "github.com/kubeovn/kube-ovn/pkg/client/informers/externalversions"
@@ -39,6 +42,11 @@ const (
 	logicalRouterKey      = "lr"
 	portGroupKey          = "pg"
 	networkPolicyKey      = "np"
+	anpKey                = "anp"
+	banpKey               = "banp"
+	fqdnKey               = "fqdn"
+	addressGroupKey       = "address_group"
+	vpcEgressGatewayKey   = "vpc_egress_gateway"
 	sgKey                 = "sg"
 	associatedSgKeyPrefix = "associated_sg_"
 	sgsKey                = "security_groups"
@@ -88,6 +96,39 @@ type Controller struct {
 	updateVpcSnatQueue            workqueue.RateLimitingInterface
 	updateVpcSubnetQueue          workqueue.RateLimitingInterface
 	vpcNatGwKeyMutex              keymutex.KeyMutex
+	natGwRulesKeyMutex            keymutex.KeyMutex
+	natGwAgents                   *natGwAgents
+	natGwVrids                    *natGwVrids
+	snatPortRanges                *snatPortRanges
+
+	vpcEgressGatewaysLister          kubeovnlister.VpcEgressGatewayLister
+	vpcEgressGatewaysSynced          cache.InformerSynced
+	addOrUpdateVpcEgressGatewayQueue workqueue.RateLimitingInterface
+	delVpcEgressGatewayQueue         workqueue.RateLimitingInterface
+	vpcEgressGatewayKeyMutex         keymutex.KeyMutex
+
+	vpcEipsLister        kubeovnlister.VpcEipLister
+	vpcEipsSynced        cache.InformerSynced
+	syncVpcEipQueue      workqueue.RateLimitingInterface
+	vpcFipsLister        kubeovnlister.VpcFipLister
+	vpcFipsSynced        cache.InformerSynced
+	syncVpcFipQueue      workqueue.RateLimitingInterface
+	vpcDnatRulesLister   kubeovnlister.VpcDnatRuleLister
+	vpcDnatRulesSynced   cache.InformerSynced
+	syncVpcDnatRuleQueue workqueue.RateLimitingInterface
+	vpcSnatRulesLister   kubeovnlister.VpcSnatRuleLister
+	vpcSnatRulesSynced   cache.InformerSynced
+	syncVpcSnatRuleQueue workqueue.RateLimitingInterface
+
+	vpcPeeringConnectionsLister   kubeovnlister.VpcPeeringConnectionLister
+	vpcPeeringConnectionsSynced   cache.InformerSynced
+	syncVpcPeeringConnectionQueue workqueue.RateLimitingInterface
+	vpcPeeringBlocks              *vpcPeeringBlocks
+
+	vpcTransitHubsLister   kubeovnlister.VpcTransitHubLister
+	vpcTransitHubsSynced   cache.InformerSynced
+	syncVpcTransitHubQueue workqueue.RateLimitingInterface
+	vpcTransitHubBlocks    *vpcPeeringBlocks
 
 	switchLBRuleLister      kubeovnlister.SwitchLBRuleLister
 	switchLBRuleSynced      cache.InformerSynced
@@ -115,6 +156,13 @@ type Controller struct {
 	deleteIPPoolQueue       workqueue.RateLimitingInterface
 	ippoolKeyMutex          keymutex.KeyMutex
 
+	ipQuotaLister            kubeovnlister.IPQuotaLister
+	ipQuotaSynced            cache.InformerSynced
+	addOrUpdateIPQuotaQueue  workqueue.RateLimitingInterface
+	updateIPQuotaStatusQueue workqueue.RateLimitingInterface
+	deleteIPQuotaQueue       workqueue.RateLimitingInterface
+	ipQuotaKeyMutex          keymutex.KeyMutex
+
 	ipsLister kubeovnlister.IPLister
 	ipSynced  cache.InformerSynced
 
@@ -225,6 +273,18 @@ type Controller struct {
 	deleteNpQueue workqueue.RateLimitingInterface
 	npKeyMutex    keymutex.KeyMutex
 
+	anpsLister     anplister.AdminNetworkPolicyLister
+	anpsSynced     cache.InformerSynced
+	updateAnpQueue workqueue.RateLimitingInterface
+	deleteAnpQueue workqueue.RateLimitingInterface
+	anpKeyMutex    keymutex.KeyMutex
+
+	banpsLister     anplister.BaselineAdminNetworkPolicyLister
+	banpsSynced     cache.InformerSynced
+	updateBanpQueue workqueue.RateLimitingInterface
+	deleteBanpQueue workqueue.RateLimitingInterface
+	banpKeyMutex    keymutex.KeyMutex
+
 	sgsLister          kubeovnlister.SecurityGroupLister
 	sgSynced           cache.InformerSynced
 	addOrUpdateSgQueue workqueue.RateLimitingInterface
@@ -232,6 +292,14 @@ type Controller struct {
 	syncSgPortsQueue   workqueue.RateLimitingInterface
 	sgKeyMutex         keymutex.KeyMutex
 
+	fqdnCachesLister kubeovnlister.FqdnCacheLister
+	fqdnCachesSynced cache.InformerSynced
+	updateFqdnQueue  workqueue.RateLimitingInterface
+
+	addressGroupsLister     kubeovnlister.AddressGroupLister
+	addressGroupsSynced     cache.InformerSynced
+	updateAddressGroupQueue workqueue.RateLimitingInterface
+
 	qosPoliciesLister    kubeovnlister.QoSPolicyLister
 	qosPolicySynced      cache.InformerSynced
 	addQoSPolicyQueue    workqueue.RateLimitingInterface
@@ -245,11 +313,13 @@ type Controller struct {
 	informerFactory        kubeinformers.SharedInformerFactory
 	cmInformerFactory      kubeinformers.SharedInformerFactory
 	kubeovnInformerFactory kubeovninformer.SharedInformerFactory
+	anpInformerFactory     anpinformer.SharedInformerFactory
 }
 
 // Run creates and runs a new ovn controller
 func Run(ctx context.Context, config *Configuration) {
 	utilruntime.Must(kubeovnv1.AddToScheme(scheme.Scheme))
+	utilruntime.Must(anpv1alpha1.AddToScheme(scheme.Scheme))
 	klog.V(4).Info("Creating event broadcaster")
 	eventBroadcaster := record.NewBroadcasterWithCorrelatorOptions(record.CorrelatorOptions{BurstSize: 100})
 	eventBroadcaster.StartLogging(klog.Infof)
@@ -272,11 +342,23 @@ func Run(ctx context.Context, config *Configuration) {
 		kubeovninformer.WithTweakListOptions(func(listOption *metav1.ListOptions) {
 			listOption.AllowWatchBookmarks = true
 		}))
+	anpInformerFactory := anpinformer.NewSharedInformerFactoryWithOptions(config.AnpClient, 0,
+		anpinformer.WithTweakListOptions(func(listOption *metav1.ListOptions) {
+			listOption.AllowWatchBookmarks = true
+		}))
 
 	vpcInformer := kubeovnInformerFactory.Kubeovn().V1().Vpcs()
 	vpcNatGatewayInformer := kubeovnInformerFactory.Kubeovn().V1().VpcNatGateways()
+	vpcEgressGatewayInformer := kubeovnInformerFactory.Kubeovn().V1().VpcEgressGateways()
+	vpcEipInformer := kubeovnInformerFactory.Kubeovn().V1().VpcEips()
+	vpcFipInformer := kubeovnInformerFactory.Kubeovn().V1().VpcFips()
+	vpcDnatRuleInformer := kubeovnInformerFactory.Kubeovn().V1().VpcDnatRules()
+	vpcSnatRuleInformer := kubeovnInformerFactory.Kubeovn().V1().VpcSnatRules()
+	vpcPeeringConnectionInformer := kubeovnInformerFactory.Kubeovn().V1().VpcPeeringConnections()
+	vpcTransitHubInformer := kubeovnInformerFactory.Kubeovn().V1().VpcTransitHubs()
 	subnetInformer := kubeovnInformerFactory.Kubeovn().V1().Subnets()
 	ippoolInformer := kubeovnInformerFactory.Kubeovn().V1().IPPools()
+	ipQuotaInformer := kubeovnInformerFactory.Kubeovn().V1().IPQuotas()
 	ipInformer := kubeovnInformerFactory.Kubeovn().V1().IPs()
 	virtualIPInformer := kubeovnInformerFactory.Kubeovn().V1().Vips()
 	iptablesEipInformer := kubeovnInformerFactory.Kubeovn().V1().IptablesEIPs()
@@ -302,6 +384,10 @@ func Run(ctx context.Context, config *Configuration) {
 	ovnFipInformer := kubeovnInformerFactory.Kubeovn().V1().OvnFips()
 	ovnSnatRuleInformer := kubeovnInformerFactory.Kubeovn().V1().OvnSnatRules()
 	ovnDnatRuleInformer := kubeovnInformerFactory.Kubeovn().V1().OvnDnatRules()
+	fqdnCacheInformer := kubeovnInformerFactory.Kubeovn().V1().FqdnCaches()
+	addressGroupInformer := kubeovnInformerFactory.Kubeovn().V1().AddressGroups()
+	anpInformer := anpInformerFactory.Policy().V1alpha1().AdminNetworkPolicies()
+	banpInformer := anpInformerFactory.Policy().V1alpha1().BaselineAdminNetworkPolicies()
 
 	numKeyLocks := runtime.NumCPU() * 2
 	if numKeyLocks < config.WorkerNum*2 {
@@ -334,6 +420,39 @@ func Run(ctx context.Context, config *Configuration) {
 		updateVpcSnatQueue:            workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "UpdateVpcSnat"),
 		updateVpcSubnetQueue:          workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "UpdateVpcSubnet"),
 		vpcNatGwKeyMutex:              keymutex.NewHashed(numKeyLocks),
+		natGwRulesKeyMutex:            keymutex.NewHashed(numKeyLocks),
+		natGwAgents:                   newNatGwAgents(),
+		natGwVrids:                    newNatGwVrids(),
+		snatPortRanges:                newSnatPortRanges(),
+
+		vpcEgressGatewaysLister:          vpcEgressGatewayInformer.Lister(),
+		vpcEgressGatewaysSynced:          vpcEgressGatewayInformer.Informer().HasSynced,
+		addOrUpdateVpcEgressGatewayQueue: workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "AddOrUpdateVpcEgressGateway"),
+		delVpcEgressGatewayQueue:         workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "DeleteVpcEgressGateway"),
+		vpcEgressGatewayKeyMutex:         keymutex.NewHashed(numKeyLocks),
+
+		vpcEipsLister:        vpcEipInformer.Lister(),
+		vpcEipsSynced:        vpcEipInformer.Informer().HasSynced,
+		syncVpcEipQueue:      workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "SyncVpcEip"),
+		vpcFipsLister:        vpcFipInformer.Lister(),
+		vpcFipsSynced:        vpcFipInformer.Informer().HasSynced,
+		syncVpcFipQueue:      workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "SyncVpcFip"),
+		vpcDnatRulesLister:   vpcDnatRuleInformer.Lister(),
+		vpcDnatRulesSynced:   vpcDnatRuleInformer.Informer().HasSynced,
+		syncVpcDnatRuleQueue: workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "SyncVpcDnatRule"),
+		vpcSnatRulesLister:   vpcSnatRuleInformer.Lister(),
+		vpcSnatRulesSynced:   vpcSnatRuleInformer.Informer().HasSynced,
+		syncVpcSnatRuleQueue: workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "SyncVpcSnatRule"),
+
+		vpcPeeringConnectionsLister:   vpcPeeringConnectionInformer.Lister(),
+		vpcPeeringConnectionsSynced:   vpcPeeringConnectionInformer.Informer().HasSynced,
+		syncVpcPeeringConnectionQueue: workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "SyncVpcPeeringConnection"),
+		vpcPeeringBlocks:              newVpcPeeringBlocks(),
+
+		vpcTransitHubsLister:   vpcTransitHubInformer.Lister(),
+		vpcTransitHubsSynced:   vpcTransitHubInformer.Informer().HasSynced,
+		syncVpcTransitHubQueue: workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "SyncVpcTransitHub"),
+		vpcTransitHubBlocks:    newVpcPeeringBlocks(),
 
 		subnetsLister:           subnetInformer.Lister(),
 		subnetSynced:            subnetInformer.Informer().HasSynced,
@@ -350,6 +469,13 @@ func Run(ctx context.Context, config *Configuration) {
 		deleteIPPoolQueue:       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "DeleteIPPool"),
 		ippoolKeyMutex:          keymutex.NewHashed(numKeyLocks),
 
+		ipQuotaLister:            ipQuotaInformer.Lister(),
+		ipQuotaSynced:            ipQuotaInformer.Informer().HasSynced,
+		addOrUpdateIPQuotaQueue:  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "AddIPQuota"),
+		updateIPQuotaStatusQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "UpdateIPQuotaStatus"),
+		deleteIPQuotaQueue:       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "DeleteIPQuota"),
+		ipQuotaKeyMutex:          keymutex.NewHashed(numKeyLocks),
+
 		ipsLister: ipInformer.Lister(),
 		ipSynced:  ipInformer.Informer().HasSynced,
 
@@ -455,6 +581,14 @@ func Run(ctx context.Context, config *Configuration) {
 		delSgQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "DeleteSg"),
 		syncSgPortsQueue:   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "SyncSgPorts"),
 
+		fqdnCachesLister: fqdnCacheInformer.Lister(),
+		fqdnCachesSynced: fqdnCacheInformer.Informer().HasSynced,
+		updateFqdnQueue:  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "UpdateFqdn"),
+
+		addressGroupsLister:     addressGroupInformer.Lister(),
+		addressGroupsSynced:     addressGroupInformer.Informer().HasSynced,
+		updateAddressGroupQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "UpdateAddressGroup"),
+
 		ovnEipsLister:     ovnEipInformer.Lister(),
 		ovnEipSynced:      ovnEipInformer.Informer().HasSynced,
 		addOvnEipQueue:    workqueue.NewNamedRateLimitingQueue(custCrdRateLimiter, "AddOvnEip"),
@@ -484,6 +618,7 @@ func Run(ctx context.Context, config *Configuration) {
 		informerFactory:        informerFactory,
 		cmInformerFactory:      cmInformerFactory,
 		kubeovnInformerFactory: kubeovnInformerFactory,
+		anpInformerFactory:     anpInformerFactory,
 	}
 
 	var err error
@@ -514,6 +649,20 @@ func Run(ctx context.Context, config *Configuration) {
 		controller.npKeyMutex = keymutex.NewHashed(numKeyLocks)
 	}
 
+	if config.EnableANP {
+		controller.anpsLister = anpInformer.Lister()
+		controller.anpsSynced = anpInformer.Informer().HasSynced
+		controller.updateAnpQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "UpdateAnp")
+		controller.deleteAnpQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "DeleteAnp")
+		controller.anpKeyMutex = keymutex.NewHashed(numKeyLocks)
+
+		controller.banpsLister = banpInformer.Lister()
+		controller.banpsSynced = banpInformer.Informer().HasSynced
+		controller.updateBanpQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "UpdateBanp")
+		controller.deleteBanpQueue = workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "DeleteBanp")
+		controller.banpKeyMutex = keymutex.NewHashed(numKeyLocks)
+	}
+
 	defer controller.shutdown()
 	klog.Info("Starting OVN controller")
 
@@ -521,6 +670,9 @@ func Run(ctx context.Context, config *Configuration) {
 	controller.informerFactory.Start(ctx.Done())
 	controller.cmInformerFactory.Start(ctx.Done())
 	controller.kubeovnInformerFactory.Start(ctx.Done())
+	if controller.config.EnableANP {
+		controller.anpInformerFactory.Start(ctx.Done())
+	}
 
 	klog.Info("Waiting for informer caches to sync")
 	cacheSyncs := []cache.InformerSynced{
@@ -531,7 +683,10 @@ func Run(ctx context.Context, config *Configuration) {
 		controller.vlanSynced, controller.podsSynced, controller.namespacesSynced, controller.nodesSynced,
 		controller.serviceSynced, controller.endpointsSynced, controller.configMapsSynced,
 		controller.ovnEipSynced, controller.ovnFipSynced, controller.ovnSnatRuleSynced,
-		controller.ovnDnatRuleSynced,
+		controller.ovnDnatRuleSynced, controller.ipQuotaSynced, controller.fqdnCachesSynced,
+		controller.addressGroupsSynced, controller.vpcEgressGatewaysSynced,
+		controller.vpcEipsSynced, controller.vpcFipsSynced, controller.vpcDnatRulesSynced, controller.vpcSnatRulesSynced,
+		controller.vpcPeeringConnectionsSynced, controller.vpcTransitHubsSynced,
 	}
 	if controller.config.EnableLb {
 		cacheSyncs = append(cacheSyncs, controller.switchLBRuleSynced, controller.vpcDNSSynced)
@@ -539,9 +694,13 @@ func Run(ctx context.Context, config *Configuration) {
 	if controller.config.EnableNP {
 		cacheSyncs = append(cacheSyncs, controller.npsSynced)
 	}
+	if controller.config.EnableANP {
+		cacheSyncs = append(cacheSyncs, controller.anpsSynced, controller.banpsSynced)
+	}
 	if !cache.WaitForCacheSync(ctx.Done(), cacheSyncs...) {
 		util.LogFatalAndExit(nil, "failed to wait for caches to sync")
 	}
+	policyVerdictController.Store(controller)
 
 	if _, err = podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    controller.enqueueAddPod,
@@ -598,6 +757,81 @@ func Run(ctx context.Context, config *Configuration) {
 		util.LogFatalAndExit(err, "failed to add vpc nat gateway event handler")
 	}
 
+	if _, err = vpcEgressGatewayInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueAddVpcEgressGateway,
+		UpdateFunc: controller.enqueueUpdateVpcEgressGateway,
+		DeleteFunc: controller.enqueueDeleteVpcEgressGateway,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add vpc egress gateway event handler")
+	}
+	// the next hops of the policy routes are updated as soon as the status of the bfd sessions changes
+	controller.OVNNbClient.MonitorBFD(controller.enqueueVpcEgressGatewaysForBFD)
+
+	if _, err = vpcEipInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueAddVpcEip,
+		UpdateFunc: controller.enqueueUpdateVpcEip,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add vpc eip event handler")
+	}
+
+	if _, err = vpcFipInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueAddVpcFip,
+		UpdateFunc: controller.enqueueUpdateVpcFip,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add vpc fip event handler")
+	}
+
+	if _, err = vpcDnatRuleInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueAddVpcDnatRule,
+		UpdateFunc: controller.enqueueUpdateVpcDnatRule,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add vpc dnat rule event handler")
+	}
+
+	if _, err = vpcSnatRuleInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueAddVpcSnatRule,
+		UpdateFunc: controller.enqueueUpdateVpcSnatRule,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add vpc snat rule event handler")
+	}
+
+	if _, err = vpcPeeringConnectionInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueAddVpcPeeringConnection,
+		UpdateFunc: controller.enqueueUpdateVpcPeeringConnection,
+		DeleteFunc: controller.enqueueDeleteVpcPeeringConnection,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add vpc peering connection event handler")
+	}
+
+	if _, err = vpcTransitHubInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueAddVpcTransitHub,
+		UpdateFunc: controller.enqueueUpdateVpcTransitHub,
+		DeleteFunc: controller.enqueueDeleteVpcTransitHub,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add vpc transit hub event handler")
+	}
+
+	// the backend neutral eips and nat rules follow the status of their implementation objects
+	for _, informer := range []cache.SharedIndexInformer{
+		iptablesEipInformer.Informer(), iptablesFipInformer.Informer(), iptablesDnatRuleInformer.Informer(), iptablesSnatRuleInformer.Informer(),
+		ovnEipInformer.Informer(), ovnFipInformer.Informer(), ovnDnatRuleInformer.Informer(), ovnSnatRuleInformer.Informer(),
+	} {
+		if _, err = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    controller.enqueueVpcNatOwner,
+			UpdateFunc: controller.enqueueUpdateVpcNatOwner,
+			DeleteFunc: controller.enqueueVpcNatOwner,
+		}); err != nil {
+			util.LogFatalAndExit(err, "failed to add vpc nat owner event handler")
+		}
+	}
+
+	if _, err = vpcNatGatewayInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueVpcEipsForNatGw,
+		DeleteFunc: controller.enqueueVpcEipsForNatGw,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add vpc nat gateway event handler for vpc eips")
+	}
+
 	if _, err = subnetInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    controller.enqueueAddSubnet,
 		UpdateFunc: controller.enqueueUpdateSubnet,
@@ -614,6 +848,14 @@ func Run(ctx context.Context, config *Configuration) {
 		util.LogFatalAndExit(err, "failed to add ippool event handler")
 	}
 
+	if _, err = ipQuotaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueAddIPQuota,
+		UpdateFunc: controller.enqueueUpdateIPQuota,
+		DeleteFunc: controller.enqueueDeleteIPQuota,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add ip quota event handler")
+	}
+
 	if _, err = ipInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    controller.enqueueAddOrDelIP,
 		UpdateFunc: controller.enqueueUpdateIP,
@@ -638,6 +880,22 @@ func Run(ctx context.Context, config *Configuration) {
 		util.LogFatalAndExit(err, "failed to add security group event handler")
 	}
 
+	if _, err = fqdnCacheInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueAddFqdnCache,
+		UpdateFunc: controller.enqueueUpdateFqdnCache,
+		DeleteFunc: controller.enqueueDeleteFqdnCache,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add fqdn cache event handler")
+	}
+
+	if _, err = addressGroupInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    controller.enqueueAddAddressGroup,
+		UpdateFunc: controller.enqueueUpdateAddressGroup,
+		DeleteFunc: controller.enqueueDeleteAddressGroup,
+	}); err != nil {
+		util.LogFatalAndExit(err, "failed to add address group event handler")
+	}
+
 	if _, err = virtualIPInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    controller.enqueueAddVirtualIP,
 		UpdateFunc: controller.enqueueUpdateVirtualIP,
@@ -761,6 +1019,24 @@ func Run(ctx context.Context, config *Configuration) {
 		}
 	}
 
+	if config.EnableANP {
+		if _, err = anpInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    controller.enqueueAddAnp,
+			UpdateFunc: controller.enqueueUpdateAnp,
+			DeleteFunc: controller.enqueueDeleteAnp,
+		}); err != nil {
+			util.LogFatalAndExit(err, "failed to add admin network policy event handler")
+		}
+
+		if _, err = banpInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    controller.enqueueAddBanp,
+			UpdateFunc: controller.enqueueUpdateBanp,
+			DeleteFunc: controller.enqueueDeleteBanp,
+		}); err != nil {
+			util.LogFatalAndExit(err, "failed to add baseline admin network policy event handler")
+		}
+	}
+
 	controller.Run(ctx)
 }
 
@@ -817,6 +1093,10 @@ func (c *Controller) Run(ctx context.Context) {
 	c.initResourceOnce()
 	<-ctx.Done()
 	klog.Info("Shutting down workers")
+
+	if c.config.IPAMCheckpointPath != "" {
+		c.checkpointIPAM()
+	}
 }
 
 func (c *Controller) shutdown() {
@@ -837,6 +1117,10 @@ func (c *Controller) shutdown() {
 	c.updateIPPoolStatusQueue.ShutDown()
 	c.deleteIPPoolQueue.ShutDown()
 
+	c.addOrUpdateIPQuotaQueue.ShutDown()
+	c.updateIPQuotaStatusQueue.ShutDown()
+	c.deleteIPQuotaQueue.ShutDown()
+
 	c.addNodeQueue.ShutDown()
 	c.updateNodeQueue.ShutDown()
 	c.deleteNodeQueue.ShutDown()
@@ -857,6 +1141,14 @@ func (c *Controller) shutdown() {
 	c.addOrUpdateVpcNatGatewayQueue.ShutDown()
 	c.initVpcNatGatewayQueue.ShutDown()
 	c.delVpcNatGatewayQueue.ShutDown()
+	c.addOrUpdateVpcEgressGatewayQueue.ShutDown()
+	c.delVpcEgressGatewayQueue.ShutDown()
+	c.syncVpcEipQueue.ShutDown()
+	c.syncVpcFipQueue.ShutDown()
+	c.syncVpcDnatRuleQueue.ShutDown()
+	c.syncVpcSnatRuleQueue.ShutDown()
+	c.syncVpcPeeringConnectionQueue.ShutDown()
+	c.syncVpcTransitHubQueue.ShutDown()
 	c.updateVpcEipQueue.ShutDown()
 	c.updateVpcFloatingIPQueue.ShutDown()
 	c.updateVpcDnatQueue.ShutDown()
@@ -925,9 +1217,17 @@ func (c *Controller) shutdown() {
 		c.updateNpQueue.ShutDown()
 		c.deleteNpQueue.ShutDown()
 	}
+	if c.config.EnableANP {
+		c.updateAnpQueue.ShutDown()
+		c.deleteAnpQueue.ShutDown()
+		c.updateBanpQueue.ShutDown()
+		c.deleteBanpQueue.ShutDown()
+	}
 	c.addOrUpdateSgQueue.ShutDown()
 	c.delSgQueue.ShutDown()
 	c.syncSgPortsQueue.ShutDown()
+	c.updateFqdnQueue.ShutDown()
+	c.updateAddressGroupQueue.ShutDown()
 }
 
 func (c *Controller) startWorkers(ctx context.Context) {
@@ -938,6 +1238,15 @@ func (c *Controller) startWorkers(ctx context.Context) {
 	go wait.Until(c.runAddOrUpdateVpcNatGwWorker, time.Second, ctx.Done())
 	go wait.Until(c.runInitVpcNatGwWorker, time.Second, ctx.Done())
 	go wait.Until(c.runDelVpcNatGwWorker, time.Second, ctx.Done())
+	go wait.Until(c.runAddOrUpdateVpcEgressGatewayWorker, time.Second, ctx.Done())
+	go wait.Until(c.runDelVpcEgressGatewayWorker, time.Second, ctx.Done())
+	go wait.Until(c.runSyncVpcEipWorker, time.Second, ctx.Done())
+	go wait.Until(c.runSyncVpcFipWorker, time.Second, ctx.Done())
+	go wait.Until(c.runSyncVpcDnatRuleWorker, time.Second, ctx.Done())
+	go wait.Until(c.runSyncVpcSnatRuleWorker, time.Second, ctx.Done())
+	// the interconnect addresses are allocated from a shared cidr by a single worker
+	go wait.Until(c.runSyncVpcPeeringConnectionWorker, time.Second, ctx.Done())
+	go wait.Until(c.runSyncVpcTransitHubWorker, time.Second, ctx.Done())
 	go wait.Until(c.runUpdateVpcFloatingIPWorker, time.Second, ctx.Done())
 	go wait.Until(c.runUpdateVpcEipWorker, time.Second, ctx.Done())
 	go wait.Until(c.runUpdateVpcDnatWorker, time.Second, ctx.Done())
@@ -947,6 +1256,7 @@ func (c *Controller) startWorkers(ctx context.Context) {
 	// add default/join subnet and wait them ready
 	go wait.Until(c.runAddSubnetWorker, time.Second, ctx.Done())
 	go wait.Until(c.runAddIPPoolWorker, time.Second, ctx.Done())
+	go wait.Until(c.runAddIPQuotaWorker, time.Second, ctx.Done())
 	go wait.Until(c.runAddVlanWorker, time.Second, ctx.Done())
 	go wait.Until(c.runAddNamespaceWorker, time.Second, ctx.Done())
 	err := wait.PollUntilContextCancel(ctx, 3*time.Second, true, func(_ context.Context) (done bool, err error) {
@@ -962,6 +1272,8 @@ func (c *Controller) startWorkers(ctx context.Context) {
 	go wait.Until(c.runAddSgWorker, time.Second, ctx.Done())
 	go wait.Until(c.runDelSgWorker, time.Second, ctx.Done())
 	go wait.Until(c.runSyncSgPortsWorker, time.Second, ctx.Done())
+	go wait.Until(c.runUpdateFqdnWorker, time.Second, ctx.Done())
+	go wait.Until(c.runUpdateAddressGroupWorker, time.Second, ctx.Done())
 
 	// run node worker before handle any pods
 	for i := 0; i < c.config.WorkerNum; i++ {
@@ -1016,6 +1328,8 @@ func (c *Controller) startWorkers(ctx context.Context) {
 		go wait.Until(c.runDeleteIPPoolWorker, time.Second, ctx.Done())
 		go wait.Until(c.runUpdateSubnetStatusWorker, time.Second, ctx.Done())
 		go wait.Until(c.runUpdateIPPoolStatusWorker, time.Second, ctx.Done())
+		go wait.Until(c.runDeleteIPQuotaWorker, time.Second, ctx.Done())
+		go wait.Until(c.runUpdateIPQuotaStatusWorker, time.Second, ctx.Done())
 		go wait.Until(c.runSyncVirtualPortsWorker, time.Second, ctx.Done())
 
 		if c.config.EnableLb {
@@ -1028,6 +1342,13 @@ func (c *Controller) startWorkers(ctx context.Context) {
 			go wait.Until(c.runDeleteNpWorker, time.Second, ctx.Done())
 		}
 
+		if c.config.EnableANP {
+			go wait.Until(c.runUpdateAnpWorker, time.Second, ctx.Done())
+			go wait.Until(c.runDeleteAnpWorker, time.Second, ctx.Done())
+			go wait.Until(c.runUpdateBanpWorker, time.Second, ctx.Done())
+			go wait.Until(c.runDeleteBanpWorker, time.Second, ctx.Done())
+		}
+
 		go wait.Until(c.runDelVlanWorker, time.Second, ctx.Done())
 		go wait.Until(c.runUpdateVlanWorker, time.Second, ctx.Done())
 	}
@@ -1047,6 +1368,8 @@ func (c *Controller) startWorkers(ctx context.Context) {
 	go wait.Until(func() {
 		c.resyncVpcNatGwConfig()
 	}, time.Second, ctx.Done())
+	go wait.Until(c.resyncVpcNatGwInstances, 5*time.Second, ctx.Done())
+	go wait.Until(c.resyncNatGwSessions, 30*time.Second, ctx.Done())
 
 	go wait.Until(func() {
 		if err := c.markAndCleanLSP(); err != nil {
@@ -1066,6 +1389,10 @@ func (c *Controller) startWorkers(ctx context.Context) {
 		}, 5*time.Second, ctx.Done())
 	}
 
+	if c.config.IPAMCheckpointPath != "" {
+		go wait.Until(c.checkpointIPAM, time.Duration(c.config.IPAMCheckpointInterval)*time.Second, ctx.Done())
+	}
+
 	go wait.Until(c.resyncProviderNetworkStatus, 30*time.Second, ctx.Done())
 	go wait.Until(c.resyncSubnetMetrics, 30*time.Second, ctx.Done())
 	go wait.Until(c.CheckGatewayReady, 5*time.Second, ctx.Done())
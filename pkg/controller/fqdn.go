@@ -0,0 +1,253 @@
+package controller
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/scylladb/go-set/strset"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovs"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// sgEgressFqdns returns the fqdns referenced by the egress rules of a security group
+func sgEgressFqdns(sg *kubeovnv1.SecurityGroup) []string {
+	var fqdns []string
+	for _, rule := range sg.Spec.EgressRules {
+		if rule.RemoteType == kubeovnv1.SgRemoteTypeFqdn {
+			fqdns = append(fqdns, util.NormalizeFqdn(rule.RemoteAddress))
+		}
+	}
+	return util.UniqString(fqdns)
+}
+
+// npEgressFqdns returns the valid fqdns in the egress fqdns annotation of a network policy
+func npEgressFqdns(np *netv1.NetworkPolicy) []string {
+	var fqdns []string
+	for _, fqdn := range util.ParseFqdns(np.Annotations[util.NetworkPolicyEgressFqdnsAnnotation]) {
+		if err := util.ValidateFqdn(fqdn); err != nil {
+			klog.Errorf("ignore invalid fqdn %q of network policy %s/%s: %v", fqdn, np.Namespace, np.Name, err)
+			continue
+		}
+		fqdns = append(fqdns, fqdn)
+	}
+	return fqdns
+}
+
+func (c *Controller) enqueueFqdns(fqdns ...string) {
+	for _, fqdn := range fqdns {
+		klog.V(3).Infof("enqueue update fqdn %s", fqdn)
+		c.updateFqdnQueue.Add(fqdn)
+	}
+}
+
+func (c *Controller) enqueueFqdnCacheEntries(entries ...kubeovnv1.FqdnCacheEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	fqdns, err := c.referencedFqdns()
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, fqdn := range fqdns.List() {
+		for _, entry := range entries {
+			if util.MatchFqdn(fqdn, entry.Name) {
+				c.enqueueFqdns(fqdn)
+				break
+			}
+		}
+	}
+}
+
+func (c *Controller) enqueueAddFqdnCache(obj interface{}) {
+	fc := obj.(*kubeovnv1.FqdnCache)
+	klog.V(3).Infof("enqueue add fqdn cache %s", fc.Name)
+	c.enqueueFqdnCacheEntries(fc.Spec.Entries...)
+}
+
+func (c *Controller) enqueueUpdateFqdnCache(oldObj, newObj interface{}) {
+	oldFc := oldObj.(*kubeovnv1.FqdnCache)
+	newFc := newObj.(*kubeovnv1.FqdnCache)
+	if oldFc.ResourceVersion == newFc.ResourceVersion {
+		return
+	}
+	klog.V(3).Infof("enqueue update fqdn cache %s", newFc.Name)
+	c.enqueueFqdnCacheEntries(append(oldFc.Spec.Entries, newFc.Spec.Entries...)...)
+}
+
+func (c *Controller) enqueueDeleteFqdnCache(obj interface{}) {
+	var fc *kubeovnv1.FqdnCache
+	switch t := obj.(type) {
+	case *kubeovnv1.FqdnCache:
+		fc = t
+	case cache.DeletedFinalStateUnknown:
+		f, ok := t.Obj.(*kubeovnv1.FqdnCache)
+		if !ok {
+			klog.Warningf("unexpected object type: %T", t.Obj)
+			return
+		}
+		fc = f
+	default:
+		klog.Warningf("unexpected type: %T", obj)
+		return
+	}
+
+	klog.V(3).Infof("enqueue delete fqdn cache %s", fc.Name)
+	c.enqueueFqdnCacheEntries(fc.Spec.Entries...)
+}
+
+func (c *Controller) runUpdateFqdnWorker() {
+	for c.processNextUpdateFqdnWorkItem() {
+	}
+}
+
+func (c *Controller) processNextUpdateFqdnWorkItem() bool {
+	obj, shutdown := c.updateFqdnQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.updateFqdnQueue.Done(obj)
+		var key string
+		var ok bool
+		if key, ok = obj.(string); !ok {
+			c.updateFqdnQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleUpdateFqdn(key); err != nil {
+			c.updateFqdnQueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing fqdn %s: %v, requeuing", key, err)
+		}
+		c.updateFqdnQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+// referencedFqdns returns all the fqdns referenced by security groups and network policies
+func (c *Controller) referencedFqdns() (*strset.Set, error) {
+	fqdns := strset.New()
+	sgs, err := c.sgsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list security groups: %v", err)
+		return nil, err
+	}
+	for _, sg := range sgs {
+		fqdns.Add(sgEgressFqdns(sg)...)
+	}
+
+	if c.config.EnableNP {
+		nps, err := c.npsLister.List(labels.Everything())
+		if err != nil {
+			klog.Errorf("failed to list network policies: %v", err)
+			return nil, err
+		}
+		for _, np := range nps {
+			fqdns.Add(npEgressFqdns(np)...)
+		}
+	}
+	return fqdns, nil
+}
+
+// createFqdnAddressSets makes sure the address sets of the fqdns exist before acls referencing them are created
+func (c *Controller) createFqdnAddressSets(fqdns ...string) error {
+	for _, fqdn := range fqdns {
+		externalIDs := map[string]string{fqdnKey: fqdn}
+		for _, asName := range []string{ovs.GetFqdnV4AddressSetName(fqdn), ovs.GetFqdnV6AddressSetName(fqdn)} {
+			if err := c.OVNNbClient.CreateAddressSet(asName, externalIDs); err != nil {
+				klog.Errorf("failed to create address set %s for fqdn %s: %v", asName, fqdn, err)
+				return err
+			}
+		}
+		c.enqueueFqdns(fqdn)
+	}
+	return nil
+}
+
+// fqdnAddresses returns the unexpired addresses of the fqdn in the fqdn caches and the earliest expiration time of them
+func fqdnAddresses(caches []*kubeovnv1.FqdnCache, fqdn string, now time.Time) (*strset.Set, *strset.Set, time.Time) {
+	var nextExpire time.Time
+	v4s, v6s := strset.New(), strset.New()
+	for _, fc := range caches {
+		for _, entry := range fc.Spec.Entries {
+			if !entry.ExpireTime.After(now) || !util.MatchFqdn(fqdn, entry.Name) {
+				continue
+			}
+			ip := net.ParseIP(entry.IP)
+			if ip == nil {
+				continue
+			}
+			if ip.To4() != nil {
+				v4s.Add(ip.String())
+			} else {
+				v6s.Add(ip.String())
+			}
+			if nextExpire.IsZero() || entry.ExpireTime.Time.Before(nextExpire) {
+				nextExpire = entry.ExpireTime.Time
+			}
+		}
+	}
+	return v4s, v6s, nextExpire
+}
+
+func (c *Controller) handleUpdateFqdn(fqdn string) error {
+	klog.Infof("handle update fqdn %s", fqdn)
+
+	fqdns, err := c.referencedFqdns()
+	if err != nil {
+		return err
+	}
+	if !fqdns.Has(fqdn) {
+		klog.Infof("fqdn %s is no longer referenced, delete its address sets", fqdn)
+		if err = c.OVNNbClient.DeleteAddressSets(map[string]string{fqdnKey: fqdn}); err != nil {
+			klog.Errorf("failed to delete address sets of fqdn %s: %v", fqdn, err)
+			return err
+		}
+		return nil
+	}
+
+	caches, err := c.fqdnCachesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list fqdn caches: %v", err)
+		return err
+	}
+
+	now := time.Now()
+	v4s, v6s, nextExpire := fqdnAddresses(caches, fqdn, now)
+
+	externalIDs := map[string]string{fqdnKey: fqdn}
+	for asName, addresses := range map[string][]string{
+		ovs.GetFqdnV4AddressSetName(fqdn): v4s.List(),
+		ovs.GetFqdnV6AddressSetName(fqdn): v6s.List(),
+	} {
+		if err = c.OVNNbClient.CreateAddressSet(asName, externalIDs); err != nil {
+			klog.Errorf("failed to create address set %s for fqdn %s: %v", asName, fqdn, err)
+			return err
+		}
+		if err = c.OVNNbClient.AddressSetUpdateAddress(asName, addresses...); err != nil {
+			klog.Errorf("failed to update addresses of address set %s for fqdn %s: %v", asName, fqdn, err)
+			return err
+		}
+	}
+
+	// remove the expired addresses in time even if no dns response is snooped
+	if !nextExpire.IsZero() {
+		c.updateFqdnQueue.AddAfter(fqdn, nextExpire.Sub(now))
+	}
+	return nil
+}
@@ -31,8 +31,13 @@ func (c *Controller) gc() error {
 		// The lsp gc is processed periodically by markAndCleanLSP, will not gc lsp when init
 		c.gcLoadBalancer,
 		c.gcPortGroup,
+		c.gcAdminNetworkPolicy,
+		c.gcFqdn,
+		c.gcAddressGroup,
 		c.gcStaticRoute,
+		c.gcBgpImportedRoute,
 		c.gcVpcNatGateway,
+		c.gcVpcEgressGateway,
 		c.gcLogicalRouterPort,
 		c.gcVip,
 		c.gcLbSvcPods,
@@ -60,6 +65,29 @@ func (c *Controller) gcLogicalRouterPort() error {
 			exceptPeerPorts.Add(fmt.Sprintf("%s-%s", vpc.Name, peer))
 		}
 	}
+	conns, err := c.vpcPeeringConnectionsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc peering connections, %v", err)
+		return err
+	}
+	for _, conn := range conns {
+		if conn.Status.RemoteVpc != "" {
+			exceptPeerPorts.Add(vpcPeeringRouterPort(conn.Spec.Vpc, conn.Status.RemoteVpc))
+		}
+	}
+	hubs, err := c.vpcTransitHubsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc transit hubs, %v", err)
+		return err
+	}
+	for _, hub := range hubs {
+		for _, attachment := range hub.Status.Attachments {
+			if attachment.HubConnectIP != "" {
+				exceptPeerPorts.Add(vpcPeeringRouterPort(hub.Spec.Vpc, attachment.Vpc))
+				exceptPeerPorts.Add(vpcPeeringRouterPort(attachment.Vpc, hub.Spec.Vpc))
+			}
+		}
+	}
 
 	if err = c.OVNNbClient.DeleteLogicalRouterPorts(nil, logicalRouterPortFilter(exceptPeerPorts)); err != nil {
 		klog.Errorf("delete non-existent peer logical router port: %v", err)
@@ -631,6 +659,179 @@ func (c *Controller) gcPortGroup() error {
 	return nil
 }
 
+func (c *Controller) gcAdminNetworkPolicy() error {
+	klog.Infof("start to gc admin network policy")
+
+	for _, policyKey := range []string{anpKey, banpKey} {
+		names := strset.New()
+		if c.config.EnableANP {
+			if policyKey == anpKey {
+				anps, err := c.anpsLister.List(labels.Everything())
+				if err != nil {
+					klog.Errorf("failed to list admin network policy, %v", err)
+					return err
+				}
+				for _, anp := range anps {
+					names.Add(anp.Name)
+				}
+			} else {
+				banps, err := c.banpsLister.List(labels.Everything())
+				if err != nil {
+					klog.Errorf("failed to list baseline admin network policy, %v", err)
+					return err
+				}
+				for _, banp := range banps {
+					names.Add(banp.Name)
+				}
+			}
+		}
+
+		pgs, err := c.OVNNbClient.ListPortGroups(map[string]string{policyKey: ""})
+		if err != nil {
+			klog.Errorf("list %s port group: %v", policyKey, err)
+			return err
+		}
+		for _, pg := range pgs {
+			name := pg.ExternalIDs[policyKey]
+			if names.Has(name) {
+				continue
+			}
+			klog.Infof("gc port group '%s' of %s '%s'", pg.Name, policyKey, name)
+			switch {
+			case !c.config.EnableANP:
+				// workers are not running, clean up the resources directly
+				if err = c.deleteAdminPolicy(policyKey, name, pg.Name); err != nil {
+					return err
+				}
+			case policyKey == anpKey:
+				c.deleteAnpQueue.Add(name)
+			default:
+				c.deleteBanpQueue.Add(name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) gcFqdn() error {
+	klog.Infof("start to gc fqdn")
+
+	caches, err := c.fqdnCachesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list fqdn caches, %v", err)
+		return err
+	}
+	for _, fc := range caches {
+		if _, err = c.nodesLister.Get(fc.Name); err == nil {
+			continue
+		} else if !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to get node %s, %v", fc.Name, err)
+			return err
+		}
+		klog.Infof("gc fqdn cache of node %s", fc.Name)
+		if err = c.config.KubeOvnClient.KubeovnV1().FqdnCaches().Delete(context.Background(), fc.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to delete fqdn cache %s, %v", fc.Name, err)
+			return err
+		}
+	}
+
+	// address sets of fqdns no longer referenced are deleted by the fqdn worker
+	ass, err := c.OVNNbClient.ListAddressSets(map[string]string{fqdnKey: ""})
+	if err != nil {
+		klog.Errorf("failed to list fqdn address sets, %v", err)
+		return err
+	}
+	fqdns := strset.New()
+	for _, as := range ass {
+		fqdns.Add(as.ExternalIDs[fqdnKey])
+	}
+	c.enqueueFqdns(fqdns.List()...)
+	return nil
+}
+
+func (c *Controller) gcAddressGroup() error {
+	klog.Infof("start to gc address group")
+
+	// address sets of address groups deleted and no longer referenced are deleted by the address group worker
+	ass, err := c.OVNNbClient.ListAddressSets(map[string]string{addressGroupKey: ""})
+	if err != nil {
+		klog.Errorf("failed to list address group address sets, %v", err)
+		return err
+	}
+	ags := strset.New()
+	for _, as := range ass {
+		ags.Add(as.ExternalIDs[addressGroupKey])
+	}
+	c.enqueueAddressGroups(ags.List()...)
+	return nil
+}
+
+func (c *Controller) gcVpcEgressGateway() error {
+	klog.Infof("start to gc vpc egress gateway")
+
+	gws, err := c.vpcEgressGatewaysLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc egress gateways, %v", err)
+		return err
+	}
+	gwNames := strset.New()
+	for _, gw := range gws {
+		gwNames.Add(gw.Name)
+	}
+
+	stsList, err := c.config.KubeClient.AppsV1().StatefulSets(c.config.PodNamespace).List(context.Background(), metav1.ListOptions{LabelSelector: util.VpcEgressGatewayLabel})
+	if err != nil {
+		klog.Errorf("failed to list vpc egress gateway statefulsets, %v", err)
+		return err
+	}
+	for _, sts := range stsList.Items {
+		if gwNames.Has(sts.Labels[util.VpcEgressGatewayLabel]) {
+			continue
+		}
+		klog.Infof("gc vpc egress gateway statefulset %s", sts.Name)
+		if err = c.config.KubeClient.AppsV1().StatefulSets(c.config.PodNamespace).Delete(context.Background(), sts.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to delete statefulset %s, %v", sts.Name, err)
+			return err
+		}
+	}
+
+	ass, err := c.OVNNbClient.ListAddressSets(map[string]string{vpcEgressGatewayKey: ""})
+	if err != nil {
+		klog.Errorf("failed to list vpc egress gateway address sets, %v", err)
+		return err
+	}
+	staleGws := strset.New()
+	for _, as := range ass {
+		if name := as.ExternalIDs[vpcEgressGatewayKey]; !gwNames.Has(name) {
+			staleGws.Add(name)
+		}
+	}
+	if staleGws.IsEmpty() {
+		return nil
+	}
+
+	lrs, err := c.OVNNbClient.ListLogicalRouter(c.config.EnableExternalVpc, nil)
+	if err != nil {
+		klog.Errorf("failed to list logical routers, %v", err)
+		return err
+	}
+	for _, name := range staleGws.List() {
+		klog.Infof("gc policy routes and address sets of vpc egress gateway %s", name)
+		for _, lr := range lrs {
+			if err = c.OVNNbClient.DeleteLogicalRouterPolicies(lr.Name, util.EgressGatewayPolicyPriority, map[string]string{vpcEgressGatewayKey: name}); err != nil {
+				klog.Errorf("failed to delete policy routes of vpc egress gateway %s, %v", name, err)
+				return err
+			}
+		}
+		if err = c.OVNNbClient.DeleteAddressSets(map[string]string{vpcEgressGatewayKey: name}); err != nil {
+			klog.Errorf("failed to delete address sets of vpc egress gateway %s, %v", name, err)
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *Controller) gcStaticRoute() error {
 	klog.Infof("start to gc static routes")
 	routes, err := c.OVNNbClient.ListLogicalRouterStaticRoutes(c.config.ClusterRouter, nil, nil, "", nil)
@@ -643,10 +844,27 @@ func (c *Controller) gcStaticRoute() error {
 		klog.Errorf("failed to get default vpc, %v", err)
 		return err
 	}
+	peeringRoutes, err := c.getVpcPeeringConnectionRoutes(c.config.ClusterRouter)
+	if err != nil {
+		klog.Errorf("failed to get peering connection routes of default vpc, %v", err)
+		return err
+	}
+	transitHubRoutes, err := c.getVpcTransitHubRoutes(c.config.ClusterRouter)
+	if err != nil {
+		klog.Errorf("failed to get transit hub routes of default vpc, %v", err)
+		return err
+	}
+	keepRoutes := make([]*kubeovnv1.StaticRoute, 0, len(defaultVpc.Spec.StaticRoutes)+len(peeringRoutes)+len(transitHubRoutes))
+	keepRoutes = append(keepRoutes, defaultVpc.Spec.StaticRoutes...)
+	keepRoutes = append(keepRoutes, peeringRoutes...)
+	keepRoutes = append(keepRoutes, transitHubRoutes...)
 	var keepStaticRoute bool
 	for _, route := range routes {
+		if route.ExternalIDs[util.BgpImportNodeKey] != "" {
+			continue
+		}
 		keepStaticRoute = false
-		for _, item := range defaultVpc.Spec.StaticRoutes {
+		for _, item := range keepRoutes {
 			if route.IPPrefix == item.CIDR && route.Nexthop == item.NextHopIP && route.RouteTable == item.RouteTable {
 				keepStaticRoute = true
 				break
@@ -679,6 +897,38 @@ func (c *Controller) gcStaticRoute() error {
 	return nil
 }
 
+// gcBgpImportedRoute deletes the vpc static routes imported by the speakers of deleted nodes
+func (c *Controller) gcBgpImportedRoute() error {
+	klog.Infof("start to gc bgp imported routes")
+	vpcs, err := c.vpcsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpcs: %v", err)
+		return err
+	}
+	for _, vpc := range vpcs {
+		routes, err := c.OVNNbClient.ListLogicalRouterStaticRoutes(vpc.Name, nil, nil, "", map[string]string{util.BgpImportNodeKey: ""})
+		if err != nil {
+			klog.Errorf("failed to list bgp imported routes of vpc %s: %v", vpc.Name, err)
+			continue
+		}
+		for _, route := range routes {
+			node := route.ExternalIDs[util.BgpImportNodeKey]
+			if _, err = c.nodesLister.Get(node); err == nil || !k8serrors.IsNotFound(err) {
+				continue
+			}
+			policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+			if route.Policy != nil {
+				policy = *route.Policy
+			}
+			klog.Infof("gc static route %s via %s of vpc %s imported by deleted node %s", route.IPPrefix, route.Nexthop, vpc.Name, node)
+			if err = c.OVNNbClient.DeleteLogicalRouterStaticRoute(vpc.Name, &route.RouteTable, &policy, route.IPPrefix, route.Nexthop); err != nil {
+				klog.Errorf("failed to delete static route %s via %s of vpc %s: %v", route.IPPrefix, route.Nexthop, vpc.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
 func (c *Controller) gcChassis() error {
 	klog.Infof("start to gc chassis")
 	chassises, err := c.OVNSbClient.GetKubeOvnChassisses()
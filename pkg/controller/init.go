@@ -277,20 +277,24 @@ func (c *Controller) initLoadBalancer() error {
 
 func (c *Controller) InitIPAM() error {
 	start := time.Now()
+	replay := newIPAMReplay(c.ipam, c.restoreIPAMCheckpoint())
 	subnets, err := c.subnetsLister.List(labels.Everything())
 	if err != nil {
 		klog.Errorf("failed to list subnet: %v", err)
 		return err
 	}
 	for _, subnet := range subnets {
-		if err := c.ipam.AddOrUpdateSubnet(subnet.Name, subnet.Spec.CIDRBlock, subnet.Spec.Gateway, subnet.Spec.ExcludeIps); err != nil {
+		if err := c.ipam.AddOrUpdateSubnet(subnet.Name, subnet.Spec.CIDRBlock, subnet.Spec.Gateway, subnet.Spec.ExcludeIps, subnet.Spec.SecondaryCIDRBlocks...); err != nil {
 			klog.Errorf("failed to init subnet %s: %v", subnet.Name, err)
+		} else if err = c.ipam.SetSubnetAllocationStrategy(subnet.Name, subnet.Spec.IPAllocationStrategy, time.Duration(subnet.Spec.IPReleaseCooldown)*time.Second); err != nil {
+			klog.Errorf("failed to set allocation strategy of subnet %s: %v", subnet.Name, err)
 		}
+		replay.addSubnet(subnet.Name)
 
 		u2oInterconnName := fmt.Sprintf(util.U2OInterconnName, subnet.Spec.Vpc, subnet.Name)
 		u2oInterconnLrpName := fmt.Sprintf("%s-%s", subnet.Spec.Vpc, subnet.Name)
 		if subnet.Status.U2OInterconnectionIP != "" {
-			if _, _, _, err = c.ipam.GetStaticAddress(u2oInterconnName, u2oInterconnLrpName, subnet.Status.U2OInterconnectionIP, nil, subnet.Name, true); err != nil {
+			if _, _, _, _, err = replay.getStaticAddress(u2oInterconnName, u2oInterconnLrpName, subnet.Status.U2OInterconnectionIP, nil, subnet.Name); err != nil {
 				klog.Errorf("failed to init subnet %q u2o interonnection ip to ipam %v", subnet.Name, err)
 			}
 		}
@@ -305,6 +309,7 @@ func (c *Controller) InitIPAM() error {
 		if err = c.ipam.AddOrUpdateIPPool(ippool.Spec.Subnet, ippool.Name, ippool.Spec.IPs); err != nil {
 			klog.Errorf("failed to init ippool %s: %v", ippool.Name, err)
 		}
+		replay.addIPPool(ippool.Spec.Subnet, ippool.Name)
 	}
 
 	pods, err := c.podsLister.List(labels.Everything())
@@ -331,7 +336,7 @@ func (c *Controller) InitIPAM() error {
 		} else {
 			ipamKey = fmt.Sprintf("node-%s", ip.Spec.PodName)
 		}
-		if _, _, _, err = c.ipam.GetStaticAddress(ipamKey, ip.Name, ip.Spec.IPAddress, &ip.Spec.MacAddress, ip.Spec.Subnet, true); err != nil {
+		if _, _, _, _, err = replay.getStaticAddress(ipamKey, ip.Name, ip.Spec.IPAddress, &ip.Spec.MacAddress, ip.Spec.Subnet); err != nil {
 			klog.Errorf("failed to init IPAM from IP CR %s: %v", ip.Name, err)
 		}
 	}
@@ -361,10 +366,12 @@ func (c *Controller) InitIPAM() error {
 				portName := ovs.PodNameToPortName(podName, pod.Namespace, podNet.ProviderName)
 				ip := pod.Annotations[fmt.Sprintf(util.IPAddressAnnotationTemplate, podNet.ProviderName)]
 				mac := pod.Annotations[fmt.Sprintf(util.MacAddressAnnotationTemplate, podNet.ProviderName)]
-				_, _, _, err := c.ipam.GetStaticAddress(key, portName, ip, &mac, podNet.Subnet.Name, true)
+				_, _, _, _, err := replay.getStaticAddress(key, portName, ip, &mac, podNet.Subnet.Name)
 				if err != nil {
 					klog.Errorf("failed to init pod %s.%s address %s: %v", podName, pod.Namespace, pod.Annotations[fmt.Sprintf(util.IPAddressAnnotationTemplate, podNet.ProviderName)], err)
 				} else {
+					// an address found in the ipam checkpoint does not prove the IP CR exists,
+					// the CR is compared with the cached one and only written if it is missing or changed
 					err = c.createOrUpdateCrdIPs(podName, ip, mac, podNet.Subnet.Name, pod.Namespace, pod.Spec.NodeName, podNet.ProviderName, podType)
 					if err != nil {
 						klog.Errorf("failed to create/update ips CR %s.%s with ip address %s: %v", podName, pod.Namespace, ip, err)
@@ -388,7 +395,7 @@ func (c *Controller) InitIPAM() error {
 		} else {
 			ipamKey = vip.Name
 		}
-		if _, _, _, err = c.ipam.GetStaticAddress(ipamKey, vip.Name, vip.Status.V4ip, &vip.Status.Mac, vip.Spec.Subnet, true); err != nil {
+		if _, _, _, _, err = replay.getStaticAddress(ipamKey, vip.Name, vip.Status.V4ip, &vip.Status.Mac, vip.Spec.Subnet); err != nil {
 			klog.Errorf("failed to init ipam from vip cr %s: %v", vip.Name, err)
 		}
 	}
@@ -400,7 +407,7 @@ func (c *Controller) InitIPAM() error {
 	}
 	for _, eip := range eips {
 		externalNetwork := util.GetExternalNetwork(eip.Spec.ExternalSubnet)
-		if _, _, _, err = c.ipam.GetStaticAddress(eip.Name, eip.Name, eip.Status.IP, &eip.Spec.MacAddress, externalNetwork, true); err != nil {
+		if _, _, _, _, err = replay.getStaticAddress(eip.Name, eip.Name, eip.Status.IP, &eip.Spec.MacAddress, externalNetwork); err != nil {
 			klog.Errorf("failed to init ipam from iptables eip cr %s: %v", eip.Name, err)
 		}
 	}
@@ -411,7 +418,7 @@ func (c *Controller) InitIPAM() error {
 		return err
 	}
 	for _, oeip := range oeips {
-		if _, _, _, err = c.ipam.GetStaticAddress(oeip.Name, oeip.Name, oeip.Status.V4Ip, &oeip.Status.MacAddress, oeip.Spec.ExternalSubnet, true); err != nil {
+		if _, _, _, _, err = replay.getStaticAddress(oeip.Name, oeip.Name, oeip.Status.V4Ip, &oeip.Status.MacAddress, oeip.Spec.ExternalSubnet); err != nil {
 			klog.Errorf("failed to init ipam from ovn eip cr %s: %v", oeip.Name, err)
 		}
 	}
@@ -425,9 +432,9 @@ func (c *Controller) InitIPAM() error {
 		if node.Annotations[util.AllocatedAnnotation] == "true" {
 			portName := fmt.Sprintf("node-%s", node.Name)
 			mac := node.Annotations[util.MacAddressAnnotation]
-			v4IP, v6IP, _, err := c.ipam.GetStaticAddress(portName, portName,
+			v4IP, v6IP, _, _, err := replay.getStaticAddress(portName, portName,
 				node.Annotations[util.IPAddressAnnotation], &mac,
-				node.Annotations[util.LogicalSwitchAnnotation], true)
+				node.Annotations[util.LogicalSwitchAnnotation])
 			if err != nil {
 				klog.Errorf("failed to init node %s.%s address %s: %v", node.Name, node.Namespace, node.Annotations[util.IPAddressAnnotation], err)
 			}
@@ -437,6 +444,8 @@ func (c *Controller) InitIPAM() error {
 		}
 	}
 
+	replay.finish()
+
 	klog.Infof("take %.2f seconds to initialize IPAM", time.Since(start).Seconds())
 	return nil
 }
@@ -0,0 +1,259 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/scylladb/go-set/strset"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+func (c *Controller) enqueueAddIPQuota(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue add ip quota %s", key)
+	c.addOrUpdateIPQuotaQueue.Add(key)
+}
+
+func (c *Controller) enqueueDeleteIPQuota(obj interface{}) {
+	var quota *kubeovnv1.IPQuota
+	switch t := obj.(type) {
+	case *kubeovnv1.IPQuota:
+		quota = t
+	case cache.DeletedFinalStateUnknown:
+		q, ok := t.Obj.(*kubeovnv1.IPQuota)
+		if !ok {
+			klog.Warningf("unexpected object type: %T", t.Obj)
+			return
+		}
+		quota = q
+	default:
+		klog.Warningf("unexpected type: %T", obj)
+		return
+	}
+
+	klog.V(3).Infof("enqueue delete ip quota %s", quota.Name)
+	c.deleteIPQuotaQueue.Add(quota.Name)
+}
+
+func (c *Controller) enqueueUpdateIPQuota(oldObj, newObj interface{}) {
+	oldQuota := oldObj.(*kubeovnv1.IPQuota)
+	newQuota := newObj.(*kubeovnv1.IPQuota)
+	if reflect.DeepEqual(oldQuota.Spec, newQuota.Spec) {
+		return
+	}
+
+	key, err := cache.MetaNamespaceKeyFunc(newObj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue update ip quota %s", key)
+	c.addOrUpdateIPQuotaQueue.Add(key)
+}
+
+func (c *Controller) runAddIPQuotaWorker() {
+	for c.processNextAddIPQuotaWorkItem() {
+	}
+}
+
+func (c *Controller) runUpdateIPQuotaStatusWorker() {
+	for c.processNextUpdateIPQuotaStatusWorkItem() {
+	}
+}
+
+func (c *Controller) runDeleteIPQuotaWorker() {
+	for c.processNextDeleteIPQuotaWorkItem() {
+	}
+}
+
+func (c *Controller) processNextAddIPQuotaWorkItem() bool {
+	obj, shutdown := c.addOrUpdateIPQuotaQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.addOrUpdateIPQuotaQueue.Done(obj)
+		key, ok := obj.(string)
+		if !ok {
+			c.addOrUpdateIPQuotaQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleAddOrUpdateIPQuota(key); err != nil {
+			c.addOrUpdateIPQuotaQueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing ip quota %q: %s, requeuing", key, err.Error())
+		}
+		c.addOrUpdateIPQuotaQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+func (c *Controller) processNextUpdateIPQuotaStatusWorkItem() bool {
+	obj, shutdown := c.updateIPQuotaStatusQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.updateIPQuotaStatusQueue.Done(obj)
+		key, ok := obj.(string)
+		if !ok {
+			c.updateIPQuotaStatusQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleUpdateIPQuotaStatus(key); err != nil {
+			c.updateIPQuotaStatusQueue.AddRateLimited(key)
+			return fmt.Errorf("error syncing status of ip quota %q: %s, requeuing", key, err.Error())
+		}
+		c.updateIPQuotaStatusQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+func (c *Controller) processNextDeleteIPQuotaWorkItem() bool {
+	obj, shutdown := c.deleteIPQuotaQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.deleteIPQuotaQueue.Done(obj)
+		key, ok := obj.(string)
+		if !ok {
+			c.deleteIPQuotaQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected string in workqueue but got %#v", obj))
+			return nil
+		}
+		c.handleDeleteIPQuota(key)
+		c.deleteIPQuotaQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+// ipQuotaSelects returns whether a pod, in the form of the IPAM key namespace/name,
+// is counted against the quota. Pods not found in the cache only match quotas without a label selector.
+func (c *Controller) ipQuotaSelects(quota *kubeovnv1.IPQuota) (func(string) bool, error) {
+	selector := labels.Everything()
+	if quota.Spec.Selector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(quota.Spec.Selector); err != nil {
+			return nil, err
+		}
+	}
+	namespaces := strset.New(quota.Spec.Namespaces...)
+
+	return func(podName string) bool {
+		namespace, name, err := cache.SplitMetaNamespaceKey(podName)
+		if err != nil || namespace == "" {
+			// not a pod
+			return false
+		}
+		if !namespaces.IsEmpty() && !namespaces.Has(namespace) {
+			return false
+		}
+		if selector.Empty() {
+			return true
+		}
+		pod, err := c.podsLister.Pods(namespace).Get(name)
+		if err != nil {
+			return false
+		}
+		return selector.Matches(labels.Set(pod.Labels))
+	}, nil
+}
+
+func (c *Controller) handleAddOrUpdateIPQuota(key string) error {
+	c.ipQuotaKeyMutex.LockKey(key)
+	defer func() { _ = c.ipQuotaKeyMutex.UnlockKey(key) }()
+
+	cachedQuota, err := c.ipQuotaLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	klog.Infof("handle add/update ip quota %s", key)
+
+	selects, err := c.ipQuotaSelects(cachedQuota)
+	if err != nil {
+		// the spec has to be changed for the quota to take effect, so do not requeue
+		klog.Errorf("invalid selector of ip quota %s: %v", key, err)
+		c.recorder.Eventf(cachedQuota, corev1.EventTypeWarning, "InvalidSelector", err.Error())
+		return nil
+	}
+	c.ipam.AddOrUpdateQuota(cachedQuota.Name, cachedQuota.Spec.Subnet, cachedQuota.Spec.V4IPs, cachedQuota.Spec.V6IPs, selects)
+	c.updateIPQuotaStatusQueue.Add(key)
+	return nil
+}
+
+func (c *Controller) handleDeleteIPQuota(key string) {
+	c.ipQuotaKeyMutex.LockKey(key)
+	defer func() { _ = c.ipQuotaKeyMutex.UnlockKey(key) }()
+
+	klog.Infof("handle delete ip quota %s", key)
+	c.ipam.DeleteQuota(key)
+}
+
+func (c *Controller) handleUpdateIPQuotaStatus(key string) error {
+	c.ipQuotaKeyMutex.LockKey(key)
+	defer func() { _ = c.ipQuotaKeyMutex.UnlockKey(key) }()
+
+	cachedQuota, err := c.ipQuotaLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+
+	quota := cachedQuota.DeepCopy()
+	quota.Status.V4UsingIPs, quota.Status.V6UsingIPs = c.ipam.QuotaUsage(quota.Name)
+	if reflect.DeepEqual(quota.Status, cachedQuota.Status) {
+		return nil
+	}
+
+	bytes, err := quota.Status.Bytes()
+	if err != nil {
+		klog.Errorf("failed to generate json representation for status of ip quota %s: %v", key, err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().IPQuotas().Patch(context.Background(), quota.Name, types.MergePatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		klog.Errorf("failed to patch status of ip quota %s: %v", key, err)
+		return err
+	}
+	return nil
+}
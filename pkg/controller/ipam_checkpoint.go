@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	ovnipam "github.com/kubeovn/kube-ovn/pkg/ipam"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// ipamReplay replays addresses recorded in kubernetes resources into IPAM.
+// When IPAM has been restored from a checkpoint, addresses already held by
+// the same nic are skipped and everything not seen during the replay is
+// pruned in finish().
+type ipamReplay struct {
+	ipam     *ovnipam.IPAM
+	restored bool
+
+	subnets map[string][]string
+	nics    map[string]map[string]bool
+
+	skipped, replayed int
+}
+
+func newIPAMReplay(ipam *ovnipam.IPAM, restored bool) *ipamReplay {
+	return &ipamReplay{
+		ipam:     ipam,
+		restored: restored,
+		subnets:  map[string][]string{},
+		nics:     map[string]map[string]bool{},
+	}
+}
+
+func (r *ipamReplay) addSubnet(subnet string) {
+	if _, ok := r.subnets[subnet]; !ok {
+		r.subnets[subnet] = []string{}
+	}
+}
+
+func (r *ipamReplay) addIPPool(subnet, ippool string) {
+	r.subnets[subnet] = append(r.subnets[subnet], ippool)
+}
+
+// getStaticAddress works like IPAM.GetStaticAddress with conflict checking,
+// the returned bool reports whether the address was actually allocated
+// instead of being found in the restored checkpoint.
+func (r *ipamReplay) getStaticAddress(podName, nicName, ip string, mac *string, subnetName string) (string, string, string, bool, error) {
+	if r.nics[subnetName] == nil {
+		r.nics[subnetName] = map[string]bool{}
+	}
+	r.nics[subnetName][nicName] = true
+
+	var macStr string
+	if mac != nil {
+		macStr = *mac
+	}
+	if r.restored && r.ipam.HasAddress(podName, nicName, ip, macStr, subnetName) {
+		r.skipped++
+		v4IP, v6IP := util.SplitStringIP(ip)
+		return v4IP, v6IP, macStr, false, nil
+	}
+
+	r.replayed++
+	v4IP, v6IP, macStr, err := r.ipam.GetStaticAddress(podName, nicName, ip, mac, subnetName, true)
+	return v4IP, v6IP, macStr, true, err
+}
+
+func (r *ipamReplay) finish() {
+	if !r.restored {
+		return
+	}
+	r.ipam.PruneCheckpoint(r.subnets, r.nics)
+	klog.Infof("ipam checkpoint delta: %d addresses unchanged, %d addresses replayed", r.skipped, r.replayed)
+}
+
+// restoreIPAMCheckpoint loads the IPAM checkpoint if enabled and returns
+// whether IPAM has been restored from it
+func (c *Controller) restoreIPAMCheckpoint() bool {
+	if c.config.IPAMCheckpointPath == "" {
+		return false
+	}
+
+	start := time.Now()
+	cp, err := ovnipam.LoadCheckpoint(c.config.IPAMCheckpointPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			klog.Infof("ipam checkpoint %s does not exist, initialize ipam from scratch", c.config.IPAMCheckpointPath)
+		} else {
+			klog.Errorf("failed to load ipam checkpoint %s, initialize ipam from scratch: %v", c.config.IPAMCheckpointPath, err)
+		}
+		return false
+	}
+	if err = c.ipam.Restore(cp); err != nil {
+		klog.Errorf("failed to restore ipam from checkpoint %s, initialize ipam from scratch: %v", c.config.IPAMCheckpointPath, err)
+		return false
+	}
+
+	klog.Infof("take %.2f seconds to restore %d subnets from ipam checkpoint %s", time.Since(start).Seconds(), len(cp.Subnets), c.config.IPAMCheckpointPath)
+	return true
+}
+
+func (c *Controller) checkpointIPAM() {
+	start := time.Now()
+	if err := ovnipam.SaveCheckpoint(c.config.IPAMCheckpointPath, c.ipam.Checkpoint()); err != nil {
+		klog.Errorf("failed to save ipam checkpoint %s: %v", c.config.IPAMCheckpointPath, err)
+		return
+	}
+	klog.V(3).Infof("take %.2f seconds to save ipam checkpoint %s", time.Since(start).Seconds(), c.config.IPAMCheckpointPath)
+}
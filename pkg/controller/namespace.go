@@ -24,6 +24,14 @@ func (c *Controller) enqueueAddNamespace(obj interface{}) {
 			c.updateNpQueue.Add(np)
 		}
 	}
+	if c.config.EnableANP {
+		for _, anp := range c.namespaceMatchAdminNetworkPolicies(obj.(*v1.Namespace)) {
+			c.updateAnpQueue.Add(anp)
+		}
+		for _, banp := range c.namespaceMatchBaselineAdminNetworkPolicies(obj.(*v1.Namespace)) {
+			c.updateBanpQueue.Add(banp)
+		}
+	}
 	var key string
 	var err error
 	if key, err = cache.MetaNamespaceKeyFunc(obj); err != nil {
@@ -39,6 +47,14 @@ func (c *Controller) enqueueDeleteNamespace(obj interface{}) {
 			c.updateNpQueue.Add(np)
 		}
 	}
+	if c.config.EnableANP {
+		for _, anp := range c.namespaceMatchAdminNetworkPolicies(obj.(*v1.Namespace)) {
+			c.updateAnpQueue.Add(anp)
+		}
+		for _, banp := range c.namespaceMatchBaselineAdminNetworkPolicies(obj.(*v1.Namespace)) {
+			c.updateBanpQueue.Add(banp)
+		}
+	}
 }
 
 func (c *Controller) enqueueUpdateNamespace(oldObj, newObj interface{}) {
@@ -56,6 +72,27 @@ func (c *Controller) enqueueUpdateNamespace(oldObj, newObj interface{}) {
 		}
 	}
 
+	if c.config.EnableANP && !reflect.DeepEqual(oldNs.Labels, newNs.Labels) {
+		oldAnps := c.namespaceMatchAdminNetworkPolicies(oldNs)
+		newAnps := c.namespaceMatchAdminNetworkPolicies(newNs)
+		for _, anp := range util.DiffStringSlice(oldAnps, newAnps) {
+			c.updateAnpQueue.Add(anp)
+		}
+		oldBanps := c.namespaceMatchBaselineAdminNetworkPolicies(oldNs)
+		newBanps := c.namespaceMatchBaselineAdminNetworkPolicies(newNs)
+		for _, banp := range util.DiffStringSlice(oldBanps, newBanps) {
+			c.updateBanpQueue.Add(banp)
+		}
+	}
+
+	if !reflect.DeepEqual(oldNs.Labels, newNs.Labels) {
+		oldGws := c.namespaceMatchVpcEgressGateways(oldNs)
+		newGws := c.namespaceMatchVpcEgressGateways(newNs)
+		for _, gw := range util.UniqString(append(oldGws, newGws...)) {
+			c.addOrUpdateVpcEgressGatewayQueue.Add(gw)
+		}
+	}
+
 	// in case annotations are removed by other controllers
 	if newNs.Annotations == nil || newNs.Annotations[util.LogicalSwitchAnnotation] == "" {
 		klog.Warningf("no logical switch annotation for ns %s", newNs.Name)
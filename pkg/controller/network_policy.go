@@ -3,6 +3,7 @@ package controller
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"unicode"
@@ -19,6 +20,7 @@ import (
 
 	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
 	"github.com/kubeovn/kube-ovn/pkg/ovs"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
 	"github.com/kubeovn/kube-ovn/pkg/util"
 )
 
@@ -42,6 +44,9 @@ func (c *Controller) enqueueDeleteNp(obj interface{}) {
 	}
 	klog.V(3).Infof("enqueue delete np %s", key)
 	c.deleteNpQueue.Add(key)
+	if np, ok := obj.(*netv1.NetworkPolicy); ok {
+		c.enqueueFqdns(npEgressFqdns(np)...)
+	}
 }
 
 func (c *Controller) enqueueUpdateNp(oldObj, newObj interface{}) {
@@ -57,6 +62,7 @@ func (c *Controller) enqueueUpdateNp(oldObj, newObj interface{}) {
 		}
 		klog.V(3).Infof("enqueue update np %s", key)
 		c.updateNpQueue.Add(key)
+		c.enqueueFqdns(npEgressFqdns(oldNp)...)
 	}
 }
 
@@ -174,6 +180,29 @@ func (c *Controller) handleUpdateNp(key string) error {
 	egressAllowAsNamePrefix := strings.ReplaceAll(fmt.Sprintf("%s.%s.egress.allow", np.Name, np.Namespace), "-", ".")
 	egressExceptAsNamePrefix := strings.ReplaceAll(fmt.Sprintf("%s.%s.egress.except", np.Name, np.Namespace), "-", ".")
 
+	meterName := ovs.GetACLLogMeterName(pgName)
+	if logEnable || np.Annotations[util.NetworkPolicyAuditAnnotation] == "true" {
+		rate := util.DefaultACLLogRate
+		if s := np.Annotations[util.NetworkPolicyLogRateAnnotation]; s != "" {
+			if r, parseErr := strconv.Atoi(s); parseErr != nil || r <= 0 {
+				klog.Errorf("invalid acl log rate %q of np %s, use the default rate %d", s, key, rate)
+			} else {
+				rate = r
+			}
+		}
+		if err = c.OVNNbClient.CreateOrUpdateMeter(meterName, ovnnb.MeterUnitPktps, rate, map[string]string{networkPolicyKey: key}); err != nil {
+			klog.Errorf("create acl log meter for np %s: %v", key, err)
+			return err
+		}
+	} else if err = c.OVNNbClient.DeleteMeter(meterName); err != nil {
+		klog.Errorf("delete acl log meter of np %s: %v", key, err)
+		return err
+	}
+	var logDrop bool
+	if logConfig := npACLLogConfig(np, meterName, ""); logConfig != nil {
+		logDrop = logConfig.LogDrop
+	}
+
 	if err = c.OVNNbClient.CreatePortGroup(pgName, map[string]string{networkPolicyKey: np.Namespace + "/" + np.Name}); err != nil {
 		klog.Errorf("create port group for np %s: %v", key, err)
 		return err
@@ -196,6 +225,16 @@ func (c *Controller) handleUpdateNp(key string) error {
 		subnets = append(subnets, subnet)
 	}
 
+	// the acls of a protocol are generated only once for all the subnets of the selected pods
+	var protocols []string
+	for _, subnet := range subnets {
+		for _, protocol := range subnetCIDRProtocols(subnet) {
+			if !slices.Contains(protocols, protocol) {
+				protocols = append(protocols, protocol)
+			}
+		}
+	}
+
 	if err = c.OVNNbClient.PortGroupSetPorts(pgName, ports); err != nil {
 		klog.Errorf("failed to set ports of port group %s to %v: %v", pgName, ports, err)
 		return err
@@ -209,143 +248,276 @@ func (c *Controller) handleUpdateNp(key string) error {
 		klog.Errorf("failed to fetchSelectedSvc svcIPs result  %v", err)
 		return err
 	}
-	for _, subnet := range subnets {
-		for _, cidrBlock := range strings.Split(subnet.Spec.CIDRBlock, ",") {
-			protocol := util.CheckProtocol(cidrBlock)
-			svcAsName := svcAsNameIPv4
-			svcIPs := svcIpv4s
-			if protocol == kubeovnv1.ProtocolIPv6 {
-				svcAsName = svcAsNameIPv6
-				svcIPs = svcIpv6s
-			}
+	for _, protocol := range protocols {
+		svcAsName := svcAsNameIPv4
+		svcIPs := svcIpv4s
+		if protocol == kubeovnv1.ProtocolIPv6 {
+			svcAsName = svcAsNameIPv6
+			svcIPs = svcIpv6s
+		}
 
-			if err = c.OVNNbClient.CreateAddressSet(svcAsName, map[string]string{
-				networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "service"),
-			}); err != nil {
-				klog.Errorf("create address set %s for np %s: %v", svcAsName, key, err)
-				return err
-			}
+		if err = c.OVNNbClient.CreateAddressSet(svcAsName, map[string]string{
+			networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "service"),
+		}); err != nil {
+			klog.Errorf("create address set %s for np %s: %v", svcAsName, key, err)
+			return err
+		}
 
-			if err = c.OVNNbClient.AddressSetUpdateAddress(svcAsName, svcIPs...); err != nil {
-				klog.Errorf("set service ips to address set %s: %v", svcAsName, err)
-				return err
-			}
+		if err = c.OVNNbClient.AddressSetUpdateAddress(svcAsName, svcIPs...); err != nil {
+			klog.Errorf("set service ips to address set %s: %v", svcAsName, err)
+			return err
 		}
 	}
 
-	var ingressACLOps []ovsdb.Operation
+	// put clear and add acls of both directions and the gateway acls in a single transaction to imitate acl update
+	var aclOps []ovsdb.Operation
 
 	clearIngressACLOps, err := c.OVNNbClient.DeleteAclsOps(pgName, portGroupKey, "to-lport", nil)
 	if err != nil {
 		klog.Errorf("generate operations that clear np %s ingress acls: %v", key, err)
 		return err
 	}
-
-	// put clear acl and update acl in a single transaction to imitate update acl
-	ingressACLOps = append(ingressACLOps, clearIngressACLOps...)
+	aclOps = append(aclOps, clearIngressACLOps...)
 
 	if hasIngressRule(np) {
-		for _, subnet := range subnets {
-			for _, cidrBlock := range strings.Split(subnet.Spec.CIDRBlock, ",") {
-				protocol := util.CheckProtocol(cidrBlock)
-
-				for idx, npr := range np.Spec.Ingress {
-					// A single address set must contain addresses of the same type and the name must be unique within table, so IPv4 and IPv6 address set should be different
-					ingressAllowAsName := fmt.Sprintf("%s.%s.%d", ingressAllowAsNamePrefix, protocol, idx)
-					ingressExceptAsName := fmt.Sprintf("%s.%s.%d", ingressExceptAsNamePrefix, protocol, idx)
-
-					var allows, excepts []string
-					if len(npr.From) == 0 {
-						if protocol == kubeovnv1.ProtocolIPv4 {
-							allows = []string{"0.0.0.0/0"}
-						} else {
-							allows = []string{"::/0"}
-						}
+		for _, protocol := range protocols {
+			for idx, npr := range np.Spec.Ingress {
+				// A single address set must contain addresses of the same type and the name must be unique within table, so IPv4 and IPv6 address set should be different
+				ingressAllowAsName := fmt.Sprintf("%s.%s.%d", ingressAllowAsNamePrefix, protocol, idx)
+				ingressExceptAsName := fmt.Sprintf("%s.%s.%d", ingressExceptAsNamePrefix, protocol, idx)
+
+				var allows, excepts []string
+				if len(npr.From) == 0 {
+					if protocol == kubeovnv1.ProtocolIPv4 {
+						allows = []string{"0.0.0.0/0"}
 					} else {
-						var allow, except []string
-						for _, npp := range npr.From {
-							if allow, except, err = c.fetchPolicySelectedAddresses(np.Namespace, protocol, npp); err != nil {
-								klog.Errorf("failed to fetch policy selected addresses, %v", err)
-								return err
-							}
-							allows = append(allows, allow...)
-							excepts = append(excepts, except...)
+						allows = []string{"::/0"}
+					}
+				} else {
+					var allow, except []string
+					for _, npp := range npr.From {
+						if allow, except, err = c.fetchPolicySelectedAddresses(np.Namespace, protocol, npp); err != nil {
+							klog.Errorf("failed to fetch policy selected addresses, %v", err)
+							return err
 						}
+						allows = append(allows, allow...)
+						excepts = append(excepts, except...)
 					}
-					klog.Infof("UpdateNp Ingress, allows is %v, excepts is %v, log %v", allows, excepts, logEnable)
+				}
+				klog.Infof("UpdateNp Ingress, allows is %v, excepts is %v, log %v", allows, excepts, logEnable)
 
-					if err = c.OVNNbClient.CreateAddressSet(ingressAllowAsName, map[string]string{
-						networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "ingress"),
-					}); err != nil {
-						klog.Errorf("create address set %s for np %s: %v", ingressAllowAsName, key, err)
-						return err
-					}
+				if err = c.OVNNbClient.CreateAddressSet(ingressAllowAsName, map[string]string{
+					networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "ingress"),
+				}); err != nil {
+					klog.Errorf("create address set %s for np %s: %v", ingressAllowAsName, key, err)
+					return err
+				}
 
-					if err = c.OVNNbClient.AddressSetUpdateAddress(ingressAllowAsName, allows...); err != nil {
-						klog.Errorf("set ingress allow ips to address set %s: %v", ingressAllowAsName, err)
-						return err
-					}
+				if err = c.OVNNbClient.AddressSetUpdateAddress(ingressAllowAsName, allows...); err != nil {
+					klog.Errorf("set ingress allow ips to address set %s: %v", ingressAllowAsName, err)
+					return err
+				}
 
-					if err = c.OVNNbClient.CreateAddressSet(ingressExceptAsName, map[string]string{
-						networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "ingress"),
-					}); err != nil {
-						klog.Errorf("create address set %s for np %s: %v", ingressExceptAsName, key, err)
-						return err
-					}
+				if err = c.OVNNbClient.CreateAddressSet(ingressExceptAsName, map[string]string{
+					networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "ingress"),
+				}); err != nil {
+					klog.Errorf("create address set %s for np %s: %v", ingressExceptAsName, key, err)
+					return err
+				}
 
-					if err = c.OVNNbClient.AddressSetUpdateAddress(ingressExceptAsName, excepts...); err != nil {
-						klog.Errorf("set ingress except ips to address set %s: %v", ingressExceptAsName, err)
-						return err
-					}
+				if err = c.OVNNbClient.AddressSetUpdateAddress(ingressExceptAsName, excepts...); err != nil {
+					klog.Errorf("set ingress except ips to address set %s: %v", ingressExceptAsName, err)
+					return err
+				}
 
-					npp := []netv1.NetworkPolicyPort{}
-					if len(allows) != 0 || len(excepts) != 0 {
-						npp = npr.Ports
-					}
+				npp := []netv1.NetworkPolicyPort{}
+				if len(allows) != 0 || len(excepts) != 0 {
+					npp = npr.Ports
+				}
 
-					ops, err := c.OVNNbClient.UpdateIngressACLOps(pgName, ingressAllowAsName, ingressExceptAsName, protocol, npp, logEnable, namedPortMap)
-					if err != nil {
-						klog.Errorf("generate operations that add ingress acls to np %s: %v", key, err)
-						return err
-					}
+				ops, err := c.OVNNbClient.UpdateIngressACLOps(pgName, ingressAllowAsName, ingressExceptAsName, protocol, npp, npACLLogConfig(np, meterName, strconv.Itoa(idx)), namedPortMap)
+				if err != nil {
+					klog.Errorf("generate operations that add ingress acls to np %s: %v", key, err)
+					return err
+				}
 
-					ingressACLOps = append(ingressACLOps, ops...)
+				aclOps = append(aclOps, ops...)
+			}
+			if len(np.Spec.Ingress) == 0 {
+				ingressAllowAsName := fmt.Sprintf("%s.%s.all", ingressAllowAsNamePrefix, protocol)
+				ingressExceptAsName := fmt.Sprintf("%s.%s.all", ingressExceptAsNamePrefix, protocol)
+
+				if err = c.OVNNbClient.CreateAddressSet(ingressAllowAsName, map[string]string{
+					networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "ingress"),
+				}); err != nil {
+					klog.Errorf("create address set %s for np %s: %v", ingressAllowAsName, key, err)
+					return err
 				}
-				if len(np.Spec.Ingress) == 0 {
-					ingressAllowAsName := fmt.Sprintf("%s.%s.all", ingressAllowAsNamePrefix, protocol)
-					ingressExceptAsName := fmt.Sprintf("%s.%s.all", ingressExceptAsNamePrefix, protocol)
-
-					if err = c.OVNNbClient.CreateAddressSet(ingressAllowAsName, map[string]string{
-						networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "ingress"),
-					}); err != nil {
-						klog.Errorf("create address set %s for np %s: %v", ingressAllowAsName, key, err)
-						return err
-					}
 
-					if err = c.OVNNbClient.CreateAddressSet(ingressExceptAsName, map[string]string{
-						networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "ingress"),
-					}); err != nil {
-						klog.Errorf("create address set %s for np %s: %v", ingressExceptAsName, key, err)
-						return err
+				if err = c.OVNNbClient.CreateAddressSet(ingressExceptAsName, map[string]string{
+					networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "ingress"),
+				}); err != nil {
+					klog.Errorf("create address set %s for np %s: %v", ingressExceptAsName, key, err)
+					return err
+				}
+
+				ops, err := c.OVNNbClient.UpdateIngressACLOps(pgName, ingressAllowAsName, ingressExceptAsName, protocol, nil, npACLLogConfig(np, meterName, "all"), namedPortMap)
+				if err != nil {
+					klog.Errorf("generate operations that add ingress acls to np %s: %v", key, err)
+					return err
+				}
+
+				aclOps = append(aclOps, ops...)
+			}
+		}
+	}
+
+	clearEgressACLOps, err := c.OVNNbClient.DeleteAclsOps(pgName, portGroupKey, "from-lport", nil)
+	if err != nil {
+		klog.Errorf("generate operations that clear np %s egress acls: %v", key, err)
+		return err
+	}
+	aclOps = append(aclOps, clearEgressACLOps...)
+
+	if hasEgressRule(np) {
+		fqdns := npEgressFqdns(np)
+		if err = c.createFqdnAddressSets(fqdns...); err != nil {
+			return err
+		}
+
+		for _, protocol := range protocols {
+			for idx, npr := range np.Spec.Egress {
+				// A single address set must contain addresses of the same type and the name must be unique within table, so IPv4 and IPv6 address set should be different
+				egressAllowAsName := fmt.Sprintf("%s.%s.%d", egressAllowAsNamePrefix, protocol, idx)
+				egressExceptAsName := fmt.Sprintf("%s.%s.%d", egressExceptAsNamePrefix, protocol, idx)
+
+				var allows, excepts []string
+				if len(npr.To) == 0 {
+					if protocol == kubeovnv1.ProtocolIPv4 {
+						allows = []string{"0.0.0.0/0"}
+					} else {
+						allows = []string{"::/0"}
+					}
+				} else {
+					var allow, except []string
+					for _, npp := range npr.To {
+						if allow, except, err = c.fetchPolicySelectedAddresses(np.Namespace, protocol, npp); err != nil {
+							klog.Errorf("failed to fetch policy selected addresses, %v", err)
+							return err
+						}
+						allows = append(allows, allow...)
+						excepts = append(excepts, except...)
 					}
+				}
+				klog.Infof("UpdateNp Egress, allows is %v, excepts is %v, log %v", allows, excepts, logEnable)
+
+				if err = c.OVNNbClient.CreateAddressSet(egressAllowAsName, map[string]string{
+					networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "egress"),
+				}); err != nil {
+					klog.Errorf("create address set %s for np %s: %v", egressAllowAsName, key, err)
+					return err
+				}
+
+				if err = c.OVNNbClient.AddressSetUpdateAddress(egressAllowAsName, allows...); err != nil {
+					klog.Errorf("set egress allow ips to address set %s: %v", egressAllowAsName, err)
+					return err
+				}
+
+				if err = c.OVNNbClient.CreateAddressSet(egressExceptAsName, map[string]string{
+					networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "egress"),
+				}); err != nil {
+					klog.Errorf("create address set %s for np %s: %v", egressExceptAsName, key, err)
+					return err
+				}
+
+				if err = c.OVNNbClient.AddressSetUpdateAddress(egressExceptAsName, excepts...); err != nil {
+					klog.Errorf("set egress except ips to address set %s: %v", egressExceptAsName, err)
+					return err
+				}
 
-					ops, err := c.OVNNbClient.UpdateIngressACLOps(pgName, ingressAllowAsName, ingressExceptAsName, protocol, nil, logEnable, namedPortMap)
+				if len(allows) != 0 || len(excepts) != 0 {
+					ops, err := c.OVNNbClient.UpdateEgressACLOps(pgName, egressAllowAsName, egressExceptAsName, protocol, npr.Ports, npACLLogConfig(np, meterName, strconv.Itoa(idx)), namedPortMap)
 					if err != nil {
-						klog.Errorf("generate operations that add ingress acls to np %s: %v", key, err)
+						klog.Errorf("generate operations that add egress acls to np %s: %v", key, err)
 						return err
 					}
 
-					ingressACLOps = append(ingressACLOps, ops...)
+					aclOps = append(aclOps, ops...)
+				}
+			}
+			if len(np.Spec.Egress) == 0 {
+				egressAllowAsName := fmt.Sprintf("%s.%s.all", egressAllowAsNamePrefix, protocol)
+				egressExceptAsName := fmt.Sprintf("%s.%s.all", egressExceptAsNamePrefix, protocol)
+
+				if err = c.OVNNbClient.CreateAddressSet(egressAllowAsName, map[string]string{
+					networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "egress"),
+				}); err != nil {
+					klog.Errorf("create address set %s for np %s: %v", egressAllowAsName, key, err)
+					return err
 				}
 
-				if err = c.OVNNbClient.Transact("add-ingress-acls", ingressACLOps); err != nil {
-					return fmt.Errorf("add ingress acls to %s: %v", pgName, err)
+				if err = c.OVNNbClient.CreateAddressSet(egressExceptAsName, map[string]string{
+					networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "egress"),
+				}); err != nil {
+					klog.Errorf("create address set %s for np %s: %v", egressExceptAsName, key, err)
+					return err
 				}
 
-				if err = c.OVNNbClient.SetACLLog(pgName, protocol, logEnable, true); err != nil {
-					// just log and do not return err here
-					klog.Errorf("failed to set ingress acl log for np %s, %v", key, err)
+				ops, err := c.OVNNbClient.UpdateEgressACLOps(pgName, egressAllowAsName, egressExceptAsName, protocol, nil, npACLLogConfig(np, meterName, "all"), namedPortMap)
+				if err != nil {
+					klog.Errorf("generate operations that add egress acls to np %s: %v", key, err)
+					return err
 				}
+
+				aclOps = append(aclOps, ops...)
+			}
+
+			if len(fqdns) != 0 {
+				ops, err := c.OVNNbClient.UpdateEgressFqdnACLOps(pgName, protocol, fqdns)
+				if err != nil {
+					klog.Errorf("generate operations that add egress fqdn acls to np %s: %v", key, err)
+					return err
+				}
+				aclOps = append(aclOps, ops...)
+			}
+		}
+	}
+
+	var gateways []string
+	for _, subnet := range subnets {
+		gateway := subnet.Spec.Gateway
+		if len(subnet.Spec.SecondaryCIDRBlocks) != 0 {
+			secondaryGateways, err := util.GetGwByCidr(strings.Join(subnet.Spec.SecondaryCIDRBlocks, ","))
+			if err != nil {
+				klog.Error(err)
+				return err
+			}
+			gateway = gateway + "," + secondaryGateways
+		}
+		for _, gw := range strings.Split(gateway, ",") {
+			if !slices.Contains(gateways, gw) {
+				gateways = append(gateways, gw)
+			}
+		}
+	}
+	if len(gateways) != 0 {
+		ops, err := c.OVNNbClient.UpdateGatewayACLOps(pgName, strings.Join(gateways, ","))
+		if err != nil {
+			klog.Errorf("generate operations that add gateway acls to np %s: %v", key, err)
+			return err
+		}
+		aclOps = append(aclOps, ops...)
+	}
+
+	if err = c.OVNNbClient.Transact("update-np-acls", aclOps); err != nil {
+		return fmt.Errorf("update acls of %s: %v", pgName, err)
+	}
+
+	if hasIngressRule(np) {
+		for _, protocol := range protocols {
+			if err = c.OVNNbClient.SetACLLog(pgName, protocol, logDrop, true); err != nil {
+				// just log and do not return err here
+				klog.Errorf("failed to set ingress acl log for np %s, %v", key, err)
 			}
 		}
 
@@ -376,11 +548,6 @@ func (c *Controller) handleUpdateNp(key string) error {
 			}
 		}
 	} else {
-		if err = c.OVNNbClient.DeleteAcls(pgName, portGroupKey, "to-lport", nil); err != nil {
-			klog.Errorf("delete np %s ingress acls: %v", key, err)
-			return err
-		}
-
 		if err := c.OVNNbClient.DeleteAddressSets(map[string]string{
 			networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "ingress"),
 		}); err != nil {
@@ -389,116 +556,11 @@ func (c *Controller) handleUpdateNp(key string) error {
 		}
 	}
 
-	var egressACLOps []ovsdb.Operation
-
-	clearEgressACLOps, err := c.OVNNbClient.DeleteAclsOps(pgName, portGroupKey, "from-lport", nil)
-	if err != nil {
-		klog.Errorf("generate operations that clear np %s egress acls: %v", key, err)
-		return err
-	}
-
-	// put clear and add acl in a single transaction to imitate acl update
-	egressACLOps = append(egressACLOps, clearEgressACLOps...)
-
 	if hasEgressRule(np) {
-		for _, subnet := range subnets {
-			for _, cidrBlock := range strings.Split(subnet.Spec.CIDRBlock, ",") {
-				protocol := util.CheckProtocol(cidrBlock)
-
-				for idx, npr := range np.Spec.Egress {
-					// A single address set must contain addresses of the same type and the name must be unique within table, so IPv4 and IPv6 address set should be different
-					egressAllowAsName := fmt.Sprintf("%s.%s.%d", egressAllowAsNamePrefix, protocol, idx)
-					egressExceptAsName := fmt.Sprintf("%s.%s.%d", egressExceptAsNamePrefix, protocol, idx)
-
-					var allows, excepts []string
-					if len(npr.To) == 0 {
-						if protocol == kubeovnv1.ProtocolIPv4 {
-							allows = []string{"0.0.0.0/0"}
-						} else {
-							allows = []string{"::/0"}
-						}
-					} else {
-						var allow, except []string
-						for _, npp := range npr.To {
-							if allow, except, err = c.fetchPolicySelectedAddresses(np.Namespace, protocol, npp); err != nil {
-								klog.Errorf("failed to fetch policy selected addresses, %v", err)
-								return err
-							}
-							allows = append(allows, allow...)
-							excepts = append(excepts, except...)
-						}
-					}
-					klog.Infof("UpdateNp Egress, allows is %v, excepts is %v, log %v", allows, excepts, logEnable)
-
-					if err = c.OVNNbClient.CreateAddressSet(egressAllowAsName, map[string]string{
-						networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "egress"),
-					}); err != nil {
-						klog.Errorf("create address set %s for np %s: %v", egressAllowAsName, key, err)
-						return err
-					}
-
-					if err = c.OVNNbClient.AddressSetUpdateAddress(egressAllowAsName, allows...); err != nil {
-						klog.Errorf("set egress allow ips to address set %s: %v", egressAllowAsName, err)
-						return err
-					}
-
-					if err = c.OVNNbClient.CreateAddressSet(egressExceptAsName, map[string]string{
-						networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "egress"),
-					}); err != nil {
-						klog.Errorf("create address set %s for np %s: %v", egressExceptAsName, key, err)
-						return err
-					}
-
-					if err = c.OVNNbClient.AddressSetUpdateAddress(egressExceptAsName, excepts...); err != nil {
-						klog.Errorf("set egress except ips to address set %s: %v", egressExceptAsName, err)
-						return err
-					}
-
-					if len(allows) != 0 || len(excepts) != 0 {
-						ops, err := c.OVNNbClient.UpdateEgressACLOps(pgName, egressAllowAsName, egressExceptAsName, protocol, npr.Ports, logEnable, namedPortMap)
-						if err != nil {
-							klog.Errorf("generate operations that add egress acls to np %s: %v", key, err)
-							return err
-						}
-
-						egressACLOps = append(egressACLOps, ops...)
-					}
-				}
-				if len(np.Spec.Egress) == 0 {
-					egressAllowAsName := fmt.Sprintf("%s.%s.all", egressAllowAsNamePrefix, protocol)
-					egressExceptAsName := fmt.Sprintf("%s.%s.all", egressExceptAsNamePrefix, protocol)
-
-					if err = c.OVNNbClient.CreateAddressSet(egressAllowAsName, map[string]string{
-						networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "egress"),
-					}); err != nil {
-						klog.Errorf("create address set %s for np %s: %v", egressAllowAsName, key, err)
-						return err
-					}
-
-					if err = c.OVNNbClient.CreateAddressSet(egressExceptAsName, map[string]string{
-						networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "egress"),
-					}); err != nil {
-						klog.Errorf("create address set %s for np %s: %v", egressExceptAsName, key, err)
-						return err
-					}
-
-					ops, err := c.OVNNbClient.UpdateEgressACLOps(pgName, egressAllowAsName, egressExceptAsName, protocol, nil, logEnable, namedPortMap)
-					if err != nil {
-						klog.Errorf("generate operations that add egress acls to np %s: %v", key, err)
-						return err
-					}
-
-					egressACLOps = append(egressACLOps, ops...)
-				}
-
-				if err = c.OVNNbClient.Transact("add-egress-acls", egressACLOps); err != nil {
-					return fmt.Errorf("add egress acls to %s: %v", pgName, err)
-				}
-
-				if err = c.OVNNbClient.SetACLLog(pgName, protocol, logEnable, false); err != nil {
-					// just log and do not return err here
-					klog.Errorf("failed to set egress acl log for np %s, %v", key, err)
-				}
+		for _, protocol := range protocols {
+			if err = c.OVNNbClient.SetACLLog(pgName, protocol, logDrop, false); err != nil {
+				// just log and do not return err here
+				klog.Errorf("failed to set egress acl log for np %s, %v", key, err)
 			}
 		}
 
@@ -530,11 +592,6 @@ func (c *Controller) handleUpdateNp(key string) error {
 			}
 		}
 	} else {
-		if err = c.OVNNbClient.DeleteAcls(pgName, portGroupKey, "from-lport", nil); err != nil {
-			klog.Errorf("delete np %s egress acls: %v", key, err)
-			return err
-		}
-
 		if err := c.OVNNbClient.DeleteAddressSets(map[string]string{
 			networkPolicyKey: fmt.Sprintf("%s/%s/%s", np.Namespace, np.Name, "egress"),
 		}); err != nil {
@@ -543,12 +600,6 @@ func (c *Controller) handleUpdateNp(key string) error {
 		}
 	}
 
-	for _, subnet := range subnets {
-		if err = c.OVNNbClient.CreateGatewayACL("", pgName, subnet.Spec.Gateway); err != nil {
-			klog.Errorf("create gateway acl: %v", err)
-			return err
-		}
-	}
 	return nil
 }
 
@@ -574,6 +625,11 @@ func (c *Controller) handleDeleteNp(key string) error {
 		klog.Errorf("delete np %s port group: %v", key, err)
 	}
 
+	if err = c.OVNNbClient.DeleteMeter(ovs.GetACLLogMeterName(pgName)); err != nil {
+		klog.Errorf("delete np %s acl log meter: %v", key, err)
+		return err
+	}
+
 	if err := c.OVNNbClient.DeleteAddressSets(map[string]string{
 		networkPolicyKey: fmt.Sprintf("%s/%s/%s", namespace, npName, "service"),
 	}); err != nil {
@@ -598,6 +654,42 @@ func (c *Controller) handleDeleteNp(key string) error {
 	return nil
 }
 
+// npACLLogConfig returns the log config of the acls created for a network policy rule,
+// nil is returned if acl log is disabled and the policy is not in audit mode
+func npACLLogConfig(np *netv1.NetworkPolicy, meter, rule string) *ovs.ACLLogConfig {
+	logEnable := np.Annotations[util.NetworkPolicyLogAnnotation] == "true"
+	audit := np.Annotations[util.NetworkPolicyAuditAnnotation] == "true"
+	if !logEnable && !audit {
+		return nil
+	}
+
+	config := &ovs.ACLLogConfig{
+		Policy: fmt.Sprintf("%s/%s", np.Namespace, np.Name),
+		Rule:   rule,
+		Meter:  meter,
+		Audit:  audit,
+	}
+	if !logEnable {
+		return config
+	}
+	actions := np.Annotations[util.NetworkPolicyLogActionsAnnotation]
+	if actions == "" {
+		config.LogDrop = true
+		return config
+	}
+	for _, action := range strings.Split(actions, ",") {
+		switch strings.TrimSpace(action) {
+		case "allow":
+			config.LogAllow = true
+		case "drop":
+			config.LogDrop = true
+		default:
+			klog.Warningf("ignore unknown acl log action %q of np %s/%s", action, np.Namespace, np.Name)
+		}
+	}
+	return config
+}
+
 func (c *Controller) fetchSelectedPorts(namespace string, selector *metav1.LabelSelector) ([]string, []string, error) {
 	var subnets []string
 	sel, err := metav1.LabelSelectorAsSelector(selector)
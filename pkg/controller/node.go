@@ -518,6 +518,11 @@ func (c *Controller) handleDeleteNode(key string) error {
 
 	c.ipam.ReleaseAddressByPod(portName)
 
+	if err := c.config.KubeOvnClient.KubeovnV1().FqdnCaches().Delete(context.Background(), key, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		klog.Errorf("failed to delete fqdn cache of node %s: %v", key, err)
+		return err
+	}
+
 	providerNetworks, err := c.providerNetworksLister.List(labels.Everything())
 	if err != nil && !k8serrors.IsNotFound(err) {
 		klog.Errorf("failed to list provider networks: %v", err)
@@ -769,7 +774,7 @@ func (c *Controller) checkGatewayReady() error {
 		for _, node := range nodes {
 			ipStr := node.Annotations[util.IPAddressAnnotation]
 			for _, ip := range strings.Split(ipStr, ",") {
-				for _, cidrBlock := range strings.Split(subnet.Spec.CIDRBlock, ",") {
+				for _, cidrBlock := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
 					if util.CheckProtocol(cidrBlock) != util.CheckProtocol(ip) {
 						continue
 					}
@@ -1099,7 +1104,7 @@ func (c *Controller) deletePolicyRouteForNode(nodeName string) error {
 
 		if subnet.Spec.GatewayType == kubeovnv1.GWCentralizedType {
 			if subnet.Spec.EnableEcmp {
-				for _, cidrBlock := range strings.Split(subnet.Spec.CIDRBlock, ",") {
+				for _, cidrBlock := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
 					nextHops, nameIPMap, err := c.getPolicyRouteParas(cidrBlock, util.GatewayRouterPolicyPriority)
 					if err != nil {
 						klog.Errorf("get ecmp policy route paras for subnet %v, error %v", subnet.Name, err)
@@ -1160,7 +1165,7 @@ func (c *Controller) addPolicyRouteForCentralizedSubnetOnNode(nodeName, nodeIP s
 			}
 
 			for _, nextHop := range strings.Split(nodeIP, ",") {
-				for _, cidrBlock := range strings.Split(subnet.Spec.CIDRBlock, ",") {
+				for _, cidrBlock := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
 					if util.CheckProtocol(cidrBlock) != util.CheckProtocol(nextHop) {
 						continue
 					}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strconv"
 
 	"github.com/ovn-org/libovsdb/ovsdb"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -185,23 +186,29 @@ func (c *Controller) processNextDeleteOvnDnatRuleWorkItem() bool {
 	return true
 }
 
-func (c *Controller) isOvnDnatDuplicated(eipName, dnatName, externalPort string) error {
-	// check if eip:external port already used
-	dnats, err := c.ovnDnatRulesLister.List(labels.SelectorFromSet(labels.Set{
-		util.VpcDnatEPortLabel: externalPort,
-	}))
+func (c *Controller) isOvnDnatDuplicated(eipName, dnatName, protocol, externalPort, internalPort string) error {
+	// check if any external port of the eip is already used
+	mappings, err := util.ParsePortMappings(externalPort, internalPort)
 	if err != nil {
-		if !k8serrors.IsNotFound(err) {
-			klog.Error(err)
-			return err
-		}
+		err = fmt.Errorf("failed to create dnat %s, %v", dnatName, err)
+		return err
 	}
-	if len(dnats) != 0 {
-		for _, d := range dnats {
-			if d.Name != dnatName && d.Spec.OvnEip == eipName {
-				err = fmt.Errorf("failed to create dnat %s, duplicate, same eip %s, same external port '%s' is using by dnat %s", dnatName, eipName, externalPort, d.Name)
-				return err
-			}
+	dnats, err := c.ovnDnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	for _, d := range dnats {
+		if d.Name == dnatName || d.Spec.OvnEip != eipName || util.DnatProtocol(d.Spec.Protocol) != util.DnatProtocol(protocol) {
+			continue
+		}
+		others, err := util.ParsePortMappings(d.Spec.ExternalPort, d.Spec.InternalPort)
+		if err != nil {
+			continue
+		}
+		if util.PortMappingsOverlap(mappings, others) {
+			err = fmt.Errorf("failed to create dnat %s, duplicate, same eip %s, external port '%s' overlaps with '%s' of dnat %s", dnatName, eipName, externalPort, d.Spec.ExternalPort, d.Name)
+			return err
 		}
 	}
 	return nil
@@ -245,7 +252,7 @@ func (c *Controller) handleAddOvnDnatRule(key string) error {
 		klog.Error(err)
 		return err
 	}
-	if err := c.isOvnDnatDuplicated(eipName, key, cachedDnat.Spec.ExternalPort); err != nil {
+	if err := c.isOvnDnatDuplicated(eipName, key, cachedDnat.Spec.Protocol, cachedDnat.Spec.ExternalPort, cachedDnat.Spec.InternalPort); err != nil {
 		klog.Errorf("failed to create dnat %s, %v", cachedDnat.Name, err)
 		return err
 	}
@@ -346,8 +353,8 @@ func (c *Controller) handleDelOvnDnatRule(key string) error {
 		return err
 	}
 	if cachedDnat.Status.Vpc != "" && cachedDnat.Status.V4Eip != "" && cachedDnat.Status.ExternalPort != "" {
-		if err = c.DelDnatRule(cachedDnat.Status.Vpc, cachedDnat.Name,
-			cachedDnat.Status.V4Eip, cachedDnat.Status.ExternalPort); err != nil {
+		if err = c.DelDnatRule(cachedDnat.Status.Vpc, cachedDnat.Name, cachedDnat.Status.V4Eip,
+			cachedDnat.Status.ExternalPort, cachedDnat.Status.InternalPort); err != nil {
 			klog.Errorf("failed to delete dnat %s, %v", key, err)
 			return err
 		}
@@ -396,7 +403,7 @@ func (c *Controller) handleUpdateOvnDnatRule(key string) error {
 		klog.Error(err)
 		return err
 	}
-	if err := c.isOvnDnatDuplicated(eipName, key, cachedDnat.Spec.ExternalPort); err != nil {
+	if err := c.isOvnDnatDuplicated(eipName, key, cachedDnat.Spec.Protocol, cachedDnat.Spec.ExternalPort, cachedDnat.Spec.InternalPort); err != nil {
 		klog.Errorf("failed to create dnat %s, %v", cachedDnat.Name, err)
 		return err
 	}
@@ -448,7 +455,7 @@ func (c *Controller) handleUpdateOvnDnatRule(key string) error {
 	dnat := cachedDnat.DeepCopy()
 	if dnat.Status.Ready {
 		klog.Infof("dnat change ip, old ip '%s', new ip %s", dnat.Status.V4Ip, cachedEip.Status.V4Ip)
-		if err = c.DelDnatRule(dnat.Status.Vpc, dnat.Name, dnat.Status.V4Eip, dnat.Status.ExternalPort); err != nil {
+		if err = c.DelDnatRule(dnat.Status.Vpc, dnat.Name, dnat.Status.V4Eip, dnat.Status.ExternalPort, dnat.Status.InternalPort); err != nil {
 			klog.Errorf("failed to delete dnat, %v", err)
 			return err
 		}
@@ -619,20 +626,29 @@ func (c *Controller) patchOvnDnatStatus(key, vpcName, v4Eip, podIP string, ready
 	return nil
 }
 
+// AddDnatRule adds a vip to the load balancer of the dnat for each external port in one transaction,
+// the external ports of a port range are mapped to the internal ports one to one
 func (c *Controller) AddDnatRule(vpcName, dnatName, externalIP, internalIP, externalPort, internalPort, protocol string) error {
-	var (
-		externalEndpoint = net.JoinHostPort(externalIP, externalPort)
-		internalEndpoint = net.JoinHostPort(internalIP, internalPort)
-		err              error
-	)
+	mappings, err := util.ParsePortMappings(externalPort, internalPort)
+	if err != nil {
+		klog.Errorf("failed to parse ports of dnat %s: %v", dnatName, err)
+		return err
+	}
 
 	if err = c.OVNNbClient.CreateLoadBalancer(dnatName, protocol, ""); err != nil {
 		klog.Errorf("create loadBalancer %s: %v", dnatName, err)
 		return err
 	}
 
-	if err = c.OVNNbClient.LoadBalancerAddVip(dnatName, externalEndpoint, internalEndpoint); err != nil {
-		klog.Errorf("add vip %s with backends %s to LB %s: %v", externalEndpoint, internalEndpoint, dnatName, err)
+	vips := make(map[string]string)
+	for _, m := range mappings {
+		for port := m.ExternalStart; port <= m.ExternalEnd; port++ {
+			externalEndpoint := net.JoinHostPort(externalIP, strconv.Itoa(port))
+			vips[externalEndpoint] = net.JoinHostPort(internalIP, strconv.Itoa(m.InternalPort(port)))
+		}
+	}
+	if err = c.OVNNbClient.LoadBalancerAddVips(dnatName, vips); err != nil {
+		klog.Errorf("add vips of external ports %s to LB %s: %v", externalPort, dnatName, err)
 		return err
 	}
 
@@ -643,16 +659,21 @@ func (c *Controller) AddDnatRule(vpcName, dnatName, externalIP, internalIP, exte
 	return nil
 }
 
-func (c *Controller) DelDnatRule(vpcName, dnatName, externalIP, externalPort string) error {
-	var (
-		ignoreHealthCheck = true
-		externalEndpoint  string
-		err               error
-	)
-	externalEndpoint = net.JoinHostPort(externalIP, externalPort)
+func (c *Controller) DelDnatRule(vpcName, dnatName, externalIP, externalPort, internalPort string) error {
+	mappings, err := util.ParsePortMappings(externalPort, internalPort)
+	if err != nil {
+		klog.Errorf("failed to parse ports of dnat %s: %v", dnatName, err)
+		return err
+	}
 
-	if err = c.OVNNbClient.LoadBalancerDeleteVip(dnatName, externalEndpoint, ignoreHealthCheck); err != nil {
-		klog.Errorf("delete loadBalancer vips %s: %v", externalEndpoint, err)
+	var vips []string
+	for _, m := range mappings {
+		for port := m.ExternalStart; port <= m.ExternalEnd; port++ {
+			vips = append(vips, net.JoinHostPort(externalIP, strconv.Itoa(port)))
+		}
+	}
+	if err = c.OVNNbClient.LoadBalancerDeleteVips(dnatName, vips); err != nil {
+		klog.Errorf("delete loadBalancer vips of external ports %s: %v", externalPort, err)
 		return err
 	}
 
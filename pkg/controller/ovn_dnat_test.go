@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ovn-org/libovsdb/ovsdb"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AddDnatRule(t *testing.T) {
+	t.Parallel()
+
+	fakeController := newFakeController(t)
+	ctrl := fakeController.fakeController
+	mockOvnClient := fakeController.mockOvnClient
+
+	gomock.InOrder(
+		mockOvnClient.EXPECT().CreateLoadBalancer("dnat1", "udp", "").Return(nil),
+		mockOvnClient.EXPECT().LoadBalancerAddVips("dnat1", map[string]string{
+			"172.18.0.10:80":    "10.0.1.10:8080",
+			"172.18.0.10:27000": "10.0.1.10:37000",
+			"172.18.0.10:27001": "10.0.1.10:37001",
+		}).Return(nil),
+		mockOvnClient.EXPECT().LogicalRouterUpdateLoadBalancers("vpc1", ovsdb.MutateOperationInsert, "dnat1").Return(nil),
+	)
+	err := ctrl.AddDnatRule("vpc1", "dnat1", "172.18.0.10", "10.0.1.10", "80,27000-27001", "8080,37000-37001", "udp")
+	require.NoError(t, err)
+
+	err = ctrl.AddDnatRule("vpc1", "dnat1", "172.18.0.10", "10.0.1.10", "27000-27001", "37000", "udp")
+	require.Error(t, err)
+}
+
+func Test_DelDnatRule(t *testing.T) {
+	t.Parallel()
+
+	fakeController := newFakeController(t)
+	ctrl := fakeController.fakeController
+	mockOvnClient := fakeController.mockOvnClient
+
+	gomock.InOrder(
+		mockOvnClient.EXPECT().LoadBalancerDeleteVips("dnat1", []string{"172.18.0.10:27000", "172.18.0.10:27001"}).Return(nil),
+		mockOvnClient.EXPECT().LogicalRouterUpdateLoadBalancers("vpc1", ovsdb.MutateOperationDelete, "dnat1").Return(nil),
+	)
+	err := ctrl.DelDnatRule("vpc1", "dnat1", "172.18.0.10", "27000-27001", "37000-37001")
+	require.NoError(t, err)
+}
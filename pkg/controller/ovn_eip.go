@@ -358,6 +358,10 @@ func (c *Controller) handleDelOvnEip(key string) error {
 		}
 	}
 
+	if c.isVpcEipAddressHeld(kubeovnv1.NatBackendIptables, eip.Name) {
+		klog.Infof("keep the address of ovn eip %s held by iptables eip %s", eip.Name, eip.Name)
+		return nil
+	}
 	c.ipam.ReleaseAddressByPod(eip.Name)
 	c.updateSubnetStatusQueue.Add(eip.Spec.ExternalSubnet)
 	return nil
@@ -72,7 +72,7 @@ func (c *Controller) resyncInterConnection() {
 	}
 	for _, subnet := range subnets {
 		if subnet.Spec.DisableInterConnection || subnet.Name == c.config.NodeSwitch {
-			blackList = append(blackList, subnet.Spec.CIDRBlock)
+			blackList = append(blackList, util.GetSubnetCIDRBlocks(subnet))
 		}
 	}
 	nodes, err := c.nodesLister.List(labels.Everything())
@@ -52,7 +52,8 @@ func (c *Controller) enqueueUpdateOvnSnatRule(oldObj, newObj interface{}) {
 	}
 	if oldSnat.Spec.OvnEip != newSnat.Spec.OvnEip ||
 		oldSnat.Spec.VpcSubnet != newSnat.Spec.VpcSubnet ||
-		oldSnat.Spec.IPName != newSnat.Spec.IPName {
+		oldSnat.Spec.IPName != newSnat.Spec.IPName ||
+		oldSnat.Spec.PortBlockSize != newSnat.Spec.PortBlockSize {
 		klog.Infof("enqueue update snat %s", key)
 		c.updateOvnSnatRuleQueue.Add(key)
 		return
@@ -251,16 +252,26 @@ func (c *Controller) handleAddOvnSnatRule(key string) error {
 		return err
 	}
 
+	portBlockStart, err := c.allocateOvnSnatPortBlocks(cachedSnat, v4IpCidr)
+	if err != nil {
+		klog.Errorf("failed to allocate port blocks of snat %s, %v", key, err)
+		return err
+	}
+
 	// create snat
 	if err = c.handleAddOvnEipFinalizer(cachedEip, util.ControllerName); err != nil {
 		klog.Errorf("failed to add finalizer for ovn eip, %v", err)
 		return err
 	}
 	// about conflicts: if multi vpc snat use the same eip, if only one gw node exist, it may should work
-	if err = c.OVNNbClient.AddNat(vpcName, ovnnb.NATTypeSNAT, cachedEip.Spec.V4Ip, v4IpCidr, "", "", nil); err != nil {
+	if err = c.addOvnSnat(vpcName, cachedEip.Spec.V4Ip, v4IpCidr, cachedSnat.Spec.PortBlockSize, portBlockStart); err != nil {
 		klog.Errorf("failed to create snat, %v", err)
 		return err
 	}
+	if err = c.syncSnatPortBlocks(cachedSnat, ovnSnatRuleKind, cachedSnat.Spec.PortBlockSize, portBlockStart, cachedEip.Spec.V4Ip, v4IpCidr); err != nil {
+		klog.Errorf("failed to record port blocks of snat %s, %v", key, err)
+		return err
+	}
 	if err := c.handleAddOvnSnatFinalizer(cachedSnat, util.ControllerName); err != nil {
 		klog.Errorf("failed to add finalizer for ovn snat %s, %v", cachedSnat.Name, err)
 		return err
@@ -297,11 +308,16 @@ func (c *Controller) handleUpdateOvnSnatRule(key string) error {
 	if !cachedSnat.DeletionTimestamp.IsZero() {
 		klog.Infof("ovn delete snat %s", key)
 		if cachedSnat.Status.Vpc != "" && cachedSnat.Status.V4Eip != "" && cachedSnat.Status.V4IpCidr != "" {
-			if err = c.OVNNbClient.DeleteNat(cachedSnat.Status.Vpc, ovnnb.NATTypeSNAT, cachedSnat.Status.V4Eip, cachedSnat.Status.V4IpCidr); err != nil {
+			portBlockStart := c.snatPortBlockStart(ovnSnatRuleKind, cachedSnat.Name, cachedSnat.Status.PortBlockStart)
+			if err = c.deleteOvnSnat(cachedSnat.Status.Vpc, cachedSnat.Status.V4Eip, cachedSnat.Status.V4IpCidr, cachedSnat.Status.PortBlockSize, portBlockStart); err != nil {
 				klog.Errorf("failed to delete snat, %v", err)
 				return err
 			}
 		}
+		if err = c.releaseSnatPortBlocks(cachedSnat, ovnSnatRuleKind); err != nil {
+			klog.Errorf("failed to release port blocks of snat %s, %v", key, err)
+			return err
+		}
 		c.resetOvnEipQueue.Add(cachedSnat.Spec.OvnEip)
 		return nil
 	}
@@ -376,18 +392,30 @@ func (c *Controller) handleUpdateOvnSnatRule(key string) error {
 		klog.Error(err)
 		return err
 	}
-	// snat change eip
-	if c.ovnSnatChangeEip(cachedSnat, cachedEip) {
-		klog.Infof("snat change ip, old ip %s, new ip %s", cachedEip.Status.V4Ip, cachedEip.Spec.V4Ip)
-		if err = c.OVNNbClient.DeleteNat(vpcName, ovnnb.NATTypeSNAT, cachedEip.Status.V4Ip, v4IpCidr); err != nil {
+	// snat change eip or port block size
+	if c.ovnSnatChangeEip(cachedSnat, cachedEip) ||
+		(cachedSnat.Status.V4Eip != "" && cachedSnat.Status.PortBlockSize != cachedSnat.Spec.PortBlockSize) {
+		klog.Infof("snat change ip, old ip %s, new ip %s, old port block size %d, new port block size %d",
+			cachedEip.Status.V4Ip, cachedEip.Spec.V4Ip, cachedSnat.Status.PortBlockSize, cachedSnat.Spec.PortBlockSize)
+		oldPortBlockStart := c.snatPortBlockStart(ovnSnatRuleKind, cachedSnat.Name, cachedSnat.Status.PortBlockStart)
+		portBlockStart, err := c.allocateOvnSnatPortBlocks(cachedSnat, v4IpCidr)
+		if err != nil {
+			klog.Errorf("failed to allocate port blocks of snat %s, %v", key, err)
+			return err
+		}
+		if err = c.deleteOvnSnat(vpcName, cachedEip.Status.V4Ip, v4IpCidr, cachedSnat.Status.PortBlockSize, oldPortBlockStart); err != nil {
 			klog.Errorf("failed to delte snat, %v", err)
 			return err
 		}
 		// ovn add snat with new eip
-		if err = c.OVNNbClient.AddNat(vpcName, ovnnb.NATTypeSNAT, cachedEip.Spec.V4Ip, v4IpCidr, "", "", nil); err != nil {
+		if err = c.addOvnSnat(vpcName, cachedEip.Spec.V4Ip, v4IpCidr, cachedSnat.Spec.PortBlockSize, portBlockStart); err != nil {
 			klog.Errorf("failed to create snat, %v", err)
 			return err
 		}
+		if err = c.syncSnatPortBlocks(cachedSnat, ovnSnatRuleKind, cachedSnat.Spec.PortBlockSize, portBlockStart, cachedEip.Spec.V4Ip, v4IpCidr); err != nil {
+			klog.Errorf("failed to record port blocks of snat %s, %v", key, err)
+			return err
+		}
 		if err = c.natLabelAndAnnoOvnEip(eipName, cachedSnat.Name, vpcName); err != nil {
 			klog.Errorf("failed to label snat '%s' in eip %s, %v", cachedSnat.Name, eipName, err)
 			return err
@@ -417,12 +445,17 @@ func (c *Controller) handleDelOvnSnatRule(key string) error {
 	}
 	// ovn delete snat
 	if cachedSnat.Status.Vpc != "" && cachedSnat.Status.V4Eip != "" && cachedSnat.Status.V4IpCidr != "" {
-		if err = c.OVNNbClient.DeleteNat(cachedSnat.Status.Vpc, ovnnb.NATTypeSNAT,
-			cachedSnat.Status.V4Eip, cachedSnat.Status.V4IpCidr); err != nil {
+		portBlockStart := c.snatPortBlockStart(ovnSnatRuleKind, cachedSnat.Name, cachedSnat.Status.PortBlockStart)
+		if err = c.deleteOvnSnat(cachedSnat.Status.Vpc, cachedSnat.Status.V4Eip,
+			cachedSnat.Status.V4IpCidr, cachedSnat.Status.PortBlockSize, portBlockStart); err != nil {
 			klog.Errorf("failed to delete snat %s, %v", key, err)
 			return err
 		}
 	}
+	if err = c.releaseSnatPortBlocks(cachedSnat, ovnSnatRuleKind); err != nil {
+		klog.Errorf("failed to release port blocks of snat %s, %v", key, err)
+		return err
+	}
 	if err = c.handleDelOvnSnatFinalizer(cachedSnat, util.ControllerName); err != nil {
 		klog.Errorf("failed to remove finalizer for ovn snat %s, %v", cachedSnat.Name, err)
 		return err
@@ -483,6 +516,14 @@ func (c *Controller) patchOvnSnatStatus(key, vpc, v4Eip, v4IpCidr string, ready
 		snat.Status.V4IpCidr = v4IpCidr
 		changed = true
 	}
+	if ready && snat.Status.PortBlockSize != snat.Spec.PortBlockSize {
+		snat.Status.PortBlockSize = snat.Spec.PortBlockSize
+		changed = true
+	}
+	if portRange, ok := c.snatPortRanges.get(ovnSnatRuleKind, snat.Name); ok && ready && int(snat.Status.PortBlockStart) != portRange.start {
+		snat.Status.PortBlockStart = int32(portRange.start)
+		changed = true
+	}
 	if changed {
 		bytes, err := snat.Status.Bytes()
 		if err != nil {
@@ -498,6 +539,48 @@ func (c *Controller) patchOvnSnatStatus(key, vpc, v4Eip, v4IpCidr string, ready
 	return nil
 }
 
+// addOvnSnat adds the snat of the internal cidr, in port block mode a snat is added
+// for each internal ip to translate it to the port block allocated to it
+func (c *Controller) addOvnSnat(vpcName, v4Eip, v4IpCidr string, portBlockSize int32, portBlockStart int) error {
+	if portBlockSize == 0 {
+		return c.OVNNbClient.AddNat(vpcName, ovnnb.NATTypeSNAT, v4Eip, v4IpCidr, "", "", nil)
+	}
+	blocks, err := util.AllocateSnatPortBlocks(v4IpCidr, int(portBlockSize), portBlockStart)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	portRanges := make(map[string]string, len(blocks))
+	for _, block := range blocks {
+		portRanges[block.InternalIP] = block.PortRange()
+	}
+	if err = c.OVNNbClient.UpdateSnatPortRanges(vpcName, v4Eip, portRanges); err != nil {
+		klog.Errorf("failed to add snats of %s with port blocks, %v", v4IpCidr, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) deleteOvnSnat(vpcName, v4Eip, v4IpCidr string, portBlockSize int32, portBlockStart int) error {
+	if portBlockSize == 0 {
+		return c.OVNNbClient.DeleteNat(vpcName, ovnnb.NATTypeSNAT, v4Eip, v4IpCidr)
+	}
+	blocks, err := util.AllocateSnatPortBlocks(v4IpCidr, int(portBlockSize), portBlockStart)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	logicalIPs := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		logicalIPs = append(logicalIPs, block.InternalIP)
+	}
+	if err = c.OVNNbClient.DeleteSnats(vpcName, v4Eip, logicalIPs); err != nil {
+		klog.Errorf("failed to delete snats of %s with port blocks, %v", v4IpCidr, err)
+		return err
+	}
+	return nil
+}
+
 func (c *Controller) patchOvnSnatAnnotation(key, eipName string) error {
 	oriFip, err := c.ovnSnatRulesLister.Get(key)
 	if err != nil {
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+)
+
+func Test_addOvnSnat(t *testing.T) {
+	t.Parallel()
+
+	fakeController := newFakeController(t)
+	ctrl := fakeController.fakeController
+	mockOvnClient := fakeController.mockOvnClient
+
+	mockOvnClient.EXPECT().AddNat("vpc1", ovnnb.NATTypeSNAT, "172.18.0.10", "10.0.1.0/24", "", "", nil).Return(nil)
+	err := ctrl.addOvnSnat("vpc1", "172.18.0.10", "10.0.1.0/24", 0, 0)
+	require.NoError(t, err)
+
+	mockOvnClient.EXPECT().UpdateSnatPortRanges("vpc1", "172.18.0.10", map[string]string{"10.0.1.0": "1024-33279", "10.0.1.1": "33280-65535"}).Return(nil)
+	err = ctrl.addOvnSnat("vpc1", "172.18.0.10", "10.0.1.0/31", 32256, 1024)
+	require.NoError(t, err)
+
+	// the port blocks start at the port allocated on the eip
+	mockOvnClient.EXPECT().UpdateSnatPortRanges("vpc1", "172.18.0.10", map[string]string{"10.0.2.0": "3024-4023", "10.0.2.1": "4024-5023"}).Return(nil)
+	err = ctrl.addOvnSnat("vpc1", "172.18.0.10", "10.0.2.0/31", 1000, 3024)
+	require.NoError(t, err)
+
+	err = ctrl.addOvnSnat("vpc1", "172.18.0.10", "10.0.1.0/30", 32256, 1024)
+	require.Error(t, err)
+}
+
+func Test_deleteOvnSnat(t *testing.T) {
+	t.Parallel()
+
+	fakeController := newFakeController(t)
+	ctrl := fakeController.fakeController
+	mockOvnClient := fakeController.mockOvnClient
+
+	mockOvnClient.EXPECT().DeleteNat("vpc1", ovnnb.NATTypeSNAT, "172.18.0.10", "10.0.1.0/24").Return(nil)
+	err := ctrl.deleteOvnSnat("vpc1", "172.18.0.10", "10.0.1.0/24", 0, 0)
+	require.NoError(t, err)
+
+	mockOvnClient.EXPECT().DeleteSnats("vpc1", "172.18.0.10", []string{"10.0.1.0", "10.0.1.1"}).Return(nil)
+	err = ctrl.deleteOvnSnat("vpc1", "172.18.0.10", "10.0.1.0/31", 32256, 1024)
+	require.NoError(t, err)
+}
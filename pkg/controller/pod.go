@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"reflect"
@@ -180,12 +181,20 @@ func (c *Controller) enqueueAddPod(obj interface{}) {
 
 	p := obj.(*v1.Pod)
 	// TODO: we need to find a way to reduce duplicated np added to the queue
-	if c.config.EnableNP {
+	if c.config.EnableNP || c.config.EnableANP {
 		c.namedPort.AddNamedPortByPod(p)
-		if p.Status.PodIP != "" {
-			for _, np := range c.podMatchNetworkPolicies(p) {
-				c.updateNpQueue.Add(np)
-			}
+	}
+	if c.config.EnableNP && p.Status.PodIP != "" {
+		for _, np := range c.podMatchNetworkPolicies(p) {
+			c.updateNpQueue.Add(np)
+		}
+	}
+	if c.config.EnableANP && p.Status.PodIP != "" {
+		for _, anp := range c.podMatchAdminNetworkPolicies(p) {
+			c.updateAnpQueue.Add(anp)
+		}
+		for _, banp := range c.podMatchBaselineAdminNetworkPolicies(p) {
+			c.updateBanpQueue.Add(banp)
 		}
 	}
 
@@ -193,6 +202,8 @@ func (c *Controller) enqueueAddPod(obj interface{}) {
 		return
 	}
 
+	c.enqueueVpcEgressGatewaysForPod(p)
+
 	if !isPodAlive(p) {
 		isStateful, statefulSetName := isStatefulSetPod(p)
 		isVMPod, vmName := isVMPod(p)
@@ -235,17 +246,29 @@ func (c *Controller) enqueueDeletePod(obj interface{}) {
 	}
 
 	p := obj.(*v1.Pod)
-	if c.config.EnableNP {
+	if c.config.EnableNP || c.config.EnableANP {
 		c.namedPort.DeleteNamedPortByPod(p)
+	}
+	if c.config.EnableNP {
 		for _, np := range c.podMatchNetworkPolicies(p) {
 			c.updateNpQueue.Add(np)
 		}
 	}
+	if c.config.EnableANP {
+		for _, anp := range c.podMatchAdminNetworkPolicies(p) {
+			c.updateAnpQueue.Add(anp)
+		}
+		for _, banp := range c.podMatchBaselineAdminNetworkPolicies(p) {
+			c.updateBanpQueue.Add(banp)
+		}
+	}
 
 	if p.Spec.HostNetwork {
 		return
 	}
 
+	c.enqueueVpcEgressGatewaysForPod(p)
+
 	klog.Infof("enqueue delete pod %s", key)
 	c.deletingPodObjMap.Store(key, p)
 	c.deletePodQueue.Add(key)
@@ -295,8 +318,10 @@ func (c *Controller) enqueueUpdatePod(oldObj, newObj interface{}) {
 		return
 	}
 
-	if c.config.EnableNP {
+	if c.config.EnableNP || c.config.EnableANP {
 		c.namedPort.AddNamedPortByPod(newPod)
+	}
+	if c.config.EnableNP {
 		newNp := c.podMatchNetworkPolicies(newPod)
 		if !reflect.DeepEqual(oldPod.Labels, newPod.Labels) {
 			oldNp := c.podMatchNetworkPolicies(oldPod)
@@ -318,10 +343,43 @@ func (c *Controller) enqueueUpdatePod(oldObj, newObj interface{}) {
 		}
 	}
 
+	if c.config.EnableANP {
+		newAnps := c.podMatchAdminNetworkPolicies(newPod)
+		newBanps := c.podMatchBaselineAdminNetworkPolicies(newPod)
+		if !reflect.DeepEqual(oldPod.Labels, newPod.Labels) {
+			for _, anp := range util.DiffStringSlice(c.podMatchAdminNetworkPolicies(oldPod), newAnps) {
+				c.updateAnpQueue.Add(anp)
+			}
+			for _, banp := range util.DiffStringSlice(c.podMatchBaselineAdminNetworkPolicies(oldPod), newBanps) {
+				c.updateBanpQueue.Add(banp)
+			}
+		}
+
+		for _, podNet := range podNets {
+			oldAllocated := oldPod.Annotations[fmt.Sprintf(util.AllocatedAnnotationTemplate, podNet.ProviderName)]
+			newAllocated := newPod.Annotations[fmt.Sprintf(util.AllocatedAnnotationTemplate, podNet.ProviderName)]
+			if oldAllocated != newAllocated {
+				for _, anp := range newAnps {
+					klog.V(3).Infof("enqueue update admin network policy %s for pod %s", anp, key)
+					c.updateAnpQueue.Add(anp)
+				}
+				for _, banp := range newBanps {
+					klog.V(3).Infof("enqueue update baseline admin network policy %s for pod %s", banp, key)
+					c.updateBanpQueue.Add(banp)
+				}
+				break
+			}
+		}
+	}
+
 	if newPod.Spec.HostNetwork {
 		return
 	}
 
+	if isVpcEgressGatewayPodChanged(oldPod, newPod) {
+		c.enqueueVpcEgressGatewaysForPod(oldPod, newPod)
+	}
+
 	isStateful, statefulSetName := isStatefulSetPod(newPod)
 	isVMPod, vmName := isVMPod(newPod)
 	if !isPodStatusPhaseAlive(newPod) && !isStateful && !isVMPod {
@@ -651,7 +709,11 @@ func (c *Controller) reconcileAllocateSubnets(cachedPod, pod *v1.Pod, needAlloca
 		// the subnet may changed when alloc static ip from the latter subnet after ns supports multi subnets
 		v4IP, v6IP, mac, subnet, err := c.acquireAddress(pod, podNet)
 		if err != nil {
-			c.recorder.Eventf(pod, v1.EventTypeWarning, "AcquireAddressFailed", err.Error())
+			reason := "AcquireAddressFailed"
+			if errors.Is(err, ipam.ErrQuotaExceeded) {
+				reason = "IPQuotaExceeded"
+			}
+			c.recorder.Eventf(pod, v1.EventTypeWarning, reason, err.Error())
 			klog.Error(err)
 			return nil, err
 		}
@@ -662,8 +724,9 @@ func (c *Controller) reconcileAllocateSubnets(cachedPod, pod *v1.Pod, needAlloca
 		} else {
 			pod.Annotations[fmt.Sprintf(util.MacAddressAnnotationTemplate, podNet.ProviderName)] = mac
 		}
-		pod.Annotations[fmt.Sprintf(util.CidrAnnotationTemplate, podNet.ProviderName)] = subnet.Spec.CIDRBlock
-		pod.Annotations[fmt.Sprintf(util.GatewayAnnotationTemplate, podNet.ProviderName)] = subnet.Spec.Gateway
+		cidr, gw := util.GetSubnetCIDRAndGateway(subnet, ipStr)
+		pod.Annotations[fmt.Sprintf(util.CidrAnnotationTemplate, podNet.ProviderName)] = cidr
+		pod.Annotations[fmt.Sprintf(util.GatewayAnnotationTemplate, podNet.ProviderName)] = gw
 		if isOvnSubnet(podNet.Subnet) {
 			pod.Annotations[fmt.Sprintf(util.LogicalSwitchAnnotationTemplate, podNet.ProviderName)] = subnet.Name
 			if pod.Annotations[fmt.Sprintf(util.PodNicAnnotationTemplate, podNet.ProviderName)] == "" {
@@ -682,7 +745,7 @@ func (c *Controller) reconcileAllocateSubnets(cachedPod, pod *v1.Pod, needAlloca
 			}
 		}
 
-		if err := util.ValidatePodCidr(podNet.Subnet.Spec.CIDRBlock, ipStr); err != nil {
+		if err := util.ValidatePodCidr(cidr, ipStr); err != nil {
 			klog.Errorf("validate pod %s/%s failed: %v", namespace, name, err)
 			c.recorder.Eventf(pod, v1.EventTypeWarning, "ValidatePodNetworkFailed", err.Error())
 			return nil, err
@@ -1211,9 +1274,7 @@ func (c *Controller) syncKubeOvnNet(cachedPod, pod *v1.Pod, podNets []*kubeovnNe
 
 	for _, portNeedDel := range portsNeedToDel {
 
-		if subnet, ok := c.ipam.Subnets[subnetUsedByPort[portNeedDel]]; ok {
-			subnet.ReleaseAddressWithNicName(podName, portNeedDel)
-		}
+		c.ipam.ReleaseAddressByNic(podName, portNeedDel, subnetUsedByPort[portNeedDel])
 
 		if err := c.OVNNbClient.DeleteLogicalSwitchPort(portNeedDel); err != nil {
 			klog.Errorf("failed to delete lsp %s, %v", portNeedDel, err)
@@ -1782,6 +1843,9 @@ func (c *Controller) acquireAddress(pod *v1.Pod, podNet *kubeovnNet) (string, st
 			}
 		}
 	}
+	if errors.Is(err, ipam.ErrQuotaExceeded) {
+		return "", "", "", podNet.Subnet, err
+	}
 	klog.Errorf("alloc address for %s failed, return NoAvailableAddress", key)
 	return "", "", "", podNet.Subnet, ipam.ErrNoAvailable
 }
@@ -1858,7 +1922,7 @@ func appendCheckPodToDel(c *Controller, pod *v1.Pod, ownerRefName, ownerRefKind
 		klog.Errorf("failed to get subnet %s, %v", pod.Annotations[util.LogicalSwitchAnnotation], err)
 		return false, err
 	}
-	if podSubnet != nil && !util.CIDRContainIP(podSubnet.Spec.CIDRBlock, pod.Annotations[util.IPAddressAnnotation]) {
+	if podSubnet != nil && !util.SubnetContainIP(podSubnet, pod.Annotations[util.IPAddressAnnotation]) {
 		klog.Infof("pod's ip %s is not in the range of subnet %s, delete pod", pod.Annotations[util.IPAddressAnnotation], podSubnet.Name)
 		return true, nil
 	}
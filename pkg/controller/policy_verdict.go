@@ -0,0 +1,985 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	anpv1alpha1 "sigs.k8s.io/network-policy-api/apis/v1alpha1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovs"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// policyVerdictController is the controller running on the leader, whose caches are used to serve the policy verdict api
+var policyVerdictController atomic.Pointer[Controller]
+
+const (
+	// PolicyVerdictPath is the path of the policy verdict api
+	PolicyVerdictPath = "/api/v1/policy-verdict"
+
+	PolicyVerdictAllow = "allow"
+	PolicyVerdictDeny  = "deny"
+
+	policyKindSubnet                     = "Subnet"
+	policyKindSecurityGroup              = "SecurityGroup"
+	policyKindNetworkPolicy              = "NetworkPolicy"
+	policyKindAdminNetworkPolicy         = "AdminNetworkPolicy"
+	policyKindBaselineAdminNetworkPolicy = "BaselineAdminNetworkPolicy"
+
+	// policyActionPass is the action of the Pass rules of admin network policies
+	policyActionPass = "pass"
+)
+
+// PolicyVerdictRule is an acl generated from a subnet, security group, network policy,
+// admin network policy or baseline admin network policy
+type PolicyVerdictRule struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Rule        string `json:"rule,omitempty"`
+	Description string `json:"description,omitempty"`
+	Priority    int    `json:"priority"`
+	Action      string `json:"action"`
+	// Error is the reason why the rule can not be simulated
+	Error string `json:"error,omitempty"`
+}
+
+// PolicyVerdictStage is the acl stage of a logical switch traversed by the packet
+type PolicyVerdictStage struct {
+	LogicalSwitch string `json:"logicalSwitch"`
+	Direction     string `json:"direction"`
+	Port          string `json:"port"`
+	// Rules are the matched rules sorted by priority, the first one decides the verdict of the stage
+	// unless it's a Pass rule of an admin network policy, which skips the admin network policy rules
+	// evaluated later
+	Rules []PolicyVerdictRule `json:"rules"`
+	// Skipped are the rules which can not be simulated
+	Skipped []PolicyVerdictRule `json:"skipped,omitempty"`
+	Verdict string              `json:"verdict"`
+}
+
+// PolicyVerdict is the result of simulating a packet against the acls
+type PolicyVerdict struct {
+	Src      string               `json:"src"`
+	Dst      string               `json:"dst"`
+	Protocol string               `json:"protocol"`
+	Port     int                  `json:"port,omitempty"`
+	Stages   []PolicyVerdictStage `json:"stages"`
+	Verdict  string               `json:"verdict"`
+}
+
+// PolicyVerdictHandler serves the policy verdict api which simulates a packet against the acls of
+// subnets, security groups, network policies and admin network policies without touching the data plane, e.g.
+// GET /api/v1/policy-verdict?src=default/client&dst=10.16.0.10&protocol=tcp&port=80
+// the requests must carry a bearer token of a user allowed to get the non-resource url of the api
+func PolicyVerdictHandler(w http.ResponseWriter, r *http.Request) {
+	c := policyVerdictController.Load()
+	if c == nil {
+		http.Error(w, "kube-ovn-controller is not the leader", http.StatusServiceUnavailable)
+		return
+	}
+	if status, err := c.authorizePolicyVerdictRequest(r); err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	query := r.URL.Query()
+	protocol := strings.ToLower(query.Get("protocol"))
+	var port int
+	switch protocol {
+	case "tcp", "udp":
+		var err error
+		if port, err = strconv.Atoi(query.Get("port")); err != nil || port < 1 || port > 65535 {
+			http.Error(w, fmt.Sprintf("invalid %s port %q", protocol, query.Get("port")), http.StatusBadRequest)
+			return
+		}
+	case "icmp":
+	default:
+		http.Error(w, fmt.Sprintf("invalid protocol %q, it must be one of tcp, udp and icmp", protocol), http.StatusBadRequest)
+		return
+	}
+
+	verdict, err := newPolicyVerdictSimulator(c).simulate(query.Get("src"), query.Get("dst"), protocol, port)
+	if err != nil {
+		klog.Errorf("failed to simulate policy verdict: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(verdict); err != nil {
+		klog.Errorf("failed to write policy verdict: %v", err)
+	}
+}
+
+// authorizePolicyVerdictRequest authenticates the bearer token of the request with a token review and checks
+// whether the user is allowed to access the api with a subject access review, the status code is returned on failure
+func (c *Controller) authorizePolicyVerdictRequest(r *http.Request) (int, error) {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		return http.StatusUnauthorized, errors.New("bearer token is required")
+	}
+
+	tr := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	tr, err := c.config.KubeClient.AuthenticationV1().TokenReviews().Create(r.Context(), tr, metav1.CreateOptions{})
+	if err != nil {
+		klog.Errorf("failed to review token: %v", err)
+		return http.StatusInternalServerError, err
+	}
+	if !tr.Status.Authenticated {
+		return http.StatusUnauthorized, fmt.Errorf("failed to authenticate the bearer token: %s", tr.Status.Error)
+	}
+
+	user := tr.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	sar := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+		User:   user.Username,
+		UID:    user.UID,
+		Groups: user.Groups,
+		Extra:  extra,
+		NonResourceAttributes: &authorizationv1.NonResourceAttributes{
+			Path: r.URL.Path,
+			Verb: strings.ToLower(r.Method),
+		},
+	}}
+	if sar, err = c.config.KubeClient.AuthorizationV1().SubjectAccessReviews().Create(r.Context(), sar, metav1.CreateOptions{}); err != nil {
+		klog.Errorf("failed to review access of user %s: %v", user.Username, err)
+		return http.StatusInternalServerError, err
+	}
+	if !sar.Status.Allowed {
+		return http.StatusForbidden, fmt.Errorf("user %q is not allowed to %s %s", user.Username, strings.ToLower(r.Method), r.URL.Path)
+	}
+	return http.StatusOK, nil
+}
+
+// simACL is a rule with the function which tells whether the packet matches it
+type simACL struct {
+	PolicyVerdictRule
+	match func(pkt *simPacket) (bool, error)
+}
+
+type simEndpoint struct {
+	ips    []net.IP
+	pod    *corev1.Pod
+	port   string
+	subnet *kubeovnv1.Subnet
+}
+
+// simStage is an acl stage of a logical switch, pod is set if the port of the stage is a pod
+type simStage struct {
+	subnet    *kubeovnv1.Subnet
+	direction string
+	port      string
+	pod       *corev1.Pod
+}
+
+type policyVerdictSimulator struct {
+	c    *Controller
+	pods []*corev1.Pod
+}
+
+func newPolicyVerdictSimulator(c *Controller) *policyVerdictSimulator {
+	return &policyVerdictSimulator{c: c}
+}
+
+func (s *policyVerdictSimulator) listPods() ([]*corev1.Pod, error) {
+	if s.pods == nil {
+		pods, err := s.c.podsLister.List(labels.Everything())
+		if err != nil {
+			klog.Errorf("failed to list pods: %v", err)
+			return nil, err
+		}
+		s.pods = pods
+	}
+	return s.pods, nil
+}
+
+func podIPs(pod *corev1.Pod) []net.IP {
+	var ips []net.IP
+	for _, s := range strings.Split(pod.Annotations[util.IPAddressAnnotation], ",") {
+		if ip := net.ParseIP(strings.TrimSpace(s)); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+// ipInAddresses returns whether the ip is one of the addresses, which are ips or cidrs
+func ipInAddresses(ip net.IP, addresses ...string) bool {
+	for _, address := range addresses {
+		address = strings.TrimSpace(address)
+		if strings.Contains(address, "/") {
+			if _, cidr, err := net.ParseCIDR(address); err == nil && cidr.Contains(ip) {
+				return true
+			}
+		} else if addr := net.ParseIP(address); addr != nil && addr.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveEndpoint resolves "<namespace>/<pod>" or an ip address to the endpoint
+func (s *policyVerdictSimulator) resolveEndpoint(name string) (*simEndpoint, error) {
+	endpoint := &simEndpoint{}
+	if ip := net.ParseIP(name); ip != nil {
+		endpoint.ips = []net.IP{ip}
+		pods, err := s.listPods()
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range pods {
+			if !pod.Spec.HostNetwork && slices.ContainsFunc(podIPs(pod), ip.Equal) {
+				endpoint.pod = pod
+				break
+			}
+		}
+	} else {
+		namespace, podName, found := strings.Cut(name, "/")
+		if !found {
+			return nil, fmt.Errorf("invalid endpoint %q, it must be an ip address or <namespace>/<pod>", name)
+		}
+		pod, err := s.c.podsLister.Pods(namespace).Get(podName)
+		if err != nil {
+			klog.Errorf("failed to get pod %s: %v", name, err)
+			return nil, err
+		}
+		if pod.Spec.HostNetwork {
+			return nil, fmt.Errorf("pod %s uses the host network", name)
+		}
+		if endpoint.ips = podIPs(pod); len(endpoint.ips) == 0 {
+			return nil, fmt.Errorf("pod %s has no address allocated", name)
+		}
+		endpoint.pod = pod
+	}
+
+	subnetName := ""
+	if endpoint.pod != nil {
+		endpoint.port = ovs.PodNameToPortName(s.c.getNameByPod(endpoint.pod), endpoint.pod.Namespace, util.OvnProvider)
+		subnetName = endpoint.pod.Annotations[util.LogicalSwitchAnnotation]
+	}
+	if subnetName != "" {
+		subnet, err := s.c.subnetsLister.Get(subnetName)
+		if err != nil {
+			klog.Errorf("failed to get subnet %s: %v", subnetName, err)
+			return nil, err
+		}
+		endpoint.subnet = subnet
+		return endpoint, nil
+	}
+
+	// the subnet of an address which is not allocated to a pod
+	subnets, err := s.c.subnetsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list subnets: %v", err)
+		return nil, err
+	}
+	sort.Slice(subnets, func(i, j int) bool { return subnets[i].Name < subnets[j].Name })
+	for _, subnet := range subnets {
+		if ipInAddresses(endpoint.ips[0], strings.Split(util.GetSubnetCIDRBlocks(subnet), ",")...) {
+			endpoint.subnet = subnet
+			break
+		}
+	}
+	return endpoint, nil
+}
+
+// routerPort returns the logical switch port connecting the subnet to its router
+func (s *policyVerdictSimulator) routerPort(subnet *kubeovnv1.Subnet) string {
+	router := subnet.Spec.Vpc
+	if vpc, err := s.c.vpcsLister.Get(subnet.Spec.Vpc); err == nil && vpc.Status.Router != "" {
+		router = vpc.Status.Router
+	}
+	return ovs.LogicalSwitchPortName(router, subnet.Name)
+}
+
+func (s *policyVerdictSimulator) simulate(srcName, dstName, protocol string, port int) (*PolicyVerdict, error) {
+	src, err := s.resolveEndpoint(srcName)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := s.resolveEndpoint(dstName)
+	if err != nil {
+		return nil, err
+	}
+	if src.subnet == nil && dst.subnet == nil {
+		return nil, fmt.Errorf("neither %s nor %s is in a subnet", srcName, dstName)
+	}
+
+	// use the addresses of the same family, ipv4 is preferred
+	pkt := &simPacket{protocol: protocol, port: port}
+	for _, v4 := range []bool{true, false} {
+		i := slices.IndexFunc(src.ips, func(ip net.IP) bool { return (ip.To4() != nil) == v4 })
+		j := slices.IndexFunc(dst.ips, func(ip net.IP) bool { return (ip.To4() != nil) == v4 })
+		if i >= 0 && j >= 0 {
+			pkt.src, pkt.dst = src.ips[i], dst.ips[j]
+			break
+		}
+	}
+	if pkt.src == nil {
+		return nil, fmt.Errorf("%s and %s have no address of the same family", srcName, dstName)
+	}
+	if protocol == "icmp" {
+		// icmp echo request
+		pkt.icmpType = 8
+		if !pkt.ipv4() {
+			pkt.icmpType = 128
+		}
+	}
+
+	// the packet traverses the ingress (from-lport) and egress (to-lport) acl stages of each logical switch
+	var stages []simStage
+	if src.subnet != nil {
+		stages = append(stages, simStage{subnet: src.subnet, direction: ovnnb.ACLDirectionFromLport, port: src.port, pod: src.pod})
+		if dst.subnet != nil && dst.subnet.Name == src.subnet.Name {
+			stages = append(stages, simStage{subnet: src.subnet, direction: ovnnb.ACLDirectionToLport, port: dst.port, pod: dst.pod})
+		} else {
+			stages = append(stages, simStage{subnet: src.subnet, direction: ovnnb.ACLDirectionToLport, port: s.routerPort(src.subnet)})
+		}
+	}
+	if dst.subnet != nil && (src.subnet == nil || dst.subnet.Name != src.subnet.Name) {
+		stages = append(stages,
+			simStage{subnet: dst.subnet, direction: ovnnb.ACLDirectionFromLport, port: s.routerPort(dst.subnet)},
+			simStage{subnet: dst.subnet, direction: ovnnb.ACLDirectionToLport, port: dst.port, pod: dst.pod},
+		)
+	}
+
+	verdict := &PolicyVerdict{
+		Src:      pkt.src.String(),
+		Dst:      pkt.dst.String(),
+		Protocol: protocol,
+		Port:     port,
+		Verdict:  PolicyVerdictAllow,
+	}
+	for _, stage := range stages {
+		result, err := s.simulateStage(stage, pkt)
+		if err != nil {
+			return nil, err
+		}
+		if result.Verdict == PolicyVerdictDeny {
+			verdict.Verdict = PolicyVerdictDeny
+		}
+		verdict.Stages = append(verdict.Stages, *result)
+		if verdict.Verdict == PolicyVerdictDeny {
+			// the packet is dropped and the following stages are not traversed
+			break
+		}
+	}
+	return verdict, nil
+}
+
+func (s *policyVerdictSimulator) simulateStage(stage simStage, pkt *simPacket) (*PolicyVerdictStage, error) {
+	pkt.inport, pkt.outport = "", ""
+	if stage.direction == ovnnb.ACLDirectionFromLport {
+		pkt.inport = stage.port
+	} else {
+		pkt.outport = stage.port
+	}
+
+	acls := s.subnetACLs(stage.subnet, stage.direction)
+	if stage.pod != nil {
+		sgACLs, err := s.sgACLs(stage.pod, stage.direction)
+		if err != nil {
+			return nil, err
+		}
+		npACLs, err := s.npACLs(stage.pod, stage.port, stage.direction, pkt)
+		if err != nil {
+			return nil, err
+		}
+		adminPolicyACLs, err := s.adminPolicyACLs(stage.pod, stage.direction, pkt)
+		if err != nil {
+			return nil, err
+		}
+		acls = append(append(append(acls, sgACLs...), npACLs...), adminPolicyACLs...)
+	}
+
+	result := &PolicyVerdictStage{
+		LogicalSwitch: stage.subnet.Name,
+		Direction:     stage.direction,
+		Port:          stage.port,
+		Rules:         []PolicyVerdictRule{},
+		Verdict:       PolicyVerdictAllow,
+	}
+	for _, acl := range acls {
+		matched, err := acl.match(pkt)
+		if err != nil {
+			rule := acl.PolicyVerdictRule
+			rule.Error = err.Error()
+			result.Skipped = append(result.Skipped, rule)
+			continue
+		}
+		if matched {
+			result.Rules = append(result.Rules, acl.PolicyVerdictRule)
+		}
+	}
+	sort.SliceStable(result.Rules, func(i, j int) bool { return result.Rules[i].Priority > result.Rules[j].Priority })
+	if rule := decidingRule(result.Rules); rule != nil {
+		switch rule.Action {
+		case ovnnb.ACLActionDrop, ovnnb.ACLActionReject:
+			result.Verdict = PolicyVerdictDeny
+		}
+	}
+	return result, nil
+}
+
+// decidingRule returns the rule deciding the verdict of the rules sorted by priority, the acls of admin network
+// policies are above all the others, a Pass rule skips the admin network policy rules evaluated later so that
+// the verdict is decided by network policies, security groups or baseline admin network policies
+func decidingRule(rules []PolicyVerdictRule) *PolicyVerdictRule {
+	passed := false
+	for i, rule := range rules {
+		if rule.Kind == policyKindAdminNetworkPolicy && (passed || rule.Action == policyActionPass) {
+			passed = true
+			continue
+		}
+		return &rules[i]
+	}
+	return nil
+}
+
+func priority(p string) int {
+	i, _ := strconv.Atoi(p)
+	return i
+}
+
+func matchAll(_ *simPacket) (bool, error) {
+	return true, nil
+}
+
+// subnetACLs returns the acls of the logical switch created for private subnet and subnet acls
+func (s *policyVerdictSimulator) subnetACLs(subnet *kubeovnv1.Subnet, direction string) []simACL {
+	var acls []simACL
+	newACL := func(rule, p, action string, match func(pkt *simPacket) (bool, error)) {
+		acls = append(acls, simACL{
+			PolicyVerdictRule: PolicyVerdictRule{Kind: policyKindSubnet, Name: subnet.Name, Rule: rule, Priority: priority(p), Action: action},
+			match:             match,
+		})
+	}
+
+	if subnet.Spec.Private && direction == ovnnb.ACLDirectionToLport {
+		newACL("private", util.DefaultDropPriority, ovnnb.ACLActionDrop, matchAll)
+		// the cidr blocks of the same protocol are matched together like the acls of the logical switch
+		for _, protocol := range subnetCIDRProtocols(subnet) {
+			cidrs := subnetCIDRBlocksOf(subnet, protocol)
+			newACL("same subnet "+strings.Join(cidrs, ","), util.SubnetAllowPriority, ovnnb.ACLActionAllowRelated, func(pkt *simPacket) (bool, error) {
+				return ipInAddresses(pkt.src, cidrs...) && ipInAddresses(pkt.dst, cidrs...), nil
+			})
+			for _, nodeCidr := range strings.Split(s.c.config.NodeSwitchCIDR, ",") {
+				nodeCidr := nodeCidr
+				newACL("node subnet "+nodeCidr, util.NodeAllowPriority, ovnnb.ACLActionAllowRelated, func(pkt *simPacket) (bool, error) {
+					return ipInAddresses(pkt.src, nodeCidr), nil
+				})
+			}
+			for _, allowSubnet := range subnet.Spec.AllowSubnets {
+				allowSubnet := strings.TrimSpace(allowSubnet)
+				if allowSubnet == "" {
+					continue
+				}
+				newACL("allow subnet "+allowSubnet, util.SubnetAllowPriority, ovnnb.ACLActionAllowRelated, func(pkt *simPacket) (bool, error) {
+					return (ipInAddresses(pkt.src, cidrs...) && ipInAddresses(pkt.dst, allowSubnet)) ||
+						(ipInAddresses(pkt.src, allowSubnet) && ipInAddresses(pkt.dst, cidrs...)), nil
+				})
+			}
+		}
+	}
+
+	if len(subnet.Spec.Acls) == 0 {
+		return acls
+	}
+	if subnet.Spec.AllowEWTraffic && direction == ovnnb.ACLDirectionToLport {
+		for _, protocol := range subnetCIDRProtocols(subnet) {
+			cidrs := subnetCIDRBlocksOf(subnet, protocol)
+			newACL("east-west traffic "+strings.Join(cidrs, ","), util.AllowEWTrafficPriority, ovnnb.ACLActionAllowRelated, func(pkt *simPacket) (bool, error) {
+				return ipInAddresses(pkt.src, cidrs...) && ipInAddresses(pkt.dst, cidrs...), nil
+			})
+		}
+	}
+	for i, acl := range subnet.Spec.Acls {
+		if acl.Direction != direction {
+			continue
+		}
+		match := acl.Match
+		newACL(fmt.Sprintf("acls[%d]: %s", i, match), strconv.Itoa(acl.Priority), acl.Action, func(pkt *simPacket) (bool, error) {
+			return evalACLMatch(match, pkt)
+		})
+	}
+	return acls
+}
+
+// subnetCIDRBlocksOf returns the primary and secondary cidr blocks of the subnet with the protocol
+func subnetCIDRBlocksOf(subnet *kubeovnv1.Subnet, protocol string) []string {
+	var cidrs []string
+	for _, cidr := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
+		if util.CheckProtocol(cidr) == protocol {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return cidrs
+}
+
+// sgAddresses returns the addresses of the pods in the security group
+func (s *policyVerdictSimulator) sgAddresses(sgName string) ([]string, error) {
+	pods, err := s.listPods()
+	if err != nil {
+		return nil, err
+	}
+	var addresses []string
+	for _, pod := range pods {
+		sgs := strings.Split(pod.Annotations[fmt.Sprintf(util.SecurityGroupAnnotationTemplate, util.OvnProvider)], ",")
+		if slices.Contains(sgs, sgName) {
+			for _, ip := range podIPs(pod) {
+				addresses = append(addresses, ip.String())
+			}
+		}
+	}
+	return addresses, nil
+}
+
+// fqdnAddresses returns the addresses of the fqdn learned by the daemons
+func (s *policyVerdictSimulator) fqdnAddresses(fqdn string) ([]string, error) {
+	caches, err := s.c.fqdnCachesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list fqdn caches: %v", err)
+		return nil, err
+	}
+	v4s, v6s, _ := fqdnAddresses(caches, fqdn, time.Now())
+	return append(v4s.List(), v6s.List()...), nil
+}
+
+// remoteIP returns the address of the peer of the pod
+func remoteIP(pkt *simPacket, direction string) net.IP {
+	if direction == ovnnb.ACLDirectionFromLport {
+		return pkt.dst
+	}
+	return pkt.src
+}
+
+// sgACLs returns the acls of the security groups of the pod, the base acls for dhcp, nd and vrrp are not simulated
+func (s *policyVerdictSimulator) sgACLs(pod *corev1.Pod, direction string) ([]simACL, error) {
+	sgNames := pod.Annotations[fmt.Sprintf(util.SecurityGroupAnnotationTemplate, util.OvnProvider)]
+	if sgNames == "" {
+		return nil, nil
+	}
+
+	var acls []simACL
+	var enforced bool
+	for _, sgName := range strings.Split(sgNames, ",") {
+		sg, err := s.c.sgsLister.Get(sgName)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			klog.Errorf("failed to get security group %s: %v", sgName, err)
+			return nil, err
+		}
+
+		// the acls of security groups in audit mode are placed below the drop acls
+		highestPriority, allowPriority := priority(util.SecurityGroupHighestPriority), priority(util.SecurityGroupAllowPriority)
+		if sg.Spec.Audit {
+			highestPriority, allowPriority = priority(util.SecurityGroupAuditHighestPriority), priority(util.SecurityGroupAuditAllowPriority)
+		} else {
+			enforced = true
+		}
+
+		var sameGroupAddresses []string
+		if sg.Spec.AllowSameGroupTraffic {
+			if sameGroupAddresses, err = s.sgAddresses(sg.Name); err != nil {
+				return nil, err
+			}
+			acls = append(acls, simACL{
+				PolicyVerdictRule: PolicyVerdictRule{Kind: policyKindSecurityGroup, Name: sg.Name, Rule: "same group", Priority: allowPriority, Action: ovnnb.ACLActionAllowRelated},
+				match: func(pkt *simPacket) (bool, error) {
+					return ipInAddresses(remoteIP(pkt, direction), sameGroupAddresses...), nil
+				},
+			})
+		}
+
+		rules := sg.Spec.IngressRules
+		if direction == ovnnb.ACLDirectionFromLport {
+			rules = sg.Spec.EgressRules
+		}
+		for i, rule := range rules {
+			rule := rule
+			remoteAddresses := []string{rule.RemoteAddress}
+			switch rule.RemoteType {
+			case kubeovnv1.SgRemoteTypeSg:
+				if remoteAddresses, err = s.sgAddresses(rule.RemoteSecurityGroup); err != nil {
+					return nil, err
+				}
+			case kubeovnv1.SgRemoteTypeFqdn:
+				if remoteAddresses, err = s.fqdnAddresses(rule.RemoteAddress); err != nil {
+					return nil, err
+				}
+			case kubeovnv1.SgRemoteTypeAddressGroup:
+				remoteAddresses = nil
+				ag, err := s.c.addressGroupsLister.Get(rule.RemoteAddressGroup)
+				if err != nil && !k8serrors.IsNotFound(err) {
+					klog.Errorf("failed to get address group %s: %v", rule.RemoteAddressGroup, err)
+					return nil, err
+				}
+				if ag != nil {
+					remoteAddresses = ag.Spec.Addresses
+				}
+			}
+
+			action, name := ovnnb.ACLActionDrop, fmt.Sprintf("rules[%d]", i)
+			if rule.Policy == kubeovnv1.PolicyAllow {
+				action = ovnnb.ACLActionAllowRelated
+				if rule.Stateless {
+					action = ovnnb.ACLActionAllowStateless
+				}
+			} else if sg.Spec.Audit {
+				action, name = ovnnb.ACLActionAllowRelated, name+" audit"
+			}
+			acls = append(acls, simACL{
+				PolicyVerdictRule: PolicyVerdictRule{Kind: policyKindSecurityGroup, Name: sg.Name, Rule: name, Description: rule.Description, Priority: highestPriority - rule.Priority, Action: action},
+				match: func(pkt *simPacket) (bool, error) {
+					if (rule.IPVersion == "ipv6") == pkt.ipv4() || !ipInAddresses(remoteIP(pkt, direction), remoteAddresses...) {
+						return false, nil
+					}
+					switch rule.Protocol {
+					case kubeovnv1.ProtocolICMP:
+						return pkt.protocol == "icmp" && (rule.IcmpType == nil || *rule.IcmpType == pkt.icmpType) &&
+							(rule.IcmpCode == nil || *rule.IcmpCode == pkt.icmpCode), nil
+					case kubeovnv1.ProtocolTCP, kubeovnv1.ProtocolUDP:
+						if pkt.protocol != string(rule.Protocol) {
+							return false, nil
+						}
+						return slices.ContainsFunc(util.GetSgRulePortRanges(rule), func(r kubeovnv1.SgPortRange) bool {
+							return pkt.port >= r.PortRangeMin && pkt.port <= r.PortRangeMax
+						}), nil
+					}
+					return true, nil
+				},
+			})
+		}
+
+		if sg.Spec.Audit {
+			acls = append(acls, simACL{
+				PolicyVerdictRule: PolicyVerdictRule{Kind: policyKindSecurityGroup, Name: sg.Name, Rule: "audit", Priority: allowPriority, Action: ovnnb.ACLActionAllowRelated},
+				match: func(pkt *simPacket) (bool, error) {
+					return !ipInAddresses(remoteIP(pkt, direction), sameGroupAddresses...), nil
+				},
+			})
+		}
+	}
+
+	// the ports are added to the deny all port group unless all the security groups are in audit mode
+	if enforced {
+		acls = append(acls, simACL{
+			PolicyVerdictRule: PolicyVerdictRule{Kind: policyKindSecurityGroup, Name: util.DenyAllSecurityGroup, Rule: "deny all", Priority: priority(util.SecurityGroupDropPriority), Action: ovnnb.ACLActionDrop},
+			match:             matchAll,
+		})
+	}
+	return acls, nil
+}
+
+// npPortsMatch returns whether the packet matches the ports of a network policy rule
+func npPortsMatch(npp []netv1.NetworkPolicyPort, namedPortMap map[string]*util.NamedPortInfo, pkt *simPacket) bool {
+	if len(npp) == 0 {
+		return true
+	}
+	for _, port := range npp {
+		protocol := "tcp"
+		if port.Protocol != nil {
+			protocol = strings.ToLower(string(*port.Protocol))
+		}
+		if protocol != pkt.protocol {
+			continue
+		}
+		if port.Port == nil {
+			return true
+		}
+		if port.EndPort == nil {
+			var portID int32
+			if port.Port.Type == intstr.Int {
+				portID = port.Port.IntVal
+			} else if info, ok := namedPortMap[port.Port.StrVal]; ok {
+				portID = info.PortID
+			}
+			if int32(pkt.port) == portID {
+				return true
+			}
+			continue
+		}
+		if int32(pkt.port) >= port.Port.IntVal && int32(pkt.port) <= *port.EndPort {
+			return true
+		}
+	}
+	return false
+}
+
+// npACLs returns the acls of the network policies selecting the pod
+func (s *policyVerdictSimulator) npACLs(pod *corev1.Pod, port, direction string, pkt *simPacket) ([]simACL, error) {
+	if !s.c.config.EnableNP {
+		return nil, nil
+	}
+
+	nps, err := s.c.npsLister.NetworkPolicies(pod.Namespace).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list network policies: %v", err)
+		return nil, err
+	}
+
+	protocol := kubeovnv1.ProtocolIPv4
+	allAddresses := "0.0.0.0/0"
+	if !pkt.ipv4() {
+		protocol, allAddresses = kubeovnv1.ProtocolIPv6, "::/0"
+	}
+	namedPortMap := s.c.namedPort.GetNamedPortByNs(pod.Namespace)
+
+	var acls []simACL
+	for _, np := range nps {
+		ingress := direction == ovnnb.ACLDirectionToLport
+		if (ingress && !hasIngressRule(np)) || (!ingress && !hasEgressRule(np)) {
+			continue
+		}
+		ports, _, err := s.c.fetchSelectedPorts(np.Namespace, &np.Spec.PodSelector)
+		if err != nil {
+			klog.Errorf("failed to fetch ports selected by np %s/%s: %v", np.Namespace, np.Name, err)
+			return nil, err
+		}
+		if !slices.Contains(ports, port) {
+			continue
+		}
+
+		name := np.Namespace + "/" + np.Name
+		dropRule, dropPriority, dropAction := "drop", util.IngressDefaultDrop, ovnnb.ACLActionDrop
+		if !ingress {
+			dropPriority = util.EgressDefaultDrop
+		}
+		if np.Annotations[util.NetworkPolicyAuditAnnotation] == "true" {
+			dropRule, dropPriority, dropAction = "audit", util.IngressAuditPriority, ovnnb.ACLActionAllowRelated
+			if !ingress {
+				dropPriority = util.EgressAuditPriority
+			}
+		}
+		acls = append(acls, simACL{
+			PolicyVerdictRule: PolicyVerdictRule{Kind: policyKindNetworkPolicy, Name: name, Rule: dropRule, Priority: priority(dropPriority), Action: dropAction},
+			match:             matchAll,
+		})
+
+		type npRule struct {
+			peers []netv1.NetworkPolicyPeer
+			ports []netv1.NetworkPolicyPort
+		}
+		var rules []npRule
+		allowPriority := util.IngressAllowPriority
+		if ingress {
+			for _, r := range np.Spec.Ingress {
+				rules = append(rules, npRule{r.From, r.Ports})
+			}
+		} else {
+			allowPriority = util.EgressAllowPriority
+			for _, r := range np.Spec.Egress {
+				rules = append(rules, npRule{r.To, r.Ports})
+			}
+		}
+
+		for idx, rule := range rules {
+			rule := rule
+			var allows, excepts []string
+			if len(rule.peers) == 0 {
+				allows = []string{allAddresses}
+			} else {
+				for _, peer := range rule.peers {
+					allow, except, err := s.c.fetchPolicySelectedAddresses(np.Namespace, protocol, peer)
+					if err != nil {
+						klog.Errorf("failed to fetch policy selected addresses: %v", err)
+						return nil, err
+					}
+					allows = append(allows, allow...)
+					excepts = append(excepts, except...)
+				}
+			}
+			acls = append(acls, simACL{
+				PolicyVerdictRule: PolicyVerdictRule{Kind: policyKindNetworkPolicy, Name: name, Rule: strconv.Itoa(idx), Priority: priority(allowPriority), Action: ovnnb.ACLActionAllowRelated},
+				match: func(pkt *simPacket) (bool, error) {
+					remote := remoteIP(pkt, direction)
+					return ipInAddresses(remote, allows...) && !ipInAddresses(remote, excepts...) && npPortsMatch(rule.ports, namedPortMap, pkt), nil
+				},
+			})
+		}
+
+		if !ingress {
+			for _, fqdn := range npEgressFqdns(np) {
+				addresses, err := s.fqdnAddresses(fqdn)
+				if err != nil {
+					return nil, err
+				}
+				acls = append(acls, simACL{
+					PolicyVerdictRule: PolicyVerdictRule{Kind: policyKindNetworkPolicy, Name: name, Rule: "fqdn " + fqdn, Priority: priority(util.EgressAllowPriority), Action: ovnnb.ACLActionAllowRelated},
+					match: func(pkt *simPacket) (bool, error) {
+						return ipInAddresses(pkt.dst, addresses...), nil
+					},
+				})
+			}
+		}
+	}
+	return acls, nil
+}
+
+// adminPolicyPortsMatch returns whether the packet matches the ports of an admin network policy rule
+func adminPolicyPortsMatch(ports []anpv1alpha1.AdminNetworkPolicyPort, namedPortMap map[string]*util.NamedPortInfo, pkt *simPacket) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for _, port := range ports {
+		switch {
+		case port.PortNumber != nil:
+			if strings.ToLower(string(port.PortNumber.Protocol)) == pkt.protocol && int32(pkt.port) == port.PortNumber.Port {
+				return true
+			}
+		case port.PortRange != nil:
+			protocol := strings.ToLower(string(port.PortRange.Protocol))
+			if protocol == "" {
+				protocol = "tcp"
+			}
+			if protocol == pkt.protocol && int32(pkt.port) >= port.PortRange.Start && int32(pkt.port) <= port.PortRange.End {
+				return true
+			}
+		case port.NamedPort != nil:
+			// the protocol of named ports is not recorded, all of the layer 4 protocols are matched
+			if info, ok := namedPortMap[*port.NamedPort]; ok && pkt.protocol != "icmp" && int32(pkt.port) == info.PortID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// adminPolicySubjectMatches returns whether the pod is selected by the subject of an admin network policy
+func adminPolicySubjectMatches(pod *corev1.Pod, podNs *corev1.Namespace, subject anpv1alpha1.AdminNetworkPolicySubject) bool {
+	if subject.Pods != nil {
+		return labelSelectorMatches(&subject.Pods.NamespaceSelector, podNs.Labels) && labelSelectorMatches(&subject.Pods.PodSelector, pod.Labels)
+	}
+	return labelSelectorMatches(subject.Namespaces, podNs.Labels)
+}
+
+// adminPolicyACLs returns the acls of the admin network policies and baseline admin network policies whose subject
+// is the pod, they are placed above and below the acls of network policies respectively
+func (s *policyVerdictSimulator) adminPolicyACLs(pod *corev1.Pod, direction string, pkt *simPacket) ([]simACL, error) {
+	if !s.c.config.EnableANP {
+		return nil, nil
+	}
+
+	podNs, err := s.c.namespacesLister.Get(pod.Namespace)
+	if err != nil {
+		klog.Errorf("failed to get namespace %s: %v", pod.Namespace, err)
+		return nil, err
+	}
+
+	protocol := kubeovnv1.ProtocolIPv4
+	if !pkt.ipv4() {
+		protocol = kubeovnv1.ProtocolIPv6
+	}
+
+	var acls []simACL
+	ingress := direction == ovnnb.ACLDirectionToLport
+	anps, err := s.c.anpsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list admin network policies: %v", err)
+		return nil, err
+	}
+	for _, anp := range anps {
+		// invalid policies are not applied
+		if util.ValidateAdminNetworkPolicy(anp) != nil || !adminPolicySubjectMatches(pod, podNs, anp.Spec.Subject) {
+			continue
+		}
+		rules := anpIngressRules(anp)
+		if !ingress {
+			rules = anpEgressRules(anp)
+		}
+		maxPriority := util.AnpACLMaxPriority - int(anp.Spec.Priority)*util.AnpMaxRules
+		ruleACLs, err := s.adminPolicyRuleACLs(policyKindAdminNetworkPolicy, anp.Name, anp.Spec.Subject, rules, maxPriority, direction, protocol)
+		if err != nil {
+			return nil, err
+		}
+		acls = append(acls, ruleACLs...)
+	}
+
+	banps, err := s.c.banpsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list baseline admin network policies: %v", err)
+		return nil, err
+	}
+	for _, banp := range banps {
+		if util.ValidateBaselineAdminNetworkPolicy(banp) != nil || !adminPolicySubjectMatches(pod, podNs, banp.Spec.Subject) {
+			continue
+		}
+		rules := banpIngressRules(banp)
+		if !ingress {
+			rules = banpEgressRules(banp)
+		}
+		ruleACLs, err := s.adminPolicyRuleACLs(policyKindBaselineAdminNetworkPolicy, banp.Name, banp.Spec.Subject, rules, util.BanpACLMaxPriority, direction, protocol)
+		if err != nil {
+			return nil, err
+		}
+		acls = append(acls, ruleACLs...)
+	}
+	return acls, nil
+}
+
+// adminPolicyRuleACLs returns the acls of the rules of an admin network policy or a baseline admin network policy,
+// the rules are evaluated in order starting from maxPriority
+func (s *policyVerdictSimulator) adminPolicyRuleACLs(kind, name string, subject anpv1alpha1.AdminNetworkPolicySubject, rules []adminPolicyRule, maxPriority int, direction, protocol string) ([]simACL, error) {
+	subjectNamespaces, err := s.c.selectAdminPolicySubjectNamespaces(subject)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+
+	acls := make([]simACL, 0, len(rules))
+	for idx, rule := range rules {
+		rule := rule
+		addresses, peerNamespaces, err := s.c.fetchAdminPolicyPeerAddresses(rule.peers, protocol)
+		if err != nil {
+			klog.Errorf("failed to fetch peer addresses of %s %s: %v", kind, name, err)
+			return nil, err
+		}
+		// named ports of ingress rules are the ones of the subject pods
+		namedPortMap := s.c.namedPortMapOfNamespaces(subjectNamespaces)
+		if direction == ovnnb.ACLDirectionFromLport {
+			namedPortMap = s.c.namedPortMapOfNamespaces(peerNamespaces)
+		}
+
+		var action string
+		switch rule.action {
+		case anpv1alpha1.AdminNetworkPolicyRuleActionAllow:
+			action = ovnnb.ACLActionAllowRelated
+		case anpv1alpha1.AdminNetworkPolicyRuleActionDeny:
+			action = ovnnb.ACLActionDrop
+		case anpv1alpha1.AdminNetworkPolicyRuleActionPass:
+			action = policyActionPass
+		}
+		acls = append(acls, simACL{
+			PolicyVerdictRule: PolicyVerdictRule{Kind: kind, Name: name, Rule: strconv.Itoa(idx), Priority: maxPriority - idx, Action: action},
+			match: func(pkt *simPacket) (bool, error) {
+				return ipInAddresses(remoteIP(pkt, direction), addresses...) && adminPolicyPortsMatch(rule.ports, namedPortMap, pkt), nil
+			},
+		})
+	}
+	return acls, nil
+}
@@ -0,0 +1,385 @@
+package controller
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// simPacket is the packet evaluated by the policy verdict simulator
+type simPacket struct {
+	src, dst net.IP
+	// protocol is one of tcp, udp and icmp
+	protocol string
+	// port is the destination port of tcp or udp
+	port int
+	// icmpType and icmpCode are the type and code of icmp, which is echo request
+	icmpType, icmpCode int
+	// inport and outport are the logical switch ports the packet enters or leaves the logical switch
+	inport, outport string
+}
+
+func (p *simPacket) ipv4() bool {
+	return p.src.To4() != nil
+}
+
+// evalACLMatch evaluates an ovn acl match against the packet, an error is returned
+// if the match references fields or address sets which can not be simulated
+func evalACLMatch(match string, pkt *simPacket) (bool, error) {
+	tokens, err := tokenizeACLMatch(match)
+	if err != nil {
+		return false, err
+	}
+	parser := &aclMatchParser{tokens: tokens, pkt: pkt}
+	result, err := parser.parseOr()
+	if err != nil {
+		return false, err
+	}
+	if parser.pos != len(parser.tokens) {
+		return false, fmt.Errorf("unexpected token %q in match %q", parser.tokens[parser.pos], match)
+	}
+	return result, nil
+}
+
+func tokenizeACLMatch(match string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(match); {
+		ch := match[i]
+		switch {
+		case unicode.IsSpace(rune(ch)):
+			i++
+		case strings.HasPrefix(match[i:], "&&"), strings.HasPrefix(match[i:], "||"),
+			strings.HasPrefix(match[i:], "=="), strings.HasPrefix(match[i:], "!="),
+			strings.HasPrefix(match[i:], "<="), strings.HasPrefix(match[i:], ">="):
+			tokens = append(tokens, match[i:i+2])
+			i += 2
+		case strings.ContainsRune("!(){},<>", rune(ch)):
+			tokens = append(tokens, string(ch))
+			i++
+		case ch == '"':
+			end := strings.IndexByte(match[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string in match %q", match)
+			}
+			tokens = append(tokens, match[i:i+end+2])
+			i += end + 2
+		default:
+			j := i
+			for j < len(match) && !unicode.IsSpace(rune(match[j])) && !strings.ContainsRune("&|!(){},<>=\"", rune(match[j])) {
+				j++
+			}
+			if j == i {
+				return nil, fmt.Errorf("unexpected character %q in match %q", ch, match)
+			}
+			tokens = append(tokens, match[i:j])
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+type aclMatchParser struct {
+	tokens []string
+	pos    int
+	pkt    *simPacket
+}
+
+func (p *aclMatchParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *aclMatchParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *aclMatchParser) parseOr() (bool, error) {
+	result, err := p.parseAnd()
+	if err != nil {
+		return false, err
+	}
+	for p.peek() == "||" {
+		p.next()
+		r, err := p.parseAnd()
+		if err != nil {
+			return false, err
+		}
+		result = result || r
+	}
+	return result, nil
+}
+
+func (p *aclMatchParser) parseAnd() (bool, error) {
+	result, err := p.parseNot()
+	if err != nil {
+		return false, err
+	}
+	for p.peek() == "&&" {
+		p.next()
+		r, err := p.parseNot()
+		if err != nil {
+			return false, err
+		}
+		result = result && r
+	}
+	return result, nil
+}
+
+func (p *aclMatchParser) parseNot() (bool, error) {
+	switch p.peek() {
+	case "!":
+		p.next()
+		result, err := p.parseNot()
+		return !result, err
+	case "(":
+		p.next()
+		result, err := p.parseOr()
+		if err != nil {
+			return false, err
+		}
+		if p.next() != ")" {
+			return false, fmt.Errorf("missing ')'")
+		}
+		return result, nil
+	}
+	return p.parseRelation()
+}
+
+func isRelOp(token string) bool {
+	switch token {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+// parseRelation parses 'field', 'field op value' and 'value op field op value'
+func (p *aclMatchParser) parseRelation() (bool, error) {
+	first := p.next()
+	if first == "" {
+		return false, fmt.Errorf("unexpected end of match")
+	}
+	if !isRelOp(p.peek()) {
+		return p.evalPredicate(first)
+	}
+	op := p.next()
+
+	if _, err := strconv.Atoi(first); err == nil {
+		// range like '1024 <= tcp.dst <= 2048'
+		field := p.next()
+		op2 := p.next()
+		if !isRelOp(op2) {
+			return false, fmt.Errorf("invalid range relation of field %s", field)
+		}
+		last := p.next()
+		r1, err := p.evalRelation(field, reverseRelOp(op), []string{first})
+		if err != nil {
+			return false, err
+		}
+		r2, err := p.evalRelation(field, op2, []string{last})
+		if err != nil {
+			return false, err
+		}
+		return r1 && r2, nil
+	}
+
+	var values []string
+	if p.peek() == "{" {
+		p.next()
+		for {
+			token := p.next()
+			if token == "}" {
+				break
+			}
+			if token == "" {
+				return false, fmt.Errorf("missing '}'")
+			}
+			if token != "," {
+				values = append(values, token)
+			}
+		}
+	} else {
+		values = append(values, p.next())
+	}
+	return p.evalRelation(first, op, values)
+}
+
+func reverseRelOp(op string) string {
+	switch op {
+	case "<":
+		return ">"
+	case "<=":
+		return ">="
+	case ">":
+		return "<"
+	case ">=":
+		return "<="
+	}
+	return op
+}
+
+// evalPredicate evaluates the fields which are used as boolean predicates, e.g. 'ip4' and 'tcp'
+func (p *aclMatchParser) evalPredicate(field string) (bool, error) {
+	pkt := p.pkt
+	switch field {
+	case "ip":
+		return true, nil
+	case "ip4":
+		return pkt.ipv4(), nil
+	case "ip6":
+		return !pkt.ipv4(), nil
+	case "tcp", "udp":
+		return pkt.protocol == field, nil
+	case "icmp":
+		return pkt.protocol == "icmp", nil
+	case "icmp4":
+		return pkt.protocol == "icmp" && pkt.ipv4(), nil
+	case "icmp6":
+		return pkt.protocol == "icmp" && !pkt.ipv4(), nil
+	case "1":
+		return true, nil
+	case "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("unsupported field %s", field)
+}
+
+// evalRelation evaluates 'field op value', the relation is false if the prerequisites of the field are not met
+func (p *aclMatchParser) evalRelation(field, op string, values []string) (bool, error) {
+	for _, v := range values {
+		if strings.HasPrefix(v, "$") || strings.HasPrefix(v, "@") {
+			return false, fmt.Errorf("address set or port group %s is not supported", v)
+		}
+	}
+
+	pkt := p.pkt
+	var equal func(v string) (bool, error)
+	var compare func(v string) (int, error)
+	switch field {
+	case "ip4.src", "ip4.dst", "ip6.src", "ip6.dst":
+		if strings.HasPrefix(field, "ip4") != pkt.ipv4() {
+			return false, nil
+		}
+		ip := pkt.src
+		if strings.HasSuffix(field, ".dst") {
+			ip = pkt.dst
+		}
+		equal = func(v string) (bool, error) {
+			if strings.Contains(v, "/") {
+				_, cidr, err := net.ParseCIDR(v)
+				if err != nil {
+					return false, fmt.Errorf("invalid cidr %s", v)
+				}
+				return cidr.Contains(ip), nil
+			}
+			addr := net.ParseIP(v)
+			if addr == nil {
+				return false, fmt.Errorf("invalid ip %s", v)
+			}
+			return addr.Equal(ip), nil
+		}
+	case "tcp.dst", "udp.dst":
+		if pkt.protocol != strings.TrimSuffix(field, ".dst") {
+			return false, nil
+		}
+		compare = func(v string) (int, error) {
+			port, err := strconv.Atoi(v)
+			if err != nil {
+				return 0, fmt.Errorf("invalid port %s", v)
+			}
+			return pkt.port - port, nil
+		}
+	case "icmp4.type", "icmp4.code", "icmp6.type", "icmp6.code":
+		if pkt.protocol != "icmp" || strings.HasPrefix(field, "icmp4") != pkt.ipv4() {
+			return false, nil
+		}
+		value := pkt.icmpType
+		if strings.HasSuffix(field, ".code") {
+			value = pkt.icmpCode
+		}
+		compare = func(v string) (int, error) {
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				return 0, fmt.Errorf("invalid %s %s", field, v)
+			}
+			return value - i, nil
+		}
+	case "ip.proto":
+		compare = func(v string) (int, error) {
+			proto, err := strconv.Atoi(v)
+			if err != nil {
+				return 0, fmt.Errorf("invalid ip protocol %s", v)
+			}
+			var pktProto int
+			switch pkt.protocol {
+			case "tcp":
+				pktProto = 6
+			case "udp":
+				pktProto = 17
+			case "icmp":
+				pktProto = 1
+				if !pkt.ipv4() {
+					pktProto = 58
+				}
+			}
+			return pktProto - proto, nil
+		}
+	case "inport", "outport":
+		port := pkt.inport
+		if field == "outport" {
+			port = pkt.outport
+		}
+		equal = func(v string) (bool, error) {
+			return strings.Trim(v, `"`) == port, nil
+		}
+	default:
+		return false, fmt.Errorf("unsupported field %s", field)
+	}
+
+	if equal == nil {
+		equal = func(v string) (bool, error) {
+			r, err := compare(v)
+			return r == 0, err
+		}
+	}
+
+	switch op {
+	case "==", "!=":
+		matched := false
+		for _, v := range values {
+			r, err := equal(v)
+			if err != nil {
+				return false, err
+			}
+			matched = matched || r
+		}
+		if op == "!=" {
+			return !matched, nil
+		}
+		return matched, nil
+	}
+
+	if compare == nil || len(values) != 1 {
+		return false, fmt.Errorf("operator %s is not supported by field %s", op, field)
+	}
+	r, err := compare(values[0])
+	if err != nil {
+		return false, err
+	}
+	switch op {
+	case "<":
+		return r < 0, nil
+	case "<=":
+		return r <= 0, nil
+	case ">":
+		return r > 0, nil
+	default:
+		return r >= 0, nil
+	}
+}
@@ -0,0 +1,309 @@
+package controller
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	anpv1alpha1 "sigs.k8s.io/network-policy-api/apis/v1alpha1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	kubeovnlister "github.com/kubeovn/kube-ovn/pkg/client/listers/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func Test_evalACLMatch(t *testing.T) {
+	t.Parallel()
+
+	pkt := &simPacket{
+		src:      net.ParseIP("10.16.0.2"),
+		dst:      net.ParseIP("10.16.0.3"),
+		protocol: "tcp",
+		port:     80,
+		outport:  "pod.ns",
+	}
+	tests := []struct {
+		match   string
+		matched bool
+		err     bool
+	}{
+		{match: "ip", matched: true},
+		{match: "ip6", matched: false},
+		{match: "ip4.src == 10.16.0.0/16", matched: true},
+		{match: "ip4.src == 10.17.0.0/16", matched: false},
+		{match: "ip4.dst != 10.16.0.3", matched: false},
+		{match: "ip6.src == fd00::/64", matched: false},
+		{match: "ip4.dst == {10.16.0.1, 10.16.0.3}", matched: true},
+		{match: "tcp && tcp.dst == 80", matched: true},
+		{match: "udp.dst == 80", matched: false},
+		{match: "1024 <= tcp.dst <= 2048", matched: false},
+		{match: "1 <= tcp.dst <= 1024", matched: true},
+		{match: "tcp.dst > 79 && tcp.dst < 81", matched: true},
+		{match: "ip.proto == 6", matched: true},
+		{match: "!(icmp4 || udp)", matched: true},
+		{match: `outport == "pod.ns" && ip4`, matched: true},
+		{match: `inport == "pod.ns"`, matched: false},
+		{match: "icmp4.type == 8", matched: false},
+		{match: "ip4.src == $as", err: true},
+		{match: "outport == @pg", err: true},
+		{match: "eth.src == 00:00:00:00:00:01", err: true},
+		{match: "(ip4", err: true},
+		{match: "ip4 ip6", err: true},
+	}
+	for _, tt := range tests {
+		matched, err := evalACLMatch(tt.match, pkt)
+		if tt.err {
+			require.Error(t, err, tt.match)
+			continue
+		}
+		require.NoError(t, err, tt.match)
+		require.Equal(t, tt.matched, matched, tt.match)
+	}
+
+	icmpPkt := &simPacket{src: net.ParseIP("fd00::2"), dst: net.ParseIP("fd00::3"), protocol: "icmp", icmpType: 128}
+	for match, expected := range map[string]bool{
+		"icmp6 && icmp6.type == 128 && icmp6.code == 0": true,
+		"icmp6.type == {133, 134}":                      false,
+		"icmp4.type == 8":                               false,
+	} {
+		matched, err := evalACLMatch(match, icmpPkt)
+		require.NoError(t, err, match)
+		require.Equal(t, expected, matched, match)
+	}
+}
+
+func Test_ipInAddresses(t *testing.T) {
+	t.Parallel()
+
+	ip := net.ParseIP("10.16.0.2")
+	require.True(t, ipInAddresses(ip, "10.16.0.0/16"))
+	require.True(t, ipInAddresses(ip, "192.168.0.0/24", " 10.16.0.2"))
+	require.False(t, ipInAddresses(ip, "10.16.0.3", "fd00::/64"))
+	require.False(t, ipInAddresses(ip, "invalid"))
+	require.False(t, ipInAddresses(ip))
+}
+
+func Test_npPortsMatch(t *testing.T) {
+	t.Parallel()
+
+	udp, sctp := corev1.ProtocolUDP, corev1.ProtocolSCTP
+	port80, portWeb := intstr.FromInt(80), intstr.FromString("web")
+	port1000, endPort := intstr.FromInt(1000), int32(2000)
+	namedPortMap := map[string]*util.NamedPortInfo{"web": {PortID: 8080}}
+
+	tcpPkt := func(port int) *simPacket {
+		return &simPacket{src: net.ParseIP("10.16.0.2"), dst: net.ParseIP("10.16.0.3"), protocol: "tcp", port: port}
+	}
+
+	require.True(t, npPortsMatch(nil, namedPortMap, tcpPkt(80)))
+	require.True(t, npPortsMatch([]netv1.NetworkPolicyPort{{Port: &port80}}, namedPortMap, tcpPkt(80)))
+	require.False(t, npPortsMatch([]netv1.NetworkPolicyPort{{Port: &port80}}, namedPortMap, tcpPkt(81)))
+	require.False(t, npPortsMatch([]netv1.NetworkPolicyPort{{Protocol: &udp, Port: &port80}}, namedPortMap, tcpPkt(80)))
+	require.False(t, npPortsMatch([]netv1.NetworkPolicyPort{{Protocol: &sctp}}, namedPortMap, tcpPkt(80)))
+	require.True(t, npPortsMatch([]netv1.NetworkPolicyPort{{Port: &portWeb}}, namedPortMap, tcpPkt(8080)))
+	require.False(t, npPortsMatch([]netv1.NetworkPolicyPort{{Port: &portWeb}}, nil, tcpPkt(8080)))
+	require.True(t, npPortsMatch([]netv1.NetworkPolicyPort{{Port: &port1000, EndPort: &endPort}}, namedPortMap, tcpPkt(1500)))
+	require.False(t, npPortsMatch([]netv1.NetworkPolicyPort{{Port: &port1000, EndPort: &endPort}}, namedPortMap, tcpPkt(2001)))
+	require.True(t, npPortsMatch([]netv1.NetworkPolicyPort{{Protocol: &udp}, {Port: &port80}}, namedPortMap, tcpPkt(80)))
+}
+
+func Test_sgACLsAudit(t *testing.T) {
+	t.Parallel()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, sg := range []*kubeovnv1.SecurityGroup{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "audit"},
+			Spec: kubeovnv1.SecurityGroupSpec{
+				Audit:        true,
+				IngressRules: []*kubeovnv1.SgRule{{IPVersion: "ipv4", RemoteType: kubeovnv1.SgRemoteTypeAddress, RemoteAddress: "10.0.0.0/8", Protocol: kubeovnv1.ProtocolALL, Priority: 1, Policy: kubeovnv1.PolicyDrop}},
+			},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Name: "enforced"}},
+	} {
+		require.NoError(t, indexer.Add(sg))
+	}
+	s := newPolicyVerdictSimulator(&Controller{sgsLister: kubeovnlister.NewSecurityGroupLister(indexer)})
+	newPod := func(sgs string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{fmt.Sprintf(util.SecurityGroupAnnotationTemplate, util.OvnProvider): sgs},
+		}}
+	}
+
+	// the packets are not denied if all the security groups are in audit mode
+	acls, err := s.sgACLs(newPod("audit"), ovnnb.ACLDirectionToLport)
+	require.NoError(t, err)
+	require.Len(t, acls, 2)
+	for _, acl := range acls {
+		require.Equal(t, ovnnb.ACLActionAllowRelated, acl.Action)
+		require.Less(t, acl.Priority, priority(util.DefaultDropPriority))
+	}
+
+	// the deny all acl of the enforced security group is not overridden by the audit acls
+	acls, err = s.sgACLs(newPod("audit,enforced"), ovnnb.ACLDirectionToLport)
+	require.NoError(t, err)
+	require.Len(t, acls, 3)
+	denyAll := acls[len(acls)-1]
+	require.Equal(t, util.DenyAllSecurityGroup, denyAll.Name)
+	require.Equal(t, ovnnb.ACLActionDrop, denyAll.Action)
+	for _, acl := range acls[:len(acls)-1] {
+		require.Less(t, acl.Priority, denyAll.Priority)
+	}
+}
+
+func Test_subnetACLsPrivate(t *testing.T) {
+	t.Parallel()
+
+	s := newPolicyVerdictSimulator(&Controller{config: &Configuration{NodeSwitchCIDR: "100.64.0.0/16"}})
+	subnet := &kubeovnv1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "private"},
+		Spec: kubeovnv1.SubnetSpec{
+			CIDRBlock:           "10.16.0.0/24",
+			SecondaryCIDRBlocks: []string{"10.17.0.0/24"},
+			Private:             true,
+		},
+	}
+
+	allowed := func(src, dst string) bool {
+		pkt := &simPacket{src: net.ParseIP(src), dst: net.ParseIP(dst)}
+		var verdict *simACL
+		acls := s.subnetACLs(subnet, ovnnb.ACLDirectionToLport)
+		for i := range acls {
+			matched, err := acls[i].match(pkt)
+			require.NoError(t, err)
+			if matched && (verdict == nil || acls[i].Priority > verdict.Priority) {
+				verdict = &acls[i]
+			}
+		}
+		return verdict != nil && verdict.Action != ovnnb.ACLActionDrop
+	}
+
+	// the traffic between the primary and secondary cidr blocks is allowed
+	require.True(t, allowed("10.16.0.2", "10.17.0.2"))
+	require.True(t, allowed("10.17.0.2", "10.17.0.3"))
+	require.True(t, allowed("100.64.0.2", "10.17.0.2"))
+	require.False(t, allowed("10.18.0.2", "10.17.0.2"))
+}
+
+func Test_adminPolicyPortsMatch(t *testing.T) {
+	t.Parallel()
+
+	portWeb := "web"
+	namedPortMap := map[string]*util.NamedPortInfo{"web": {PortID: 8080}}
+	tcpPkt := func(port int) *simPacket {
+		return &simPacket{src: net.ParseIP("10.16.0.2"), dst: net.ParseIP("10.16.0.3"), protocol: "tcp", port: port}
+	}
+	icmpPkt := &simPacket{src: net.ParseIP("10.16.0.2"), dst: net.ParseIP("10.16.0.3"), protocol: "icmp", icmpType: 8}
+	portNumber := func(protocol corev1.Protocol, port int32) anpv1alpha1.AdminNetworkPolicyPort {
+		return anpv1alpha1.AdminNetworkPolicyPort{PortNumber: &anpv1alpha1.Port{Protocol: protocol, Port: port}}
+	}
+	portRange := anpv1alpha1.AdminNetworkPolicyPort{PortRange: &anpv1alpha1.PortRange{Start: 1000, End: 2000}}
+
+	require.True(t, adminPolicyPortsMatch(nil, namedPortMap, tcpPkt(80)))
+	require.True(t, adminPolicyPortsMatch(nil, namedPortMap, icmpPkt))
+	require.True(t, adminPolicyPortsMatch([]anpv1alpha1.AdminNetworkPolicyPort{portNumber(corev1.ProtocolTCP, 80)}, namedPortMap, tcpPkt(80)))
+	require.False(t, adminPolicyPortsMatch([]anpv1alpha1.AdminNetworkPolicyPort{portNumber(corev1.ProtocolTCP, 80)}, namedPortMap, tcpPkt(81)))
+	require.False(t, adminPolicyPortsMatch([]anpv1alpha1.AdminNetworkPolicyPort{portNumber(corev1.ProtocolUDP, 80)}, namedPortMap, tcpPkt(80)))
+	require.False(t, adminPolicyPortsMatch([]anpv1alpha1.AdminNetworkPolicyPort{portNumber(corev1.ProtocolTCP, 80)}, namedPortMap, icmpPkt))
+	require.True(t, adminPolicyPortsMatch([]anpv1alpha1.AdminNetworkPolicyPort{portRange}, namedPortMap, tcpPkt(1500)))
+	require.False(t, adminPolicyPortsMatch([]anpv1alpha1.AdminNetworkPolicyPort{portRange}, namedPortMap, tcpPkt(2001)))
+	require.True(t, adminPolicyPortsMatch([]anpv1alpha1.AdminNetworkPolicyPort{{NamedPort: &portWeb}}, namedPortMap, tcpPkt(8080)))
+	require.False(t, adminPolicyPortsMatch([]anpv1alpha1.AdminNetworkPolicyPort{{NamedPort: &portWeb}}, nil, tcpPkt(8080)))
+}
+
+func Test_decidingRule(t *testing.T) {
+	t.Parallel()
+
+	anpRule := func(name, action string, p int) PolicyVerdictRule {
+		return PolicyVerdictRule{Kind: policyKindAdminNetworkPolicy, Name: name, Priority: p, Action: action}
+	}
+	npDrop := PolicyVerdictRule{Kind: policyKindNetworkPolicy, Name: "default/np", Rule: "drop", Priority: priority(util.IngressDefaultDrop), Action: ovnnb.ACLActionDrop}
+	banpAllow := PolicyVerdictRule{Kind: policyKindBaselineAdminNetworkPolicy, Name: "default", Priority: util.BanpACLMaxPriority, Action: ovnnb.ACLActionAllowRelated}
+
+	tests := []struct {
+		name  string
+		rules []PolicyVerdictRule
+		want  string
+	}{
+		{"no rule", nil, ""},
+		{"anp allows before np", []PolicyVerdictRule{anpRule("allow", ovnnb.ACLActionAllowRelated, 29000), npDrop}, "allow"},
+		{"anp passes to np", []PolicyVerdictRule{anpRule("pass", policyActionPass, 29900), anpRule("allow", ovnnb.ACLActionAllowRelated, 29000), npDrop}, "default/np"},
+		{"anp passes to banp", []PolicyVerdictRule{anpRule("pass", policyActionPass, 29900), anpRule("deny", ovnnb.ACLActionDrop, 29000), banpAllow}, "default"},
+		{"anp passes to nothing", []PolicyVerdictRule{anpRule("pass", policyActionPass, 29900)}, ""},
+		{"np before banp", []PolicyVerdictRule{npDrop, banpAllow}, "default/np"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rule := decidingRule(tt.rules)
+			if tt.want == "" {
+				require.Nil(t, rule)
+				return
+			}
+			require.NotNil(t, rule)
+			require.Equal(t, tt.want, rule.Name)
+		})
+	}
+}
+
+func Test_authorizePolicyVerdictRequest(t *testing.T) {
+	t.Parallel()
+
+	kubeClient := fake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		tr := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if tr.Spec.Token == "valid" || tr.Spec.Token == "forbidden" {
+			tr.Status = authenticationv1.TokenReviewStatus{Authenticated: true, User: authenticationv1.UserInfo{Username: tr.Spec.Token}}
+		} else {
+			tr.Status = authenticationv1.TokenReviewStatus{Error: "invalid token"}
+		}
+		return true, tr, nil
+	})
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		sar := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		sar.Status.Allowed = sar.Spec.User == "valid" && sar.Spec.NonResourceAttributes != nil &&
+			sar.Spec.NonResourceAttributes.Path == PolicyVerdictPath && sar.Spec.NonResourceAttributes.Verb == "get"
+		return true, sar, nil
+	})
+	c := &Controller{config: &Configuration{KubeClient: kubeClient}}
+
+	tests := []struct {
+		name   string
+		header string
+		status int
+	}{
+		{"no token", "", http.StatusUnauthorized},
+		{"not a bearer token", "Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+		{"invalid token", "Bearer invalid", http.StatusUnauthorized},
+		{"forbidden", "Bearer forbidden", http.StatusForbidden},
+		{"allowed", "Bearer valid", http.StatusOK},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := httptest.NewRequest(http.MethodGet, PolicyVerdictPath+"?src=default/client", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			status, err := c.authorizePolicyVerdictRequest(r)
+			require.Equal(t, tt.status, status)
+			require.Equal(t, tt.status != http.StatusOK, err != nil)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
 
@@ -319,27 +320,24 @@ func (c *Controller) reconcileEIPBandtithLimitRules(
 	deleted kubeovnv1.QoSPolicyBandwidthLimitRules,
 	updated kubeovnv1.QoSPolicyBandwidthLimitRules,
 ) error {
-	var err error
-	// in this case, we must delete rules first, then add or update rules
-	if len(deleted) > 0 {
-		if err = c.delEIPBandtithLimitRules(eip, eip.Status.IP, deleted); err != nil {
-			klog.Errorf("failed to delete eip %s bandwidth limit rules, %v", eip.Name, err)
-			return err
-		}
+	// the agent deletes the old rules of the updated ones before adding the new ones
+	qosPolicy, err := c.qosPoliciesLister.Get(eip.Status.QoSPolicy)
+	if err != nil {
+		klog.Errorf("get qos policy %s failed: %v", eip.Status.QoSPolicy, err)
+		return err
 	}
-	if len(added) > 0 {
-		if err = c.addOrUpdateEIPBandtithLimitRules(eip, eip.Status.IP, added); err != nil {
-			klog.Errorf("failed to add eip %s bandwidth limit rules, %v", eip.Name, err)
-			return err
+	var old kubeovnv1.QoSPolicyBandwidthLimitRules
+	for _, rule := range qosPolicy.Status.BandwidthLimitRules {
+		if slices.ContainsFunc(deleted, func(r *kubeovnv1.QoSPolicyBandwidthLimitRule) bool { return r.Name == rule.Name }) ||
+			slices.ContainsFunc(updated, func(r *kubeovnv1.QoSPolicyBandwidthLimitRule) bool { return r.Name == rule.Name }) {
+			old = append(old, rule)
 		}
 	}
-	if len(updated) > 0 {
-		if err = c.addOrUpdateEIPBandtithLimitRules(eip, eip.Status.IP, updated); err != nil {
-			klog.Errorf("failed to update eip %s bandwidth limit rules, %v", eip.Name, err)
-			return err
-		}
+	rules := natGwEIPQoS(eip.Status.IP, append(added, updated...))
+	if err = c.syncNatGwRules(eip.Spec.NatGwDp, rules, staleNatGwRules(natGwEIPQoS(eip.Status.IP, old), rules)); err != nil {
+		klog.Errorf("failed to reconcile eip %s bandwidth limit rules, %v", eip.Name, err)
+		return err
 	}
-
 	return nil
 }
 
@@ -44,6 +44,8 @@ func (c *Controller) enqueueUpdateSg(oldObj, newObj interface{}) {
 		}
 		klog.V(3).Infof("enqueue update securityGroup %s", key)
 		c.addOrUpdateSgQueue.Add(key)
+		c.enqueueFqdns(sgEgressFqdns(oldSg)...)
+		c.enqueueAddressGroups(sgAddressGroups(oldSg)...)
 	}
 }
 
@@ -56,6 +58,10 @@ func (c *Controller) enqueueDeleteSg(obj interface{}) {
 	}
 	klog.V(3).Infof("enqueue delete securityGroup %s", key)
 	c.delSgQueue.Add(key)
+	if sg, ok := obj.(*kubeovnv1.SecurityGroup); ok {
+		c.enqueueFqdns(sgEgressFqdns(sg)...)
+		c.enqueueAddressGroups(sgAddressGroups(sg)...)
+	}
 }
 
 func (c *Controller) runAddSgWorker() {
@@ -209,6 +215,15 @@ func (c *Controller) updateDenyAllSgPorts() error {
 		if allNotExist {
 			continue
 		}
+		// the traffic of ports is not denied if all the security groups are in audit mode
+		enforced, err := c.securityGroupsEnforced(sgs)
+		if err != nil {
+			klog.Error(err)
+			return err
+		}
+		if !enforced {
+			continue
+		}
 
 		addPorts = append(addPorts, lsp.Name)
 	}
@@ -275,6 +290,15 @@ func (c *Controller) handleAddOrUpdateSg(key string) error {
 		return err
 	}
 
+	// address sets of fqdns must exist before the acls referencing them
+	if err = c.createFqdnAddressSets(sgEgressFqdns(sg)...); err != nil {
+		return err
+	}
+	// so do address sets of address groups
+	if err = c.createAddressGroupAddressSets(sgAddressGroups(sg)...); err != nil {
+		return err
+	}
+
 	ingressNeedUpdate := false
 	egressNeedUpdate := false
 
@@ -290,21 +314,55 @@ func (c *Controller) handleAddOrUpdateSg(key string) error {
 		egressNeedUpdate = true
 	}
 
-	// check allowSameGroupTraffic switch
-	if sg.Status.AllowSameGroupTraffic != sg.Spec.AllowSameGroupTraffic {
+	// check allowSameGroupTraffic and audit switch
+	if sg.Status.AllowSameGroupTraffic != sg.Spec.AllowSameGroupTraffic || sg.Status.Audit != sg.Spec.Audit {
 		klog.Infof("both ingress && egress need update, sg:%s", sg.Name)
 		ingressNeedUpdate = true
 		egressNeedUpdate = true
 	}
 
-	// update sg rule
-	if ingressNeedUpdate {
-		if err = c.OVNNbClient.UpdateSgACL(sg, ovnnb.ACLDirectionToLport); err != nil {
-			sg.Status.IngressLastSyncSuccess = false
-			c.patchSgStatus(sg)
+	meterName := ovs.GetACLLogMeterName(pgName)
+	if sg.Spec.Audit {
+		if err = c.OVNNbClient.CreateOrUpdateMeter(meterName, ovnnb.MeterUnitPktps, util.DefaultACLLogRate, externalIDs); err != nil {
+			klog.Errorf("create acl log meter for sg %s: %v", sg.Name, err)
+			return err
+		}
+	} else if err = c.OVNNbClient.DeleteMeter(meterName); err != nil {
+		klog.Errorf("delete acl log meter of sg %s: %v", sg.Name, err)
+		return err
+	}
+
+	// the ports of security groups in audit mode are removed from the deny all port group before
+	// the acls are moved below the drop acls, and added back after the acls are enforced
+	auditChanged := sg.Status.Audit != sg.Spec.Audit
+	if auditChanged && sg.Spec.Audit {
+		if err = c.updateDenyAllSgPorts(); err != nil {
+			klog.Errorf("update sg deny all policy failed. %v", err)
 			return err
 		}
+	}
 
+	// update sg rule, the acls of both directions are updated in a single transaction
+	// so that switching between audit and enforce is atomic
+	if ingressNeedUpdate || egressNeedUpdate {
+		direction := ""
+		if !egressNeedUpdate {
+			direction = ovnnb.ACLDirectionToLport
+		} else if !ingressNeedUpdate {
+			direction = ovnnb.ACLDirectionFromLport
+		}
+		if err = c.OVNNbClient.UpdateSgACL(sg, direction); err != nil {
+			if ingressNeedUpdate {
+				sg.Status.IngressLastSyncSuccess = false
+			}
+			if egressNeedUpdate {
+				sg.Status.EgressLastSyncSuccess = false
+			}
+			c.patchSgStatus(sg)
+			return err
+		}
+	}
+	if ingressNeedUpdate {
 		if err := c.OVNNbClient.CreateSgBaseACL(sg.Name, ovnnb.ACLDirectionToLport); err != nil {
 			return err
 		}
@@ -313,12 +371,6 @@ func (c *Controller) handleAddOrUpdateSg(key string) error {
 		c.patchSgStatus(sg)
 	}
 	if egressNeedUpdate {
-		if err = c.OVNNbClient.UpdateSgACL(sg, ovnnb.ACLDirectionFromLport); err != nil {
-			sg.Status.IngressLastSyncSuccess = false
-			c.patchSgStatus(sg)
-			return err
-		}
-
 		if err := c.OVNNbClient.CreateSgBaseACL(sg.Name, ovnnb.ACLDirectionFromLport); err != nil {
 			return err
 		}
@@ -328,9 +380,17 @@ func (c *Controller) handleAddOrUpdateSg(key string) error {
 		c.patchSgStatus(sg)
 	}
 
+	if auditChanged && !sg.Spec.Audit {
+		if err = c.updateDenyAllSgPorts(); err != nil {
+			klog.Errorf("update sg deny all policy failed. %v", err)
+			return err
+		}
+	}
+
 	// update status
 	sg.Status.PortGroup = ovs.GetSgPortGroupName(sg.Name)
 	sg.Status.AllowSameGroupTraffic = sg.Spec.AllowSameGroupTraffic
+	sg.Status.Audit = sg.Spec.Audit
 	c.patchSgStatus(sg)
 	c.syncSgPortsQueue.Add(key)
 	return nil
@@ -338,6 +398,12 @@ func (c *Controller) handleAddOrUpdateSg(key string) error {
 
 func (c *Controller) validateSgRule(sg *kubeovnv1.SecurityGroup) error {
 	// check sg rules
+	for _, rule := range sg.Spec.IngressRules {
+		if rule.RemoteType == kubeovnv1.SgRemoteTypeFqdn {
+			return fmt.Errorf("sgRemoteType '%s' is only supported by egress rules", rule.RemoteType)
+		}
+	}
+
 	allRules := append(sg.Spec.IngressRules, sg.Spec.EgressRules...)
 	for _, rule := range allRules {
 		if rule.IPVersion != "ipv4" && rule.IPVersion != "ipv6" {
@@ -364,17 +430,49 @@ func (c *Controller) validateSgRule(sg *kubeovnv1.SecurityGroup) error {
 			if err != nil {
 				return fmt.Errorf("failed to get remote sg '%s', %v", rule.RemoteSecurityGroup, err)
 			}
+		case kubeovnv1.SgRemoteTypeFqdn:
+			if err := util.ValidateFqdn(rule.RemoteAddress); err != nil {
+				return fmt.Errorf("invalid fqdn '%s': %v", rule.RemoteAddress, err)
+			}
+		case kubeovnv1.SgRemoteTypeAddressGroup:
+			_, err := c.addressGroupsLister.Get(rule.RemoteAddressGroup)
+			if err != nil {
+				return fmt.Errorf("failed to get remote address group '%s', %v", rule.RemoteAddressGroup, err)
+			}
 		default:
 			return fmt.Errorf("not support sgRemoteType '%s'", rule.RemoteType)
 		}
 
 		if rule.Protocol == kubeovnv1.ProtocolTCP || rule.Protocol == kubeovnv1.ProtocolUDP {
-			if rule.PortRangeMin < 1 || rule.PortRangeMin > 65535 || rule.PortRangeMax < 1 || rule.PortRangeMax > 65535 {
-				return fmt.Errorf("portRange is out of range")
+			if len(rule.PortRanges) != 0 && (rule.PortRangeMin != 0 || rule.PortRangeMax != 0) {
+				return fmt.Errorf("portRanges can not be used together with portRangeMin and portRangeMax")
+			}
+			for _, portRange := range util.GetSgRulePortRanges(rule) {
+				if portRange.PortRangeMin < 1 || portRange.PortRangeMin > 65535 || portRange.PortRangeMax < 1 || portRange.PortRangeMax > 65535 {
+					return fmt.Errorf("portRange is out of range")
+				}
+				if portRange.PortRangeMin > portRange.PortRangeMax {
+					return fmt.Errorf("portRange err, range Minimum value greater than maximum value")
+				}
+			}
+		} else if len(rule.PortRanges) != 0 {
+			return fmt.Errorf("portRanges is only supported by tcp and udp rules")
+		}
+
+		if rule.IcmpType != nil || rule.IcmpCode != nil {
+			if rule.Protocol != kubeovnv1.ProtocolICMP {
+				return fmt.Errorf("icmpType and icmpCode are only supported by icmp rules")
 			}
-			if rule.PortRangeMin > rule.PortRangeMax {
-				return fmt.Errorf("portRange err, range Minimum value greater than maximum value")
+			if rule.IcmpType != nil && (*rule.IcmpType < 0 || *rule.IcmpType > 255) {
+				return fmt.Errorf("icmpType '%d' is not in the range of 0 to 255", *rule.IcmpType)
 			}
+			if rule.IcmpCode != nil && (*rule.IcmpCode < 0 || *rule.IcmpCode > 255) {
+				return fmt.Errorf("icmpCode '%d' is not in the range of 0 to 255", *rule.IcmpCode)
+			}
+		}
+
+		if rule.Stateless && rule.Policy != kubeovnv1.PolicyAllow {
+			return fmt.Errorf("stateless is only supported by allow rules")
 		}
 	}
 	return nil
@@ -401,6 +499,11 @@ func (c *Controller) handleDeleteSg(key string) error {
 		return err
 	}
 
+	if err := c.OVNNbClient.DeleteMeter(ovs.GetACLLogMeterName(ovs.GetSgPortGroupName(key))); err != nil {
+		klog.Errorf("delete acl log meter of sg %s: %v", key, err)
+		return err
+	}
+
 	return nil
 }
 
@@ -518,6 +621,24 @@ func (c *Controller) reconcilePortSg(portName, securityGroups string) error {
 }
 
 // securityGroupAllNotExist return true if all sgs does not exist
+// securityGroupsEnforced returns whether any of the security groups exists and is not in audit mode
+func (c *Controller) securityGroupsEnforced(sgs []string) (bool, error) {
+	for _, name := range sgs {
+		sg, err := c.sgsLister.Get(name)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			klog.Errorf("failed to get security group %s: %v", name, err)
+			return false, err
+		}
+		if !sg.Spec.Audit {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (c *Controller) securityGroupAllNotExist(sgs []string) (bool, error) {
 	if len(sgs) == 0 {
 		return true, nil
@@ -6,6 +6,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
 	"github.com/kubeovn/kube-ovn/pkg/ovs"
 	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
 )
@@ -72,3 +73,20 @@ func Test_securityGroupALLNotExist(t *testing.T) {
 		require.True(t, exist)
 	})
 }
+
+func Test_sgEgressFqdns(t *testing.T) {
+	sg := &kubeovnv1.SecurityGroup{
+		Spec: kubeovnv1.SecurityGroupSpec{
+			IngressRules: []*kubeovnv1.SgRule{
+				{RemoteType: kubeovnv1.SgRemoteTypeAddress, RemoteAddress: "10.0.0.1"},
+			},
+			EgressRules: []*kubeovnv1.SgRule{
+				{RemoteType: kubeovnv1.SgRemoteTypeFqdn, RemoteAddress: "Example.com."},
+				{RemoteType: kubeovnv1.SgRemoteTypeAddress, RemoteAddress: "10.0.0.0/8"},
+				{RemoteType: kubeovnv1.SgRemoteTypeFqdn, RemoteAddress: "*.example.com"},
+				{RemoteType: kubeovnv1.SgRemoteTypeFqdn, RemoteAddress: "example.com"},
+			},
+		},
+	}
+	require.ElementsMatch(t, []string{"example.com", "*.example.com"}, sgEgressFqdns(sg))
+}
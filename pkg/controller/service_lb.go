@@ -221,8 +221,8 @@ func (c *Controller) validateSvc(svc *corev1.Service) error {
 			return err
 		}
 
-		if svc.Spec.LoadBalancerIP != "" && !util.CIDRContainIP(subnet.Spec.CIDRBlock, svc.Spec.LoadBalancerIP) {
-			return fmt.Errorf("the loadbalancer IP %s is not in the range of subnet %s, cidr %v", svc.Spec.LoadBalancerIP, subnet.Name, subnet.Spec.CIDRBlock)
+		if svc.Spec.LoadBalancerIP != "" && !util.SubnetContainIP(subnet, svc.Spec.LoadBalancerIP) {
+			return fmt.Errorf("the loadbalancer IP %s is not in the range of subnet %s, cidr %v", svc.Spec.LoadBalancerIP, subnet.Name, util.GetSubnetCIDRBlocks(subnet))
 		}
 	}
 	return nil
@@ -0,0 +1,333 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+const (
+	iptablesSnatRuleKind = "IptablesSnatRule"
+	ovnSnatRuleKind      = "OvnSnatRule"
+)
+
+// snatPortRange is the eip ports used by the port blocks of a snat
+type snatPortRange struct {
+	eip        string
+	start, end int
+}
+
+// snatPortBlockRule is a snat on the eip, the port range is the one recorded in status
+type snatPortBlockRule struct {
+	name          string
+	portBlockSize int32
+	portRange     snatPortRange
+}
+
+// newSnatPortBlockRule returns the snat with the port range recorded in status, the snats created
+// before the port range is recorded in status use the ports starting at util.SnatPortBlockMinPort
+func newSnatPortBlockRule(name, eip string, portBlockSize, statusPortBlockSize, statusPortBlockStart int32, statusCIDR string) snatPortBlockRule {
+	rule := snatPortBlockRule{name: name, portBlockSize: portBlockSize}
+	if statusPortBlockSize == 0 || statusCIDR == "" {
+		return rule
+	}
+	ports, err := util.SnatPortBlockPorts(statusCIDR, int(statusPortBlockSize))
+	if err != nil {
+		return rule
+	}
+	start := int(statusPortBlockStart)
+	if start == 0 {
+		start = util.SnatPortBlockMinPort
+	}
+	rule.portRange = snatPortRange{eip: eip, start: start, end: start + ports - 1}
+	return rule
+}
+
+// snatPortRanges reserves the eip ports of the snats in port block mode. The port blocks of all the snats
+// on an eip are allocated from the ports of the eip, so that they never overlap. The reservations are
+// keyed by the kind and name of the snat, the ones made before the controller restarts are recorded in status.
+type snatPortRanges struct {
+	mutex  sync.Mutex
+	ranges map[string]snatPortRange
+}
+
+func newSnatPortRanges() *snatPortRanges {
+	return &snatPortRanges{ranges: make(map[string]snatPortRange)}
+}
+
+func snatPortRangeKey(kind, name string) string {
+	return kind + "/" + name
+}
+
+// allocate returns the first eip port of the port blocks of the snat which uses ports eip ports, the range
+// allocated before is kept unless it overlaps with the one of another snat on the eip
+func (r *snatPortRanges) allocate(kind, eip string, snat snatPortBlockRule, ports int, others []snatPortBlockRule) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	used := make([]snatPortRange, 0, len(others))
+	for _, other := range others {
+		portRange, ok := r.ranges[snatPortRangeKey(kind, other.name)]
+		if !ok {
+			portRange = other.portRange
+		}
+		if portRange.eip == eip {
+			used = append(used, portRange)
+		}
+	}
+	slices.SortFunc(used, func(a, b snatPortRange) int { return a.start - b.start })
+	isFree := func(start int) bool {
+		if start < util.SnatPortBlockMinPort || start+ports-1 > util.SnatPortBlockMaxPort {
+			return false
+		}
+		for _, u := range used {
+			if start <= u.end && u.start <= start+ports-1 {
+				return false
+			}
+		}
+		return true
+	}
+
+	key := snatPortRangeKey(kind, snat.name)
+	preferred := snat.portRange
+	if portRange, ok := r.ranges[key]; ok {
+		preferred = portRange
+	}
+	if preferred.eip == eip && isFree(preferred.start) {
+		r.ranges[key] = snatPortRange{eip: eip, start: preferred.start, end: preferred.start + ports - 1}
+		return preferred.start, nil
+	}
+	start := util.SnatPortBlockMinPort
+	for _, u := range used {
+		if start+ports-1 < u.start {
+			break
+		}
+		start = max(start, u.end+1)
+	}
+	if !isFree(start) {
+		return 0, fmt.Errorf("no %d consecutive ports are available on eip %s", ports, eip)
+	}
+	r.ranges[key] = snatPortRange{eip: eip, start: start, end: start + ports - 1}
+	return start, nil
+}
+
+// get returns the port range reserved for the snat
+func (r *snatPortRanges) get(kind, name string) (snatPortRange, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	portRange, ok := r.ranges[snatPortRangeKey(kind, name)]
+	return portRange, ok
+}
+
+// release drops the reservation of the snat which is deleted or not in port block mode
+func (r *snatPortRanges) release(kind, name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.ranges, snatPortRangeKey(kind, name))
+}
+
+// allocateSnatPortBlocks checks that either all or none of the snats on the eip are in port block mode, and allocates
+// the eip ports of the port blocks of the snat. The first eip port of the port blocks is returned, 0 if the port
+// block mode is disabled.
+func (c *Controller) allocateSnatPortBlocks(kind, eip, internalCIDR string, snat snatPortBlockRule, others []snatPortBlockRule) (int, error) {
+	for _, other := range others {
+		if (snat.portBlockSize == 0) != (other.portBlockSize == 0) {
+			err := fmt.Errorf("%s %s and %s on eip %s must be both in or both not in port block mode", kind, snat.name, other.name, eip)
+			klog.Error(err)
+			return 0, err
+		}
+	}
+	if snat.portBlockSize == 0 {
+		c.snatPortRanges.release(kind, snat.name)
+		return 0, nil
+	}
+
+	ports, err := util.SnatPortBlockPorts(internalCIDR, int(snat.portBlockSize))
+	if err != nil {
+		klog.Error(err)
+		return 0, err
+	}
+	start, err := c.snatPortRanges.allocate(kind, eip, snat, ports, others)
+	if err != nil {
+		klog.Errorf("failed to allocate port blocks for %s %s: %v", kind, snat.name, err)
+		return 0, err
+	}
+	if start != snat.portRange.start {
+		klog.Infof("allocate eip %s ports %d-%d to the port blocks of %s %s", eip, start, start+ports-1, kind, snat.name)
+	}
+	return start, nil
+}
+
+// allocateOvnSnatPortBlocks allocates the eip ports of the port blocks of the ovn snat
+func (c *Controller) allocateOvnSnatPortBlocks(snat *kubeovnv1.OvnSnatRule, internalCIDR string) (int, error) {
+	snats, err := c.ovnSnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list ovn snats: %v", err)
+		return 0, err
+	}
+	newRule := func(s *kubeovnv1.OvnSnatRule) snatPortBlockRule {
+		return newSnatPortBlockRule(s.Name, s.Spec.OvnEip, s.Spec.PortBlockSize, s.Status.PortBlockSize, s.Status.PortBlockStart, s.Status.V4IpCidr)
+	}
+	var others []snatPortBlockRule
+	for _, s := range snats {
+		if s.Name != snat.Name && s.Spec.OvnEip == snat.Spec.OvnEip {
+			others = append(others, newRule(s))
+		}
+	}
+	return c.allocateSnatPortBlocks(ovnSnatRuleKind, snat.Spec.OvnEip, internalCIDR, newRule(snat), others)
+}
+
+// allocateIptablesSnatPortBlocks allocates the eip ports of the port blocks of the iptables snat
+func (c *Controller) allocateIptablesSnatPortBlocks(snat *kubeovnv1.IptablesSnatRule, internalCIDR string) (int, error) {
+	snats, err := c.iptablesSnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list iptables snats: %v", err)
+		return 0, err
+	}
+	newRule := func(s *kubeovnv1.IptablesSnatRule) snatPortBlockRule {
+		v4Cidr, _ := util.SplitStringIP(s.Status.InternalCIDR)
+		return newSnatPortBlockRule(s.Name, s.Spec.EIP, s.Spec.PortBlockSize, s.Status.PortBlockSize, s.Status.PortBlockStart, v4Cidr)
+	}
+	var others []snatPortBlockRule
+	for _, s := range snats {
+		if s.Name != snat.Name && s.Spec.EIP == snat.Spec.EIP {
+			others = append(others, newRule(s))
+		}
+	}
+	return c.allocateSnatPortBlocks(iptablesSnatRuleKind, snat.Spec.EIP, internalCIDR, newRule(snat), others)
+}
+
+// snatPortBlockStart returns the first eip port of the port blocks of the snat, the one reserved
+// in memory is preferred to the one recorded in status
+func (c *Controller) snatPortBlockStart(kind, name string, statusPortBlockStart int32) int {
+	if portRange, ok := c.snatPortRanges.get(kind, name); ok {
+		return portRange.start
+	}
+	if statusPortBlockStart != 0 {
+		return int(statusPortBlockStart)
+	}
+	return util.SnatPortBlockMinPort
+}
+
+// snatPortBlockConfigMapName returns the name of the config map holding the port block allocation table of the snat,
+// the keys of the table are the internal ips and the values are the eip port ranges like 172.18.0.10:1024-2047
+func snatPortBlockConfigMapName(kind, name string) string {
+	return fmt.Sprintf("snat-port-blocks-%s-%s", strings.ToLower(kind), name)
+}
+
+// snatPortBlockTable returns the allocation table of the port blocks on the eip
+func snatPortBlockTable(eip string, blocks []util.SnatPortBlock) map[string]string {
+	table := make(map[string]string, len(blocks))
+	for _, block := range blocks {
+		table[block.InternalIP] = fmt.Sprintf("%s:%s", eip, block.PortRange())
+	}
+	return table
+}
+
+// recordSnatPortBlocks saves the port blocks allocated to the snat in the allocation table and logs the mapping
+// of each block assigned or released, the table is owned by the snat and garbage collected with it
+func (c *Controller) recordSnatPortBlocks(owner client.Object, kind, eip string, blocks []util.SnatPortBlock) error {
+	name := snatPortBlockConfigMapName(kind, owner.GetName())
+	table := snatPortBlockTable(eip, blocks)
+	cm, err := c.configMapsLister.ConfigMaps(c.config.PodNamespace).Get(name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Error(err)
+			return err
+		}
+		if len(table) == 0 {
+			return nil
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       c.config.PodNamespace,
+				Labels:          map[string]string{util.SnatPortBlockLabel: kind},
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(owner, kubeovnv1.SchemeGroupVersion.WithKind(kind))},
+			},
+			Data: table,
+		}
+		if _, err = c.config.KubeClient.CoreV1().ConfigMaps(c.config.PodNamespace).Create(context.Background(), cm, metav1.CreateOptions{}); err != nil {
+			klog.Errorf("failed to create snat port block table %s: %v", name, err)
+			return err
+		}
+		c.logSnatPortBlocks(owner, kind, nil, table)
+		return nil
+	}
+	if maps.Equal(cm.Data, table) {
+		return nil
+	}
+
+	newCm := cm.DeepCopy()
+	newCm.Data = table
+	if _, err = c.config.KubeClient.CoreV1().ConfigMaps(c.config.PodNamespace).Update(context.Background(), newCm, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("failed to update snat port block table %s: %v", name, err)
+		return err
+	}
+	c.logSnatPortBlocks(owner, kind, cm.Data, table)
+	return nil
+}
+
+// releaseSnatPortBlocks releases all the port blocks of the snat, e.g. when the snat is deleted
+// or the port block mode is disabled
+func (c *Controller) releaseSnatPortBlocks(owner client.Object, kind string) error {
+	if err := c.recordSnatPortBlocks(owner, kind, "", nil); err != nil {
+		return err
+	}
+	c.snatPortRanges.release(kind, owner.GetName())
+	return nil
+}
+
+// syncSnatPortBlocks records the port blocks allocated to the internal ips of the snat,
+// or releases them if the port block mode is disabled
+func (c *Controller) syncSnatPortBlocks(owner client.Object, kind string, portBlockSize int32, portBlockStart int, eip, internalCIDR string) error {
+	if portBlockSize == 0 {
+		return c.releaseSnatPortBlocks(owner, kind)
+	}
+	blocks, err := util.AllocateSnatPortBlocks(internalCIDR, int(portBlockSize), portBlockStart)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	return c.recordSnatPortBlocks(owner, kind, eip, blocks)
+}
+
+func (c *Controller) logSnatPortBlocks(owner client.Object, kind string, oldTable, newTable map[string]string) {
+	var assigned, released int
+	for _, ip := range sortedKeys(oldTable) {
+		if newTable[ip] != oldTable[ip] {
+			klog.Infof("snat port block released: %s %s, internal ip %s, external %s", kind, owner.GetName(), ip, oldTable[ip])
+			released++
+		}
+	}
+	for _, ip := range sortedKeys(newTable) {
+		if oldTable[ip] != newTable[ip] {
+			klog.Infof("snat port block assigned: %s %s, internal ip %s, external %s", kind, owner.GetName(), ip, newTable[ip])
+			assigned++
+		}
+	}
+	c.recorder.Eventf(owner, corev1.EventTypeNormal, "SnatPortBlocksChanged", "%d port blocks assigned and %d released, see config map %s/%s",
+		assigned, released, c.config.PodNamespace, snatPortBlockConfigMapName(kind, owner.GetName()))
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func Test_newSnatPortBlockRule(t *testing.T) {
+	t.Parallel()
+
+	rule := newSnatPortBlockRule("snat1", "eip1", 100, 100, 2000, "10.0.1.0/30")
+	require.Equal(t, snatPortBlockRule{name: "snat1", portBlockSize: 100, portRange: snatPortRange{eip: "eip1", start: 2000, end: 2399}}, rule)
+
+	// the port blocks allocated before the port range is recorded in status start at the min port
+	rule = newSnatPortBlockRule("snat1", "eip1", 100, 100, 0, "10.0.1.0/30")
+	require.Equal(t, snatPortRange{eip: "eip1", start: util.SnatPortBlockMinPort, end: util.SnatPortBlockMinPort + 399}, rule.portRange)
+
+	// the port block mode is not applied yet
+	rule = newSnatPortBlockRule("snat1", "eip1", 100, 0, 0, "")
+	require.Equal(t, snatPortBlockRule{name: "snat1", portBlockSize: 100}, rule)
+}
+
+func Test_snatPortRangesAllocate(t *testing.T) {
+	t.Parallel()
+
+	r := newSnatPortRanges()
+	snat1 := newSnatPortBlockRule("snat1", "eip1", 100, 100, 0, "10.0.1.0/30")
+	snat2 := newSnatPortBlockRule("snat2", "eip1", 100, 0, 0, "")
+	snat3 := newSnatPortBlockRule("snat3", "eip2", 100, 0, 0, "")
+
+	// the port range recorded in status is kept
+	start, err := r.allocate(ovnSnatRuleKind, "eip1", snat1, 400, []snatPortBlockRule{snat2, snat3})
+	require.NoError(t, err)
+	require.Equal(t, util.SnatPortBlockMinPort, start)
+
+	// the port blocks of the snats on the same eip never overlap
+	start, err = r.allocate(ovnSnatRuleKind, "eip1", snat2, 200, []snatPortBlockRule{snat1, snat3})
+	require.NoError(t, err)
+	require.Equal(t, util.SnatPortBlockMinPort+400, start)
+
+	// the reservation which is not recorded in status yet is kept
+	start, err = r.allocate(ovnSnatRuleKind, "eip1", snat2, 200, []snatPortBlockRule{snat1, snat3})
+	require.NoError(t, err)
+	require.Equal(t, util.SnatPortBlockMinPort+400, start)
+
+	// the snats on other eips and of other kinds use the ports independently
+	start, err = r.allocate(ovnSnatRuleKind, "eip2", snat3, 100, []snatPortBlockRule{snat1, snat2})
+	require.NoError(t, err)
+	require.Equal(t, util.SnatPortBlockMinPort, start)
+	start, err = r.allocate(iptablesSnatRuleKind, "eip1", snat1, 100, nil)
+	require.NoError(t, err)
+	require.Equal(t, util.SnatPortBlockMinPort, start)
+
+	// the gap left by the released snat is reused
+	r.release(ovnSnatRuleKind, "snat1")
+	_, ok := r.get(ovnSnatRuleKind, "snat1")
+	require.False(t, ok)
+	snat4 := newSnatPortBlockRule("snat4", "eip1", 100, 0, 0, "")
+	start, err = r.allocate(ovnSnatRuleKind, "eip1", snat4, 300, []snatPortBlockRule{snat2})
+	require.NoError(t, err)
+	require.Equal(t, util.SnatPortBlockMinPort, start)
+
+	// the legacy snats recorded in status overlap, the one processed later is moved
+	legacy1 := newSnatPortBlockRule("legacy1", "eip3", 100, 100, 0, "10.0.1.0/31")
+	legacy2 := newSnatPortBlockRule("legacy2", "eip3", 100, 100, 0, "10.0.2.0/31")
+	start, err = r.allocate(ovnSnatRuleKind, "eip3", legacy2, 200, []snatPortBlockRule{legacy1})
+	require.NoError(t, err)
+	require.Equal(t, util.SnatPortBlockMinPort+200, start)
+
+	// all of the ports are used
+	_, err = r.allocate(ovnSnatRuleKind, "eip1", newSnatPortBlockRule("snat5", "eip1", 100, 0, 0, ""),
+		util.SnatPortBlockMaxPort-util.SnatPortBlockMinPort, []snatPortBlockRule{snat2, snat4})
+	require.Error(t, err)
+}
+
+func Test_allocateSnatPortBlocks(t *testing.T) {
+	t.Parallel()
+
+	c := &Controller{snatPortRanges: newSnatPortRanges()}
+	plain := newSnatPortBlockRule("plain", "eip1", 0, 0, 0, "")
+	blocks := newSnatPortBlockRule("blocks", "eip1", 100, 0, 0, "")
+
+	// either all or none of the snats on the eip are in port block mode
+	_, err := c.allocateSnatPortBlocks(ovnSnatRuleKind, "eip1", "10.0.1.0/30", blocks, []snatPortBlockRule{plain})
+	require.Error(t, err)
+	_, err = c.allocateSnatPortBlocks(ovnSnatRuleKind, "eip1", "10.0.2.0/24", plain, []snatPortBlockRule{blocks})
+	require.Error(t, err)
+
+	start, err := c.allocateSnatPortBlocks(ovnSnatRuleKind, "eip1", "10.0.1.0/30", blocks, nil)
+	require.NoError(t, err)
+	require.Equal(t, util.SnatPortBlockMinPort, start)
+	require.Equal(t, util.SnatPortBlockMinPort, c.snatPortBlockStart(ovnSnatRuleKind, "blocks", 0))
+
+	// the reservation is released once the port block mode is disabled
+	blocks.portBlockSize = 0
+	start, err = c.allocateSnatPortBlocks(ovnSnatRuleKind, "eip1", "10.0.1.0/30", blocks, []snatPortBlockRule{plain})
+	require.NoError(t, err)
+	require.Zero(t, start)
+	_, ok := c.snatPortRanges.get(ovnSnatRuleKind, "blocks")
+	require.False(t, ok)
+	require.Equal(t, 3000, c.snatPortBlockStart(ovnSnatRuleKind, "blocks", 3000))
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -95,6 +96,7 @@ func (c *Controller) enqueueUpdateSubnet(oldObj, newObj interface{}) {
 
 	if oldSubnet.Spec.Private != newSubnet.Spec.Private ||
 		oldSubnet.Spec.CIDRBlock != newSubnet.Spec.CIDRBlock ||
+		!reflect.DeepEqual(oldSubnet.Spec.SecondaryCIDRBlocks, newSubnet.Spec.SecondaryCIDRBlocks) ||
 		!reflect.DeepEqual(oldSubnet.Spec.AllowSubnets, newSubnet.Spec.AllowSubnets) ||
 		!reflect.DeepEqual(oldSubnet.Spec.Namespaces, newSubnet.Spec.Namespaces) ||
 		oldSubnet.Spec.GatewayType != newSubnet.Spec.GatewayType ||
@@ -416,6 +418,18 @@ func checkAndUpdateCIDR(subnet *kubeovnv1.Subnet) (bool, error) {
 		cidrBlocks = append(cidrBlocks, ipNet.String())
 	}
 	subnet.Spec.CIDRBlock = strings.Join(cidrBlocks, ",")
+
+	for i, cidr := range subnet.Spec.SecondaryCIDRBlocks {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			klog.Error(err)
+			return false, fmt.Errorf("subnet %s secondary cidr %s is invalid", subnet.Name, cidr)
+		}
+		if ipNet.String() != cidr {
+			subnet.Spec.SecondaryCIDRBlocks[i] = ipNet.String()
+			changed = true
+		}
+	}
 	return changed, nil
 }
 
@@ -453,6 +467,15 @@ func checkAndUpdateExcludeIPs(subnet *kubeovnv1.Subnet) bool {
 		excludeIPs []string
 	)
 	excludeIPs = append(excludeIPs, strings.Split(subnet.Spec.Gateway, ",")...)
+	if len(subnet.Spec.SecondaryCIDRBlocks) != 0 {
+		// the first address of each secondary cidr block is used as its gateway
+		gws, err := util.GetGwByCidr(strings.Join(subnet.Spec.SecondaryCIDRBlocks, ","))
+		if err != nil {
+			klog.Error(err)
+		} else {
+			excludeIPs = append(excludeIPs, strings.Split(gws, ",")...)
+		}
+	}
 	sort.Strings(excludeIPs)
 	if len(subnet.Spec.ExcludeIps) == 0 {
 		subnet.Spec.ExcludeIps = excludeIPs
@@ -584,8 +607,8 @@ func (c *Controller) checkSubnetConflict(subnet *kubeovnv1.Subnet) error {
 			continue
 		}
 
-		if util.CIDROverlap(sub.Spec.CIDRBlock, subnet.Spec.CIDRBlock) {
-			err = fmt.Errorf("subnet %s cidr %s is conflict with subnet %s cidr %s", subnet.Name, subnet.Spec.CIDRBlock, sub.Name, sub.Spec.CIDRBlock)
+		if cidrBlocks, subCIDRBlocks := util.GetSubnetCIDRBlocks(subnet), util.GetSubnetCIDRBlocks(sub); util.CIDROverlap(subCIDRBlocks, cidrBlocks) {
+			err = fmt.Errorf("subnet %s cidr %s is conflict with subnet %s cidr %s", subnet.Name, cidrBlocks, sub.Name, subCIDRBlocks)
 			klog.Error(err)
 			c.patchSubnetStatus(subnet, "ValidateLogicalSwitchFailed", err.Error())
 			return err
@@ -606,13 +629,19 @@ func (c *Controller) checkSubnetConflict(subnet *kubeovnv1.Subnet) error {
 			klog.Errorf("failed to list nodes: %v", err)
 			return err
 		}
+		cidrBlocks := strings.Split(util.GetSubnetCIDRBlocks(subnet), ",")
 		for _, node := range nodes {
 			for _, addr := range node.Status.Addresses {
-				if addr.Type == v1.NodeInternalIP && util.CIDRContainIP(subnet.Spec.CIDRBlock, addr.Address) {
-					err = fmt.Errorf("subnet %s cidr %s conflict with node %s address %s", subnet.Name, subnet.Spec.CIDRBlock, node.Name, addr.Address)
-					klog.Error(err)
-					c.patchSubnetStatus(subnet, "ValidateLogicalSwitchFailed", err.Error())
-					return err
+				if addr.Type != v1.NodeInternalIP {
+					continue
+				}
+				for _, cidr := range cidrBlocks {
+					if util.CIDRContainIP(cidr, addr.Address) {
+						err = fmt.Errorf("subnet %s cidr %s conflict with node %s address %s", subnet.Name, cidr, node.Name, addr.Address)
+						klog.Error(err)
+						c.patchSubnetStatus(subnet, "ValidateLogicalSwitchFailed", err.Error())
+						return err
+					}
 				}
 			}
 		}
@@ -726,7 +755,11 @@ func (c *Controller) handleAddOrUpdateSubnet(key string) error {
 		return err
 	}
 
-	if err := c.ipam.AddOrUpdateSubnet(subnet.Name, subnet.Spec.CIDRBlock, subnet.Spec.Gateway, subnet.Spec.ExcludeIps); err != nil {
+	if err := c.ipam.AddOrUpdateSubnet(subnet.Name, subnet.Spec.CIDRBlock, subnet.Spec.Gateway, subnet.Spec.ExcludeIps, subnet.Spec.SecondaryCIDRBlocks...); err != nil {
+		return err
+	}
+	if err := c.ipam.SetSubnetAllocationStrategy(subnet.Name, subnet.Spec.IPAllocationStrategy, time.Duration(subnet.Spec.IPReleaseCooldown)*time.Second); err != nil {
+		klog.Error(err)
 		return err
 	}
 
@@ -769,6 +802,17 @@ func (c *Controller) handleAddOrUpdateSubnet(key string) error {
 	if subnet.Status.U2OInterconnectionIP != "" && subnet.Spec.U2OInterconnection {
 		gateway = subnet.Status.U2OInterconnectionIP
 	}
+	cidrBlock := subnet.Spec.CIDRBlock
+	if len(subnet.Spec.SecondaryCIDRBlocks) != 0 {
+		// logical router port networks include the gateways of all secondary cidr blocks
+		secondaryGateways, err := util.GetGwByCidr(strings.Join(subnet.Spec.SecondaryCIDRBlocks, ","))
+		if err != nil {
+			klog.Error(err)
+			return err
+		}
+		cidrBlock = util.GetSubnetCIDRBlocks(subnet)
+		gateway = gateway + "," + secondaryGateways
+	}
 
 	if err := c.clearOldU2OResource(subnet); err != nil {
 		klog.Errorf("clear subnet %s old u2o resource failed: %v", subnet.Name, err)
@@ -776,7 +820,7 @@ func (c *Controller) handleAddOrUpdateSubnet(key string) error {
 	}
 
 	// create or update logical switch
-	if err := c.OVNNbClient.CreateLogicalSwitch(subnet.Name, vpc.Status.Router, subnet.Spec.CIDRBlock, gateway, needRouter, randomAllocateGW); err != nil {
+	if err := c.OVNNbClient.CreateLogicalSwitch(subnet.Name, vpc.Status.Router, cidrBlock, gateway, needRouter, randomAllocateGW); err != nil {
 		klog.Errorf("create logical switch %s: %v", subnet.Name, err)
 		return err
 	}
@@ -839,7 +883,7 @@ func (c *Controller) handleAddOrUpdateSubnet(key string) error {
 	}
 
 	if subnet.Spec.Private {
-		if err := c.OVNNbClient.SetLogicalSwitchPrivate(subnet.Name, subnet.Spec.CIDRBlock, c.config.NodeSwitchCIDR, subnet.Spec.AllowSubnets); err != nil {
+		if err := c.OVNNbClient.SetLogicalSwitchPrivate(subnet.Name, util.GetSubnetCIDRBlocks(subnet), c.config.NodeSwitchCIDR, subnet.Spec.AllowSubnets); err != nil {
 			c.patchSubnetStatus(subnet, "SetPrivateLogicalSwitchFailed", err.Error())
 			return err
 		}
@@ -855,12 +899,16 @@ func (c *Controller) handleAddOrUpdateSubnet(key string) error {
 		c.patchSubnetStatus(subnet, "ResetLogicalSwitchAclSuccess", "")
 	}
 
-	if err := c.OVNNbClient.UpdateLogicalSwitchACL(subnet.Name, subnet.Spec.CIDRBlock, subnet.Spec.Acls, subnet.Spec.AllowEWTraffic); err != nil {
+	if err := c.OVNNbClient.UpdateLogicalSwitchACL(subnet.Name, util.GetSubnetCIDRBlocks(subnet), subnet.Spec.Acls, subnet.Spec.AllowEWTraffic); err != nil {
 		c.patchSubnetStatus(subnet, "SetLogicalSwitchAclsFailed", err.Error())
 		return err
 	}
 
 	c.updateVpcStatusQueue.Add(subnet.Spec.Vpc)
+	if len(subnet.Spec.SecondaryCIDRBlocks) != 0 {
+		// capacity of the secondary cidr blocks is only known after ipam is updated
+		c.updateSubnetStatusQueue.Add(subnet.Name)
+	}
 
 	ippools, err := c.ippoolLister.List(labels.Everything())
 	if err != nil {
@@ -902,6 +950,17 @@ func (c *Controller) handleUpdateSubnetStatus(key string) error {
 		}
 	}
 
+	quotas, err := c.ipQuotaLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list ip quota: %v", err)
+		return err
+	}
+	for _, q := range quotas {
+		if q.Spec.Subnet == subnet.Name {
+			c.updateIPQuotaStatusQueue.Add(q.Name)
+		}
+	}
+
 	if util.CheckProtocol(subnet.Spec.CIDRBlock) == kubeovnv1.ProtocolDual {
 		return calcDualSubnetStatusIP(subnet, c)
 	}
@@ -1287,30 +1346,35 @@ func (c *Controller) reconcileCustomVpcBfdStaticRoute(vpcName, subnetName string
 			return err
 		}
 		// TODO:// support v6
-		v4Exist = false
-		for _, route := range vpc.Spec.StaticRoutes {
-			if route.Policy == kubeovnv1.PolicySrc &&
-				route.NextHopIP == eip.Status.V4Ip &&
-				route.ECMPMode == util.StaticRouteBfdEcmp &&
-				route.CIDR == subnet.Spec.CIDRBlock &&
-				route.RouteTable == subnet.Spec.RouteTable {
-				v4Exist = true
-				break
+		for _, cidr := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
+			if util.CheckProtocol(cidr) != kubeovnv1.ProtocolIPv4 {
+				continue
 			}
-		}
-		if !v4Exist {
-			// add ecmp type static route with bfd
-			route := &kubeovnv1.StaticRoute{
-				Policy:     kubeovnv1.PolicySrc,
-				CIDR:       subnet.Spec.CIDRBlock,
-				NextHopIP:  eip.Status.V4Ip,
-				ECMPMode:   util.StaticRouteBfdEcmp,
-				BfdID:      bfd.UUID,
-				RouteTable: subnet.Spec.RouteTable,
+			v4Exist = false
+			for _, route := range vpc.Spec.StaticRoutes {
+				if route.Policy == kubeovnv1.PolicySrc &&
+					route.NextHopIP == eip.Status.V4Ip &&
+					route.ECMPMode == util.StaticRouteBfdEcmp &&
+					route.CIDR == cidr &&
+					route.RouteTable == subnet.Spec.RouteTable {
+					v4Exist = true
+					break
+				}
+			}
+			if !v4Exist {
+				// add ecmp type static route with bfd
+				route := &kubeovnv1.StaticRoute{
+					Policy:     kubeovnv1.PolicySrc,
+					CIDR:       cidr,
+					NextHopIP:  eip.Status.V4Ip,
+					ECMPMode:   util.StaticRouteBfdEcmp,
+					BfdID:      bfd.UUID,
+					RouteTable: subnet.Spec.RouteTable,
+				}
+				klog.Infof("add ecmp bfd static route %v", route)
+				vpc.Spec.StaticRoutes = append(vpc.Spec.StaticRoutes, route)
+				needUpdate = true
 			}
-			klog.Infof("add ecmp bfd static route %v", route)
-			vpc.Spec.StaticRoutes = append(vpc.Spec.StaticRoutes, route)
-			needUpdate = true
 		}
 	}
 	if needUpdate {
@@ -2007,8 +2071,8 @@ func calcDualSubnetStatusIP(subnet *kubeovnv1.Subnet, c *Controller) error {
 	v6toSubIPs := util.ExpandExcludeIPs(v6ExcludeIPs, cidrBlocks[1])
 	_, v4CIDR, _ := net.ParseCIDR(cidrBlocks[0])
 	_, v6CIDR, _ := net.ParseCIDR(cidrBlocks[1])
-	v4availableIPs := util.AddressCount(v4CIDR) - util.CountIPNums(v4toSubIPs)
-	v6availableIPs := util.AddressCount(v6CIDR) - util.CountIPNums(v6toSubIPs)
+	v4availableIPs := util.AddressCount(v4CIDR) - util.CountIPNums(v4toSubIPs) + secondaryCIDRAddressCount(subnet, kubeovnv1.ProtocolIPv4)
+	v6availableIPs := util.AddressCount(v6CIDR) - util.CountIPNums(v6toSubIPs) + secondaryCIDRAddressCount(subnet, kubeovnv1.ProtocolIPv6)
 
 	usingIPs := float64(len(podUsedIPs))
 
@@ -2041,6 +2105,7 @@ func calcDualSubnetStatusIP(subnet *kubeovnv1.Subnet, c *Controller) error {
 	}
 
 	v4UsingIPStr, v6UsingIPStr, v4AvailableIPStr, v6AvailableIPStr := c.ipam.GetSubnetIPRangeString(subnet.Name)
+	cidrs := c.getSubnetCIDRStatus(subnet.Name)
 
 	if subnet.Status.V4AvailableIPs == v4availableIPs &&
 		subnet.Status.V6AvailableIPs == v6availableIPs &&
@@ -2049,7 +2114,8 @@ func calcDualSubnetStatusIP(subnet *kubeovnv1.Subnet, c *Controller) error {
 		subnet.Status.V4UsingIPRange == v4UsingIPStr &&
 		subnet.Status.V6UsingIPRange == v6UsingIPStr &&
 		subnet.Status.V4AvailableIPRange == v4AvailableIPStr &&
-		subnet.Status.V6AvailableIPRange == v6AvailableIPStr {
+		subnet.Status.V6AvailableIPRange == v6AvailableIPStr &&
+		reflect.DeepEqual(subnet.Status.CIDRs, cidrs) {
 		return nil
 	}
 
@@ -2061,6 +2127,7 @@ func calcDualSubnetStatusIP(subnet *kubeovnv1.Subnet, c *Controller) error {
 	subnet.Status.V6UsingIPRange = v6UsingIPStr
 	subnet.Status.V4AvailableIPRange = v4AvailableIPStr
 	subnet.Status.V6AvailableIPRange = v6AvailableIPStr
+	subnet.Status.CIDRs = cidrs
 
 	bytes, err := subnet.Status.Bytes()
 	if err != nil {
@@ -2084,7 +2151,7 @@ func calcSubnetStatusIP(subnet *kubeovnv1.Subnet, c *Controller) error {
 	}
 	// gateway always in excludeIPs
 	toSubIPs := util.ExpandExcludeIPs(subnet.Spec.ExcludeIps, subnet.Spec.CIDRBlock)
-	availableIPs := util.AddressCount(cidr) - util.CountIPNums(toSubIPs)
+	availableIPs := util.AddressCount(cidr) - util.CountIPNums(toSubIPs) + secondaryCIDRAddressCount(subnet, util.CheckProtocol(subnet.Spec.CIDRBlock))
 	usingIPs := float64(len(podUsedIPs))
 	vips, err := c.virtualIpsLister.List(labels.SelectorFromSet(labels.Set{
 		util.SubnetNameLabel: subnet.Name,
@@ -2123,6 +2190,8 @@ func calcSubnetStatusIP(subnet *kubeovnv1.Subnet, c *Controller) error {
 		subnet.Status.V6UsingIPRange,
 		subnet.Status.V6AvailableIPRange,
 	}
+	cachedCIDRs := subnet.Status.CIDRs
+	subnet.Status.CIDRs = c.getSubnetCIDRStatus(subnet.Name)
 
 	if subnet.Spec.Protocol == kubeovnv1.ProtocolIPv4 {
 		subnet.Status.V4AvailableIPs = availableIPs
@@ -2149,7 +2218,7 @@ func calcSubnetStatusIP(subnet *kubeovnv1.Subnet, c *Controller) error {
 		subnet.Status.V4AvailableIPRange,
 		subnet.Status.V6UsingIPRange,
 		subnet.Status.V6AvailableIPRange,
-	} {
+	} && reflect.DeepEqual(cachedCIDRs, subnet.Status.CIDRs) {
 		return nil
 	}
 
@@ -2162,6 +2231,41 @@ func calcSubnetStatusIP(subnet *kubeovnv1.Subnet, c *Controller) error {
 	return err
 }
 
+// secondaryCIDRAddressCount returns the number of assignable addresses in the secondary cidr blocks of the protocol
+func secondaryCIDRAddressCount(subnet *kubeovnv1.Subnet, protocol string) float64 {
+	var count float64
+	for _, cidrBlock := range subnet.Spec.SecondaryCIDRBlocks {
+		if util.CheckProtocol(cidrBlock) != protocol {
+			continue
+		}
+		_, cidr, err := net.ParseCIDR(cidrBlock)
+		if err != nil {
+			klog.Error(err)
+			continue
+		}
+		count += util.AddressCount(cidr) - util.CountIPNums(util.ExpandExcludeIPs(subnet.Spec.ExcludeIps, cidrBlock))
+	}
+	return count
+}
+
+func (c *Controller) getSubnetCIDRStatus(subnetName string) []kubeovnv1.SubnetCIDRStatus {
+	stats := c.ipam.CIDRStatistics(subnetName)
+	if len(stats) == 0 {
+		return nil
+	}
+	ret := make([]kubeovnv1.SubnetCIDRStatus, 0, len(stats))
+	for _, stat := range stats {
+		ret = append(ret, kubeovnv1.SubnetCIDRStatus{
+			CIDR:         stat.CIDR,
+			Gateway:      stat.Gateway,
+			Secondary:    stat.Secondary,
+			AvailableIPs: stat.Available.Float64(),
+			UsingIPs:     stat.Using.Float64(),
+		})
+	}
+	return ret
+}
+
 func isOvnSubnet(subnet *kubeovnv1.Subnet) bool {
 	return subnet.Spec.Provider == "" || subnet.Spec.Provider == util.OvnProvider || strings.HasSuffix(subnet.Spec.Provider, "ovn")
 }
@@ -2262,20 +2366,14 @@ func (c *Controller) checkGwNodeExists(gatewayNode string) bool {
 }
 
 func (c *Controller) addCommonRoutesForSubnet(subnet *kubeovnv1.Subnet) error {
-	for _, cidr := range strings.Split(subnet.Spec.CIDRBlock, ",") {
+	for _, cidr := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
 		if cidr == "" {
 			continue
 		}
 
-		var gateway string
 		protocol := util.CheckProtocol(cidr)
-		for _, gw := range strings.Split(subnet.Spec.Gateway, ",") {
-			if util.CheckProtocol(gw) == protocol {
-				gateway = gw
-				break
-			}
-		}
-		if gateway == "" {
+		if _, err := util.GetSubnetCIDRBlockGateway(subnet, cidr); err != nil {
+			klog.Error(err)
 			return fmt.Errorf("failed to get gateway of CIDR %s", cidr)
 		}
 
@@ -2307,6 +2405,17 @@ func (c *Controller) addCommonRoutesForSubnet(subnet *kubeovnv1.Subnet) error {
 	return nil
 }
 
+// subnetCIDRProtocols returns the protocols of the primary and secondary cidr blocks of the subnet without duplicates
+func subnetCIDRProtocols(subnet *kubeovnv1.Subnet) []string {
+	var protocols []string
+	for _, cidr := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
+		if protocol := util.CheckProtocol(cidr); !slices.Contains(protocols, protocol) {
+			protocols = append(protocols, protocol)
+		}
+	}
+	return protocols
+}
+
 func getOverlaySubnetsPortGroupName(subnetName, nodeName string) string {
 	return strings.ReplaceAll(fmt.Sprintf("%s.%s", subnetName, nodeName), "-", ".")
 }
@@ -2369,7 +2478,7 @@ func (c *Controller) updatePolicyRouteForCentralizedSubnet(subnetName, cidr stri
 func (c *Controller) addPolicyRouteForCentralizedSubnet(subnet *kubeovnv1.Subnet, nodeName string, ipNameMap map[string]string, nodeIPs []string) error {
 	for _, nodeIP := range nodeIPs {
 		// node v4ip v6ip
-		for _, cidrBlock := range strings.Split(subnet.Spec.CIDRBlock, ",") {
+		for _, cidrBlock := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
 			if util.CheckProtocol(cidrBlock) != util.CheckProtocol(nodeIP) {
 				continue
 			}
@@ -2392,7 +2501,7 @@ func (c *Controller) addPolicyRouteForCentralizedSubnet(subnet *kubeovnv1.Subnet
 }
 
 func (c *Controller) deletePolicyRouteForCentralizedSubnet(subnet *kubeovnv1.Subnet) error {
-	for _, cidr := range strings.Split(subnet.Spec.CIDRBlock, ",") {
+	for _, cidr := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
 		ipSuffix := "ip4"
 		if util.CheckProtocol(cidr) == kubeovnv1.ProtocolIPv6 {
 			ipSuffix = "ip6"
@@ -2416,9 +2525,10 @@ func (c *Controller) addPolicyRouteForDistributedSubnet(subnet *kubeovnv1.Subnet
 	}
 
 	pgName := getOverlaySubnetsPortGroupName(subnet.Name, nodeName)
-	for _, cidrBlock := range strings.Split(subnet.Spec.CIDRBlock, ",") {
+	// the port group address sets hold the pod addresses of all the cidr blocks, one policy per protocol is enough
+	for _, protocol := range subnetCIDRProtocols(subnet) {
 		ipSuffix, nodeIP := "ip4", nodeIPv4
-		if util.CheckProtocol(cidrBlock) == kubeovnv1.ProtocolIPv6 {
+		if protocol == kubeovnv1.ProtocolIPv6 {
 			ipSuffix, nodeIP = "ip6", nodeIPv6
 		}
 		if nodeIP == "" {
@@ -2456,9 +2566,9 @@ func (c *Controller) addPolicyRouteForDistributedSubnet(subnet *kubeovnv1.Subnet
 
 func (c *Controller) deletePolicyRouteForDistributedSubnet(subnet *kubeovnv1.Subnet, nodeName string) error {
 	pgName := getOverlaySubnetsPortGroupName(subnet.Name, nodeName)
-	for _, cidrBlock := range strings.Split(subnet.Spec.CIDRBlock, ",") {
+	for _, protocol := range subnetCIDRProtocols(subnet) {
 		ipSuffix := "ip4"
-		if util.CheckProtocol(cidrBlock) == kubeovnv1.ProtocolIPv6 {
+		if protocol == kubeovnv1.ProtocolIPv6 {
 			ipSuffix = "ip6"
 		}
 		pgAs := fmt.Sprintf("%s_%s", pgName, ipSuffix)
@@ -2477,7 +2587,7 @@ func (c *Controller) deletePolicyRouteByGatewayType(subnet *kubeovnv1.Subnet, ga
 		return nil
 	}
 
-	for _, cidr := range strings.Split(subnet.Spec.CIDRBlock, ",") {
+	for _, cidr := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
 		if cidr == "" || !isDelete {
 			continue
 		}
@@ -2529,16 +2639,6 @@ func (c *Controller) deletePolicyRouteByGatewayType(subnet *kubeovnv1.Subnet, ga
 }
 
 func (c *Controller) addPolicyRouteForU2OInterconn(subnet *kubeovnv1.Subnet) error {
-	var v4Gw, v6Gw string
-	for _, gw := range strings.Split(subnet.Spec.Gateway, ",") {
-		switch util.CheckProtocol(gw) {
-		case kubeovnv1.ProtocolIPv4:
-			v4Gw = gw
-		case kubeovnv1.ProtocolIPv6:
-			v6Gw = gw
-		}
-	}
-
 	externalIDs := map[string]string{
 		"vendor":           util.CniTypeName,
 		"subnet":           subnet.Name,
@@ -2590,15 +2690,18 @@ func (c *Controller) addPolicyRouteForU2OInterconn(subnet *kubeovnv1.Subnet) err
 		}
 	}
 
-	for _, cidrBlock := range strings.Split(subnet.Spec.CIDRBlock, ",") {
+	for _, cidrBlock := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
 		ipSuffix := "ip4"
-		nextHop := v4Gw
 		U2OexcludeIPAs := u2oExcludeIP4Ag
 		if util.CheckProtocol(cidrBlock) == kubeovnv1.ProtocolIPv6 {
 			ipSuffix = "ip6"
-			nextHop = v6Gw
 			U2OexcludeIPAs = u2oExcludeIP6Ag
 		}
+		nextHop, err := util.GetSubnetCIDRBlockGateway(subnet, cidrBlock)
+		if err != nil {
+			klog.Errorf("failed to get gateway of cidr %s in subnet %s: %v", cidrBlock, subnet.Name, err)
+			return err
+		}
 
 		match1 := fmt.Sprintf("%s.dst == %s", ipSuffix, cidrBlock)
 		match2 := fmt.Sprintf("%s.dst == $%s && %s.src == %s", ipSuffix, U2OexcludeIPAs, ipSuffix, cidrBlock)
@@ -2720,45 +2823,18 @@ func (c *Controller) deletePolicyRouteForU2OInterconn(subnet *kubeovnv1.Subnet)
 }
 
 func (c *Controller) addStaticRouteForU2OInterconn(subnet *kubeovnv1.Subnet) error {
-	var v4Gw, v6Gw, v4Cidr, v6Cidr string
-	for _, gw := range strings.Split(subnet.Spec.Gateway, ",") {
-		switch util.CheckProtocol(gw) {
-		case kubeovnv1.ProtocolIPv4:
-			v4Gw = gw
-		case kubeovnv1.ProtocolIPv6:
-			v6Gw = gw
-		}
-	}
-
-	for _, cidr := range strings.Split(subnet.Spec.CIDRBlock, ",") {
-		if util.CheckProtocol(cidr) == kubeovnv1.ProtocolIPv4 {
-			v4Cidr = cidr
-		} else {
-			v6Cidr = cidr
-		}
-	}
-
-	if v4Gw != "" && v4Cidr != "" {
-		if err := c.addStaticRouteToVpc(
-			subnet.Spec.Vpc,
-			&kubeovnv1.StaticRoute{
-				Policy:    kubeovnv1.PolicySrc,
-				CIDR:      v4Cidr,
-				NextHopIP: v4Gw,
-			},
-		); err != nil {
-			klog.Errorf("failed to add static route, %v", err)
+	for _, cidr := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
+		gw, err := util.GetSubnetCIDRBlockGateway(subnet, cidr)
+		if err != nil {
+			klog.Errorf("failed to get gateway of cidr %s in subnet %s: %v", cidr, subnet.Name, err)
 			return err
 		}
-	}
-
-	if v6Gw != "" && v6Cidr != "" {
 		if err := c.addStaticRouteToVpc(
 			subnet.Spec.Vpc,
 			&kubeovnv1.StaticRoute{
 				Policy:    kubeovnv1.PolicySrc,
-				CIDR:      v6Cidr,
-				NextHopIP: v6Gw,
+				CIDR:      cidr,
+				NextHopIP: gw,
 			},
 		); err != nil {
 			klog.Errorf("failed to add static route, %v", err)
@@ -2769,45 +2845,20 @@ func (c *Controller) addStaticRouteForU2OInterconn(subnet *kubeovnv1.Subnet) err
 }
 
 func (c *Controller) deleteStaticRouteForU2OInterconn(subnet *kubeovnv1.Subnet) error {
-	var v4Gw, v6Gw, v4Cidr, v6Cidr string
-	for _, gw := range strings.Split(subnet.Spec.Gateway, ",") {
-		switch util.CheckProtocol(gw) {
-		case kubeovnv1.ProtocolIPv4:
-			v4Gw = gw
-		case kubeovnv1.ProtocolIPv6:
-			v6Gw = gw
-		}
-	}
-
-	for _, cidr := range strings.Split(subnet.Spec.CIDRBlock, ",") {
-		if util.CheckProtocol(cidr) == kubeovnv1.ProtocolIPv4 {
-			v4Cidr = cidr
-		} else {
-			v6Cidr = cidr
-		}
-	}
-
-	if v4Gw != "" && v4Cidr != "" {
-		if err := c.deleteStaticRouteFromVpc(
-			subnet.Spec.Vpc,
-			subnet.Spec.RouteTable,
-			v4Cidr,
-			v4Gw,
-			kubeovnv1.PolicySrc,
-		); err != nil {
-			klog.Errorf("failed to add static route, %v", err)
+	for _, cidr := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
+		gw, err := util.GetSubnetCIDRBlockGateway(subnet, cidr)
+		if err != nil {
+			klog.Errorf("failed to get gateway of cidr %s in subnet %s: %v", cidr, subnet.Name, err)
 			return err
 		}
-	}
-
-	if v6Gw != "" && v6Cidr != "" {
 		if err := c.deleteStaticRouteFromVpc(
 			subnet.Spec.Vpc,
 			subnet.Spec.RouteTable,
-			v6Cidr,
-			v6Gw,
+			cidr,
+			gw,
 			kubeovnv1.PolicySrc,
 		); err != nil {
+			klog.Errorf("failed to delete static route, %v", err)
 			return err
 		}
 	}
@@ -2853,7 +2904,7 @@ func (c *Controller) deleteCustomVPCPolicyRoutesForSubnet(subnet *kubeovnv1.Subn
 		klog.Infof("logical router %s already deleted", subnet.Spec.Vpc)
 		return nil
 	}
-	for _, cidr := range strings.Split(subnet.Spec.CIDRBlock, ",") {
+	for _, cidr := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
 		af := 4
 		if util.CheckProtocol(cidr) == kubeovnv1.ProtocolIPv6 {
 			af = 6
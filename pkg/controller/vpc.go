@@ -40,12 +40,29 @@ func (c *Controller) enqueueAddVpc(obj interface{}) {
 	if _, ok := vpc.Labels[util.VpcExternalLabel]; !ok {
 		c.addOrUpdateVpcQueue.Add(key)
 	}
+	c.enqueueVpcTransitHubsForVpc(vpc.Name)
 }
 
 func (c *Controller) enqueueUpdateVpc(oldObj, newObj interface{}) {
 	oldVpc := oldObj.(*kubeovnv1.Vpc)
 	newVpc := newObj.(*kubeovnv1.Vpc)
 
+	if oldVpc.Spec.NatBackend != newVpc.Spec.NatBackend || oldVpc.Spec.EnableExternal != newVpc.Spec.EnableExternal {
+		// migrate the vpc eips and nat rules to the new backend
+		c.enqueueVpcEipsForVpc(newVpc.Name)
+	}
+	if !newVpc.DeletionTimestamp.IsZero() ||
+		!reflect.DeepEqual(oldVpc.Spec.Namespaces, newVpc.Spec.Namespaces) ||
+		!reflect.DeepEqual(oldVpc.Spec.VpcPeerings, newVpc.Spec.VpcPeerings) {
+		// the peering connections follow the owners and the vpc peerings of the vpc
+		c.enqueueVpcPeeringConnectionsForVpc(newVpc.Name)
+	}
+	if !newVpc.DeletionTimestamp.IsZero() || !reflect.DeepEqual(oldVpc.Spec.VpcPeerings, newVpc.Spec.VpcPeerings) ||
+		!reflect.DeepEqual(oldVpc.Spec.StaticRoutes, newVpc.Spec.StaticRoutes) {
+		// the route tables of the hubs can not be used by the static routes of the hub vpc
+		c.enqueueVpcTransitHubsForVpc(newVpc.Name)
+	}
+
 	if !newVpc.DeletionTimestamp.IsZero() ||
 		!reflect.DeepEqual(oldVpc.Spec.Namespaces, newVpc.Spec.Namespaces) ||
 		!reflect.DeepEqual(oldVpc.Spec.StaticRoutes, newVpc.Spec.StaticRoutes) ||
@@ -94,6 +111,8 @@ func (c *Controller) enqueueDelVpc(obj interface{}) {
 		klog.V(3).Infof("enqueue delete vpc %s", key)
 		c.delVpcQueue.Add(obj)
 	}
+	c.enqueueVpcPeeringConnectionsForVpc(vpc.Name)
+	c.enqueueVpcTransitHubsForVpc(vpc.Name)
 }
 
 func (c *Controller) runAddVpcWorker() {
@@ -320,6 +339,19 @@ func (c *Controller) handleAddOrUpdateVpc(key string) error {
 	staticRouteMapping = c.getRouteTablesByVpc(vpc)
 	staticTargetRoutes = vpc.Spec.StaticRoutes
 
+	peeringRoutes, err := c.getVpcPeeringConnectionRoutes(vpc.Name)
+	if err != nil {
+		klog.Errorf("failed to get peering connection routes of vpc %s: %v", vpc.Name, err)
+		return err
+	}
+	staticTargetRoutes = append(staticTargetRoutes, peeringRoutes...)
+	transitHubRoutes, err := c.getVpcTransitHubRoutes(vpc.Name)
+	if err != nil {
+		klog.Errorf("failed to get transit hub routes of vpc %s: %v", vpc.Name, err)
+		return err
+	}
+	staticTargetRoutes = append(staticTargetRoutes, transitHubRoutes...)
+
 	if vpc.Name == c.config.ClusterRouter {
 		if _, ok := staticRouteMapping[util.MainRouteTable]; !ok {
 			staticRouteMapping[util.MainRouteTable] = nil
@@ -457,21 +489,14 @@ func (c *Controller) handleAddOrUpdateVpc(key string) error {
 		// diff list
 		policyRouteNeedDel, policyRouteNeedAdd = diffPolicyRouteWithExisted(policyRouteExisted, vpc.Spec.PolicyRoutes)
 	} else {
-		if vpc.Spec.PolicyRoutes == nil {
-			// do not clean default vpc policy routes
-			if err = c.OVNNbClient.ClearLogicalRouterPolicy(vpc.Name); err != nil {
-				klog.Errorf("clean all vpc %s policy route failed, %v", vpc.Name, err)
-				return err
-			}
-		} else {
-			policyRouteLogical, err = c.OVNNbClient.ListLogicalRouterPolicies(vpc.Name, -1, nil, true)
-			if err != nil {
-				klog.Errorf("failed to get vpc %s policy route list, %v", vpc.Name, err)
-				return err
-			}
-			// diff vpc policy route
-			policyRouteNeedDel, policyRouteNeedAdd = diffPolicyRouteWithLogical(policyRouteLogical, vpc.Spec.PolicyRoutes)
+		// do not clean default vpc policy routes
+		policyRouteLogical, err = c.OVNNbClient.ListLogicalRouterPolicies(vpc.Name, -1, nil, true)
+		if err != nil {
+			klog.Errorf("failed to get vpc %s policy route list, %v", vpc.Name, err)
+			return err
 		}
+		// diff vpc policy route, all of the policy routes are deleted if none is specified
+		policyRouteNeedDel, policyRouteNeedAdd = diffPolicyRouteWithLogical(vpcSpecPolicyRoutes(policyRouteLogical), vpc.Spec.PolicyRoutes)
 	}
 	// delete policies non-exist
 	for _, item := range policyRouteNeedDel {
@@ -757,6 +782,14 @@ func diffPolicyRouteWithExisted(exists, target []*kubeovnv1.PolicyRoute) ([]*kub
 	return dels, adds
 }
 
+// vpcSpecPolicyRoutes returns the policy routes which are not managed by other resources, the ones of
+// vpc egress gateways are excluded so that they are not deleted with the policy routes of the vpc spec
+func vpcSpecPolicyRoutes(policies []*ovnnb.LogicalRouterPolicy) []*ovnnb.LogicalRouterPolicy {
+	return slices.DeleteFunc(slices.Clone(policies), func(policy *ovnnb.LogicalRouterPolicy) bool {
+		return policy.ExternalIDs[vpcEgressGatewayKey] != ""
+	})
+}
+
 func diffPolicyRouteWithLogical(exists []*ovnnb.LogicalRouterPolicy, target []*kubeovnv1.PolicyRoute) ([]*kubeovnv1.PolicyRoute, []*kubeovnv1.PolicyRoute) {
 	var (
 		dels, adds []*kubeovnv1.PolicyRoute
@@ -798,6 +831,10 @@ func getPolicyRouteItemKey(item *kubeovnv1.PolicyRoute) (key string) {
 func diffStaticRoute(exist []*ovnnb.LogicalRouterStaticRoute, target []*kubeovnv1.StaticRoute) (routeNeedDel, routeNeedAdd []*kubeovnv1.StaticRoute, err error) {
 	existRouteMap := make(map[string]*kubeovnv1.StaticRoute, len(exist))
 	for _, item := range exist {
+		// routes imported from bgp peers are managed by the speakers
+		if item.ExternalIDs[util.BgpImportNodeKey] != "" {
+			continue
+		}
 		policy := kubeovnv1.PolicyDst
 		if item.Policy != nil && *item.Policy == ovnnb.LogicalRouterStaticRoutePolicySrcIP {
 			policy = kubeovnv1.PolicySrc
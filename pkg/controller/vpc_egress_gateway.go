@@ -0,0 +1,916 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovs"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+var vpcEgressGatewayDefaultImage = ""
+
+func (c *Controller) enqueueAddVpcEgressGateway(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue add vpc egress gateway %s", key)
+	c.addOrUpdateVpcEgressGatewayQueue.Add(key)
+}
+
+func (c *Controller) enqueueUpdateVpcEgressGateway(oldObj, newObj interface{}) {
+	oldGw := oldObj.(*kubeovnv1.VpcEgressGateway)
+	newGw := newObj.(*kubeovnv1.VpcEgressGateway)
+	if reflect.DeepEqual(oldGw.Spec, newGw.Spec) {
+		return
+	}
+	klog.V(3).Infof("enqueue update vpc egress gateway %s", newGw.Name)
+	c.addOrUpdateVpcEgressGatewayQueue.Add(newGw.Name)
+	if !reflect.DeepEqual(oldGw.Spec.Selectors, newGw.Spec.Selectors) {
+		// the selectors of the other gateways are checked against the new selectors
+		c.enqueueVpcEgressGatewaysInVpc(vpcEgressGatewayVpc(newGw, c.config.ClusterRouter), newGw.Name)
+	}
+}
+
+// enqueueVpcEgressGatewaysInVpc enqueues the vpc egress gateways in the vpc except the one specified
+func (c *Controller) enqueueVpcEgressGatewaysInVpc(vpcName, except string) {
+	gws, err := c.vpcEgressGatewaysLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc egress gateways: %v", err)
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, gw := range gws {
+		if gw.Name != except && vpcEgressGatewayVpc(gw, c.config.ClusterRouter) == vpcName {
+			klog.V(3).Infof("enqueue update vpc egress gateway %s", gw.Name)
+			c.addOrUpdateVpcEgressGatewayQueue.Add(gw.Name)
+		}
+	}
+}
+
+func (c *Controller) enqueueDeleteVpcEgressGateway(obj interface{}) {
+	var gw *kubeovnv1.VpcEgressGateway
+	switch t := obj.(type) {
+	case *kubeovnv1.VpcEgressGateway:
+		gw = t
+	case cache.DeletedFinalStateUnknown:
+		g, ok := t.Obj.(*kubeovnv1.VpcEgressGateway)
+		if !ok {
+			klog.Warningf("unexpected object type: %T", t.Obj)
+			return
+		}
+		gw = g
+	default:
+		klog.Warningf("unexpected type: %T", obj)
+		return
+	}
+
+	klog.V(3).Infof("enqueue delete vpc egress gateway %s", gw.Name)
+	c.delVpcEgressGatewayQueue.Add(gw.DeepCopy())
+}
+
+func (c *Controller) runAddOrUpdateVpcEgressGatewayWorker() {
+	for c.processNextWorkItem("addOrUpdateVpcEgressGateway", c.addOrUpdateVpcEgressGatewayQueue, c.handleAddOrUpdateVpcEgressGateway) {
+	}
+}
+
+func (c *Controller) runDelVpcEgressGatewayWorker() {
+	for c.processNextDelVpcEgressGatewayWorkItem() {
+	}
+}
+
+func (c *Controller) processNextDelVpcEgressGatewayWorkItem() bool {
+	obj, shutdown := c.delVpcEgressGatewayQueue.Get()
+	if shutdown {
+		return false
+	}
+
+	err := func(obj interface{}) error {
+		defer c.delVpcEgressGatewayQueue.Done(obj)
+		gw, ok := obj.(*kubeovnv1.VpcEgressGateway)
+		if !ok {
+			c.delVpcEgressGatewayQueue.Forget(obj)
+			utilruntime.HandleError(fmt.Errorf("expected vpc egress gateway in workqueue but got %#v", obj))
+			return nil
+		}
+		if err := c.handleDelVpcEgressGateway(gw); err != nil {
+			c.delVpcEgressGatewayQueue.AddRateLimited(obj)
+			return fmt.Errorf("error syncing vpc egress gateway %s: %v, requeuing", gw.Name, err)
+		}
+		c.delVpcEgressGatewayQueue.Forget(obj)
+		return nil
+	}(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return true
+	}
+	return true
+}
+
+// podMatchVpcEgressGateways returns the vpc egress gateways which the pod is an instance of or is selected by
+func (c *Controller) podMatchVpcEgressGateways(pod *corev1.Pod) []string {
+	if name := pod.Labels[util.VpcEgressGatewayLabel]; name != "" && pod.Namespace == c.config.PodNamespace {
+		return []string{name}
+	}
+
+	gws, err := c.vpcEgressGatewaysLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc egress gateways: %v", err)
+		utilruntime.HandleError(err)
+		return nil
+	}
+	if len(gws) == 0 {
+		return nil
+	}
+	ns, err := c.namespacesLister.Get(pod.Namespace)
+	if err != nil {
+		klog.Errorf("failed to get namespace %s: %v", pod.Namespace, err)
+		utilruntime.HandleError(err)
+		return nil
+	}
+
+	var match []string
+	for _, gw := range gws {
+		if isPodSelectedByVpcEgressGateway(pod, ns, gw.Spec.Selectors) {
+			match = append(match, gw.Name)
+		}
+	}
+	return match
+}
+
+func (c *Controller) enqueueVpcEgressGatewaysForPod(pods ...*corev1.Pod) {
+	var gws []string
+	for _, pod := range pods {
+		gws = append(gws, c.podMatchVpcEgressGateways(pod)...)
+	}
+	for _, gw := range util.UniqString(gws) {
+		klog.V(3).Infof("enqueue update vpc egress gateway %s for pod %s/%s", gw, pods[0].Namespace, pods[0].Name)
+		c.addOrUpdateVpcEgressGatewayQueue.Add(gw)
+	}
+}
+
+// enqueueVpcEgressGatewaysForBFD enqueues the vpc egress gateway which has an instance at the
+// destination of the bfd session, so that the next hops are updated once the session goes up or down
+func (c *Controller) enqueueVpcEgressGatewaysForBFD(bfd *ovnnb.BFD) {
+	sel, _ := labels.Parse(util.VpcEgressGatewayLabel)
+	pods, err := c.podsLister.Pods(c.config.PodNamespace).List(sel)
+	if err != nil {
+		klog.Errorf("failed to list pods of vpc egress gateways: %v", err)
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, pod := range pods {
+		if slices.Contains(strings.Split(pod.Annotations[util.IPAddressAnnotation], ","), bfd.DstIP) {
+			gw := pod.Labels[util.VpcEgressGatewayLabel]
+			klog.V(3).Infof("enqueue update vpc egress gateway %s for bfd session to %s", gw, bfd.DstIP)
+			c.addOrUpdateVpcEgressGatewayQueue.Add(gw)
+		}
+	}
+}
+
+// namespaceMatchVpcEgressGateways returns the vpc egress gateways which select pods by the labels of the namespace
+func (c *Controller) namespaceMatchVpcEgressGateways(ns *corev1.Namespace) []string {
+	gws, err := c.vpcEgressGatewaysLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc egress gateways: %v", err)
+		utilruntime.HandleError(err)
+		return nil
+	}
+
+	var match []string
+	for _, gw := range gws {
+		for _, selector := range gw.Spec.Selectors {
+			if selector.NamespaceSelector == nil {
+				continue
+			}
+			if sel, err := metav1.LabelSelectorAsSelector(selector.NamespaceSelector); err == nil && sel.Matches(labels.Set(ns.Labels)) {
+				match = append(match, gw.Name)
+				break
+			}
+		}
+	}
+	return match
+}
+
+// isVpcEgressGatewayPodChanged returns whether the change of the pod affects the vpc egress gateways
+func isVpcEgressGatewayPodChanged(oldPod, newPod *corev1.Pod) bool {
+	return !reflect.DeepEqual(oldPod.Labels, newPod.Labels) ||
+		oldPod.Annotations[util.IPAddressAnnotation] != newPod.Annotations[util.IPAddressAnnotation] ||
+		oldPod.Annotations[util.LogicalSwitchAnnotation] != newPod.Annotations[util.LogicalSwitchAnnotation] ||
+		oldPod.Status.Phase != newPod.Status.Phase ||
+		isPodReady(oldPod) != isPodReady(newPod) ||
+		(oldPod.DeletionTimestamp == nil) != (newPod.DeletionTimestamp == nil)
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func isPodSelectedByVpcEgressGateway(pod *corev1.Pod, ns *corev1.Namespace, selectors []kubeovnv1.VpcEgressGatewaySelector) bool {
+	for _, selector := range selectors {
+		if selector.NamespaceSelector != nil {
+			nsSel, err := metav1.LabelSelectorAsSelector(selector.NamespaceSelector)
+			if err != nil || !nsSel.Matches(labels.Set(ns.Labels)) {
+				continue
+			}
+		}
+		if selector.PodSelector != nil {
+			podSel, err := metav1.LabelSelectorAsSelector(selector.PodSelector)
+			if err != nil || !podSel.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// vpcEgressGatewayAttachment returns the namespace and name of the network attachment definition of a subnet provider
+func vpcEgressGatewayAttachment(provider string) (string, string, error) {
+	fields := strings.Split(provider, ".")
+	if len(fields) < 2 || provider == util.OvnProvider {
+		return "", "", fmt.Errorf("subnet provider %q is not a network attachment definition", provider)
+	}
+	return fields[1], fields[0], nil
+}
+
+func (c *Controller) getVpcEgressGatewayImage(gw *kubeovnv1.VpcEgressGateway) (string, error) {
+	if gw.Spec.Image != "" {
+		return gw.Spec.Image, nil
+	}
+	if vpcEgressGatewayDefaultImage != "" {
+		return vpcEgressGatewayDefaultImage, nil
+	}
+
+	// the gateway instances run with the image of kube-ovn by default
+	pod, err := c.config.KubeClient.CoreV1().Pods(c.config.PodNamespace).Get(context.Background(), c.config.PodName, metav1.GetOptions{})
+	if err != nil {
+		klog.Errorf("failed to get pod %s/%s: %v", c.config.PodNamespace, c.config.PodName, err)
+		return "", err
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name == "kube-ovn-controller" {
+			vpcEgressGatewayDefaultImage = container.Image
+			return container.Image, nil
+		}
+	}
+	vpcEgressGatewayDefaultImage = pod.Spec.Containers[0].Image
+	return vpcEgressGatewayDefaultImage, nil
+}
+
+// validateVpcEgressGateway checks the spec of the vpc egress gateway and returns the vpc and subnets it refers to
+func (c *Controller) validateVpcEgressGateway(gw *kubeovnv1.VpcEgressGateway) (*kubeovnv1.Vpc, *kubeovnv1.Subnet, *kubeovnv1.Subnet, error) {
+	if gw.Spec.Replicas < 0 {
+		return nil, nil, nil, fmt.Errorf("invalid replicas %d", gw.Spec.Replicas)
+	}
+	replicas := int(vpcEgressGatewayReplicas(gw))
+	if len(gw.Spec.InternalIPs) != 0 && len(gw.Spec.InternalIPs) < replicas {
+		return nil, nil, nil, fmt.Errorf("%d internal ips are not enough for %d replicas", len(gw.Spec.InternalIPs), replicas)
+	}
+	if len(gw.Spec.ExternalIPs) != 0 && len(gw.Spec.ExternalIPs) < replicas {
+		return nil, nil, nil, fmt.Errorf("%d external ips are not enough for %d replicas", len(gw.Spec.ExternalIPs), replicas)
+	}
+	for _, selector := range gw.Spec.Selectors {
+		if _, err := metav1.LabelSelectorAsSelector(selector.NamespaceSelector); err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid namespace selector: %v", err)
+		}
+		if _, err := metav1.LabelSelectorAsSelector(selector.PodSelector); err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid pod selector: %v", err)
+		}
+	}
+
+	vpcName := vpcEgressGatewayVpc(gw, c.config.ClusterRouter)
+	vpc, err := c.vpcsLister.Get(vpcName)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get vpc %s: %v", vpcName, err)
+	}
+	if vpc.Status.Router == "" {
+		return nil, nil, nil, fmt.Errorf("logical router of vpc %s is not ready", vpcName)
+	}
+
+	if gw.Spec.InternalSubnet == "" {
+		return nil, nil, nil, fmt.Errorf("internal subnet is not specified")
+	}
+	internalSubnet, err := c.subnetsLister.Get(gw.Spec.InternalSubnet)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get internal subnet %s: %v", gw.Spec.InternalSubnet, err)
+	}
+	if internalSubnet.Spec.Vpc != vpcName {
+		return nil, nil, nil, fmt.Errorf("internal subnet %s does not belong to vpc %s", internalSubnet.Name, vpcName)
+	}
+
+	if gw.Spec.ExternalSubnet == "" {
+		return nil, nil, nil, fmt.Errorf("external subnet is not specified")
+	}
+	externalSubnet, err := c.subnetsLister.Get(gw.Spec.ExternalSubnet)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get external subnet %s: %v", gw.Spec.ExternalSubnet, err)
+	}
+	if _, _, err = vpcEgressGatewayAttachment(externalSubnet.Spec.Provider); err != nil {
+		return nil, nil, nil, fmt.Errorf("external subnet %s must be attached by multus: %v", externalSubnet.Name, err)
+	}
+	return vpc, internalSubnet, externalSubnet, nil
+}
+
+func vpcEgressGatewayReplicas(gw *kubeovnv1.VpcEgressGateway) int32 {
+	if gw.Spec.Replicas == 0 {
+		return 1
+	}
+	return gw.Spec.Replicas
+}
+
+// vpcEgressGatewayRoutes returns the routes to the subnets of the vpc via the gateway of the internal subnet
+// in the format of cidr,nexthop
+func (c *Controller) vpcEgressGatewayRoutes(vpcName string, internalSubnet *kubeovnv1.Subnet) ([]string, error) {
+	subnets, err := c.subnetsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list subnets: %v", err)
+		return nil, err
+	}
+
+	var routes []string
+	for _, subnet := range subnets {
+		if subnet.Spec.Vpc != vpcName || !isOvnSubnet(subnet) {
+			continue
+		}
+		for _, cidr := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
+			protocol := util.CheckProtocol(cidr)
+			for _, gw := range strings.Split(internalSubnet.Spec.Gateway, ",") {
+				if util.CheckProtocol(gw) == protocol {
+					routes = append(routes, fmt.Sprintf("%s,%s", cidr, gw))
+				}
+			}
+		}
+	}
+	sort.Strings(routes)
+	return routes, nil
+}
+
+func genVpcEgressGatewayStatefulSet(gw *kubeovnv1.VpcEgressGateway, image string, internalSubnet, externalSubnet *kubeovnv1.Subnet, routes []string) *v1.StatefulSet {
+	replicas := vpcEgressGatewayReplicas(gw)
+	name := util.GenVpcEgressGatewayStsName(gw.Name)
+	privileged := true
+	labels := map[string]string{
+		"app":                      name,
+		util.VpcEgressGatewayLabel: gw.Name,
+	}
+
+	nadNamespace, nadName, _ := vpcEgressGatewayAttachment(externalSubnet.Spec.Provider)
+	podAnnotations := map[string]string{
+		util.LogicalSwitchAnnotation:     internalSubnet.Name,
+		util.AttachmentNetworkAnnotation: fmt.Sprintf("%s/%s", nadNamespace, nadName),
+		fmt.Sprintf(util.LogicalSwitchAnnotationTemplate, externalSubnet.Spec.Provider): externalSubnet.Name,
+	}
+	// pods of statefulsets take the ip addresses in the pools by their ordinals
+	if len(gw.Spec.InternalIPs) != 0 {
+		podAnnotations[util.IPPoolAnnotation] = strings.Join(gw.Spec.InternalIPs, ";")
+	}
+	if len(gw.Spec.ExternalIPs) != 0 {
+		podAnnotations[fmt.Sprintf(util.IPPoolAnnotationTemplate, externalSubnet.Spec.Provider)] = strings.Join(gw.Spec.ExternalIPs, ";")
+	}
+
+	selectors := make(map[string]string)
+	for _, v := range gw.Spec.NodeSelector {
+		parts := strings.Split(strings.TrimSpace(v), ":")
+		if len(parts) != 2 {
+			continue
+		}
+		selectors[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	args := []string{"serve"}
+	if gw.Spec.BFD.Enabled {
+		args = append([]string{"bfd"}, strings.Split(internalSubnet.Spec.Gateway, ",")...)
+	}
+	initArgs := append([]string{"init", externalSubnet.Spec.Gateway}, routes...)
+
+	return &v1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: v1.StatefulSetSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			// the instances are independent of each other
+			PodManagementPolicy: v1.ParallelPodManagement,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: podAnnotations,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:            "gateway",
+							Image:           image,
+							Command:         []string{"bash", "/kube-ovn/vpc-egress-gateway.sh"},
+							Args:            args,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							SecurityContext: &corev1.SecurityContext{
+								Privileged: &privileged,
+							},
+						},
+					},
+					InitContainers: []corev1.Container{
+						{
+							Name:            "init",
+							Image:           image,
+							Command:         []string{"bash", "/kube-ovn/vpc-egress-gateway.sh"},
+							Args:            initArgs,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							SecurityContext: &corev1.SecurityContext{
+								Privileged: &privileged,
+							},
+						},
+					},
+					NodeSelector: selectors,
+					Tolerations:  gw.Spec.Tolerations,
+					Affinity: &corev1.Affinity{
+						// spread the instances across nodes for high availability
+						PodAntiAffinity: &corev1.PodAntiAffinity{
+							PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+								Weight: 100,
+								PodAffinityTerm: corev1.PodAffinityTerm{
+									LabelSelector: &metav1.LabelSelector{MatchLabels: labels},
+									TopologyKey:   corev1.LabelHostname,
+								},
+							}},
+						},
+					},
+				},
+			},
+			UpdateStrategy: v1.StatefulSetUpdateStrategy{
+				Type: v1.RollingUpdateStatefulSetStrategyType,
+			},
+		},
+	}
+}
+
+func (c *Controller) reconcileVpcEgressGatewayStatefulSet(gw *kubeovnv1.VpcEgressGateway, vpc *kubeovnv1.Vpc, internalSubnet, externalSubnet *kubeovnv1.Subnet) error {
+	image, err := c.getVpcEgressGatewayImage(gw)
+	if err != nil {
+		return err
+	}
+	routes, err := c.vpcEgressGatewayRoutes(vpc.Name, internalSubnet)
+	if err != nil {
+		return err
+	}
+	newSts := genVpcEgressGatewayStatefulSet(gw, image, internalSubnet, externalSubnet, routes)
+
+	oldSts, err := c.config.KubeClient.AppsV1().StatefulSets(c.config.PodNamespace).Get(context.Background(), newSts.Name, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Error(err)
+			return err
+		}
+		klog.Infof("create statefulset %s for vpc egress gateway %s", newSts.Name, gw.Name)
+		if _, err = c.config.KubeClient.AppsV1().StatefulSets(c.config.PodNamespace).Create(context.Background(), newSts, metav1.CreateOptions{}); err != nil {
+			err = fmt.Errorf("failed to create statefulset %s: %v", newSts.Name, err)
+			klog.Error(err)
+			return err
+		}
+		return nil
+	}
+
+	// the fields not set by the controller are defaulted by the apiserver
+	if equality.Semantic.DeepDerivative(newSts.Spec, oldSts.Spec) {
+		return nil
+	}
+	klog.Infof("update statefulset %s for vpc egress gateway %s", newSts.Name, gw.Name)
+	oldSts = oldSts.DeepCopy()
+	oldSts.Labels = newSts.Labels
+	oldSts.Spec.Replicas = newSts.Spec.Replicas
+	oldSts.Spec.Template = newSts.Spec.Template
+	if _, err = c.config.KubeClient.AppsV1().StatefulSets(c.config.PodNamespace).Update(context.Background(), oldSts, metav1.UpdateOptions{}); err != nil {
+		err = fmt.Errorf("failed to update statefulset %s: %v", newSts.Name, err)
+		klog.Error(err)
+		return err
+	}
+	return nil
+}
+
+// vpcEgressGatewayInstances returns the instances of the vpc egress gateway which have been allocated ip addresses
+func (c *Controller) vpcEgressGatewayInstances(gw *kubeovnv1.VpcEgressGateway, externalSubnet *kubeovnv1.Subnet) ([]kubeovnv1.VpcEgressGatewayInstance, map[string]bool, error) {
+	sel := labels.SelectorFromSet(labels.Set{util.VpcEgressGatewayLabel: gw.Name})
+	pods, err := c.podsLister.Pods(c.config.PodNamespace).List(sel)
+	if err != nil {
+		klog.Errorf("failed to list pods of vpc egress gateway %s: %v", gw.Name, err)
+		return nil, nil, err
+	}
+
+	ready := make(map[string]bool, len(pods))
+	instances := make([]kubeovnv1.VpcEgressGatewayInstance, 0, len(pods))
+	for _, pod := range pods {
+		internalIP := pod.Annotations[util.IPAddressAnnotation]
+		if internalIP == "" || !isPodAlive(pod) {
+			continue
+		}
+		instances = append(instances, kubeovnv1.VpcEgressGatewayInstance{
+			Name:       pod.Name,
+			Node:       pod.Spec.NodeName,
+			InternalIP: internalIP,
+			ExternalIP: pod.Annotations[fmt.Sprintf(util.IPAddressAnnotationTemplate, externalSubnet.Spec.Provider)],
+		})
+		ready[pod.Name] = pod.DeletionTimestamp == nil && pod.Status.Phase == corev1.PodRunning && isPodReady(pod)
+	}
+	sort.Slice(instances, func(i, j int) bool { return instances[i].Name < instances[j].Name })
+	return instances, ready, nil
+}
+
+// reconcileVpcEgressGatewayBFD makes sure the bfd sessions to the instances exist and
+// marks the instances whose bfd sessions are up as active
+func (c *Controller) reconcileVpcEgressGatewayBFD(gw *kubeovnv1.VpcEgressGateway, lrpName string, instances []kubeovnv1.VpcEgressGatewayInstance, ready map[string]bool) error {
+	var ips []string
+	for i := range instances {
+		instance := &instances[i]
+		instance.Active = ready[instance.Name]
+		if !gw.Spec.BFD.Enabled {
+			continue
+		}
+
+		minRX, minTX, multiplier := int(gw.Spec.BFD.MinRX), int(gw.Spec.BFD.MinTX), int(gw.Spec.BFD.Multiplier)
+		if minRX == 0 {
+			minRX = c.config.BfdMinRx
+		}
+		if minTX == 0 {
+			minTX = c.config.BfdMinTx
+		}
+		if multiplier == 0 {
+			multiplier = c.config.BfdDetectMult
+		}
+		for _, ip := range strings.Split(instance.InternalIP, ",") {
+			bfd, err := c.OVNNbClient.CreateBFD(lrpName, ip, minRX, minTX, multiplier)
+			if err != nil {
+				klog.Errorf("failed to create bfd session to instance %s of vpc egress gateway %s: %v", instance.Name, gw.Name, err)
+				return err
+			}
+			if bfd.Status == nil || *bfd.Status != ovnnb.BFDStatusUp {
+				instance.Active = false
+			}
+			ips = append(ips, ip)
+		}
+	}
+
+	// remove the bfd sessions to the instances gone
+	for _, instance := range gw.Status.Instances {
+		for _, ip := range strings.Split(instance.InternalIP, ",") {
+			if ip == "" || slices.Contains(ips, ip) {
+				continue
+			}
+			if err := c.OVNNbClient.DeleteBFD(lrpName, ip); err != nil {
+				klog.Errorf("failed to delete bfd session to %s of vpc egress gateway %s: %v", ip, gw.Name, err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func vpcEgressGatewayVpc(gw *kubeovnv1.VpcEgressGateway, defaultVpc string) string {
+	if gw.Spec.Vpc == "" {
+		return defaultVpc
+	}
+	return gw.Spec.Vpc
+}
+
+// labelSelectorOrEverything converts the label selector to a selector which matches everything if it is not set
+func labelSelectorOrEverything(selector *metav1.LabelSelector) (labels.Selector, error) {
+	if selector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(selector)
+}
+
+// vpcEgressGatewaySelectedPods returns the pods in the vpc selected by the vpc egress gateway,
+// only the namespaces and pods matching the selectors are listed
+func (c *Controller) vpcEgressGatewaySelectedPods(gw *kubeovnv1.VpcEgressGateway, vpcName string) ([]*corev1.Pod, error) {
+	var selected []*corev1.Pod
+	visited := make(map[string]bool)
+	for _, selector := range gw.Spec.Selectors {
+		nsSel, err := labelSelectorOrEverything(selector.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace selector: %v", err)
+		}
+		podSel, err := labelSelectorOrEverything(selector.PodSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pod selector: %v", err)
+		}
+		namespaces, err := c.namespacesLister.List(nsSel)
+		if err != nil {
+			klog.Errorf("failed to list namespaces: %v", err)
+			return nil, err
+		}
+		for _, ns := range namespaces {
+			pods, err := c.podsLister.Pods(ns.Name).List(podSel)
+			if err != nil {
+				klog.Errorf("failed to list pods in namespace %s: %v", ns.Name, err)
+				return nil, err
+			}
+			for _, pod := range pods {
+				key := ns.Name + "/" + pod.Name
+				if visited[key] {
+					continue
+				}
+				visited[key] = true
+				if pod.Spec.HostNetwork || !isPodAlive(pod) || pod.Labels[util.VpcEgressGatewayLabel] != "" ||
+					pod.Annotations[util.IPAddressAnnotation] == "" {
+					continue
+				}
+				subnet, err := c.subnetsLister.Get(pod.Annotations[util.LogicalSwitchAnnotation])
+				if err != nil {
+					if k8serrors.IsNotFound(err) {
+						continue
+					}
+					klog.Errorf("failed to get subnet of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+					return nil, err
+				}
+				if subnet.Spec.Vpc == vpcName {
+					selected = append(selected, pod)
+				}
+			}
+		}
+	}
+	return selected, nil
+}
+
+// checkVpcEgressGatewayOverlap makes sure none of the selected pods is selected by another vpc egress gateway
+// created earlier in the same vpc, since a pod can only be routed by one policy route
+func (c *Controller) checkVpcEgressGatewayOverlap(gw *kubeovnv1.VpcEgressGateway, vpcName string, pods []*corev1.Pod) error {
+	if len(pods) == 0 {
+		return nil
+	}
+	gws, err := c.vpcEgressGatewaysLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc egress gateways: %v", err)
+		return err
+	}
+
+	var others []*kubeovnv1.VpcEgressGateway
+	for _, other := range gws {
+		if other.Name == gw.Name || other.DeletionTimestamp != nil || vpcEgressGatewayVpc(other, c.config.ClusterRouter) != vpcName {
+			continue
+		}
+		if other.CreationTimestamp.Before(&gw.CreationTimestamp) ||
+			(other.CreationTimestamp.Equal(&gw.CreationTimestamp) && other.Name < gw.Name) {
+			others = append(others, other)
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+
+	for _, pod := range pods {
+		ns, err := c.namespacesLister.Get(pod.Namespace)
+		if err != nil {
+			klog.Errorf("failed to get namespace %s: %v", pod.Namespace, err)
+			return err
+		}
+		for _, other := range others {
+			if isPodSelectedByVpcEgressGateway(pod, ns, other.Spec.Selectors) {
+				return fmt.Errorf("pod %s/%s is also selected by vpc egress gateway %s", pod.Namespace, pod.Name, other.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// vpcEgressGatewaySourceIPs returns the ipv4 and ipv6 addresses of the pods
+func vpcEgressGatewaySourceIPs(pods []*corev1.Pod) ([]string, []string) {
+	var v4s, v6s []string
+	for _, pod := range pods {
+		v4, v6 := util.SplitStringIP(pod.Annotations[util.IPAddressAnnotation])
+		if v4 != "" {
+			v4s = append(v4s, v4)
+		}
+		if v6 != "" {
+			v6s = append(v6s, v6)
+		}
+	}
+	return v4s, v6s
+}
+
+// activeVpcEgressGatewayNextHops returns the ipv4 and ipv6 addresses of the active instances
+func activeVpcEgressGatewayNextHops(instances []kubeovnv1.VpcEgressGatewayInstance) ([]string, []string) {
+	var v4s, v6s []string
+	for _, instance := range instances {
+		if !instance.Active {
+			continue
+		}
+		v4, v6 := util.SplitStringIP(instance.InternalIP)
+		if v4 != "" {
+			v4s = append(v4s, v4)
+		}
+		if v6 != "" {
+			v6s = append(v6s, v6)
+		}
+	}
+	return v4s, v6s
+}
+
+func (c *Controller) reconcileVpcEgressGatewayPolicies(gw *kubeovnv1.VpcEgressGateway, vpc *kubeovnv1.Vpc, pods []*corev1.Pod, instances []kubeovnv1.VpcEgressGatewayInstance) error {
+	var err error
+	v4Sources, v6Sources := vpcEgressGatewaySourceIPs(pods)
+	v4NextHops, v6NextHops := activeVpcEgressGatewayNextHops(instances)
+
+	for _, af := range []struct {
+		version  int
+		asName   string
+		sources  []string
+		nextHops []string
+	}{
+		{4, ovs.GetVpcEgressGatewayV4AddressSetName(gw.Name), v4Sources, v4NextHops},
+		{6, ovs.GetVpcEgressGatewayV6AddressSetName(gw.Name), v6Sources, v6NextHops},
+	} {
+		// the address set is created before the policy route referencing it
+		if err = c.OVNNbClient.CreateAddressSet(af.asName, map[string]string{vpcEgressGatewayKey: gw.Name}); err != nil {
+			klog.Errorf("failed to create address set %s for vpc egress gateway %s: %v", af.asName, gw.Name, err)
+			return err
+		}
+		if err = c.OVNNbClient.AddressSetUpdateAddress(af.asName, af.sources...); err != nil {
+			klog.Errorf("failed to update addresses of address set %s for vpc egress gateway %s: %v", af.asName, gw.Name, err)
+			return err
+		}
+
+		match := fmt.Sprintf("ip%d.src == $%s", af.version, af.asName)
+		if len(af.nextHops) == 0 {
+			// the selected pods fall back to the default egress path until an instance becomes active
+			if err = c.OVNNbClient.DeleteLogicalRouterPolicy(vpc.Status.Router, util.EgressGatewayPolicyPriority, match); err != nil {
+				klog.Errorf("failed to delete policy route %q of vpc egress gateway %s: %v", match, gw.Name, err)
+				return err
+			}
+			continue
+		}
+
+		externalIDs := map[string]string{
+			"vendor":            util.CniTypeName,
+			vpcEgressGatewayKey: gw.Name,
+			"af":                fmt.Sprint(af.version),
+		}
+		sort.Strings(af.nextHops)
+		if err = c.OVNNbClient.AddLogicalRouterPolicy(vpc.Status.Router, util.EgressGatewayPolicyPriority, match,
+			ovnnb.LogicalRouterPolicyActionReroute, af.nextHops, externalIDs); err != nil {
+			klog.Errorf("failed to add policy route %q of vpc egress gateway %s: %v", match, gw.Name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Controller) updateVpcEgressGatewayStatus(gw *kubeovnv1.VpcEgressGateway, status kubeovnv1.VpcEgressGatewayStatus) error {
+	if reflect.DeepEqual(gw.Status, status) {
+		return nil
+	}
+	gw = gw.DeepCopy()
+	gw.Status = status
+	if _, err := c.config.KubeOvnClient.KubeovnV1().VpcEgressGateways().UpdateStatus(context.Background(), gw, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("failed to update status of vpc egress gateway %s: %v", gw.Name, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) handleAddOrUpdateVpcEgressGateway(key string) error {
+	c.vpcEgressGatewayKeyMutex.LockKey(key)
+	defer func() { _ = c.vpcEgressGatewayKeyMutex.UnlockKey(key) }()
+
+	gw, err := c.vpcEgressGatewaysLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	klog.Infof("handle add/update vpc egress gateway %s", key)
+
+	vpc, internalSubnet, externalSubnet, err := c.validateVpcEgressGateway(gw)
+	if err != nil {
+		klog.Errorf("failed to validate vpc egress gateway %s: %v", key, err)
+		status := gw.Status.DeepCopy()
+		status.Ready, status.Message = false, err.Error()
+		if err := c.updateVpcEgressGatewayStatus(gw, *status); err != nil {
+			return err
+		}
+		return err
+	}
+
+	pods, err := c.vpcEgressGatewaySelectedPods(gw, vpc.Name)
+	if err != nil {
+		return err
+	}
+	if err = c.checkVpcEgressGatewayOverlap(gw, vpc.Name, pods); err != nil {
+		klog.Errorf("failed to validate selectors of vpc egress gateway %s: %v", key, err)
+		// the pods are routed by the policy routes of the vpc egress gateway created earlier
+		if err := c.OVNNbClient.DeleteLogicalRouterPolicies(vpc.Status.Router, util.EgressGatewayPolicyPriority, map[string]string{vpcEgressGatewayKey: gw.Name}); err != nil {
+			klog.Errorf("failed to delete policy routes of vpc egress gateway %s: %v", gw.Name, err)
+			return err
+		}
+		status := gw.Status.DeepCopy()
+		status.Ready, status.Message = false, err.Error()
+		if err := c.updateVpcEgressGatewayStatus(gw, *status); err != nil {
+			return err
+		}
+		return err
+	}
+
+	if err = c.reconcileVpcEgressGatewayStatefulSet(gw, vpc, internalSubnet, externalSubnet); err != nil {
+		return err
+	}
+
+	instances, ready, err := c.vpcEgressGatewayInstances(gw, externalSubnet)
+	if err != nil {
+		return err
+	}
+	lrpName := fmt.Sprintf("%s-%s", vpc.Status.Router, internalSubnet.Name)
+	if err = c.reconcileVpcEgressGatewayBFD(gw, lrpName, instances, ready); err != nil {
+		return err
+	}
+	if err = c.reconcileVpcEgressGatewayPolicies(gw, vpc, pods, instances); err != nil {
+		return err
+	}
+
+	status := kubeovnv1.VpcEgressGatewayStatus{Instances: instances}
+	for _, instance := range instances {
+		if instance.Active {
+			status.ActiveInstances++
+		}
+	}
+	status.Ready = status.ActiveInstances != 0
+	if !status.Ready {
+		status.Message = "no active instance"
+	}
+	if err = c.updateVpcEgressGatewayStatus(gw, status); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) handleDelVpcEgressGateway(gw *kubeovnv1.VpcEgressGateway) error {
+	c.vpcEgressGatewayKeyMutex.LockKey(gw.Name)
+	defer func() { _ = c.vpcEgressGatewayKeyMutex.UnlockKey(gw.Name) }()
+	klog.Infof("handle delete vpc egress gateway %s", gw.Name)
+
+	name := util.GenVpcEgressGatewayStsName(gw.Name)
+	if err := c.config.KubeClient.AppsV1().StatefulSets(c.config.PodNamespace).Delete(context.Background(), name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		klog.Errorf("failed to delete statefulset %s: %v", name, err)
+		return err
+	}
+
+	vpcName := vpcEgressGatewayVpc(gw, c.config.ClusterRouter)
+	vpc, err := c.vpcsLister.Get(vpcName)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		klog.Errorf("failed to get vpc %s: %v", vpcName, err)
+		return err
+	}
+	if err == nil && vpc.Status.Router != "" {
+		if err = c.OVNNbClient.DeleteLogicalRouterPolicies(vpc.Status.Router, util.EgressGatewayPolicyPriority, map[string]string{vpcEgressGatewayKey: gw.Name}); err != nil {
+			klog.Errorf("failed to delete policy routes of vpc egress gateway %s: %v", gw.Name, err)
+			return err
+		}
+		lrpName := fmt.Sprintf("%s-%s", vpc.Status.Router, gw.Spec.InternalSubnet)
+		for _, instance := range gw.Status.Instances {
+			for _, ip := range strings.Split(instance.InternalIP, ",") {
+				if err = c.OVNNbClient.DeleteBFD(lrpName, ip); err != nil {
+					klog.Errorf("failed to delete bfd session to %s of vpc egress gateway %s: %v", ip, gw.Name, err)
+					return err
+				}
+			}
+		}
+	}
+
+	if err = c.OVNNbClient.DeleteAddressSets(map[string]string{vpcEgressGatewayKey: gw.Name}); err != nil {
+		klog.Errorf("failed to delete address sets of vpc egress gateway %s: %v", gw.Name, err)
+		return err
+	}
+
+	// the pods selected by the deleted gateway may be routed by the other gateways now
+	c.enqueueVpcEgressGatewaysInVpc(vpcName, gw.Name)
+	return nil
+}
@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	kubeovnlister "github.com/kubeovn/kube-ovn/pkg/client/listers/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func Test_isPodSelectedByVpcEgressGateway(t *testing.T) {
+	t.Parallel()
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1", Labels: map[string]string{"team": "a"}}}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1", Labels: map[string]string{"app": "web"}}}
+
+	require.False(t, isPodSelectedByVpcEgressGateway(pod, ns, nil))
+	require.True(t, isPodSelectedByVpcEgressGateway(pod, ns, []kubeovnv1.VpcEgressGatewaySelector{{}}))
+	require.True(t, isPodSelectedByVpcEgressGateway(pod, ns, []kubeovnv1.VpcEgressGatewaySelector{{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+		PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+	}}))
+	require.False(t, isPodSelectedByVpcEgressGateway(pod, ns, []kubeovnv1.VpcEgressGatewaySelector{{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}},
+	}}))
+	require.True(t, isPodSelectedByVpcEgressGateway(pod, ns, []kubeovnv1.VpcEgressGatewaySelector{
+		{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}},
+		{PodSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      "app",
+			Operator: metav1.LabelSelectorOpIn,
+			Values:   []string{"web", "api"},
+		}}}},
+	}))
+}
+
+func Test_vpcEgressGatewayAttachment(t *testing.T) {
+	t.Parallel()
+
+	ns, name, err := vpcEgressGatewayAttachment("macvlan.kube-system")
+	require.NoError(t, err)
+	require.Equal(t, "kube-system", ns)
+	require.Equal(t, "macvlan", name)
+
+	ns, name, err = vpcEgressGatewayAttachment("attach.default.ovn")
+	require.NoError(t, err)
+	require.Equal(t, "default", ns)
+	require.Equal(t, "attach", name)
+
+	_, _, err = vpcEgressGatewayAttachment(util.OvnProvider)
+	require.Error(t, err)
+	_, _, err = vpcEgressGatewayAttachment("")
+	require.Error(t, err)
+}
+
+func Test_activeVpcEgressGatewayNextHops(t *testing.T) {
+	t.Parallel()
+
+	v4s, v6s := activeVpcEgressGatewayNextHops([]kubeovnv1.VpcEgressGatewayInstance{
+		{Name: "gw-0", InternalIP: "10.0.0.2,fd00::2", Active: true},
+		{Name: "gw-1", InternalIP: "10.0.0.3,fd00::3"},
+		{Name: "gw-2", InternalIP: "10.0.0.4", Active: true},
+	})
+	require.Equal(t, []string{"10.0.0.2", "10.0.0.4"}, v4s)
+	require.Equal(t, []string{"fd00::2"}, v6s)
+}
+
+func Test_genVpcEgressGatewayStatefulSet(t *testing.T) {
+	t.Parallel()
+
+	gw := &kubeovnv1.VpcEgressGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw"},
+		Spec: kubeovnv1.VpcEgressGatewaySpec{
+			Replicas:       2,
+			InternalSubnet: "internal",
+			ExternalSubnet: "external",
+			ExternalIPs:    []string{"192.168.0.10", "192.168.0.11"},
+			BFD:            kubeovnv1.VpcEgressGatewayBFDConfig{Enabled: true},
+			NodeSelector:   []string{"kubernetes.io/os: linux", "invalid"},
+		},
+	}
+	internalSubnet := &kubeovnv1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "internal"},
+		Spec:       kubeovnv1.SubnetSpec{Gateway: "10.0.0.1", Provider: util.OvnProvider},
+	}
+	externalSubnet := &kubeovnv1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{Name: "external"},
+		Spec:       kubeovnv1.SubnetSpec{Gateway: "192.168.0.1", Provider: "macvlan.kube-system"},
+	}
+
+	sts := genVpcEgressGatewayStatefulSet(gw, "kube-ovn:test", internalSubnet, externalSubnet, []string{"10.0.0.0/24,10.0.0.1"})
+	require.Equal(t, util.GenVpcEgressGatewayStsName(gw.Name), sts.Name)
+	require.Equal(t, int32(2), *sts.Spec.Replicas)
+	require.Equal(t, gw.Name, sts.Spec.Template.Labels[util.VpcEgressGatewayLabel])
+
+	annotations := sts.Spec.Template.Annotations
+	require.Equal(t, "internal", annotations[util.LogicalSwitchAnnotation])
+	require.Equal(t, "kube-system/macvlan", annotations[util.AttachmentNetworkAnnotation])
+	require.Equal(t, "external", annotations["macvlan.kube-system.kubernetes.io/logical_switch"])
+	require.Equal(t, "192.168.0.10;192.168.0.11", annotations["macvlan.kube-system.kubernetes.io/ip_pool"])
+	require.NotContains(t, annotations, util.IPPoolAnnotation)
+
+	require.Equal(t, map[string]string{"kubernetes.io/os": "linux"}, sts.Spec.Template.Spec.NodeSelector)
+	require.Equal(t, []string{"bfd", "10.0.0.1"}, sts.Spec.Template.Spec.Containers[0].Args)
+	require.Equal(t, []string{"init", "192.168.0.1", "10.0.0.0/24,10.0.0.1"}, sts.Spec.Template.Spec.InitContainers[0].Args)
+
+	gw.Spec.BFD.Enabled = false
+	sts = genVpcEgressGatewayStatefulSet(gw, "kube-ovn:test", internalSubnet, externalSubnet, nil)
+	require.Equal(t, []string{"serve"}, sts.Spec.Template.Spec.Containers[0].Args)
+}
+
+func Test_checkVpcEgressGatewayOverlap(t *testing.T) {
+	t.Parallel()
+
+	nsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	podIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	subnetIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	gwIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+
+	require.NoError(t, subnetIndexer.Add(&kubeovnv1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "s1"}, Spec: kubeovnv1.SubnetSpec{Vpc: "vpc1"}}))
+	require.NoError(t, subnetIndexer.Add(&kubeovnv1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "s2"}, Spec: kubeovnv1.SubnetSpec{Vpc: "vpc2"}}))
+	for _, ns := range []string{"ns1", "ns2"} {
+		require.NoError(t, nsIndexer.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: ns, Labels: map[string]string{"name": ns}}}))
+	}
+	for _, pod := range []struct{ ns, name, app, subnet, ip string }{
+		{"ns1", "a", "a", "s1", "10.0.0.1"},
+		{"ns1", "b", "b", "s1", "10.0.0.2"},
+		{"ns2", "a", "a", "s1", "10.0.0.3,fd00::3"},
+		{"ns2", "c", "a", "s2", "10.0.0.4"},
+	} {
+		require.NoError(t, podIndexer.Add(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   pod.ns,
+				Name:        pod.name,
+				Labels:      map[string]string{"app": pod.app},
+				Annotations: map[string]string{util.LogicalSwitchAnnotation: pod.subnet, util.IPAddressAnnotation: pod.ip},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}))
+	}
+
+	now := metav1.Now()
+	later := metav1.NewTime(now.Add(time.Minute))
+	gw1 := &kubeovnv1.VpcEgressGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw1", CreationTimestamp: now},
+		Spec: kubeovnv1.VpcEgressGatewaySpec{Vpc: "vpc1", Selectors: []kubeovnv1.VpcEgressGatewaySelector{{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"name": "ns1"}},
+		}}},
+	}
+	gw2 := &kubeovnv1.VpcEgressGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw2", CreationTimestamp: later},
+		Spec: kubeovnv1.VpcEgressGatewaySpec{Vpc: "vpc1", Selectors: []kubeovnv1.VpcEgressGatewaySelector{{
+			PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}},
+		}}},
+	}
+	require.NoError(t, gwIndexer.Add(gw1))
+	require.NoError(t, gwIndexer.Add(gw2))
+
+	c := &Controller{
+		config:                  &Configuration{ClusterRouter: util.DefaultVpc},
+		namespacesLister:        listerv1.NewNamespaceLister(nsIndexer),
+		podsLister:              listerv1.NewPodLister(podIndexer),
+		subnetsLister:           kubeovnlister.NewSubnetLister(subnetIndexer),
+		vpcEgressGatewaysLister: kubeovnlister.NewVpcEgressGatewayLister(gwIndexer),
+	}
+
+	pods1, err := c.vpcEgressGatewaySelectedPods(gw1, "vpc1")
+	require.NoError(t, err)
+	require.Len(t, pods1, 2)
+	pods2, err := c.vpcEgressGatewaySelectedPods(gw2, "vpc1")
+	require.NoError(t, err)
+	require.Len(t, pods2, 2)
+	v4s, v6s := vpcEgressGatewaySourceIPs(pods2)
+	require.ElementsMatch(t, []string{"10.0.0.1", "10.0.0.3"}, v4s)
+	require.Equal(t, []string{"fd00::3"}, v6s)
+
+	// the gateway created later is rejected
+	require.NoError(t, c.checkVpcEgressGatewayOverlap(gw1, "vpc1", pods1))
+	require.ErrorContains(t, c.checkVpcEgressGatewayOverlap(gw2, "vpc1", pods2), "ns1/a is also selected by vpc egress gateway gw1")
+	for _, pod := range pods2 {
+		if pod.Namespace == "ns2" {
+			require.NoError(t, c.checkVpcEgressGatewayOverlap(gw2, "vpc1", []*corev1.Pod{pod}))
+		}
+	}
+}
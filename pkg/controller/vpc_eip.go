@@ -0,0 +1,780 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// kinds of the backend neutral eip and nat rules, which own their implementation objects
+const (
+	vpcEipKind      = "VpcEip"
+	vpcFipKind      = "VpcFip"
+	vpcDnatRuleKind = "VpcDnatRule"
+	vpcSnatRuleKind = "VpcSnatRule"
+)
+
+func (c *Controller) enqueueAddVpcEip(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue add vpc eip %s", key)
+	c.syncVpcEipQueue.Add(key)
+}
+
+func (c *Controller) enqueueUpdateVpcEip(oldObj, newObj interface{}) {
+	oldEip := oldObj.(*kubeovnv1.VpcEip)
+	newEip := newObj.(*kubeovnv1.VpcEip)
+	if !reflect.DeepEqual(oldEip.Spec, newEip.Spec) {
+		klog.V(3).Infof("enqueue update vpc eip %s", newEip.Name)
+		c.syncVpcEipQueue.Add(newEip.Name)
+	}
+	if oldEip.Status.Backend != newEip.Status.Backend ||
+		oldEip.Status.V4Ip != newEip.Status.V4Ip ||
+		oldEip.Status.Ready != newEip.Status.Ready ||
+		oldEip.Status.TargetBackend != newEip.Status.TargetBackend ||
+		oldEip.Status.TargetReady != newEip.Status.TargetReady {
+		// the nat rules follow the backend, the migration target and the address of the eip
+		c.enqueueVpcNatRulesForEip(newEip.Name)
+	}
+}
+
+func (c *Controller) enqueueAddVpcFip(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue add vpc fip %s", key)
+	c.syncVpcFipQueue.Add(key)
+}
+
+func (c *Controller) enqueueUpdateVpcFip(oldObj, newObj interface{}) {
+	oldFip := oldObj.(*kubeovnv1.VpcFip)
+	newFip := newObj.(*kubeovnv1.VpcFip)
+	if oldFip.Status.TargetReady != newFip.Status.TargetReady {
+		// the eip being migrated waits for its nat rules to be ready in the target backend
+		c.syncVpcEipQueue.Add(newFip.Spec.Eip)
+	}
+	if reflect.DeepEqual(oldFip.Spec, newFip.Spec) {
+		return
+	}
+	klog.V(3).Infof("enqueue update vpc fip %s", newFip.Name)
+	c.syncVpcFipQueue.Add(newFip.Name)
+}
+
+func (c *Controller) enqueueAddVpcDnatRule(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue add vpc dnat rule %s", key)
+	c.syncVpcDnatRuleQueue.Add(key)
+}
+
+func (c *Controller) enqueueUpdateVpcDnatRule(oldObj, newObj interface{}) {
+	oldDnat := oldObj.(*kubeovnv1.VpcDnatRule)
+	newDnat := newObj.(*kubeovnv1.VpcDnatRule)
+	if oldDnat.Status.TargetReady != newDnat.Status.TargetReady {
+		// the eip being migrated waits for its nat rules to be ready in the target backend
+		c.syncVpcEipQueue.Add(newDnat.Spec.Eip)
+	}
+	if reflect.DeepEqual(oldDnat.Spec, newDnat.Spec) {
+		return
+	}
+	klog.V(3).Infof("enqueue update vpc dnat rule %s", newDnat.Name)
+	c.syncVpcDnatRuleQueue.Add(newDnat.Name)
+}
+
+func (c *Controller) enqueueAddVpcSnatRule(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue add vpc snat rule %s", key)
+	c.syncVpcSnatRuleQueue.Add(key)
+}
+
+func (c *Controller) enqueueUpdateVpcSnatRule(oldObj, newObj interface{}) {
+	oldSnat := oldObj.(*kubeovnv1.VpcSnatRule)
+	newSnat := newObj.(*kubeovnv1.VpcSnatRule)
+	if oldSnat.Status.TargetReady != newSnat.Status.TargetReady {
+		// the eip being migrated waits for its nat rules to be ready in the target backend
+		c.syncVpcEipQueue.Add(newSnat.Spec.Eip)
+	}
+	if reflect.DeepEqual(oldSnat.Spec, newSnat.Spec) {
+		return
+	}
+	klog.V(3).Infof("enqueue update vpc snat rule %s", newSnat.Name)
+	c.syncVpcSnatRuleQueue.Add(newSnat.Name)
+}
+
+// enqueueVpcNatOwner enqueues the vpc eip or nat rule owning the changed implementation object,
+// so that its status follows the status of the backend
+func (c *Controller) enqueueVpcNatOwner(obj interface{}) {
+	if t, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = t.Obj
+	}
+	o, ok := obj.(metav1.Object)
+	if !ok {
+		klog.Warningf("unexpected type: %T", obj)
+		return
+	}
+	owner := metav1.GetControllerOf(o)
+	if owner == nil || owner.APIVersion != kubeovnv1.SchemeGroupVersion.String() {
+		return
+	}
+	switch owner.Kind {
+	case vpcEipKind:
+		c.syncVpcEipQueue.Add(owner.Name)
+	case vpcFipKind:
+		c.syncVpcFipQueue.Add(owner.Name)
+	case vpcDnatRuleKind:
+		c.syncVpcDnatRuleQueue.Add(owner.Name)
+	case vpcSnatRuleKind:
+		c.syncVpcSnatRuleQueue.Add(owner.Name)
+	}
+}
+
+func (c *Controller) enqueueUpdateVpcNatOwner(_, newObj interface{}) {
+	c.enqueueVpcNatOwner(newObj)
+}
+
+// enqueueVpcEipsForVpc enqueues the vpc eips of the vpc whose nat backend may have changed
+func (c *Controller) enqueueVpcEipsForVpc(vpcName string) {
+	eips, err := c.vpcEipsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc eips: %v", err)
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, eip := range eips {
+		if eip.Spec.Vpc == vpcName {
+			klog.V(3).Infof("enqueue update vpc eip %s for vpc %s", eip.Name, vpcName)
+			c.syncVpcEipQueue.Add(eip.Name)
+		}
+	}
+}
+
+func (c *Controller) enqueueVpcEipsForNatGw(obj interface{}) {
+	if t, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = t.Obj
+	}
+	gw, ok := obj.(*kubeovnv1.VpcNatGateway)
+	if !ok {
+		klog.Warningf("unexpected type: %T", obj)
+		return
+	}
+	c.enqueueVpcEipsForVpc(gw.Spec.Vpc)
+}
+
+func (c *Controller) enqueueVpcNatRulesForEip(eipName string) {
+	fips, err := c.vpcFipsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc fips: %v", err)
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, fip := range fips {
+		if fip.Spec.Eip == eipName {
+			c.syncVpcFipQueue.Add(fip.Name)
+		}
+	}
+	dnats, err := c.vpcDnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc dnat rules: %v", err)
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, dnat := range dnats {
+		if dnat.Spec.Eip == eipName {
+			c.syncVpcDnatRuleQueue.Add(dnat.Name)
+		}
+	}
+	snats, err := c.vpcSnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc snat rules: %v", err)
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, snat := range snats {
+		if snat.Spec.Eip == eipName {
+			c.syncVpcSnatRuleQueue.Add(snat.Name)
+		}
+	}
+}
+
+func (c *Controller) runSyncVpcEipWorker() {
+	for c.processNextWorkItem("syncVpcEip", c.syncVpcEipQueue, c.handleSyncVpcEip) {
+	}
+}
+
+func (c *Controller) runSyncVpcFipWorker() {
+	for c.processNextWorkItem("syncVpcFip", c.syncVpcFipQueue, c.handleSyncVpcFip) {
+	}
+}
+
+func (c *Controller) runSyncVpcDnatRuleWorker() {
+	for c.processNextWorkItem("syncVpcDnatRule", c.syncVpcDnatRuleQueue, c.handleSyncVpcDnatRule) {
+	}
+}
+
+func (c *Controller) runSyncVpcSnatRuleWorker() {
+	for c.processNextWorkItem("syncVpcSnatRule", c.syncVpcSnatRuleQueue, c.handleSyncVpcSnatRule) {
+	}
+}
+
+// selectVpcNatBackend returns the nat backend of the vpc and the nat gateway implementing the iptables backend,
+// the iptables backend is the default if the vpc has a nat gateway and the ovn backend otherwise
+func selectVpcNatBackend(vpc *kubeovnv1.Vpc, gws []*kubeovnv1.VpcNatGateway, clusterRouter string) (string, string, error) {
+	var natGws []string
+	for _, gw := range gws {
+		if gw.Spec.Vpc == vpc.Name && gw.DeletionTimestamp.IsZero() {
+			natGws = append(natGws, gw.Name)
+		}
+	}
+	sort.Strings(natGws)
+
+	backend := vpc.Spec.NatBackend
+	if backend == "" {
+		backend = kubeovnv1.NatBackendOvn
+		if len(natGws) != 0 {
+			backend = kubeovnv1.NatBackendIptables
+		}
+	}
+
+	switch backend {
+	case kubeovnv1.NatBackendIptables:
+		if len(natGws) == 0 {
+			return backend, "", fmt.Errorf("vpc %s has no nat gateway for the %s nat backend", vpc.Name, backend)
+		}
+		return backend, natGws[0], nil
+	case kubeovnv1.NatBackendOvn:
+		if vpc.Name != clusterRouter && !vpc.Spec.EnableExternal {
+			return backend, "", fmt.Errorf("vpc %s must enable external for the %s nat backend", vpc.Name, backend)
+		}
+		return backend, "", nil
+	default:
+		return backend, "", fmt.Errorf("unsupported nat backend %q of vpc %s", backend, vpc.Name)
+	}
+}
+
+func (c *Controller) getVpcNatBackend(vpcName string) (string, string, error) {
+	vpc, err := c.vpcsLister.Get(vpcName)
+	if err != nil {
+		klog.Errorf("failed to get vpc %s: %v", vpcName, err)
+		return "", "", err
+	}
+	gws, err := c.vpcNatGatewayLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc nat gateways: %v", err)
+		return "", "", err
+	}
+	return selectVpcNatBackend(vpc, gws, c.config.ClusterRouter)
+}
+
+// adoptVpcNatImpl makes the owner the controller of an existing implementation object,
+// so that the objects created before the backend neutral api are taken over without recreation
+func adoptVpcNatImpl(impl, owner metav1.Object, kind string) (bool, error) {
+	if ref := metav1.GetControllerOf(impl); ref != nil {
+		if ref.UID != owner.GetUID() {
+			return false, fmt.Errorf("%s is already controlled by %s %s", impl.GetName(), ref.Kind, ref.Name)
+		}
+		return false, nil
+	}
+	refs := append(impl.GetOwnerReferences(), *metav1.NewControllerRef(owner, kubeovnv1.SchemeGroupVersion.WithKind(kind)))
+	impl.SetOwnerReferences(refs)
+	return true, nil
+}
+
+// handleSyncVpcEip creates the implementation object of the eip in the backend of the vpc. When the backend
+// changes, the eip is migrated make before break: the address is allocated for the name of the eip by the
+// implementation objects of both backends, so the eip and its nat rules are created in the new backend with
+// the address while the old one keeps serving them, and they are removed from the old backend once all of them
+// are ready in the new one. The address is announced by both backends until then. The migration is rejected
+// and the eip is kept in the old backend if the address can not be kept, i.e. neither spec.v4Ip nor
+// spec.externalSubnet is set. The iptables eip can not be created in another nat gateway of the iptables
+// backend before it is removed from the old one, so it is moved between the nat gateways break before make.
+func (c *Controller) handleSyncVpcEip(key string) error {
+	cachedEip, err := c.vpcEipsLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// the implementation objects are garbage collected with the owner
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	if !cachedEip.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	klog.Infof("handle sync vpc eip %s", key)
+
+	backend, natGw, err := c.getVpcNatBackend(cachedEip.Spec.Vpc)
+	if err != nil {
+		klog.Error(err)
+		status := cachedEip.Status
+		status.Ready, status.Message = false, err.Error()
+		if err2 := c.patchVpcEipStatus(cachedEip, status); err2 != nil {
+			klog.Error(err2)
+		}
+		return err
+	}
+
+	v4ip := cachedEip.Spec.V4Ip
+	if v4ip == "" && cachedEip.Spec.ExternalSubnet != "" {
+		// keep the address across the backends
+		v4ip = cachedEip.Status.V4Ip
+	}
+	if cachedEip.Status.Backend != "" && (cachedEip.Status.Backend != backend || cachedEip.Status.NatGateway != natGw) {
+		if cachedEip.Status.V4Ip != "" && v4ip == "" {
+			msg := fmt.Sprintf("migration to %s backend %q is rejected as address %s can not be kept, set spec.v4Ip or spec.externalSubnet to migrate",
+				backend, natGw, cachedEip.Status.V4Ip)
+			klog.Warningf("vpc eip %s: %s", key, msg)
+			if cachedEip.Status.Message != msg {
+				c.recorder.Event(cachedEip, corev1.EventTypeWarning, "MigrationRejected", msg)
+			}
+			status := cachedEip.Status
+			status.Message = msg
+			return c.patchVpcEipStatus(cachedEip, status)
+		}
+		if cachedEip.Status.Backend != backend {
+			return c.migrateVpcEip(cachedEip, backend, natGw, v4ip)
+		}
+	}
+
+	if cachedEip.Status.TargetBackend != "" {
+		// the migration is cancelled, e.g. the nat backend of the vpc is switched back before it completes
+		klog.Infof("cancel the migration of vpc eip %s to %s backend %q", key, cachedEip.Status.TargetBackend, cachedEip.Status.TargetNatGateway)
+		status := cachedEip.Status
+		if err = c.cleanVpcEipBackend(cachedEip, cachedEip.Status.TargetBackend); err != nil {
+			klog.Errorf("failed to clean %s backend of vpc eip %s: %v", cachedEip.Status.TargetBackend, key, err)
+			status.Message = err.Error()
+			if err2 := c.patchVpcEipStatus(cachedEip, status); err2 != nil {
+				klog.Error(err2)
+			}
+			return err
+		}
+		status.TargetBackend, status.TargetNatGateway, status.TargetReady, status.Message = "", "", false, ""
+		if err = c.patchVpcEipStatus(cachedEip, status); err != nil {
+			klog.Error(err)
+			return err
+		}
+		c.syncVpcEipQueue.Add(key)
+		return nil
+	}
+
+	if cachedEip.Status.Backend != "" && cachedEip.Status.NatGateway != natGw {
+		klog.Infof("moving vpc eip %s from nat gateway %q to %q", key, cachedEip.Status.NatGateway, natGw)
+		if cachedEip.Status.Ready {
+			c.recorder.Eventf(cachedEip, corev1.EventTypeWarning, "Migrating",
+				"moving from nat gateway %q to %q, the eip and its nat rules are unavailable until the move completes",
+				cachedEip.Status.NatGateway, natGw)
+		}
+		status := cachedEip.Status
+		if err = c.cleanVpcEipBackend(cachedEip, cachedEip.Status.Backend); err != nil {
+			klog.Errorf("failed to clean %s backend of vpc eip %s: %v", cachedEip.Status.Backend, key, err)
+			status.Ready, status.Message = false, err.Error()
+			if err2 := c.patchVpcEipStatus(cachedEip, status); err2 != nil {
+				klog.Error(err2)
+			}
+			return err
+		}
+		// switch the status to the new nat gateway, the nat rules are moved after the eip is ready
+		if err = c.patchVpcEipStatus(cachedEip, kubeovnv1.VpcEipStatus{Backend: backend, NatGateway: natGw, V4Ip: v4ip, Message: "migrating"}); err != nil {
+			klog.Error(err)
+			return err
+		}
+		return nil
+	}
+
+	ready, v4ip, err := c.syncVpcEipBackend(cachedEip, backend, natGw, v4ip)
+	if err != nil {
+		klog.Errorf("failed to sync %s backend of vpc eip %s: %v", backend, key, err)
+		if err2 := c.patchVpcEipStatus(cachedEip, kubeovnv1.VpcEipStatus{Backend: backend, NatGateway: natGw, V4Ip: v4ip, Message: err.Error()}); err2 != nil {
+			klog.Error(err2)
+		}
+		return err
+	}
+	return c.patchVpcEipStatus(cachedEip, kubeovnv1.VpcEipStatus{Backend: backend, NatGateway: natGw, V4Ip: v4ip, Ready: ready})
+}
+
+// migrateVpcEip creates the eip and its nat rules in the target backend with the address, and removes them
+// from the current backend once all of them are ready in the target one. The nat rules are created in the
+// target backend by their own handlers after the eip is ready there.
+func (c *Controller) migrateVpcEip(eip *kubeovnv1.VpcEip, backend, natGw, v4ip string) error {
+	status := eip.Status
+	if status.TargetBackend != backend || status.TargetNatGateway != natGw {
+		if status.TargetBackend != "" {
+			// the target is changed before the migration completes
+			if err := c.cleanVpcEipBackend(eip, status.TargetBackend); err != nil {
+				klog.Errorf("failed to clean %s backend of vpc eip %s: %v", status.TargetBackend, eip.Name, err)
+				return err
+			}
+		}
+		klog.Infof("migrating vpc eip %s from %s backend %q to %s backend %q", eip.Name, status.Backend, status.NatGateway, backend, natGw)
+		c.recorder.Eventf(eip, corev1.EventTypeNormal, "Migrating", "migrating from %s backend %q to %s backend %q",
+			status.Backend, status.NatGateway, backend, natGw)
+		status.TargetBackend, status.TargetNatGateway, status.TargetReady = backend, natGw, false
+	}
+
+	ready, v4ip, err := c.syncVpcEipBackend(eip, backend, natGw, v4ip)
+	if err != nil {
+		klog.Errorf("failed to sync %s backend of vpc eip %s: %v", backend, eip.Name, err)
+		status.TargetReady, status.Message = false, err.Error()
+		if err2 := c.patchVpcEipStatus(eip, status); err2 != nil {
+			klog.Error(err2)
+		}
+		return err
+	}
+	status.TargetReady, status.Message = ready, "migrating"
+	if !ready {
+		// the eip is enqueued again once its implementation object is ready
+		return c.patchVpcEipStatus(eip, status)
+	}
+
+	pending, err := c.vpcEipRulesNotInBackend(eip.Name, backend)
+	if err != nil {
+		return err
+	}
+	if len(pending) != 0 {
+		// the eip is enqueued again once the nat rules are ready in the target backend
+		status.Message = fmt.Sprintf("migrating, waiting for nat rules %v to be ready in the %s backend", pending, backend)
+		return c.patchVpcEipStatus(eip, status)
+	}
+	if err = c.patchVpcEipStatus(eip, status); err != nil {
+		klog.Error(err)
+		return err
+	}
+
+	if err = c.cleanVpcEipBackend(eip, eip.Status.Backend); err != nil {
+		klog.Errorf("failed to clean %s backend of vpc eip %s: %v", eip.Status.Backend, eip.Name, err)
+		return err
+	}
+	// switch the status to the new backend, the nat rules follow it
+	klog.Infof("migrated vpc eip %s to %s backend %q", eip.Name, backend, natGw)
+	return c.patchVpcEipStatus(eip, kubeovnv1.VpcEipStatus{Backend: backend, NatGateway: natGw, V4Ip: v4ip, Ready: true})
+}
+
+// vpcEipRulesNotInBackend returns the nat rules of the eip which are not ready in the target backend
+func (c *Controller) vpcEipRulesNotInBackend(eipName, backend string) ([]string, error) {
+	var pending []string
+	fips, err := c.vpcFipsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc fips: %v", err)
+		return nil, err
+	}
+	for _, fip := range fips {
+		if fip.Spec.Eip == eipName && (fip.Status.TargetBackend != backend || !fip.Status.TargetReady) {
+			pending = append(pending, fip.Name)
+		}
+	}
+	dnats, err := c.vpcDnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc dnat rules: %v", err)
+		return nil, err
+	}
+	for _, dnat := range dnats {
+		if dnat.Spec.Eip == eipName && (dnat.Status.TargetBackend != backend || !dnat.Status.TargetReady) {
+			pending = append(pending, dnat.Name)
+		}
+	}
+	snats, err := c.vpcSnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc snat rules: %v", err)
+		return nil, err
+	}
+	for _, snat := range snats {
+		if snat.Spec.Eip == eipName && (snat.Status.TargetBackend != backend || !snat.Status.TargetReady) {
+			pending = append(pending, snat.Name)
+		}
+	}
+	return pending, nil
+}
+
+func (c *Controller) syncVpcEipBackend(eip *kubeovnv1.VpcEip, backend, natGw, v4ip string) (bool, string, error) {
+	switch backend {
+	case kubeovnv1.NatBackendIptables:
+		return c.syncVpcEipIptables(eip, natGw, v4ip)
+	case kubeovnv1.NatBackendOvn:
+		return c.syncVpcEipOvn(eip, v4ip)
+	}
+	return false, v4ip, nil
+}
+
+func (c *Controller) syncVpcEipIptables(eip *kubeovnv1.VpcEip, natGw, v4ip string) (bool, string, error) {
+	impl, err := c.iptablesEipsLister.Get(eip.Name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Error(err)
+			return false, v4ip, err
+		}
+		impl = &kubeovnv1.IptablesEIP{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            eip.Name,
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(eip, kubeovnv1.SchemeGroupVersion.WithKind(vpcEipKind))},
+			},
+			Spec: kubeovnv1.IptablesEipSpec{
+				V4ip:           v4ip,
+				NatGwDp:        natGw,
+				ExternalSubnet: eip.Spec.ExternalSubnet,
+			},
+		}
+		if _, err = c.config.KubeOvnClient.KubeovnV1().IptablesEIPs().Create(context.Background(), impl, metav1.CreateOptions{}); err != nil {
+			klog.Errorf("failed to create iptables eip %s: %v", eip.Name, err)
+			return false, v4ip, err
+		}
+		return false, v4ip, nil
+	}
+
+	impl = impl.DeepCopy()
+	adopted, err := adoptVpcNatImpl(impl, eip, vpcEipKind)
+	if err != nil {
+		return false, impl.Status.IP, err
+	}
+	if adopted {
+		if _, err = c.config.KubeOvnClient.KubeovnV1().IptablesEIPs().Update(context.Background(), impl, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("failed to adopt iptables eip %s: %v", eip.Name, err)
+			return false, impl.Status.IP, err
+		}
+	}
+	if impl.Spec.NatGwDp != natGw {
+		return false, impl.Status.IP, fmt.Errorf("iptables eip %s belongs to nat gateway %s rather than %s", impl.Name, impl.Spec.NatGwDp, natGw)
+	}
+	return impl.Status.Ready, impl.Status.IP, nil
+}
+
+func (c *Controller) syncVpcEipOvn(eip *kubeovnv1.VpcEip, v4ip string) (bool, string, error) {
+	impl, err := c.ovnEipsLister.Get(eip.Name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Error(err)
+			return false, v4ip, err
+		}
+		impl = &kubeovnv1.OvnEip{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            eip.Name,
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(eip, kubeovnv1.SchemeGroupVersion.WithKind(vpcEipKind))},
+			},
+			Spec: kubeovnv1.OvnEipSpec{
+				ExternalSubnet: eip.Spec.ExternalSubnet,
+				V4Ip:           v4ip,
+				Type:           util.NatUsingEip,
+			},
+		}
+		if _, err = c.config.KubeOvnClient.KubeovnV1().OvnEips().Create(context.Background(), impl, metav1.CreateOptions{}); err != nil {
+			klog.Errorf("failed to create ovn eip %s: %v", eip.Name, err)
+			return false, v4ip, err
+		}
+		return false, v4ip, nil
+	}
+
+	impl = impl.DeepCopy()
+	adopted, err := adoptVpcNatImpl(impl, eip, vpcEipKind)
+	if err != nil {
+		return false, impl.Status.V4Ip, err
+	}
+	if adopted {
+		if _, err = c.config.KubeOvnClient.KubeovnV1().OvnEips().Update(context.Background(), impl, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("failed to adopt ovn eip %s: %v", eip.Name, err)
+			return false, impl.Status.V4Ip, err
+		}
+	}
+	if impl.Spec.Type == util.Lsp {
+		return false, impl.Status.V4Ip, fmt.Errorf("ovn eip %s of type %s can not be used by nat", impl.Name, util.Lsp)
+	}
+	return impl.Status.Ready, impl.Status.V4Ip, nil
+}
+
+// isVpcEipAddressHeld returns whether the implementation object of the backend with the name holds an address.
+// The implementation objects of both backends allocate the address for the name of the eip, so that the eip is
+// migrated between the backends with the address, which is released with the last one of them.
+func (c *Controller) isVpcEipAddressHeld(backend, name string) bool {
+	switch backend {
+	case kubeovnv1.NatBackendIptables:
+		eip, err := c.iptablesEipsLister.Get(name)
+		return err == nil && eip.DeletionTimestamp.IsZero() && eip.Status.IP != ""
+	case kubeovnv1.NatBackendOvn:
+		eip, err := c.ovnEipsLister.Get(name)
+		return err == nil && eip.DeletionTimestamp.IsZero() && eip.Status.V4Ip != ""
+	}
+	return false
+}
+
+// cleanVpcEipBackend removes the implementation objects of the eip and its nat rules from the backend,
+// the nat rules are removed first as the eip in use can not be deleted
+func (c *Controller) cleanVpcEipBackend(eip *kubeovnv1.VpcEip, backend string) error {
+	var pending []string
+	fips, err := c.vpcFipsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc fips: %v", err)
+		return err
+	}
+	for _, fip := range fips {
+		if fip.Spec.Eip == eip.Name && (fip.Status.Backend == backend || fip.Status.TargetBackend == backend) {
+			exists, err := c.deleteVpcNatImpl(vpcFipKind, fip.Name, backend)
+			if err != nil {
+				return err
+			}
+			if exists {
+				pending = append(pending, fip.Name)
+			}
+		}
+	}
+	dnats, err := c.vpcDnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc dnat rules: %v", err)
+		return err
+	}
+	for _, dnat := range dnats {
+		if dnat.Spec.Eip == eip.Name && (dnat.Status.Backend == backend || dnat.Status.TargetBackend == backend) {
+			exists, err := c.deleteVpcNatImpl(vpcDnatRuleKind, dnat.Name, backend)
+			if err != nil {
+				return err
+			}
+			if exists {
+				pending = append(pending, dnat.Name)
+			}
+		}
+	}
+	snats, err := c.vpcSnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc snat rules: %v", err)
+		return err
+	}
+	for _, snat := range snats {
+		if snat.Spec.Eip == eip.Name && (snat.Status.Backend == backend || snat.Status.TargetBackend == backend) {
+			exists, err := c.deleteVpcNatImpl(vpcSnatRuleKind, snat.Name, backend)
+			if err != nil {
+				return err
+			}
+			if exists {
+				pending = append(pending, snat.Name)
+			}
+		}
+	}
+	if len(pending) != 0 {
+		return fmt.Errorf("waiting for nat rules %v of eip %s to be removed from the %s backend", pending, eip.Name, backend)
+	}
+
+	exists, err := c.deleteVpcNatImpl(vpcEipKind, eip.Name, backend)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("waiting for eip %s to be removed from the %s backend", eip.Name, backend)
+	}
+	return nil
+}
+
+// deleteVpcNatImpl deletes the implementation object of the kind in the backend,
+// and returns whether the object still exists
+func (c *Controller) deleteVpcNatImpl(kind, name, backend string) (bool, error) {
+	var (
+		obj    metav1.Object
+		err    error
+		delete func(context.Context, string, metav1.DeleteOptions) error
+	)
+	client := c.config.KubeOvnClient.KubeovnV1()
+	switch backend + "/" + kind {
+	case kubeovnv1.NatBackendIptables + "/" + vpcEipKind:
+		obj, err = c.iptablesEipsLister.Get(name)
+		delete = client.IptablesEIPs().Delete
+	case kubeovnv1.NatBackendIptables + "/" + vpcFipKind:
+		obj, err = c.iptablesFipsLister.Get(name)
+		delete = client.IptablesFIPRules().Delete
+	case kubeovnv1.NatBackendIptables + "/" + vpcDnatRuleKind:
+		obj, err = c.iptablesDnatRulesLister.Get(name)
+		delete = client.IptablesDnatRules().Delete
+	case kubeovnv1.NatBackendIptables + "/" + vpcSnatRuleKind:
+		obj, err = c.iptablesSnatRulesLister.Get(name)
+		delete = client.IptablesSnatRules().Delete
+	case kubeovnv1.NatBackendOvn + "/" + vpcEipKind:
+		obj, err = c.ovnEipsLister.Get(name)
+		delete = client.OvnEips().Delete
+	case kubeovnv1.NatBackendOvn + "/" + vpcFipKind:
+		obj, err = c.ovnFipsLister.Get(name)
+		delete = client.OvnFips().Delete
+	case kubeovnv1.NatBackendOvn + "/" + vpcDnatRuleKind:
+		obj, err = c.ovnDnatRulesLister.Get(name)
+		delete = client.OvnDnatRules().Delete
+	case kubeovnv1.NatBackendOvn + "/" + vpcSnatRuleKind:
+		obj, err = c.ovnSnatRulesLister.Get(name)
+		delete = client.OvnSnatRules().Delete
+	default:
+		return false, nil
+	}
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		klog.Error(err)
+		return false, err
+	}
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return true, nil
+	}
+
+	klog.Infof("delete %s %s from the %s backend", kind, name, backend)
+	if err = delete(context.Background(), name, metav1.DeleteOptions{}); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		klog.Errorf("failed to delete %s %s from the %s backend: %v", kind, name, backend, err)
+		return true, err
+	}
+	return true, nil
+}
+
+func (c *Controller) patchVpcEipStatus(eip *kubeovnv1.VpcEip, status kubeovnv1.VpcEipStatus) error {
+	if eip.Status == status {
+		return nil
+	}
+	bytes, err := status.Bytes()
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().VpcEips().Patch(context.Background(), eip.Name, types.MergePatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Errorf("failed to patch status of vpc eip %s: %v", eip.Name, err)
+		return err
+	}
+	return nil
+}
+
+// vpcNatRuleEip returns the eip of the nat rule if the eip is ready in its backend
+func (c *Controller) vpcNatRuleEip(eipName string) (*kubeovnv1.VpcEip, string, error) {
+	eip, err := c.vpcEipsLister.Get(eipName)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, fmt.Sprintf("vpc eip %s not found", eipName), nil
+		}
+		klog.Error(err)
+		return nil, "", err
+	}
+	if eip.Status.Backend == "" || !eip.Status.Ready {
+		// the rule is enqueued again once the eip is ready
+		return nil, fmt.Sprintf("waiting for vpc eip %s to be ready", eipName), nil
+	}
+	return eip, "", nil
+}
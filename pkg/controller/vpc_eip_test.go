@@ -0,0 +1,304 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	kubeovnfake "github.com/kubeovn/kube-ovn/pkg/client/clientset/versioned/fake"
+	kubeovnlister "github.com/kubeovn/kube-ovn/pkg/client/listers/kubeovn/v1"
+)
+
+func Test_selectVpcNatBackend(t *testing.T) {
+	t.Parallel()
+
+	vpc := &kubeovnv1.Vpc{ObjectMeta: metav1.ObjectMeta{Name: "vpc1"}}
+	gws := []*kubeovnv1.VpcNatGateway{
+		{ObjectMeta: metav1.ObjectMeta{Name: "gw2"}, Spec: kubeovnv1.VpcNatSpec{Vpc: "vpc1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gw1"}, Spec: kubeovnv1.VpcNatSpec{Vpc: "vpc1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "gw0"}, Spec: kubeovnv1.VpcNatSpec{Vpc: "vpc2"}},
+	}
+
+	// the iptables backend is the default of the vpc with nat gateways
+	backend, gw, err := selectVpcNatBackend(vpc, gws, "ovn-cluster")
+	require.NoError(t, err)
+	require.Equal(t, kubeovnv1.NatBackendIptables, backend)
+	require.Equal(t, "gw1", gw)
+
+	// the ovn backend requires the vpc to enable external
+	_, _, err = selectVpcNatBackend(vpc, nil, "ovn-cluster")
+	require.ErrorContains(t, err, "must enable external")
+	vpc.Spec.EnableExternal = true
+	backend, gw, err = selectVpcNatBackend(vpc, nil, "ovn-cluster")
+	require.NoError(t, err)
+	require.Equal(t, kubeovnv1.NatBackendOvn, backend)
+	require.Empty(t, gw)
+
+	vpc.Spec.NatBackend = kubeovnv1.NatBackendOvn
+	backend, gw, err = selectVpcNatBackend(vpc, gws, "ovn-cluster")
+	require.NoError(t, err)
+	require.Equal(t, kubeovnv1.NatBackendOvn, backend)
+	require.Empty(t, gw)
+
+	vpc.Spec.NatBackend = kubeovnv1.NatBackendIptables
+	_, _, err = selectVpcNatBackend(vpc, gws[2:], "ovn-cluster")
+	require.ErrorContains(t, err, "has no nat gateway")
+
+	backend, _, err = selectVpcNatBackend(&kubeovnv1.Vpc{ObjectMeta: metav1.ObjectMeta{Name: "ovn-cluster"}}, nil, "ovn-cluster")
+	require.NoError(t, err)
+	require.Equal(t, kubeovnv1.NatBackendOvn, backend)
+}
+
+func Test_adoptVpcNatImpl(t *testing.T) {
+	t.Parallel()
+
+	owner := &kubeovnv1.VpcEip{ObjectMeta: metav1.ObjectMeta{Name: "eip1", UID: types.UID("uid1")}}
+	impl := &kubeovnv1.IptablesEIP{ObjectMeta: metav1.ObjectMeta{Name: "eip1"}}
+
+	adopted, err := adoptVpcNatImpl(impl, owner, vpcEipKind)
+	require.NoError(t, err)
+	require.True(t, adopted)
+	require.True(t, metav1.IsControlledBy(impl, owner))
+
+	adopted, err = adoptVpcNatImpl(impl, owner, vpcEipKind)
+	require.NoError(t, err)
+	require.False(t, adopted)
+
+	other := &kubeovnv1.VpcEip{ObjectMeta: metav1.ObjectMeta{Name: "eip1", UID: types.UID("uid2")}}
+	_, err = adoptVpcNatImpl(impl, other, vpcEipKind)
+	require.Error(t, err)
+}
+
+func Test_handleSyncVpcEipMigration(t *testing.T) {
+	t.Parallel()
+
+	newIndexer := func(objects ...interface{}) cache.Indexer {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		for _, obj := range objects {
+			require.NoError(t, indexer.Add(obj))
+		}
+		return indexer
+	}
+	newEip := func(name, externalSubnet string) *kubeovnv1.VpcEip {
+		eip := &kubeovnv1.VpcEip{
+			ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name)},
+			Spec:       kubeovnv1.VpcEipSpec{Vpc: "vpc1", ExternalSubnet: externalSubnet},
+		}
+		eip.Status = kubeovnv1.VpcEipStatus{Backend: kubeovnv1.NatBackendIptables, NatGateway: "gw1", V4Ip: "172.18.0.10", Ready: true}
+		return eip
+	}
+
+	// the vpc is switched from the iptables backend to the ovn backend
+	vpc := &kubeovnv1.Vpc{ObjectMeta: metav1.ObjectMeta{Name: "vpc1"}}
+	vpc.Spec.EnableExternal, vpc.Spec.NatBackend = true, kubeovnv1.NatBackendOvn
+	eip1, eip2 := newEip("eip1", "external"), newEip("eip2", "")
+	fip1 := &kubeovnv1.VpcFip{
+		ObjectMeta: metav1.ObjectMeta{Name: "fip1", UID: types.UID("fip1")},
+		Spec:       kubeovnv1.VpcFipSpec{Eip: "eip1", InternalIP: "10.0.1.2"},
+		Status:     kubeovnv1.VpcFipStatus{Backend: kubeovnv1.NatBackendIptables, V4Ip: "172.18.0.10", Ready: true},
+	}
+	iptablesEip1 := &kubeovnv1.IptablesEIP{ObjectMeta: metav1.ObjectMeta{Name: "eip1"}, Spec: kubeovnv1.IptablesEipSpec{NatGwDp: "gw1"}}
+	iptablesEip2 := &kubeovnv1.IptablesEIP{ObjectMeta: metav1.ObjectMeta{Name: "eip2"}, Spec: kubeovnv1.IptablesEipSpec{NatGwDp: "gw1"}}
+	iptablesFip1 := &kubeovnv1.IptablesFIPRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "fip1"},
+		Spec:       kubeovnv1.IptablesFIPRuleSpec{EIP: "eip1", InternalIP: "10.0.1.2"},
+		Status:     kubeovnv1.IptablesFIPRuleStatus{Ready: true},
+	}
+
+	// the objects are created by the typed clients whose resource names can not be guessed from the kinds
+	client := kubeovnfake.NewSimpleClientset()
+	for _, eip := range []*kubeovnv1.VpcEip{eip1, eip2} {
+		_, err := client.KubeovnV1().VpcEips().Create(context.Background(), eip, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	for _, eip := range []*kubeovnv1.IptablesEIP{iptablesEip1, iptablesEip2} {
+		_, err := client.KubeovnV1().IptablesEIPs().Create(context.Background(), eip, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	_, err := client.KubeovnV1().VpcFips().Create(context.Background(), fip1, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = client.KubeovnV1().IptablesFIPRules().Create(context.Background(), iptablesFip1, metav1.CreateOptions{})
+	require.NoError(t, err)
+	vpcEips, vpcFips := newIndexer(eip1, eip2), newIndexer(fip1)
+	iptablesEips, iptablesFips := newIndexer(iptablesEip1, iptablesEip2), newIndexer(iptablesFip1)
+	ovnEips, ovnFips := newIndexer(), newIndexer()
+	c := &Controller{
+		config:                  &Configuration{KubeOvnClient: client, ClusterRouter: "ovn-cluster"},
+		recorder:                record.NewFakeRecorder(10),
+		syncVpcEipQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "SyncVpcEip"),
+		vpcsLister:              kubeovnlister.NewVpcLister(newIndexer(vpc)),
+		vpcNatGatewayLister:     kubeovnlister.NewVpcNatGatewayLister(newIndexer()),
+		vpcEipsLister:           kubeovnlister.NewVpcEipLister(vpcEips),
+		vpcFipsLister:           kubeovnlister.NewVpcFipLister(vpcFips),
+		vpcDnatRulesLister:      kubeovnlister.NewVpcDnatRuleLister(newIndexer()),
+		vpcSnatRulesLister:      kubeovnlister.NewVpcSnatRuleLister(newIndexer()),
+		iptablesEipsLister:      kubeovnlister.NewIptablesEIPLister(iptablesEips),
+		ovnEipsLister:           kubeovnlister.NewOvnEipLister(ovnEips),
+		iptablesFipsLister:      kubeovnlister.NewIptablesFIPRuleLister(iptablesFips),
+		ovnFipsLister:           kubeovnlister.NewOvnFipLister(ovnFips),
+		iptablesDnatRulesLister: kubeovnlister.NewIptablesDnatRuleLister(newIndexer()),
+		iptablesSnatRulesLister: kubeovnlister.NewIptablesSnatRuleLister(newIndexer()),
+	}
+	syncEip := func(name string) (*kubeovnv1.VpcEip, error) {
+		t.Helper()
+		err := c.handleSyncVpcEip(name)
+		eip, err2 := client.KubeovnV1().VpcEips().Get(context.Background(), name, metav1.GetOptions{})
+		require.NoError(t, err2)
+		require.NoError(t, vpcEips.Update(eip))
+		return eip, err
+	}
+	syncFip := func(name string) *kubeovnv1.VpcFip {
+		t.Helper()
+		require.NoError(t, c.handleSyncVpcFip(name))
+		fip, err := client.KubeovnV1().VpcFips().Get(context.Background(), name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.NoError(t, vpcFips.Update(fip))
+		return fip
+	}
+
+	// the migration of the eip whose address can not be kept is rejected
+	eip, err := syncEip("eip2")
+	require.NoError(t, err)
+	require.Equal(t, kubeovnv1.NatBackendIptables, eip.Status.Backend)
+	require.True(t, eip.Status.Ready)
+	require.Empty(t, eip.Status.TargetBackend)
+	require.Contains(t, eip.Status.Message, "rejected")
+	_, err = client.KubeovnV1().IptablesEIPs().Get(context.Background(), "eip2", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	// the eip is created in the new backend with the address while the old backend keeps serving it
+	eip, err = syncEip("eip1")
+	require.NoError(t, err)
+	require.Equal(t, kubeovnv1.VpcEipStatus{
+		Backend: kubeovnv1.NatBackendIptables, NatGateway: "gw1", V4Ip: "172.18.0.10", Ready: true, Message: "migrating",
+		TargetBackend: kubeovnv1.NatBackendOvn,
+	}, eip.Status)
+	ovnEip, err := client.KubeovnV1().OvnEips().Get(context.Background(), "eip1", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "172.18.0.10", ovnEip.Spec.V4Ip)
+	require.Equal(t, "external", ovnEip.Spec.ExternalSubnet)
+	require.True(t, metav1.IsControlledBy(ovnEip, eip))
+	_, err = client.KubeovnV1().IptablesEIPs().Get(context.Background(), "eip1", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	// the nat rules are created in the new backend once the eip is ready there
+	ovnEip.Status = kubeovnv1.OvnEipStatus{V4Ip: "172.18.0.10", Ready: true}
+	require.NoError(t, ovnEips.Add(ovnEip))
+	eip, err = syncEip("eip1")
+	require.NoError(t, err)
+	require.True(t, eip.Status.TargetReady)
+	require.Contains(t, eip.Status.Message, "waiting for nat rules [fip1]")
+
+	fip := syncFip("fip1")
+	require.Equal(t, kubeovnv1.VpcFipStatus{
+		Backend: kubeovnv1.NatBackendIptables, V4Ip: "172.18.0.10", Ready: true, TargetBackend: kubeovnv1.NatBackendOvn,
+	}, fip.Status)
+	ovnFip, err := client.KubeovnV1().OvnFips().Get(context.Background(), "fip1", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, kubeovnv1.OvnFipSpec{OvnEip: "eip1", Vpc: "vpc1", V4Ip: "10.0.1.2"}, ovnFip.Spec)
+	ovnFip.Status.Ready = true
+	require.NoError(t, ovnFips.Add(ovnFip))
+	fip = syncFip("fip1")
+	require.True(t, fip.Status.Ready)
+	require.True(t, fip.Status.TargetReady)
+
+	// the nat rules and then the eip are removed from the old backend
+	_, err = syncEip("eip1")
+	require.ErrorContains(t, err, "waiting for nat rules [fip1] of eip eip1 to be removed")
+	_, err = client.KubeovnV1().IptablesFIPRules().Get(context.Background(), "fip1", metav1.GetOptions{})
+	require.True(t, k8serrors.IsNotFound(err))
+	require.NoError(t, iptablesFips.Delete(iptablesFip1))
+	_, err = syncEip("eip1")
+	require.ErrorContains(t, err, "waiting for eip eip1 to be removed")
+	require.NoError(t, iptablesEips.Delete(iptablesEip1))
+
+	// the status is switched to the new backend and the nat rules follow it
+	eip, err = syncEip("eip1")
+	require.NoError(t, err)
+	require.Equal(t, kubeovnv1.VpcEipStatus{Backend: kubeovnv1.NatBackendOvn, V4Ip: "172.18.0.10", Ready: true}, eip.Status)
+	fip = syncFip("fip1")
+	require.Equal(t, kubeovnv1.VpcFipStatus{Backend: kubeovnv1.NatBackendOvn, V4Ip: "172.18.0.10", Ready: true}, fip.Status)
+}
+
+func Test_handleSyncVpcEipCancelMigration(t *testing.T) {
+	t.Parallel()
+
+	vpc := &kubeovnv1.Vpc{ObjectMeta: metav1.ObjectMeta{Name: "vpc1"}}
+	vpc.Spec.NatBackend = kubeovnv1.NatBackendIptables
+	gw := &kubeovnv1.VpcNatGateway{ObjectMeta: metav1.ObjectMeta{Name: "gw1"}, Spec: kubeovnv1.VpcNatSpec{Vpc: "vpc1"}}
+	// the vpc is switched back to the iptables backend before the migration to the ovn backend completes
+	eip := &kubeovnv1.VpcEip{
+		ObjectMeta: metav1.ObjectMeta{Name: "eip1", UID: types.UID("eip1")},
+		Spec:       kubeovnv1.VpcEipSpec{Vpc: "vpc1", V4Ip: "172.18.0.10"},
+		Status: kubeovnv1.VpcEipStatus{
+			Backend: kubeovnv1.NatBackendIptables, NatGateway: "gw1", V4Ip: "172.18.0.10", Ready: true, Message: "migrating",
+			TargetBackend: kubeovnv1.NatBackendOvn, TargetReady: true,
+		},
+	}
+	ovnEip := &kubeovnv1.OvnEip{ObjectMeta: metav1.ObjectMeta{Name: "eip1"}}
+
+	client := kubeovnfake.NewSimpleClientset()
+	_, err := client.KubeovnV1().VpcEips().Create(context.Background(), eip, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = client.KubeovnV1().OvnEips().Create(context.Background(), ovnEip, metav1.CreateOptions{})
+	require.NoError(t, err)
+	newIndexer := func(objects ...interface{}) cache.Indexer {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		for _, obj := range objects {
+			require.NoError(t, indexer.Add(obj))
+		}
+		return indexer
+	}
+	vpcEips, ovnEips := newIndexer(eip), newIndexer(ovnEip)
+	c := &Controller{
+		config:              &Configuration{KubeOvnClient: client, ClusterRouter: "ovn-cluster"},
+		recorder:            record.NewFakeRecorder(10),
+		syncVpcEipQueue:     workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "SyncVpcEip"),
+		vpcsLister:          kubeovnlister.NewVpcLister(newIndexer(vpc)),
+		vpcNatGatewayLister: kubeovnlister.NewVpcNatGatewayLister(newIndexer(gw)),
+		vpcEipsLister:       kubeovnlister.NewVpcEipLister(vpcEips),
+		vpcFipsLister:       kubeovnlister.NewVpcFipLister(newIndexer()),
+		vpcDnatRulesLister:  kubeovnlister.NewVpcDnatRuleLister(newIndexer()),
+		vpcSnatRulesLister:  kubeovnlister.NewVpcSnatRuleLister(newIndexer()),
+		ovnEipsLister:       kubeovnlister.NewOvnEipLister(ovnEips),
+	}
+
+	// the eip is removed from the target backend
+	err = c.handleSyncVpcEip("eip1")
+	require.ErrorContains(t, err, "waiting for eip eip1 to be removed from the ovn backend")
+	_, err = client.KubeovnV1().OvnEips().Get(context.Background(), "eip1", metav1.GetOptions{})
+	require.True(t, k8serrors.IsNotFound(err))
+
+	// and the migration target is cleared
+	require.NoError(t, ovnEips.Delete(ovnEip))
+	require.NoError(t, c.handleSyncVpcEip("eip1"))
+	eip, err = client.KubeovnV1().VpcEips().Get(context.Background(), "eip1", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, kubeovnv1.VpcEipStatus{Backend: kubeovnv1.NatBackendIptables, NatGateway: "gw1", V4Ip: "172.18.0.10", Ready: true}, eip.Status)
+}
+
+func Test_isVpcEipAddressHeld(t *testing.T) {
+	t.Parallel()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(&kubeovnv1.OvnEip{ObjectMeta: metav1.ObjectMeta{Name: "eip1"}, Status: kubeovnv1.OvnEipStatus{V4Ip: "172.18.0.10"}}))
+	require.NoError(t, indexer.Add(&kubeovnv1.OvnEip{ObjectMeta: metav1.ObjectMeta{Name: "eip2"}}))
+	c := &Controller{
+		ovnEipsLister:      kubeovnlister.NewOvnEipLister(indexer),
+		iptablesEipsLister: kubeovnlister.NewIptablesEIPLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})),
+	}
+
+	// the address of the iptables eip being migrated is kept for the ovn eip with the same name
+	require.True(t, c.isVpcEipAddressHeld(kubeovnv1.NatBackendOvn, "eip1"))
+	require.False(t, c.isVpcEipAddressHeld(kubeovnv1.NatBackendOvn, "eip2"))
+	require.False(t, c.isVpcEipAddressHeld(kubeovnv1.NatBackendOvn, "eip3"))
+	require.False(t, c.isVpcEipAddressHeld(kubeovnv1.NatBackendIptables, "eip1"))
+}
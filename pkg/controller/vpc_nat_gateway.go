@@ -3,10 +3,9 @@ package controller
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"reflect"
-	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -22,6 +21,7 @@ import (
 	"k8s.io/klog/v2"
 
 	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/natgw"
 	"github.com/kubeovn/kube-ovn/pkg/util"
 )
 
@@ -31,29 +31,6 @@ var (
 	natGwCreatedAT  = ""
 )
 
-const (
-	natGwInit              = "init"
-	natGwEipAdd            = "eip-add"
-	natGwEipDel            = "eip-del"
-	natGwDnatAdd           = "dnat-add"
-	natGwDnatDel           = "dnat-del"
-	natGwSnatAdd           = "snat-add"
-	natGwSnatDel           = "snat-del"
-	natGwEipIngressQoSAdd  = "eip-ingress-qos-add"
-	natGwEipIngressQoSDel  = "eip-ingress-qos-del"
-	QoSAdd                 = "qos-add"
-	QoSDel                 = "qos-del"
-	natGwEipEgressQoSAdd   = "eip-egress-qos-add"
-	natGwEipEgressQoSDel   = "eip-egress-qos-del"
-	natGwSubnetFipAdd      = "floating-ip-add"
-	natGwSubnetFipDel      = "floating-ip-del"
-	natGwSubnetRouteAdd    = "subnet-route-add"
-	natGwSubnetRouteDel    = "subnet-route-del"
-	natGwExtSubnetRouteAdd = "ext-subnet-route-add"
-
-	getIptablesVersion = "get-iptables-version"
-)
-
 func (c *Controller) resyncVpcNatGwConfig() {
 	cm, err := c.configMapsLister.ConfigMaps(c.config.PodNamespace).Get(util.VpcNatGatewayConfig)
 	if err != nil && !k8serrors.IsNotFound(err) {
@@ -202,6 +179,11 @@ func (c *Controller) handleDelVpcNatGw(key string) error {
 	defer func() { _ = c.vpcNatGwKeyMutex.UnlockKey(key) }()
 	name := util.GenNatGwStsName(key)
 	klog.Infof("delete vpc nat gw %s", name)
+	c.natGwAgents.remove(c.config.PodNamespace, key)
+	c.natGwVrids.release(key)
+	if err := c.deleteNatGwHAVip(key); err != nil {
+		return err
+	}
 	if err := c.config.KubeClient.AppsV1().StatefulSets(c.config.PodNamespace).Delete(context.Background(),
 		name, metav1.DeleteOptions{}); err != nil {
 		if k8serrors.IsNotFound(err) {
@@ -232,6 +214,17 @@ func isVpcNatGwChanged(gw *kubeovnv1.VpcNatGateway) bool {
 	return false
 }
 
+// natGwStsHasAgent checks whether the nat gateway pod runs the agent,
+// statefulsets created by previous versions are updated to run it
+func natGwStsHasAgent(sts *v1.StatefulSet) bool {
+	for _, container := range sts.Spec.Template.Spec.Containers {
+		if container.Name == "vpc-nat-gw" && len(container.Command) != 0 && container.Command[0] == natGwAgentCommand {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Controller) handleAddOrUpdateVpcNatGw(key string) error {
 	// create nat gw statefulset
 	c.vpcNatGwKeyMutex.LockKey(key)
@@ -259,6 +252,21 @@ func (c *Controller) handleAddOrUpdateVpcNatGw(key string) error {
 		klog.Error(err)
 		return err
 	}
+	if natGwHAEnabled(gw) && gw.Spec.LanIP == "" {
+		err = fmt.Errorf("lanIp of vpc nat gw %s is required in active/standby mode", gw.Name)
+		klog.Error(err)
+		return err
+	}
+	if natGwHAEnabled(gw) {
+		vrid, err := c.allocateNatGwVrid(gw)
+		if err != nil {
+			return err
+		}
+		gw = gw.DeepCopy()
+		gw.Status.Vrid = vrid
+	} else {
+		c.natGwVrids.release(gw.Name)
+	}
 
 	// check or create statefulset
 	needToCreate := false
@@ -273,8 +281,37 @@ func (c *Controller) handleAddOrUpdateVpcNatGw(key string) error {
 			return err
 		}
 	}
-	newSts := c.genNatGwStatefulSet(gw, oldSts.DeepCopy())
-	if !needToCreate && isVpcNatGwChanged(gw) {
+	vipReady, err := c.reconcileNatGwHAVip(gw)
+	if err != nil {
+		klog.Errorf("failed to reconcile vip of vpc nat gw %s: %v", key, err)
+		return err
+	}
+	if !vipReady {
+		if !needToCreate && !natGwStsHAEnabled(oldSts) {
+			// the lan ip is held by the single instance, release it before switching to active/standby mode
+			klog.Infof("delete statefulset %s to release lan ip %s", oldSts.Name, gw.Spec.LanIP)
+			if err = c.config.KubeClient.AppsV1().StatefulSets(c.config.PodNamespace).
+				Delete(context.Background(), oldSts.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+				klog.Error(err)
+				return err
+			}
+		}
+		err = fmt.Errorf("vip of vpc nat gw %s is not ready", key)
+		klog.Error(err)
+		return err
+	}
+	if _, err = c.getNatGwAgentToken(); err != nil {
+		klog.Errorf("failed to get token of nat gw agent: %v", err)
+		return err
+	}
+	newSts, err := c.genNatGwStatefulSet(gw, oldSts.DeepCopy())
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if !needToCreate && (isVpcNatGwChanged(gw) || !natGwStsHasAgent(oldSts) ||
+		oldSts.Spec.Replicas == nil || *oldSts.Spec.Replicas != natGwReplicas(gw) ||
+		(natGwHAEnabled(gw) && natGwStsVrid(oldSts) != gw.Status.Vrid)) {
 		needToUpdate = true
 	}
 
@@ -306,17 +343,9 @@ func (c *Controller) handleAddOrUpdateVpcNatGw(key string) error {
 	default:
 		// check if need to change qos
 		if gw.Spec.QoSPolicy != gw.Status.QoSPolicy {
-			if gw.Status.QoSPolicy != "" {
-				if err = c.execNatGwQoS(gw, gw.Status.QoSPolicy, QoSDel); err != nil {
-					klog.Errorf("failed to add qos for nat gw %s, %v", key, err)
-					return err
-				}
-			}
-			if gw.Spec.QoSPolicy != "" {
-				if err = c.execNatGwQoS(gw, gw.Spec.QoSPolicy, QoSAdd); err != nil {
-					klog.Errorf("failed to del qos for nat gw %s, %v", key, err)
-					return err
-				}
+			if err = c.syncVpcNatGwRules(gw); err != nil {
+				klog.Errorf("failed to update qos for nat gw %s, %v", key, err)
+				return err
 			}
 			if err := c.updateCrdNatGwLabels(key, gw.Spec.QoSPolicy); err != nil {
 				err := fmt.Errorf("failed to update nat gw %s: %v", gw.Name, err)
@@ -353,36 +382,43 @@ func (c *Controller) handleInitVpcNatGw(key string) error {
 	}
 	// subnet for vpc-nat-gw has been checked when create vpc-nat-gw
 
-	oriPod, err := c.getNatGwPod(key)
+	pods, err := c.getNatGwPods(key)
 	if err != nil {
 		err := fmt.Errorf("failed to get nat gw %s pod: %v", gw.Name, err)
 		klog.Error(err)
 		return err
 	}
-	pod := oriPod.DeepCopy()
-
-	if pod.Status.Phase != corev1.PodRunning {
-		time.Sleep(10 * time.Second)
-		return fmt.Errorf("failed to init vpc nat gateway, pod is not ready")
+	// in active/standby mode, the instances are initialized one by one as they become running
+	for _, pod := range pods {
+		if err = c.initVpcNatGwPod(gw, pod); err != nil {
+			return err
+		}
+	}
+	if replicas := natGwReplicas(gw); len(pods) < int(replicas) {
+		// retry until the other instances are running
+		err = fmt.Errorf("%d of %d instances of vpc nat gw %s are running", len(pods), replicas, key)
+		klog.Error(err)
+		return err
 	}
+	return nil
+}
 
+func (c *Controller) initVpcNatGwPod(gw *kubeovnv1.VpcNatGateway, oriPod *corev1.Pod) error {
+	key := gw.Name
+	pod := oriPod.DeepCopy()
 	if _, hasInit := pod.Annotations[util.VpcNatGatewayInitAnnotation]; hasInit {
 		return nil
 	}
 	natGwCreatedAT = pod.CreationTimestamp.Format("2006-01-02T15:04:05")
-	klog.V(3).Infof("nat gw pod '%s' inited at %s", key, natGwCreatedAT)
-	if err = c.execNatGwRules(pod, natGwInit, []string{fmt.Sprintf("%s,%s", c.config.ServiceClusterIPRange, pod.Annotations[util.GatewayAnnotation])}); err != nil {
+	klog.V(3).Infof("nat gw pod '%s' inited at %s", pod.Name, natGwCreatedAT)
+	// the agent initializes the pod before applying the routes and qos of the gateway
+	err := c.syncVpcNatGwRules(gw)
+	if err != nil {
 		err = fmt.Errorf("failed to init vpc nat gateway, %v", err)
 		klog.Error(err)
 		return err
 	}
 
-	if gw.Spec.QoSPolicy != "" {
-		if err = c.execNatGwQoS(gw, gw.Spec.QoSPolicy, QoSAdd); err != nil {
-			klog.Errorf("failed to add qos for nat gw %s, %v", key, err)
-			return err
-		}
-	}
 	// if update qos success, will update nat gw status
 	if gw.Spec.QoSPolicy != gw.Status.QoSPolicy {
 		if err = c.patchNatGwQoSStatus(key, gw.Spec.QoSPolicy); err != nil {
@@ -554,39 +590,6 @@ func (c *Controller) handleUpdateVpcDnat(natGwKey string) error {
 	return nil
 }
 
-func (c *Controller) getIptablesVersion(pod *corev1.Pod) (version string, err error) {
-	operation := getIptablesVersion
-	cmd := fmt.Sprintf("bash /kube-ovn/nat-gateway.sh %s", operation)
-	klog.V(3).Infof(cmd)
-	stdOutput, errOutput, err := util.ExecuteCommandInContainer(c.config.KubeClient, c.config.KubeRestConfig, pod.Namespace, pod.Name, "vpc-nat-gw", []string{"/bin/bash", "-c", cmd}...)
-	if err != nil {
-		if len(errOutput) > 0 {
-			klog.Errorf("failed to ExecuteCommandInContainer, errOutput: %v", errOutput)
-		}
-		if len(stdOutput) > 0 {
-			klog.V(3).Infof("failed to ExecuteCommandInContainer, stdOutput: %v", stdOutput)
-		}
-		klog.Error(err)
-		return "", err
-	}
-
-	if len(stdOutput) > 0 {
-		klog.V(3).Infof("ExecuteCommandInContainer stdOutput: %v", stdOutput)
-	}
-
-	if len(errOutput) > 0 {
-		klog.Errorf("failed to ExecuteCommandInContainer errOutput: %v", errOutput)
-		return "", err
-	}
-
-	versionMatcher := regexp.MustCompile(`v([0-9]+(\.[0-9]+)+)`)
-	match := versionMatcher.FindStringSubmatch(stdOutput)
-	if match == nil {
-		return "", fmt.Errorf("no iptables version found in string: %s", stdOutput)
-	}
-	return match[1], nil
-}
-
 func (c *Controller) handleUpdateNatGwSubnetRoute(natGwKey string) error {
 	if vpcNatEnabled != "true" {
 		return fmt.Errorf("iptables nat gw not enable")
@@ -596,146 +599,40 @@ func (c *Controller) handleUpdateNatGwSubnetRoute(natGwKey string) error {
 	defer func() { _ = c.vpcNatGwKeyMutex.UnlockKey(natGwKey) }()
 	klog.Infof("handle update subnet route for nat gateway %s", natGwKey)
 
-	gw, err := c.vpcNatGatewayLister.Get(natGwKey)
-	if err != nil {
-		klog.Error(err)
-		return err
-	}
-
-	oriPod, err := c.getNatGwPod(natGwKey)
-	if err != nil {
-		err = fmt.Errorf("failed to get nat gw '%s' pod, %v", natGwKey, err)
-		klog.Error(err)
-		return err
-	}
-	pod := oriPod.DeepCopy()
-	var extRules []string
-	var v4ExternalGw, v4InternalGw, v4ExternalCidr string
-	externalNetwork := util.GetNatGwExternalNetwork(gw.Spec.ExternalSubnets)
-	if subnet, ok := c.ipam.Subnets[externalNetwork]; ok {
-		v4ExternalGw = subnet.V4Gw
-		v4ExternalCidr = subnet.V4CIDR.String()
-	} else {
-		return fmt.Errorf("failed to get external subnet %s", externalNetwork)
-	}
-	extRules = append(extRules, fmt.Sprintf("%s,%s", v4ExternalCidr, v4ExternalGw))
-	if err = c.execNatGwRules(pod, natGwExtSubnetRouteAdd, extRules); err != nil {
-		err = fmt.Errorf("failed to exec nat gateway rule, err: %v", err)
+	// the routes are collected from the subnets of the vpc, and the stale ones are deleted by the agents
+	if err := c.syncNatGwRules(natGwKey, nil, nil); err != nil {
+		err = fmt.Errorf("failed to update subnet routes of nat gw %s, %v", natGwKey, err)
 		klog.Error(err)
 		return err
 	}
+	return nil
+}
 
-	if v4InternalGw, _, err = c.GetGwBySubnet(gw.Spec.Subnet); err != nil {
-		err = fmt.Errorf("failed to get gw, err: %v", err)
-		klog.Error(err)
-		return err
-	}
-	vpc, err := c.vpcsLister.Get(gw.Spec.Vpc)
-	if err != nil {
-		err = fmt.Errorf("failed to get vpc, err: %v", err)
-		klog.Error(err)
-		return err
+// syncVpcNatGwRules syncs the routes and qos of the nat gateway, with the qos policy
+// in spec which has not been recorded in status yet
+func (c *Controller) syncVpcNatGwRules(gw *kubeovnv1.VpcNatGateway) error {
+	if gw.Spec.QoSPolicy == gw.Status.QoSPolicy {
+		return c.syncNatGwRules(gw.Name, nil, nil)
 	}
 
-	// update route table
-	var newCIDRS, oldCIDRs, toBeDelCIDRs []string
-	if len(vpc.Status.Subnets) > 0 {
-		for _, s := range vpc.Status.Subnets {
-			subnet, ok := c.ipam.Subnets[s]
-			if !ok {
-				err = fmt.Errorf("failed to get subnet, err: %v", err)
-				klog.Error(err)
-				return err
-			}
-			newCIDRS = append(newCIDRS, subnet.V4CIDR.String())
-		}
-	}
-	if cidrs, ok := pod.Annotations[util.VpcCIDRsAnnotation]; ok {
-		if err = json.Unmarshal([]byte(cidrs), &oldCIDRs); err != nil {
+	added, deleted := &natgw.RuleSet{}, &natgw.RuleSet{}
+	var err error
+	if gw.Spec.QoSPolicy != "" {
+		if added.QoS, err = c.natGwQoSRules(gw.Spec.QoSPolicy); err != nil {
 			return err
 		}
 	}
-	for _, old := range oldCIDRs {
-		if !util.ContainsString(newCIDRS, old) {
-			toBeDelCIDRs = append(toBeDelCIDRs, old)
-		}
-	}
-
-	if len(newCIDRS) > 0 {
-		var rules []string
-		for _, cidr := range newCIDRS {
-			if !util.CIDRContainIP(cidr, v4InternalGw) {
-				rules = append(rules, fmt.Sprintf("%s,%s", cidr, v4InternalGw))
-			}
-		}
-		if len(rules) > 0 {
-			if err = c.execNatGwRules(pod, natGwSubnetRouteAdd, rules); err != nil {
-				err = fmt.Errorf("failed to exec nat gateway rule, err: %v", err)
-				klog.Error(err)
-				return err
-			}
-		}
-	}
-
-	if len(toBeDelCIDRs) > 0 {
-		for _, cidr := range toBeDelCIDRs {
-			if err = c.execNatGwRules(pod, natGwSubnetRouteDel, []string{cidr}); err != nil {
-				err = fmt.Errorf("failed to exec nat gateway rule, err: %v", err)
-				klog.Error(err)
-				return err
-			}
-		}
-	}
-
-	cidrBytes, err := json.Marshal(newCIDRS)
-	if err != nil {
-		klog.Errorf("marshal eip annotation failed %v", err)
-		return err
-	}
-	pod.Annotations[util.VpcCIDRsAnnotation] = string(cidrBytes)
-	patch, err := util.GenerateStrategicMergePatchPayload(oriPod, pod)
-	if err != nil {
-		klog.Error(err)
-		return err
-	}
-	if _, err := c.config.KubeClient.CoreV1().Pods(pod.Namespace).Patch(context.Background(), pod.Name,
-		types.StrategicMergePatchType, patch, metav1.PatchOptions{}, ""); err != nil {
-		err = fmt.Errorf("patch pod %s/%s failed %v", pod.Name, pod.Namespace, err)
-		klog.Error(err)
-		return err
-	}
-
-	return nil
-}
-
-func (c *Controller) execNatGwRules(pod *corev1.Pod, operation string, rules []string) error {
-	cmd := fmt.Sprintf("bash /kube-ovn/nat-gateway.sh %s %s", operation, strings.Join(rules, " "))
-	klog.V(3).Infof(cmd)
-	stdOutput, errOutput, err := util.ExecuteCommandInContainer(c.config.KubeClient, c.config.KubeRestConfig, pod.Namespace, pod.Name, "vpc-nat-gw", []string{"/bin/bash", "-c", cmd}...)
-	if err != nil {
-		if len(errOutput) > 0 {
-			klog.Errorf("failed to ExecuteCommandInContainer, errOutput: %v", errOutput)
-		}
-		if len(stdOutput) > 0 {
-			klog.V(3).Infof("failed to ExecuteCommandInContainer, stdOutput: %v", stdOutput)
+	if gw.Status.QoSPolicy != "" {
+		// rules of a policy which is gone are not desired, so they are deleted anyway
+		if deleted.QoS, err = c.natGwQoSRules(gw.Status.QoSPolicy); err != nil && !k8serrors.IsNotFound(err) {
+			return err
 		}
-		klog.Error(err)
-		return err
 	}
-
-	if len(stdOutput) > 0 {
-		klog.V(3).Infof("ExecuteCommandInContainer stdOutput: %v", stdOutput)
-	}
-
-	if len(errOutput) > 0 {
-		klog.Errorf("failed to ExecuteCommandInContainer errOutput: %v", errOutput)
-		return errors.New(errOutput)
-	}
-	return nil
+	return c.syncNatGwRules(gw.Name, added, staleNatGwRules(deleted, added))
 }
 
-func (c *Controller) genNatGwStatefulSet(gw *kubeovnv1.VpcNatGateway, oldSts *v1.StatefulSet) (newSts *v1.StatefulSet) {
-	replicas := int32(1)
+func (c *Controller) genNatGwStatefulSet(gw *kubeovnv1.VpcNatGateway, oldSts *v1.StatefulSet) (*v1.StatefulSet, error) {
+	replicas := natGwReplicas(gw)
 	name := util.GenNatGwStsName(gw.Name)
 	allowPrivilegeEscalation := true
 	privileged := true
@@ -757,6 +654,31 @@ func (c *Controller) genNatGwStatefulSet(gw *kubeovnv1.VpcNatGateway, oldSts *v1
 	for key, value := range podAnnotations {
 		newPodAnnotations[key] = value
 	}
+	agentEnv := []corev1.EnvVar{{
+		Name: natgw.TokenEnv,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: natGwAgentSecretName},
+				Key:                  natgw.TokenSecretKey,
+			},
+		},
+	}}
+	affinity := &gw.Spec.Affinity
+	var haContainers []corev1.Container
+	if natGwHAEnabled(gw) {
+		// each instance has its own lan address, the lan ip is a vip held by the active instance
+		lanCIDR, err := c.getNatGwLanCIDR(gw)
+		if err != nil {
+			return nil, err
+		}
+		delete(newPodAnnotations, util.IPAddressAnnotation)
+		newPodAnnotations[util.AAPsAnnotation] = util.GenNatGwHAVipName(gw.Name)
+		agentEnv = append(agentEnv, corev1.EnvVar{Name: natGwHAEnv, Value: "true"})
+		affinity = natGwHAAffinity(gw, labels)
+		haContainers = append(haContainers, genNatGwHAContainer(lanCIDR, gw.Status.Vrid, &privileged, &allowPrivilegeEscalation))
+	} else {
+		delete(newPodAnnotations, util.AAPsAnnotation)
+	}
 
 	selectors := make(map[string]string)
 	for _, v := range gw.Spec.Selector {
@@ -768,7 +690,7 @@ func (c *Controller) genNatGwStatefulSet(gw *kubeovnv1.VpcNatGateway, oldSts *v1
 	}
 	klog.V(3).Infof("prepare for vpc nat gateway pod, node selector: %v", selectors)
 	v4SubnetGw, _, _ := c.GetGwBySubnet(gw.Spec.Subnet)
-	newSts = &v1.StatefulSet{
+	newSts := &v1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   name,
 			Labels: labels,
@@ -788,15 +710,25 @@ func (c *Controller) genNatGwStatefulSet(gw *kubeovnv1.VpcNatGateway, oldSts *v1
 						{
 							Name:            "vpc-nat-gw",
 							Image:           vpcNatImage,
-							Command:         []string{"bash"},
-							Args:            []string{"-c", "while true; do sleep 10000; done"},
+							Command:         []string{natGwAgentCommand},
+							Args:            []string{fmt.Sprintf("--port=%d", natgw.DefaultPort), fmt.Sprintf("--state-file=%s/nat-gw-agent.json", natGwAgentStateDir)},
 							ImagePullPolicy: corev1.PullIfNotPresent,
+							Env:             agentEnv,
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      "agent-state",
+								MountPath: natGwAgentStateDir,
+							}},
 							SecurityContext: &corev1.SecurityContext{
 								Privileged:               &privileged,
 								AllowPrivilegeEscalation: &allowPrivilegeEscalation,
 							},
 						},
 					},
+					// the applied rules live as long as the network namespace of the pod
+					Volumes: []corev1.Volume{{
+						Name:         "agent-state",
+						VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+					}},
 					InitContainers: []corev1.Container{
 						{
 							Name:            "vpc-nat-gw-init",
@@ -812,7 +744,7 @@ func (c *Controller) genNatGwStatefulSet(gw *kubeovnv1.VpcNatGateway, oldSts *v1
 					},
 					NodeSelector: selectors,
 					Tolerations:  gw.Spec.Tolerations,
-					Affinity:     &gw.Spec.Affinity,
+					Affinity:     affinity,
 				},
 			},
 			UpdateStrategy: v1.StatefulSetUpdateStrategy{
@@ -820,7 +752,8 @@ func (c *Controller) genNatGwStatefulSet(gw *kubeovnv1.VpcNatGateway, oldSts *v1
 			},
 		},
 	}
-	return
+	newSts.Spec.Template.Spec.Containers = append(newSts.Spec.Template.Spec.Containers, haContainers...)
+	return newSts, nil
 }
 
 func (c *Controller) cleanUpVpcNatGw() error {
@@ -835,40 +768,46 @@ func (c *Controller) cleanUpVpcNatGw() error {
 	return nil
 }
 
-func (c *Controller) getNatGwPod(name string) (*corev1.Pod, error) {
+// getNatGwPods returns the running pods of the nat gateway, rules are applied to all of them
+// so that any instance is able to take over in active/standby mode
+func (c *Controller) getNatGwPods(name string) ([]*corev1.Pod, error) {
 	sel, _ := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
 		MatchLabels: map[string]string{"app": util.GenNatGwStsName(name), util.VpcNatGatewayLabel: "true"},
 	})
 
 	pods, err := c.podsLister.Pods(c.config.PodNamespace).List(sel)
-
-	switch {
-	case err != nil:
+	if err != nil {
 		klog.Error(err)
 		return nil, err
-	case len(pods) == 0:
+	}
+	if len(pods) == 0 {
 		return nil, k8serrors.NewNotFound(v1.Resource("pod"), name)
-	case len(pods) != 1:
-		time.Sleep(5 * time.Second)
-		return nil, fmt.Errorf("too many pod")
-	case pods[0].Status.Phase != "Running":
+	}
+
+	running := make([]*corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil {
+			running = append(running, pod)
+		}
+	}
+	if len(running) == 0 {
 		time.Sleep(5 * time.Second)
 		return nil, fmt.Errorf("pod is not active now")
 	}
-
-	return pods[0], nil
+	sort.Slice(running, func(i, j int) bool { return running[i].Name < running[j].Name })
+	return running, nil
 }
 
 func (c *Controller) initCreateAt(key string) (err error) {
 	if natGwCreatedAT != "" {
 		return nil
 	}
-	pod, err := c.getNatGwPod(key)
+	pods, err := c.getNatGwPods(key)
 	if err != nil {
 		klog.Error(err)
 		return err
 	}
-	natGwCreatedAT = pod.CreationTimestamp.Format("2006-01-02T15:04:05")
+	natGwCreatedAT = pods[0].CreationTimestamp.Format("2006-01-02T15:04:05")
 	return nil
 }
 
@@ -984,6 +923,10 @@ func (c *Controller) patchNatGwStatus(key string) error {
 		return err
 	}
 	gw := oriGw.DeepCopy()
+	// the vrid patched recently may not be in cache yet
+	if vrid, ok := c.natGwVrids.get(gw.Name); ok {
+		gw.Status.Vrid = vrid
+	}
 
 	if !reflect.DeepEqual(gw.Spec.ExternalSubnets, gw.Status.ExternalSubnets) {
 		gw.Status.ExternalSubnets = gw.Spec.ExternalSubnets
@@ -1019,76 +962,3 @@ func (c *Controller) patchNatGwStatus(key string) error {
 	}
 	return nil
 }
-
-func (c *Controller) execNatGwQoS(gw *kubeovnv1.VpcNatGateway, qos, operation string) error {
-	qosPolicy, err := c.qosPoliciesLister.Get(qos)
-	if err != nil {
-		klog.Errorf("get qos policy %s failed: %v", qos, err)
-		return err
-	}
-	if !qosPolicy.Status.Shared {
-		err := fmt.Errorf("not support unshared qos policy %s to related to gw", qos)
-		klog.Error(err)
-		return err
-	}
-	if qosPolicy.Status.BindingType != kubeovnv1.QoSBindingTypeNatGw {
-		err := fmt.Errorf("not support qos policy %s binding type %s to related to gw", qos, qosPolicy.Status.BindingType)
-		klog.Error(err)
-		return err
-	}
-	return c.execNatGwBandtithLimitRules(gw, qosPolicy.Status.BandwidthLimitRules, operation)
-}
-
-func (c *Controller) execNatGwBandtithLimitRules(gw *kubeovnv1.VpcNatGateway, rules kubeovnv1.QoSPolicyBandwidthLimitRules, operation string) error {
-	var err error
-	for _, rule := range rules {
-		if err = c.execNatGwQoSInPod(gw.Name, rule, operation); err != nil {
-			klog.Errorf("failed to %s ingress gw '%s' qos in pod, %v", operation, gw.Name, err)
-			return err
-		}
-	}
-	return nil
-}
-
-func (c *Controller) execNatGwQoSInPod(
-	dp string, r *kubeovnv1.QoSPolicyBandwidthLimitRule, operation string,
-) error {
-	gwPod, err := c.getNatGwPod(dp)
-	if err != nil {
-		klog.Errorf("failed to get nat gw pod, %v", err)
-		return err
-	}
-	var addRules []string
-	var classifierType, matchDirection, cidr string
-	switch {
-	case r.MatchType == "ip":
-		classifierType = "u32"
-		// matchValue: dst xxx.xxx.xxx.xxx/32
-		splitStr := strings.Split(r.MatchValue, " ")
-		if len(splitStr) != 2 {
-			err := fmt.Errorf("matchValue %s format error", r.MatchValue)
-			klog.Error(err)
-			return err
-		}
-		matchDirection = splitStr[0]
-		cidr = splitStr[1]
-	case r.MatchType == "":
-		classifierType = "matchall"
-	default:
-		err := fmt.Errorf("MatchType %s format error", r.MatchType)
-		klog.Error(err)
-		return err
-	}
-	rule := fmt.Sprintf("%s,%s,%d,%s,%s,%s,%s,%s,%s",
-		r.Direction, r.Interface, r.Priority,
-		classifierType, r.MatchType, matchDirection,
-		cidr, r.RateMax, r.BurstMax)
-	addRules = append(addRules, rule)
-
-	if err = c.execNatGwRules(gwPod, operation, addRules); err != nil {
-		err = fmt.Errorf("failed to exec nat gateway rule, err: %v", err)
-		klog.Error(err)
-		return err
-	}
-	return nil
-}
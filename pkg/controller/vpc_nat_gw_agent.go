@@ -0,0 +1,867 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/natgw"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+const (
+	natGwAgentSecretName     = "vpc-nat-gw-agent"
+	natGwAgentCommand        = "/kube-ovn/vpc-nat-gw-agent"
+	natGwAgentStateDir       = "/var/run/kube-ovn"
+	natGwAgentForwardTimeout = 10 * time.Second
+	// applying thousands of rules with nat-gateway.sh takes a while
+	natGwAgentRequestTimeout = 5 * time.Minute
+
+	natGwRuleReasonApplied = "RuleApplied"
+	natGwRuleReasonFailed  = "RuleFailed"
+
+	vpcNatGatewayKind    = "VpcNatGateway"
+	iptablesEipKind      = "IptablesEIP"
+	iptablesFipRuleKind  = "IptablesFIPRule"
+	iptablesDnatRuleKind = "IptablesDnatRule"
+)
+
+type natGwAgentClient struct {
+	podUID    types.UID
+	forwarder *util.PortForwarder
+	client    *natgw.Client
+}
+
+// natGwAgents caches the connections to the agents in nat gateway pods
+// and the generations of the rule sets sent to them, both are keyed by pod
+type natGwAgents struct {
+	mutex       sync.Mutex
+	token       string
+	clients     map[string]*natGwAgentClient
+	generations map[string]int64
+}
+
+func newNatGwAgents() *natGwAgents {
+	return &natGwAgents{
+		clients:     make(map[string]*natGwAgentClient),
+		generations: make(map[string]int64),
+	}
+}
+
+// remove drops the cached connections and generations of the deleted nat gateway
+func (a *natGwAgents) remove(namespace, gwName string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	prefix := fmt.Sprintf("%s/%s-", namespace, util.GenNatGwStsName(gwName))
+	for key, agent := range a.clients {
+		if strings.HasPrefix(key, prefix) {
+			agent.forwarder.Stop()
+			delete(a.clients, key)
+		}
+	}
+	for key := range a.generations {
+		if strings.HasPrefix(key, prefix) {
+			delete(a.generations, key)
+		}
+	}
+}
+
+type natGwRule struct {
+	kind natgw.RuleKind
+	rule string
+}
+
+// natGwRuleObject is a nat gateway or an iptables eip/fip/dnat/snat whose rules are synced to the nat gateway.
+// The rules of a nat gateway are its routes and qos, the rules of an eip include its qos,
+// and a dnat with a list of ports has a rule for each port or port range.
+type natGwRuleObject struct {
+	kind  string
+	name  string
+	rules []natGwRule
+}
+
+func (o *natGwRuleObject) add(kind natgw.RuleKind, rule string) {
+	o.rules = append(o.rules, natGwRule{kind: kind, rule: rule})
+}
+
+// getNatGwAgentToken returns the token of the nat gateway agents and creates it if not exists
+func (c *Controller) getNatGwAgentToken() (string, error) {
+	c.natGwAgents.mutex.Lock()
+	defer c.natGwAgents.mutex.Unlock()
+	if c.natGwAgents.token != "" {
+		return c.natGwAgents.token, nil
+	}
+
+	secrets := c.config.KubeClient.CoreV1().Secrets(c.config.PodNamespace)
+	secret, err := secrets.Get(context.Background(), natGwAgentSecretName, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to get secret %s: %v", natGwAgentSecretName, err)
+			return "", err
+		}
+		buf := make([]byte, 32)
+		if _, err = rand.Read(buf); err != nil {
+			klog.Errorf("failed to generate token for nat gateway agent: %v", err)
+			return "", err
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: natGwAgentSecretName},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       map[string][]byte{natgw.TokenSecretKey: []byte(hex.EncodeToString(buf))},
+		}
+		if secret, err = secrets.Create(context.Background(), secret, metav1.CreateOptions{}); err != nil {
+			if !k8serrors.IsAlreadyExists(err) {
+				klog.Errorf("failed to create secret %s: %v", natGwAgentSecretName, err)
+				return "", err
+			}
+			if secret, err = secrets.Get(context.Background(), natGwAgentSecretName, metav1.GetOptions{}); err != nil {
+				klog.Errorf("failed to get secret %s: %v", natGwAgentSecretName, err)
+				return "", err
+			}
+		}
+	}
+
+	token := string(secret.Data[natgw.TokenSecretKey])
+	if token == "" {
+		err = fmt.Errorf("secret %s has no %s", natGwAgentSecretName, natgw.TokenSecretKey)
+		klog.Error(err)
+		return "", err
+	}
+	c.natGwAgents.token = token
+	return token, nil
+}
+
+// getNatGwAgentClient returns a client of the agent in the nat gateway pod,
+// which is reached by forwarding a local port to the pod through the API server
+func (c *Controller) getNatGwAgentClient(pod *corev1.Pod) (*natgw.Client, error) {
+	token, err := c.getNatGwAgentToken()
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+	c.natGwAgents.mutex.Lock()
+	defer c.natGwAgents.mutex.Unlock()
+	if agent := c.natGwAgents.clients[key]; agent != nil {
+		if agent.podUID == pod.UID && !agent.forwarder.Closed() {
+			return agent.client, nil
+		}
+		agent.forwarder.Stop()
+		delete(c.natGwAgents.clients, key)
+	}
+
+	forwarder, err := util.PortForward(c.config.KubeClient, c.config.KubeRestConfig, pod.Namespace, pod.Name, natgw.DefaultPort, natGwAgentForwardTimeout)
+	if err != nil {
+		klog.Errorf("failed to connect to agent of nat gw pod %s: %v", key, err)
+		return nil, err
+	}
+	agent := &natGwAgentClient{
+		podUID:    pod.UID,
+		forwarder: forwarder,
+		client:    natgw.NewClient(fmt.Sprintf("http://127.0.0.1:%d", forwarder.LocalPort), token, natGwAgentRequestTimeout),
+	}
+	c.natGwAgents.clients[key] = agent
+	return agent.client, nil
+}
+
+// resetNatGwAgentClient drops the connection to the agent if the request failed before reaching it
+func (c *Controller) resetNatGwAgentClient(pod *corev1.Pod, err error) {
+	var agentErr *natgw.Error
+	if errors.As(err, &agentErr) {
+		return
+	}
+
+	key := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+	c.natGwAgents.mutex.Lock()
+	defer c.natGwAgents.mutex.Unlock()
+	if agent := c.natGwAgents.clients[key]; agent != nil {
+		agent.forwarder.Stop()
+		delete(c.natGwAgents.clients, key)
+	}
+}
+
+// desiredNatGwRules collects the routes and qos of the nat gateway and the rules of its eips, fips, dnats and snats.
+// The fip and dnat rules recorded in status, which are deleted or changed, are returned as drains with the drain
+// periods of the fips and dnats, so that their sessions are drained no matter which sync deletes them.
+func (c *Controller) desiredNatGwRules(gwName string) (*natgw.RuleSet, *natgw.RuleSet, []natGwRuleObject, error) {
+	rules, drains := &natgw.RuleSet{}, &natgw.RuleSet{}
+	var objects []natGwRuleObject
+	gw, err := c.vpcNatGatewayLister.Get(gwName)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		klog.Errorf("failed to get vpc nat gw %s: %v", gwName, err)
+		return nil, nil, nil, err
+	}
+	if err == nil {
+		obj := natGwRuleObject{kind: vpcNatGatewayKind, name: gw.Name}
+		if rules.ExternalRoutes, rules.Routes, err = c.natGwRoutes(gw); err != nil {
+			return nil, nil, nil, err
+		}
+		if gw.Status.QoSPolicy != "" {
+			// the policy is validated before it is recorded in status
+			if rules.QoS, err = c.natGwQoSRules(gw.Status.QoSPolicy); err != nil {
+				klog.Errorf("failed to get qos rules of vpc nat gw %s: %v", gw.Name, err)
+			}
+		}
+		for kind, list := range rules.Rules() {
+			for _, rule := range list {
+				obj.add(kind, rule)
+			}
+		}
+		objects = append(objects, obj)
+	}
+
+	eips, err := c.iptablesEipsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list iptables eips: %v", err)
+		return nil, nil, nil, err
+	}
+	eipIPs := make(map[string]string)
+	for _, eip := range eips {
+		if eip.Spec.NatGwDp != gwName || eip.Status.IP == "" || !eip.DeletionTimestamp.IsZero() {
+			continue
+		}
+		externalNetwork := util.GetExternalNetwork(eip.Spec.ExternalSubnet)
+		v4Cidr, err := c.getEipV4Cidr(eip.Status.IP, externalNetwork)
+		if err != nil {
+			klog.Errorf("failed to get cidr of eip %s: %v", eip.Name, err)
+			return nil, nil, nil, err
+		}
+		v4Gw, _, err := c.GetGwBySubnet(externalNetwork)
+		if err != nil {
+			klog.Errorf("failed to get gateway of subnet %s: %v", externalNetwork, err)
+			return nil, nil, nil, err
+		}
+		rule := natgw.EIP{CIDR: v4Cidr, Gateway: v4Gw}
+		rules.EIPs = append(rules.EIPs, rule)
+		obj := natGwRuleObject{kind: iptablesEipKind, name: eip.Name}
+		obj.add(natgw.RuleKindEIP, rule.Rule())
+		if eip.Status.QoSPolicy != "" {
+			qosPolicy, err := c.qosPoliciesLister.Get(eip.Status.QoSPolicy)
+			if err != nil {
+				klog.Errorf("failed to get qos policy %s of eip %s: %v", eip.Status.QoSPolicy, eip.Name, err)
+			} else {
+				qos := natGwEIPQoS(eip.Status.IP, qosPolicy.Status.BandwidthLimitRules)
+				rules.EIPIngressQoS = append(rules.EIPIngressQoS, qos.EIPIngressQoS...)
+				rules.EIPEgressQoS = append(rules.EIPEgressQoS, qos.EIPEgressQoS...)
+				for kind, list := range qos.Rules() {
+					for _, rule := range list {
+						obj.add(kind, rule)
+					}
+				}
+			}
+		}
+		objects = append(objects, obj)
+		eipIPs[eip.Name] = eip.Status.IP
+	}
+
+	// rules which have not been accepted by the controller yet have no v4ip in status
+	fips, err := c.iptablesFipsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list iptables fips: %v", err)
+		return nil, nil, nil, err
+	}
+	for _, fip := range fips {
+		var desired []natgw.FloatingIP
+		if eipIP := eipIPs[fip.Spec.EIP]; eipIP != "" && fip.Status.V4ip != "" && fip.DeletionTimestamp.IsZero() {
+			rule := natgw.FloatingIP{EIP: eipIP, InternalIP: fip.Spec.InternalIP}
+			rules.FloatingIPs = append(rules.FloatingIPs, rule)
+			obj := natGwRuleObject{kind: iptablesFipRuleKind, name: fip.Name}
+			obj.add(natgw.RuleKindFloatingIP, rule.Rule())
+			objects = append(objects, obj)
+			desired = append(desired, rule)
+		}
+		drains.FloatingIPs = append(drains.FloatingIPs, natGwFIPDrains(gwName, fip, desired)...)
+	}
+
+	dnats, err := c.iptablesDnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list iptables dnats: %v", err)
+		return nil, nil, nil, err
+	}
+	for _, dnat := range dnats {
+		var desired []natgw.DNAT
+		if eipIP := eipIPs[dnat.Spec.EIP]; eipIP != "" && dnat.Status.V4ip != "" && dnat.DeletionTimestamp.IsZero() {
+			desired = natGwDNATs(eipIP, dnat.Spec.Protocol, dnat.Spec.InternalIP, dnat.Spec.ExternalPort, dnat.Spec.InternalPort, 0)
+			obj := natGwRuleObject{kind: iptablesDnatRuleKind, name: dnat.Name}
+			for _, rule := range desired {
+				rules.DNATs = append(rules.DNATs, rule)
+				obj.add(natgw.RuleKindDNAT, rule.Rule())
+			}
+			objects = append(objects, obj)
+		}
+		drains.DNATs = append(drains.DNATs, natGwDNATDrains(gwName, dnat, desired)...)
+	}
+
+	snats, err := c.iptablesSnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list iptables snats: %v", err)
+		return nil, nil, nil, err
+	}
+	for _, snat := range snats {
+		eipIP := eipIPs[snat.Spec.EIP]
+		if eipIP == "" || snat.Status.V4ip == "" || !snat.DeletionTimestamp.IsZero() {
+			continue
+		}
+		v4Cidr, _ := util.SplitStringIP(snat.Spec.InternalCIDR)
+		if v4Cidr == "" {
+			continue
+		}
+		portBlockStart := c.snatPortBlockStart(iptablesSnatRuleKind, snat.Name, snat.Status.PortBlockStart)
+		snatRules, err := natGwSNATs(eipIP, v4Cidr, snat.Spec.PortBlockSize, portBlockStart)
+		if err != nil {
+			klog.Errorf("failed to get rules of snat %s: %v", snat.Name, err)
+			continue
+		}
+		obj := natGwRuleObject{kind: iptablesSnatRuleKind, name: snat.Name}
+		for _, rule := range snatRules {
+			rules.SNATs = append(rules.SNATs, rule)
+			obj.add(natgw.RuleKindSNAT, rule.Rule())
+		}
+		objects = append(objects, obj)
+	}
+
+	return rules, drains, objects, nil
+}
+
+// natGwFIPDrains returns the rule of the fip recorded in status with the drain period of the fip,
+// if the rule is applied in the nat gateway and is deleted or changed
+func natGwFIPDrains(gwName string, fip *kubeovnv1.IptablesFIPRule, desired []natgw.FloatingIP) []natgw.FloatingIP {
+	if fip.Spec.DrainPeriodSeconds == 0 || fip.Status.NatGwDp != gwName || fip.Status.V4ip == "" || fip.Status.InternalIP == "" {
+		return nil
+	}
+	recorded := []natgw.FloatingIP{{EIP: fip.Status.V4ip, InternalIP: fip.Status.InternalIP, DrainSeconds: fip.Spec.DrainPeriodSeconds}}
+	return removeNatGwRules(recorded, desired)
+}
+
+// natGwDNATDrains returns the rules of the dnat recorded in status with the drain period of the dnat,
+// if the rules are applied in the nat gateway and are deleted or changed
+func natGwDNATDrains(gwName string, dnat *kubeovnv1.IptablesDnatRule, desired []natgw.DNAT) []natgw.DNAT {
+	if dnat.Spec.DrainPeriodSeconds == 0 || dnat.Status.NatGwDp != gwName || dnat.Status.V4ip == "" || dnat.Status.InternalIP == "" {
+		return nil
+	}
+	recorded := natGwDNATs(dnat.Status.V4ip, dnat.Status.Protocol, dnat.Status.InternalIP,
+		dnat.Status.ExternalPort, dnat.Status.InternalPort, dnat.Spec.DrainPeriodSeconds)
+	return removeNatGwRules(recorded, desired)
+}
+
+// natGwRoutes returns the default route via the external subnet, and the routes to the service cidr
+// and the subnets of the vpc via the gateway of the internal subnet
+func (c *Controller) natGwRoutes(gw *kubeovnv1.VpcNatGateway) ([]natgw.Route, []natgw.Route, error) {
+	externalNetwork := util.GetNatGwExternalNetwork(gw.Spec.ExternalSubnets)
+	externalSubnet, ok := c.ipam.Subnets[externalNetwork]
+	if !ok || externalSubnet.V4CIDR == nil {
+		err := fmt.Errorf("failed to get external subnet %s", externalNetwork)
+		klog.Error(err)
+		return nil, nil, err
+	}
+	externalRoutes := []natgw.Route{{CIDR: externalSubnet.V4CIDR.String(), NextHop: externalSubnet.V4Gw}}
+
+	v4InternalGw, _, err := c.GetGwBySubnet(gw.Spec.Subnet)
+	if err != nil {
+		klog.Errorf("failed to get gateway of subnet %s: %v", gw.Spec.Subnet, err)
+		return nil, nil, err
+	}
+	vpc, err := c.vpcsLister.Get(gw.Spec.Vpc)
+	if err != nil {
+		klog.Errorf("failed to get vpc %s: %v", gw.Spec.Vpc, err)
+		return nil, nil, err
+	}
+	var routes []natgw.Route
+	if svcCIDR, _ := util.SplitStringIP(c.config.ServiceClusterIPRange); svcCIDR != "" {
+		routes = append(routes, natgw.Route{CIDR: svcCIDR, NextHop: v4InternalGw})
+	}
+	for _, name := range vpc.Status.Subnets {
+		subnet, ok := c.ipam.Subnets[name]
+		if !ok {
+			err = fmt.Errorf("failed to get subnet %s of vpc %s", name, vpc.Name)
+			klog.Error(err)
+			return nil, nil, err
+		}
+		if subnet.V4CIDR == nil || util.CIDRContainIP(subnet.V4CIDR.String(), v4InternalGw) {
+			continue
+		}
+		routes = append(routes, natgw.Route{CIDR: subnet.V4CIDR.String(), NextHop: v4InternalGw})
+	}
+	return externalRoutes, routes, nil
+}
+
+// natGwQoSRules returns the bandwidth limit rules of the shared qos policy bound to nat gateways
+func (c *Controller) natGwQoSRules(qos string) ([]natgw.QoS, error) {
+	qosPolicy, err := c.qosPoliciesLister.Get(qos)
+	if err != nil {
+		klog.Errorf("get qos policy %s failed: %v", qos, err)
+		return nil, err
+	}
+	if !qosPolicy.Status.Shared {
+		err := fmt.Errorf("not support unshared qos policy %s to related to gw", qos)
+		klog.Error(err)
+		return nil, err
+	}
+	if qosPolicy.Status.BindingType != kubeovnv1.QoSBindingTypeNatGw {
+		err := fmt.Errorf("not support qos policy %s binding type %s to related to gw", qos, qosPolicy.Status.BindingType)
+		klog.Error(err)
+		return nil, err
+	}
+	rules := make([]natgw.QoS, 0, len(qosPolicy.Status.BandwidthLimitRules))
+	for _, r := range qosPolicy.Status.BandwidthLimitRules {
+		rule := natgw.QoS{
+			Direction: string(r.Direction),
+			Interface: r.Interface,
+			Priority:  r.Priority,
+			MatchType: string(r.MatchType),
+			Rate:      r.RateMax,
+			Burst:     r.BurstMax,
+		}
+		switch r.MatchType {
+		case "ip":
+			rule.ClassifierType = "u32"
+			// matchValue: dst xxx.xxx.xxx.xxx/32
+			splitStr := strings.Split(r.MatchValue, " ")
+			if len(splitStr) != 2 {
+				err := fmt.Errorf("matchValue %s format error", r.MatchValue)
+				klog.Error(err)
+				return nil, err
+			}
+			rule.MatchDirection, rule.CIDR = splitStr[0], splitStr[1]
+		case "":
+			rule.ClassifierType = "matchall"
+		default:
+			err := fmt.Errorf("MatchType %s format error", r.MatchType)
+			klog.Error(err)
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// natGwEIPQoS returns the ingress and egress bandwidth limits of the eip
+func natGwEIPQoS(eip string, limits kubeovnv1.QoSPolicyBandwidthLimitRules) *natgw.RuleSet {
+	rules := &natgw.RuleSet{}
+	for _, r := range limits {
+		rule := natgw.EIPQoS{EIP: eip, Priority: r.Priority, Rate: r.RateMax, Burst: r.BurstMax}
+		switch r.Direction {
+		case kubeovnv1.DirectionIngress:
+			rules.EIPIngressQoS = append(rules.EIPIngressQoS, rule)
+		case kubeovnv1.DirectionEgress:
+			rules.EIPEgressQoS = append(rules.EIPEgressQoS, rule)
+		}
+	}
+	return rules
+}
+
+// staleNatGwRules returns the rules in old which are not in rules
+func staleNatGwRules(old, rules *natgw.RuleSet) *natgw.RuleSet {
+	return &natgw.RuleSet{
+		ExternalRoutes: removeNatGwRules(slices.Clone(old.ExternalRoutes), rules.ExternalRoutes),
+		Routes:         removeNatGwRules(slices.Clone(old.Routes), rules.Routes),
+		EIPs:           removeNatGwRules(slices.Clone(old.EIPs), rules.EIPs),
+		FloatingIPs:    removeNatGwRules(slices.Clone(old.FloatingIPs), rules.FloatingIPs),
+		DNATs:          removeNatGwRules(slices.Clone(old.DNATs), rules.DNATs),
+		SNATs:          removeNatGwRules(slices.Clone(old.SNATs), rules.SNATs),
+		QoS:            removeNatGwRules(slices.Clone(old.QoS), rules.QoS),
+		EIPIngressQoS:  removeNatGwRules(slices.Clone(old.EIPIngressQoS), rules.EIPIngressQoS),
+		EIPEgressQoS:   removeNatGwRules(slices.Clone(old.EIPEgressQoS), rules.EIPEgressQoS),
+	}
+}
+
+// mergeNatGwRules adds the rules in added to rules and removes the ones in deleted
+func mergeNatGwRules(rules, added, deleted *natgw.RuleSet) {
+	if added != nil {
+		rules.ExternalRoutes = append(rules.ExternalRoutes, added.ExternalRoutes...)
+		rules.Routes = append(rules.Routes, added.Routes...)
+		rules.EIPs = append(rules.EIPs, added.EIPs...)
+		rules.FloatingIPs = append(rules.FloatingIPs, added.FloatingIPs...)
+		rules.DNATs = append(rules.DNATs, added.DNATs...)
+		rules.SNATs = append(rules.SNATs, added.SNATs...)
+		rules.QoS = append(rules.QoS, added.QoS...)
+		rules.EIPIngressQoS = append(rules.EIPIngressQoS, added.EIPIngressQoS...)
+		rules.EIPEgressQoS = append(rules.EIPEgressQoS, added.EIPEgressQoS...)
+	}
+	if deleted != nil {
+		// eips are deleted by cidr
+		eips := rules.EIPs[:0]
+		for _, eip := range rules.EIPs {
+			if !slices.ContainsFunc(deleted.EIPs, func(e natgw.EIP) bool { return e.CIDR == eip.CIDR }) {
+				eips = append(eips, eip)
+			}
+		}
+		rules.EIPs = eips
+		rules.ExternalRoutes = removeNatGwRules(rules.ExternalRoutes, deleted.ExternalRoutes)
+		rules.Routes = removeNatGwRules(rules.Routes, deleted.Routes)
+		rules.FloatingIPs = removeNatGwRules(rules.FloatingIPs, deleted.FloatingIPs)
+		rules.DNATs = removeNatGwRules(rules.DNATs, deleted.DNATs)
+		rules.SNATs = removeNatGwRules(rules.SNATs, deleted.SNATs)
+		rules.QoS = removeNatGwRules(rules.QoS, deleted.QoS)
+		rules.EIPIngressQoS = removeNatGwRules(rules.EIPIngressQoS, deleted.EIPIngressQoS)
+		rules.EIPEgressQoS = removeNatGwRules(rules.EIPEgressQoS, deleted.EIPEgressQoS)
+	}
+}
+
+func removeNatGwRules[T interface{ Rule() string }](rules, deleted []T) []T {
+	if len(deleted) == 0 {
+		return rules
+	}
+	set := make(map[string]struct{}, len(deleted))
+	for _, rule := range deleted {
+		set[rule.Rule()] = struct{}{}
+	}
+	result := rules[:0]
+	for _, rule := range rules {
+		if _, ok := set[rule.Rule()]; !ok {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
+// syncNatGwRules sends the full rule set of the nat gateway to the agents of all its instances.
+// The rule set is collected from the nat gateway and its iptables eips, fips, dnats and snats, together with the rules
+// in added which may not be recorded in status yet, and without the rules in deleted.
+func (c *Controller) syncNatGwRules(gwName string, added, deleted *natgw.RuleSet) error {
+	c.natGwRulesKeyMutex.LockKey(gwName)
+	defer func() { _ = c.natGwRulesKeyMutex.UnlockKey(gwName) }()
+
+	pods, err := c.getNatGwPods(gwName)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to get nat gw %s pod: %v", gwName, err)
+		}
+		return err
+	}
+	rules, drains, objects, err := c.desiredNatGwRules(gwName)
+	if err != nil {
+		return err
+	}
+	mergeNatGwRules(rules, added, deleted)
+
+	// standby instances apply the same rules, so that they are ready to take over
+	states := make(map[string]*natgw.State, len(pods))
+	for _, pod := range pods {
+		state, err := c.syncNatGwPodRules(pod, rules, drains)
+		if err != nil {
+			return err
+		}
+		states[pod.Name] = state
+	}
+
+	for _, obj := range objects {
+		if err = c.patchNatGwRuleCondition(obj, mergeNatGwObjectStatus(states, obj)); err != nil {
+			return err
+		}
+	}
+
+	var errs []string
+	if added != nil {
+		for kind, list := range added.Rules() {
+			for _, rule := range list {
+				if status := mergeNatGwRuleStatus(states, kind, rule); status == nil || !status.Applied {
+					errs = append(errs, fmt.Sprintf("failed to add %s rule %s: %s", kind, rule, natGwRuleError(status)))
+				}
+			}
+		}
+	}
+	if deleted != nil {
+		for _, pod := range pods {
+			for _, status := range states[pod.Name].Rules {
+				if status.Applied && isDeletedNatGwRule(deleted, &status) {
+					errs = append(errs, fmt.Sprintf("failed to delete %s rule %s in pod %s: %s", status.Kind, status.Rule, pod.Name, natGwRuleError(&status)))
+				}
+			}
+		}
+	}
+	if len(errs) != 0 {
+		err = fmt.Errorf("failed to sync rules of nat gw %s: %s", gwName, strings.Join(errs, "; "))
+		klog.Error(err)
+		return err
+	}
+	return nil
+}
+
+// syncNatGwPodRules sends the rule set to the agent in the pod with the next generation of the pod,
+// the drains carry the drain periods of the sessions of the deleted rules
+func (c *Controller) syncNatGwPodRules(pod *corev1.Pod, rules, drains *natgw.RuleSet) (*natgw.State, error) {
+	client, err := c.getNatGwAgentClient(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+	c.natGwAgents.mutex.Lock()
+	generation := c.natGwAgents.generations[key] + 1
+	c.natGwAgents.mutex.Unlock()
+
+	ctx := context.Background()
+	state, err := client.Sync(ctx, &natgw.SyncRequest{Generation: generation, Rules: *rules, Deleted: drains})
+	var agentErr *natgw.Error
+	if errors.As(err, &agentErr) && agentErr.Generation >= generation {
+		// the controller has restarted, continue with the generation of the agent
+		generation = agentErr.Generation + 1
+		state, err = client.Sync(ctx, &natgw.SyncRequest{Generation: generation, Rules: *rules, Deleted: drains})
+	}
+	if err != nil {
+		c.resetNatGwAgentClient(pod, err)
+		klog.Errorf("failed to sync rules to nat gw pod %s: %v", key, err)
+		return nil, err
+	}
+	c.natGwAgents.mutex.Lock()
+	c.natGwAgents.generations[key] = generation
+	c.natGwAgents.mutex.Unlock()
+	klog.V(3).Infof("synced %d rules of generation %d to nat gw pod %s", len(state.Rules), generation, key)
+	return state, nil
+}
+
+// mergeNatGwRuleStatus merges the status of the rule in the instances keyed by pod name,
+// the rule is applied only if it is applied in every instance
+func mergeNatGwRuleStatus(states map[string]*natgw.State, kind natgw.RuleKind, rule string) *natgw.RuleStatus {
+	names := make([]string, 0, len(states))
+	for name := range states {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var merged *natgw.RuleStatus
+	var errs []string
+	for _, name := range names {
+		status := states[name].Find(kind, rule)
+		if status == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &natgw.RuleStatus{Kind: kind, Rule: rule, Applied: true}
+		}
+		if status.Applied && status.Error == "" {
+			continue
+		}
+		merged.Applied = merged.Applied && status.Applied
+		msg := natGwRuleError(status)
+		if len(states) > 1 {
+			msg = fmt.Sprintf("%s: %s", name, msg)
+		}
+		errs = append(errs, msg)
+	}
+	if merged != nil {
+		merged.Error = strings.Join(errs, "; ")
+	}
+	return merged
+}
+
+// mergeNatGwObjectStatus merges the status of the rules of the object,
+// the object is applied only if all its rules are applied
+func mergeNatGwObjectStatus(states map[string]*natgw.State, obj natGwRuleObject) *natgw.RuleStatus {
+	var merged *natgw.RuleStatus
+	var errs []string
+	for _, rule := range obj.rules {
+		status := mergeNatGwRuleStatus(states, rule.kind, rule.rule)
+		if status == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &natgw.RuleStatus{Kind: rule.kind, Rule: rule.rule, Applied: true}
+		}
+		merged.Applied = merged.Applied && status.Applied
+		if status.Error != "" {
+			msg := status.Error
+			if len(obj.rules) > 1 {
+				msg = fmt.Sprintf("%s %s: %s", rule.kind, rule.rule, msg)
+			}
+			errs = append(errs, msg)
+		}
+	}
+	if merged != nil {
+		merged.Error = strings.Join(errs, "; ")
+	}
+	return merged
+}
+
+// natGwDNATs returns a rule for each port or port range of the dnat. Ports failing to parse are
+// kept as is, so that the error is reported by the agent in the conditions of the dnat.
+func natGwDNATs(eip, protocol, internalIP, externalPort, internalPort string, drainSeconds int32) []natgw.DNAT {
+	mappings, err := util.ParsePortMappings(externalPort, internalPort)
+	if err != nil {
+		klog.Warningf("failed to parse ports of dnat %s:%s to %s:%s: %v", eip, externalPort, internalIP, internalPort, err)
+		return []natgw.DNAT{{
+			EIP:          eip,
+			ExternalPort: externalPort,
+			Protocol:     protocol,
+			InternalIP:   internalIP,
+			InternalPort: internalPort,
+			DrainSeconds: drainSeconds,
+		}}
+	}
+	rules := make([]natgw.DNAT, 0, len(mappings))
+	for _, m := range mappings {
+		rules = append(rules, natgw.DNAT{
+			EIP:          eip,
+			ExternalPort: m.External(),
+			Protocol:     protocol,
+			InternalIP:   internalIP,
+			InternalPort: m.Internal(),
+			DrainSeconds: drainSeconds,
+		})
+	}
+	return rules
+}
+
+// natGwSNATs returns the rule of the snat, in port block mode tcp and udp rules are returned
+// for each internal ip to translate it to the port block allocated to it, with a rule without
+// the port block for the other protocols like icmp
+func natGwSNATs(eip, internalCIDR string, portBlockSize int32, portBlockStart int) ([]natgw.SNAT, error) {
+	if portBlockSize == 0 {
+		return []natgw.SNAT{{EIP: eip, InternalCIDR: internalCIDR}}, nil
+	}
+	blocks, err := util.AllocateSnatPortBlocks(internalCIDR, int(portBlockSize), portBlockStart)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+	rules := make([]natgw.SNAT, 0, 3*len(blocks))
+	for _, block := range blocks {
+		cidr := block.InternalIP + "/32"
+		rules = append(rules,
+			natgw.SNAT{EIP: eip, InternalCIDR: cidr, PortRange: block.PortRange(), Protocol: "tcp"},
+			natgw.SNAT{EIP: eip, InternalCIDR: cidr, PortRange: block.PortRange(), Protocol: "udp"},
+			natgw.SNAT{EIP: eip, InternalCIDR: cidr},
+		)
+	}
+	return rules, nil
+}
+
+func isDeletedNatGwRule(deleted *natgw.RuleSet, status *natgw.RuleStatus) bool {
+	if status.Kind == natgw.RuleKindEIP {
+		for _, eip := range deleted.EIPs {
+			if strings.HasPrefix(status.Rule, eip.CIDR+",") {
+				return true
+			}
+		}
+		return false
+	}
+	return slices.Contains(deleted.Rules()[status.Kind], status.Rule)
+}
+
+func natGwRuleError(status *natgw.RuleStatus) string {
+	if status == nil || status.Error == "" {
+		return "unknown error"
+	}
+	return status.Error
+}
+
+// patchNatGwRuleCondition records the applied state reported by the agent in the status of the object
+func (c *Controller) patchNatGwRuleCondition(obj natGwRuleObject, status *natgw.RuleStatus) error {
+	if status == nil {
+		return nil
+	}
+
+	update := func(s interface {
+		SetCondition(ctype kubeovnv1.ConditionType, reason, message string) bool
+		ClearCondition(ctype kubeovnv1.ConditionType, reason, message string) bool
+	},
+	) bool {
+		if status.Applied && status.Error == "" {
+			return s.SetCondition(kubeovnv1.Applied, natGwRuleReasonApplied, "")
+		}
+		return s.ClearCondition(kubeovnv1.Applied, natGwRuleReasonFailed, natGwRuleError(status))
+	}
+
+	var conditions interface{}
+	switch obj.kind {
+	case vpcNatGatewayKind:
+		gw, err := c.vpcNatGatewayLister.Get(obj.name)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+		s := gw.Status.DeepCopy()
+		if !update(s) {
+			return nil
+		}
+		conditions = s.Conditions
+	case iptablesEipKind:
+		eip, err := c.iptablesEipsLister.Get(obj.name)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+		s := eip.Status.DeepCopy()
+		if !update(s) {
+			return nil
+		}
+		conditions = s.Conditions
+	case iptablesFipRuleKind:
+		fip, err := c.iptablesFipsLister.Get(obj.name)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+		s := fip.Status.DeepCopy()
+		if !update(s) {
+			return nil
+		}
+		conditions = s.Conditions
+	case iptablesDnatRuleKind:
+		dnat, err := c.iptablesDnatRulesLister.Get(obj.name)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+		s := dnat.Status.DeepCopy()
+		if !update(s) {
+			return nil
+		}
+		conditions = s.Conditions
+	case iptablesSnatRuleKind:
+		snat, err := c.iptablesSnatRulesLister.Get(obj.name)
+		if err != nil {
+			return ignoreNotFound(err)
+		}
+		s := snat.Status.DeepCopy()
+		if !update(s) {
+			return nil
+		}
+		conditions = s.Conditions
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{"status": map[string]interface{}{"conditions": conditions}})
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	iptables := c.config.KubeOvnClient.KubeovnV1()
+	ctx := context.Background()
+	switch obj.kind {
+	case vpcNatGatewayKind:
+		_, err = iptables.VpcNatGateways().Patch(ctx, obj.name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	case iptablesEipKind:
+		_, err = iptables.IptablesEIPs().Patch(ctx, obj.name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	case iptablesFipRuleKind:
+		_, err = iptables.IptablesFIPRules().Patch(ctx, obj.name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	case iptablesDnatRuleKind:
+		_, err = iptables.IptablesDnatRules().Patch(ctx, obj.name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	case iptablesSnatRuleKind:
+		_, err = iptables.IptablesSnatRules().Patch(ctx, obj.name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	}
+	if err != nil && !k8serrors.IsNotFound(err) {
+		klog.Errorf("failed to patch conditions of %s %s: %v", obj.kind, obj.name, err)
+		return err
+	}
+	return nil
+}
+
+func ignoreNotFound(err error) error {
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	klog.Error(err)
+	return err
+}
@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/natgw"
+)
+
+func Test_mergeNatGwRules(t *testing.T) {
+	t.Parallel()
+
+	rules := &natgw.RuleSet{
+		EIPs:        []natgw.EIP{{CIDR: "10.0.0.2/24", Gateway: "10.0.0.1"}, {CIDR: "10.0.0.3/24", Gateway: "10.0.0.1"}},
+		FloatingIPs: []natgw.FloatingIP{{EIP: "10.0.0.2", InternalIP: "10.16.0.2"}},
+		SNATs:       []natgw.SNAT{{EIP: "10.0.0.3", InternalCIDR: "10.16.0.0/16"}},
+	}
+	mergeNatGwRules(rules,
+		&natgw.RuleSet{DNATs: []natgw.DNAT{{EIP: "10.0.0.2", ExternalPort: "80", Protocol: "tcp", InternalIP: "10.16.0.3", InternalPort: "8080"}}},
+		&natgw.RuleSet{EIPs: []natgw.EIP{{CIDR: "10.0.0.3/24"}}, SNATs: []natgw.SNAT{{EIP: "10.0.0.3", InternalCIDR: "10.16.0.0/16"}}},
+	)
+	require.Equal(t, []natgw.EIP{{CIDR: "10.0.0.2/24", Gateway: "10.0.0.1"}}, rules.EIPs)
+	require.Len(t, rules.FloatingIPs, 1)
+	require.Len(t, rules.DNATs, 1)
+	require.Empty(t, rules.SNATs)
+}
+
+func Test_isDeletedNatGwRule(t *testing.T) {
+	t.Parallel()
+
+	deleted := &natgw.RuleSet{
+		EIPs:        []natgw.EIP{{CIDR: "10.0.0.3/24"}},
+		FloatingIPs: []natgw.FloatingIP{{EIP: "10.0.0.2", InternalIP: "10.16.0.2"}},
+	}
+	require.True(t, isDeletedNatGwRule(deleted, &natgw.RuleStatus{Kind: natgw.RuleKindEIP, Rule: "10.0.0.3/24,10.0.0.1"}))
+	require.False(t, isDeletedNatGwRule(deleted, &natgw.RuleStatus{Kind: natgw.RuleKindEIP, Rule: "10.0.0.33/24,10.0.0.1"}))
+	require.True(t, isDeletedNatGwRule(deleted, &natgw.RuleStatus{Kind: natgw.RuleKindFloatingIP, Rule: "10.0.0.2,10.16.0.2"}))
+	require.False(t, isDeletedNatGwRule(deleted, &natgw.RuleStatus{Kind: natgw.RuleKindSNAT, Rule: "10.0.0.2,10.16.0.2"}))
+}
+
+func Test_mergeNatGwRuleStatus(t *testing.T) {
+	t.Parallel()
+
+	rule := "10.0.0.2,10.16.0.2"
+	states := map[string]*natgw.State{
+		"vpc-nat-gw-gw1-0": {Rules: []natgw.RuleStatus{{Kind: natgw.RuleKindFloatingIP, Rule: rule, Applied: true}}},
+		"vpc-nat-gw-gw1-1": {Rules: []natgw.RuleStatus{{Kind: natgw.RuleKindFloatingIP, Rule: rule, Applied: true}}},
+	}
+	status := mergeNatGwRuleStatus(states, natgw.RuleKindFloatingIP, rule)
+	require.True(t, status.Applied)
+	require.Empty(t, status.Error)
+	require.Nil(t, mergeNatGwRuleStatus(states, natgw.RuleKindDNAT, rule))
+
+	states["vpc-nat-gw-gw1-1"].Rules[0] = natgw.RuleStatus{Kind: natgw.RuleKindFloatingIP, Rule: rule, Error: "exit code 1"}
+	status = mergeNatGwRuleStatus(states, natgw.RuleKindFloatingIP, rule)
+	require.False(t, status.Applied)
+	require.Equal(t, "vpc-nat-gw-gw1-1: exit code 1", status.Error)
+}
+
+func Test_natGwDNATs(t *testing.T) {
+	t.Parallel()
+
+	rules := natGwDNATs("10.0.0.2", "udp", "10.16.0.3", "80,27000-27100", "8080,37000-37100", 30)
+	require.Equal(t, []natgw.DNAT{
+		{EIP: "10.0.0.2", ExternalPort: "80", Protocol: "udp", InternalIP: "10.16.0.3", InternalPort: "8080", DrainSeconds: 30},
+		{EIP: "10.0.0.2", ExternalPort: "27000-27100", Protocol: "udp", InternalIP: "10.16.0.3", InternalPort: "37000-37100", DrainSeconds: 30},
+	}, rules)
+
+	// invalid ports are passed to the agent which reports the error
+	rules = natGwDNATs("10.0.0.2", "tcp", "10.16.0.3", "80-90", "8080", 0)
+	require.Equal(t, []natgw.DNAT{{EIP: "10.0.0.2", ExternalPort: "80-90", Protocol: "tcp", InternalIP: "10.16.0.3", InternalPort: "8080"}}, rules)
+}
+
+func Test_natGwFIPDrains(t *testing.T) {
+	t.Parallel()
+
+	fip := &kubeovnv1.IptablesFIPRule{
+		Spec:   kubeovnv1.IptablesFIPRuleSpec{EIP: "eip1", InternalIP: "10.16.0.2", DrainPeriodSeconds: 30},
+		Status: kubeovnv1.IptablesFIPRuleStatus{V4ip: "10.0.0.2", InternalIP: "10.16.0.2", NatGwDp: "gw1"},
+	}
+	recorded := []natgw.FloatingIP{{EIP: "10.0.0.2", InternalIP: "10.16.0.2", DrainSeconds: 30}}
+
+	// the fip is deleted or moved to another nat gateway
+	require.Equal(t, recorded, natGwFIPDrains("gw1", fip, nil))
+	// the fip is unchanged
+	require.Empty(t, natGwFIPDrains("gw1", fip, []natgw.FloatingIP{{EIP: "10.0.0.2", InternalIP: "10.16.0.2"}}))
+	// the internal ip of the fip is changed
+	require.Equal(t, recorded, natGwFIPDrains("gw1", fip, []natgw.FloatingIP{{EIP: "10.0.0.2", InternalIP: "10.16.0.3"}}))
+	// the fip is not applied in the nat gateway
+	require.Empty(t, natGwFIPDrains("gw2", fip, nil))
+
+	fip.Spec.DrainPeriodSeconds = 0
+	require.Empty(t, natGwFIPDrains("gw1", fip, nil))
+}
+
+func Test_natGwDNATDrains(t *testing.T) {
+	t.Parallel()
+
+	dnat := &kubeovnv1.IptablesDnatRule{
+		Spec: kubeovnv1.IptablesDnatRuleSpec{
+			EIP: "eip1", Protocol: "tcp", InternalIP: "10.16.0.2", ExternalPort: "80,8000-8100", InternalPort: "8080,9000-9100", DrainPeriodSeconds: 30,
+		},
+		Status: kubeovnv1.IptablesDnatRuleStatus{
+			V4ip: "10.0.0.2", Protocol: "tcp", InternalIP: "10.16.0.2", ExternalPort: "80,8000-8100", InternalPort: "8080,9000-9100", NatGwDp: "gw1",
+		},
+	}
+	recorded := natGwDNATs("10.0.0.2", "tcp", "10.16.0.2", "80,8000-8100", "8080,9000-9100", 30)
+
+	// the dnat is deleted or moved to another nat gateway
+	require.Equal(t, recorded, natGwDNATDrains("gw1", dnat, nil))
+	// only the rule of the changed ports is drained
+	desired := natGwDNATs("10.0.0.2", "tcp", "10.16.0.2", "80,8000-8200", "8080,9000-9200", 0)
+	require.Equal(t, recorded[1:], natGwDNATDrains("gw1", dnat, desired))
+	// the dnat is unchanged
+	desired = natGwDNATs("10.0.0.2", "tcp", "10.16.0.2", "80,8000-8100", "8080,9000-9100", 0)
+	require.Empty(t, natGwDNATDrains("gw1", dnat, desired))
+	// the dnat is not applied in the nat gateway
+	require.Empty(t, natGwDNATDrains("gw2", dnat, nil))
+}
+
+func Test_natGwSNATs(t *testing.T) {
+	t.Parallel()
+
+	rules, err := natGwSNATs("10.0.0.2", "10.16.0.0/24", 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []natgw.SNAT{{EIP: "10.0.0.2", InternalCIDR: "10.16.0.0/24"}}, rules)
+
+	rules, err = natGwSNATs("10.0.0.2", "10.16.0.0/31", 1000, 3024)
+	require.NoError(t, err)
+	require.Equal(t, []natgw.SNAT{
+		{EIP: "10.0.0.2", InternalCIDR: "10.16.0.0/32", PortRange: "3024-4023", Protocol: "tcp"},
+		{EIP: "10.0.0.2", InternalCIDR: "10.16.0.0/32", PortRange: "3024-4023", Protocol: "udp"},
+		{EIP: "10.0.0.2", InternalCIDR: "10.16.0.0/32"},
+		{EIP: "10.0.0.2", InternalCIDR: "10.16.0.1/32", PortRange: "4024-5023", Protocol: "tcp"},
+		{EIP: "10.0.0.2", InternalCIDR: "10.16.0.1/32", PortRange: "4024-5023", Protocol: "udp"},
+		{EIP: "10.0.0.2", InternalCIDR: "10.16.0.1/32"},
+	}, rules)
+	require.Equal(t, "10.0.0.2:4024-5023,10.16.0.1/32,udp", rules[4].Rule())
+	require.Equal(t, "10.0.0.2,10.16.0.1/32", rules[5].Rule())
+
+	_, err = natGwSNATs("10.0.0.2", "10.16.0.0/16", 1000, 1024)
+	require.Error(t, err)
+}
+
+func Test_mergeNatGwObjectStatus(t *testing.T) {
+	t.Parallel()
+
+	obj := natGwRuleObject{kind: iptablesDnatRuleKind, name: "dnat1"}
+	obj.add(natgw.RuleKindDNAT, "r1")
+	obj.add(natgw.RuleKindDNAT, "r2")
+	states := map[string]*natgw.State{
+		"vpc-nat-gw-gw1-0": {Rules: []natgw.RuleStatus{
+			{Kind: natgw.RuleKindDNAT, Rule: "r1", Applied: true},
+			{Kind: natgw.RuleKindDNAT, Rule: "r2", Applied: true},
+		}},
+	}
+	status := mergeNatGwObjectStatus(states, obj)
+	require.True(t, status.Applied)
+	require.Empty(t, status.Error)
+
+	states["vpc-nat-gw-gw1-0"].Rules[1] = natgw.RuleStatus{Kind: natgw.RuleKindDNAT, Rule: "r2", Error: "exit code 1"}
+	status = mergeNatGwObjectStatus(states, obj)
+	require.False(t, status.Applied)
+	require.Equal(t, "dnat r2: exit code 1", status.Error)
+	require.Nil(t, mergeNatGwObjectStatus(states, natGwRuleObject{kind: iptablesSnatRuleKind, rules: []natGwRule{{kind: natgw.RuleKindSNAT, rule: "r1"}}}))
+
+	// the rules of an eip include its qos
+	obj = natGwRuleObject{kind: iptablesEipKind, name: "eip1"}
+	obj.add(natgw.RuleKindEIP, "10.0.0.2/24,10.0.0.1")
+	obj.add(natgw.RuleKindEIPIngressQoS, "10.0.0.2,1,10,10")
+	states["vpc-nat-gw-gw1-0"].Rules = []natgw.RuleStatus{
+		{Kind: natgw.RuleKindEIP, Rule: "10.0.0.2/24,10.0.0.1", Applied: true},
+		{Kind: natgw.RuleKindEIPIngressQoS, Rule: "10.0.0.2,1,10,10", Error: "exit code 2"},
+	}
+	status = mergeNatGwObjectStatus(states, obj)
+	require.False(t, status.Applied)
+	require.Equal(t, "eip-ingress-qos 10.0.0.2,1,10,10: exit code 2", status.Error)
+}
+
+func Test_natGwEIPQoS(t *testing.T) {
+	t.Parallel()
+
+	rules := natGwEIPQoS("10.0.0.2", kubeovnv1.QoSPolicyBandwidthLimitRules{
+		{Name: "in", Direction: kubeovnv1.DirectionIngress, Priority: 1, RateMax: "10", BurstMax: "20"},
+		{Name: "out", Direction: kubeovnv1.DirectionEgress, Priority: 2, RateMax: "5", BurstMax: "5"},
+	})
+	require.Equal(t, []natgw.EIPQoS{{EIP: "10.0.0.2", Priority: 1, Rate: "10", Burst: "20"}}, rules.EIPIngressQoS)
+	require.Equal(t, []natgw.EIPQoS{{EIP: "10.0.0.2", Priority: 2, Rate: "5", Burst: "5"}}, rules.EIPEgressQoS)
+	require.Equal(t, []string{"10.0.0.2,1,10,20"}, rules.Rules()[natgw.RuleKindEIPIngressQoS])
+}
+
+func Test_staleNatGwRules(t *testing.T) {
+	t.Parallel()
+
+	old := &natgw.RuleSet{
+		Routes:        []natgw.Route{{CIDR: "10.96.0.0/12", NextHop: "10.16.0.1"}},
+		EIPIngressQoS: []natgw.EIPQoS{{EIP: "10.0.0.2", Priority: 1, Rate: "10", Burst: "10"}, {EIP: "10.0.0.3", Priority: 1, Rate: "10", Burst: "10"}},
+	}
+	rules := &natgw.RuleSet{
+		Routes:        []natgw.Route{{CIDR: "10.96.0.0/12", NextHop: "10.16.0.1"}},
+		EIPIngressQoS: []natgw.EIPQoS{{EIP: "10.0.0.2", Priority: 1, Rate: "20", Burst: "20"}, {EIP: "10.0.0.3", Priority: 1, Rate: "10", Burst: "10"}},
+	}
+	stale := staleNatGwRules(old, rules)
+	require.Empty(t, stale.Routes)
+	require.Equal(t, []natgw.EIPQoS{{EIP: "10.0.0.2", Priority: 1, Rate: "10", Burst: "10"}}, stale.EIPIngressQoS)
+	// the old rules are not modified
+	require.Len(t, old.EIPIngressQoS, 2)
+
+	// the unchanged rules stay in the merged rule set, the duplicated ones are skipped by the agent
+	merged := &natgw.RuleSet{Routes: slices.Clone(old.Routes), EIPIngressQoS: slices.Clone(old.EIPIngressQoS)}
+	mergeNatGwRules(merged, rules, stale)
+	require.Equal(t, old.Routes, slices.Compact(merged.Routes))
+	require.Subset(t, merged.EIPIngressQoS, rules.EIPIngressQoS)
+	require.Subset(t, rules.EIPIngressQoS, merged.EIPIngressQoS)
+}
@@ -16,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/natgw"
 	"github.com/kubeovn/kube-ovn/pkg/ovs"
 	"github.com/kubeovn/kube-ovn/pkg/util"
 )
@@ -243,17 +244,10 @@ func (c *Controller) handleAddIptablesEip(key string) error {
 		klog.Errorf("failed to get gw, err: %v", err)
 		return err
 	}
-	if err = c.createEipInPod(cachedEip.Spec.NatGwDp, v4Gw, eipV4Cidr); err != nil {
+	if err = c.createEipInPod(cachedEip, v4ip, v4Gw, eipV4Cidr); err != nil {
 		klog.Errorf("failed to create eip '%s' in pod, %v", key, err)
 		return err
 	}
-
-	if cachedEip.Spec.QoSPolicy != "" {
-		if err = c.addEipQoS(cachedEip, v4ip); err != nil {
-			klog.Errorf("failed to add qos '%s' in pod, %v", key, err)
-			return err
-		}
-	}
 	if err = c.createOrUpdateCrdEip(key, v4ip, v6ip, mac, cachedEip.Spec.NatGwDp, cachedEip.Spec.QoSPolicy, externalNetwork); err != nil {
 		klog.Errorf("failed to update eip %s, %v", key, err)
 		return err
@@ -308,12 +302,6 @@ func (c *Controller) handleUpdateIptablesEip(key string) error {
 				return err
 			}
 		}
-		if cachedEip.Status.QoSPolicy != "" {
-			if err = c.delEipQoS(cachedEip, cachedEip.Status.IP); err != nil {
-				klog.Errorf("failed to del qos '%s' in pod, %v", key, err)
-				return err
-			}
-		}
 		if err = c.handleDelIptablesEipFinalizer(key); err != nil {
 			klog.Errorf("failed to handle del finalizer for eip %s, %v", key, err)
 			return err
@@ -336,17 +324,14 @@ func (c *Controller) handleUpdateIptablesEip(key string) error {
 
 	// update qos
 	if cachedEip.Status.QoSPolicy != cachedEip.Spec.QoSPolicy {
-		if cachedEip.Status.QoSPolicy != "" {
-			if err = c.delEipQoS(cachedEip, cachedEip.Status.IP); err != nil {
-				klog.Errorf("failed to del qos '%s' in pod, %v", key, err)
-				return err
-			}
+		added, deleted, err := c.eipQoSRules(cachedEip, cachedEip.Status.IP)
+		if err != nil {
+			klog.Errorf("failed to get qos rules of eip %s, %v", key, err)
+			return err
 		}
-		if cachedEip.Spec.QoSPolicy != "" {
-			if err = c.addEipQoS(cachedEip, cachedEip.Status.IP); err != nil {
-				klog.Errorf("failed to add qos '%s' in pod, %v", key, err)
-				return err
-			}
+		if err = c.syncNatGwRules(cachedEip.Spec.NatGwDp, added, deleted); err != nil {
+			klog.Errorf("failed to update qos '%s' in pod, %v", key, err)
+			return err
 		}
 
 		if err = c.patchEipLabel(key); err != nil {
@@ -375,18 +360,11 @@ func (c *Controller) handleUpdateIptablesEip(key string) error {
 			klog.Errorf("failed to get gw, %v", err)
 			return err
 		}
-		if err = c.createEipInPod(cachedEip.Spec.NatGwDp, v4Gw, eipV4Cidr); err != nil {
+		if err = c.createEipInPod(cachedEip, cachedEip.Status.IP, v4Gw, eipV4Cidr); err != nil {
 			klog.Errorf("failed to create eip, %v", err)
 			return err
 		}
 
-		if cachedEip.Spec.QoSPolicy != "" {
-			if err = c.addEipQoS(cachedEip, cachedEip.Status.IP); err != nil {
-				klog.Errorf("failed to add qos '%s' in pod, %v", key, err)
-				return err
-			}
-		}
-
 		if err = c.patchEipStatus(key, "", "", cachedEip.Spec.QoSPolicy, true); err != nil {
 			klog.Errorf("failed to patch status for eip %s, %v", key, err)
 			return err
@@ -400,6 +378,10 @@ func (c *Controller) handleUpdateIptablesEip(key string) error {
 }
 
 func (c *Controller) handleDelIptablesEip(key string) error {
+	if c.isVpcEipAddressHeld(kubeovnv1.NatBackendOvn, key) {
+		klog.Infof("keep the address of iptables eip %s held by ovn eip %s", key, key)
+		return nil
+	}
 	c.ipam.ReleaseAddressByPod(key)
 	klog.V(3).Infof("deleted vpc nat eip %s", key)
 	return nil
@@ -418,140 +400,70 @@ func (c *Controller) GetEip(eipName string) (*kubeovnv1.IptablesEIP, error) {
 	return eip, nil
 }
 
-func (c *Controller) createEipInPod(dp, gw, v4Cidr string) error {
-	gwPod, err := c.getNatGwPod(dp)
+// createEipInPod adds the eip to the nat gateway together with the bandwidth limits of its qos policy
+func (c *Controller) createEipInPod(eip *kubeovnv1.IptablesEIP, v4ip, gw, v4Cidr string) error {
+	added, deleted, err := c.eipQoSRules(eip, v4ip)
 	if err != nil {
-		klog.Error(err)
+		klog.Errorf("failed to get qos rules of eip %s, %v", eip.Name, err)
 		return err
 	}
-	var addRules []string
-	rule := fmt.Sprintf("%s,%s", v4Cidr, gw)
-	addRules = append(addRules, rule)
-	return c.execNatGwRules(gwPod, natGwEipAdd, addRules)
+	added.EIPs = []natgw.EIP{{CIDR: v4Cidr, Gateway: gw}}
+	return c.syncNatGwRules(eip.Spec.NatGwDp, added, deleted)
 }
 
+// deleteEipInPod deletes the eip from the nat gateway, the bandwidth limits
+// of the eip are not desired any more and deleted as well
 func (c *Controller) deleteEipInPod(dp, v4Cidr string) error {
-	gwPod, err := c.getNatGwPod(dp)
-	if err != nil {
+	rules := &natgw.RuleSet{EIPs: []natgw.EIP{{CIDR: v4Cidr}}}
+	if err := c.syncNatGwRules(dp, nil, rules); err != nil {
 		if k8serrors.IsNotFound(err) {
 			return nil
 		}
 		klog.Error(err)
 		return err
 	}
-	var delRules []string
-	rule := v4Cidr
-	delRules = append(delRules, rule)
-	if err = c.execNatGwRules(gwPod, natGwEipDel, delRules); err != nil {
-		klog.Error(err)
-		return err
-	}
 	return nil
 }
 
-func (c *Controller) addOrUpdateEIPBandtithLimitRules(eip *kubeovnv1.IptablesEIP, v4ip string, rules kubeovnv1.QoSPolicyBandwidthLimitRules) error {
-	var err error
-	for _, rule := range rules {
-		if err = c.addEipQoSInPod(eip.Spec.NatGwDp, v4ip, rule.Direction, rule.Priority, rule.RateMax, rule.BurstMax); err != nil {
-			klog.Errorf("failed to set ingress eip '%s' qos in pod, %v", eip.Name, err)
-			return err
-		}
-	}
-	return nil
-}
-
-// add tc rule for eip in nat gw pod
-func (c *Controller) addEipQoS(eip *kubeovnv1.IptablesEIP, v4ip string) error {
-	var err error
-	qosPolicy, err := c.qosPoliciesLister.Get(eip.Spec.QoSPolicy)
-	if !qosPolicy.Status.Shared {
-		eips, err := c.iptablesEipsLister.List(
-			labels.SelectorFromSet(labels.Set{util.QoSLabel: qosPolicy.Name}))
+// eipQoSRules returns the bandwidth limits of the qos policy in the spec of the eip which are added,
+// and the ones of the qos policy in status which are deleted if the policy is changed
+func (c *Controller) eipQoSRules(eip *kubeovnv1.IptablesEIP, v4ip string) (*natgw.RuleSet, *natgw.RuleSet, error) {
+	added := &natgw.RuleSet{}
+	if eip.Spec.QoSPolicy != "" {
+		qosPolicy, err := c.qosPoliciesLister.Get(eip.Spec.QoSPolicy)
 		if err != nil {
-			klog.Errorf("failed to get eip list, %v", err)
-			return err
+			klog.Errorf("get qos policy %s failed: %v", eip.Spec.QoSPolicy, err)
+			return nil, nil, err
 		}
-		if len(eips) != 0 {
-			if eips[0].Name != eip.Name {
+		if !qosPolicy.Status.Shared {
+			eips, err := c.iptablesEipsLister.List(
+				labels.SelectorFromSet(labels.Set{util.QoSLabel: qosPolicy.Name}))
+			if err != nil {
+				klog.Errorf("failed to get eip list, %v", err)
+				return nil, nil, err
+			}
+			if len(eips) != 0 && eips[0].Name != eip.Name {
 				err := fmt.Errorf("not support unshared qos policy %s to related to multiple eip", eip.Spec.QoSPolicy)
 				klog.Error(err)
-				return err
+				return nil, nil, err
 			}
 		}
+		added = natGwEIPQoS(v4ip, qosPolicy.Status.BandwidthLimitRules)
 	}
-	if err != nil {
-		klog.Errorf("get qos policy %s failed: %v", eip.Spec.QoSPolicy, err)
-		return err
-	}
-	return c.addOrUpdateEIPBandtithLimitRules(eip, v4ip, qosPolicy.Status.BandwidthLimitRules)
-}
 
-func (c *Controller) delEIPBandtithLimitRules(eip *kubeovnv1.IptablesEIP, v4ip string, rules kubeovnv1.QoSPolicyBandwidthLimitRules) error {
-	var err error
-	for _, rule := range rules {
-		// del qos
-		if err = c.delEipQoSInPod(eip.Spec.NatGwDp, v4ip, rule.Direction); err != nil {
-			klog.Errorf("failed to del egress eip '%s' qos in pod, %v", eip.Name, err)
-			return err
+	deleted := &natgw.RuleSet{}
+	if eip.Status.QoSPolicy != "" && eip.Status.QoSPolicy != eip.Spec.QoSPolicy {
+		// rules of a policy which is gone are not desired, so they are deleted anyway
+		qosPolicy, err := c.qosPoliciesLister.Get(eip.Status.QoSPolicy)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			klog.Errorf("get qos policy %s failed: %v", eip.Status.QoSPolicy, err)
+			return nil, nil, err
+		}
+		if err == nil {
+			deleted = staleNatGwRules(natGwEIPQoS(v4ip, qosPolicy.Status.BandwidthLimitRules), added)
 		}
 	}
-	return nil
-}
-
-// del tc rule for eip in nat gw pod
-func (c *Controller) delEipQoS(eip *kubeovnv1.IptablesEIP, v4ip string) error {
-	var err error
-	qosPolicy, err := c.qosPoliciesLister.Get(eip.Status.QoSPolicy)
-	if err != nil {
-		klog.Errorf("get qos policy %s failed: %v", eip.Status.QoSPolicy, err)
-		return err
-	}
-
-	return c.delEIPBandtithLimitRules(eip, v4ip, qosPolicy.Status.BandwidthLimitRules)
-}
-
-func (c *Controller) addEipQoSInPod(
-	dp, v4ip string, direction kubeovnv1.QoSPolicyRuleDirection, priority int, rate string,
-	burst string,
-) error {
-	var operation string
-	gwPod, err := c.getNatGwPod(dp)
-	if err != nil {
-		klog.Error(err)
-		return err
-	}
-	var addRules []string
-	rule := fmt.Sprintf("%s,%d,%s,%s", v4ip, priority, rate, burst)
-	addRules = append(addRules, rule)
-
-	switch direction {
-	case kubeovnv1.DirectionIngress:
-		operation = natGwEipIngressQoSAdd
-	case kubeovnv1.DirectionEgress:
-		operation = natGwEipEgressQoSAdd
-	}
-
-	return c.execNatGwRules(gwPod, operation, addRules)
-}
-
-func (c *Controller) delEipQoSInPod(dp, v4ip string, direction kubeovnv1.QoSPolicyRuleDirection) error {
-	var operation string
-	gwPod, err := c.getNatGwPod(dp)
-	if err != nil {
-		klog.Error(err)
-		return err
-	}
-	var delRules []string
-	delRules = append(delRules, v4ip)
-
-	switch direction {
-	case kubeovnv1.DirectionIngress:
-		operation = natGwEipIngressQoSDel
-	case kubeovnv1.DirectionEgress:
-		operation = natGwEipEgressQoSDel
-	}
-
-	return c.execNatGwRules(gwPod, operation, delRules)
+	return added, deleted, nil
 }
 
 func (c *Controller) acquireStaticEip(name, _, nicName, ip, externalSubnet string) (string, string, string, error) {
@@ -0,0 +1,375 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+
+	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/natgw"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+const (
+	natGwHAContainerName = "vpc-nat-gw-ha"
+	natGwHAEnv           = "NAT_GW_HA"
+)
+
+// natGwReplicas returns the number of instances of the nat gateway
+func natGwReplicas(gw *kubeovnv1.VpcNatGateway) int32 {
+	if gw.Spec.Replicas > 1 {
+		return gw.Spec.Replicas
+	}
+	return 1
+}
+
+// natGwHAEnabled returns whether the nat gateway runs in active/standby mode
+func natGwHAEnabled(gw *kubeovnv1.VpcNatGateway) bool {
+	return natGwReplicas(gw) > 1
+}
+
+// natGwStsHAEnabled returns whether the statefulset is generated in active/standby mode
+func natGwStsHAEnabled(sts *v1.StatefulSet) bool {
+	for _, container := range sts.Spec.Template.Spec.Containers {
+		if container.Name == natGwHAContainerName {
+			return true
+		}
+	}
+	return false
+}
+
+// natGwVrid returns the preferred vrrp virtual router id of the nat gateway,
+// which is also used as the last byte of the conntrack sync multicast group
+func natGwVrid(name string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return int(h.Sum32()%255) + 1
+}
+
+// natGwStsVrid returns the vrid the statefulset is generated with, or 0 if it is not in active/standby mode
+func natGwStsVrid(sts *v1.StatefulSet) int {
+	for _, container := range sts.Spec.Template.Spec.Containers {
+		if container.Name == natGwHAContainerName && len(container.Args) != 0 {
+			vrid, _ := strconv.Atoi(container.Args[len(container.Args)-1])
+			return vrid
+		}
+	}
+	return 0
+}
+
+type natGwVrrpInstance struct {
+	subnet string
+	vrid   int
+}
+
+// natGwVrids reserves the vrids of the nat gateways in active/standby mode. The vrids must be unique among
+// the nat gateways in the same subnet, since the vrrp advertisements and conntrack sync packets are multicast
+// in the lan. The reservations cover the vrids which are not recorded in status yet.
+type natGwVrids struct {
+	mutex     sync.Mutex
+	instances map[string]natGwVrrpInstance
+}
+
+func newNatGwVrids() *natGwVrids {
+	return &natGwVrids{instances: make(map[string]natGwVrrpInstance)}
+}
+
+// allocate returns the vrid of the nat gateway, the vrid allocated before is kept unless it collides with the one
+// of another nat gateway in the same subnet, which is returned as collision and a new vrid is allocated
+func (v *natGwVrids) allocate(gw *kubeovnv1.VpcNatGateway, gws []*kubeovnv1.VpcNatGateway) (vrid int, collision string, err error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	used := make(map[int]string)
+	for _, other := range gws {
+		if other.Name == gw.Name || !natGwHAEnabled(other) {
+			continue
+		}
+		instance, ok := v.instances[other.Name]
+		if !ok {
+			// the vrids allocated before the controller restarts are recorded in status
+			instance = natGwVrrpInstance{subnet: other.Spec.Subnet, vrid: other.Status.Vrid}
+		}
+		if instance.subnet == gw.Spec.Subnet && instance.vrid != 0 {
+			used[instance.vrid] = other.Name
+		}
+	}
+
+	vrid = gw.Status.Vrid
+	if instance, ok := v.instances[gw.Name]; ok && instance.subnet == gw.Spec.Subnet {
+		vrid = instance.vrid
+	}
+	if vrid != 0 {
+		if collision = used[vrid]; collision == "" {
+			v.instances[gw.Name] = natGwVrrpInstance{subnet: gw.Spec.Subnet, vrid: vrid}
+			return vrid, "", nil
+		}
+	}
+	for i, id := 0, natGwVrid(gw.Name); i < 255; i, id = i+1, id%255+1 {
+		if used[id] == "" {
+			v.instances[gw.Name] = natGwVrrpInstance{subnet: gw.Spec.Subnet, vrid: id}
+			return id, collision, nil
+		}
+	}
+	return 0, collision, fmt.Errorf("no vrid is available in subnet %s", gw.Spec.Subnet)
+}
+
+// get returns the vrid reserved for the nat gateway
+func (v *natGwVrids) get(gwName string) (int, bool) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	instance, ok := v.instances[gwName]
+	return instance.vrid, ok
+}
+
+// release drops the reservation of the nat gateway which is deleted or not in active/standby mode
+func (v *natGwVrids) release(gwName string) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	delete(v.instances, gwName)
+}
+
+// allocateNatGwVrid allocates the vrid of the nat gateway and records it in status
+func (c *Controller) allocateNatGwVrid(gw *kubeovnv1.VpcNatGateway) (int, error) {
+	gws, err := c.vpcNatGatewayLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc nat gateways: %v", err)
+		return 0, err
+	}
+	vrid, collision, err := c.natGwVrids.allocate(gw, gws)
+	if collision != "" {
+		msg := fmt.Sprintf("vrid of vpc nat gw %s collides with vpc nat gw %s in subnet %s", gw.Name, collision, gw.Spec.Subnet)
+		klog.Warning(msg)
+		c.recorder.Event(gw, corev1.EventTypeWarning, "VridCollision", msg)
+	}
+	if err != nil {
+		klog.Errorf("failed to allocate vrid for vpc nat gw %s: %v", gw.Name, err)
+		c.recorder.Event(gw, corev1.EventTypeWarning, "AllocateVridFailed", err.Error())
+		return 0, err
+	}
+	if gw.Status.Vrid == vrid {
+		return vrid, nil
+	}
+
+	klog.Infof("allocate vrid %d for vpc nat gw %s", vrid, gw.Name)
+	patch, err := json.Marshal(map[string]interface{}{"status": map[string]interface{}{"vrid": vrid}})
+	if err != nil {
+		klog.Error(err)
+		return 0, err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().VpcNatGateways().Patch(context.Background(), gw.Name, types.MergePatchType,
+		patch, metav1.PatchOptions{}, "status"); err != nil {
+		klog.Errorf("failed to patch vrid of vpc nat gw %s: %v", gw.Name, err)
+		return 0, err
+	}
+	return vrid, nil
+}
+
+// genNatGwHAContainer returns the container running keepalived and conntrackd,
+// keepalived moves the lan ip and eips to the instance elected as master
+func genNatGwHAContainer(lanCIDR string, vrid int, privileged, allowPrivilegeEscalation *bool) corev1.Container {
+	return corev1.Container{
+		Name:            natGwHAContainerName,
+		Image:           vpcNatImage,
+		Command:         []string{"bash"},
+		Args:            []string{"/kube-ovn/nat-gateway.sh", "ha-run", lanCIDR, strconv.Itoa(vrid)},
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Env:             []corev1.EnvVar{{Name: natGwHAEnv, Value: "true"}},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      "agent-state",
+			MountPath: natGwAgentStateDir,
+		}},
+		SecurityContext: &corev1.SecurityContext{
+			Privileged:               privileged,
+			AllowPrivilegeEscalation: allowPrivilegeEscalation,
+		},
+	}
+}
+
+// natGwHAAffinity spreads the instances across nodes unless pod anti affinity is specified
+func natGwHAAffinity(gw *kubeovnv1.VpcNatGateway, podLabels map[string]string) *corev1.Affinity {
+	affinity := gw.Spec.Affinity.DeepCopy()
+	if affinity.PodAntiAffinity != nil {
+		return affinity
+	}
+	affinity.PodAntiAffinity = &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+			Weight: 100,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: podLabels},
+				TopologyKey:   corev1.LabelHostname,
+			},
+		}},
+	}
+	return affinity
+}
+
+// getNatGwLanCIDR returns the lan ip with the prefix length of the subnet
+func (c *Controller) getNatGwLanCIDR(gw *kubeovnv1.VpcNatGateway) (string, error) {
+	subnet, err := c.subnetsLister.Get(gw.Spec.Subnet)
+	if err != nil {
+		klog.Errorf("failed to get subnet %s: %v", gw.Spec.Subnet, err)
+		return "", err
+	}
+	v4CIDR, _ := util.SplitStringIP(subnet.Spec.CIDRBlock)
+	_, ipNet, err := net.ParseCIDR(v4CIDR)
+	if err != nil {
+		err = fmt.Errorf("failed to parse ipv4 cidr of subnet %s: %v", subnet.Name, err)
+		klog.Error(err)
+		return "", err
+	}
+	ones, _ := ipNet.Mask.Size()
+	return fmt.Sprintf("%s/%d", gw.Spec.LanIP, ones), nil
+}
+
+// reconcileNatGwHAVip makes the lan ip a virtual port whose parents are the nat gateway pods,
+// so that the lan ip is reachable on the instance holding it by vrrp.
+// It returns whether the vip is ready to be used by the pods.
+func (c *Controller) reconcileNatGwHAVip(gw *kubeovnv1.VpcNatGateway) (bool, error) {
+	name := util.GenNatGwHAVipName(gw.Name)
+	if !natGwHAEnabled(gw) {
+		return true, c.deleteNatGwHAVip(gw.Name)
+	}
+
+	vip, err := c.virtualIpsLister.Get(name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to get vip %s: %v", name, err)
+			return false, err
+		}
+		vip = &kubeovnv1.Vip{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kubeovnv1.VipSpec{
+				Namespace: c.config.PodNamespace,
+				Subnet:    gw.Spec.Subnet,
+				V4ip:      gw.Spec.LanIP,
+				Selector:  []string{"app: " + util.GenNatGwStsName(gw.Name)},
+			},
+		}
+		if _, err = c.config.KubeOvnClient.KubeovnV1().Vips().Create(context.Background(), vip, metav1.CreateOptions{}); err != nil {
+			klog.Errorf("failed to create vip %s: %v", name, err)
+			return false, err
+		}
+		return false, nil
+	}
+	if vip.Spec.Subnet != gw.Spec.Subnet || vip.Spec.V4ip != gw.Spec.LanIP {
+		// vip does not support to update, delete it and create it again
+		klog.Infof("lan ip of vpc nat gw %s changed, recreate vip %s", gw.Name, name)
+		return false, c.deleteNatGwHAVip(gw.Name)
+	}
+	return vip.Status.V4ip == gw.Spec.LanIP, nil
+}
+
+func (c *Controller) deleteNatGwHAVip(gwName string) error {
+	name := util.GenNatGwHAVipName(gwName)
+	if _, err := c.virtualIpsLister.Get(name); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Errorf("failed to get vip %s: %v", name, err)
+		return err
+	}
+	if err := c.config.KubeOvnClient.KubeovnV1().Vips().Delete(context.Background(), name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		klog.Errorf("failed to delete vip %s: %v", name, err)
+		return err
+	}
+	return nil
+}
+
+// resyncVpcNatGwInstances records the instances of the nat gateways and the active one in status.
+// In active/standby mode the role of each instance is reported by the agent.
+func (c *Controller) resyncVpcNatGwInstances() {
+	if vpcNatEnabled != "true" {
+		return
+	}
+	gws, err := c.vpcNatGatewayLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc nat gateways: %v", err)
+		return
+	}
+	for _, gw := range gws {
+		if err = c.syncVpcNatGwInstances(gw); err != nil {
+			klog.Errorf("failed to sync instances of vpc nat gw %s: %v", gw.Name, err)
+		}
+	}
+}
+
+func (c *Controller) syncVpcNatGwInstances(gw *kubeovnv1.VpcNatGateway) error {
+	sel := labels.SelectorFromSet(labels.Set{"app": util.GenNatGwStsName(gw.Name), util.VpcNatGatewayLabel: "true"})
+	pods, err := c.podsLister.Pods(c.config.PodNamespace).List(sel)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	var active string
+	var instances []kubeovnv1.VpcNatGatewayInstance
+	for _, pod := range pods {
+		instance := kubeovnv1.VpcNatGatewayInstance{Name: pod.Name, Node: pod.Spec.NodeName, IP: pod.Status.PodIP}
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil {
+			if natGwHAEnabled(gw) {
+				instance.Role = c.getNatGwInstanceRole(pod)
+			} else {
+				instance.Role = natgw.HARoleMaster
+			}
+		}
+		// the first master is reported if vrrp is split, which is also visible in instances
+		if instance.Role == natgw.HARoleMaster && active == "" {
+			active = pod.Name
+		}
+		instances = append(instances, instance)
+	}
+	if gw.Status.ActiveInstance == active && reflect.DeepEqual(gw.Status.Instances, instances) {
+		return nil
+	}
+	if gw.Status.ActiveInstance != active {
+		klog.Infof("active instance of vpc nat gw %s changed from %q to %q", gw.Name, gw.Status.ActiveInstance, active)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{"status": map[string]interface{}{"activeInstance": active, "instances": instances}})
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().VpcNatGateways().Patch(context.Background(), gw.Name, types.MergePatchType,
+		patch, metav1.PatchOptions{}, "status"); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Errorf("failed to patch status of vpc nat gw %s: %v", gw.Name, err)
+		return err
+	}
+	return nil
+}
+
+// getNatGwInstanceRole returns the vrrp role of the instance, or an empty string if it is unknown
+func (c *Controller) getNatGwInstanceRole(pod *corev1.Pod) string {
+	client, err := c.getNatGwAgentClient(pod)
+	if err != nil {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), natGwAgentForwardTimeout)
+	defer cancel()
+	status, err := client.HAStatus(ctx)
+	if err != nil {
+		c.resetNatGwAgentClient(pod, err)
+		klog.Errorf("failed to get ha status of nat gw pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return ""
+	}
+	return status.Role
+}
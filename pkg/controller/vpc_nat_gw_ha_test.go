@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+func Test_natGwReplicas(t *testing.T) {
+	t.Parallel()
+
+	gw := &kubeovnv1.VpcNatGateway{}
+	require.Equal(t, int32(1), natGwReplicas(gw))
+	require.False(t, natGwHAEnabled(gw))
+
+	gw.Spec.Replicas = 2
+	require.Equal(t, int32(2), natGwReplicas(gw))
+	require.True(t, natGwHAEnabled(gw))
+}
+
+func Test_natGwVrid(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"", "gw1", "gw2", "a-very-long-nat-gateway-name"} {
+		vrid := natGwVrid(name)
+		require.GreaterOrEqual(t, vrid, 1)
+		require.LessOrEqual(t, vrid, 255)
+		require.Equal(t, vrid, natGwVrid(name))
+	}
+}
+
+func Test_natGwVridsAllocate(t *testing.T) {
+	t.Parallel()
+
+	newGw := func(name, subnet string, vrid int) *kubeovnv1.VpcNatGateway {
+		gw := &kubeovnv1.VpcNatGateway{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		gw.Spec.Subnet, gw.Spec.Replicas, gw.Status.Vrid = subnet, 2, vrid
+		return gw
+	}
+
+	v := newNatGwVrids()
+	gw1, gw2, gw3 := newGw("gw1", "subnet1", 0), newGw("gw2", "subnet1", natGwVrid("gw1")), newGw("gw3", "subnet2", 0)
+	gws := []*kubeovnv1.VpcNatGateway{gw1, gw2, gw3}
+
+	// the vrid recorded in status is kept
+	vrid, collision, err := v.allocate(gw2, gws)
+	require.NoError(t, err)
+	require.Empty(t, collision)
+	require.Equal(t, natGwVrid("gw1"), vrid)
+
+	// the preferred vrid is used by another nat gateway in the same subnet
+	vrid, collision, err = v.allocate(gw1, gws)
+	require.NoError(t, err)
+	require.Empty(t, collision)
+	require.NotEqual(t, natGwVrid("gw1"), vrid)
+	gw1Vrid := vrid
+
+	// the reservation which is not recorded in status yet is kept
+	vrid, _, err = v.allocate(gw1, gws)
+	require.NoError(t, err)
+	require.Equal(t, gw1Vrid, vrid)
+
+	// the vrids are unique in each subnet
+	gw3.Status.Vrid = gw1Vrid
+	vrid, collision, err = v.allocate(gw3, gws)
+	require.NoError(t, err)
+	require.Empty(t, collision)
+	require.Equal(t, gw1Vrid, vrid)
+
+	// the collision is detected and a new vrid is allocated
+	gw4 := newGw("gw4", "subnet1", gw1Vrid)
+	gws = append(gws, gw4)
+	vrid, collision, err = v.allocate(gw4, gws)
+	require.NoError(t, err)
+	require.Equal(t, "gw1", collision)
+	require.NotEqual(t, gw1Vrid, vrid)
+	require.NotEqual(t, natGwVrid("gw1"), vrid)
+
+	// the vrid is released with the nat gateway
+	v.release("gw4")
+	_, ok := v.get("gw4")
+	require.False(t, ok)
+
+	// all of the vrids are used
+	gws = gws[:0]
+	for i := 1; i <= 255; i++ {
+		gws = append(gws, newGw(fmt.Sprintf("full%d", i), "subnet3", i))
+	}
+	_, _, err = newNatGwVrids().allocate(newGw("gw5", "subnet3", 0), gws)
+	require.Error(t, err)
+}
+
+func Test_natGwStsVrid(t *testing.T) {
+	t.Parallel()
+
+	sts := &appsv1.StatefulSet{}
+	require.Zero(t, natGwStsVrid(sts))
+	sts.Spec.Template.Spec.Containers = []corev1.Container{genNatGwHAContainer("10.0.1.254/24", 10, nil, nil)}
+	require.Equal(t, 10, natGwStsVrid(sts))
+}
+
+func Test_natGwHAAffinity(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{"app": "vpc-nat-gw-gw1"}
+	gw := &kubeovnv1.VpcNatGateway{}
+	affinity := natGwHAAffinity(gw, labels)
+	require.NotNil(t, affinity.PodAntiAffinity)
+	require.Len(t, affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 1)
+	require.Nil(t, gw.Spec.Affinity.PodAntiAffinity)
+
+	gw.Spec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	affinity = natGwHAAffinity(gw, labels)
+	require.Empty(t, affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -16,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/natgw"
 	"github.com/kubeovn/kube-ovn/pkg/util"
 )
 
@@ -153,7 +155,8 @@ func (c *Controller) enqueueUpdateIptablesSnatRule(oldObj, newObj interface{}) {
 	if oldSnat.Status.V4ip != newSnat.Status.V4ip ||
 		oldSnat.Spec.EIP != newSnat.Spec.EIP ||
 		oldSnat.Status.Redo != newSnat.Status.Redo ||
-		oldSnat.Spec.InternalCIDR != newSnat.Spec.InternalCIDR {
+		oldSnat.Spec.InternalCIDR != newSnat.Spec.InternalCIDR ||
+		oldSnat.Spec.PortBlockSize != newSnat.Spec.PortBlockSize {
 		klog.V(3).Infof("enqueue update snat %s", key)
 		c.updateIptablesSnatRuleQueue.Add(key)
 		return
@@ -611,12 +614,10 @@ func (c *Controller) handleUpdateIptablesFip(key string) error {
 	}
 
 	klog.V(3).Infof("fip change ip, old ip '%s', new ip %s", cachedFip.Status.V4ip, eip.Status.IP)
-	if err = c.deleteFipInPod(cachedFip.Status.NatGwDp, cachedFip.Status.V4ip, cachedFip.Status.InternalIP); err != nil {
-		klog.Errorf("failed to delete old fip, %v", err)
-		return err
-	}
-	if err = c.createFipInPod(eip.Spec.NatGwDp, eip.Status.IP, cachedFip.Spec.InternalIP); err != nil {
-		klog.Errorf("failed to create new fip, %v", err)
+	oldFip := natgw.FloatingIP{EIP: cachedFip.Status.V4ip, InternalIP: cachedFip.Status.InternalIP}
+	newFip := natgw.FloatingIP{EIP: eip.Status.IP, InternalIP: cachedFip.Spec.InternalIP}
+	if err = c.replaceFipInPod(cachedFip.Status.NatGwDp, oldFip, eip.Spec.NatGwDp, newFip); err != nil {
+		klog.Errorf("failed to replace fip %s, %v", key, err)
 		return err
 	}
 	if err = c.patchFipStatus(key, eip.Status.IP, eip.Spec.V6ip, eip.Spec.NatGwDp, "", true); err != nil {
@@ -696,7 +697,7 @@ func (c *Controller) handleAddIptablesDnatRule(key string) error {
 		klog.Errorf("failed to get eip, %v", err)
 		return err
 	}
-	if dup, err := c.isDnatDuplicated(eip.Spec.NatGwDp, eipName, dnat.Name, dnat.Spec.ExternalPort); dup || err != nil {
+	if dup, err := c.isDnatDuplicated(eip.Spec.NatGwDp, eipName, dnat.Name, dnat.Spec.Protocol, dnat.Spec.ExternalPort, dnat.Spec.InternalPort); dup || err != nil {
 		return err
 	}
 	// create nat
@@ -769,7 +770,7 @@ func (c *Controller) handleUpdateIptablesDnatRule(key string) error {
 		klog.Errorf("failed to get eip, %v", err)
 		return err
 	}
-	if dup, err := c.isDnatDuplicated(cachedDnat.Status.NatGwDp, eipName, cachedDnat.Name, cachedDnat.Spec.ExternalPort); dup || err != nil {
+	if dup, err := c.isDnatDuplicated(cachedDnat.Status.NatGwDp, eipName, cachedDnat.Name, cachedDnat.Spec.Protocol, cachedDnat.Spec.ExternalPort, cachedDnat.Spec.InternalPort); dup || err != nil {
 		klog.Errorf("failed to update dnat, %v", err)
 		return err
 	}
@@ -778,16 +779,15 @@ func (c *Controller) handleUpdateIptablesDnatRule(key string) error {
 		return fmt.Errorf("iptables nat gw not enable")
 	}
 
-	if err = c.deleteDnatInPod(cachedDnat.Status.NatGwDp, cachedDnat.Status.Protocol,
-		cachedDnat.Status.V4ip, cachedDnat.Status.InternalIP,
-		cachedDnat.Status.ExternalPort, cachedDnat.Status.InternalPort); err != nil {
-		klog.Errorf("failed to delete old dnat, %v", err)
-		return err
+	var oldDnats []natgw.DNAT
+	if cachedDnat.Status.V4ip != "" {
+		oldDnats = natGwDNATs(cachedDnat.Status.V4ip, cachedDnat.Status.Protocol, cachedDnat.Status.InternalIP,
+			cachedDnat.Status.ExternalPort, cachedDnat.Status.InternalPort, 0)
 	}
-	if err = c.createDnatInPod(eip.Spec.NatGwDp, cachedDnat.Spec.Protocol,
-		eip.Status.IP, cachedDnat.Spec.InternalIP,
-		cachedDnat.Spec.ExternalPort, cachedDnat.Spec.InternalPort); err != nil {
-		klog.Errorf("failed to create new dnat %s, %v", key, err)
+	newDnats := natGwDNATs(eip.Status.IP, cachedDnat.Spec.Protocol, cachedDnat.Spec.InternalIP,
+		cachedDnat.Spec.ExternalPort, cachedDnat.Spec.InternalPort, 0)
+	if err = c.replaceDnatInPod(cachedDnat.Status.NatGwDp, oldDnats, eip.Spec.NatGwDp, newDnats); err != nil {
+		klog.Errorf("failed to replace dnat %s, %v", key, err)
 		return err
 	}
 	if err = c.patchDnatStatus(key, eip.Status.IP, eip.Spec.V6ip, eip.Spec.NatGwDp, "", true); err != nil {
@@ -876,10 +876,19 @@ func (c *Controller) handleAddIptablesSnatRule(key string) error {
 		err = fmt.Errorf("failed to get snat v4 internal cidr, original cidr is %s", snat.Spec.InternalCIDR)
 		return err
 	}
-	if err = c.createSnatInPod(eip.Spec.NatGwDp, eip.Status.IP, v4Cidr); err != nil {
+	portBlockStart, err := c.allocateIptablesSnatPortBlocks(snat, v4Cidr)
+	if err != nil {
+		klog.Errorf("failed to allocate port blocks of snat %s, %v", key, err)
+		return err
+	}
+	if err = c.createSnatInPod(eip.Spec.NatGwDp, eip.Status.IP, v4Cidr, snat.Spec.PortBlockSize, portBlockStart); err != nil {
 		klog.Errorf("failed to create snat, %v", err)
 		return err
 	}
+	if err = c.syncSnatPortBlocks(snat, iptablesSnatRuleKind, snat.Spec.PortBlockSize, portBlockStart, eip.Status.IP, v4Cidr); err != nil {
+		klog.Errorf("failed to record port blocks of snat %s, %v", key, err)
+		return err
+	}
 	if err = c.patchSnatStatus(key, eip.Status.IP, eip.Spec.V6ip, eip.Spec.NatGwDp, "", true); err != nil {
 		klog.Errorf("failed to update status for snat %s, %v", key, err)
 		return err
@@ -927,11 +936,16 @@ func (c *Controller) handleUpdateIptablesSnatRule(key string) error {
 	if !cachedSnat.DeletionTimestamp.IsZero() {
 		klog.V(3).Infof("clean snat '%s' in pod", key)
 		if vpcNatEnabled == "true" {
-			if err = c.deleteSnatInPod(cachedSnat.Status.NatGwDp, cachedSnat.Status.V4ip, v4Cidr); err != nil {
+			portBlockStart := c.snatPortBlockStart(iptablesSnatRuleKind, cachedSnat.Name, cachedSnat.Status.PortBlockStart)
+			if err = c.deleteSnatInPod(cachedSnat.Status.NatGwDp, cachedSnat.Status.V4ip, v4Cidr, cachedSnat.Status.PortBlockSize, portBlockStart); err != nil {
 				klog.Errorf("failed to delete snat, %v", err)
 				return err
 			}
 		}
+		if err = c.releaseSnatPortBlocks(cachedSnat, iptablesSnatRuleKind); err != nil {
+			klog.Errorf("failed to release port blocks of snat %s, %v", key, err)
+			return err
+		}
 		if err = c.handleDelIptablesSnatFinalizer(key); err != nil {
 			klog.Errorf("failed to handle del finalizer for snat %s, %v", key, err)
 			return err
@@ -957,14 +971,24 @@ func (c *Controller) handleUpdateIptablesSnatRule(key string) error {
 	}
 
 	klog.V(3).Infof("snat change ip, old ip %s, new ip %s", cachedSnat.Status.V4ip, eip.Status.IP)
-	if err = c.deleteSnatInPod(cachedSnat.Status.NatGwDp, cachedSnat.Status.V4ip, v4Cidr); err != nil {
+	oldPortBlockStart := c.snatPortBlockStart(iptablesSnatRuleKind, cachedSnat.Name, cachedSnat.Status.PortBlockStart)
+	portBlockStart, err := c.allocateIptablesSnatPortBlocks(cachedSnat, v4CidrSpec)
+	if err != nil {
+		klog.Errorf("failed to allocate port blocks of snat %s, %v", key, err)
+		return err
+	}
+	if err = c.deleteSnatInPod(cachedSnat.Status.NatGwDp, cachedSnat.Status.V4ip, v4Cidr, cachedSnat.Status.PortBlockSize, oldPortBlockStart); err != nil {
 		klog.Errorf("failed to delete old snat, %v", err)
 		return err
 	}
-	if err = c.createSnatInPod(cachedSnat.Status.NatGwDp, eip.Status.IP, v4CidrSpec); err != nil {
+	if err = c.createSnatInPod(cachedSnat.Status.NatGwDp, eip.Status.IP, v4CidrSpec, cachedSnat.Spec.PortBlockSize, portBlockStart); err != nil {
 		klog.Errorf("failed to create new snat, %v", err)
 		return err
 	}
+	if err = c.syncSnatPortBlocks(cachedSnat, iptablesSnatRuleKind, cachedSnat.Spec.PortBlockSize, portBlockStart, eip.Status.IP, v4CidrSpec); err != nil {
+		klog.Errorf("failed to record port blocks of snat %s, %v", key, err)
+		return err
+	}
 	if err = c.patchSnatStatus(key, eip.Status.IP, eip.Spec.V6ip, eip.Spec.NatGwDp, "", true); err != nil {
 		klog.Errorf("failed to patch status for snat %s, %v", key, err)
 		return err
@@ -986,7 +1010,7 @@ func (c *Controller) handleUpdateIptablesSnatRule(key string) error {
 		cachedSnat.Status.Redo != "" &&
 		cachedSnat.Status.V4ip != "" &&
 		cachedSnat.DeletionTimestamp.IsZero() {
-		if err = c.createSnatInPod(cachedSnat.Status.NatGwDp, cachedSnat.Status.V4ip, v4CidrSpec); err != nil {
+		if err = c.createSnatInPod(cachedSnat.Status.NatGwDp, cachedSnat.Status.V4ip, v4CidrSpec, cachedSnat.Spec.PortBlockSize, portBlockStart); err != nil {
 			klog.Errorf("failed to create new snat, %v", err)
 			return err
 		}
@@ -1340,7 +1364,7 @@ func (c *Controller) patchDnatLabel(key string, eip *kubeovnv1.IptablesEIP) erro
 		op = "add"
 		dnat.Labels = map[string]string{
 			util.VpcNatGatewayNameLabel: eip.Spec.NatGwDp,
-			util.VpcDnatEPortLabel:      dnat.Spec.ExternalPort,
+			util.VpcDnatEPortLabel:      util.DnatPortsLabelValue(dnat.Spec.ExternalPort),
 			util.EipV4IpLabel:           eip.Spec.V4ip,
 		}
 		needUpdateLabel = true
@@ -1348,7 +1372,7 @@ func (c *Controller) patchDnatLabel(key string, eip *kubeovnv1.IptablesEIP) erro
 		dnat.Labels[util.EipV4IpLabel] != eip.Spec.V4ip {
 		op = "replace"
 		dnat.Labels[util.VpcNatGatewayNameLabel] = eip.Spec.NatGwDp
-		dnat.Labels[util.VpcDnatEPortLabel] = dnat.Spec.ExternalPort
+		dnat.Labels[util.VpcDnatEPortLabel] = util.DnatPortsLabelValue(dnat.Spec.ExternalPort)
 		dnat.Labels[util.EipV4IpLabel] = eip.Spec.V4ip
 		needUpdateLabel = true
 	}
@@ -1540,6 +1564,14 @@ func (c *Controller) patchSnatStatus(key, v4ip, v6ip, natGwDp, redo string, read
 		snat.Status.NatGwDp = natGwDp
 		changed = true
 	}
+	if ready && snat.Status.PortBlockSize != snat.Spec.PortBlockSize {
+		snat.Status.PortBlockSize = snat.Spec.PortBlockSize
+		changed = true
+	}
+	if portRange, ok := c.snatPortRanges.get(iptablesSnatRuleKind, snat.Name); ok && ready && int(snat.Status.PortBlockStart) != portRange.start {
+		snat.Status.PortBlockStart = int32(portRange.start)
+		changed = true
+	}
 	if ready && snat.Spec.InternalCIDR != "" {
 		v4CidrSpec, _ := util.SplitStringIP(snat.Spec.InternalCIDR)
 		if v4CidrSpec != "" {
@@ -1596,119 +1628,116 @@ func (c *Controller) redoSnat(key, redo string, eipReady bool) error {
 }
 
 func (c *Controller) createFipInPod(dp, v4ip, internalIP string) error {
-	gwPod, err := c.getNatGwPod(dp)
-	if err != nil {
-		klog.Error(err)
-		return err
-	}
-	var addRules []string
-	rule := fmt.Sprintf("%s,%s", v4ip, internalIP)
-	addRules = append(addRules, rule)
-	if err = c.execNatGwRules(gwPod, natGwSubnetFipAdd, addRules); err != nil {
+	rules := &natgw.RuleSet{FloatingIPs: []natgw.FloatingIP{{EIP: v4ip, InternalIP: internalIP}}}
+	if err := c.syncNatGwRules(dp, rules, nil); err != nil {
 		klog.Errorf("failed to create fip, err: %v", err)
 		return err
 	}
 	return nil
 }
 
+// deleteFipInPod deletes the fip, the existing sessions are flushed after the drain period of the fip
 func (c *Controller) deleteFipInPod(dp, v4ip, internalIP string) error {
-	gwPod, err := c.getNatGwPod(dp)
-	if err != nil {
+	rules := &natgw.RuleSet{FloatingIPs: []natgw.FloatingIP{{EIP: v4ip, InternalIP: internalIP}}}
+	if err := c.syncNatGwRules(dp, nil, rules); err != nil {
 		if k8serrors.IsNotFound(err) {
 			return nil
 		}
-		klog.Error(err)
-		return err
-	}
-	// del nat
-	var delRules []string
-	rule := fmt.Sprintf("%s,%s", v4ip, internalIP)
-	delRules = append(delRules, rule)
-	if err = c.execNatGwRules(gwPod, natGwSubnetFipDel, delRules); err != nil {
 		klog.Errorf("failed to delete fip, err: %v", err)
 		return err
 	}
 	return nil
 }
 
-func (c *Controller) createDnatInPod(dp, protocol, v4ip, internalIP, externalPort, internalPort string) error {
-	gwPod, err := c.getNatGwPod(dp)
-	if err != nil {
-		klog.Errorf("failed to get nat gw pod, %v", err)
+// replaceFipInPod replaces the old fip with the new one in a single sync, so that the sessions
+// of an unchanged fip are kept and only the sessions of a changed fip are flushed or drained
+func (c *Controller) replaceFipInPod(oldDp string, oldFip natgw.FloatingIP, newDp string, newFip natgw.FloatingIP) error {
+	if oldDp != "" && oldDp != newDp {
+		if err := c.deleteFipInPod(oldDp, oldFip.EIP, oldFip.InternalIP); err != nil {
+			return err
+		}
+	}
+	var deleted *natgw.RuleSet
+	if oldDp == newDp && oldFip.EIP != "" && oldFip.Rule() != newFip.Rule() {
+		deleted = &natgw.RuleSet{FloatingIPs: []natgw.FloatingIP{oldFip}}
+	}
+	added := &natgw.RuleSet{FloatingIPs: []natgw.FloatingIP{newFip}}
+	if err := c.syncNatGwRules(newDp, added, deleted); err != nil {
+		klog.Errorf("failed to replace fip, err: %v", err)
 		return err
 	}
-	var addRules []string
-	rule := fmt.Sprintf("%s,%s,%s,%s,%s", v4ip, externalPort, protocol, internalIP, internalPort)
-	addRules = append(addRules, rule)
+	return nil
+}
 
-	if err = c.execNatGwRules(gwPod, natGwDnatAdd, addRules); err != nil {
+func (c *Controller) createDnatInPod(dp, protocol, v4ip, internalIP, externalPort, internalPort string) error {
+	rules := &natgw.RuleSet{DNATs: natGwDNATs(v4ip, protocol, internalIP, externalPort, internalPort, 0)}
+	if err := c.syncNatGwRules(dp, rules, nil); err != nil {
 		klog.Errorf("failed to create dnat, err: %v", err)
 		return err
 	}
 	return nil
 }
 
+// deleteDnatInPod deletes the dnat, the existing sessions are flushed after the drain period of the dnat
 func (c *Controller) deleteDnatInPod(dp, protocol, v4ip, internalIP, externalPort, internalPort string) error {
-	gwPod, err := c.getNatGwPod(dp)
-	if err != nil {
+	rules := &natgw.RuleSet{DNATs: natGwDNATs(v4ip, protocol, internalIP, externalPort, internalPort, 0)}
+	if err := c.syncNatGwRules(dp, nil, rules); err != nil {
 		if k8serrors.IsNotFound(err) {
 			return nil
 		}
-		klog.Error(err)
-		return err
-	}
-
-	// del nat
-	var delRules []string
-	rule := fmt.Sprintf("%s,%s,%s,%s,%s", v4ip, externalPort, protocol, internalIP, internalPort)
-	delRules = append(delRules, rule)
-	if err = c.execNatGwRules(gwPod, natGwDnatDel, delRules); err != nil {
 		klog.Errorf("failed to delete dnat, err: %v", err)
 		return err
 	}
 	return nil
 }
 
-func (c *Controller) createSnatInPod(dp, v4ip, internalCIDR string) error {
-	gwPod, err := c.getNatGwPod(dp)
-	if err != nil {
-		klog.Errorf("failed to get nat gw pod, %v", err)
+// replaceDnatInPod replaces the rules of the old dnat with the new ones in a single sync like
+// replaceFipInPod, only the sessions of the ports which are changed are flushed or drained
+func (c *Controller) replaceDnatInPod(oldDp string, oldDnats []natgw.DNAT, newDp string, newDnats []natgw.DNAT) error {
+	if oldDp != "" && oldDp != newDp && len(oldDnats) != 0 {
+		if err := c.syncNatGwRules(oldDp, nil, &natgw.RuleSet{DNATs: oldDnats}); err != nil && !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to delete dnat, err: %v", err)
+			return err
+		}
+	}
+	var deleted *natgw.RuleSet
+	if oldDp == newDp {
+		if stale := removeNatGwRules(slices.Clone(oldDnats), newDnats); len(stale) != 0 {
+			deleted = &natgw.RuleSet{DNATs: stale}
+		}
+	}
+	added := &natgw.RuleSet{DNATs: newDnats}
+	if err := c.syncNatGwRules(newDp, added, deleted); err != nil {
+		klog.Errorf("failed to replace dnat, err: %v", err)
 		return err
 	}
-	var rules []string
-	rule := fmt.Sprintf("%s,%s", v4ip, internalCIDR)
+	return nil
+}
 
-	version, err := c.getIptablesVersion(gwPod)
+func (c *Controller) createSnatInPod(dp, v4ip, internalCIDR string, portBlockSize int32, portBlockStart int) error {
+	// the agent appends --random-fully if iptables supports it
+	snats, err := natGwSNATs(v4ip, internalCIDR, portBlockSize, portBlockStart)
 	if err != nil {
-		version = "1.0.0"
-		klog.Warningf("failed to checking iptables version, assuming version at least %s: %v", version, err)
-	}
-	if util.CompareVersion(version, "1.6.2") >= 1 {
-		rule = fmt.Sprintf("%s,%s", rule, "--random-fully")
+		return err
 	}
-
-	rules = append(rules, rule)
-	if err = c.execNatGwRules(gwPod, natGwSnatAdd, rules); err != nil {
-		klog.Errorf("failed to exec nat gateway rule, err: %v", err)
+	rules := &natgw.RuleSet{SNATs: snats}
+	if err := c.syncNatGwRules(dp, rules, nil); err != nil {
+		klog.Errorf("failed to create snat, err: %v", err)
 		return err
 	}
 	return nil
 }
 
-func (c *Controller) deleteSnatInPod(dp, v4ip, internalCIDR string) error {
-	gwPod, err := c.getNatGwPod(dp)
+func (c *Controller) deleteSnatInPod(dp, v4ip, internalCIDR string, portBlockSize int32, portBlockStart int) error {
+	snats, err := natGwSNATs(v4ip, internalCIDR, portBlockSize, portBlockStart)
 	if err != nil {
+		return err
+	}
+	rules := &natgw.RuleSet{SNATs: snats}
+	if err := c.syncNatGwRules(dp, nil, rules); err != nil {
 		if k8serrors.IsNotFound(err) {
 			return nil
 		}
-		klog.Error(err)
-		return err
-	}
-	// del nat
-	var delRules []string
-	rule := fmt.Sprintf("%s,%s", v4ip, internalCIDR)
-	delRules = append(delRules, rule)
-	if err = c.execNatGwRules(gwPod, natGwSnatDel, delRules); err != nil {
 		klog.Errorf("failed to delete snat, err: %v", err)
 		return err
 	}
@@ -1748,23 +1777,32 @@ func (c *Controller) snatChangeEip(snat *kubeovnv1.IptablesSnatRule, eip *kubeov
 	return false
 }
 
-func (c *Controller) isDnatDuplicated(gwName, eipName, dnatName, externalPort string) (bool, error) {
-	// check if eip:external port already used
+func (c *Controller) isDnatDuplicated(gwName, eipName, dnatName, protocol, externalPort, internalPort string) (bool, error) {
+	// check if any external port of the eip is already used
+	mappings, err := util.ParsePortMappings(externalPort, internalPort)
+	if err != nil {
+		err = fmt.Errorf("failed to create dnat %s, %v", dnatName, err)
+		return true, err
+	}
 	dnats, err := c.iptablesDnatRulesLister.List(labels.SelectorFromSet(labels.Set{
 		util.VpcNatGatewayNameLabel: gwName,
-		util.VpcDnatEPortLabel:      externalPort,
 	}))
 	if err != nil {
 		if !k8serrors.IsNotFound(err) {
 			return false, err
 		}
 	}
-	if len(dnats) != 0 {
-		for _, d := range dnats {
-			if d.Name != dnatName && d.Spec.EIP == eipName {
-				err = fmt.Errorf("failed to create dnat %s, duplicate, same eip %s, same external port '%s' is using by dnat %s", dnatName, eipName, externalPort, d.Name)
-				return true, err
-			}
+	for _, d := range dnats {
+		if d.Name == dnatName || d.Spec.EIP != eipName || util.DnatProtocol(d.Spec.Protocol) != util.DnatProtocol(protocol) {
+			continue
+		}
+		others, err := util.ParsePortMappings(d.Spec.ExternalPort, d.Spec.InternalPort)
+		if err != nil {
+			continue
+		}
+		if util.PortMappingsOverlap(mappings, others) {
+			err = fmt.Errorf("failed to create dnat %s, duplicate, same eip %s, external port '%s' overlaps with '%s' of dnat %s", dnatName, eipName, externalPort, d.Spec.ExternalPort, d.Name)
+			return true, err
 		}
 	}
 	return false, nil
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/natgw"
+)
+
+// resyncNatGwSessions records the active sessions of the fips and dnats in status,
+// the sessions are counted in the conntrack table of the active instance of each nat gateway
+func (c *Controller) resyncNatGwSessions() {
+	if vpcNatEnabled != "true" {
+		return
+	}
+	gws, err := c.vpcNatGatewayLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc nat gateways: %v", err)
+		return
+	}
+	for _, gw := range gws {
+		if err = c.syncNatGwSessions(gw); err != nil {
+			klog.Errorf("failed to sync sessions of vpc nat gw %s: %v", gw.Name, err)
+		}
+	}
+}
+
+func (c *Controller) syncNatGwSessions(gw *kubeovnv1.VpcNatGateway) error {
+	if gw.Status.ActiveInstance == "" {
+		return nil
+	}
+	pod, err := c.podsLister.Pods(c.config.PodNamespace).Get(gw.Status.ActiveInstance)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	client, err := c.getNatGwAgentClient(pod)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), natGwAgentRequestTimeout)
+	defer cancel()
+	sessions, err := client.Sessions(ctx)
+	if err != nil {
+		c.resetNatGwAgentClient(pod, err)
+		klog.Errorf("failed to get sessions of nat gw pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return err
+	}
+
+	fips, err := c.iptablesFipsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list iptables fips: %v", err)
+		return err
+	}
+	for _, fip := range fips {
+		if fip.Status.NatGwDp != gw.Name || fip.Status.V4ip == "" {
+			continue
+		}
+		rule := natgw.FloatingIP{EIP: fip.Status.V4ip, InternalIP: fip.Status.InternalIP}
+		count := natGwRuleSessions(sessions, natgw.RuleKindFloatingIP, rule.Rule())
+		if count == fip.Status.ActiveSessions {
+			continue
+		}
+		patch, _ := json.Marshal(map[string]interface{}{"status": map[string]int64{"activeSessions": count}})
+		if _, err = c.config.KubeOvnClient.KubeovnV1().IptablesFIPRules().Patch(context.Background(), fip.Name,
+			types.MergePatchType, patch, metav1.PatchOptions{}, "status"); err != nil && !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to patch sessions of fip %s: %v", fip.Name, err)
+			return err
+		}
+	}
+
+	dnats, err := c.iptablesDnatRulesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list iptables dnats: %v", err)
+		return err
+	}
+	for _, dnat := range dnats {
+		if dnat.Status.NatGwDp != gw.Name || dnat.Status.V4ip == "" {
+			continue
+		}
+		var count int64
+		for _, rule := range natGwDNATs(dnat.Status.V4ip, dnat.Status.Protocol, dnat.Status.InternalIP,
+			dnat.Status.ExternalPort, dnat.Status.InternalPort, 0) {
+			count += natGwRuleSessions(sessions, natgw.RuleKindDNAT, rule.Rule())
+		}
+		if count == dnat.Status.ActiveSessions {
+			continue
+		}
+		patch, _ := json.Marshal(map[string]interface{}{"status": map[string]int64{"activeSessions": count}})
+		if _, err = c.config.KubeOvnClient.KubeovnV1().IptablesDnatRules().Patch(context.Background(), dnat.Name,
+			types.MergePatchType, patch, metav1.PatchOptions{}, "status"); err != nil && !k8serrors.IsNotFound(err) {
+			klog.Errorf("failed to patch sessions of dnat %s: %v", dnat.Name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// natGwRuleSessions returns the active sessions of the applied rule, sessions still translated
+// by a draining rule with the same match belong to the old target and are not counted
+func natGwRuleSessions(sessions *natgw.Sessions, kind natgw.RuleKind, rule string) int64 {
+	if s := sessions.Find(kind, rule); s != nil {
+		return s.Sessions
+	}
+	return 0
+}
@@ -0,0 +1,566 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// moveVpcNatRule removes the implementation object of the rule from its previous backend,
+// the rule is created in the backend of the eip after the previous one is gone
+func (c *Controller) moveVpcNatRule(kind, name, oldBackend, newBackend string) error {
+	if oldBackend == "" || oldBackend == newBackend {
+		return nil
+	}
+	exists, err := c.deleteVpcNatImpl(kind, name, oldBackend)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("waiting for %s %s to be removed from the %s backend", kind, name, oldBackend)
+	}
+	return nil
+}
+
+// recreateVpcNatImpl deletes the implementation object whose spec differs from the rule,
+// as the nat rules of both backends do not support changing a ready rule in place
+func (c *Controller) recreateVpcNatImpl(kind, name, backend string) error {
+	if _, err := c.deleteVpcNatImpl(kind, name, backend); err != nil {
+		return err
+	}
+	return fmt.Errorf("waiting for %s %s to be recreated in the %s backend", kind, name, backend)
+}
+
+// vpcNatRuleTarget returns the backend the eip of the nat rules is migrated to, the nat rules are created
+// in the target backend while the current one keeps serving them, once the eip is ready in the target backend
+func vpcNatRuleTarget(eip *kubeovnv1.VpcEip) string {
+	if !eip.Status.TargetReady {
+		return ""
+	}
+	return eip.Status.TargetBackend
+}
+
+func newVpcNatImplMeta(owner metav1.Object, kind string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:            owner.GetName(),
+		OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(owner, kubeovnv1.SchemeGroupVersion.WithKind(kind))},
+	}
+}
+
+func (c *Controller) handleSyncVpcFip(key string) error {
+	cachedFip, err := c.vpcFipsLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	if !cachedFip.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	klog.Infof("handle sync vpc fip %s", key)
+
+	eip, message, err := c.vpcNatRuleEip(cachedFip.Spec.Eip)
+	if err != nil {
+		return err
+	}
+	if eip == nil {
+		return c.patchVpcFipStatus(cachedFip, cachedFip.Status.Backend, "", false, "", false, message)
+	}
+
+	backend, target := eip.Status.Backend, vpcNatRuleTarget(eip)
+	var ready, targetReady bool
+	if err = c.moveVpcNatRule(vpcFipKind, key, cachedFip.Status.Backend, backend); err == nil {
+		ready, err = c.syncVpcFipBackend(cachedFip, eip, backend)
+	}
+	if err != nil {
+		klog.Errorf("failed to sync %s backend of vpc fip %s: %v", backend, key, err)
+		if err2 := c.patchVpcFipStatus(cachedFip, cachedFip.Status.Backend, eip.Status.V4Ip, false,
+			cachedFip.Status.TargetBackend, cachedFip.Status.TargetReady, err.Error()); err2 != nil {
+			klog.Error(err2)
+		}
+		return err
+	}
+	if target != "" {
+		if targetReady, err = c.syncVpcFipBackend(cachedFip, eip, target); err != nil {
+			klog.Errorf("failed to sync %s backend of vpc fip %s: %v", target, key, err)
+			if err2 := c.patchVpcFipStatus(cachedFip, backend, eip.Status.V4Ip, ready, target, false, err.Error()); err2 != nil {
+				klog.Error(err2)
+			}
+			return err
+		}
+	}
+	return c.patchVpcFipStatus(cachedFip, backend, eip.Status.V4Ip, ready, target, targetReady, "")
+}
+
+func (c *Controller) syncVpcFipBackend(fip *kubeovnv1.VpcFip, eip *kubeovnv1.VpcEip, backend string) (bool, error) {
+	switch backend {
+	case kubeovnv1.NatBackendIptables:
+		return c.syncVpcFipIptables(fip, eip)
+	case kubeovnv1.NatBackendOvn:
+		return c.syncVpcFipOvn(fip, eip)
+	}
+	return false, nil
+}
+
+func (c *Controller) syncVpcFipIptables(fip *kubeovnv1.VpcFip, eip *kubeovnv1.VpcEip) (bool, error) {
+	impl, err := c.iptablesFipsLister.Get(fip.Name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Error(err)
+			return false, err
+		}
+		impl = &kubeovnv1.IptablesFIPRule{
+			ObjectMeta: newVpcNatImplMeta(fip, vpcFipKind),
+			Spec: kubeovnv1.IptablesFIPRuleSpec{
+				EIP:        eip.Name,
+				InternalIP: fip.Spec.InternalIP,
+			},
+		}
+		if _, err = c.config.KubeOvnClient.KubeovnV1().IptablesFIPRules().Create(context.Background(), impl, metav1.CreateOptions{}); err != nil {
+			klog.Errorf("failed to create iptables fip %s: %v", fip.Name, err)
+			return false, err
+		}
+		return false, nil
+	}
+
+	impl = impl.DeepCopy()
+	adopted, err := adoptVpcNatImpl(impl, fip, vpcFipKind)
+	if err != nil {
+		return false, err
+	}
+	if impl.Spec.EIP != eip.Name || impl.Spec.InternalIP != fip.Spec.InternalIP {
+		return false, c.recreateVpcNatImpl(vpcFipKind, fip.Name, kubeovnv1.NatBackendIptables)
+	}
+	if adopted {
+		if _, err = c.config.KubeOvnClient.KubeovnV1().IptablesFIPRules().Update(context.Background(), impl, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("failed to adopt iptables fip %s: %v", fip.Name, err)
+			return false, err
+		}
+	}
+	return impl.Status.Ready, nil
+}
+
+func (c *Controller) syncVpcFipOvn(fip *kubeovnv1.VpcFip, eip *kubeovnv1.VpcEip) (bool, error) {
+	spec := kubeovnv1.OvnFipSpec{
+		OvnEip: eip.Name,
+		Vpc:    eip.Spec.Vpc,
+		V4Ip:   fip.Spec.InternalIP,
+	}
+	impl, err := c.ovnFipsLister.Get(fip.Name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Error(err)
+			return false, err
+		}
+		impl = &kubeovnv1.OvnFip{ObjectMeta: newVpcNatImplMeta(fip, vpcFipKind), Spec: spec}
+		if _, err = c.config.KubeOvnClient.KubeovnV1().OvnFips().Create(context.Background(), impl, metav1.CreateOptions{}); err != nil {
+			klog.Errorf("failed to create ovn fip %s: %v", fip.Name, err)
+			return false, err
+		}
+		return false, nil
+	}
+
+	impl = impl.DeepCopy()
+	adopted, err := adoptVpcNatImpl(impl, fip, vpcFipKind)
+	if err != nil {
+		return false, err
+	}
+	vpc, v4ip, err := c.resolveOvnNatImplTarget(impl.Spec.Vpc, impl.Spec.V4Ip, impl.Spec.IPType, impl.Spec.IPName)
+	if err != nil {
+		return false, err
+	}
+	if impl.Spec.OvnEip != spec.OvnEip || vpc != spec.Vpc || v4ip != spec.V4Ip {
+		return false, c.recreateVpcNatImpl(vpcFipKind, fip.Name, kubeovnv1.NatBackendOvn)
+	}
+	if adopted {
+		if _, err = c.config.KubeOvnClient.KubeovnV1().OvnFips().Update(context.Background(), impl, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("failed to adopt ovn fip %s: %v", fip.Name, err)
+			return false, err
+		}
+	}
+	return impl.Status.Ready, nil
+}
+
+// resolveOvnNatImplTarget returns the vpc and the internal ip of an ovn fip or dnat rule,
+// which may refer to the internal ip by the name of an ip or a vip instead of the address.
+func (c *Controller) resolveOvnNatImplTarget(vpc, v4ip, ipType, ipName string) (string, string, error) {
+	if v4ip != "" || ipName == "" {
+		return vpc, v4ip, nil
+	}
+	var subnetName string
+	if ipType == util.Vip {
+		vip, err := c.virtualIpsLister.Get(ipName)
+		if err != nil {
+			klog.Errorf("failed to get vip %s, %v", ipName, err)
+			return "", "", err
+		}
+		v4ip, subnetName = vip.Status.V4ip, vip.Spec.Subnet
+	} else {
+		ip, err := c.ipsLister.Get(ipName)
+		if err != nil {
+			klog.Errorf("failed to get ip %s, %v", ipName, err)
+			return "", "", err
+		}
+		v4ip, subnetName = ip.Spec.V4IPAddress, ip.Spec.Subnet
+	}
+	if vpc == "" {
+		subnet, err := c.subnetsLister.Get(subnetName)
+		if err != nil {
+			klog.Errorf("failed to get vpc subnet %s, %v", subnetName, err)
+			return "", "", err
+		}
+		vpc = subnet.Spec.Vpc
+	}
+	return vpc, v4ip, nil
+}
+
+func (c *Controller) patchVpcFipStatus(fip *kubeovnv1.VpcFip, backend, v4ip string, ready bool, target string, targetReady bool, message string) error {
+	status := kubeovnv1.VpcFipStatus{Backend: backend, V4Ip: v4ip, Ready: ready, Message: message, TargetBackend: target, TargetReady: targetReady}
+	if fip.Status == status {
+		return nil
+	}
+	bytes, err := status.Bytes()
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().VpcFips().Patch(context.Background(), fip.Name, types.MergePatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Errorf("failed to patch status of vpc fip %s: %v", fip.Name, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) handleSyncVpcDnatRule(key string) error {
+	cachedDnat, err := c.vpcDnatRulesLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	if !cachedDnat.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	klog.Infof("handle sync vpc dnat rule %s", key)
+
+	eip, message, err := c.vpcNatRuleEip(cachedDnat.Spec.Eip)
+	if err != nil {
+		return err
+	}
+	if eip == nil {
+		return c.patchVpcDnatRuleStatus(cachedDnat, cachedDnat.Status.Backend, "", false, "", false, message)
+	}
+
+	backend, target := eip.Status.Backend, vpcNatRuleTarget(eip)
+	var ready, targetReady bool
+	if err = c.moveVpcNatRule(vpcDnatRuleKind, key, cachedDnat.Status.Backend, backend); err == nil {
+		ready, err = c.syncVpcDnatRuleBackend(cachedDnat, eip, backend)
+	}
+	if err != nil {
+		klog.Errorf("failed to sync %s backend of vpc dnat rule %s: %v", backend, key, err)
+		if err2 := c.patchVpcDnatRuleStatus(cachedDnat, cachedDnat.Status.Backend, eip.Status.V4Ip, false,
+			cachedDnat.Status.TargetBackend, cachedDnat.Status.TargetReady, err.Error()); err2 != nil {
+			klog.Error(err2)
+		}
+		return err
+	}
+	if target != "" {
+		if targetReady, err = c.syncVpcDnatRuleBackend(cachedDnat, eip, target); err != nil {
+			klog.Errorf("failed to sync %s backend of vpc dnat rule %s: %v", target, key, err)
+			if err2 := c.patchVpcDnatRuleStatus(cachedDnat, backend, eip.Status.V4Ip, ready, target, false, err.Error()); err2 != nil {
+				klog.Error(err2)
+			}
+			return err
+		}
+	}
+	return c.patchVpcDnatRuleStatus(cachedDnat, backend, eip.Status.V4Ip, ready, target, targetReady, "")
+}
+
+func (c *Controller) syncVpcDnatRuleBackend(dnat *kubeovnv1.VpcDnatRule, eip *kubeovnv1.VpcEip, backend string) (bool, error) {
+	switch backend {
+	case kubeovnv1.NatBackendIptables:
+		return c.syncVpcDnatRuleIptables(dnat, eip)
+	case kubeovnv1.NatBackendOvn:
+		return c.syncVpcDnatRuleOvn(dnat, eip)
+	}
+	return false, nil
+}
+
+func (c *Controller) syncVpcDnatRuleIptables(dnat *kubeovnv1.VpcDnatRule, eip *kubeovnv1.VpcEip) (bool, error) {
+	impl, err := c.iptablesDnatRulesLister.Get(dnat.Name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Error(err)
+			return false, err
+		}
+		impl = &kubeovnv1.IptablesDnatRule{
+			ObjectMeta: newVpcNatImplMeta(dnat, vpcDnatRuleKind),
+			Spec: kubeovnv1.IptablesDnatRuleSpec{
+				EIP:          eip.Name,
+				ExternalPort: dnat.Spec.ExternalPort,
+				Protocol:     dnat.Spec.Protocol,
+				InternalIP:   dnat.Spec.InternalIP,
+				InternalPort: dnat.Spec.InternalPort,
+			},
+		}
+		if _, err = c.config.KubeOvnClient.KubeovnV1().IptablesDnatRules().Create(context.Background(), impl, metav1.CreateOptions{}); err != nil {
+			klog.Errorf("failed to create iptables dnat rule %s: %v", dnat.Name, err)
+			return false, err
+		}
+		return false, nil
+	}
+
+	impl = impl.DeepCopy()
+	adopted, err := adoptVpcNatImpl(impl, dnat, vpcDnatRuleKind)
+	if err != nil {
+		return false, err
+	}
+	if impl.Spec.EIP != eip.Name ||
+		impl.Spec.ExternalPort != dnat.Spec.ExternalPort ||
+		impl.Spec.Protocol != dnat.Spec.Protocol ||
+		impl.Spec.InternalIP != dnat.Spec.InternalIP ||
+		impl.Spec.InternalPort != dnat.Spec.InternalPort {
+		return false, c.recreateVpcNatImpl(vpcDnatRuleKind, dnat.Name, kubeovnv1.NatBackendIptables)
+	}
+	if adopted {
+		if _, err = c.config.KubeOvnClient.KubeovnV1().IptablesDnatRules().Update(context.Background(), impl, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("failed to adopt iptables dnat rule %s: %v", dnat.Name, err)
+			return false, err
+		}
+	}
+	return impl.Status.Ready, nil
+}
+
+func (c *Controller) syncVpcDnatRuleOvn(dnat *kubeovnv1.VpcDnatRule, eip *kubeovnv1.VpcEip) (bool, error) {
+	spec := kubeovnv1.OvnDnatRuleSpec{
+		OvnEip:       eip.Name,
+		InternalPort: dnat.Spec.InternalPort,
+		ExternalPort: dnat.Spec.ExternalPort,
+		Protocol:     dnat.Spec.Protocol,
+		Vpc:          eip.Spec.Vpc,
+		V4Ip:         dnat.Spec.InternalIP,
+	}
+	impl, err := c.ovnDnatRulesLister.Get(dnat.Name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Error(err)
+			return false, err
+		}
+		impl = &kubeovnv1.OvnDnatRule{ObjectMeta: newVpcNatImplMeta(dnat, vpcDnatRuleKind), Spec: spec}
+		if _, err = c.config.KubeOvnClient.KubeovnV1().OvnDnatRules().Create(context.Background(), impl, metav1.CreateOptions{}); err != nil {
+			klog.Errorf("failed to create ovn dnat rule %s: %v", dnat.Name, err)
+			return false, err
+		}
+		return false, nil
+	}
+
+	impl = impl.DeepCopy()
+	adopted, err := adoptVpcNatImpl(impl, dnat, vpcDnatRuleKind)
+	if err != nil {
+		return false, err
+	}
+	vpc, v4ip, err := c.resolveOvnNatImplTarget(impl.Spec.Vpc, impl.Spec.V4Ip, impl.Spec.IPType, impl.Spec.IPName)
+	if err != nil {
+		return false, err
+	}
+	if impl.Spec.OvnEip != spec.OvnEip || vpc != spec.Vpc || v4ip != spec.V4Ip ||
+		impl.Spec.InternalPort != spec.InternalPort || impl.Spec.ExternalPort != spec.ExternalPort || impl.Spec.Protocol != spec.Protocol {
+		return false, c.recreateVpcNatImpl(vpcDnatRuleKind, dnat.Name, kubeovnv1.NatBackendOvn)
+	}
+	if adopted {
+		if _, err = c.config.KubeOvnClient.KubeovnV1().OvnDnatRules().Update(context.Background(), impl, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("failed to adopt ovn dnat rule %s: %v", dnat.Name, err)
+			return false, err
+		}
+	}
+	return impl.Status.Ready, nil
+}
+
+func (c *Controller) patchVpcDnatRuleStatus(dnat *kubeovnv1.VpcDnatRule, backend, v4ip string, ready bool, target string, targetReady bool, message string) error {
+	status := kubeovnv1.VpcDnatRuleStatus{Backend: backend, V4Ip: v4ip, Ready: ready, Message: message, TargetBackend: target, TargetReady: targetReady}
+	if dnat.Status == status {
+		return nil
+	}
+	bytes, err := status.Bytes()
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().VpcDnatRules().Patch(context.Background(), dnat.Name, types.MergePatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Errorf("failed to patch status of vpc dnat rule %s: %v", dnat.Name, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) handleSyncVpcSnatRule(key string) error {
+	cachedSnat, err := c.vpcSnatRulesLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	if !cachedSnat.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	klog.Infof("handle sync vpc snat rule %s", key)
+
+	eip, message, err := c.vpcNatRuleEip(cachedSnat.Spec.Eip)
+	if err != nil {
+		return err
+	}
+	if eip == nil {
+		return c.patchVpcSnatRuleStatus(cachedSnat, cachedSnat.Status.Backend, "", false, "", false, message)
+	}
+
+	backend, target := eip.Status.Backend, vpcNatRuleTarget(eip)
+	var ready, targetReady bool
+	if err = c.moveVpcNatRule(vpcSnatRuleKind, key, cachedSnat.Status.Backend, backend); err == nil {
+		ready, err = c.syncVpcSnatRuleBackend(cachedSnat, eip, backend)
+	}
+	if err != nil {
+		klog.Errorf("failed to sync %s backend of vpc snat rule %s: %v", backend, key, err)
+		if err2 := c.patchVpcSnatRuleStatus(cachedSnat, cachedSnat.Status.Backend, eip.Status.V4Ip, false,
+			cachedSnat.Status.TargetBackend, cachedSnat.Status.TargetReady, err.Error()); err2 != nil {
+			klog.Error(err2)
+		}
+		return err
+	}
+	if target != "" {
+		if targetReady, err = c.syncVpcSnatRuleBackend(cachedSnat, eip, target); err != nil {
+			klog.Errorf("failed to sync %s backend of vpc snat rule %s: %v", target, key, err)
+			if err2 := c.patchVpcSnatRuleStatus(cachedSnat, backend, eip.Status.V4Ip, ready, target, false, err.Error()); err2 != nil {
+				klog.Error(err2)
+			}
+			return err
+		}
+	}
+	return c.patchVpcSnatRuleStatus(cachedSnat, backend, eip.Status.V4Ip, ready, target, targetReady, "")
+}
+
+func (c *Controller) syncVpcSnatRuleBackend(snat *kubeovnv1.VpcSnatRule, eip *kubeovnv1.VpcEip, backend string) (bool, error) {
+	switch backend {
+	case kubeovnv1.NatBackendIptables:
+		return c.syncVpcSnatRuleIptables(snat, eip)
+	case kubeovnv1.NatBackendOvn:
+		return c.syncVpcSnatRuleOvn(snat, eip)
+	}
+	return false, nil
+}
+
+func (c *Controller) syncVpcSnatRuleIptables(snat *kubeovnv1.VpcSnatRule, eip *kubeovnv1.VpcEip) (bool, error) {
+	impl, err := c.iptablesSnatRulesLister.Get(snat.Name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Error(err)
+			return false, err
+		}
+		impl = &kubeovnv1.IptablesSnatRule{
+			ObjectMeta: newVpcNatImplMeta(snat, vpcSnatRuleKind),
+			Spec: kubeovnv1.IptablesSnatRuleSpec{
+				EIP:          eip.Name,
+				InternalCIDR: snat.Spec.InternalCIDR,
+			},
+		}
+		if _, err = c.config.KubeOvnClient.KubeovnV1().IptablesSnatRules().Create(context.Background(), impl, metav1.CreateOptions{}); err != nil {
+			klog.Errorf("failed to create iptables snat rule %s: %v", snat.Name, err)
+			return false, err
+		}
+		return false, nil
+	}
+
+	impl = impl.DeepCopy()
+	adopted, err := adoptVpcNatImpl(impl, snat, vpcSnatRuleKind)
+	if err != nil {
+		return false, err
+	}
+	if impl.Spec.EIP != eip.Name || impl.Spec.InternalCIDR != snat.Spec.InternalCIDR {
+		return false, c.recreateVpcNatImpl(vpcSnatRuleKind, snat.Name, kubeovnv1.NatBackendIptables)
+	}
+	if adopted {
+		if _, err = c.config.KubeOvnClient.KubeovnV1().IptablesSnatRules().Update(context.Background(), impl, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("failed to adopt iptables snat rule %s: %v", snat.Name, err)
+			return false, err
+		}
+	}
+	return impl.Status.Ready, nil
+}
+
+func (c *Controller) syncVpcSnatRuleOvn(snat *kubeovnv1.VpcSnatRule, eip *kubeovnv1.VpcEip) (bool, error) {
+	impl, err := c.ovnSnatRulesLister.Get(snat.Name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Error(err)
+			return false, err
+		}
+		impl = &kubeovnv1.OvnSnatRule{
+			ObjectMeta: newVpcNatImplMeta(snat, vpcSnatRuleKind),
+			Spec: kubeovnv1.OvnSnatRuleSpec{
+				OvnEip:   eip.Name,
+				Vpc:      eip.Spec.Vpc,
+				V4IpCidr: snat.Spec.InternalCIDR,
+			},
+		}
+		if _, err = c.config.KubeOvnClient.KubeovnV1().OvnSnatRules().Create(context.Background(), impl, metav1.CreateOptions{}); err != nil {
+			klog.Errorf("failed to create ovn snat rule %s: %v", snat.Name, err)
+			return false, err
+		}
+		return false, nil
+	}
+
+	impl = impl.DeepCopy()
+	adopted, err := adoptVpcNatImpl(impl, snat, vpcSnatRuleKind)
+	if err != nil {
+		return false, err
+	}
+	if impl.Spec.OvnEip != eip.Name || impl.Spec.Vpc != eip.Spec.Vpc || impl.Spec.V4IpCidr != snat.Spec.InternalCIDR ||
+		impl.Spec.VpcSubnet != "" || impl.Spec.IPName != "" {
+		return false, c.recreateVpcNatImpl(vpcSnatRuleKind, snat.Name, kubeovnv1.NatBackendOvn)
+	}
+	if adopted {
+		if _, err = c.config.KubeOvnClient.KubeovnV1().OvnSnatRules().Update(context.Background(), impl, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("failed to adopt ovn snat rule %s: %v", snat.Name, err)
+			return false, err
+		}
+	}
+	return impl.Status.Ready, nil
+}
+
+func (c *Controller) patchVpcSnatRuleStatus(snat *kubeovnv1.VpcSnatRule, backend, v4ip string, ready bool, target string, targetReady bool, message string) error {
+	status := kubeovnv1.VpcSnatRuleStatus{Backend: backend, V4Ip: v4ip, Ready: ready, Message: message, TargetBackend: target, TargetReady: targetReady}
+	if snat.Status == status {
+		return nil
+	}
+	bytes, err := status.Bytes()
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().VpcSnatRules().Patch(context.Background(), snat.Name, types.MergePatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Errorf("failed to patch status of vpc snat rule %s: %v", snat.Name, err)
+		return err
+	}
+	return nil
+}
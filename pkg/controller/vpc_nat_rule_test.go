@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	kubeovnfake "github.com/kubeovn/kube-ovn/pkg/client/clientset/versioned/fake"
+	kubeovnlister "github.com/kubeovn/kube-ovn/pkg/client/listers/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func Test_syncVpcNatRuleOvnAdopt(t *testing.T) {
+	t.Parallel()
+
+	newIndexer := func(objects ...interface{}) cache.Indexer {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		for _, obj := range objects {
+			require.NoError(t, indexer.Add(obj))
+		}
+		return indexer
+	}
+	eip := &kubeovnv1.VpcEip{ObjectMeta: metav1.ObjectMeta{Name: "eip1"}, Spec: kubeovnv1.VpcEipSpec{Vpc: "vpc1"}}
+	fip := &kubeovnv1.VpcFip{
+		ObjectMeta: metav1.ObjectMeta{Name: "fip1", UID: types.UID("fip1")},
+		Spec:       kubeovnv1.VpcFipSpec{Eip: "eip1", InternalIP: "10.0.1.2"},
+	}
+	dnat := &kubeovnv1.VpcDnatRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "dnat1", UID: types.UID("dnat1")},
+		Spec:       kubeovnv1.VpcDnatRuleSpec{Eip: "eip1", InternalIP: "10.0.1.3", InternalPort: "80", ExternalPort: "8080", Protocol: "tcp"},
+	}
+	// the existing ovn rules refer to the internal ips by the names of an ip and a vip
+	ovnFip := &kubeovnv1.OvnFip{
+		ObjectMeta: metav1.ObjectMeta{Name: "fip1"},
+		Spec:       kubeovnv1.OvnFipSpec{OvnEip: "eip1", IPType: "ip", IPName: "ip1"},
+		Status:     kubeovnv1.OvnFipStatus{Ready: true},
+	}
+	ovnDnat := &kubeovnv1.OvnDnatRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "dnat1"},
+		Spec:       kubeovnv1.OvnDnatRuleSpec{OvnEip: "eip1", IPType: util.Vip, IPName: "vip1", InternalPort: "80", ExternalPort: "8080", Protocol: "tcp"},
+		Status:     kubeovnv1.OvnDnatRuleStatus{Ready: true},
+	}
+	ip := &kubeovnv1.IP{ObjectMeta: metav1.ObjectMeta{Name: "ip1"}, Spec: kubeovnv1.IPSpec{Subnet: "subnet1", V4IPAddress: "10.0.1.2"}}
+	vip := &kubeovnv1.Vip{ObjectMeta: metav1.ObjectMeta{Name: "vip1"}, Spec: kubeovnv1.VipSpec{Subnet: "subnet1"}, Status: kubeovnv1.VipStatus{V4ip: "10.0.1.3"}}
+	subnet := &kubeovnv1.Subnet{ObjectMeta: metav1.ObjectMeta{Name: "subnet1"}, Spec: kubeovnv1.SubnetSpec{Vpc: "vpc1"}}
+
+	client := kubeovnfake.NewSimpleClientset()
+	_, err := client.KubeovnV1().OvnFips().Create(context.Background(), ovnFip, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = client.KubeovnV1().OvnDnatRules().Create(context.Background(), ovnDnat, metav1.CreateOptions{})
+	require.NoError(t, err)
+	c := &Controller{
+		config:             &Configuration{KubeOvnClient: client},
+		ovnFipsLister:      kubeovnlister.NewOvnFipLister(newIndexer(ovnFip)),
+		ovnDnatRulesLister: kubeovnlister.NewOvnDnatRuleLister(newIndexer(ovnDnat)),
+		ipsLister:          kubeovnlister.NewIPLister(newIndexer(ip)),
+		virtualIpsLister:   kubeovnlister.NewVipLister(newIndexer(vip)),
+		subnetsLister:      kubeovnlister.NewSubnetLister(newIndexer(subnet)),
+	}
+
+	// the rules are adopted instead of being recreated
+	ready, err := c.syncVpcFipOvn(fip, eip)
+	require.NoError(t, err)
+	require.True(t, ready)
+	adoptedFip, err := client.KubeovnV1().OvnFips().Get(context.Background(), "fip1", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, metav1.IsControlledBy(adoptedFip, fip))
+	require.Equal(t, ovnFip.Spec, adoptedFip.Spec)
+
+	ready, err = c.syncVpcDnatRuleOvn(dnat, eip)
+	require.NoError(t, err)
+	require.True(t, ready)
+	adoptedDnat, err := client.KubeovnV1().OvnDnatRules().Get(context.Background(), "dnat1", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, metav1.IsControlledBy(adoptedDnat, dnat))
+	require.Equal(t, ovnDnat.Spec, adoptedDnat.Spec)
+}
@@ -0,0 +1,657 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func (c *Controller) enqueueAddVpcPeeringConnection(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue add vpc peering connection %s", key)
+	c.syncVpcPeeringConnectionQueue.Add(key)
+}
+
+func (c *Controller) enqueueUpdateVpcPeeringConnection(oldObj, newObj interface{}) {
+	oldConn := oldObj.(*kubeovnv1.VpcPeeringConnection)
+	newConn := newObj.(*kubeovnv1.VpcPeeringConnection)
+	key, err := cache.MetaNamespaceKeyFunc(newObj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	if !newConn.DeletionTimestamp.IsZero() || !reflect.DeepEqual(oldConn.Spec, newConn.Spec) {
+		klog.V(3).Infof("enqueue update vpc peering connection %s", key)
+		c.syncVpcPeeringConnectionQueue.Add(key)
+	}
+	if !reflect.DeepEqual(oldConn.Spec, newConn.Spec) || !reflect.DeepEqual(oldConn.Status, newConn.Status) {
+		// the peer follows the handshake, the interconnect addresses and the propagated subnets of this side
+		c.enqueueVpcPeeringConnectionPeer(newConn)
+	}
+	if oldConn.Status.Phase != newConn.Status.Phase || !reflect.DeepEqual(oldConn.Status.Routes, newConn.Status.Routes) ||
+		!newConn.DeletionTimestamp.IsZero() {
+		// the routes of the connection are applied by the vpc
+		c.addOrUpdateVpcQueue.Add(newConn.Spec.Vpc)
+	}
+}
+
+func (c *Controller) enqueueDeleteVpcPeeringConnection(obj interface{}) {
+	var conn *kubeovnv1.VpcPeeringConnection
+	switch t := obj.(type) {
+	case *kubeovnv1.VpcPeeringConnection:
+		conn = t
+	case cache.DeletedFinalStateUnknown:
+		obj, ok := t.Obj.(*kubeovnv1.VpcPeeringConnection)
+		if !ok {
+			klog.Warningf("unexpected object type: %T", t.Obj)
+			return
+		}
+		conn = obj
+	default:
+		klog.Warningf("unexpected type: %T", obj)
+		return
+	}
+
+	klog.V(3).Infof("enqueue delete vpc peering connection %s/%s", conn.Namespace, conn.Name)
+	c.enqueueVpcPeeringConnectionPeer(conn)
+	c.addOrUpdateVpcQueue.Add(conn.Spec.Vpc)
+}
+
+// enqueueVpcPeeringConnectionPeer enqueues the requester of an accepter, or the accepters of a requester
+func (c *Controller) enqueueVpcPeeringConnectionPeer(conn *kubeovnv1.VpcPeeringConnection) {
+	if conn.Spec.Requester != nil {
+		c.syncVpcPeeringConnectionQueue.Add(conn.Spec.Requester.String())
+		return
+	}
+	conns, err := c.vpcPeeringConnectionsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc peering connections: %v", err)
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, accepter := range conns {
+		if accepter.Spec.Requester != nil && accepter.Spec.Requester.Namespace == conn.Namespace && accepter.Spec.Requester.Name == conn.Name {
+			c.syncVpcPeeringConnectionQueue.Add(accepter.Namespace + "/" + accepter.Name)
+		}
+	}
+}
+
+// enqueueVpcPeeringConnectionsForVpc enqueues the connections of both sides of the vpc
+// whose owner namespaces or peerings may have changed
+func (c *Controller) enqueueVpcPeeringConnectionsForVpc(vpcName string) {
+	conns, err := c.vpcPeeringConnectionsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc peering connections: %v", err)
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, conn := range conns {
+		if conn.Spec.Vpc == vpcName || conn.Spec.RemoteVpc == vpcName || conn.Status.RemoteVpc == vpcName {
+			klog.V(3).Infof("enqueue update vpc peering connection %s/%s for vpc %s", conn.Namespace, conn.Name, vpcName)
+			c.syncVpcPeeringConnectionQueue.Add(conn.Namespace + "/" + conn.Name)
+		}
+	}
+}
+
+func (c *Controller) runSyncVpcPeeringConnectionWorker() {
+	for c.processNextWorkItem("syncVpcPeeringConnection", c.syncVpcPeeringConnectionQueue, c.handleSyncVpcPeeringConnection) {
+	}
+}
+
+func (c *Controller) handleSyncVpcPeeringConnection(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	cachedConn, err := c.vpcPeeringConnectionsLister.VpcPeeringConnections(namespace).Get(name)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	if !cachedConn.DeletionTimestamp.IsZero() {
+		return c.handleDelVpcPeeringConnection(cachedConn)
+	}
+	klog.Infof("handle sync vpc peering connection %s", key)
+
+	if err = c.handleAddVpcPeeringConnectionFinalizer(cachedConn); err != nil {
+		return err
+	}
+
+	status := cachedConn.Status.DeepCopy()
+	status.Role = kubeovnv1.VpcPeeringRoleRequester
+	if cachedConn.Spec.Requester != nil {
+		status.Role = kubeovnv1.VpcPeeringRoleAccepter
+	}
+	syncErr := c.syncVpcPeeringConnection(cachedConn, status)
+	accepted := status.IsConditionTrue(kubeovnv1.Accepted)
+	if (!accepted || status.Phase == kubeovnv1.VpcPeeringPhaseFailed || status.LocalConnectIP == "") && cachedConn.Status.RemoteVpc != "" {
+		// tear down the interconnect of the connection which is no longer accepted, valid or addressed,
+		// which also releases the interconnect addresses allocated by the requester
+		if err = c.OVNNbClient.DeleteLogicalRouterPort(vpcPeeringRouterPort(cachedConn.Spec.Vpc, cachedConn.Status.RemoteVpc)); err != nil {
+			klog.Errorf("failed to delete peer router port of vpc peering connection %s: %v", key, err)
+			return err
+		}
+		status.LocalConnectIP = ""
+		status.RemoteConnectIP = ""
+		status.Routes = nil
+	}
+	if status.LocalConnectIP == "" {
+		c.vpcPeeringBlocks.release(key)
+	}
+	if !accepted {
+		status.Peer = nil
+		status.RemoteVpc = ""
+	}
+	if err = c.patchVpcPeeringConnectionStatus(cachedConn, status); err != nil {
+		return err
+	}
+	return syncErr
+}
+
+// syncVpcPeeringConnection sets up the local side of the connection and records the result in the status,
+// only the errors which should be retried are returned
+func (c *Controller) syncVpcPeeringConnection(conn *kubeovnv1.VpcPeeringConnection, status *kubeovnv1.VpcPeeringConnectionStatus) error {
+	key := conn.Namespace + "/" + conn.Name
+	status.Phase = kubeovnv1.VpcPeeringPhaseFailed
+
+	vpc, err := c.vpcsLister.Get(conn.Spec.Vpc)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Error(err)
+			return err
+		}
+		msg := fmt.Sprintf("vpc %s not found", conn.Spec.Vpc)
+		status.ClearCondition(kubeovnv1.Validated, "VpcNotFound", msg)
+		status.ClearCondition(kubeovnv1.Ready, "VpcNotFound", msg)
+		return nil
+	}
+	if !slices.Contains(vpc.Spec.Namespaces, conn.Namespace) {
+		msg := fmt.Sprintf("vpc %s is not owned by namespace %s", vpc.Name, conn.Namespace)
+		status.ClearCondition(kubeovnv1.Validated, "VpcNotOwned", msg)
+		status.ClearCondition(kubeovnv1.Ready, "VpcNotOwned", msg)
+		return nil
+	}
+	if _, msg, err := c.getVpcPeeringSubnetCIDRs(conn); err != nil || msg != "" {
+		if err != nil {
+			return err
+		}
+		status.ClearCondition(kubeovnv1.Validated, "InvalidSubnet", msg)
+		status.ClearCondition(kubeovnv1.Ready, "InvalidSubnet", msg)
+		return nil
+	}
+	status.SetCondition(kubeovnv1.Validated, "Validated", "")
+
+	status.Phase = kubeovnv1.VpcPeeringPhasePending
+	requester, accepter, msg, err := c.getVpcPeeringPair(conn)
+	if err != nil {
+		return err
+	}
+	if msg != "" {
+		status.ClearCondition(kubeovnv1.Accepted, "NotAccepted", msg)
+		status.ClearCondition(kubeovnv1.Ready, "NotAccepted", msg)
+		return nil
+	}
+	status.SetCondition(kubeovnv1.Accepted, "Accepted", "")
+
+	peer := requester
+	if requester.UID == conn.UID {
+		peer = accepter
+	}
+	if status.RemoteVpc != "" && status.RemoteVpc != peer.Spec.Vpc {
+		// the requester has been recreated with another vpc
+		if err = c.OVNNbClient.DeleteLogicalRouterPort(vpcPeeringRouterPort(conn.Spec.Vpc, status.RemoteVpc)); err != nil {
+			klog.Errorf("failed to delete peer router port of vpc peering connection %s: %v", key, err)
+			return err
+		}
+	}
+	status.Peer = &kubeovnv1.VpcPeeringConnectionReference{Namespace: peer.Namespace, Name: peer.Name}
+	status.RemoteVpc = peer.Spec.Vpc
+
+	status.Phase = kubeovnv1.VpcPeeringPhaseFailed
+	if msg, err = c.checkVpcPeeringConflict(vpc, peer.Spec.Vpc); err != nil || msg != "" {
+		if err != nil {
+			return err
+		}
+		status.ClearCondition(kubeovnv1.Ready, "VpcPeeringConflict", msg)
+		return nil
+	}
+	remoteCIDRs, msg, err := c.getVpcPeeringSubnetCIDRs(peer)
+	if err != nil {
+		return err
+	}
+	if msg != "" {
+		status.Phase = kubeovnv1.VpcPeeringPhasePending
+		status.ClearCondition(kubeovnv1.Ready, "InvalidRemoteSubnet", msg)
+		return nil
+	}
+	if msg, err = c.checkVpcPeeringCIDRConflict(conn.Spec.Vpc, remoteCIDRs); err != nil || msg != "" {
+		if err != nil {
+			return err
+		}
+		status.ClearCondition(kubeovnv1.Ready, "CIDRConflict", msg)
+		return nil
+	}
+
+	// the requester allocates the interconnect addresses of both sides
+	status.Phase = kubeovnv1.VpcPeeringPhasePending
+	if requester.UID == conn.UID {
+		if status.LocalConnectIP == "" {
+			if status.LocalConnectIP, status.RemoteConnectIP, err = c.allocateVpcPeeringAddresses(conn); err != nil {
+				klog.Errorf("failed to allocate interconnect addresses for vpc peering connection %s: %v", key, err)
+				status.ClearCondition(kubeovnv1.Ready, "AllocationFailed", err.Error())
+				return err
+			}
+		}
+	} else {
+		status.LocalConnectIP, status.RemoteConnectIP = requester.Status.RemoteConnectIP, requester.Status.LocalConnectIP
+		if status.LocalConnectIP == "" {
+			status.ClearCondition(kubeovnv1.Ready, "Allocating", "waiting for the requester to allocate the interconnect addresses")
+			return nil
+		}
+	}
+
+	if err = c.OVNNbClient.CreatePeerRouterPort(conn.Spec.Vpc, status.RemoteVpc, status.LocalConnectIP); err != nil {
+		klog.Errorf("failed to create peer router port for vpc peering connection %s: %v", key, err)
+		status.ClearCondition(kubeovnv1.Ready, "CreatePortFailed", err.Error())
+		return err
+	}
+	status.Routes = vpcPeeringRoutes(remoteCIDRs, strings.Split(status.RemoteConnectIP, "/")[0])
+	status.Phase = kubeovnv1.VpcPeeringPhaseActive
+	status.SetCondition(kubeovnv1.Ready, "Active", "")
+	return nil
+}
+
+// getVpcPeeringPair returns the requester and the accepter of the connection,
+// or a message explaining why the connection has not been accepted
+func (c *Controller) getVpcPeeringPair(conn *kubeovnv1.VpcPeeringConnection) (requester, accepter *kubeovnv1.VpcPeeringConnection, msg string, err error) {
+	requester = conn
+	if conn.Spec.Requester != nil {
+		ref := conn.Spec.Requester
+		if requester, err = c.vpcPeeringConnectionsLister.VpcPeeringConnections(ref.Namespace).Get(ref.Name); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				klog.Error(err)
+				return nil, nil, "", err
+			}
+			return nil, nil, fmt.Sprintf("requester %s not found", ref), nil
+		}
+		if !requester.DeletionTimestamp.IsZero() {
+			return nil, nil, fmt.Sprintf("requester %s is being deleted", ref), nil
+		}
+		if requester.Spec.RemoteVpc != conn.Spec.Vpc {
+			return nil, nil, fmt.Sprintf("requester %s requests to peer with vpc %s rather than %s", ref, requester.Spec.RemoteVpc, conn.Spec.Vpc), nil
+		}
+		if !c.vpcOwnedByNamespace(requester.Spec.Vpc, requester.Namespace) {
+			return nil, nil, fmt.Sprintf("vpc %s of requester %s is not owned by namespace %s", requester.Spec.Vpc, ref, requester.Namespace), nil
+		}
+	}
+
+	conns, err := c.vpcPeeringConnectionsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc peering connections: %v", err)
+		return nil, nil, "", err
+	}
+	accepters := make([]*kubeovnv1.VpcPeeringConnection, 0, 1)
+	for _, item := range conns {
+		if item.DeletionTimestamp.IsZero() && c.vpcOwnedByNamespace(item.Spec.Vpc, item.Namespace) {
+			accepters = append(accepters, item)
+		}
+	}
+	if accepter = selectVpcPeeringAccepter(requester, accepters); accepter == nil {
+		return nil, nil, fmt.Sprintf("waiting for the owner of vpc %s to accept", requester.Spec.RemoteVpc), nil
+	}
+	if requester.UID != conn.UID && accepter.UID != conn.UID {
+		return nil, nil, fmt.Sprintf("requester %s has been accepted by %s/%s", conn.Spec.Requester, accepter.Namespace, accepter.Name), nil
+	}
+	return requester, accepter, "", nil
+}
+
+// selectVpcPeeringAccepter returns the oldest connection accepting the requester with the requested vpc
+func selectVpcPeeringAccepter(requester *kubeovnv1.VpcPeeringConnection, conns []*kubeovnv1.VpcPeeringConnection) *kubeovnv1.VpcPeeringConnection {
+	var accepter *kubeovnv1.VpcPeeringConnection
+	for _, conn := range conns {
+		ref := conn.Spec.Requester
+		if ref == nil || ref.Namespace != requester.Namespace || ref.Name != requester.Name || conn.Spec.Vpc != requester.Spec.RemoteVpc {
+			continue
+		}
+		if accepter == nil || conn.CreationTimestamp.Before(&accepter.CreationTimestamp) ||
+			(conn.CreationTimestamp.Equal(&accepter.CreationTimestamp) && conn.Namespace+"/"+conn.Name < accepter.Namespace+"/"+accepter.Name) {
+			accepter = conn
+		}
+	}
+	return accepter
+}
+
+func (c *Controller) vpcOwnedByNamespace(vpcName, namespace string) bool {
+	vpc, err := c.vpcsLister.Get(vpcName)
+	if err != nil {
+		return false
+	}
+	return slices.Contains(vpc.Spec.Namespaces, namespace)
+}
+
+// getVpcPeeringSubnetCIDRs returns the ipv4 cidrs of the subnets propagated by the connection,
+// or a message explaining why a subnet can not be propagated
+func (c *Controller) getVpcPeeringSubnetCIDRs(conn *kubeovnv1.VpcPeeringConnection) ([]string, string, error) {
+	return c.getVpcSubnetCIDRs(conn.Spec.Vpc, conn.Spec.Subnets)
+}
+
+// getVpcSubnetCIDRs returns the ipv4 cidrs of the subnets of the vpc,
+// or a message explaining why a subnet can not be propagated
+func (c *Controller) getVpcSubnetCIDRs(vpcName string, subnets []string) ([]string, string, error) {
+	var cidrs []string
+	for _, name := range subnets {
+		subnet, err := c.subnetsLister.Get(name)
+		if err != nil {
+			if !k8serrors.IsNotFound(err) {
+				klog.Error(err)
+				return nil, "", err
+			}
+			return nil, fmt.Sprintf("subnet %s not found", name), nil
+		}
+		if subnet.Spec.Vpc != vpcName {
+			return nil, fmt.Sprintf("subnet %s does not belong to vpc %s", name, vpcName), nil
+		}
+		for _, cidr := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
+			// the interconnect only has ipv4 addresses
+			if util.CheckProtocol(cidr) == kubeovnv1.ProtocolIPv4 {
+				cidrs = append(cidrs, cidr)
+			}
+		}
+	}
+	return cidrs, "", nil
+}
+
+// checkVpcPeeringConflict rejects the vpcs already peered by the vpc peerings of the vpc spec,
+// which share the same router ports
+func (c *Controller) checkVpcPeeringConflict(vpc *kubeovnv1.Vpc, remoteVpcName string) (string, error) {
+	remoteVpc, err := c.vpcsLister.Get(remoteVpcName)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Error(err)
+			return "", err
+		}
+		return fmt.Sprintf("vpc %s not found", remoteVpcName), nil
+	}
+	for _, peering := range vpc.Spec.VpcPeerings {
+		if peering.RemoteVpc == remoteVpc.Name {
+			return fmt.Sprintf("vpc %s has peered with vpc %s by vpcPeerings", vpc.Name, remoteVpc.Name), nil
+		}
+	}
+	for _, peering := range remoteVpc.Spec.VpcPeerings {
+		if peering.RemoteVpc == vpc.Name {
+			return fmt.Sprintf("vpc %s has peered with vpc %s by vpcPeerings", remoteVpc.Name, vpc.Name), nil
+		}
+	}
+	return "", nil
+}
+
+// checkVpcPeeringCIDRConflict rejects the remote cidrs overlapping with the subnets of the local vpc
+func (c *Controller) checkVpcPeeringCIDRConflict(vpcName string, remoteCIDRs []string) (string, error) {
+	subnets, err := c.subnetsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list subnets: %v", err)
+		return "", err
+	}
+	for _, subnet := range subnets {
+		if subnet.Spec.Vpc != vpcName {
+			continue
+		}
+		for _, cidr := range remoteCIDRs {
+			if util.CIDROverlap(cidr, util.GetSubnetCIDRBlocks(subnet)) {
+				return fmt.Sprintf("remote cidr %s overlaps with subnet %s of vpc %s", cidr, subnet.Name, vpcName), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// allocateVpcPeeringAddresses allocates a free /30 block of the vpc peering cidr for the requester, the blocks
+// reserved for the other requesters are excluded even if they are not recorded in status yet
+func (c *Controller) allocateVpcPeeringAddresses(requester *kubeovnv1.VpcPeeringConnection) (string, string, error) {
+	conns, err := c.vpcPeeringConnectionsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc peering connections: %v", err)
+		return "", "", err
+	}
+	used := make([]string, 0, len(conns))
+	for _, conn := range conns {
+		if conn.Spec.Requester == nil && conn.Status.LocalConnectIP != "" && conn.UID != requester.UID {
+			used = append(used, conn.Status.LocalConnectIP)
+		}
+	}
+	return c.vpcPeeringBlocks.allocate(c.config.VpcPeeringCIDR, requester.Namespace+"/"+requester.Name, used)
+}
+
+type vpcPeeringBlock struct {
+	local, remote string
+}
+
+// vpcPeeringBlocks holds the /30 blocks of an interconnect cidr reserved by the controller, so that a block
+// allocated to an owner whose status is not patched yet is never allocated to another one
+type vpcPeeringBlocks struct {
+	mutex  sync.Mutex
+	blocks map[string]vpcPeeringBlock
+}
+
+func newVpcPeeringBlocks() *vpcPeeringBlocks {
+	return &vpcPeeringBlocks{blocks: make(map[string]vpcPeeringBlock)}
+}
+
+// allocate returns the addresses of the interconnect of the owner, the block reserved before is kept unless it
+// collides with the addresses in used, which are the ones recorded in status of the other owners
+func (b *vpcPeeringBlocks) allocate(cidr, owner string, used []string) (string, string, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if block, ok := b.blocks[owner]; ok && !vpcPeeringBlockUsed(block.local, used) {
+		return block.local, block.remote, nil
+	}
+	used = slices.Clone(used)
+	for other, block := range b.blocks {
+		if other != owner {
+			used = append(used, block.local)
+		}
+	}
+	local, remote, err := allocateVpcPeeringBlock(cidr, used)
+	if err != nil {
+		return "", "", err
+	}
+	b.blocks[owner] = vpcPeeringBlock{local: local, remote: remote}
+	return local, remote, nil
+}
+
+// release drops the reservation of the owner whose interconnect is torn down
+func (b *vpcPeeringBlocks) release(owner string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.blocks, owner)
+}
+
+// retain drops the reservations of the owners with the prefix except the ones in owners
+func (b *vpcPeeringBlocks) retain(prefix string, owners []string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for owner := range b.blocks {
+		if strings.HasPrefix(owner, prefix) && !slices.Contains(owners, owner) {
+			delete(b.blocks, owner)
+		}
+	}
+}
+
+func vpcPeeringBlockUsed(address string, used []string) bool {
+	block, ok := vpcPeeringBlockOf(address)
+	if !ok {
+		return false
+	}
+	for _, u := range used {
+		if b, ok := vpcPeeringBlockOf(u); ok && b == block {
+			return true
+		}
+	}
+	return false
+}
+
+// vpcPeeringBlockOf returns the first address of the /30 block of the interconnect address
+func vpcPeeringBlockOf(address string) (uint32, bool) {
+	ip := net.ParseIP(strings.Split(address, "/")[0]).To4()
+	if ip == nil {
+		return 0, false
+	}
+	return ipv4ToUint32(ip) &^ 3, true
+}
+
+// allocateVpcPeeringBlock returns the addresses of the two sides of an interconnect in the first /30 block
+// of the cidr which is not used by any address in used
+func allocateVpcPeeringBlock(cidr string, used []string) (string, string, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil || ipNet.IP.To4() == nil {
+		return "", "", fmt.Errorf("invalid interconnect cidr %s", cidr)
+	}
+	usedBlocks := make(map[uint32]bool, len(used))
+	for _, address := range used {
+		if block, ok := vpcPeeringBlockOf(address); ok {
+			usedBlocks[block] = true
+		}
+	}
+
+	ones, _ := ipNet.Mask.Size()
+	start := ipv4ToUint32(ipNet.IP.To4())
+	for i := uint64(0); i < uint64(1)<<(32-ones); i += 4 {
+		block := start + uint32(i)
+		if !usedBlocks[block] {
+			return uint32ToIPv4(block+1).String() + "/30", uint32ToIPv4(block+2).String() + "/30", nil
+		}
+	}
+	return "", "", fmt.Errorf("no free /30 block in interconnect cidr %s", cidr)
+}
+
+func ipv4ToUint32(ip net.IP) uint32 {
+	return uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3])
+}
+
+func uint32ToIPv4(n uint32) net.IP {
+	return net.IPv4(byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+}
+
+func vpcPeeringRoutes(cidrs []string, nextHop string) []*kubeovnv1.StaticRoute {
+	sort.Strings(cidrs)
+	routes := make([]*kubeovnv1.StaticRoute, 0, len(cidrs))
+	for _, cidr := range slices.Compact(cidrs) {
+		routes = append(routes, &kubeovnv1.StaticRoute{
+			Policy:     kubeovnv1.PolicyDst,
+			CIDR:       cidr,
+			NextHopIP:  nextHop,
+			RouteTable: util.MainRouteTable,
+		})
+	}
+	return routes
+}
+
+func vpcPeeringRouterPort(vpc, remoteVpc string) string {
+	return fmt.Sprintf("%s-%s", vpc, remoteVpc)
+}
+
+// getVpcPeeringConnectionRoutes returns the routes of the active peering connections of the vpc
+func (c *Controller) getVpcPeeringConnectionRoutes(vpcName string) ([]*kubeovnv1.StaticRoute, error) {
+	conns, err := c.vpcPeeringConnectionsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc peering connections: %v", err)
+		return nil, err
+	}
+	var routes []*kubeovnv1.StaticRoute
+	for _, conn := range conns {
+		if conn.Spec.Vpc == vpcName && conn.DeletionTimestamp.IsZero() && conn.Status.Phase == kubeovnv1.VpcPeeringPhaseActive {
+			routes = append(routes, conn.Status.Routes...)
+		}
+	}
+	return routes, nil
+}
+
+func (c *Controller) handleDelVpcPeeringConnection(conn *kubeovnv1.VpcPeeringConnection) error {
+	klog.Infof("handle delete vpc peering connection %s/%s", conn.Namespace, conn.Name)
+	if conn.Status.RemoteVpc != "" {
+		if err := c.OVNNbClient.DeleteLogicalRouterPort(vpcPeeringRouterPort(conn.Spec.Vpc, conn.Status.RemoteVpc)); err != nil {
+			klog.Errorf("failed to delete peer router port of vpc peering connection %s/%s: %v", conn.Namespace, conn.Name, err)
+			return err
+		}
+	}
+	c.vpcPeeringBlocks.release(conn.Namespace + "/" + conn.Name)
+	if !controllerutil.ContainsFinalizer(conn, util.ControllerName) {
+		return nil
+	}
+	newConn := conn.DeepCopy()
+	controllerutil.RemoveFinalizer(newConn, util.ControllerName)
+	return c.patchVpcPeeringConnectionFinalizers(conn, newConn)
+}
+
+func (c *Controller) handleAddVpcPeeringConnectionFinalizer(conn *kubeovnv1.VpcPeeringConnection) error {
+	if controllerutil.ContainsFinalizer(conn, util.ControllerName) {
+		return nil
+	}
+	newConn := conn.DeepCopy()
+	controllerutil.AddFinalizer(newConn, util.ControllerName)
+	return c.patchVpcPeeringConnectionFinalizers(conn, newConn)
+}
+
+func (c *Controller) patchVpcPeeringConnectionFinalizers(conn, newConn *kubeovnv1.VpcPeeringConnection) error {
+	patch, err := util.GenerateMergePatchPayload(conn, newConn)
+	if err != nil {
+		klog.Errorf("failed to generate patch payload for vpc peering connection %s/%s: %v", conn.Namespace, conn.Name, err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().VpcPeeringConnections(conn.Namespace).Patch(context.Background(), conn.Name,
+		types.MergePatchType, patch, metav1.PatchOptions{}, ""); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Errorf("failed to patch finalizers of vpc peering connection %s/%s: %v", conn.Namespace, conn.Name, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) patchVpcPeeringConnectionStatus(conn *kubeovnv1.VpcPeeringConnection, status *kubeovnv1.VpcPeeringConnectionStatus) error {
+	if reflect.DeepEqual(&conn.Status, status) {
+		return nil
+	}
+	bytes, err := status.Bytes()
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().VpcPeeringConnections(conn.Namespace).Patch(context.Background(), conn.Name,
+		types.MergePatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Errorf("failed to patch status of vpc peering connection %s/%s: %v", conn.Namespace, conn.Name, err)
+		return err
+	}
+	return nil
+}
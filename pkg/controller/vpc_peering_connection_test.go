@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+func Test_allocateVpcPeeringBlock(t *testing.T) {
+	t.Parallel()
+
+	local, remote, err := allocateVpcPeeringBlock("100.127.0.0/16", nil)
+	require.NoError(t, err)
+	require.Equal(t, "100.127.0.1/30", local)
+	require.Equal(t, "100.127.0.2/30", remote)
+
+	// the first free block is reused after a connection is deleted
+	local, remote, err = allocateVpcPeeringBlock("100.127.0.0/16", []string{"100.127.0.1/30", "100.127.0.9/30"})
+	require.NoError(t, err)
+	require.Equal(t, "100.127.0.5/30", local)
+	require.Equal(t, "100.127.0.6/30", remote)
+
+	_, _, err = allocateVpcPeeringBlock("100.127.0.0/29", []string{"100.127.0.1/30", "100.127.0.5/30"})
+	require.ErrorContains(t, err, "no free /30 block")
+
+	_, _, err = allocateVpcPeeringBlock("fd00::/64", nil)
+	require.Error(t, err)
+}
+
+func Test_vpcPeeringBlocksAllocate(t *testing.T) {
+	t.Parallel()
+
+	b := newVpcPeeringBlocks()
+
+	// the block reserved for an owner whose status is not patched yet is not allocated to another one
+	local, remote, err := b.allocate("100.127.0.0/16", "ns1/conn1", nil)
+	require.NoError(t, err)
+	require.Equal(t, "100.127.0.1/30", local)
+	require.Equal(t, "100.127.0.2/30", remote)
+	local, _, err = b.allocate("100.127.0.0/16", "ns1/conn2", nil)
+	require.NoError(t, err)
+	require.Equal(t, "100.127.0.5/30", local)
+
+	// the reservation is kept
+	local, _, err = b.allocate("100.127.0.0/16", "ns1/conn1", nil)
+	require.NoError(t, err)
+	require.Equal(t, "100.127.0.1/30", local)
+
+	// the reservation colliding with the addresses recorded in status is moved
+	local, _, err = b.allocate("100.127.0.0/16", "ns1/conn1", []string{"100.127.0.1/30"})
+	require.NoError(t, err)
+	require.Equal(t, "100.127.0.9/30", local)
+
+	// the released block is reused
+	b.release("ns1/conn2")
+	local, _, err = b.allocate("100.127.0.0/16", "ns1/conn3", []string{"100.127.0.1/30"})
+	require.NoError(t, err)
+	require.Equal(t, "100.127.0.5/30", local)
+
+	// only the reservations of the owners with the prefix which are not retained are dropped
+	b.retain("ns1/conn3", nil)
+	b.retain("ns1/", []string{"ns1/conn1"})
+	local, _, err = b.allocate("100.127.0.0/16", "ns2/conn1", nil)
+	require.NoError(t, err)
+	require.Equal(t, "100.127.0.1/30", local)
+	local, _, err = b.allocate("100.127.0.0/16", "ns1/conn1", nil)
+	require.NoError(t, err)
+	require.Equal(t, "100.127.0.9/30", local)
+}
+
+func Test_selectVpcPeeringAccepter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	requester := &kubeovnv1.VpcPeeringConnection{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "to-vpc2"},
+		Spec:       kubeovnv1.VpcPeeringConnectionSpec{Vpc: "vpc1", RemoteVpc: "vpc2"},
+	}
+	newAccepter := func(namespace, name, vpc string, created time.Time) *kubeovnv1.VpcPeeringConnection {
+		return &kubeovnv1.VpcPeeringConnection{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, CreationTimestamp: metav1.NewTime(created)},
+			Spec: kubeovnv1.VpcPeeringConnectionSpec{
+				Vpc:       vpc,
+				Requester: &kubeovnv1.VpcPeeringConnectionReference{Namespace: "ns1", Name: "to-vpc2"},
+			},
+		}
+	}
+
+	require.Nil(t, selectVpcPeeringAccepter(requester, []*kubeovnv1.VpcPeeringConnection{requester}))
+
+	// the accepter must own the requested vpc
+	wrongVpc := newAccepter("ns3", "from-vpc1", "vpc3", now)
+	require.Nil(t, selectVpcPeeringAccepter(requester, []*kubeovnv1.VpcPeeringConnection{requester, wrongVpc}))
+
+	// the oldest accepter wins
+	late := newAccepter("ns2", "late", "vpc2", now)
+	early := newAccepter("ns2", "early", "vpc2", now.Add(-time.Minute))
+	require.Equal(t, early, selectVpcPeeringAccepter(requester, []*kubeovnv1.VpcPeeringConnection{late, wrongVpc, early}))
+}
+
+func Test_vpcPeeringRoutes(t *testing.T) {
+	t.Parallel()
+
+	routes := vpcPeeringRoutes([]string{"10.2.0.0/16", "10.1.0.0/16", "10.2.0.0/16"}, "100.127.0.2")
+	require.Equal(t, []*kubeovnv1.StaticRoute{
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.1.0.0/16", NextHopIP: "100.127.0.2"},
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.2.0.0/16", NextHopIP: "100.127.0.2"},
+	}, routes)
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func Test_diffStaticRoute(t *testing.T) {
+	t.Parallel()
+
+	exist := []*ovnnb.LogicalRouterStaticRoute{
+		{IPPrefix: "10.0.0.0/24", Nexthop: "192.168.0.1"},
+		{IPPrefix: "10.0.1.0/24", Nexthop: "192.168.0.1"},
+		{IPPrefix: "172.16.0.0/16", Nexthop: "192.168.0.254", ExternalIDs: map[string]string{"vendor": util.CniTypeName, util.BgpImportNodeKey: "node1"}},
+	}
+	target := []*kubeovnv1.StaticRoute{
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.0.0.0/24", NextHopIP: "192.168.0.1"},
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.0.2.0/24", NextHopIP: "192.168.0.1"},
+	}
+
+	routeNeedDel, routeNeedAdd, err := diffStaticRoute(exist, target)
+	require.NoError(t, err)
+	// the route imported from a bgp peer is left to the speaker
+	require.Len(t, routeNeedDel, 1)
+	require.Equal(t, "10.0.1.0/24", routeNeedDel[0].CIDR)
+	require.Equal(t, []*kubeovnv1.StaticRoute{target[1]}, routeNeedAdd)
+}
+
+func Test_diffPolicyRouteWithLogical(t *testing.T) {
+	t.Parallel()
+
+	exist := []*ovnnb.LogicalRouterPolicy{
+		{Priority: 31000, Match: "ip4.dst == 10.0.0.0/24", Action: ovnnb.LogicalRouterPolicyActionAllow},
+		{Priority: 31000, Match: "ip4.dst == 10.0.1.0/24", Action: ovnnb.LogicalRouterPolicyActionAllow},
+		{
+			Priority: util.EgressGatewayPolicyPriority, Match: "ip4.src == $VEG.gw1.ipv4", Action: ovnnb.LogicalRouterPolicyActionReroute,
+			Nexthops: []string{"10.0.0.10"}, ExternalIDs: map[string]string{"vendor": util.CniTypeName, vpcEgressGatewayKey: "gw1"},
+		},
+	}
+	target := []*kubeovnv1.PolicyRoute{
+		{Priority: 31000, Match: "ip4.dst == 10.0.0.0/24", Action: kubeovnv1.PolicyRouteActionAllow},
+		{Priority: 31000, Match: "ip4.dst == 10.0.2.0/24", Action: kubeovnv1.PolicyRouteActionAllow},
+	}
+
+	// the policy route of the vpc egress gateway is left to the gateway
+	policyRouteNeedDel, policyRouteNeedAdd := diffPolicyRouteWithLogical(vpcSpecPolicyRoutes(exist), target)
+	require.Len(t, policyRouteNeedDel, 1)
+	require.Equal(t, "ip4.dst == 10.0.1.0/24", policyRouteNeedDel[0].Match)
+	require.Equal(t, []*kubeovnv1.PolicyRoute{target[1]}, policyRouteNeedAdd)
+
+	// all of the policy routes except the one of the vpc egress gateway are deleted if none is specified
+	policyRouteNeedDel, policyRouteNeedAdd = diffPolicyRouteWithLogical(vpcSpecPolicyRoutes(exist), nil)
+	require.Len(t, policyRouteNeedDel, 2)
+	require.Empty(t, policyRouteNeedAdd)
+	for _, policy := range policyRouteNeedDel {
+		require.NotEqual(t, util.EgressGatewayPolicyPriority, policy.Priority)
+	}
+	require.Len(t, exist, 3)
+}
@@ -0,0 +1,681 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// vpcTransitHubSpoke is an attachment of a transit hub whose interconnect addresses have been allocated
+type vpcTransitHubSpoke struct {
+	index          int
+	vpc            string
+	routeTable     string
+	propagations   []string
+	cidrs          []string
+	hubConnectIP   string
+	spokeConnectIP string
+	routes         []*kubeovnv1.StaticRoute
+}
+
+func (c *Controller) enqueueAddVpcTransitHub(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	klog.V(3).Infof("enqueue add vpc transit hub %s", key)
+	c.syncVpcTransitHubQueue.Add(key)
+}
+
+func (c *Controller) enqueueUpdateVpcTransitHub(oldObj, newObj interface{}) {
+	oldHub := oldObj.(*kubeovnv1.VpcTransitHub)
+	newHub := newObj.(*kubeovnv1.VpcTransitHub)
+	key, err := cache.MetaNamespaceKeyFunc(newObj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	if !newHub.DeletionTimestamp.IsZero() || !reflect.DeepEqual(oldHub.Spec, newHub.Spec) {
+		klog.V(3).Infof("enqueue update vpc transit hub %s", key)
+		c.syncVpcTransitHubQueue.Add(key)
+	}
+	if !reflect.DeepEqual(oldHub.Status.Attachments, newHub.Status.Attachments) ||
+		!reflect.DeepEqual(oldHub.Status.RouteTables, newHub.Status.RouteTables) || !newHub.DeletionTimestamp.IsZero() {
+		// the routes of the hub and the spokes are applied by the vpcs
+		c.enqueueVpcTransitHubVpcs(oldHub)
+		c.enqueueVpcTransitHubVpcs(newHub)
+	}
+	if !newHub.DeletionTimestamp.IsZero() || !reflect.DeepEqual(oldHub.Spec, newHub.Spec) {
+		// the hubs conflicting with this one may take over the vpcs released by it
+		c.enqueueVpcTransitHubsForHub(oldHub)
+	}
+}
+
+func (c *Controller) enqueueDeleteVpcTransitHub(obj interface{}) {
+	var hub *kubeovnv1.VpcTransitHub
+	switch t := obj.(type) {
+	case *kubeovnv1.VpcTransitHub:
+		hub = t
+	case cache.DeletedFinalStateUnknown:
+		obj, ok := t.Obj.(*kubeovnv1.VpcTransitHub)
+		if !ok {
+			klog.Warningf("unexpected object type: %T", t.Obj)
+			return
+		}
+		hub = obj
+	default:
+		klog.Warningf("unexpected type: %T", obj)
+		return
+	}
+
+	klog.V(3).Infof("enqueue delete vpc transit hub %s", hub.Name)
+	c.enqueueVpcTransitHubVpcs(hub)
+	c.enqueueVpcTransitHubsForHub(hub)
+}
+
+// enqueueVpcTransitHubVpcs enqueues the hub vpc and the attached spoke vpcs of the hub
+func (c *Controller) enqueueVpcTransitHubVpcs(hub *kubeovnv1.VpcTransitHub) {
+	c.addOrUpdateVpcQueue.Add(hub.Spec.Vpc)
+	for _, attachment := range hub.Status.Attachments {
+		c.addOrUpdateVpcQueue.Add(attachment.Vpc)
+	}
+}
+
+// enqueueVpcTransitHubsForHub enqueues the other hubs sharing the vpcs with the hub
+func (c *Controller) enqueueVpcTransitHubsForHub(hub *kubeovnv1.VpcTransitHub) {
+	c.enqueueVpcTransitHubsForVpc(hub.Spec.Vpc)
+	for _, attachment := range hub.Spec.Attachments {
+		c.enqueueVpcTransitHubsForVpc(attachment.Vpc)
+	}
+}
+
+// enqueueVpcTransitHubsForVpc enqueues the hubs using the vpc as the hub or a spoke
+func (c *Controller) enqueueVpcTransitHubsForVpc(vpcName string) {
+	hubs, err := c.vpcTransitHubsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc transit hubs: %v", err)
+		utilruntime.HandleError(err)
+		return
+	}
+	for _, hub := range hubs {
+		if vpcTransitHubUsesVpc(hub, vpcName) {
+			klog.V(3).Infof("enqueue update vpc transit hub %s for vpc %s", hub.Name, vpcName)
+			c.syncVpcTransitHubQueue.Add(hub.Name)
+		}
+	}
+}
+
+func vpcTransitHubUsesVpc(hub *kubeovnv1.VpcTransitHub, vpcName string) bool {
+	if hub.Spec.Vpc == vpcName {
+		return true
+	}
+	for _, attachment := range hub.Spec.Attachments {
+		if attachment.Vpc == vpcName {
+			return true
+		}
+	}
+	for _, attachment := range hub.Status.Attachments {
+		if attachment.Vpc == vpcName {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Controller) runSyncVpcTransitHubWorker() {
+	for c.processNextWorkItem("syncVpcTransitHub", c.syncVpcTransitHubQueue, c.handleSyncVpcTransitHub) {
+	}
+}
+
+func (c *Controller) handleSyncVpcTransitHub(key string) error {
+	cachedHub, err := c.vpcTransitHubsLister.Get(key)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Error(err)
+		return err
+	}
+	if !cachedHub.DeletionTimestamp.IsZero() {
+		return c.handleDelVpcTransitHub(cachedHub)
+	}
+	klog.Infof("handle sync vpc transit hub %s", key)
+
+	if err = c.handleAddVpcTransitHubFinalizer(cachedHub); err != nil {
+		return err
+	}
+
+	status := cachedHub.Status.DeepCopy()
+	if err = c.syncVpcTransitHub(cachedHub, status); err != nil {
+		return err
+	}
+	// tear down the interconnects of the attachments which are removed or no longer active,
+	// which also releases the interconnect addresses
+	for _, attachment := range cachedHub.Status.Attachments {
+		if attachment.HubConnectIP != "" && !vpcTransitHubAttached(status, attachment.Vpc) {
+			if err = c.deleteVpcTransitHubPorts(cachedHub.Spec.Vpc, attachment.Vpc); err != nil {
+				return err
+			}
+		}
+	}
+	owners := make([]string, 0, len(status.Attachments))
+	for _, attachment := range status.Attachments {
+		if attachment.HubConnectIP != "" {
+			owners = append(owners, vpcTransitHubBlockOwner(cachedHub.Name, attachment.Vpc))
+		}
+	}
+	c.vpcTransitHubBlocks.retain(vpcTransitHubBlockOwner(cachedHub.Name, ""), owners)
+	return c.patchVpcTransitHubStatus(cachedHub, status)
+}
+
+// syncVpcTransitHub sets up the interconnects and the route tables of the hub and records the result in the status,
+// only the errors which should be retried are returned
+func (c *Controller) syncVpcTransitHub(hub *kubeovnv1.VpcTransitHub, status *kubeovnv1.VpcTransitHubStatus) error {
+	status.Attachments = make([]kubeovnv1.VpcTransitHubAttachmentStatus, 0, len(hub.Spec.Attachments))
+	status.RouteTables = nil
+
+	hubVpc, msg, err := c.validateVpcTransitHub(hub)
+	if err != nil {
+		return err
+	}
+	if msg != "" {
+		status.ClearCondition(kubeovnv1.Validated, "InvalidHub", msg)
+		status.ClearCondition(kubeovnv1.Ready, "InvalidHub", msg)
+		return nil
+	}
+	status.SetCondition(kubeovnv1.Validated, "Validated", "")
+
+	used, err := c.getVpcTransitHubAddresses(hub)
+	if err != nil {
+		return err
+	}
+	spokes := make([]*vpcTransitHubSpoke, 0, len(hub.Spec.Attachments))
+	for _, attachment := range hub.Spec.Attachments {
+		routeTable := attachment.RouteTable
+		if routeTable == "" {
+			routeTable = kubeovnv1.VpcTransitHubMainRouteTable
+		}
+		status.Attachments = append(status.Attachments, kubeovnv1.VpcTransitHubAttachmentStatus{
+			Vpc:        attachment.Vpc,
+			Phase:      kubeovnv1.VpcTransitHubAttachmentFailed,
+			RouteTable: routeTable,
+		})
+		attachmentStatus := &status.Attachments[len(status.Attachments)-1]
+
+		cidrs, msg, err := c.validateVpcTransitHubAttachment(hub, hubVpc, attachment, spokes)
+		if err != nil {
+			return err
+		}
+		if msg != "" {
+			attachmentStatus.Message = msg
+			continue
+		}
+
+		spoke := &vpcTransitHubSpoke{
+			index:        len(status.Attachments) - 1,
+			vpc:          attachment.Vpc,
+			routeTable:   routeTable,
+			propagations: []string{routeTable},
+			cidrs:        cidrs,
+		}
+		if len(attachment.Propagations) != 0 {
+			spoke.propagations = slices.Clone(attachment.Propagations)
+			sort.Strings(spoke.propagations)
+			spoke.propagations = slices.Compact(spoke.propagations)
+		}
+		// the allocated interconnect addresses are kept until the attachment is torn down
+		for _, allocated := range hub.Status.Attachments {
+			if allocated.Vpc == attachment.Vpc && allocated.HubConnectIP != "" {
+				spoke.hubConnectIP, spoke.spokeConnectIP = allocated.HubConnectIP, allocated.SpokeConnectIP
+				break
+			}
+		}
+		if spoke.hubConnectIP == "" {
+			if spoke.hubConnectIP, spoke.spokeConnectIP, err = c.vpcTransitHubBlocks.allocate(c.config.VpcTransitHubCIDR, vpcTransitHubBlockOwner(hub.Name, attachment.Vpc), used); err != nil {
+				klog.Errorf("failed to allocate interconnect addresses for attachment %s of vpc transit hub %s: %v", attachment.Vpc, hub.Name, err)
+				return err
+			}
+		}
+		used = append(used, spoke.hubConnectIP)
+		spokes = append(spokes, spoke)
+	}
+
+	// the attachments failed to propagate or learn routes are excluded until the route tables are stable
+	failed := make(map[string]string)
+	for {
+		active := make([]*vpcTransitHubSpoke, 0, len(spokes))
+		for _, spoke := range spokes {
+			if _, ok := failed[spoke.vpc]; !ok {
+				active = append(active, spoke)
+			}
+		}
+		routeTables, conflicts := vpcTransitHubRouteTables(active)
+		for vpc, msg := range conflicts {
+			failed[vpc] = msg
+		}
+		for _, spoke := range active {
+			if _, ok := conflicts[spoke.vpc]; ok {
+				continue
+			}
+			spoke.routes = vpcTransitHubSpokeRoutes(spoke, routeTables)
+			routeCIDRs := make([]string, 0, len(spoke.routes))
+			for _, route := range spoke.routes {
+				routeCIDRs = append(routeCIDRs, route.CIDR)
+			}
+			if msg, err = c.checkVpcPeeringCIDRConflict(spoke.vpc, routeCIDRs); err != nil {
+				return err
+			}
+			if msg != "" {
+				failed[spoke.vpc] = msg
+				conflicts[spoke.vpc] = msg
+			}
+		}
+		if len(conflicts) == 0 {
+			status.RouteTables = routeTables
+			break
+		}
+	}
+
+	for _, spoke := range spokes {
+		attachmentStatus := &status.Attachments[spoke.index]
+		if msg, ok := failed[spoke.vpc]; ok {
+			attachmentStatus.Message = msg
+			continue
+		}
+		if err = c.createVpcTransitHubPorts(hub.Spec.Vpc, spoke); err != nil {
+			return err
+		}
+		attachmentStatus.Phase = kubeovnv1.VpcTransitHubAttachmentActive
+		attachmentStatus.HubConnectIP = spoke.hubConnectIP
+		attachmentStatus.SpokeConnectIP = spoke.spokeConnectIP
+		attachmentStatus.Routes = spoke.routes
+	}
+	var failedVpcs []string
+	for _, attachment := range status.Attachments {
+		if attachment.Phase != kubeovnv1.VpcTransitHubAttachmentActive {
+			failedVpcs = append(failedVpcs, attachment.Vpc)
+		}
+	}
+	if len(failedVpcs) != 0 {
+		status.ClearCondition(kubeovnv1.Ready, "AttachmentFailed", fmt.Sprintf("attachments of vpc %s failed", strings.Join(failedVpcs, ", ")))
+		return nil
+	}
+	status.SetCondition(kubeovnv1.Ready, "Active", "")
+	return nil
+}
+
+// vpcTransitHubBlockOwner returns the owner of the interconnect block reserved for the attachment of the hub
+func vpcTransitHubBlockOwner(hub, vpc string) string {
+	return hub + "/" + vpc
+}
+
+func vpcTransitHubAttached(status *kubeovnv1.VpcTransitHubStatus, vpcName string) bool {
+	for _, attachment := range status.Attachments {
+		if attachment.Vpc == vpcName && attachment.HubConnectIP != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// vpcTransitHubPrecedes returns whether the hub a takes precedence over the hub b on the vpcs used by both
+func vpcTransitHubPrecedes(a, b *kubeovnv1.VpcTransitHub) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
+
+// getVpcTransitHubConflict returns a message if the vpc is used by another hub which takes precedence over the hub
+func (c *Controller) getVpcTransitHubConflict(hub *kubeovnv1.VpcTransitHub, vpcName string) (string, error) {
+	hubs, err := c.vpcTransitHubsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc transit hubs: %v", err)
+		return "", err
+	}
+	for _, other := range hubs {
+		if other.UID == hub.UID || !other.DeletionTimestamp.IsZero() || !vpcTransitHubPrecedes(other, hub) {
+			continue
+		}
+		if other.Spec.Vpc == vpcName {
+			return fmt.Sprintf("vpc %s is the hub of vpc transit hub %s", vpcName, other.Name), nil
+		}
+		for _, attachment := range other.Spec.Attachments {
+			if attachment.Vpc == vpcName {
+				return fmt.Sprintf("vpc %s is attached to vpc transit hub %s", vpcName, other.Name), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// validateVpcTransitHub returns the hub vpc, or a message explaining why the hub is invalid
+func (c *Controller) validateVpcTransitHub(hub *kubeovnv1.VpcTransitHub) (*kubeovnv1.Vpc, string, error) {
+	hubVpc, err := c.vpcsLister.Get(hub.Spec.Vpc)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Error(err)
+			return nil, "", err
+		}
+		return nil, fmt.Sprintf("vpc %s not found", hub.Spec.Vpc), nil
+	}
+	if !hubVpc.DeletionTimestamp.IsZero() {
+		return nil, fmt.Sprintf("vpc %s is being deleted", hub.Spec.Vpc), nil
+	}
+	msg, err := c.getVpcTransitHubConflict(hub, hub.Spec.Vpc)
+	return hubVpc, msg, err
+}
+
+// validateVpcTransitHubAttachment returns the ipv4 cidrs propagated by the attachment,
+// or a message explaining why the vpc can not be attached
+func (c *Controller) validateVpcTransitHubAttachment(hub *kubeovnv1.VpcTransitHub, hubVpc *kubeovnv1.Vpc, attachment kubeovnv1.VpcTransitHubAttachment, spokes []*vpcTransitHubSpoke) ([]string, string, error) {
+	if attachment.Vpc == hub.Spec.Vpc {
+		return nil, fmt.Sprintf("vpc %s can not be attached to itself", attachment.Vpc), nil
+	}
+	if slices.ContainsFunc(spokes, func(spoke *vpcTransitHubSpoke) bool { return spoke.vpc == attachment.Vpc }) {
+		return nil, fmt.Sprintf("vpc %s is attached more than once", attachment.Vpc), nil
+	}
+	// the route tables of the hub share the ovn route tables with the static routes of the hub vpc
+	for _, name := range append([]string{attachment.RouteTable}, attachment.Propagations...) {
+		if routeTable := vpcTransitHubOvnRouteTable(name); routeTable != util.MainRouteTable &&
+			slices.ContainsFunc(hubVpc.Spec.StaticRoutes, func(route *kubeovnv1.StaticRoute) bool { return route.RouteTable == routeTable }) {
+			return nil, fmt.Sprintf("route table %s has been used by the static routes of vpc %s", name, hubVpc.Name), nil
+		}
+	}
+	vpc, err := c.vpcsLister.Get(attachment.Vpc)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			klog.Error(err)
+			return nil, "", err
+		}
+		return nil, fmt.Sprintf("vpc %s not found", attachment.Vpc), nil
+	}
+	if !vpc.DeletionTimestamp.IsZero() {
+		return nil, fmt.Sprintf("vpc %s is being deleted", attachment.Vpc), nil
+	}
+	if msg, err := c.getVpcTransitHubConflict(hub, attachment.Vpc); err != nil || msg != "" {
+		return nil, msg, err
+	}
+
+	// the interconnect shares the router ports with the peerings between the hub and the spoke
+	if msg, err := c.checkVpcPeeringConflict(hubVpc, attachment.Vpc); err != nil || msg != "" {
+		return nil, msg, err
+	}
+	conns, err := c.vpcPeeringConnectionsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc peering connections: %v", err)
+		return nil, "", err
+	}
+	for _, conn := range conns {
+		if (conn.Spec.Vpc == hub.Spec.Vpc && conn.Status.RemoteVpc == attachment.Vpc) ||
+			(conn.Spec.Vpc == attachment.Vpc && conn.Status.RemoteVpc == hub.Spec.Vpc) {
+			return nil, fmt.Sprintf("vpc %s has peered with vpc %s by vpc peering connection %s/%s", conn.Spec.Vpc, conn.Status.RemoteVpc, conn.Namespace, conn.Name), nil
+		}
+	}
+
+	cidrs, msg, err := c.getVpcSubnetCIDRs(attachment.Vpc, attachment.Subnets)
+	if err != nil || msg != "" {
+		return nil, msg, err
+	}
+	if msg, err = c.checkVpcPeeringCIDRConflict(hub.Spec.Vpc, cidrs); err != nil || msg != "" {
+		return nil, msg, err
+	}
+	return cidrs, "", nil
+}
+
+// vpcTransitHubRouteTables propagates the cidrs of the spokes to the route tables in order,
+// the spokes whose cidrs overlap with the cidrs propagated by the previous spokes are returned with messages.
+// As the routes of the main route table are also used by the attachments associated with the other route tables,
+// the main route table is checked together with each of the route tables.
+func vpcTransitHubRouteTables(spokes []*vpcTransitHubSpoke) ([]kubeovnv1.VpcTransitHubRouteTable, map[string]string) {
+	tables := make(map[string]*kubeovnv1.VpcTransitHubRouteTable)
+	getTable := func(name string) *kubeovnv1.VpcTransitHubRouteTable {
+		if tables[name] == nil {
+			tables[name] = &kubeovnv1.VpcTransitHubRouteTable{Name: name}
+		}
+		return tables[name]
+	}
+
+	conflicts := make(map[string]string)
+	for _, spoke := range spokes {
+		var msg string
+		checked := append([]string{kubeovnv1.VpcTransitHubMainRouteTable}, spoke.propagations...)
+		if slices.Contains(spoke.propagations, kubeovnv1.VpcTransitHubMainRouteTable) {
+			checked = checked[:0]
+			for name := range tables {
+				checked = append(checked, name)
+			}
+		}
+		sort.Strings(checked)
+	check:
+		for _, name := range slices.Compact(checked) {
+			if tables[name] == nil {
+				continue
+			}
+			for _, route := range tables[name].Routes {
+				for _, cidr := range spoke.cidrs {
+					if util.CIDROverlap(cidr, route.CIDR) {
+						msg = fmt.Sprintf("cidr %s overlaps with cidr %s propagated to route table %s", cidr, route.CIDR, name)
+						break check
+					}
+				}
+			}
+		}
+		if msg != "" {
+			conflicts[spoke.vpc] = msg
+			continue
+		}
+
+		table := getTable(spoke.routeTable)
+		table.Associations = append(table.Associations, spoke.vpc)
+		nextHop := strings.Split(spoke.spokeConnectIP, "/")[0]
+		for _, name := range spoke.propagations {
+			table = getTable(name)
+			table.Propagations = append(table.Propagations, spoke.vpc)
+			for _, route := range vpcPeeringRoutes(slices.Clone(spoke.cidrs), nextHop) {
+				route.RouteTable = vpcTransitHubOvnRouteTable(name)
+				table.Routes = append(table.Routes, route)
+			}
+		}
+	}
+
+	routeTables := make([]kubeovnv1.VpcTransitHubRouteTable, 0, len(tables))
+	for _, table := range tables {
+		sort.Strings(table.Associations)
+		sort.Strings(table.Propagations)
+		sort.Slice(table.Routes, func(i, j int) bool { return table.Routes[i].CIDR < table.Routes[j].CIDR })
+		routeTables = append(routeTables, *table)
+	}
+	sort.Slice(routeTables, func(i, j int) bool { return routeTables[i].Name < routeTables[j].Name })
+	return routeTables, conflicts
+}
+
+// vpcTransitHubSpokeRoutes returns the routes of the spoke to the cidrs of the associated route table and the main route table
+func vpcTransitHubSpokeRoutes(spoke *vpcTransitHubSpoke, routeTables []kubeovnv1.VpcTransitHubRouteTable) []*kubeovnv1.StaticRoute {
+	var cidrs []string
+	seen := make(map[string]bool)
+	spokeIP := strings.Split(spoke.spokeConnectIP, "/")[0]
+	for _, name := range slices.Compact([]string{spoke.routeTable, kubeovnv1.VpcTransitHubMainRouteTable}) {
+		for _, table := range routeTables {
+			if table.Name != name {
+				continue
+			}
+			for _, route := range table.Routes {
+				if seen[route.CIDR] {
+					continue
+				}
+				seen[route.CIDR] = true
+				// the routes back to the spoke itself are not learned
+				if route.NextHopIP != spokeIP {
+					cidrs = append(cidrs, route.CIDR)
+				}
+			}
+		}
+	}
+	return vpcPeeringRoutes(cidrs, strings.Split(spoke.hubConnectIP, "/")[0])
+}
+
+// vpcTransitHubOvnRouteTable returns the ovn route table of the route table of the hub
+func vpcTransitHubOvnRouteTable(name string) string {
+	if name == kubeovnv1.VpcTransitHubMainRouteTable {
+		return util.MainRouteTable
+	}
+	return name
+}
+
+// getVpcTransitHubAddresses returns the hub side interconnect addresses allocated by the other hubs
+func (c *Controller) getVpcTransitHubAddresses(hub *kubeovnv1.VpcTransitHub) ([]string, error) {
+	hubs, err := c.vpcTransitHubsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc transit hubs: %v", err)
+		return nil, err
+	}
+	var used []string
+	for _, other := range hubs {
+		if other.UID == hub.UID {
+			continue
+		}
+		for _, attachment := range other.Status.Attachments {
+			if attachment.HubConnectIP != "" {
+				used = append(used, attachment.HubConnectIP)
+			}
+		}
+	}
+	return used, nil
+}
+
+func (c *Controller) createVpcTransitHubPorts(hubVpc string, spoke *vpcTransitHubSpoke) error {
+	if err := c.OVNNbClient.CreatePeerRouterPort(hubVpc, spoke.vpc, spoke.hubConnectIP); err != nil {
+		klog.Errorf("failed to create router port of vpc %s to attach vpc %s: %v", hubVpc, spoke.vpc, err)
+		return err
+	}
+	if err := c.OVNNbClient.CreatePeerRouterPort(spoke.vpc, hubVpc, spoke.spokeConnectIP); err != nil {
+		klog.Errorf("failed to create router port of vpc %s to attach to vpc %s: %v", spoke.vpc, hubVpc, err)
+		return err
+	}
+	// the traffic from the spoke is routed by the associated route table of the hub
+	lrpName := vpcPeeringRouterPort(hubVpc, spoke.vpc)
+	options := map[string]string{"route_table": vpcTransitHubOvnRouteTable(spoke.routeTable)}
+	if err := c.OVNNbClient.UpdateLogicalRouterPortOptions(lrpName, options); err != nil {
+		klog.Errorf("failed to associate router port %s with route table %s: %v", lrpName, spoke.routeTable, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) deleteVpcTransitHubPorts(hubVpc, spokeVpc string) error {
+	for _, lrpName := range []string{vpcPeeringRouterPort(hubVpc, spokeVpc), vpcPeeringRouterPort(spokeVpc, hubVpc)} {
+		if err := c.OVNNbClient.DeleteLogicalRouterPort(lrpName); err != nil {
+			klog.Errorf("failed to delete router port %s between vpc %s and vpc %s: %v", lrpName, hubVpc, spokeVpc, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// getVpcTransitHubRoutes returns the routes of the route tables of the hub vpc,
+// or the routes learned by the spoke vpc from the route table associated with it
+func (c *Controller) getVpcTransitHubRoutes(vpcName string) ([]*kubeovnv1.StaticRoute, error) {
+	hubs, err := c.vpcTransitHubsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list vpc transit hubs: %v", err)
+		return nil, err
+	}
+	var routes []*kubeovnv1.StaticRoute
+	for _, hub := range hubs {
+		if !hub.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if hub.Spec.Vpc == vpcName {
+			for _, table := range hub.Status.RouteTables {
+				routes = append(routes, table.Routes...)
+			}
+		}
+		for _, attachment := range hub.Status.Attachments {
+			if attachment.Vpc == vpcName && attachment.Phase == kubeovnv1.VpcTransitHubAttachmentActive {
+				routes = append(routes, attachment.Routes...)
+			}
+		}
+	}
+	return routes, nil
+}
+
+func (c *Controller) handleDelVpcTransitHub(hub *kubeovnv1.VpcTransitHub) error {
+	klog.Infof("handle delete vpc transit hub %s", hub.Name)
+	for _, attachment := range hub.Status.Attachments {
+		if attachment.HubConnectIP != "" {
+			if err := c.deleteVpcTransitHubPorts(hub.Spec.Vpc, attachment.Vpc); err != nil {
+				return err
+			}
+		}
+	}
+	c.vpcTransitHubBlocks.retain(vpcTransitHubBlockOwner(hub.Name, ""), nil)
+	if !controllerutil.ContainsFinalizer(hub, util.ControllerName) {
+		return nil
+	}
+	newHub := hub.DeepCopy()
+	controllerutil.RemoveFinalizer(newHub, util.ControllerName)
+	return c.patchVpcTransitHubFinalizers(hub, newHub)
+}
+
+func (c *Controller) handleAddVpcTransitHubFinalizer(hub *kubeovnv1.VpcTransitHub) error {
+	if controllerutil.ContainsFinalizer(hub, util.ControllerName) {
+		return nil
+	}
+	newHub := hub.DeepCopy()
+	controllerutil.AddFinalizer(newHub, util.ControllerName)
+	return c.patchVpcTransitHubFinalizers(hub, newHub)
+}
+
+func (c *Controller) patchVpcTransitHubFinalizers(hub, newHub *kubeovnv1.VpcTransitHub) error {
+	patch, err := util.GenerateMergePatchPayload(hub, newHub)
+	if err != nil {
+		klog.Errorf("failed to generate patch payload for vpc transit hub %s: %v", hub.Name, err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().VpcTransitHubs().Patch(context.Background(), hub.Name,
+		types.MergePatchType, patch, metav1.PatchOptions{}, ""); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Errorf("failed to patch finalizers of vpc transit hub %s: %v", hub.Name, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) patchVpcTransitHubStatus(hub *kubeovnv1.VpcTransitHub, status *kubeovnv1.VpcTransitHubStatus) error {
+	if reflect.DeepEqual(&hub.Status, status) {
+		return nil
+	}
+	bytes, err := status.Bytes()
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if _, err = c.config.KubeOvnClient.KubeovnV1().VpcTransitHubs().Patch(context.Background(), hub.Name,
+		types.MergePatchType, bytes, metav1.PatchOptions{}, "status"); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		klog.Errorf("failed to patch status of vpc transit hub %s: %v", hub.Name, err)
+		return err
+	}
+	return nil
+}
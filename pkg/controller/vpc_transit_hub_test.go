@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+func Test_vpcTransitHubRouteTables(t *testing.T) {
+	t.Parallel()
+
+	spokes := []*vpcTransitHubSpoke{
+		{vpc: "prod", routeTable: "prod", propagations: []string{"prod", "shared"}, cidrs: []string{"10.1.0.0/16"}, hubConnectIP: "100.126.0.1/30", spokeConnectIP: "100.126.0.2/30"},
+		{vpc: "dev", routeTable: "dev", propagations: []string{"dev", "shared"}, cidrs: []string{"10.2.0.0/16"}, hubConnectIP: "100.126.0.5/30", spokeConnectIP: "100.126.0.6/30"},
+		{vpc: "shared", routeTable: "shared", propagations: []string{"dev", "prod"}, cidrs: []string{"10.3.0.0/16"}, hubConnectIP: "100.126.0.9/30", spokeConnectIP: "100.126.0.10/30"},
+		// overlaps with the cidr propagated to the shared route table by prod
+		{vpc: "legacy", routeTable: "legacy", propagations: []string{"shared"}, cidrs: []string{"10.1.1.0/24"}, hubConnectIP: "100.126.0.13/30", spokeConnectIP: "100.126.0.14/30"},
+	}
+	tables, conflicts := vpcTransitHubRouteTables(spokes)
+	require.Equal(t, map[string]string{"legacy": "cidr 10.1.1.0/24 overlaps with cidr 10.1.0.0/16 propagated to route table shared"}, conflicts)
+	require.Equal(t, []kubeovnv1.VpcTransitHubRouteTable{
+		{
+			Name:         "dev",
+			Associations: []string{"dev"},
+			Propagations: []string{"dev", "shared"},
+			Routes: []*kubeovnv1.StaticRoute{
+				{Policy: kubeovnv1.PolicyDst, CIDR: "10.2.0.0/16", NextHopIP: "100.126.0.6", RouteTable: "dev"},
+				{Policy: kubeovnv1.PolicyDst, CIDR: "10.3.0.0/16", NextHopIP: "100.126.0.10", RouteTable: "dev"},
+			},
+		},
+		{
+			Name:         "prod",
+			Associations: []string{"prod"},
+			Propagations: []string{"prod", "shared"},
+			Routes: []*kubeovnv1.StaticRoute{
+				{Policy: kubeovnv1.PolicyDst, CIDR: "10.1.0.0/16", NextHopIP: "100.126.0.2", RouteTable: "prod"},
+				{Policy: kubeovnv1.PolicyDst, CIDR: "10.3.0.0/16", NextHopIP: "100.126.0.10", RouteTable: "prod"},
+			},
+		},
+		{
+			Name:         "shared",
+			Associations: []string{"shared"},
+			Propagations: []string{"dev", "prod"},
+			Routes: []*kubeovnv1.StaticRoute{
+				{Policy: kubeovnv1.PolicyDst, CIDR: "10.1.0.0/16", NextHopIP: "100.126.0.2", RouteTable: "shared"},
+				{Policy: kubeovnv1.PolicyDst, CIDR: "10.2.0.0/16", NextHopIP: "100.126.0.6", RouteTable: "shared"},
+			},
+		},
+	}, tables)
+
+	// prod and dev are isolated from each other and both reach shared
+	require.Equal(t, []*kubeovnv1.StaticRoute{
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.3.0.0/16", NextHopIP: "100.126.0.1"},
+	}, vpcTransitHubSpokeRoutes(spokes[0], tables))
+	require.Equal(t, []*kubeovnv1.StaticRoute{
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.1.0.0/16", NextHopIP: "100.126.0.9"},
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.2.0.0/16", NextHopIP: "100.126.0.9"},
+	}, vpcTransitHubSpokeRoutes(spokes[2], tables))
+}
+
+func Test_vpcTransitHubSpokeRoutes(t *testing.T) {
+	t.Parallel()
+
+	spokes := []*vpcTransitHubSpoke{
+		{vpc: "vpc1", routeTable: kubeovnv1.VpcTransitHubMainRouteTable, propagations: []string{kubeovnv1.VpcTransitHubMainRouteTable}, cidrs: []string{"10.1.0.0/16"}, hubConnectIP: "100.126.0.1/30", spokeConnectIP: "100.126.0.2/30"},
+		{vpc: "vpc2", routeTable: kubeovnv1.VpcTransitHubMainRouteTable, propagations: []string{kubeovnv1.VpcTransitHubMainRouteTable}, cidrs: []string{"10.2.0.0/16"}, hubConnectIP: "100.126.0.5/30", spokeConnectIP: "100.126.0.6/30"},
+		// the main route table is also used by the attachments associated with the isolated route table
+		{vpc: "vpc3", routeTable: "isolated", propagations: []string{"isolated"}, cidrs: []string{"10.2.0.0/16"}, hubConnectIP: "100.126.0.9/30", spokeConnectIP: "100.126.0.10/30"},
+		{vpc: "vpc4", routeTable: "isolated", propagations: []string{"isolated"}, cidrs: []string{"10.4.0.0/16"}, hubConnectIP: "100.126.0.13/30", spokeConnectIP: "100.126.0.14/30"},
+		// overlaps with the cidr propagated to the isolated route table by vpc4
+		{vpc: "vpc5", routeTable: kubeovnv1.VpcTransitHubMainRouteTable, propagations: []string{kubeovnv1.VpcTransitHubMainRouteTable}, cidrs: []string{"10.4.1.0/24"}, hubConnectIP: "100.126.0.17/30", spokeConnectIP: "100.126.0.18/30"},
+	}
+	tables, conflicts := vpcTransitHubRouteTables(spokes)
+	require.Equal(t, map[string]string{
+		"vpc3": "cidr 10.2.0.0/16 overlaps with cidr 10.2.0.0/16 propagated to route table main",
+		"vpc5": "cidr 10.4.1.0/24 overlaps with cidr 10.4.0.0/16 propagated to route table isolated",
+	}, conflicts)
+	require.Len(t, tables, 2)
+	require.Equal(t, "main", tables[1].Name)
+	require.Equal(t, []*kubeovnv1.StaticRoute{
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.1.0.0/16", NextHopIP: "100.126.0.2"},
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.2.0.0/16", NextHopIP: "100.126.0.6"},
+	}, tables[1].Routes)
+
+	// the main route table is shared by all the attachments
+	require.Equal(t, []*kubeovnv1.StaticRoute{
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.2.0.0/16", NextHopIP: "100.126.0.1"},
+	}, vpcTransitHubSpokeRoutes(spokes[0], tables))
+	require.Equal(t, []*kubeovnv1.StaticRoute{
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.1.0.0/16", NextHopIP: "100.126.0.13"},
+		{Policy: kubeovnv1.PolicyDst, CIDR: "10.2.0.0/16", NextHopIP: "100.126.0.13"},
+	}, vpcTransitHubSpokeRoutes(spokes[3], tables))
+}
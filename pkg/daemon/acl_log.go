@@ -0,0 +1,290 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// ovnControllerLogFile is the log file of ovn-controller which contains the acl hits
+	ovnControllerLogFile = "/var/log/ovn/ovn-controller.log"
+	// the log files are checked for rotation at most once per aclLogCheckInterval
+	aclLogCheckInterval = time.Second
+)
+
+// aclLogRecord is the structured form of an acl log message written by ovn-controller, e.g.
+// name="default/allow-web/ingress/0", verdict=allow, severity=info, direction=to-lport: tcp,...,nw_src=10.16.0.2,nw_dst=10.16.0.3,...,tp_src=43210,tp_dst=80
+type aclLogRecord struct {
+	Time      string `json:"time"`
+	Node      string `json:"node"`
+	ACL       string `json:"acl,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Policy    string `json:"policy,omitempty"`
+	Direction string `json:"direction"`
+	Rule      string `json:"rule,omitempty"`
+	Verdict   string `json:"verdict"`
+	Severity  string `json:"severity"`
+	Protocol  string `json:"protocol"`
+	Src       string `json:"src,omitempty"`
+	Dst       string `json:"dst,omitempty"`
+	SrcPort   string `json:"srcPort,omitempty"`
+	DstPort   string `json:"dstPort,omitempty"`
+}
+
+// parseACLLog parses an ovn-controller log line, false is returned if it's not an acl log
+func parseACLLog(line string) (*aclLogRecord, bool) {
+	fields := strings.SplitN(line, "|", 5)
+	if len(fields) != 5 || !strings.HasPrefix(fields[2], "acl_log") {
+		return nil, false
+	}
+	header, flow, found := strings.Cut(fields[4], ": ")
+	if !found {
+		return nil, false
+	}
+
+	record := &aclLogRecord{Time: fields[0]}
+	for _, kv := range strings.Split(header, ", ") {
+		k, v, _ := strings.Cut(kv, "=")
+		switch k {
+		case "name":
+			record.ACL = strings.Trim(v, `"`)
+		case "verdict":
+			record.Verdict = v
+		case "severity":
+			record.Severity = v
+		case "direction":
+			if v == "from-lport" {
+				record.Direction = "egress"
+			} else {
+				record.Direction = "ingress"
+			}
+		}
+	}
+
+	// acls of network policies are named as "<namespace>/<name>/<direction>/<rule>",
+	// and acls of security groups are named as "<name>/<direction>/<rule>"
+	switch parts := strings.Split(record.ACL, "/"); len(parts) {
+	case 4:
+		record.Namespace, record.Policy, record.Direction, record.Rule = parts[0], parts[1], parts[2], parts[3]
+	case 3:
+		record.Policy, record.Direction, record.Rule = parts[0], parts[1], parts[2]
+	}
+	// the packets hitting acls in audit mode would have been dropped if the policy is enforced
+	if record.Rule == "audit" {
+		record.Verdict = "audit"
+	}
+
+	for i, kv := range strings.Split(strings.TrimSpace(flow), ",") {
+		if i == 0 {
+			record.Protocol = kv
+			continue
+		}
+		k, v, _ := strings.Cut(kv, "=")
+		switch k {
+		case "nw_src", "ipv6_src":
+			record.Src = v
+		case "nw_dst", "ipv6_dst":
+			record.Dst = v
+		case "tp_src":
+			record.SrcPort = v
+		case "tp_dst":
+			record.DstPort = v
+		}
+	}
+	return record, true
+}
+
+// tailFile calls handle with the lines appended to the file, it follows the file when it's rotated or truncated
+func tailFile(path string, interval time.Duration, stopCh <-chan struct{}, handle func(line string)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	// only the new messages are handled
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(f)
+	var partial string
+	for {
+		line, err := reader.ReadString('\n')
+		offset += int64(len(line))
+		if err == nil {
+			handle(partial + strings.TrimSuffix(line, "\n"))
+			partial = ""
+			continue
+		}
+		if !errors.Is(err, io.EOF) {
+			return err
+		}
+		partial += line
+
+		select {
+		case <-stopCh:
+			return nil
+		case <-time.After(interval):
+		}
+
+		current, err := f.Stat()
+		if err != nil {
+			return err
+		}
+		latest, err := os.Stat(path)
+		if err != nil {
+			// the file may be being rotated
+			continue
+		}
+		switch {
+		case !os.SameFile(current, latest):
+			klog.Infof("file %s is rotated, reopen it", path)
+			_ = f.Close()
+			if f, err = os.Open(path); err != nil {
+				return err
+			}
+			reader.Reset(f)
+			offset, partial = 0, ""
+		case latest.Size() < offset:
+			klog.Infof("file %s is truncated, read it from the beginning", path)
+			if _, err = f.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			reader.Reset(f)
+			offset, partial = 0, ""
+		}
+	}
+}
+
+// aclAuditLogWriter appends records to the audit log file. The file is reopened once it's
+// rotated by others, and it's rotated to <path>.1 once it exceeds maxSize.
+type aclAuditLogWriter struct {
+	path      string
+	maxSize   int64
+	file      *os.File
+	size      int64
+	lastCheck time.Time
+}
+
+func newACLAuditLogWriter(path string, maxSize int64) (*aclAuditLogWriter, error) {
+	w := &aclAuditLogWriter{path: path, maxSize: maxSize}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *aclAuditLogWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o640)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	w.file, w.size, w.lastCheck = f, info.Size(), time.Now()
+	return nil
+}
+
+// check reopens the file if it has been removed or renamed, and refreshes the size in case it has been truncated
+func (w *aclAuditLogWriter) check() error {
+	w.lastCheck = time.Now()
+	current, err := w.file.Stat()
+	if err != nil {
+		return err
+	}
+	latest, err := os.Stat(w.path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err != nil || !os.SameFile(current, latest) {
+		klog.Infof("acl audit log file %s is rotated, reopen it", w.path)
+		_ = w.file.Close()
+		w.file = nil
+		return w.open()
+	}
+	w.size = current.Size()
+	return nil
+}
+
+func (w *aclAuditLogWriter) rotate() error {
+	klog.Infof("acl audit log file %s exceeds %d bytes, rotate it", w.path, w.maxSize)
+	_ = w.file.Close()
+	w.file = nil
+	if err := os.Rename(w.path, w.path+".1"); err != nil {
+		klog.Errorf("failed to rotate acl audit log file %s: %v", w.path, err)
+	}
+	return w.open()
+}
+
+func (w *aclAuditLogWriter) Write(p []byte) (int, error) {
+	if w.file == nil {
+		// the file failed to be reopened
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	} else if time.Since(w.lastCheck) >= aclLogCheckInterval {
+		if err := w.check(); err != nil {
+			return 0, err
+		}
+	}
+	if w.maxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *aclAuditLogWriter) Close() error {
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Close()
+}
+
+// runACLLogCollector converts the acl log messages of ovn-controller into json records and prometheus metrics
+func (c *Controller) runACLLogCollector(stopCh <-chan struct{}) {
+	output, err := newACLAuditLogWriter(c.config.ACLAuditLogFile, int64(c.config.ACLAuditLogMaxSize)<<20)
+	if err != nil {
+		klog.Errorf("failed to open acl audit log file %s: %v", c.config.ACLAuditLogFile, err)
+		return
+	}
+	defer func() { _ = output.Close() }()
+
+	encoder := json.NewEncoder(output)
+	handle := func(line string) {
+		record, ok := parseACLLog(line)
+		if !ok {
+			return
+		}
+		record.Node = c.config.NodeName
+		metricACLLogHits.WithLabelValues(c.config.NodeName, record.Namespace, record.Policy, record.Direction, record.Rule, record.Verdict).Inc()
+		if err := encoder.Encode(record); err != nil {
+			klog.Errorf("failed to write acl audit log: %v", err)
+		}
+	}
+
+	for {
+		if err = tailFile(ovnControllerLogFile, aclLogCheckInterval, stopCh, handle); err != nil {
+			klog.Errorf("failed to read acl log from %s: %v", ovnControllerLogFile, err)
+		}
+		select {
+		case <-stopCh:
+			return
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
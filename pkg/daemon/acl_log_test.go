@@ -0,0 +1,217 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseACLLog(t *testing.T) {
+	t.Parallel()
+
+	const (
+		timestamp = "2024-01-02T03:04:05.678Z"
+		flow      = "tcp,vlan_tci=0x0000,dl_src=00:00:00:00:00:01,dl_dst=00:00:00:00:00:02,nw_src=10.16.0.2,nw_dst=10.16.0.3,nw_tos=0,nw_ecn=0,nw_ttl=64,tp_src=43210,tp_dst=80,tcp_flags=syn"
+	)
+	newLine := func(header string) string {
+		return timestamp + "|00042|acl_log(ovn_pinctrl0)|INFO|" + header + ": " + flow
+	}
+
+	tests := []struct {
+		name string
+		line string
+		want *aclLogRecord
+	}{
+		{
+			name: "network policy",
+			line: newLine(`name="default/allow-web/ingress/0", verdict=allow, severity=info, direction=to-lport`),
+			want: &aclLogRecord{
+				Time: timestamp, ACL: "default/allow-web/ingress/0", Namespace: "default", Policy: "allow-web",
+				Direction: "ingress", Rule: "0", Verdict: "allow", Severity: "info",
+				Protocol: "tcp", Src: "10.16.0.2", Dst: "10.16.0.3", SrcPort: "43210", DstPort: "80",
+			},
+		},
+		{
+			name: "security group",
+			line: newLine(`name="sg1/egress/1", verdict=drop, severity=warning, direction=from-lport`),
+			want: &aclLogRecord{
+				Time: timestamp, ACL: "sg1/egress/1", Policy: "sg1", Direction: "egress", Rule: "1",
+				Verdict: "drop", Severity: "warning",
+				Protocol: "tcp", Src: "10.16.0.2", Dst: "10.16.0.3", SrcPort: "43210", DstPort: "80",
+			},
+		},
+		{
+			name: "audit mode",
+			line: newLine(`name="default/deny-all/ingress/audit", verdict=allow, severity=info, direction=to-lport`),
+			want: &aclLogRecord{
+				Time: timestamp, ACL: "default/deny-all/ingress/audit", Namespace: "default", Policy: "deny-all",
+				Direction: "ingress", Rule: "audit", Verdict: "audit", Severity: "info",
+				Protocol: "tcp", Src: "10.16.0.2", Dst: "10.16.0.3", SrcPort: "43210", DstPort: "80",
+			},
+		},
+		{
+			name: "unnamed acl",
+			line: newLine(`name="<unnamed>", verdict=drop, severity=alert, direction=from-lport`),
+			want: &aclLogRecord{
+				Time: timestamp, ACL: "<unnamed>", Direction: "egress", Verdict: "drop", Severity: "alert",
+				Protocol: "tcp", Src: "10.16.0.2", Dst: "10.16.0.3", SrcPort: "43210", DstPort: "80",
+			},
+		},
+		{
+			name: "ipv6",
+			line: timestamp + `|00043|acl_log(ovn_pinctrl0)|INFO|name="default/allow-dns/egress/0", verdict=allow, severity=info, direction=from-lport: udp6,ipv6_src=fd00::2,ipv6_dst=fd00::a,tp_src=40000,tp_dst=53`,
+			want: &aclLogRecord{
+				Time: timestamp, ACL: "default/allow-dns/egress/0", Namespace: "default", Policy: "allow-dns",
+				Direction: "egress", Rule: "0", Verdict: "allow", Severity: "info",
+				Protocol: "udp6", Src: "fd00::2", Dst: "fd00::a", SrcPort: "40000", DstPort: "53",
+			},
+		},
+		{
+			name: "other module",
+			line: timestamp + "|00044|binding|INFO|Claiming lport pod1.default for this chassis.",
+		},
+		{
+			name: "no flow",
+			line: timestamp + `|00045|acl_log(ovn_pinctrl0)|INFO|name="sg1/egress/1", verdict=drop`,
+		},
+		{
+			name: "not a log line",
+			line: "ovn-controller started",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			record, ok := parseACLLog(tt.line)
+			require.Equal(t, tt.want != nil, ok)
+			require.Equal(t, tt.want, record)
+		})
+	}
+}
+
+func appendFile(t *testing.T, path, content string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func Test_tailFile(t *testing.T) {
+	t.Parallel()
+
+	const interval = 10 * time.Millisecond
+	path := filepath.Join(t.TempDir(), "ovn-controller.log")
+	appendFile(t, path, "old\n")
+
+	lines := make(chan string, 10)
+	stopCh := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- tailFile(path, interval, stopCh, func(line string) { lines <- line })
+	}()
+	expectLine := func(line string) {
+		t.Helper()
+		select {
+		case l := <-lines:
+			require.Equal(t, line, l)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("line %q is not read", line)
+		}
+	}
+	// wait for the file to be opened
+	time.Sleep(10 * interval)
+
+	tests := []struct {
+		name   string
+		modify func()
+		want   []string
+	}{
+		{
+			name:   "appended lines",
+			modify: func() { appendFile(t, path, "line1\nline2\n") },
+			want:   []string{"line1", "line2"},
+		},
+		{
+			name: "partial line",
+			modify: func() {
+				appendFile(t, path, "li")
+				time.Sleep(5 * interval)
+				appendFile(t, path, "ne3\n")
+			},
+			want: []string{"line3"},
+		},
+		{
+			name: "rotated",
+			modify: func() {
+				require.NoError(t, os.Rename(path, path+".1"))
+				appendFile(t, path, "line4\n")
+			},
+			want: []string{"line4"},
+		},
+		{
+			name:   "truncated",
+			modify: func() { require.NoError(t, os.WriteFile(path, []byte("5\n"), 0o600)) },
+			want:   []string{"5"},
+		},
+	}
+	// the cases depend on each other, so they are not run in parallel
+	for _, tt := range tests {
+		tt.modify()
+		for _, line := range tt.want {
+			expectLine(line)
+		}
+	}
+
+	close(stopCh)
+	require.NoError(t, <-errCh)
+	require.Empty(t, lines)
+}
+
+func Test_aclAuditLogWriter(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "acl-audit.log")
+	w, err := newACLAuditLogWriter(path, 10)
+	require.NoError(t, err)
+	defer func() { _ = w.Close() }()
+
+	readFile := func(path string) string {
+		t.Helper()
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	_, err = w.Write([]byte("record1\n"))
+	require.NoError(t, err)
+	require.Equal(t, "record1\n", readFile(path))
+
+	// the file is rotated once it exceeds the max size
+	_, err = w.Write([]byte("record2\n"))
+	require.NoError(t, err)
+	require.Equal(t, "record1\n", readFile(path+".1"))
+	require.Equal(t, "record2\n", readFile(path))
+
+	// the file is reopened once it's rotated by others
+	require.NoError(t, os.Rename(path, path+".2"))
+	w.lastCheck = time.Time{}
+	_, err = w.Write([]byte("r3\n"))
+	require.NoError(t, err)
+	require.Equal(t, "r3\n", readFile(path))
+	require.Equal(t, "record2\n", readFile(path+".2"))
+
+	// the size is refreshed once the file is truncated by others
+	require.NoError(t, os.Truncate(path, 0))
+	w.lastCheck = time.Time{}
+	_, err = w.Write([]byte("r4\n"))
+	require.NoError(t, err)
+	require.Equal(t, "r4\n", readFile(path))
+	require.True(t, strings.HasPrefix(readFile(path+".1"), "record1"))
+}
@@ -64,6 +64,12 @@ type Configuration struct {
 	TCPConnCheckPort          int
 	UDPConnCheckPort          int
 	EnableTProxy              bool
+	EnableFqdnSnooping        bool
+	FqdnDNSService            string
+	FqdnDNSServers            string
+	EnableACLAuditLog         bool
+	ACLAuditLogFile           string
+	ACLAuditLogMaxSize        int
 	OVSVsctlConcurrency       int32
 }
 
@@ -103,6 +109,12 @@ func ParseFlags() *Configuration {
 		argTCPConnectivityCheckPort  = pflag.Int("tcp-conn-check-port", 8100, "TCP connectivity Check Port")
 		argUDPConnectivityCheckPort  = pflag.Int("udp-conn-check-port", 8101, "UDP connectivity Check Port")
 		argEnableTProxy              = pflag.Bool("enable-tproxy", false, "enable tproxy for vpc pod liveness or readiness probe")
+		argEnableFqdnSnooping        = pflag.Bool("enable-fqdn-snooping", false, "Snoop dns responses on the node to learn the addresses of fqdns referenced by security groups and network policies")
+		argFqdnDNSService            = pflag.String("fqdn-dns-service", "kube-system/kube-dns", "The namespace/name of the cluster dns service, only the responses sent by its addresses are snooped")
+		argFqdnDNSServers            = pflag.String("fqdn-dns-servers", "", "Comma separated addresses of additional dns servers whose responses are snooped, e.g. the node local dns cache")
+		argEnableACLAuditLog         = pflag.Bool("enable-acl-audit-log", false, "Convert the acl logs of ovn-controller into structured audit records attributed to network policies")
+		argACLAuditLogFile           = pflag.String("acl-audit-log-file", "/var/log/kube-ovn/acl-audit.log", "Path of the acl audit log file")
+		argACLAuditLogMaxSize        = pflag.Int("acl-audit-log-max-size", 100, "Maximum size in megabytes of the acl audit log file before it's rotated, one rotated file is kept")
 		argOVSVsctlConcurrency       = pflag.Int32("ovs-vsctl-concurrency", 100, "concurrency limit of ovs-vsctl")
 	)
 
@@ -159,6 +171,12 @@ func ParseFlags() *Configuration {
 		TCPConnCheckPort:          *argTCPConnectivityCheckPort,
 		UDPConnCheckPort:          *argUDPConnectivityCheckPort,
 		EnableTProxy:              *argEnableTProxy,
+		EnableFqdnSnooping:        *argEnableFqdnSnooping,
+		FqdnDNSService:            *argFqdnDNSService,
+		FqdnDNSServers:            *argFqdnDNSServers,
+		EnableACLAuditLog:         *argEnableACLAuditLog,
+		ACLAuditLogFile:           *argACLAuditLogFile,
+		ACLAuditLogMaxSize:        *argACLAuditLogMaxSize,
 		OVSVsctlConcurrency:       *argOVSVsctlConcurrency,
 	}
 	return config
@@ -4,13 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"os/exec"
 	"strconv"
+	"strings"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -18,6 +21,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	listerv1 "k8s.io/client-go/listers/core/v1"
+	netlisterv1 "k8s.io/client-go/listers/networking/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -54,6 +58,18 @@ type Controller struct {
 	nodesLister listerv1.NodeLister
 	nodesSynced cache.InformerSynced
 
+	sgsLister kubeovnlister.SecurityGroupLister
+	sgsSynced cache.InformerSynced
+	npsLister netlisterv1.NetworkPolicyLister
+	npsSynced cache.InformerSynced
+	fqdnCache *fqdnCache
+	// the cluster dns service and its endpoints are watched by the fqdn snooper
+	dnsServicesLister  listerv1.ServiceLister
+	dnsServicesSynced  cache.InformerSynced
+	dnsEndpointsLister listerv1.EndpointsLister
+	dnsEndpointsSynced cache.InformerSynced
+	fqdnDNSServers     []string
+
 	recorder record.EventRecorder
 
 	protocol string
@@ -114,13 +130,55 @@ func NewController(config *Configuration, stopCh <-chan struct{}, podInformerFac
 		return nil, err
 	}
 
+	cacheSyncs := []cache.InformerSynced{
+		controller.providerNetworksSynced, controller.subnetsSynced,
+		controller.podsSynced, controller.nodesSynced,
+	}
+	if config.EnableFqdnSnooping {
+		sgInformer := kubeovnInformerFactory.Kubeovn().V1().SecurityGroups()
+		npInformer := nodeInformerFactory.Networking().V1().NetworkPolicies()
+		controller.sgsLister = sgInformer.Lister()
+		controller.sgsSynced = sgInformer.Informer().HasSynced
+		controller.npsLister = npInformer.Lister()
+		controller.npsSynced = npInformer.Informer().HasSynced
+		controller.fqdnCache = newFqdnCache()
+		cacheSyncs = append(cacheSyncs, controller.sgsSynced, controller.npsSynced)
+
+		namespace, name, err := cache.SplitMetaNamespaceKey(config.FqdnDNSService)
+		if err != nil || namespace == "" || name == "" {
+			return nil, fmt.Errorf("invalid dns service %q, it must be in the format of namespace/name", config.FqdnDNSService)
+		}
+		for _, server := range strings.Split(config.FqdnDNSServers, ",") {
+			if server = strings.TrimSpace(server); server == "" {
+				continue
+			}
+			ip := net.ParseIP(server)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid dns server address %q", server)
+			}
+			controller.fqdnDNSServers = append(controller.fqdnDNSServers, ip.String())
+		}
+		dnsInformerFactory := informers.NewSharedInformerFactoryWithOptions(config.KubeClient, 0,
+			informers.WithNamespace(namespace),
+			informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+				options.FieldSelector = fields.OneTermEqualSelector(metav1.ObjectNameField, name).String()
+			}),
+		)
+		dnsServiceInformer := dnsInformerFactory.Core().V1().Services()
+		dnsEndpointsInformer := dnsInformerFactory.Core().V1().Endpoints()
+		controller.dnsServicesLister = dnsServiceInformer.Lister()
+		controller.dnsServicesSynced = dnsServiceInformer.Informer().HasSynced
+		controller.dnsEndpointsLister = dnsEndpointsInformer.Lister()
+		controller.dnsEndpointsSynced = dnsEndpointsInformer.Informer().HasSynced
+		cacheSyncs = append(cacheSyncs, controller.dnsServicesSynced, controller.dnsEndpointsSynced)
+		dnsInformerFactory.Start(stopCh)
+	}
+
 	podInformerFactory.Start(stopCh)
 	nodeInformerFactory.Start(stopCh)
 	kubeovnInformerFactory.Start(stopCh)
 
-	if !cache.WaitForCacheSync(stopCh,
-		controller.providerNetworksSynced, controller.subnetsSynced,
-		controller.podsSynced, controller.nodesSynced) {
+	if !cache.WaitForCacheSync(stopCh, cacheSyncs...) {
 		util.LogFatalAndExit(nil, "failed to wait for caches to sync")
 	}
 
@@ -628,6 +686,15 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 		c.cleanTProxyConfig()
 	}
 
+	if c.config.EnableFqdnSnooping {
+		go c.runFqdnSnooper(stopCh)
+		go c.runFqdnCacheWorker(stopCh)
+	}
+
+	if c.config.EnableACLAuditLog {
+		go c.runACLLogCollector(stopCh)
+	}
+
 	<-stopCh
 	klog.Info("Shutting down workers")
 }
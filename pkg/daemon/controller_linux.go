@@ -200,7 +200,7 @@ func (c *Controller) reconcileRouters(event *subnetEvent) error {
 			continue
 		}
 
-		for _, cidrBlock := range strings.Split(subnet.Spec.CIDRBlock, ",") {
+		for _, cidrBlock := range strings.Split(util.GetSubnetCIDRBlocks(subnet), ",") {
 			if _, ipNet, err := net.ParseCIDR(cidrBlock); err != nil {
 				klog.Errorf("%s is not a valid cidr block", cidrBlock)
 			} else {
@@ -0,0 +1,420 @@
+package daemon
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+const (
+	// dns answers with a smaller ttl are kept for fqdnMinTTL to avoid flapping acls
+	fqdnMinTTL = 30 * time.Second
+	// an entry about to expire in the fqdn cache is refreshed if it has been seen again
+	fqdnRefreshWindow = 10 * time.Second
+	// responses are accepted only if the query has been seen within dnsQueryTimeout
+	dnsQueryTimeout = 10 * time.Second
+	// queries exceeding the limit are ignored until the pending ones are answered or expired
+	maxPendingDNSQueries = 65536
+)
+
+// dnsPacket is a dns message carried by an udp packet
+type dnsPacket struct {
+	src, dst         net.IP
+	srcPort, dstPort uint16
+	payload          []byte
+}
+
+// dnsQueryKey identifies a query by its client, server, transaction id and question name
+type dnsQueryKey struct {
+	client     string
+	clientPort uint16
+	server     string
+	id         uint16
+	name       string
+}
+
+func newDNSQueryKey(client net.IP, clientPort uint16, server net.IP, id uint16, q dnsmessage.Question) dnsQueryKey {
+	return dnsQueryKey{
+		client:     client.String(),
+		clientPort: clientPort,
+		server:     server.String(),
+		id:         id,
+		name:       strings.ToLower(q.Name.String()),
+	}
+}
+
+type fqdnEntryKey struct {
+	name string
+	ip   string
+}
+
+// fqdnCache holds the dns answers snooped on the node which match the fqdns referenced by
+// security groups and network policies, and publishes them to the FqdnCache of the node
+type fqdnCache struct {
+	mutex    sync.Mutex
+	fqdns    []string
+	entries  map[fqdnEntryKey]time.Time
+	written  map[fqdnEntryKey]time.Time
+	notifyCh chan struct{}
+	// only the responses to the queries sent to the cluster dns servers are trusted
+	servers map[string]bool
+	queries map[dnsQueryKey]time.Time
+}
+
+func newFqdnCache() *fqdnCache {
+	return &fqdnCache{
+		entries:  make(map[fqdnEntryKey]time.Time),
+		notifyCh: make(chan struct{}, 1),
+		servers:  make(map[string]bool),
+		queries:  make(map[dnsQueryKey]time.Time),
+	}
+}
+
+func (f *fqdnCache) setServers(servers []string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	clear(f.servers)
+	for _, server := range servers {
+		f.servers[server] = true
+	}
+}
+
+// expireQueries must be called with the mutex held
+func (f *fqdnCache) expireQueries(now time.Time) {
+	for key, t := range f.queries {
+		if !t.After(now) {
+			delete(f.queries, key)
+		}
+	}
+}
+
+// recordQuery records the query sent to a cluster dns server
+func (f *fqdnCache) recordQuery(packet *dnsPacket, id uint16, questions []dnsmessage.Question) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if !f.servers[packet.dst.String()] {
+		return
+	}
+
+	now := time.Now()
+	if len(f.queries)+len(questions) > maxPendingDNSQueries {
+		if f.expireQueries(now); len(f.queries)+len(questions) > maxPendingDNSQueries {
+			klog.V(3).Infof("too many pending dns queries, ignore the query from %s", packet.src)
+			return
+		}
+	}
+	for _, q := range questions {
+		f.queries[newDNSQueryKey(packet.src, packet.srcPort, packet.dst, id, q)] = now.Add(dnsQueryTimeout)
+	}
+}
+
+// matchQuery checks whether the response is sent by a cluster dns server to a pending query,
+// the query is removed once answered
+func (f *fqdnCache) matchQuery(packet *dnsPacket, id uint16, questions []dnsmessage.Question) bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if packet.srcPort != 53 || !f.servers[packet.src.String()] || len(questions) == 0 {
+		return false
+	}
+
+	now := time.Now()
+	keys := make([]dnsQueryKey, 0, len(questions))
+	for _, q := range questions {
+		key := newDNSQueryKey(packet.dst, packet.dstPort, packet.src, id, q)
+		if t, ok := f.queries[key]; !ok || !t.After(now) {
+			return false
+		}
+		keys = append(keys, key)
+	}
+	for _, key := range keys {
+		delete(f.queries, key)
+	}
+	return true
+}
+
+func (f *fqdnCache) setFqdns(fqdns []string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.fqdns = fqdns
+}
+
+func (f *fqdnCache) match(name string) bool {
+	for _, fqdn := range f.fqdns {
+		if util.MatchFqdn(fqdn, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// add records the address of the domain names if any of them is referenced
+func (f *fqdnCache) add(names []string, ip net.IP, ttl time.Duration) {
+	if ttl < fqdnMinTTL {
+		ttl = fqdnMinTTL
+	}
+	expireTime := time.Now().Add(ttl)
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, name := range names {
+		if name = util.NormalizeFqdn(name); !f.match(name) {
+			continue
+		}
+		key := fqdnEntryKey{name: name, ip: ip.String()}
+		t, ok := f.entries[key]
+		if ok && !t.Before(expireTime) {
+			continue
+		}
+		f.entries[key] = expireTime
+		if !ok {
+			klog.V(3).Infof("learned address %s of %s", key.ip, key.name)
+			// new addresses must be published as soon as possible
+			select {
+			case f.notifyCh <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// snapshot drops the expired entries and returns the entries to be published,
+// nil is returned if the published entries are still valid
+func (f *fqdnCache) snapshot() []kubeovnv1.FqdnCacheEntry {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	now := time.Now()
+	for key, t := range f.entries {
+		if !t.After(now) {
+			delete(f.entries, key)
+		}
+	}
+	f.expireQueries(now)
+
+	needUpdate := f.written == nil || len(f.written) != len(f.entries)
+	for key, t := range f.entries {
+		if needUpdate {
+			break
+		}
+		w, ok := f.written[key]
+		needUpdate = !ok || (t.After(w) && w.Sub(now) < fqdnRefreshWindow)
+	}
+	if !needUpdate {
+		return nil
+	}
+
+	f.written = make(map[fqdnEntryKey]time.Time, len(f.entries))
+	entries := make([]kubeovnv1.FqdnCacheEntry, 0, len(f.entries))
+	for key, t := range f.entries {
+		f.written[key] = t
+		entries = append(entries, kubeovnv1.FqdnCacheEntry{Name: key.name, IP: key.ip, ExpireTime: metav1.NewTime(t)})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Name != entries[j].Name {
+			return entries[i].Name < entries[j].Name
+		}
+		return entries[i].IP < entries[j].IP
+	})
+	return entries
+}
+
+// reset makes the next snapshot publish all the entries, it's called when publishing fails
+func (f *fqdnCache) reset() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.written = nil
+}
+
+// handleDNSPacket records the queries sent to the cluster dns servers, and the addresses
+// in the answers of the responses matching the recorded queries
+func (f *fqdnCache) handleDNSPacket(packet *dnsPacket) {
+	var p dnsmessage.Parser
+	header, err := p.Start(packet.payload)
+	if err != nil {
+		return
+	}
+	questions, err := p.AllQuestions()
+	if err != nil {
+		return
+	}
+	if !header.Response {
+		if packet.dstPort == 53 {
+			f.recordQuery(packet, header.ID, questions)
+		}
+		return
+	}
+	if !f.matchQuery(packet, header.ID, questions) || header.RCode != dnsmessage.RCodeSuccess {
+		return
+	}
+
+	for {
+		h, err := p.AnswerHeader()
+		if err != nil {
+			// dnsmessage.ErrSectionDone or a malformed message
+			return
+		}
+
+		var ip net.IP
+		switch h.Type {
+		case dnsmessage.TypeA:
+			r, err := p.AResource()
+			if err != nil {
+				return
+			}
+			ip = net.IP(r.A[:])
+		case dnsmessage.TypeAAAA:
+			r, err := p.AAAAResource()
+			if err != nil {
+				return
+			}
+			ip = net.IP(r.AAAA[:])
+		default:
+			if err = p.SkipAnswer(); err != nil {
+				return
+			}
+			continue
+		}
+
+		// the question name is recorded too in case the answer is the target of a CNAME record
+		names := make([]string, 0, len(questions)+1)
+		names = append(names, h.Name.String())
+		for _, q := range questions {
+			if q.Type == h.Type {
+				names = append(names, q.Name.String())
+			}
+		}
+		f.add(names, ip, time.Duration(h.TTL)*time.Second)
+	}
+}
+
+// referencedFqdns returns all the fqdns referenced by security groups and network policies
+func (c *Controller) referencedFqdns() ([]string, error) {
+	var fqdns []string
+	sgs, err := c.sgsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list security groups: %v", err)
+		return nil, err
+	}
+	for _, sg := range sgs {
+		for _, rule := range sg.Spec.EgressRules {
+			if rule.RemoteType == kubeovnv1.SgRemoteTypeFqdn {
+				fqdns = append(fqdns, util.NormalizeFqdn(rule.RemoteAddress))
+			}
+		}
+	}
+
+	nps, err := c.npsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list network policies: %v", err)
+		return nil, err
+	}
+	for _, np := range nps {
+		fqdns = append(fqdns, util.ParseFqdns(np.Annotations[util.NetworkPolicyEgressFqdnsAnnotation])...)
+	}
+	return util.UniqString(fqdns), nil
+}
+
+// clusterDNSServers returns the cluster ips and the endpoint addresses of the cluster dns service,
+// along with the additional dns servers specified by the command line
+func (c *Controller) clusterDNSServers() ([]string, error) {
+	servers := append([]string(nil), c.fqdnDNSServers...)
+	svcs, err := c.dnsServicesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list dns services: %v", err)
+		return nil, err
+	}
+	for _, svc := range svcs {
+		for _, ip := range svc.Spec.ClusterIPs {
+			if parsed := net.ParseIP(ip); parsed != nil {
+				servers = append(servers, parsed.String())
+			}
+		}
+	}
+
+	endpoints, err := c.dnsEndpointsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list dns endpoints: %v", err)
+		return nil, err
+	}
+	for _, ep := range endpoints {
+		for _, subset := range ep.Subsets {
+			for _, addr := range subset.Addresses {
+				if parsed := net.ParseIP(addr.IP); parsed != nil {
+					servers = append(servers, parsed.String())
+				}
+			}
+		}
+	}
+	return servers, nil
+}
+
+// syncFqdnCache publishes the snooped dns answers to the FqdnCache of the node
+func (c *Controller) syncFqdnCache() {
+	servers, err := c.clusterDNSServers()
+	if err != nil {
+		return
+	}
+	c.fqdnCache.setServers(servers)
+
+	fqdns, err := c.referencedFqdns()
+	if err != nil {
+		return
+	}
+	c.fqdnCache.setFqdns(fqdns)
+
+	entries := c.fqdnCache.snapshot()
+	if entries == nil {
+		return
+	}
+	if err = c.updateFqdnCache(entries); err != nil {
+		klog.Errorf("failed to update fqdn cache %s: %v", c.config.NodeName, err)
+		c.fqdnCache.reset()
+	}
+}
+
+func (c *Controller) updateFqdnCache(entries []kubeovnv1.FqdnCacheEntry) error {
+	client := c.config.KubeOvnClient.KubeovnV1().FqdnCaches()
+	fc, err := client.Get(context.Background(), c.config.NodeName, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		fc = &kubeovnv1.FqdnCache{
+			ObjectMeta: metav1.ObjectMeta{Name: c.config.NodeName},
+			Spec:       kubeovnv1.FqdnCacheSpec{Entries: entries},
+		}
+		_, err = client.Create(context.Background(), fc, metav1.CreateOptions{})
+		return err
+	}
+
+	fc.Spec.Entries = entries
+	_, err = client.Update(context.Background(), fc, metav1.UpdateOptions{})
+	return err
+}
+
+// runFqdnCacheWorker publishes new addresses immediately and checks the expiration every second
+func (c *Controller) runFqdnCacheWorker(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-c.fqdnCache.notifyCh:
+		case <-ticker.C:
+		}
+		c.syncFqdnCache()
+	}
+}
@@ -0,0 +1,136 @@
+package daemon
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"golang.org/x/net/bpf"
+	"golang.org/x/sys/unix"
+	"k8s.io/klog/v2"
+)
+
+// dnsFilter accepts udp packets with source or destination port 53, the packets received
+// by a SOCK_DGRAM packet socket start with the network header
+var dnsFilter = []bpf.Instruction{
+	// ip version
+	bpf.LoadAbsolute{Off: 0, Size: 1},
+	bpf.ALUOpConstant{Op: bpf.ALUOpShiftRight, Val: 4},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: 4, SkipFalse: 7},
+	// ipv4: protocol and udp source/destination port
+	bpf.LoadAbsolute{Off: 9, Size: 1},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: unix.IPPROTO_UDP, SkipFalse: 13},
+	bpf.LoadMemShift{Off: 0},
+	bpf.LoadIndirect{Off: 0, Size: 2},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: 53, SkipTrue: 9},
+	bpf.LoadIndirect{Off: 2, Size: 2},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: 53, SkipTrue: 7, SkipFalse: 8},
+	// ipv6: next header and udp source/destination port
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: 6, SkipFalse: 7},
+	bpf.LoadAbsolute{Off: 6, Size: 1},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: unix.IPPROTO_UDP, SkipFalse: 5},
+	bpf.LoadAbsolute{Off: 40, Size: 2},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: 53, SkipTrue: 2},
+	bpf.LoadAbsolute{Off: 42, Size: 2},
+	bpf.JumpIf{Cond: bpf.JumpEqual, Val: 53, SkipFalse: 1},
+	bpf.RetConstant{Val: 0xffff},
+	bpf.RetConstant{Val: 0},
+}
+
+func htons(i uint16) uint16 {
+	return (i<<8)&0xff00 | i>>8
+}
+
+func openDNSSnoopSocket() (int, error) {
+	raw, err := bpf.Assemble(dnsFilter)
+	if err != nil {
+		return -1, fmt.Errorf("failed to assemble bpf filter: %v", err)
+	}
+	filter := make([]unix.SockFilter, len(raw))
+	for i, ins := range raw {
+		filter[i] = unix.SockFilter{Code: ins.Op, Jt: ins.Jt, Jf: ins.Jf, K: ins.K}
+	}
+
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_DGRAM, int(htons(unix.ETH_P_ALL)))
+	if err != nil {
+		return -1, fmt.Errorf("failed to create packet socket: %v", err)
+	}
+	prog := unix.SockFprog{Len: uint16(len(filter)), Filter: &filter[0]}
+	if err = unix.SetsockoptSockFprog(fd, unix.SOL_SOCKET, unix.SO_ATTACH_FILTER, &prog); err != nil {
+		_ = unix.Close(fd)
+		return -1, fmt.Errorf("failed to attach bpf filter: %v", err)
+	}
+	return fd, nil
+}
+
+// parseDNSPacket returns the addresses, ports and payload of an ipv4/ipv6 udp packet
+func parseDNSPacket(packet []byte) *dnsPacket {
+	if len(packet) == 0 {
+		return nil
+	}
+
+	var offset int
+	var src, dst net.IP
+	switch packet[0] >> 4 {
+	case 4:
+		if offset = int(packet[0]&0x0f) * 4; offset < 20 || len(packet) < offset {
+			return nil
+		}
+		src, dst = net.IP(packet[12:16]), net.IP(packet[16:20])
+	case 6:
+		if offset = 40; len(packet) < offset {
+			return nil
+		}
+		src, dst = net.IP(packet[8:24]), net.IP(packet[24:40])
+	default:
+		return nil
+	}
+	if len(packet) < offset+8 {
+		return nil
+	}
+	length := int(binary.BigEndian.Uint16(packet[offset+4 : offset+6]))
+	if length < 8 || len(packet) < offset+length {
+		return nil
+	}
+	return &dnsPacket{
+		src:     src,
+		dst:     dst,
+		srcPort: binary.BigEndian.Uint16(packet[offset : offset+2]),
+		dstPort: binary.BigEndian.Uint16(packet[offset+2 : offset+4]),
+		payload: packet[offset+8 : offset+length],
+	}
+}
+
+// runFqdnSnooper captures the dns queries and responses on all the interfaces of the node,
+// including the ones of pods, and records the addresses of referenced fqdns
+func (c *Controller) runFqdnSnooper(stopCh <-chan struct{}) {
+	fd, err := openDNSSnoopSocket()
+	if err != nil {
+		klog.Errorf("failed to start fqdn snooper: %v", err)
+		return
+	}
+	go func() {
+		<-stopCh
+		_ = unix.Close(fd)
+	}()
+
+	klog.Info("fqdn snooper started")
+	buf := make([]byte, 65536)
+	for {
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if err != nil {
+			if err == unix.EINTR {
+				continue
+			}
+			select {
+			case <-stopCh:
+			default:
+				klog.Errorf("failed to receive dns response: %v", err)
+			}
+			return
+		}
+		if packet := parseDNSPacket(buf[:n]); packet != nil {
+			c.fqdnCache.handleDNSPacket(packet)
+		}
+	}
+}
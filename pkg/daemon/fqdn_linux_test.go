@@ -0,0 +1,88 @@
+package daemon
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/bpf"
+	"golang.org/x/sys/unix"
+)
+
+func newUDPPacket(src, dst net.IP, srcPort, dstPort uint16, protocol uint8, payload []byte) []byte {
+	udp := make([]byte, 8+len(payload))
+	binary.BigEndian.PutUint16(udp[0:2], srcPort)
+	binary.BigEndian.PutUint16(udp[2:4], dstPort)
+	binary.BigEndian.PutUint16(udp[4:6], uint16(len(udp)))
+	copy(udp[8:], payload)
+
+	if src.To4() != nil {
+		header := make([]byte, 20)
+		header[0] = 4<<4 | 5
+		header[9] = protocol
+		copy(header[12:16], src.To4())
+		copy(header[16:20], dst.To4())
+		return append(header, udp...)
+	}
+	header := make([]byte, 40)
+	header[0] = 6 << 4
+	header[6] = protocol
+	copy(header[8:24], src.To16())
+	copy(header[24:40], dst.To16())
+	return append(header, udp...)
+}
+
+func Test_dnsFilter(t *testing.T) {
+	t.Parallel()
+
+	vm, err := bpf.NewVM(dnsFilter)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		packet []byte
+		accept bool
+	}{
+		{"ipv4 query", newUDPPacket(net.ParseIP("10.16.0.10"), net.ParseIP("10.96.0.10"), 40000, 53, unix.IPPROTO_UDP, nil), true},
+		{"ipv4 response", newUDPPacket(net.ParseIP("10.96.0.10"), net.ParseIP("10.16.0.10"), 53, 40000, unix.IPPROTO_UDP, nil), true},
+		{"ipv4 other port", newUDPPacket(net.ParseIP("10.16.0.10"), net.ParseIP("10.96.0.10"), 40000, 54, unix.IPPROTO_UDP, nil), false},
+		{"ipv4 tcp", newUDPPacket(net.ParseIP("10.16.0.10"), net.ParseIP("10.96.0.10"), 40000, 53, unix.IPPROTO_TCP, nil), false},
+		{"ipv6 query", newUDPPacket(net.ParseIP("fd00::10"), net.ParseIP("fd00::a"), 40000, 53, unix.IPPROTO_UDP, nil), true},
+		{"ipv6 response", newUDPPacket(net.ParseIP("fd00::a"), net.ParseIP("fd00::10"), 53, 40000, unix.IPPROTO_UDP, nil), true},
+		{"ipv6 other port", newUDPPacket(net.ParseIP("fd00::10"), net.ParseIP("fd00::a"), 40000, 54, unix.IPPROTO_UDP, nil), false},
+		{"ipv6 tcp", newUDPPacket(net.ParseIP("fd00::10"), net.ParseIP("fd00::a"), 40000, 53, unix.IPPROTO_TCP, nil), false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			n, err := vm.Run(tt.packet)
+			require.NoError(t, err)
+			require.Equal(t, tt.accept, n != 0)
+		})
+	}
+}
+
+func Test_parseDNSPacket(t *testing.T) {
+	t.Parallel()
+
+	packet := parseDNSPacket(newUDPPacket(net.ParseIP("10.96.0.10"), net.ParseIP("10.16.0.10"), 53, 40000, unix.IPPROTO_UDP, []byte("dns")))
+	require.NotNil(t, packet)
+	require.Equal(t, &dnsPacket{
+		src:     net.ParseIP("10.96.0.10").To4(),
+		dst:     net.ParseIP("10.16.0.10").To4(),
+		srcPort: 53,
+		dstPort: 40000,
+		payload: []byte("dns"),
+	}, packet)
+
+	packet = parseDNSPacket(newUDPPacket(net.ParseIP("fd00::a"), net.ParseIP("fd00::10"), 53, 40000, unix.IPPROTO_UDP, []byte("dns")))
+	require.NotNil(t, packet)
+	require.Equal(t, net.ParseIP("fd00::a"), packet.src)
+	require.Equal(t, []byte("dns"), packet.payload)
+
+	// truncated packets
+	require.Nil(t, parseDNSPacket(nil))
+	require.Nil(t, parseDNSPacket(newUDPPacket(net.ParseIP("10.96.0.10"), net.ParseIP("10.16.0.10"), 53, 40000, unix.IPPROTO_UDP, []byte("dns"))[:30]))
+}
@@ -0,0 +1,106 @@
+package daemon
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func newDNSMessage(t *testing.T, id uint16, response bool, name string, answer net.IP) []byte {
+	t.Helper()
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, Response: response})
+	require.NoError(t, b.StartQuestions())
+	require.NoError(t, b.Question(dnsmessage.Question{Name: dnsmessage.MustNewName(name), Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET}))
+	if answer != nil {
+		require.NoError(t, b.StartAnswers())
+		var a [4]byte
+		copy(a[:], answer.To4())
+		h := dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(name), Class: dnsmessage.ClassINET, TTL: 60}
+		require.NoError(t, b.AResource(h, dnsmessage.AResource{A: a}))
+	}
+	msg, err := b.Finish()
+	require.NoError(t, err)
+	return msg
+}
+
+func Test_fqdnCacheHandleDNSPacket(t *testing.T) {
+	t.Parallel()
+
+	var (
+		client    = net.ParseIP("10.16.0.10")
+		server    = net.ParseIP("10.96.0.10")
+		attacker  = net.ParseIP("10.16.0.20")
+		answer    = net.ParseIP("1.1.1.1")
+		forged    = net.ParseIP("6.6.6.6")
+		name      = "www.example.com."
+		queryID   = uint16(100)
+		queryPort = uint16(40000)
+	)
+	query := func() *dnsPacket {
+		return &dnsPacket{src: client, dst: server, srcPort: queryPort, dstPort: 53, payload: newDNSMessage(t, queryID, false, name, nil)}
+	}
+	response := func(src net.IP, dstPort, id uint16, qname string, ip net.IP) *dnsPacket {
+		return &dnsPacket{src: src, dst: client, srcPort: 53, dstPort: dstPort, payload: newDNSMessage(t, id, true, qname, ip)}
+	}
+
+	tests := []struct {
+		name     string
+		query    bool
+		response *dnsPacket
+		learned  bool
+	}{
+		{"matched response", true, response(server, queryPort, queryID, name, answer), true},
+		{"case insensitive question", true, response(server, queryPort, queryID, "WWW.example.COM.", answer), true},
+		{"no query", false, response(server, queryPort, queryID, name, forged), false},
+		{"not from dns server", true, response(attacker, queryPort, queryID, name, forged), false},
+		{"transaction id mismatch", true, response(server, queryPort, queryID+1, name, forged), false},
+		{"client port mismatch", true, response(server, queryPort+1, queryID, name, forged), false},
+		{"question mismatch", true, response(server, queryPort, queryID, "www.example.org.", forged), false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f := newFqdnCache()
+			f.setFqdns([]string{"www.example.com", "www.example.org"})
+			f.setServers([]string{server.String()})
+			if tt.query {
+				f.handleDNSPacket(query())
+			}
+			f.handleDNSPacket(tt.response)
+			if tt.learned {
+				require.Len(t, f.entries, 1)
+				require.Contains(t, f.entries, fqdnEntryKey{name: "www.example.com", ip: answer.String()})
+				// the query is answered
+				require.Empty(t, f.queries)
+			} else {
+				require.Empty(t, f.entries)
+			}
+		})
+	}
+}
+
+func Test_fqdnCacheQueries(t *testing.T) {
+	t.Parallel()
+
+	f := newFqdnCache()
+	f.setServers([]string{"10.96.0.10"})
+	payload := newDNSMessage(t, 1, false, "www.example.com.", nil)
+
+	// queries to other servers are ignored
+	f.handleDNSPacket(&dnsPacket{src: net.ParseIP("10.16.0.10"), dst: net.ParseIP("8.8.8.8"), srcPort: 40000, dstPort: 53, payload: payload})
+	require.Empty(t, f.queries)
+
+	f.handleDNSPacket(&dnsPacket{src: net.ParseIP("10.16.0.10"), dst: net.ParseIP("10.96.0.10"), srcPort: 40000, dstPort: 53, payload: payload})
+	require.Len(t, f.queries, 1)
+
+	// pending queries expire
+	for key := range f.queries {
+		f.queries[key] = time.Now().Add(-time.Second)
+	}
+	f.snapshot()
+	require.Empty(t, f.queries)
+}
@@ -108,6 +108,7 @@ func (c *Controller) getSubnetsNeedNAT(protocol string) ([]string, error) {
 		if c.isSubnetNeedNat(subnet, protocol) {
 			cidrBlock := getCidrByProtocol(subnet.Spec.CIDRBlock, protocol)
 			subnetsNeedNat = append(subnetsNeedNat, cidrBlock)
+			subnetsNeedNat = append(subnetsNeedNat, getSecondaryCidrsByProtocol(subnet, protocol)...)
 		}
 	}
 	return subnetsNeedNat, nil
@@ -146,6 +147,7 @@ func (c *Controller) getSubnetsDistributedGateway(protocol string) ([]string, er
 			(subnet.Spec.Protocol == kubeovnv1.ProtocolDual || subnet.Spec.Protocol == protocol) {
 			cidrBlock := getCidrByProtocol(subnet.Spec.CIDRBlock, protocol)
 			result = append(result, cidrBlock)
+			result = append(result, getSecondaryCidrsByProtocol(subnet, protocol)...)
 		}
 	}
 	return result, nil
@@ -175,6 +177,7 @@ func (c *Controller) getDefaultVpcSubnetsCIDR(protocol string) ([]string, map[st
 		if subnet.Spec.Vpc == c.config.ClusterRouter && (subnet.Spec.Vlan == "" || subnet.Spec.LogicalGateway) && subnet.Spec.CIDRBlock != "" {
 			cidrBlock := getCidrByProtocol(subnet.Spec.CIDRBlock, protocol)
 			ret = append(ret, cidrBlock)
+			ret = append(ret, getSecondaryCidrsByProtocol(subnet, protocol)...)
 			subnetMap[subnet.Name] = cidrBlock
 		}
 	}
@@ -218,6 +221,16 @@ func getCidrByProtocol(cidr, protocol string) string {
 	return cidrStr
 }
 
+func getSecondaryCidrsByProtocol(subnet *kubeovnv1.Subnet, protocol string) []string {
+	var cidrs []string
+	for _, cidr := range subnet.Spec.SecondaryCIDRBlocks {
+		if util.CheckProtocol(cidr) == protocol {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+	return cidrs
+}
+
 func (c *Controller) getEgressNatIPByNode(nodeName string) (map[string]string, error) {
 	subnetsNatIP := make(map[string]string)
 	subnetList, err := c.subnetsLister.List(labels.Everything())
@@ -463,3 +463,124 @@ func (csh cniServerHandler) handleDel(req *restful.Request, resp *restful.Respon
 
 	resp.WriteHeader(http.StatusNoContent)
 }
+
+// handleCheck verifies the container nic configured by handleAdd still matches the pod annotations
+func (csh cniServerHandler) handleCheck(req *restful.Request, resp *restful.Response) {
+	writeError := func(status int, err error) {
+		klog.Error(err)
+		if err := resp.WriteHeaderAndEntity(status, request.CniResponse{Err: err.Error()}); err != nil {
+			klog.Errorf("failed to write response, %v", err)
+		}
+	}
+
+	var podRequest request.CniRequest
+	if err := req.ReadEntity(&podRequest); err != nil {
+		writeError(http.StatusBadRequest, fmt.Errorf("parse check request failed %v", err))
+		return
+	}
+	klog.Infof("check port request: %v", podRequest)
+	if err := csh.validatePodRequest(&podRequest); err != nil {
+		writeError(http.StatusBadRequest, err)
+		return
+	}
+
+	pod, err := csh.Controller.podsLister.Pods(podRequest.PodNamespace).Get(podRequest.PodName)
+	if err != nil {
+		writeError(http.StatusInternalServerError, fmt.Errorf("get pod %s/%s failed %v", podRequest.PodNamespace, podRequest.PodName, err))
+		return
+	}
+	provider := podRequest.Provider
+	if pod.Annotations[fmt.Sprintf(util.AllocatedAnnotationTemplate, provider)] != "true" {
+		writeError(http.StatusInternalServerError, fmt.Errorf("no address allocated to pod %s/%s provider %s", pod.Namespace, pod.Name, provider))
+		return
+	}
+	if !strings.HasSuffix(provider, util.OvnProvider) {
+		// the nic is configured by another cni plugin with addresses allocated by kube-ovn
+		resp.WriteHeader(http.StatusOK)
+		return
+	}
+
+	ip := pod.Annotations[fmt.Sprintf(util.IPAddressAnnotationTemplate, provider)]
+	cidr := pod.Annotations[fmt.Sprintf(util.CidrAnnotationTemplate, provider)]
+	gw := pod.Annotations[fmt.Sprintf(util.GatewayAnnotationTemplate, provider)]
+	mac := pod.Annotations[fmt.Sprintf(util.MacAddressAnnotationTemplate, provider)]
+	subnetName := pod.Annotations[fmt.Sprintf(util.LogicalSwitchAnnotationTemplate, provider)]
+	var routes []request.Route
+	if s := pod.Annotations[fmt.Sprintf(util.RoutesAnnotationTemplate, provider)]; s != "" {
+		if err = json.Unmarshal([]byte(s), &routes); err != nil {
+			writeError(http.StatusInternalServerError, fmt.Errorf("invalid routes for pod %s/%s: %v", pod.Namespace, pod.Name, err))
+			return
+		}
+	}
+	routes = append(podRequest.Routes, routes...)
+
+	ifName := podRequest.IfName
+	if ifName == "" {
+		ifName = "eth0"
+	}
+	var isDefaultRoute bool
+	switch pod.Annotations[fmt.Sprintf(util.DefaultRouteAnnotationTemplate, provider)] {
+	case "true":
+		isDefaultRoute = true
+	case "false":
+		isDefaultRoute = false
+	default:
+		isDefaultRoute = ifName == "eth0"
+	}
+
+	var nicType string
+	switch {
+	case podRequest.DeviceID != "":
+		nicType = util.OffloadType
+	case podRequest.VhostUserSocketVolumeName != "" || podRequest.VhostUserSocketConsumption == util.ConsumptionKubevirt:
+		nicType = util.DpdkType
+	default:
+		nicType = pod.Annotations[fmt.Sprintf(util.PodNicAnnotationTemplate, provider)]
+	}
+
+	var u2oInterconnectionIP string
+	if subnetName != "" {
+		subnet, err := csh.Controller.subnetsLister.Get(subnetName)
+		if err != nil {
+			writeError(http.StatusInternalServerError, fmt.Errorf("failed to get subnet %s: %v", subnetName, err))
+			return
+		}
+		if subnet.Spec.U2OInterconnection {
+			u2oInterconnectionIP = subnet.Status.U2OInterconnectionIP
+		}
+	}
+
+	podName := podRequest.PodName
+	if vmName := pod.Annotations[fmt.Sprintf(util.VMTemplate, provider)]; vmName != "" {
+		podName = vmName
+	}
+	if err = csh.checkNic(podName, podRequest.PodNamespace, provider, podRequest.NetNs, podRequest.ContainerID, ifName, mac,
+		util.GetIPAddrWithMask(ip, cidr), gw, isDefaultRoute, routes, nicType, u2oInterconnectionIP); err != nil {
+		writeError(http.StatusConflict, fmt.Errorf("check nic of pod %s/%s failed: %v", pod.Namespace, pod.Name, err))
+		return
+	}
+	resp.WriteHeader(http.StatusOK)
+}
+
+// handleStatus reports whether the daemon and ovs are ready to serve add requests
+func (csh cniServerHandler) handleStatus(_ *restful.Request, resp *restful.Response) {
+	var err error
+	switch {
+	case !csh.Controller.podsSynced() || !csh.Controller.subnetsSynced() || !csh.Controller.nodesSynced():
+		err = fmt.Errorf("informer caches of kube-ovn-cni are not synced")
+	default:
+		if output, e := ovs.Exec("br-exists", "br-int"); e != nil {
+			err = fmt.Errorf("ovs is not ready, bridge br-int is not available: %v %q", e, output)
+		} else if ofport, e := ovs.GetInterfaceOfPort("br-int"); e != nil || ofport < 0 {
+			err = fmt.Errorf("ovs-vswitchd is not ready, bridge br-int is not attached: %v", e)
+		}
+	}
+	if err != nil {
+		klog.Error(err)
+		if err := resp.WriteHeaderAndEntity(http.StatusServiceUnavailable, request.CniResponse{Err: err.Error()}); err != nil {
+			klog.Errorf("failed to write response, %v", err)
+		}
+		return
+	}
+	resp.WriteHeader(http.StatusOK)
+}
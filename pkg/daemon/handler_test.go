@@ -0,0 +1,79 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/parnurzeal/gorequest"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/kubeovn/kube-ovn/pkg/request"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func newTestCniServer(t *testing.T, controller *Controller) request.CniServerClient {
+	t.Helper()
+	server := httptest.NewServer(createHandler(&cniServerHandler{Config: &Configuration{}, Controller: controller}))
+	t.Cleanup(server.Close)
+
+	client := gorequest.New()
+	client.Transport = &http.Transport{DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}}
+	return request.CniServerClient{SuperAgent: client}
+}
+
+func Test_handleCheck(t *testing.T) {
+	t.Parallel()
+
+	const provider = "macvlan.default"
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	require.NoError(t, indexer.Add(&v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "default",
+		Name:        "allocated",
+		Annotations: map[string]string{fmt.Sprintf(util.AllocatedAnnotationTemplate, provider): "true"},
+	}}))
+	require.NoError(t, indexer.Add(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unallocated"}}))
+	client := newTestCniServer(t, &Controller{podsLister: listerv1.NewPodLister(indexer)})
+
+	tests := []struct {
+		name    string
+		pod     string
+		wantErr string
+	}{
+		// the nic is configured by another cni plugin
+		{"allocated", "allocated", ""},
+		{"unallocated", "unallocated", "check return 500 no address allocated to pod default/unallocated provider " + provider},
+		{"not found", "not-found", `check return 500 get pod default/not-found failed pod "not-found" not found`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := client.Check(request.CniRequest{PodName: tt.pod, PodNamespace: "default", Provider: provider})
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_handleStatus(t *testing.T) {
+	t.Parallel()
+
+	notSynced := func() bool { return false }
+	client := newTestCniServer(t, &Controller{podsSynced: notSynced, subnetsSynced: notSynced, nodesSynced: notSynced})
+	err := client.Status()
+	require.ErrorIs(t, err, request.ErrNotReady)
+	require.EqualError(t, err, "cniserver is not ready: informer caches of kube-ovn-cni are not synced")
+}
@@ -91,6 +91,20 @@ var (
 			"protocol",
 		},
 	)
+
+	metricACLLogHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "acl_log_hits_total",
+			Help: "the number of packets logged by acls of network policies.",
+		}, []string{
+			"node_name",
+			"namespace",
+			"policy",
+			"direction",
+			"rule",
+			"verdict",
+		},
+	)
 	// reflector metrics
 
 	// TODO(directxman12): update these to be histograms once the metrics overhaul KEP
@@ -154,6 +168,7 @@ func InitMetrics() {
 	prometheus.MustRegister(cniOperationHistogram)
 	prometheus.MustRegister(cniWaitAddressResult)
 	prometheus.MustRegister(cniConnectivityResult)
+	prometheus.MustRegister(metricACLLogHits)
 }
 
 func registerOvnSubnetGatewayMetrics() {
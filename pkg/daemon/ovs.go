@@ -8,12 +8,36 @@ import (
 	goping "github.com/prometheus-community/pro-bing"
 	"k8s.io/klog/v2"
 
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
 	"github.com/kubeovn/kube-ovn/pkg/ovs"
+	"github.com/kubeovn/kube-ovn/pkg/request"
 	"github.com/kubeovn/kube-ovn/pkg/util"
 )
 
 const gatewayCheckMaxRetry = 200
 
+// hasCustomDefaultRoute reports whether the routes contain a default route of the protocol,
+// which replaces the default route via the subnet gateway
+func hasCustomDefaultRoute(routes []request.Route, protocol string) bool {
+	for _, r := range routes {
+		switch r.Destination {
+		case "":
+			if r.Gateway != "" && util.CheckProtocol(r.Gateway) == protocol {
+				return true
+			}
+		case "0.0.0.0/0":
+			if protocol == kubeovnv1.ProtocolIPv4 {
+				return true
+			}
+		case "::/0":
+			if protocol == kubeovnv1.ProtocolIPv6 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func pingGateway(gw, src string, verbose bool, maxRetry int) (count int, err error) {
 	pinger, err := goping.NewPinger(gw)
 	if err != nil {
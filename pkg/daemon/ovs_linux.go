@@ -273,6 +273,148 @@ func (csh cniServerHandler) deleteNic(podName, podNamespace, containerID, netns,
 	return nil
 }
 
+// checkNic verifies the ovs interface and the container nic configured by configureNic or configureNicWithInternalPort
+func (csh cniServerHandler) checkNic(podName, podNamespace, provider, netns, containerID, ifName, mac, ip, gateway string, isDefaultRoute bool, routes []request.Route, nicType, u2oInterconnectionIP string) error {
+	ifaceID := ovs.PodNameToPortName(podName, podNamespace, provider)
+	ifaces, err := ovs.GetInterfacesByIfaceID(ifaceID)
+	if err != nil {
+		return fmt.Errorf("failed to find ovs interface of port %s: %v", ifaceID, err)
+	}
+	if len(ifaces) != 1 {
+		return fmt.Errorf("expected exactly one ovs interface for port %s, found %v", ifaceID, ifaces)
+	}
+	ofport, err := ovs.GetInterfaceOfPort(ifaces[0])
+	if err != nil {
+		return fmt.Errorf("failed to get ofport of ovs interface %s: %v", ifaces[0], err)
+	}
+	if ofport < 0 {
+		return fmt.Errorf("ovs interface %s is not attached to br-int", ifaces[0])
+	}
+	podNetns, err := ovs.GetInterfacePodNs(ifaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get netns of ovs interface %s: %v", ifaces[0], err)
+	}
+	if podNetns != netns {
+		return fmt.Errorf("ovs interface %s belongs to netns %s, expected %s", ifaces[0], podNetns, netns)
+	}
+
+	hostNicName, containerNicName := generateNicName(containerID, ifName)
+	nicName := ifName
+	switch nicType {
+	case util.DpdkType, util.OffloadType:
+		// the nic is not managed through the kernel network stack of the container
+		return nil
+	case util.InternalType:
+		if ifaces[0] != containerNicName {
+			return fmt.Errorf("ovs interface of port %s is %s, expected %s", ifaceID, ifaces[0], containerNicName)
+		}
+		nicName = containerNicName
+	default:
+		if ifaces[0] != hostNicName {
+			return fmt.Errorf("ovs interface of port %s is %s, expected %s", ifaceID, ifaces[0], hostNicName)
+		}
+		hostLink, err := netlink.LinkByName(hostNicName)
+		if err != nil {
+			return fmt.Errorf("can not find host nic %s: %v", hostNicName, err)
+		}
+		if hostLink.Attrs().Flags&net.FlagUp == 0 {
+			return fmt.Errorf("host nic %s is down", hostNicName)
+		}
+	}
+
+	return ns.WithNetNSPath(netns, func(_ ns.NetNS) error {
+		link, err := netlink.LinkByName(nicName)
+		if err != nil {
+			return fmt.Errorf("can not find container nic %s: %v", nicName, err)
+		}
+		if link.Attrs().Flags&net.FlagUp == 0 {
+			return fmt.Errorf("container nic %s is down", nicName)
+		}
+		if !strings.EqualFold(link.Attrs().HardwareAddr.String(), mac) {
+			return fmt.Errorf("mac address of container nic %s is %s, expected %s", nicName, link.Attrs().HardwareAddr, mac)
+		}
+
+		addrs, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+		if err != nil {
+			return fmt.Errorf("failed to list addresses of container nic %s: %v", nicName, err)
+		}
+		for _, ipStr := range strings.Split(ip, ",") {
+			ipNet, err := netlink.ParseIPNet(ipStr)
+			if err != nil {
+				return fmt.Errorf("invalid address %s: %v", ipStr, err)
+			}
+			var found bool
+			for _, addr := range addrs {
+				if addr.IPNet.String() == ipNet.String() {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("address %s is not configured on container nic %s", ipStr, nicName)
+			}
+		}
+
+		linkRoutes, err := netlink.RouteList(link, netlink.FAMILY_ALL)
+		if err != nil {
+			return fmt.Errorf("failed to list routes of container nic %s: %v", nicName, err)
+		}
+		hasRoute := func(dst *net.IPNet, gw net.IP) bool {
+			for _, r := range linkRoutes {
+				if gw != nil && !gw.Equal(r.Gw) {
+					continue
+				}
+				if dst == nil {
+					if r.Dst == nil || (r.Dst.IP.IsUnspecified() && net.IP(r.Dst.Mask).IsUnspecified()) {
+						return true
+					}
+					continue
+				}
+				if r.Dst != nil && r.Dst.String() == dst.String() {
+					return true
+				}
+			}
+			return false
+		}
+
+		if isDefaultRoute {
+			containerGw := gateway
+			if u2oInterconnectionIP != "" {
+				containerGw = u2oInterconnectionIP
+			}
+			for _, gw := range strings.Split(containerGw, ",") {
+				// the default route configured by the routes annotation takes the place of the gateway one
+				if hasCustomDefaultRoute(routes, util.CheckProtocol(gw)) {
+					continue
+				}
+				if !hasRoute(nil, net.ParseIP(gw)) {
+					return fmt.Errorf("default route via %s is not configured on container nic %s", gw, nicName)
+				}
+			}
+		}
+		for _, r := range routes {
+			var dst *net.IPNet
+			if r.Destination != "" {
+				if _, dst, err = net.ParseCIDR(r.Destination); err != nil {
+					klog.Errorf("invalid route destination %s: %v", r.Destination, err)
+					continue
+				}
+			}
+			var gw net.IP
+			if r.Gateway != "" {
+				if gw = net.ParseIP(r.Gateway); gw == nil {
+					klog.Errorf("invalid route gateway %s", r.Gateway)
+					continue
+				}
+			}
+			if !hasRoute(dst, gw) {
+				return fmt.Errorf("route %+v is not configured on container nic %s", r, nicName)
+			}
+		}
+		return nil
+	})
+}
+
 func generateNicName(containerID, ifname string) (string, string) {
 	if ifname == "eth0" {
 		return fmt.Sprintf("%s_h", containerID[0:12]), fmt.Sprintf("%s_c", containerID[0:12])
@@ -0,0 +1,26 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/request"
+)
+
+func Test_hasCustomDefaultRoute(t *testing.T) {
+	t.Parallel()
+
+	routes := []request.Route{
+		{Destination: "192.168.0.0/16", Gateway: "10.16.0.254"},
+		{Destination: "::/0", Gateway: "fd00::fe"},
+	}
+	require.False(t, hasCustomDefaultRoute(routes, kubeovnv1.ProtocolIPv4))
+	require.True(t, hasCustomDefaultRoute(routes, kubeovnv1.ProtocolIPv6))
+
+	routes = []request.Route{{Gateway: "10.16.0.254"}}
+	require.True(t, hasCustomDefaultRoute(routes, kubeovnv1.ProtocolIPv4))
+	require.False(t, hasCustomDefaultRoute(routes, kubeovnv1.ProtocolIPv6))
+	require.False(t, hasCustomDefaultRoute(nil, kubeovnv1.ProtocolIPv4))
+}
@@ -230,6 +230,22 @@ func (csh cniServerHandler) deleteNic(podName, podNamespace, containerID, netns,
 	return hns.RemoveHnsEndpoint(epName, netns, containerID)
 }
 
+func (csh cniServerHandler) checkNic(podName, podNamespace, provider, netns, containerID, _, _, _, _ string, _ bool, _ []request.Route, _, _ string) error {
+	epName := hns.ConstructEndpointName(containerID, netns, util.HnsNetwork)[:12]
+	ifaceID := ovs.PodNameToPortName(podName, podNamespace, provider)
+	ifaces, err := ovs.GetInterfacesByIfaceID(ifaceID)
+	if err != nil {
+		return fmt.Errorf("failed to find ovs interface of port %s: %v", ifaceID, err)
+	}
+	if len(ifaces) != 1 || ifaces[0] != epName {
+		return fmt.Errorf("expected ovs interface %s for port %s, found %v", epName, ifaceID, ifaces)
+	}
+	if _, err = hcsshim.GetHNSEndpointByName(epName); err != nil {
+		return fmt.Errorf("failed to get hns endpoint %s: %v", epName, err)
+	}
+	return nil
+}
+
 func generateNicName(containerID, ifname string) (string, string) {
 	if ifname == "eth0" {
 		return fmt.Sprintf("%s_h", containerID[0:12]), fmt.Sprintf("%s_c", containerID[0:12])
@@ -52,6 +52,13 @@ func createHandler(csh *cniServerHandler) http.Handler {
 		ws.POST("/del").
 			To(csh.handleDel).
 			Reads(request.CniRequest{}))
+	ws.Route(
+		ws.POST("/check").
+			To(csh.handleCheck).
+			Reads(request.CniRequest{}))
+	ws.Route(
+		ws.GET("/status").
+			To(csh.handleStatus))
 
 	ws.Filter(requestAndResponseLogger)
 
@@ -0,0 +1,504 @@
+package ipam
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// CheckpointVersion is bumped whenever the layout of Checkpoint changes in an incompatible way
+const CheckpointVersion = 1
+
+var ErrCheckpointCorrupted = errors.New("CheckpointCorrupted")
+
+// Checkpoint is a serializable snapshot of the whole IPAM state
+type Checkpoint struct {
+	Subnets map[string]*SubnetCheckpoint `json:"subnets"`
+}
+
+type SubnetCheckpoint struct {
+	CIDR           string                       `json:"cidr"`
+	Protocol       string                       `json:"protocol"`
+	V4CIDR         string                       `json:"v4CIDR,omitempty"`
+	V6CIDR         string                       `json:"v6CIDR,omitempty"`
+	SecondaryCIDRs []string                     `json:"secondaryCIDRs,omitempty"`
+	V4Gw           string                       `json:"v4Gw,omitempty"`
+	V6Gw           string                       `json:"v6Gw,omitempty"`
+	V4Free         []string                     `json:"v4Free,omitempty"`
+	V4Reserved     []string                     `json:"v4Reserved,omitempty"`
+	V4Available    []string                     `json:"v4Available,omitempty"`
+	V4Using        []string                     `json:"v4Using,omitempty"`
+	V4NicToIP      map[string]string            `json:"v4NicToIP,omitempty"`
+	V4IPToPod      map[string]string            `json:"v4IPToPod,omitempty"`
+	V6Free         []string                     `json:"v6Free,omitempty"`
+	V6Reserved     []string                     `json:"v6Reserved,omitempty"`
+	V6Available    []string                     `json:"v6Available,omitempty"`
+	V6Using        []string                     `json:"v6Using,omitempty"`
+	V6NicToIP      map[string]string            `json:"v6NicToIP,omitempty"`
+	V6IPToPod      map[string]string            `json:"v6IPToPod,omitempty"`
+	NicToMac       map[string]string            `json:"nicToMac,omitempty"`
+	MacToPod       map[string]string            `json:"macToPod,omitempty"`
+	PodToNicList   map[string][]string          `json:"podToNicList,omitempty"`
+	IPPools        map[string]*IPPoolCheckpoint `json:"ippools"`
+	ReleaseTime    map[string]time.Time         `json:"releaseTime,omitempty"`
+}
+
+type IPPoolCheckpoint struct {
+	V4IPs       []string `json:"v4IPs,omitempty"`
+	V4Free      []string `json:"v4Free,omitempty"`
+	V4Available []string `json:"v4Available,omitempty"`
+	V4Reserved  []string `json:"v4Reserved,omitempty"`
+	V4Released  []string `json:"v4Released,omitempty"`
+	V4Using     []string `json:"v4Using,omitempty"`
+	V6IPs       []string `json:"v6IPs,omitempty"`
+	V6Free      []string `json:"v6Free,omitempty"`
+	V6Available []string `json:"v6Available,omitempty"`
+	V6Reserved  []string `json:"v6Reserved,omitempty"`
+	V6Released  []string `json:"v6Released,omitempty"`
+	V6Using     []string `json:"v6Using,omitempty"`
+}
+
+// checkpointFile is the on-disk envelope, the checksum covers the raw data
+type checkpointFile struct {
+	Version  int             `json:"version"`
+	Checksum string          `json:"checksum"`
+	Data     json.RawMessage `json:"data"`
+}
+
+func encodeIPRangeList(r *IPRangeList) []string {
+	if r == nil || r.Len() == 0 {
+		return nil
+	}
+	ret := make([]string, 0, r.Len())
+	for _, v := range r.ranges {
+		ret = append(ret, fmt.Sprintf("%s..%s", v.start, v.end))
+	}
+	return ret
+}
+
+// decodeIPRangeList rebuilds a list encoded by encodeIPRangeList. The ranges
+// must be sorted and disjoint, so the list is built directly instead of being
+// merged range by range.
+func decodeIPRangeList(x []string) (*IPRangeList, error) {
+	ret := &IPRangeList{make([]*IPRange, 0, len(x))}
+	for _, s := range x {
+		ips := strings.Split(s, "..")
+		if len(ips) != 2 {
+			return nil, fmt.Errorf("invalid ip range %q", s)
+		}
+		start, err := NewIP(ips[0])
+		if err != nil {
+			return nil, err
+		}
+		end, err := NewIP(ips[1])
+		if err != nil {
+			return nil, err
+		}
+		if start.GreaterThan(end) {
+			return nil, fmt.Errorf("invalid ip range %q: %s is greater than %s", s, start, end)
+		}
+		if n := len(ret.ranges); n != 0 && !ret.ranges[n-1].end.LessThan(start) {
+			return nil, fmt.Errorf("ip range %q overlaps with or is not sorted after %s", s, ret.ranges[n-1])
+		}
+		ret.ranges = append(ret.ranges, NewIPRange(start, end))
+	}
+	return ret, nil
+}
+
+func encodeNicToIP(m map[string]IP) map[string]string {
+	ret := make(map[string]string, len(m))
+	for nic, ip := range m {
+		ret[nic] = ip.String()
+	}
+	return ret
+}
+
+func decodeNicToIP(m map[string]string) (map[string]IP, error) {
+	ret := make(map[string]IP, len(m))
+	for nic, s := range m {
+		ip, err := NewIP(s)
+		if err != nil {
+			return nil, err
+		}
+		ret[nic] = ip
+	}
+	return ret, nil
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	ret := make(map[string]string, len(m))
+	for k, v := range m {
+		ret[k] = v
+	}
+	return ret
+}
+
+func (p *IPPool) checkpoint() *IPPoolCheckpoint {
+	return &IPPoolCheckpoint{
+		V4IPs:       encodeIPRangeList(p.V4IPs),
+		V4Free:      encodeIPRangeList(p.V4Free),
+		V4Available: encodeIPRangeList(p.V4Available),
+		V4Reserved:  encodeIPRangeList(p.V4Reserved),
+		V4Released:  encodeIPRangeList(p.V4Released),
+		V4Using:     encodeIPRangeList(p.V4Using),
+		V6IPs:       encodeIPRangeList(p.V6IPs),
+		V6Free:      encodeIPRangeList(p.V6Free),
+		V6Available: encodeIPRangeList(p.V6Available),
+		V6Reserved:  encodeIPRangeList(p.V6Reserved),
+		V6Released:  encodeIPRangeList(p.V6Released),
+		V6Using:     encodeIPRangeList(p.V6Using),
+	}
+}
+
+func restoreIPPool(cp *IPPoolCheckpoint) (*IPPool, error) {
+	var err error
+	pool := &IPPool{}
+	for _, f := range []struct {
+		dst **IPRangeList
+		src []string
+	}{
+		{&pool.V4IPs, cp.V4IPs}, {&pool.V4Free, cp.V4Free}, {&pool.V4Available, cp.V4Available},
+		{&pool.V4Reserved, cp.V4Reserved}, {&pool.V4Released, cp.V4Released}, {&pool.V4Using, cp.V4Using},
+		{&pool.V6IPs, cp.V6IPs}, {&pool.V6Free, cp.V6Free}, {&pool.V6Available, cp.V6Available},
+		{&pool.V6Reserved, cp.V6Reserved}, {&pool.V6Released, cp.V6Released}, {&pool.V6Using, cp.V6Using},
+	} {
+		if *f.dst, err = decodeIPRangeList(f.src); err != nil {
+			return nil, err
+		}
+	}
+	return pool, nil
+}
+
+func (s *Subnet) checkpoint() *SubnetCheckpoint {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	cp := &SubnetCheckpoint{
+		CIDR:         s.CIDR,
+		Protocol:     s.Protocol,
+		V4Gw:         s.V4Gw,
+		V6Gw:         s.V6Gw,
+		V4Free:       encodeIPRangeList(s.V4Free),
+		V4Reserved:   encodeIPRangeList(s.V4Reserved),
+		V4Available:  encodeIPRangeList(s.V4Available),
+		V4Using:      encodeIPRangeList(s.V4Using),
+		V4NicToIP:    encodeNicToIP(s.V4NicToIP),
+		V4IPToPod:    copyStringMap(s.V4IPToPod),
+		V6Free:       encodeIPRangeList(s.V6Free),
+		V6Reserved:   encodeIPRangeList(s.V6Reserved),
+		V6Available:  encodeIPRangeList(s.V6Available),
+		V6Using:      encodeIPRangeList(s.V6Using),
+		V6NicToIP:    encodeNicToIP(s.V6NicToIP),
+		V6IPToPod:    copyStringMap(s.V6IPToPod),
+		NicToMac:     copyStringMap(s.NicToMac),
+		MacToPod:     copyStringMap(s.MacToPod),
+		PodToNicList: make(map[string][]string, len(s.PodToNicList)),
+		IPPools:      make(map[string]*IPPoolCheckpoint, len(s.IPPools)),
+		ReleaseTime:  make(map[string]time.Time, len(s.ReleaseTime)),
+	}
+	if s.V4CIDR != nil {
+		cp.V4CIDR = s.V4CIDR.String()
+	}
+	if s.V6CIDR != nil {
+		cp.V6CIDR = s.V6CIDR.String()
+	}
+	for _, cidr := range append(append([]*net.IPNet(nil), s.V4SecondaryCIDRs...), s.V6SecondaryCIDRs...) {
+		cp.SecondaryCIDRs = append(cp.SecondaryCIDRs, cidr.String())
+	}
+	for pod, nics := range s.PodToNicList {
+		cp.PodToNicList[pod] = append([]string(nil), nics...)
+	}
+	for name, pool := range s.IPPools {
+		cp.IPPools[name] = pool.checkpoint()
+	}
+	for ip, t := range s.ReleaseTime {
+		// drop the monotonic clock reading and location which are not persisted
+		cp.ReleaseTime[ip] = t.UTC()
+	}
+	return cp
+}
+
+func restoreSubnet(name string, cp *SubnetCheckpoint) (*Subnet, error) {
+	var err error
+	subnet := &Subnet{
+		Name:         name,
+		CIDR:         cp.CIDR,
+		Protocol:     cp.Protocol,
+		V4Gw:         cp.V4Gw,
+		V6Gw:         cp.V6Gw,
+		V4IPToPod:    copyStringMap(cp.V4IPToPod),
+		V6IPToPod:    copyStringMap(cp.V6IPToPod),
+		NicToMac:     copyStringMap(cp.NicToMac),
+		MacToPod:     copyStringMap(cp.MacToPod),
+		PodToNicList: make(map[string][]string, len(cp.PodToNicList)),
+		IPPools:      make(map[string]*IPPool, len(cp.IPPools)),
+		ReleaseTime:  make(map[string]time.Time, len(cp.ReleaseTime)),
+	}
+	if cp.V4CIDR != "" {
+		if _, subnet.V4CIDR, err = net.ParseCIDR(cp.V4CIDR); err != nil {
+			return nil, ErrInvalidCIDR
+		}
+	}
+	if cp.V6CIDR != "" {
+		if _, subnet.V6CIDR, err = net.ParseCIDR(cp.V6CIDR); err != nil {
+			return nil, ErrInvalidCIDR
+		}
+	}
+	switch subnet.Protocol {
+	case kubeovnv1.ProtocolIPv4, kubeovnv1.ProtocolIPv6, kubeovnv1.ProtocolDual:
+	default:
+		return nil, fmt.Errorf("invalid protocol %q", subnet.Protocol)
+	}
+	if subnet.V4SecondaryCIDRs, subnet.V6SecondaryCIDRs, err = parseSecondaryCIDRs(subnet.Protocol, cp.SecondaryCIDRs); err != nil {
+		return nil, err
+	}
+
+	for _, f := range []struct {
+		dst **IPRangeList
+		src []string
+	}{
+		{&subnet.V4Free, cp.V4Free}, {&subnet.V4Reserved, cp.V4Reserved},
+		{&subnet.V4Available, cp.V4Available}, {&subnet.V4Using, cp.V4Using},
+		{&subnet.V6Free, cp.V6Free}, {&subnet.V6Reserved, cp.V6Reserved},
+		{&subnet.V6Available, cp.V6Available}, {&subnet.V6Using, cp.V6Using},
+	} {
+		if *f.dst, err = decodeIPRangeList(f.src); err != nil {
+			return nil, err
+		}
+	}
+	if subnet.V4NicToIP, err = decodeNicToIP(cp.V4NicToIP); err != nil {
+		return nil, err
+	}
+	if subnet.V6NicToIP, err = decodeNicToIP(cp.V6NicToIP); err != nil {
+		return nil, err
+	}
+	for pod, nics := range cp.PodToNicList {
+		subnet.PodToNicList[pod] = append([]string(nil), nics...)
+	}
+	for ip, t := range cp.ReleaseTime {
+		subnet.ReleaseTime[ip] = t
+	}
+	for poolName, poolCheckpoint := range cp.IPPools {
+		if subnet.IPPools[poolName], err = restoreIPPool(poolCheckpoint); err != nil {
+			return nil, fmt.Errorf("failed to restore ippool %q: %v", poolName, err)
+		}
+	}
+	if subnet.IPPools[""] == nil {
+		return nil, fmt.Errorf("default ippool of subnet %s is missing", name)
+	}
+	return subnet, nil
+}
+
+// Checkpoint returns a consistent snapshot of all subnets, ippools and allocated addresses
+func (ipam *IPAM) Checkpoint() *Checkpoint {
+	// hold the write lock so that no allocation is in progress in any subnet
+	ipam.mutex.Lock()
+	defer ipam.mutex.Unlock()
+
+	cp := &Checkpoint{Subnets: make(map[string]*SubnetCheckpoint, len(ipam.Subnets))}
+	for name, subnet := range ipam.Subnets {
+		cp.Subnets[name] = subnet.checkpoint()
+	}
+	return cp
+}
+
+// Restore replaces the whole IPAM state with the one saved in the checkpoint.
+// Nothing is changed if the checkpoint cannot be restored.
+func (ipam *IPAM) Restore(cp *Checkpoint) error {
+	subnets := make(map[string]*Subnet, len(cp.Subnets))
+	for name, subnetCheckpoint := range cp.Subnets {
+		subnet, err := restoreSubnet(name, subnetCheckpoint)
+		if err != nil {
+			klog.Errorf("failed to restore subnet %s from checkpoint: %v", name, err)
+			return fmt.Errorf("%w: subnet %s: %v", ErrCheckpointCorrupted, name, err)
+		}
+		subnets[name] = subnet
+	}
+
+	ipam.mutex.Lock()
+	defer ipam.mutex.Unlock()
+	ipam.Subnets = subnets
+	for _, q := range ipam.Quotas {
+		q.mutex.Lock()
+		q.recount(subnets[q.Subnet])
+		q.mutex.Unlock()
+	}
+	return nil
+}
+
+// SaveCheckpoint writes the checkpoint to path atomically: the data is written
+// to a temporary file in the same directory, synced and then renamed, so a
+// crash leaves either the previous or the new checkpoint on disk.
+func SaveCheckpoint(path string, cp *Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	content, err := json.Marshal(&checkpointFile{
+		Version:  CheckpointVersion,
+		Checksum: hex.EncodeToString(sum[:]),
+		Data:     data,
+	})
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := f.Name()
+	defer os.Remove(tmpName)
+
+	if _, err = f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpName, path); err != nil {
+		return err
+	}
+
+	// persist the rename itself
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// LoadCheckpoint reads a checkpoint written by SaveCheckpoint and verifies its version and checksum
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file checkpointFile
+	if err = json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCheckpointCorrupted, err)
+	}
+	if file.Version != CheckpointVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrCheckpointCorrupted, file.Version)
+	}
+	sum := sha256.Sum256(file.Data)
+	if hex.EncodeToString(sum[:]) != file.Checksum {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrCheckpointCorrupted)
+	}
+
+	cp := &Checkpoint{}
+	if err = json.Unmarshal(file.Data, cp); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCheckpointCorrupted, err)
+	}
+	if cp.Subnets == nil {
+		cp.Subnets = map[string]*SubnetCheckpoint{}
+	}
+	return cp, nil
+}
+
+// HasAddress returns whether the nic already holds the ip and mac in the subnet,
+// which means replaying the allocation after a restore can be skipped
+func (ipam *IPAM) HasAddress(podName, nicName, ip, mac, subnetName string) bool {
+	ipam.mutex.RLock()
+	defer ipam.mutex.RUnlock()
+
+	subnet, ok := ipam.Subnets[subnetName]
+	if !ok {
+		return false
+	}
+	return subnet.hasAddress(podName, nicName, ip, mac)
+}
+
+func (s *Subnet) hasAddress(podName, nicName, ip, mac string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if ip == "" || !util.ContainsString(s.PodToNicList[podName], nicName) {
+		return false
+	}
+	if mac != "" && s.NicToMac[nicName] != mac {
+		return false
+	}
+	for _, ipStr := range strings.Split(ip, ",") {
+		addr, err := NewIP(ipStr)
+		if err != nil {
+			return false
+		}
+		var ipToPod map[string]string
+		if addr.To4() != nil {
+			if !s.V4NicToIP[nicName].Equal(addr) {
+				return false
+			}
+			ipToPod = s.V4IPToPod
+		} else {
+			if !s.V6NicToIP[nicName].Equal(addr) {
+				return false
+			}
+			ipToPod = s.V6IPToPod
+		}
+		if !util.ContainsString(strings.Split(ipToPod[addr.String()], ","), podName) {
+			return false
+		}
+	}
+	return true
+}
+
+// PruneCheckpoint drops state restored from a checkpoint which no longer has a
+// backing resource: subnets not in subnets, ippools not listed for their
+// subnet and nics not listed in nics for their subnet.
+func (ipam *IPAM) PruneCheckpoint(subnets map[string][]string, nics map[string]map[string]bool) {
+	ipam.mutex.Lock()
+	defer ipam.mutex.Unlock()
+
+	for name, subnet := range ipam.Subnets {
+		pools, ok := subnets[name]
+		if !ok {
+			klog.Infof("delete subnet %s which no longer exists since checkpoint", name)
+			delete(ipam.Subnets, name)
+			continue
+		}
+		for poolName := range subnet.IPPools {
+			if poolName != "" && !util.ContainsString(pools, poolName) {
+				klog.Infof("remove ippool %s of subnet %s which no longer exists since checkpoint", poolName, name)
+				subnet.RemoveIPPool(poolName)
+			}
+		}
+
+		subnet.mutex.Lock()
+		for podName, nicNames := range subnet.PodToNicList {
+			for _, nicName := range append([]string(nil), nicNames...) {
+				if nics[name][nicName] {
+					continue
+				}
+				klog.Infof("release stale address of %s nic %s in subnet %s since checkpoint", podName, nicName, name)
+				subnet.releaseAddr(podName, nicName)
+				subnet.popPodNic(podName, nicName)
+			}
+		}
+		subnet.mutex.Unlock()
+	}
+}
@@ -1,7 +1,9 @@
 package ipam
 
 import (
+	"crypto/rand"
 	"fmt"
+	"math/big"
 	"net"
 	"sort"
 	"strings"
@@ -183,6 +185,24 @@ func (r *IPRangeList) Allocate(skipped []IP) IP {
 	return ret
 }
 
+// Random returns a random address in the list, or nil if the list is empty
+func (r *IPRangeList) Random() IP {
+	if r.Len() == 0 {
+		return nil
+	}
+
+	count := r.Count()
+	n, _ := rand.Int(rand.Reader, &count.Int)
+	for _, v := range r.ranges {
+		c := v.Count()
+		if n.Cmp(&c.Int) < 0 {
+			return bytes2IP(big.NewInt(0).Add(big.NewInt(0).SetBytes([]byte(v.start)), n).Bytes(), len(v.start))
+		}
+		n.Sub(n, &c.Int)
+	}
+	return nil
+}
+
 func (r *IPRangeList) Equal(x *IPRangeList) bool {
 	if r.Len() != x.Len() {
 		return false
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"k8s.io/klog/v2"
 
@@ -25,6 +26,7 @@ var (
 type IPAM struct {
 	mutex   sync.RWMutex
 	Subnets map[string]*Subnet
+	Quotas  map[string]*Quota
 }
 
 type SubnetAddress struct {
@@ -37,6 +39,7 @@ func NewIPAM() *IPAM {
 	return &IPAM{
 		mutex:   sync.RWMutex{},
 		Subnets: map[string]*Subnet{},
+		Quotas:  map[string]*Quota{},
 	}
 }
 
@@ -49,6 +52,12 @@ func (ipam *IPAM) GetRandomAddress(podName, nicName string, mac *string, subnetN
 		return "", "", "", ErrNoAvailable
 	}
 
+	release, err := ipam.acquireQuotas(subnet, podName, nicName)
+	if err != nil {
+		klog.Errorf("failed to allocate address for %s from subnet %s: %v", podName, subnetName, err)
+		return "", "", "", err
+	}
+
 	v4IP, v6IP, macStr, err := subnet.GetRandomAddress(poolName, podName, nicName, mac, skippedAddrs, checkConflict)
 	if v4IP != nil {
 		v4 = v4IP.String()
@@ -56,6 +65,11 @@ func (ipam *IPAM) GetRandomAddress(podName, nicName string, mac *string, subnetN
 	if v6IP != nil {
 		v6 = v6IP.String()
 	}
+	if err != nil {
+		release("", "")
+	} else {
+		release(v4, v6)
+	}
 	if poolName == "" {
 		klog.Infof("allocate v4 %s, v6 %s, mac %s for %s from subnet %s", v4, v6, macStr, podName, subnetName)
 	} else {
@@ -74,8 +88,15 @@ func (ipam *IPAM) GetStaticAddress(podName, nicName, ip string, mac *string, sub
 		return "", "", "", ErrNoAvailable
 	}
 
+	release, err := ipam.acquireQuotas(subnet, podName, nicName)
+	if err != nil {
+		klog.Errorf("failed to allocate static ip %s for %s: %v", ip, podName, err)
+		return "", "", "", err
+	}
+	var allocatedV4, allocatedV6 string
+	defer func() { release(allocatedV4, allocatedV6) }()
+
 	var ips []IP
-	var err error
 	var ipAddr IP
 	var v4, v6, macStr string
 	for _, ipStr := range strings.Split(ip, ",") {
@@ -96,6 +117,16 @@ func (ipam *IPAM) GetStaticAddress(podName, nicName, ip string, mac *string, sub
 		klog.Errorf("failed to append allocate ip %v mac %v for %s", ips, mac, podName)
 		return "", "", "", err
 	}
+	for _, ip := range ips {
+		if ip == nil {
+			continue
+		}
+		if ip.To4() != nil {
+			allocatedV4 = ip.String()
+		} else {
+			allocatedV6 = ip.String()
+		}
+	}
 
 	switch subnet.Protocol {
 	case kubeovnv1.ProtocolIPv4:
@@ -143,12 +174,21 @@ func (ipam *IPAM) ReleaseAddressByPod(podName string) {
 	ipam.mutex.RLock()
 	defer ipam.mutex.RUnlock()
 	for _, subnet := range ipam.Subnets {
-		subnet.ReleaseAddress(podName)
+		ipam.releaseQuotaAddresses(subnet.Name, subnet.ReleaseAddress(podName))
 	}
 }
 
-func (ipam *IPAM) AddOrUpdateSubnet(name, cidrStr, gw string, excludeIps []string) error {
-	excludeIps = util.ExpandExcludeIPs(excludeIps, cidrStr)
+// ReleaseAddressByNic releases the addresses of a single nic of the pod in the subnet
+func (ipam *IPAM) ReleaseAddressByNic(podName, nicName, subnetName string) {
+	ipam.mutex.RLock()
+	defer ipam.mutex.RUnlock()
+	if subnet, ok := ipam.Subnets[subnetName]; ok {
+		ipam.releaseQuotaAddresses(subnetName, subnet.ReleaseAddressWithNicName(podName, nicName))
+	}
+}
+
+func (ipam *IPAM) AddOrUpdateSubnet(name, cidrStr, gw string, excludeIps []string, secondaryCIDRs ...string) error {
+	excludeIps = util.ExpandExcludeIPs(excludeIps, strings.Join(append([]string{cidrStr}, secondaryCIDRs...), ","))
 
 	ipam.mutex.Lock()
 	defer ipam.mutex.Unlock()
@@ -178,10 +218,17 @@ func (ipam *IPAM) AddOrUpdateSubnet(name, cidrStr, gw string, excludeIps []strin
 		v6Gw = gw
 	}
 
+	v4Secondary, v6Secondary, err := parseSecondaryCIDRs(protocol, secondaryCIDRs)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+
 	// subnet.Spec.ExcludeIps contains both v4 and v6 addresses
 	v4ExcludeIps, v6ExcludeIps := util.SplitIpsByProtocol(excludeIps)
 
 	if subnet, ok := ipam.Subnets[name]; ok {
+		subnet.CIDR = cidrStr
 		subnet.Protocol = protocol
 		v4Reserved, err := NewIPRangeListFrom(v4ExcludeIps...)
 		if err != nil {
@@ -194,13 +241,13 @@ func (ipam *IPAM) AddOrUpdateSubnet(name, cidrStr, gw string, excludeIps []strin
 			return err
 		}
 		if (protocol == kubeovnv1.ProtocolDual || protocol == kubeovnv1.ProtocolIPv4) &&
-			(subnet.V4CIDR.String() != v4cidrStr || subnet.V4Gw != v4Gw || !subnet.V4Reserved.Equal(v4Reserved)) {
+			(subnet.V4CIDR.String() != v4cidrStr || !cidrsEqual(subnet.V4SecondaryCIDRs, v4Secondary) ||
+				subnet.V4Gw != v4Gw || !subnet.V4Reserved.Equal(v4Reserved)) {
 			_, cidr, _ := net.ParseCIDR(v4cidrStr)
 			subnet.V4CIDR = cidr
+			subnet.V4SecondaryCIDRs = v4Secondary
 			subnet.V4Reserved = v4Reserved
-			firstIP, _ := util.FirstIP(v4cidrStr)
-			lastIP, _ := util.LastIP(v4cidrStr)
-			ips, _ := NewIPRangeListFrom(fmt.Sprintf("%s..%s", firstIP, lastIP))
+			ips := cidrsRangeList(subnet.v4CIDRs())
 			subnet.V4Using = subnet.V4Using.Intersect(ips)
 			subnet.V4Free = ips.Separate(subnet.V4Reserved).Separate(subnet.V4Using)
 			subnet.V4Available = subnet.V4Free.Clone()
@@ -240,13 +287,13 @@ func (ipam *IPAM) AddOrUpdateSubnet(name, cidrStr, gw string, excludeIps []strin
 			}
 		}
 		if (protocol == kubeovnv1.ProtocolDual || protocol == kubeovnv1.ProtocolIPv6) &&
-			(subnet.V6CIDR.String() != v6cidrStr || subnet.V6Gw != v6Gw || !subnet.V6Reserved.Equal(v6Reserved)) {
+			(subnet.V6CIDR.String() != v6cidrStr || !cidrsEqual(subnet.V6SecondaryCIDRs, v6Secondary) ||
+				subnet.V6Gw != v6Gw || !subnet.V6Reserved.Equal(v6Reserved)) {
 			_, cidr, _ := net.ParseCIDR(v6cidrStr)
 			subnet.V6CIDR = cidr
+			subnet.V6SecondaryCIDRs = v6Secondary
 			subnet.V6Reserved = v6Reserved
-			firstIP, _ := util.FirstIP(v6cidrStr)
-			lastIP, _ := util.LastIP(v6cidrStr)
-			ips, _ := NewIPRangeListFrom(fmt.Sprintf("%s..%s", firstIP, lastIP))
+			ips := cidrsRangeList(subnet.v6CIDRs())
 			subnet.V6Using = subnet.V6Using.Intersect(ips)
 			subnet.V6Free = ips.Separate(subnet.V6Reserved).Separate(subnet.V6Using)
 			subnet.V6Available = subnet.V6Free.Clone()
@@ -292,10 +339,11 @@ func (ipam *IPAM) AddOrUpdateSubnet(name, cidrStr, gw string, excludeIps []strin
 				delete(subnet.MacToPod, mac)
 			}
 		}
+		ipam.recountQuotas(name)
 		return nil
 	}
 
-	subnet, err := NewSubnet(name, cidrStr, excludeIps)
+	subnet, err := NewSubnet(name, cidrStr, excludeIps, secondaryCIDRs...)
 	if err != nil {
 		klog.Errorf("failed to create subnet %s, %v", name, err)
 		return err
@@ -304,6 +352,33 @@ func (ipam *IPAM) AddOrUpdateSubnet(name, cidrStr, gw string, excludeIps []strin
 	subnet.V6Gw = v6Gw
 	klog.Infof("adding new subnet %s", name)
 	ipam.Subnets[name] = subnet
+	ipam.recountQuotas(name)
+	return nil
+}
+
+// SetSubnetAllocationStrategy sets how addresses are picked for random allocation in the subnet
+// and how long a released address is quarantined before it can be allocated again
+func (ipam *IPAM) SetSubnetAllocationStrategy(name, strategy string, cooldown time.Duration) error {
+	ipam.mutex.RLock()
+	defer ipam.mutex.RUnlock()
+
+	subnet, ok := ipam.Subnets[name]
+	if !ok {
+		return fmt.Errorf("subnet %s does not exist in IPAM", name)
+	}
+
+	subnet.mutex.Lock()
+	defer subnet.mutex.Unlock()
+	changed := subnet.AllocationStrategy != strategy || subnet.ReleaseCooldown != cooldown
+	if changed {
+		klog.Infof("set allocation strategy of subnet %s to %q with release cooldown %v", name, strategy, cooldown)
+	}
+	subnet.AllocationStrategy = strategy
+	subnet.ReleaseCooldown = cooldown
+	// the cooling addresses of a restored subnet are rebuilt once its strategy is known
+	if changed || subnet.cooling == nil {
+		subnet.resetCooling()
+	}
 	return nil
 }
 
@@ -312,6 +387,7 @@ func (ipam *IPAM) DeleteSubnet(subnetName string) {
 	defer ipam.mutex.Unlock()
 	klog.Infof("delete subnet %s", subnetName)
 	delete(ipam.Subnets, subnetName)
+	ipam.recountQuotas(subnetName)
 }
 
 func (ipam *IPAM) GetPodAddress(podName string) []*SubnetAddress {
@@ -365,6 +441,18 @@ func (ipam *IPAM) IsIPAssignedToOtherPod(ip, subnetName, podName string) (string
 	return subnet.isIPAssignedToOtherPod(ip, podName)
 }
 
+// CIDRStatistics returns the address usage of each CIDR block of the subnet
+func (ipam *IPAM) CIDRStatistics(subnetName string) []CIDRStatistics {
+	ipam.mutex.RLock()
+	defer ipam.mutex.RUnlock()
+
+	subnet, ok := ipam.Subnets[subnetName]
+	if !ok {
+		return nil
+	}
+	return subnet.CIDRStatistics()
+}
+
 func (ipam *IPAM) GetSubnetV4Mask(subnetName string) (string, error) {
 	subnet, ok := ipam.Subnets[subnetName]
 	if ok {
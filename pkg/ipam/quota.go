@@ -0,0 +1,182 @@
+package ipam
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+var ErrQuotaExceeded = errors.New("IPQuotaExceeded")
+
+// Quota caps the number of addresses the selected pods may hold in a subnet
+type Quota struct {
+	// mutex serializes the check and the allocation of the selected pods
+	mutex   sync.Mutex
+	Name    string
+	Subnet  string
+	V4Limit int
+	V6Limit int
+	// Selects reports whether the pod, keyed by namespace/name, is counted against the quota
+	Selects func(podName string) bool
+
+	// v4IPs and v6IPs are the addresses counted against the quota, which are
+	// updated on allocation and release and recounted when the quota or the subnet changes
+	v4IPs map[string]bool
+	v6IPs map[string]bool
+}
+
+func (ipam *IPAM) AddOrUpdateQuota(name, subnet string, v4Limit, v6Limit int, selects func(podName string) bool) {
+	ipam.mutex.Lock()
+	defer ipam.mutex.Unlock()
+
+	if q, ok := ipam.Quotas[name]; ok {
+		q.mutex.Lock()
+		q.Subnet, q.V4Limit, q.V6Limit, q.Selects = subnet, v4Limit, v6Limit, selects
+		q.recount(ipam.Subnets[subnet])
+		q.mutex.Unlock()
+		klog.Infof("update ip quota %s of subnet %s: v4 %d, v6 %d", name, subnet, v4Limit, v6Limit)
+		return
+	}
+
+	q := &Quota{Name: name, Subnet: subnet, V4Limit: v4Limit, V6Limit: v6Limit, Selects: selects}
+	q.recount(ipam.Subnets[subnet])
+	ipam.Quotas[name] = q
+	klog.Infof("add ip quota %s of subnet %s: v4 %d, v6 %d", name, subnet, v4Limit, v6Limit)
+}
+
+func (ipam *IPAM) DeleteQuota(name string) {
+	ipam.mutex.Lock()
+	defer ipam.mutex.Unlock()
+	klog.Infof("delete ip quota %s", name)
+	delete(ipam.Quotas, name)
+}
+
+// QuotaUsage returns the number of v4 and v6 addresses counted against the quota
+func (ipam *IPAM) QuotaUsage(name string) (int, int) {
+	ipam.mutex.RLock()
+	defer ipam.mutex.RUnlock()
+
+	q, ok := ipam.Quotas[name]
+	if !ok {
+		return 0, 0
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return len(q.v4IPs), len(q.v6IPs)
+}
+
+// recount counts the addresses of the subnet assigned to the selected pods from scratch, q.mutex must be held
+func (q *Quota) recount(subnet *Subnet) {
+	q.v4IPs, q.v6IPs = map[string]bool{}, map[string]bool{}
+	if subnet == nil {
+		return
+	}
+
+	subnet.mutex.RLock()
+	defer subnet.mutex.RUnlock()
+
+	selected := make(map[string]bool)
+	count := func(ipToPod map[string]string, ips map[string]bool) {
+		for ip, podName := range ipToPod {
+			ok, cached := selected[podName]
+			if !cached {
+				ok = q.Selects(podName)
+				selected[podName] = ok
+			}
+			if ok {
+				ips[ip] = true
+			}
+		}
+	}
+	count(subnet.V4IPToPod, q.v4IPs)
+	count(subnet.V6IPToPod, q.v6IPs)
+}
+
+// recountQuotas recounts the quotas of the subnet after its addresses are changed
+// other than by allocation and release. The caller must hold ipam.mutex.
+func (ipam *IPAM) recountQuotas(subnetName string) {
+	for _, q := range ipam.Quotas {
+		if q.Subnet == subnetName {
+			q.mutex.Lock()
+			q.recount(ipam.Subnets[subnetName])
+			q.mutex.Unlock()
+		}
+	}
+}
+
+// releaseQuotaAddresses stops counting the addresses released from the subnet. The caller must hold ipam.mutex.
+func (ipam *IPAM) releaseQuotaAddresses(subnetName string, ips []IP) {
+	if len(ips) == 0 {
+		return
+	}
+	for _, q := range ipam.Quotas {
+		if q.Subnet != subnetName {
+			continue
+		}
+		q.mutex.Lock()
+		for _, ip := range ips {
+			delete(q.v4IPs, ip.String())
+			delete(q.v6IPs, ip.String())
+		}
+		q.mutex.Unlock()
+	}
+}
+
+// acquireQuotas locks the quotas of the subnet the pod is counted against and checks
+// whether the nic can be allocated new addresses. The returned function counts the
+// allocated addresses, which are empty if the allocation fails, against the quotas and
+// unlocks them. It must be called once the allocation is done. The caller must hold ipam.mutex.
+func (ipam *IPAM) acquireQuotas(subnet *Subnet, podName, nicName string) (func(v4, v6 string), error) {
+	var quotas []*Quota
+	for _, q := range ipam.Quotas {
+		if q.Subnet == subnet.Name {
+			quotas = append(quotas, q)
+		}
+	}
+	if len(quotas) == 0 {
+		return func(string, string) {}, nil
+	}
+
+	// lock in a fixed order to avoid deadlocks between concurrent allocations
+	sort.Slice(quotas, func(i, j int) bool { return quotas[i].Name < quotas[j].Name })
+	var locked []*Quota
+	release := func(v4, v6 string) {
+		for _, q := range locked {
+			if v4 != "" {
+				q.v4IPs[v4] = true
+			}
+			if v6 != "" {
+				q.v6IPs[v6] = true
+			}
+			q.mutex.Unlock()
+		}
+	}
+
+	subnet.mutex.RLock()
+	needV4 := subnet.V4CIDR != nil && subnet.V4NicToIP[nicName] == nil
+	needV6 := subnet.V6CIDR != nil && subnet.V6NicToIP[nicName] == nil
+	subnet.mutex.RUnlock()
+
+	for _, q := range quotas {
+		q.mutex.Lock()
+		if !q.Selects(podName) {
+			q.mutex.Unlock()
+			continue
+		}
+		locked = append(locked, q)
+
+		if needV4 && q.V4Limit > 0 && len(q.v4IPs) >= q.V4Limit {
+			release("", "")
+			return nil, fmt.Errorf("%w: ip quota %s allows at most %d v4 addresses in subnet %s", ErrQuotaExceeded, q.Name, q.V4Limit, subnet.Name)
+		}
+		if needV6 && q.V6Limit > 0 && len(q.v6IPs) >= q.V6Limit {
+			release("", "")
+			return nil, fmt.Errorf("%w: ip quota %s allows at most %d v6 addresses in subnet %s", ErrQuotaExceeded, q.Name, q.V6Limit, subnet.Name)
+		}
+	}
+	return release, nil
+}
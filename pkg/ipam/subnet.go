@@ -3,8 +3,10 @@ package ipam
 import (
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"k8s.io/klog/v2"
 
@@ -38,10 +40,30 @@ type Subnet struct {
 	V4Gw         string
 	V6Gw         string
 
+	// secondary CIDR blocks share the address space of the subnet with the primary ones
+	V4SecondaryCIDRs []*net.IPNet
+	V6SecondaryCIDRs []*net.IPNet
+
 	IPPools map[string]*IPPool
+
+	AllocationStrategy string
+	ReleaseCooldown    time.Duration
+	// ReleaseTime records when an address was released and is cleared once the address is allocated again.
+	// Entries are pruned after the cooldown unless the least recently released strategy is used.
+	ReleaseTime map[string]time.Time
+
+	// cooling holds addresses released within the cooldown, and coolingQueue
+	// records the releases in time order so that they can be expired in turn
+	cooling      *IPRangeList
+	coolingQueue []releaseRecord
+}
+
+type releaseRecord struct {
+	ip   IP
+	time time.Time
 }
 
-func NewSubnet(name, cidrStr string, excludeIps []string) (*Subnet, error) {
+func NewSubnet(name, cidrStr string, excludeIps []string, secondaryCIDRs ...string) (*Subnet, error) {
 	var cidrs []*net.IPNet
 	for _, cidrBlock := range strings.Split(cidrStr, ",") {
 		_, cidr, err := net.ParseCIDR(cidrBlock)
@@ -51,8 +73,14 @@ func NewSubnet(name, cidrStr string, excludeIps []string) (*Subnet, error) {
 		cidrs = append(cidrs, cidr)
 	}
 
+	protocol := util.CheckProtocol(cidrStr)
+	v4Secondary, v6Secondary, err := parseSecondaryCIDRs(protocol, secondaryCIDRs)
+	if err != nil {
+		return nil, err
+	}
+
 	// subnet.Spec.ExcludeIps contains both v4 and v6 addresses
-	excludeIps = util.ExpandExcludeIPs(excludeIps, cidrStr)
+	excludeIps = util.ExpandExcludeIPs(excludeIps, strings.Join(append([]string{cidrStr}, secondaryCIDRs...), ","))
 	v4ExcludeIps, v6ExcludeIps := util.SplitIpsByProtocol(excludeIps)
 	v4Reserved, err := NewIPRangeListFrom(v4ExcludeIps...)
 	if err != nil {
@@ -63,48 +91,38 @@ func NewSubnet(name, cidrStr string, excludeIps []string) (*Subnet, error) {
 		return nil, err
 	}
 
-	protocol := util.CheckProtocol(cidrStr)
 	subnet := &Subnet{
-		Name:         name,
-		CIDR:         cidrStr,
-		Protocol:     protocol,
-		V4Free:       NewEmptyIPRangeList(),
-		V6Free:       NewEmptyIPRangeList(),
-		V4Reserved:   v4Reserved,
-		V6Reserved:   v6Reserved,
-		V4Using:      NewEmptyIPRangeList(),
-		V6Using:      NewEmptyIPRangeList(),
-		V4NicToIP:    map[string]IP{},
-		V6NicToIP:    map[string]IP{},
-		V4IPToPod:    map[string]string{},
-		V6IPToPod:    map[string]string{},
-		MacToPod:     map[string]string{},
-		NicToMac:     map[string]string{},
-		PodToNicList: map[string][]string{},
-		IPPools:      make(map[string]*IPPool, 0),
+		Name:             name,
+		CIDR:             cidrStr,
+		Protocol:         protocol,
+		V4Reserved:       v4Reserved,
+		V6Reserved:       v6Reserved,
+		V4Using:          NewEmptyIPRangeList(),
+		V6Using:          NewEmptyIPRangeList(),
+		V4NicToIP:        map[string]IP{},
+		V6NicToIP:        map[string]IP{},
+		V4IPToPod:        map[string]string{},
+		V6IPToPod:        map[string]string{},
+		MacToPod:         map[string]string{},
+		NicToMac:         map[string]string{},
+		PodToNicList:     map[string][]string{},
+		V4SecondaryCIDRs: v4Secondary,
+		V6SecondaryCIDRs: v6Secondary,
+		IPPools:          make(map[string]*IPPool, 0),
+		ReleaseTime:      map[string]time.Time{},
+		cooling:          NewEmptyIPRangeList(),
 	}
 	switch protocol {
 	case kubeovnv1.ProtocolIPv4:
-		firstIP, _ := util.FirstIP(cidrStr)
-		lastIP, _ := util.LastIP(cidrStr)
 		subnet.V4CIDR = cidrs[0]
-		subnet.V4Free, _ = NewIPRangeListFrom(fmt.Sprintf("%s..%s", firstIP, lastIP))
 	case kubeovnv1.ProtocolIPv6:
-		firstIP, _ := util.FirstIP(cidrStr)
-		lastIP, _ := util.LastIP(cidrStr)
 		subnet.V6CIDR = cidrs[0]
-		subnet.V6Free, _ = NewIPRangeListFrom(fmt.Sprintf("%s..%s", firstIP, lastIP))
 	default:
 		subnet.V4CIDR = cidrs[0]
 		subnet.V6CIDR = cidrs[1]
-		cidrBlocks := strings.Split(cidrStr, ",")
-		v4FirstIP, _ := util.FirstIP(cidrBlocks[0])
-		v4LastIP, _ := util.LastIP(cidrBlocks[0])
-		v6FirstIP, _ := util.FirstIP(cidrBlocks[1])
-		v6LastIP, _ := util.LastIP(cidrBlocks[1])
-		subnet.V4Free, _ = NewIPRangeListFrom(fmt.Sprintf("%s..%s", v4FirstIP, v4LastIP))
-		subnet.V6Free, _ = NewIPRangeListFrom(fmt.Sprintf("%s..%s", v6FirstIP, v6LastIP))
 	}
+	subnet.V4Free = cidrsRangeList(subnet.v4CIDRs())
+	subnet.V6Free = cidrsRangeList(subnet.v6CIDRs())
 
 	pool := &IPPool{
 		V4IPs:      subnet.V4Free.Clone(),
@@ -129,6 +147,76 @@ func NewSubnet(name, cidrStr string, excludeIps []string) (*Subnet, error) {
 	return subnet, nil
 }
 
+// parseSecondaryCIDRs parses the secondary CIDR blocks and splits them by protocol
+func parseSecondaryCIDRs(protocol string, cidrBlocks []string) (v4, v6 []*net.IPNet, err error) {
+	for _, cidrBlock := range cidrBlocks {
+		_, cidr, err := net.ParseCIDR(cidrBlock)
+		if err != nil {
+			return nil, nil, ErrInvalidCIDR
+		}
+		p := util.CheckProtocol(cidrBlock)
+		if protocol != kubeovnv1.ProtocolDual && protocol != p {
+			return nil, nil, fmt.Errorf("secondary cidr %s does not match the protocol %s of the subnet", cidrBlock, protocol)
+		}
+		if p == kubeovnv1.ProtocolIPv4 {
+			v4 = append(v4, cidr)
+		} else {
+			v6 = append(v6, cidr)
+		}
+	}
+	return v4, v6, nil
+}
+
+// cidrsRangeList returns the assignable addresses in the CIDR blocks
+func cidrsRangeList(cidrs []*net.IPNet) *IPRangeList {
+	ret := NewEmptyIPRangeList()
+	for _, cidr := range cidrs {
+		firstIP, _ := util.FirstIP(cidr.String())
+		lastIP, _ := util.LastIP(cidr.String())
+		if ips, err := NewIPRangeListFrom(fmt.Sprintf("%s..%s", firstIP, lastIP)); err == nil {
+			ret = ret.Merge(ips)
+		}
+	}
+	return ret
+}
+
+func cidrsContain(cidrs []*net.IPNet, ip IP) bool {
+	for _, cidr := range cidrs {
+		if cidr.Contains(net.IP(ip)) {
+			return true
+		}
+	}
+	return false
+}
+
+func cidrsEqual(a, b []*net.IPNet) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].String() != b[i].String() {
+			return false
+		}
+	}
+	return true
+}
+
+// v4CIDRs returns the primary IPv4 CIDR block followed by the secondary ones
+func (s *Subnet) v4CIDRs() []*net.IPNet {
+	if s.V4CIDR == nil {
+		return nil
+	}
+	return append([]*net.IPNet{s.V4CIDR}, s.V4SecondaryCIDRs...)
+}
+
+// v6CIDRs returns the primary IPv6 CIDR block followed by the secondary ones
+func (s *Subnet) v6CIDRs() []*net.IPNet {
+	if s.V6CIDR == nil {
+		return nil
+	}
+	return append([]*net.IPNet{s.V6CIDR}, s.V6SecondaryCIDRs...)
+}
+
 func (s *Subnet) GetRandomMac(podName, nicName string) string {
 	if mac, ok := s.NicToMac[nicName]; ok {
 		return mac
@@ -222,24 +310,16 @@ func (s *Subnet) getV4RandomAddress(ippoolName, podName, nicName string, mac *st
 		return nil, nil, "", ErrNoAvailable
 	}
 
-	if pool.V4Free.Len() == 0 {
-		if pool.V4Released.Len() == 0 {
-			return nil, nil, "", ErrNoAvailable
-		}
-		pool.V4Free = pool.V4Released
-		pool.V4Released = NewEmptyIPRangeList()
-	}
-
 	skipped := make([]IP, 0, len(skippedAddrs))
 	for _, s := range skippedAddrs {
 		if ip, _ := NewIP(s); ip != nil {
 			skipped = append(skipped, ip)
 		}
 	}
-	ip := pool.V4Free.Allocate(skipped)
-	if ip == nil {
-		klog.Errorf("no free v4 ip in ip pool %s", ippoolName)
-		return nil, nil, "", ErrConflict
+	ip, err := s.allocate(&pool.V4Free, &pool.V4Released, skipped)
+	if err != nil {
+		klog.Errorf("no free v4 ip in ip pool %s: %v", ippoolName, err)
+		return nil, nil, "", err
 	}
 
 	pool.V4Available.Remove(ip)
@@ -276,24 +356,16 @@ func (s *Subnet) getV6RandomAddress(ippoolName, podName, nicName string, mac *st
 		return nil, nil, "", ErrNoAvailable
 	}
 
-	if pool.V6Free.Len() == 0 {
-		if pool.V6Released.Len() == 0 {
-			return nil, nil, "", ErrNoAvailable
-		}
-		pool.V6Free = pool.V6Released
-		pool.V6Released = NewEmptyIPRangeList()
-	}
-
 	skipped := make([]IP, 0, len(skippedAddrs))
 	for _, s := range skippedAddrs {
 		if ip, _ := NewIP(s); ip != nil {
 			skipped = append(skipped, ip)
 		}
 	}
-	ip := pool.V6Free.Allocate(skipped)
-	if ip == nil {
-		klog.Errorf("no free v6 ip in ip pool %s", ippoolName)
-		return nil, nil, "", ErrConflict
+	ip, err := s.allocate(&pool.V6Free, &pool.V6Released, skipped)
+	if err != nil {
+		klog.Errorf("no free v6 ip in ip pool %s: %v", ippoolName, err)
+		return nil, nil, "", err
 	}
 
 	pool.V6Available.Remove(ip)
@@ -314,6 +386,162 @@ func (s *Subnet) getV6RandomAddress(ippoolName, podName, nicName string, mac *st
 	return nil, ip, *mac, nil
 }
 
+// allocate picks an address from the free list, or from the released list
+// once the free list is exhausted, according to the allocation strategy of
+// the subnet. Addresses released within the cooldown are never picked.
+func (s *Subnet) allocate(free, released **IPRangeList, skipped []IP) (IP, error) {
+	if s.cooling == nil {
+		s.resetCooling()
+	}
+	s.expireCooling(time.Now())
+	excluded := s.cooling
+	if len(skipped) != 0 {
+		excluded = excluded.Clone()
+		for _, ip := range skipped {
+			excluded.Add(ip)
+		}
+	}
+
+	fromReleased := false
+	candidates := separate(*free, excluded)
+	if candidates.Len() == 0 {
+		fromReleased = true
+		candidates = separate(*released, excluded)
+	}
+	if candidates.Len() == 0 {
+		if len(skipped) == 0 {
+			return nil, ErrNoAvailable
+		}
+		return nil, ErrConflict
+	}
+
+	var ip IP
+	switch s.AllocationStrategy {
+	case kubeovnv1.IPAllocationStrategyRandom:
+		ip = candidates.Random()
+	case kubeovnv1.IPAllocationStrategyLeastRecentlyReleased:
+		if fromReleased {
+			ip = s.leastRecentlyReleased(candidates)
+		} else {
+			ip = candidates.At(0).Start()
+		}
+	default:
+		ip = candidates.At(0).Start()
+	}
+
+	if fromReleased {
+		(*released).Remove(ip)
+	} else {
+		(*free).Remove(ip)
+	}
+	s.forgetRelease(ip)
+	return ip, nil
+}
+
+// separate returns addresses in r but not in x without copying r when x is empty
+func separate(r, x *IPRangeList) *IPRangeList {
+	if x.Len() == 0 {
+		return r
+	}
+	return r.Separate(x)
+}
+
+// keepReleaseTime reports whether release times are still needed after the cooldown
+func (s *Subnet) keepReleaseTime() bool {
+	return s.AllocationStrategy == kubeovnv1.IPAllocationStrategyLeastRecentlyReleased
+}
+
+// recordRelease records the release time of ip and starts its cooldown
+func (s *Subnet) recordRelease(ip IP) {
+	if s.ReleaseCooldown <= 0 && !s.keepReleaseTime() {
+		return
+	}
+	now := time.Now()
+	s.ReleaseTime[ip.String()] = now
+	if s.ReleaseCooldown > 0 && s.cooling != nil {
+		s.cooling.Add(ip)
+		s.coolingQueue = append(s.coolingQueue, releaseRecord{ip: ip, time: now})
+	}
+}
+
+// forgetRelease clears the release time of an allocated address
+func (s *Subnet) forgetRelease(ip IP) {
+	delete(s.ReleaseTime, ip.String())
+	if s.cooling != nil {
+		s.cooling.Remove(ip)
+	}
+}
+
+// expireCooling ends the cooldown of addresses released before now minus the
+// cooldown and prunes their release times if they are no longer needed
+func (s *Subnet) expireCooling(now time.Time) {
+	var i int
+	for ; i < len(s.coolingQueue); i++ {
+		r := s.coolingQueue[i]
+		if now.Sub(r.time) < s.ReleaseCooldown {
+			break
+		}
+		// skip records of addresses which have been allocated or released again since then
+		if t, ok := s.ReleaseTime[r.ip.String()]; ok && t.Equal(r.time) {
+			s.cooling.Remove(r.ip)
+			if !s.keepReleaseTime() {
+				delete(s.ReleaseTime, r.ip.String())
+			}
+		}
+	}
+	if i != 0 {
+		s.coolingQueue = append(s.coolingQueue[:0:0], s.coolingQueue[i:]...)
+	}
+}
+
+// resetCooling rebuilds the cooling addresses from the release times, which is
+// required after the release times are restored or the cooldown is changed
+func (s *Subnet) resetCooling() {
+	s.cooling = NewEmptyIPRangeList()
+	s.coolingQueue = s.coolingQueue[:0]
+	if s.ReleaseCooldown > 0 {
+		for ipStr, t := range s.ReleaseTime {
+			ip, err := NewIP(ipStr)
+			if err != nil {
+				delete(s.ReleaseTime, ipStr)
+				continue
+			}
+			s.cooling.Add(ip)
+			s.coolingQueue = append(s.coolingQueue, releaseRecord{ip: ip, time: t})
+		}
+		sort.Slice(s.coolingQueue, func(i, j int) bool {
+			return s.coolingQueue[i].time.Before(s.coolingQueue[j].time)
+		})
+	}
+	s.expireCooling(time.Now())
+	if s.ReleaseCooldown <= 0 && !s.keepReleaseTime() {
+		clear(s.ReleaseTime)
+	}
+}
+
+// leastRecentlyReleased returns the address in candidates which was released
+// the earliest. Addresses without a release time, e.g. released before the
+// controller restarted, are considered the oldest ones.
+func (s *Subnet) leastRecentlyReleased(candidates *IPRangeList) IP {
+	recorded := NewEmptyIPRangeList()
+	var ret IP
+	var oldest time.Time
+	for ipStr, t := range s.ReleaseTime {
+		ip, err := NewIP(ipStr)
+		if err != nil || !candidates.Contains(ip) {
+			continue
+		}
+		recorded.Add(ip)
+		if ret == nil || t.Before(oldest) || (t.Equal(oldest) && ip.LessThan(ret)) {
+			ret, oldest = ip, t
+		}
+	}
+	if unrecorded := candidates.Separate(recorded); unrecorded.Len() != 0 {
+		return unrecorded.At(0).Start()
+	}
+	return ret
+}
+
 func (s *Subnet) GetStaticAddress(podName, nicName string, ip IP, mac *string, force, checkConflict bool) (IP, string, error) {
 	var v4, v6 bool
 	isAllocated := false
@@ -325,10 +553,10 @@ func (s *Subnet) GetStaticAddress(podName, nicName string, ip IP, mac *string, f
 	} else {
 		v6 = s.V6CIDR != nil
 	}
-	if v4 && !s.V4CIDR.Contains(net.IP(ip)) {
+	if v4 && !cidrsContain(s.v4CIDRs(), ip) {
 		return ip, "", ErrOutOfRange
 	}
-	if v6 && !s.V6CIDR.Contains(net.IP(ip)) {
+	if v6 && !cidrsContain(s.v6CIDRs(), ip) {
 		return ip, "", ErrOutOfRange
 	}
 
@@ -403,11 +631,13 @@ func (s *Subnet) GetStaticAddress(podName, nicName string, ip IP, mac *string, f
 			s.V4Free.Remove(ip)
 			s.V4NicToIP[nicName] = ip
 			s.V4IPToPod[ip.String()] = podName
+			s.forgetRelease(ip)
 			isAllocated = true
 			return ip, macStr, nil
 		} else if pool.V4Released.Remove(ip) {
 			s.V4NicToIP[nicName] = ip
 			s.V4IPToPod[ip.String()] = podName
+			s.forgetRelease(ip)
 			isAllocated = true
 			return ip, macStr, nil
 		}
@@ -438,11 +668,13 @@ func (s *Subnet) GetStaticAddress(podName, nicName string, ip IP, mac *string, f
 			s.V6Free.Remove(ip)
 			s.V6NicToIP[nicName] = ip
 			s.V6IPToPod[ip.String()] = podName
+			s.forgetRelease(ip)
 			isAllocated = true
 			return ip, macStr, nil
 		} else if pool.V6Released.Remove(ip) {
 			s.V6NicToIP[nicName] = ip
 			s.V6IPToPod[ip.String()] = podName
+			s.forgetRelease(ip)
 			isAllocated = true
 			return ip, macStr, nil
 		}
@@ -450,7 +682,8 @@ func (s *Subnet) GetStaticAddress(podName, nicName string, ip IP, mac *string, f
 	return ip, macStr, ErrNoAvailable
 }
 
-func (s *Subnet) releaseAddr(podName, nicName string) {
+// releaseAddr releases the addresses of the nic and returns those no longer assigned to any pod
+func (s *Subnet) releaseAddr(podName, nicName string) (released []IP) {
 	var ip IP
 	var mac string
 	var ok, changed bool
@@ -462,13 +695,14 @@ func (s *Subnet) releaseAddr(podName, nicName string) {
 		} else {
 			delete(s.V4NicToIP, nicName)
 			delete(s.V4IPToPod, ip.String())
+			released = append(released, ip)
 			if mac, ok = s.NicToMac[nicName]; ok {
 				delete(s.NicToMac, nicName)
 				delete(s.MacToPod, mac)
 			}
 
 			// When CIDR changed, do not relocate ip to CIDR list
-			if !s.V4CIDR.Contains(net.IP(ip)) {
+			if !cidrsContain(s.v4CIDRs(), ip) {
 				// Continue to release IPv6 address
 				klog.Infof("release v4 %s mac %s from subnet %s for %s, ignore ip", ip, mac, s.Name, podName)
 				changed = true
@@ -485,6 +719,7 @@ func (s *Subnet) releaseAddr(podName, nicName string) {
 				if pool.V4Using.Remove(ip) {
 					pool.V4Available.Add(ip)
 					if !changed {
+						s.recordRelease(ip)
 						if pool.V4Released.Add(ip) {
 							klog.Infof("release v4 %s mac %s from subnet %s for %s, add ip to released list", ip, mac, s.Name, podName)
 						}
@@ -502,13 +737,14 @@ func (s *Subnet) releaseAddr(podName, nicName string) {
 		} else {
 			delete(s.V6NicToIP, nicName)
 			delete(s.V6IPToPod, ip.String())
+			released = append(released, ip)
 			if mac, ok = s.NicToMac[nicName]; ok {
 				delete(s.NicToMac, nicName)
 				delete(s.MacToPod, mac)
 			}
 			changed = false
 			// When CIDR changed, do not relocate ip to CIDR list
-			if !s.V6CIDR.Contains(net.IP(ip)) {
+			if !cidrsContain(s.v6CIDRs(), ip) {
 				klog.Infof("release v6 %s mac %s from subnet %s for %s, ignore ip", ip, mac, s.Name, podName)
 				changed = true
 			}
@@ -524,6 +760,7 @@ func (s *Subnet) releaseAddr(podName, nicName string) {
 				if pool.V6Using.Remove(ip) {
 					pool.V6Available.Add(ip)
 					if !changed {
+						s.recordRelease(ip)
 						if pool.V6Released.Add(ip) {
 							klog.Infof("release v6 %s mac %s from subnet %s for %s, add ip to released list", ip, mac, s.Name, podName)
 						}
@@ -533,23 +770,27 @@ func (s *Subnet) releaseAddr(podName, nicName string) {
 			}
 		}
 	}
+	return released
 }
 
-func (s *Subnet) ReleaseAddress(podName string) {
+func (s *Subnet) ReleaseAddress(podName string) []IP {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
+	var released []IP
 	for _, nicName := range s.PodToNicList[podName] {
-		s.releaseAddr(podName, nicName)
+		released = append(released, s.releaseAddr(podName, nicName)...)
 		s.popPodNic(podName, nicName)
 	}
+	return released
 }
 
-func (s *Subnet) ReleaseAddressWithNicName(podName, nicName string) {
+func (s *Subnet) ReleaseAddressWithNicName(podName, nicName string) []IP {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	s.releaseAddr(podName, nicName)
+	released := s.releaseAddr(podName, nicName)
 	s.popPodNic(podName, nicName)
+	return released
 }
 
 func (s *Subnet) ContainAddress(address IP) bool {
@@ -631,12 +872,9 @@ func (s *Subnet) AddOrUpdateIPPool(name string, ips []string) error {
 			}
 		}
 
-		firstIP, _ := util.FirstIP(s.V4CIDR.String())
-		lastIP, _ := util.LastIP(s.V4CIDR.String())
 		pool.V4Reserved = s.V4Reserved.Intersect(pool.V4IPs)
 		pool.V4Using = s.V4Using.Intersect(pool.V4IPs)
-		pool.V4Free, _ = NewIPRangeListFrom(fmt.Sprintf("%s..%s", firstIP, lastIP))
-		pool.V4Free = pool.V4Free.Intersect(pool.V4IPs).Separate(pool.V4Using).Separate(pool.V4Reserved)
+		pool.V4Free = cidrsRangeList(s.v4CIDRs()).Intersect(pool.V4IPs).Separate(pool.V4Using).Separate(pool.V4Reserved)
 	}
 	if s.V6CIDR != nil {
 		if pool.V6IPs, err = NewIPRangeListFrom(v6IPs...); err != nil {
@@ -651,12 +889,9 @@ func (s *Subnet) AddOrUpdateIPPool(name string, ips []string) error {
 			}
 		}
 
-		firstIP, _ := util.FirstIP(s.V6CIDR.String())
-		lastIP, _ := util.LastIP(s.V6CIDR.String())
 		pool.V6Reserved = s.V6Reserved.Intersect(pool.V6IPs)
 		pool.V6Using = s.V6Using.Intersect(pool.V6IPs)
-		pool.V6Free, _ = NewIPRangeListFrom(fmt.Sprintf("%s..%s", firstIP, lastIP))
-		pool.V6Free = pool.V6Free.Intersect(pool.V6IPs).Separate(pool.V6Using).Separate(pool.V6Reserved)
+		pool.V6Free = cidrsRangeList(s.v6CIDRs()).Intersect(pool.V6IPs).Separate(pool.V6Using).Separate(pool.V6Reserved)
 	}
 
 	defaultPool := s.IPPools[""]
@@ -739,3 +974,39 @@ func (s *Subnet) IPPoolStatistics(ippool string) (
 
 	return
 }
+
+// CIDRStatistics holds the address usage of a single CIDR block of the subnet
+type CIDRStatistics struct {
+	CIDR      string
+	Gateway   string
+	Secondary bool
+	Available internal.BigInt
+	Using     internal.BigInt
+}
+
+func (s *Subnet) CIDRStatistics() []CIDRStatistics {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var ret []CIDRStatistics
+	stat := func(cidr *net.IPNet, gw string, secondary bool, available, using *IPRangeList) {
+		ips := cidrsRangeList([]*net.IPNet{cidr})
+		if secondary {
+			gw, _ = util.FirstIP(cidr.String())
+		}
+		ret = append(ret, CIDRStatistics{
+			CIDR:      cidr.String(),
+			Gateway:   gw,
+			Secondary: secondary,
+			Available: available.Intersect(ips).Count(),
+			Using:     using.Intersect(ips).Count(),
+		})
+	}
+	for i, cidr := range s.v4CIDRs() {
+		stat(cidr, s.V4Gw, i != 0, s.V4Available, s.V4Using)
+	}
+	for i, cidr := range s.v6CIDRs() {
+		stat(cidr, s.V6Gw, i != 0, s.V6Available, s.V6Using)
+	}
+	return ret
+}
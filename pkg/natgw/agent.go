@@ -0,0 +1,368 @@
+package natgw
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// maxRulesPerExec limits the length of the command line of nat-gateway.sh
+const maxRulesPerExec = 256
+
+// Executor runs an operation of nat-gateway.sh
+type Executor interface {
+	Exec(operation string, rules ...string) (string, error)
+}
+
+type scriptExecutor struct {
+	script string
+}
+
+// NewScriptExecutor returns an executor running the given script with bash
+func NewScriptExecutor(script string) Executor {
+	return &scriptExecutor{script: script}
+}
+
+func (e *scriptExecutor) Exec(operation string, rules ...string) (string, error) {
+	args := append([]string{e.script, operation}, rules...)
+	output, err := exec.Command("bash", args...).CombinedOutput() // #nosec G204
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return string(output), &ExecError{Operation: operation, ExitCode: exitErr.ExitCode(), Output: string(output)}
+		}
+		return string(output), err
+	}
+	return string(output), nil
+}
+
+// persistedState is saved on disk so that a restarted agent knows the rules applied in the pod
+type persistedState struct {
+	Generation int64                             `json:"generation"`
+	Applied    map[RuleKind][]string             `json:"applied,omitempty"`
+	Draining   map[RuleKind]map[string]time.Time `json:"draining,omitempty"`
+}
+
+// Agent applies rules in the nat gateway pod
+type Agent struct {
+	executor   Executor
+	stateFile  string
+	haRoleFile string
+
+	mutex       sync.Mutex
+	randomFully *bool
+	generation  int64
+	applied     map[RuleKind]map[string]struct{}
+	errors      map[RuleKind]map[string]string
+	draining    map[RuleKind]map[string]*drainingRule
+}
+
+// NewAgent creates an agent and restores the applied state from stateFile if it exists,
+// haRoleFile is where the role of the instance is written in active/standby mode
+func NewAgent(executor Executor, stateFile, haRoleFile string) (*Agent, error) {
+	a := &Agent{
+		executor:   executor,
+		stateFile:  stateFile,
+		haRoleFile: haRoleFile,
+		applied:    make(map[RuleKind]map[string]struct{}, len(ruleKinds)),
+		errors:     make(map[RuleKind]map[string]string, len(ruleKinds)),
+		draining:   make(map[RuleKind]map[string]*drainingRule, len(ruleKinds)),
+	}
+	for _, kind := range ruleKinds {
+		a.applied[kind] = make(map[string]struct{})
+	}
+	if stateFile == "" {
+		return a, nil
+	}
+
+	data, err := os.ReadFile(stateFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return a, nil
+		}
+		klog.Errorf("failed to read state file %s: %v", stateFile, err)
+		return nil, err
+	}
+	var state persistedState
+	if err = json.Unmarshal(data, &state); err != nil {
+		klog.Errorf("failed to parse state file %s: %v", stateFile, err)
+		return nil, err
+	}
+	a.generation = state.Generation
+	for kind, rules := range state.Applied {
+		if a.applied[kind] == nil {
+			continue
+		}
+		for _, rule := range rules {
+			a.applied[kind][rule] = struct{}{}
+		}
+	}
+	// the drain periods continue after the agent restarts
+	a.mutex.Lock()
+	for kind, rules := range state.Draining {
+		for rule, deadline := range rules {
+			a.startDrain(kind, rule, deadline)
+		}
+	}
+	a.mutex.Unlock()
+	return a, nil
+}
+
+// State returns the applied state
+func (a *Agent) State() *State {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.state(nil)
+}
+
+// HAStatus returns the role of the instance written by keepalived,
+// it does not wait for the running rule sync since failover must be reported in time
+func (a *Agent) HAStatus() (*HAStatus, error) {
+	if a.haRoleFile == "" {
+		return &HAStatus{}, nil
+	}
+	data, err := os.ReadFile(a.haRoleFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// keepalived has not made any transition yet
+			return &HAStatus{}, nil
+		}
+		klog.Errorf("failed to read ha role file %s: %v", a.haRoleFile, err)
+		return nil, err
+	}
+	return &HAStatus{Role: strings.TrimSpace(string(data))}, nil
+}
+
+// Sync applies the desired rule set: stale rules are deleted and missing rules are added.
+// Rules applied successfully are not applied again, so the same request can be sent repeatedly.
+func (a *Agent) Sync(req *SyncRequest) (*State, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if req.Generation < a.generation {
+		return nil, &Error{
+			Code:       http.StatusConflict,
+			Message:    fmt.Sprintf("generation %d is older than the applied generation %d", req.Generation, a.generation),
+			Generation: a.generation,
+		}
+	}
+
+	// the chains of the rules are created once, which is skipped if they exist
+	if _, err := a.executor.Exec("init"); err != nil {
+		klog.Errorf("failed to init nat gateway: %v", err)
+		return nil, &Error{Code: http.StatusInternalServerError, Message: fmt.Sprintf("failed to init nat gateway: %v", err)}
+	}
+
+	desired := req.Rules.Rules()
+	drains := req.Deleted.drainSeconds()
+	a.errors = make(map[RuleKind]map[string]string, len(ruleKinds))
+	for i := len(ruleKinds) - 1; i >= 0; i-- {
+		kind := ruleKinds[i]
+		want := make(map[string]struct{}, len(desired[kind]))
+		for _, rule := range desired[kind] {
+			want[rule] = struct{}{}
+		}
+		var stale []string
+		for rule := range a.applied[kind] {
+			if _, ok := want[rule]; !ok {
+				stale = append(stale, rule)
+			}
+		}
+		sort.Strings(stale)
+		failed := a.apply(kind.delOperation(), stale, stale)
+		for _, rule := range stale {
+			if msg, ok := failed[rule]; ok {
+				a.setError(kind, rule, "failed to delete rule: "+msg)
+				continue
+			}
+			delete(a.applied[kind], rule)
+			a.releaseSessions(kind, rule, drains[kind][rule])
+		}
+	}
+
+	for _, kind := range ruleKinds {
+		var missing, args []string
+		seen := make(map[string]struct{}, len(desired[kind]))
+		for _, rule := range desired[kind] {
+			if _, ok := seen[rule]; ok {
+				continue
+			}
+			seen[rule] = struct{}{}
+			if _, ok := a.applied[kind][rule]; ok {
+				continue
+			}
+			missing = append(missing, rule)
+			args = append(args, a.addArg(kind, rule))
+		}
+		failed := a.apply(kind.addOperation(), missing, args)
+		for _, rule := range missing {
+			if msg, ok := failed[rule]; ok {
+				a.setError(kind, rule, msg)
+				continue
+			}
+			a.applied[kind][rule] = struct{}{}
+			a.stopDrain(kind, rule)
+		}
+	}
+
+	a.generation = req.Generation
+	if err := a.save(); err != nil {
+		return nil, err
+	}
+	return a.state(desired), nil
+}
+
+// apply runs the operation with the rules in batches. If a batch fails, the rules of the batch are
+// applied one by one to find out the failed ones, which works since the operations are idempotent.
+func (a *Agent) apply(operation string, rules, args []string) map[string]string {
+	failed := make(map[string]string)
+	for start := 0; start < len(rules); start += maxRulesPerExec {
+		end := start + maxRulesPerExec
+		if end > len(rules) {
+			end = len(rules)
+		}
+		if _, err := a.executor.Exec(operation, args[start:end]...); err == nil {
+			continue
+		}
+		for i := start; i < end; i++ {
+			if _, err := a.executor.Exec(operation, args[i]); err != nil {
+				klog.Errorf("failed to exec %s %s: %v", operation, args[i], err)
+				failed[rules[i]] = err.Error()
+			}
+		}
+	}
+	return failed
+}
+
+func (a *Agent) addArg(kind RuleKind, rule string) string {
+	if kind == RuleKindSNAT && a.supportRandomFully() {
+		return rule + ",--random-fully"
+	}
+	return rule
+}
+
+var iptablesVersionRegexp = regexp.MustCompile(`v([0-9]+)\.([0-9]+)\.([0-9]+)`)
+
+// supportRandomFully checks whether iptables supports --random-fully, which requires v1.6.2 or later
+func (a *Agent) supportRandomFully() bool {
+	if a.randomFully != nil {
+		return *a.randomFully
+	}
+
+	output, err := a.executor.Exec("get-iptables-version")
+	if err != nil {
+		klog.Warningf("failed to check iptables version, assuming --random-fully is not supported: %v", err)
+		return false
+	}
+	supported := iptablesVersionAtLeast(output, 1, 6, 2)
+	a.randomFully = &supported
+	return supported
+}
+
+func iptablesVersionAtLeast(output string, version ...int) bool {
+	match := iptablesVersionRegexp.FindStringSubmatch(output)
+	if match == nil {
+		return false
+	}
+	for i, v := range version {
+		n, _ := strconv.Atoi(match[i+1])
+		if n != v {
+			return n > v
+		}
+	}
+	return true
+}
+
+func (a *Agent) setError(kind RuleKind, rule, msg string) {
+	if a.errors[kind] == nil {
+		a.errors[kind] = make(map[string]string)
+	}
+	a.errors[kind][rule] = strings.TrimSpace(msg)
+}
+
+// state reports the applied rules together with the failed ones
+func (a *Agent) state(desired map[RuleKind][]string) *State {
+	state := &State{Generation: a.generation}
+	for _, kind := range ruleKinds {
+		rules := make(map[string]struct{}, len(a.applied[kind]))
+		for rule := range a.applied[kind] {
+			rules[rule] = struct{}{}
+		}
+		for rule := range a.errors[kind] {
+			rules[rule] = struct{}{}
+		}
+		for _, rule := range desired[kind] {
+			rules[rule] = struct{}{}
+		}
+		sorted := make([]string, 0, len(rules))
+		for rule := range rules {
+			sorted = append(sorted, rule)
+		}
+		sort.Strings(sorted)
+		for _, rule := range sorted {
+			_, applied := a.applied[kind][rule]
+			state.Rules = append(state.Rules, RuleStatus{Kind: kind, Rule: rule, Applied: applied, Error: a.errors[kind][rule]})
+		}
+		draining := make([]string, 0, len(a.draining[kind]))
+		for rule := range a.draining[kind] {
+			draining = append(draining, rule)
+		}
+		sort.Strings(draining)
+		for _, rule := range draining {
+			state.Draining = append(state.Draining, DrainingRule{Kind: kind, Rule: rule, Deadline: a.draining[kind][rule].deadline})
+		}
+	}
+	return state
+}
+
+func (a *Agent) save() error {
+	if a.stateFile == "" {
+		return nil
+	}
+
+	state := persistedState{Generation: a.generation, Applied: make(map[RuleKind][]string, len(ruleKinds))}
+	for kind, rules := range a.applied {
+		for rule := range rules {
+			state.Applied[kind] = append(state.Applied[kind], rule)
+		}
+		sort.Strings(state.Applied[kind])
+	}
+	for kind, rules := range a.draining {
+		for rule, d := range rules {
+			if state.Draining == nil {
+				state.Draining = make(map[RuleKind]map[string]time.Time)
+			}
+			if state.Draining[kind] == nil {
+				state.Draining[kind] = make(map[string]time.Time)
+			}
+			state.Draining[kind][rule] = d.deadline
+		}
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	tmp := filepath.Join(filepath.Dir(a.stateFile), "."+filepath.Base(a.stateFile)+".tmp")
+	if err = os.WriteFile(tmp, data, 0o600); err != nil {
+		klog.Errorf("failed to write state file %s: %v", tmp, err)
+		return err
+	}
+	if err = os.Rename(tmp, a.stateFile); err != nil {
+		klog.Errorf("failed to rename %s to %s: %v", tmp, a.stateFile, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+package natgw
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeExecutor struct {
+	version string
+	initErr error
+	failed  map[string]bool
+	calls   []string
+}
+
+func (e *fakeExecutor) Exec(operation string, rules ...string) (string, error) {
+	switch operation {
+	case "get-iptables-version":
+		return e.version, nil
+	case "init":
+		return "", e.initErr
+	}
+	e.calls = append(e.calls, operation+" "+strings.Join(rules, " "))
+	for _, rule := range rules {
+		if e.failed[rule] {
+			return "failed to exec", &ExecError{Operation: operation, ExitCode: 1, Output: "failed to exec"}
+		}
+	}
+	return "", nil
+}
+
+func TestAgentSync(t *testing.T) {
+	t.Parallel()
+
+	executor := &fakeExecutor{version: "iptables v1.8.9 (nf_tables)", failed: map[string]bool{"10.0.0.3,10.16.0.3": true}}
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	agent, err := NewAgent(executor, stateFile, "")
+	require.NoError(t, err)
+
+	rules := RuleSet{
+		ExternalRoutes: []Route{{CIDR: "10.0.0.0/24", NextHop: "10.0.0.1"}},
+		Routes:         []Route{{CIDR: "10.96.0.0/12", NextHop: "10.16.0.1"}},
+		EIPs:           []EIP{{CIDR: "10.0.0.2/24", Gateway: "10.0.0.1"}, {CIDR: "10.0.0.3/24", Gateway: "10.0.0.1"}},
+		FloatingIPs:    []FloatingIP{{EIP: "10.0.0.2", InternalIP: "10.16.0.2"}, {EIP: "10.0.0.3", InternalIP: "10.16.0.3"}},
+		SNATs:          []SNAT{{EIP: "10.0.0.2", InternalCIDR: "10.16.0.0/16"}},
+		EIPIngressQoS:  []EIPQoS{{EIP: "10.0.0.2", Priority: 1, Rate: "10", Burst: "10"}},
+	}
+	state, err := agent.Sync(&SyncRequest{Generation: 1, Rules: rules})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), state.Generation)
+	require.Equal(t, []string{
+		"ext-subnet-route-add 10.0.0.0/24,10.0.0.1",
+		"subnet-route-add 10.96.0.0/12,10.16.0.1",
+		"eip-add 10.0.0.2/24,10.0.0.1 10.0.0.3/24,10.0.0.1",
+		"floating-ip-add 10.0.0.2,10.16.0.2 10.0.0.3,10.16.0.3",
+		"floating-ip-add 10.0.0.2,10.16.0.2",
+		"floating-ip-add 10.0.0.3,10.16.0.3",
+		"snat-add 10.0.0.2,10.16.0.0/16,--random-fully",
+		"eip-ingress-qos-add 10.0.0.2,1,10,10",
+	}, executor.calls)
+	require.True(t, state.Find(RuleKindFloatingIP, "10.0.0.2,10.16.0.2").Applied)
+	failed := state.Find(RuleKindFloatingIP, "10.0.0.3,10.16.0.3")
+	require.False(t, failed.Applied)
+	require.Contains(t, failed.Error, "exit code 1")
+
+	// the same request only retries the failed rule
+	executor.calls, executor.failed = nil, nil
+	state, err = agent.Sync(&SyncRequest{Generation: 1, Rules: rules})
+	require.NoError(t, err)
+	require.Equal(t, []string{"floating-ip-add 10.0.0.3,10.16.0.3"}, executor.calls)
+	require.True(t, state.Find(RuleKindFloatingIP, "10.0.0.3,10.16.0.3").Applied)
+
+	// stale rules are deleted before new rules are added
+	executor.calls = nil
+	rules.FloatingIPs = rules.FloatingIPs[:1]
+	rules.EIPs = rules.EIPs[:1]
+	rules.DNATs = []DNAT{{EIP: "10.0.0.2", ExternalPort: "8080", Protocol: "tcp", InternalIP: "10.16.0.4", InternalPort: "80"}}
+	rules.EIPIngressQoS = []EIPQoS{{EIP: "10.0.0.2", Priority: 1, Rate: "20", Burst: "20"}}
+	_, err = agent.Sync(&SyncRequest{Generation: 2, Rules: rules})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"eip-ingress-qos-del 10.0.0.2,1,10,10",
+		"floating-ip-del 10.0.0.3,10.16.0.3",
+		"floating-ip-flush 10.0.0.3,10.16.0.3",
+		"eip-del 10.0.0.3/24,10.0.0.1",
+		"dnat-add 10.0.0.2,8080,tcp,10.16.0.4,80",
+		"eip-ingress-qos-add 10.0.0.2,1,20,20",
+	}, executor.calls)
+
+	_, err = agent.Sync(&SyncRequest{Generation: 1, Rules: rules})
+	var e *Error
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, 409, e.Code)
+	require.Equal(t, int64(2), e.Generation)
+
+	// the applied state is restored after restart
+	executor.calls = nil
+	agent, err = NewAgent(executor, stateFile, "")
+	require.NoError(t, err)
+	state = agent.State()
+	require.Equal(t, int64(2), state.Generation)
+	require.Len(t, state.Rules, 7)
+	_, err = agent.Sync(&SyncRequest{Generation: 3, Rules: rules})
+	require.NoError(t, err)
+	require.Empty(t, executor.calls)
+}
+
+func TestAgentSyncInit(t *testing.T) {
+	t.Parallel()
+
+	executor := &fakeExecutor{initErr: &ExecError{Operation: "init", ExitCode: 1, Output: "failed to exec"}}
+	agent, err := NewAgent(executor, "", "")
+	require.NoError(t, err)
+
+	// no rule is applied before the nat gateway is initialized
+	_, err = agent.Sync(&SyncRequest{Generation: 1, Rules: RuleSet{SNATs: []SNAT{{EIP: "10.0.0.2", InternalCIDR: "10.16.0.0/16"}}}})
+	var e *Error
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, 500, e.Code)
+	require.Empty(t, executor.calls)
+	require.Zero(t, agent.State().Generation)
+}
+
+func TestAgentSyncBatch(t *testing.T) {
+	t.Parallel()
+
+	executor := &fakeExecutor{}
+	agent, err := NewAgent(executor, "", "")
+	require.NoError(t, err)
+
+	var rules RuleSet
+	for i := 0; i < maxRulesPerExec+1; i++ {
+		rules.DNATs = append(rules.DNATs, DNAT{EIP: "10.0.0.2", ExternalPort: fmt.Sprint(1000 + i), Protocol: "tcp", InternalIP: "10.16.0.2", InternalPort: "80"})
+	}
+	state, err := agent.Sync(&SyncRequest{Generation: 1, Rules: rules})
+	require.NoError(t, err)
+	require.Len(t, executor.calls, 2)
+	require.Len(t, state.Rules, maxRulesPerExec+1)
+}
+
+func TestIptablesVersionAtLeast(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, iptablesVersionAtLeast("iptables v1.8.9 (nf_tables)", 1, 6, 2))
+	require.True(t, iptablesVersionAtLeast("iptables v1.6.2", 1, 6, 2))
+	require.False(t, iptablesVersionAtLeast("iptables v1.6.1", 1, 6, 2))
+	require.False(t, iptablesVersionAtLeast("iptables v1.4.21", 1, 6, 2))
+	require.False(t, iptablesVersionAtLeast("unknown", 1, 6, 2))
+}
+
+func TestAgentHAStatus(t *testing.T) {
+	t.Parallel()
+
+	roleFile := filepath.Join(t.TempDir(), "ha-role")
+	agent, err := NewAgent(&fakeExecutor{}, "", roleFile)
+	require.NoError(t, err)
+
+	status, err := agent.HAStatus()
+	require.NoError(t, err)
+	require.Empty(t, status.Role)
+
+	require.NoError(t, os.WriteFile(roleFile, []byte("master\n"), 0o600))
+	status, err = agent.HAStatus()
+	require.NoError(t, err)
+	require.Equal(t, HARoleMaster, status.Role)
+}
+
+func TestAgentSyncDrain(t *testing.T) {
+	t.Parallel()
+
+	executor := &fakeExecutor{}
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	agent, err := NewAgent(executor, stateFile, "")
+	require.NoError(t, err)
+
+	old := DNAT{EIP: "10.0.0.2", ExternalPort: "80", Protocol: "tcp", InternalIP: "10.16.0.2", InternalPort: "8080", DrainSeconds: 3600}
+	_, err = agent.Sync(&SyncRequest{Generation: 1, Rules: RuleSet{DNATs: []DNAT{old}}})
+	require.NoError(t, err)
+
+	// the old target is drained instead of flushed while new sessions go to the new target
+	executor.calls = nil
+	target := DNAT{EIP: "10.0.0.2", ExternalPort: "80", Protocol: "tcp", InternalIP: "10.16.0.3", InternalPort: "8080"}
+	state, err := agent.Sync(&SyncRequest{Generation: 2, Rules: RuleSet{DNATs: []DNAT{target}}, Deleted: &RuleSet{DNATs: []DNAT{old}}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"dnat-del " + old.Rule(), "dnat-add " + target.Rule()}, executor.calls)
+	require.Len(t, state.Draining, 1)
+	require.Equal(t, old.Rule(), state.Draining[0].Rule)
+
+	// draining continues after the agent restarts
+	restarted, err := NewAgent(executor, stateFile, "")
+	require.NoError(t, err)
+	require.Len(t, restarted.State().Draining, 1)
+	restarted.mutex.Lock()
+	restarted.stopDrain(RuleKindDNAT, old.Rule())
+	restarted.mutex.Unlock()
+
+	executor.calls = nil
+	agent.mutex.Lock()
+	agent.finishDrain(RuleKindDNAT, old.Rule())
+	agent.mutex.Unlock()
+	require.Equal(t, []string{"dnat-flush " + old.Rule()}, executor.calls)
+	require.Empty(t, agent.State().Draining)
+
+	// adding the rule back cancels draining
+	drained := target
+	drained.DrainSeconds = 60
+	_, err = agent.Sync(&SyncRequest{Generation: 3, Rules: RuleSet{DNATs: []DNAT{old}}, Deleted: &RuleSet{DNATs: []DNAT{drained}}})
+	require.NoError(t, err)
+	require.Len(t, agent.State().Draining, 1)
+	_, err = agent.Sync(&SyncRequest{Generation: 4, Rules: RuleSet{DNATs: []DNAT{target}}})
+	require.NoError(t, err)
+	require.Empty(t, agent.State().Draining)
+}
+
+func TestAgentSessions(t *testing.T) {
+	t.Parallel()
+
+	executor := &conntrackExecutor{output: `tcp      6 431999 ESTABLISHED src=1.1.1.1 dst=10.0.0.2 sport=5000 dport=80 src=10.16.0.2 dst=1.1.1.1 sport=8080 dport=5000 [ASSURED] mark=0 use=1
+tcp      6 100 TIME_WAIT src=1.1.1.2 dst=10.0.0.2 sport=5001 dport=80 src=10.16.0.2 dst=1.1.1.2 sport=8080 dport=5001 [ASSURED] mark=0 use=1
+udp      17 29 src=10.16.0.5 dst=8.8.8.8 sport=5353 dport=53 src=8.8.8.8 dst=10.0.0.5 sport=53 dport=5353 mark=0 use=1
+icmp     1 29 src=1.1.1.1 dst=10.0.0.5 type=8 code=0 id=1 src=10.16.0.5 dst=1.1.1.1 type=0 code=0 id=1 mark=0 use=1
+udp      17 29 src=1.1.1.3 dst=10.0.0.2 sport=6000 dport=27015 src=10.16.0.3 dst=1.1.1.3 sport=37015 dport=6000 mark=0 use=1
+udp      17 29 src=1.1.1.3 dst=10.0.0.2 sport=6001 dport=27016 src=10.16.0.3 dst=1.1.1.3 sport=37015 dport=6001 mark=0 use=1
+conntrack v1.4.7 (conntrack-tools): 6 flow entries have been shown.
+`}
+	agent, err := NewAgent(executor, "", "")
+	require.NoError(t, err)
+	dnat := DNAT{EIP: "10.0.0.2", ExternalPort: "80", Protocol: "tcp", InternalIP: "10.16.0.2", InternalPort: "8080"}
+	// the second udp session is not translated by the one to one port range mapping
+	portRange := DNAT{EIP: "10.0.0.2", ExternalPort: "27000-27100", Protocol: "udp", InternalIP: "10.16.0.3", InternalPort: "37000-37100"}
+	fip := FloatingIP{EIP: "10.0.0.5", InternalIP: "10.16.0.5"}
+	_, err = agent.Sync(&SyncRequest{Generation: 1, Rules: RuleSet{FloatingIPs: []FloatingIP{fip}, DNATs: []DNAT{dnat, portRange}}})
+	require.NoError(t, err)
+
+	sessions, err := agent.Sessions()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), sessions.Find(RuleKindDNAT, dnat.Rule()).Sessions)
+	require.Equal(t, int64(1), sessions.Find(RuleKindDNAT, portRange.Rule()).Sessions)
+	require.Equal(t, int64(2), sessions.Find(RuleKindFloatingIP, fip.Rule()).Sessions)
+}
+
+type conntrackExecutor struct {
+	output string
+}
+
+func (e *conntrackExecutor) Exec(operation string, _ ...string) (string, error) {
+	if operation == conntrackListOperation {
+		return e.output, nil
+	}
+	return "", nil
+}
+
+// TestScriptSNAT runs nat-gateway.sh with fake iptables commands which log the command lines
+func TestScriptSNAT(t *testing.T) {
+	dir := t.TempDir()
+	saved, calls := filepath.Join(dir, "iptables-save.out"), filepath.Join(dir, "iptables.log")
+	iptablesSave := "#!/bin/sh\necho '-A SNAT_FILTER -j SHARED_SNAT'\ncat " + saved + " 2>/dev/null\n"
+	iptables := "#!/bin/sh\necho \"$@\" >> " + calls + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "iptables-save"), []byte(iptablesSave), 0o755)) // #nosec G306
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "iptables"), []byte(iptables), 0o755))          // #nosec G306
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	executor := NewScriptExecutor(filepath.Join("..", "..", "dist", "images", "vpcnatgateway", "nat-gateway.sh"))
+	tcp := SNAT{EIP: "10.0.0.2", InternalCIDR: "10.16.0.5/32", PortRange: "1024-2047", Protocol: "tcp"}
+	udp := SNAT{EIP: "10.0.0.2", InternalCIDR: "10.16.0.5/32", PortRange: "1024-2047", Protocol: "udp"}
+	other := SNAT{EIP: "10.0.0.2", InternalCIDR: "10.16.0.5/32"}
+	_, err := executor.Exec("snat-add", tcp.Rule()+",--random-fully", udp.Rule(), other.Rule()+",--random-fully")
+	require.NoError(t, err)
+	output, err := os.ReadFile(calls)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"-t nat -I SHARED_SNAT -o net1 -s 10.16.0.5/32 -p tcp -j SNAT --to-source 10.0.0.2:1024-2047 --random-fully",
+		"-t nat -I SHARED_SNAT -o net1 -s 10.16.0.5/32 -p udp -j SNAT --to-source 10.0.0.2:1024-2047",
+		"-t nat -A SHARED_SNAT -o net1 -s 10.16.0.5/32 -j SNAT --to-source 10.0.0.2 --random-fully",
+	}, strings.Split(strings.TrimSpace(string(output)), "\n"))
+
+	require.NoError(t, os.WriteFile(saved, []byte(`-A SHARED_SNAT -s 10.16.0.5/32 -o net1 -p udp -j SNAT --to-source 10.0.0.2:1024-2047
+-A SHARED_SNAT -s 10.16.0.5/32 -o net1 -p tcp -j SNAT --to-source 10.0.0.2:1024-2047 --random-fully
+-A SHARED_SNAT -s 10.16.0.5/32 -o net1 -j SNAT --to-source 10.0.0.2 --random-fully
+`), 0o600))
+	require.NoError(t, os.Remove(calls))
+	// existing rules are not added again
+	_, err = executor.Exec("snat-add", tcp.Rule(), udp.Rule(), other.Rule())
+	require.NoError(t, err)
+	require.NoFileExists(t, calls)
+
+	_, err = executor.Exec("snat-del", tcp.Rule(), other.Rule())
+	require.NoError(t, err)
+	output, err = os.ReadFile(calls)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"-t nat -D SHARED_SNAT -s 10.16.0.5/32 -o net1 -p tcp -j SNAT --to-source 10.0.0.2:1024-2047 --random-fully",
+		"-t nat -D SHARED_SNAT -s 10.16.0.5/32 -o net1 -j SNAT --to-source 10.0.0.2 --random-fully",
+	}, strings.Split(strings.TrimSpace(string(output)), "\n"))
+}
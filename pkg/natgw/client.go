@@ -0,0 +1,103 @@
+package natgw
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Client talks to the agent in a nat gateway pod
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the agent listening at baseURL, e.g. http://127.0.0.1:10665
+func NewClient(baseURL, token string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:    baseURL,
+		token:      token,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Sync sends the desired rule set to the agent and returns the applied state,
+// an *Error with code 409 is returned if the generation is stale
+func (c *Client) Sync(ctx context.Context, req *SyncRequest) (*State, error) {
+	var state State
+	if err := c.do(ctx, http.MethodPut, PathRules, req, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// State returns the applied state of the agent
+func (c *Client) State(ctx context.Context) (*State, error) {
+	var state State
+	if err := c.do(ctx, http.MethodGet, PathRules, nil, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// HAStatus returns the role of the nat gateway instance in active/standby mode
+func (c *Client) HAStatus(ctx context.Context) (*HAStatus, error) {
+	var status HAStatus
+	if err := c.do(ctx, http.MethodGet, PathHA, nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// Sessions returns the active sessions of the fips and dnats
+func (c *Client) Sessions(ctx context.Context) (*Sessions, error) {
+	var sessions Sessions
+	if err := c.do(ctx, http.MethodGet, PathSessions, nil, &sessions); err != nil {
+		return nil, err
+	}
+	return &sessions, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		e := &Error{}
+		if err = json.NewDecoder(resp.Body).Decode(e); err != nil || e.Message == "" {
+			return &Error{Code: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		}
+		e.Code = resp.StatusCode
+		return e
+	}
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response of %s %s: %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package natgw
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const conntrackListOperation = "conntrack-list"
+
+// drainingRule is a deleted rule whose conntrack entries are flushed when the timer fires
+type drainingRule struct {
+	deadline time.Time
+	timer    *time.Timer
+}
+
+// releaseSessions handles the conntrack entries of a deleted rule. Without a drain period they are
+// flushed at once, otherwise existing sessions keep working until the drain period ends, while new
+// sessions are translated by the current rules since iptables nat rules only apply to new connections.
+// The mutex must be held.
+func (a *Agent) releaseSessions(kind RuleKind, rule string, drainSeconds int32) {
+	if !kind.hasSessions() {
+		return
+	}
+	if drainSeconds <= 0 {
+		a.flushSessions(kind, rule)
+		return
+	}
+	klog.Infof("drain sessions of %s rule %s for %d seconds", kind, rule, drainSeconds)
+	a.startDrain(kind, rule, time.Now().Add(time.Duration(drainSeconds)*time.Second))
+}
+
+// startDrain schedules the flush of the conntrack entries of the rule at the deadline.
+// The mutex must be held.
+func (a *Agent) startDrain(kind RuleKind, rule string, deadline time.Time) {
+	a.stopDrain(kind, rule)
+	d := &drainingRule{deadline: deadline}
+	d.timer = time.AfterFunc(time.Until(deadline), func() {
+		a.mutex.Lock()
+		defer a.mutex.Unlock()
+		if a.draining[kind][rule] != d {
+			return
+		}
+		a.finishDrain(kind, rule)
+		if err := a.save(); err != nil {
+			klog.Errorf("failed to save state after draining %s rule %s: %v", kind, rule, err)
+		}
+	})
+	if a.draining[kind] == nil {
+		a.draining[kind] = make(map[string]*drainingRule)
+	}
+	a.draining[kind][rule] = d
+}
+
+// stopDrain cancels draining the rule, e.g. the rule is added back before the drain period ends
+// and flushing its sessions would break them. The mutex must be held.
+func (a *Agent) stopDrain(kind RuleKind, rule string) {
+	if d := a.draining[kind][rule]; d != nil {
+		d.timer.Stop()
+		delete(a.draining[kind], rule)
+	}
+}
+
+// finishDrain flushes the conntrack entries of the draining rule. The mutex must be held.
+func (a *Agent) finishDrain(kind RuleKind, rule string) {
+	if _, ok := a.draining[kind][rule]; !ok {
+		return
+	}
+	delete(a.draining[kind], rule)
+	klog.Infof("drain period of %s rule %s ends", kind, rule)
+	a.flushSessions(kind, rule)
+}
+
+// flushSessions deletes the conntrack entries translated by the rule only,
+// failures are logged since the entries expire eventually
+func (a *Agent) flushSessions(kind RuleKind, rule string) {
+	if output, err := a.executor.Exec(kind.flushOperation(), rule); err != nil {
+		klog.Errorf("failed to flush conntrack entries of %s rule %s: %v, output: %s", kind, rule, err, output)
+	}
+}
+
+// Sessions counts the active sessions of the applied and draining fips and dnats
+func (a *Agent) Sessions() (*Sessions, error) {
+	var result Sessions
+	a.mutex.Lock()
+	for _, kind := range ruleKinds {
+		if !kind.hasSessions() {
+			continue
+		}
+		for rule := range a.applied[kind] {
+			result.Rules = append(result.Rules, RuleSessions{Kind: kind, Rule: rule})
+		}
+		for rule := range a.draining[kind] {
+			result.Rules = append(result.Rules, RuleSessions{Kind: kind, Rule: rule, Draining: true})
+		}
+	}
+	a.mutex.Unlock()
+
+	// listing a large conntrack table takes a while, do not block rule sync
+	output, err := a.executor.Exec(conntrackListOperation)
+	if err != nil {
+		klog.Errorf("failed to list conntrack entries: %v", err)
+		return nil, err
+	}
+	entries := parseConntrackEntries(output)
+	for i := range result.Rules {
+		r := &result.Rules[i]
+		for _, entry := range entries {
+			if entry.active() && entry.matches(r.Kind, r.Rule) {
+				r.Sessions++
+			}
+		}
+	}
+	sort.Slice(result.Rules, func(i, j int) bool {
+		if result.Rules[i].Kind != result.Rules[j].Kind {
+			return result.Rules[i].Kind < result.Rules[j].Kind
+		}
+		return result.Rules[i].Rule < result.Rules[j].Rule
+	})
+	return &result, nil
+}
+
+type conntrackTuple struct {
+	src, dst, sport, dport string
+}
+
+type conntrackEntry struct {
+	protocol string
+	state    string
+	orig     conntrackTuple
+	reply    conntrackTuple
+}
+
+// parseConntrackEntries parses the output of conntrack -L, e.g.
+// tcp 6 431999 ESTABLISHED src=1.1.1.1 dst=10.0.0.2 sport=5000 dport=80 src=10.16.0.2 dst=1.1.1.1 sport=8080 dport=5000 [ASSURED] mark=0 use=1
+func parseConntrackEntries(output string) []conntrackEntry {
+	var entries []conntrackEntry
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || !strings.Contains(line, "src=") {
+			continue
+		}
+		entry := conntrackEntry{protocol: fields[0]}
+		if fields[0] == "tcp" && len(fields) > 3 && !strings.Contains(fields[3], "=") {
+			entry.state = fields[3]
+		}
+		seen := make(map[string]bool, 4)
+		for _, field := range fields {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			tuple := &entry.orig
+			if seen[key] {
+				tuple = &entry.reply
+			}
+			switch key {
+			case "src":
+				tuple.src = value
+			case "dst":
+				tuple.dst = value
+			case "sport":
+				tuple.sport = value
+			case "dport":
+				tuple.dport = value
+			default:
+				continue
+			}
+			seen[key] = true
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// active returns false for tcp sessions being closed
+func (e *conntrackEntry) active() bool {
+	return e.state != "TIME_WAIT" && e.state != "CLOSE"
+}
+
+// matches returns whether the entry is translated by the rule in the format of nat-gateway.sh
+func (e *conntrackEntry) matches(kind RuleKind, rule string) bool {
+	fields := strings.Split(rule, ",")
+	switch kind {
+	case RuleKindFloatingIP:
+		if len(fields) < 2 {
+			return false
+		}
+		eip, internalIP := fields[0], fields[1]
+		// inbound sessions are translated by dnat and outbound ones by snat
+		return (e.orig.dst == eip && e.reply.src == internalIP) ||
+			(e.orig.src == internalIP && e.reply.dst == eip)
+	case RuleKindDNAT:
+		if len(fields) < 5 {
+			return false
+		}
+		eip, externalPort, protocol, internalIP, internalPort := fields[0], fields[1], fields[2], fields[3], fields[4]
+		if e.protocol != protocol || e.orig.dst != eip || e.reply.src != internalIP {
+			return false
+		}
+		// ports of a port range are mapped one to one
+		offset, ok := portOffset(e.orig.dport, externalPort)
+		if !ok {
+			return false
+		}
+		internalOffset, ok := portOffset(e.reply.sport, internalPort)
+		return ok && offset == internalOffset
+	}
+	return false
+}
+
+// portOffset returns the offset of the port in the port or port range like 8000-8100
+func portOffset(port, portRange string) (int, bool) {
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return 0, false
+	}
+	first, last, isRange := strings.Cut(portRange, "-")
+	start, err := strconv.Atoi(first)
+	if err != nil {
+		return 0, false
+	}
+	end := start
+	if isRange {
+		if end, err = strconv.Atoi(last); err != nil {
+			return 0, false
+		}
+	}
+	if p < start || p > end {
+		return 0, false
+	}
+	return p - start, true
+}
@@ -0,0 +1,117 @@
+package natgw
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// maxRequestBodySize is large enough for tens of thousands of rules
+const maxRequestBodySize = 64 << 20
+
+type server struct {
+	agent *Agent
+	token string
+}
+
+// NewHandler returns the http handler of the agent api,
+// all requests except health checks must carry the bearer token
+func NewHandler(agent *Agent, token string) http.Handler {
+	s := &server{agent: agent, token: token}
+	mux := http.NewServeMux()
+	mux.HandleFunc(PathHealthz, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc(PathRules, s.authenticate(s.handleRules))
+	mux.HandleFunc(PathHA, s.authenticate(s.handleHA))
+	mux.HandleFunc(PathSessions, s.authenticate(s.handleSessions))
+	return mux
+}
+
+func (s *server) authenticate(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			writeError(w, &Error{Code: http.StatusUnauthorized, Message: "invalid bearer token"})
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func (s *server) handleRules(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.agent.State())
+	case http.MethodPut:
+		var req SyncRequest
+		if err := decode(w, r, &req); err != nil {
+			writeError(w, err)
+			return
+		}
+		klog.Infof("sync rules of generation %d", req.Generation)
+		state, err := s.agent.Sync(&req)
+		if err != nil {
+			klog.Errorf("failed to sync rules of generation %d: %v", req.Generation, err)
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, state)
+	default:
+		writeError(w, &Error{Code: http.StatusMethodNotAllowed, Message: fmt.Sprintf("method %s is not allowed", r.Method)})
+	}
+}
+
+func (s *server) handleHA(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, &Error{Code: http.StatusMethodNotAllowed, Message: fmt.Sprintf("method %s is not allowed", r.Method)})
+		return
+	}
+	status, err := s.agent.HAStatus()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func (s *server) handleSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, &Error{Code: http.StatusMethodNotAllowed, Message: fmt.Sprintf("method %s is not allowed", r.Method)})
+		return
+	}
+	sessions, err := s.agent.Sessions()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, sessions)
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize)).Decode(v); err != nil {
+		return &Error{Code: http.StatusBadRequest, Message: fmt.Sprintf("failed to decode request: %v", err)}
+	}
+	return nil
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var e *Error
+	if !errors.As(err, &e) {
+		e = &Error{Code: http.StatusInternalServerError, Message: err.Error()}
+	}
+	writeJSON(w, e.Code, e)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		klog.Errorf("failed to write response: %v", err)
+	}
+}
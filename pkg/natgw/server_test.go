@@ -0,0 +1,51 @@
+package natgw
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	executor := &fakeExecutor{failed: map[string]bool{"bad": true}}
+	agent, err := NewAgent(executor, "", "")
+	require.NoError(t, err)
+	server := httptest.NewServer(NewHandler(agent, "secret"))
+	defer server.Close()
+
+	ctx := context.Background()
+	_, err = NewClient(server.URL, "wrong", time.Second).State(ctx)
+	var e *Error
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, http.StatusUnauthorized, e.Code)
+
+	client := NewClient(server.URL, "secret", time.Second)
+	state, err := client.Sync(ctx, &SyncRequest{Generation: 2, Rules: RuleSet{SNATs: []SNAT{{EIP: "10.0.0.2", InternalCIDR: "10.16.0.0/16"}}}})
+	require.NoError(t, err)
+	require.True(t, state.Find(RuleKindSNAT, "10.0.0.2,10.16.0.0/16").Applied)
+
+	_, err = client.Sync(ctx, &SyncRequest{Generation: 1})
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, http.StatusConflict, e.Code)
+	require.Equal(t, int64(2), e.Generation)
+
+	state, err = client.State(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), state.Generation)
+	require.Len(t, state.Rules, 1)
+
+	ha, err := client.HAStatus(ctx)
+	require.NoError(t, err)
+	require.Empty(t, ha.Role)
+
+	resp, err := http.Get(server.URL + PathHealthz)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
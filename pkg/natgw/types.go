@@ -0,0 +1,314 @@
+package natgw
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPort is the port the agent listens on inside the nat gateway pod
+	DefaultPort = 10665
+	// TokenEnv is the environment variable holding the bearer token of the agent
+	TokenEnv = "NAT_GW_AGENT_TOKEN"
+	// TokenSecretKey is the key of the token in the agent secret
+	TokenSecretKey = "token"
+
+	PathHealthz  = "/healthz"
+	PathRules    = "/v1/rules"
+	PathHA       = "/v1/ha"
+	PathSessions = "/v1/sessions"
+)
+
+// roles of a nat gateway instance in active/standby mode
+const (
+	HARoleMaster = "master"
+	HARoleBackup = "backup"
+	HARoleFault  = "fault"
+)
+
+// RuleKind is the kind of a rule managed by desired state sync
+type RuleKind string
+
+const (
+	RuleKindExternalRoute RuleKind = "ext-subnet-route"
+	RuleKindRoute         RuleKind = "subnet-route"
+	RuleKindEIP           RuleKind = "eip"
+	RuleKindFloatingIP    RuleKind = "floating-ip"
+	RuleKindDNAT          RuleKind = "dnat"
+	RuleKindSNAT          RuleKind = "snat"
+	RuleKindQoS           RuleKind = "qos"
+	RuleKindEIPIngressQoS RuleKind = "eip-ingress-qos"
+	RuleKindEIPEgressQoS  RuleKind = "eip-egress-qos"
+)
+
+// ruleKinds lists the kinds in the order they are added, rules are deleted in the reverse order
+var ruleKinds = []RuleKind{
+	RuleKindExternalRoute, RuleKindRoute, RuleKindEIP, RuleKindFloatingIP, RuleKindDNAT, RuleKindSNAT,
+	RuleKindQoS, RuleKindEIPIngressQoS, RuleKindEIPEgressQoS,
+}
+
+func (k RuleKind) addOperation() string {
+	return string(k) + "-add"
+}
+
+func (k RuleKind) delOperation() string {
+	return string(k) + "-del"
+}
+
+// hasSessions returns whether the rule translates sessions whose conntrack entries outlive the rule
+func (k RuleKind) hasSessions() bool {
+	return k == RuleKindFloatingIP || k == RuleKindDNAT
+}
+
+func (k RuleKind) flushOperation() string {
+	return string(k) + "-flush"
+}
+
+// Route is a route to cidr via nexthop in the nat gateway pod, external routes go through
+// the external network interface and the others through the internal one
+type Route struct {
+	CIDR    string `json:"cidr"`
+	NextHop string `json:"nextHop"`
+}
+
+func (r Route) Rule() string {
+	return fmt.Sprintf("%s,%s", r.CIDR, r.NextHop)
+}
+
+// EIP is an external address in cidr format with the gateway of the external subnet
+type EIP struct {
+	CIDR    string `json:"cidr"`
+	Gateway string `json:"gateway"`
+}
+
+// Rule returns the rule in the format accepted by nat-gateway.sh
+func (e EIP) Rule() string {
+	return fmt.Sprintf("%s,%s", e.CIDR, e.Gateway)
+}
+
+// FloatingIP is a one to one nat rule, DrainSeconds is not a part of the rule
+// and delays flushing the conntrack entries after the rule is deleted
+type FloatingIP struct {
+	EIP          string `json:"eip"`
+	InternalIP   string `json:"internalIP"`
+	DrainSeconds int32  `json:"drainSeconds,omitempty"`
+}
+
+func (f FloatingIP) Rule() string {
+	return fmt.Sprintf("%s,%s", f.EIP, f.InternalIP)
+}
+
+type DNAT struct {
+	EIP          string `json:"eip"`
+	ExternalPort string `json:"externalPort"`
+	Protocol     string `json:"protocol"`
+	InternalIP   string `json:"internalIP"`
+	InternalPort string `json:"internalPort"`
+	DrainSeconds int32  `json:"drainSeconds,omitempty"`
+}
+
+func (d DNAT) Rule() string {
+	return fmt.Sprintf("%s,%s,%s,%s,%s", d.EIP, d.ExternalPort, d.Protocol, d.InternalIP, d.InternalPort)
+}
+
+// SNAT is a shared snat rule, the agent appends --random-fully if iptables supports it.
+// The translated source ports of Protocol tcp or udp are limited to PortRange like 1024-2047 if it is set,
+// iptables only translates ports of a protocol with ports, so other protocols need a rule without PortRange.
+type SNAT struct {
+	EIP          string `json:"eip"`
+	InternalCIDR string `json:"internalCIDR"`
+	PortRange    string `json:"portRange,omitempty"`
+	Protocol     string `json:"protocol,omitempty"`
+}
+
+func (s SNAT) Rule() string {
+	if s.PortRange != "" {
+		return fmt.Sprintf("%s:%s,%s,%s", s.EIP, s.PortRange, s.InternalCIDR, s.Protocol)
+	}
+	return fmt.Sprintf("%s,%s", s.EIP, s.InternalCIDR)
+}
+
+// QoS is a bandwidth limit of the nat gateway on an interface, which applies to all the traffic
+// if ClassifierType is matchall, or to the traffic from or to CIDR if ClassifierType is u32
+type QoS struct {
+	Direction      string `json:"direction"`
+	Interface      string `json:"interface"`
+	Priority       int    `json:"priority"`
+	ClassifierType string `json:"classifierType"`
+	MatchType      string `json:"matchType,omitempty"`
+	MatchDirection string `json:"matchDirection,omitempty"`
+	CIDR           string `json:"cidr,omitempty"`
+	Rate           string `json:"rate"`
+	Burst          string `json:"burst"`
+}
+
+func (q QoS) Rule() string {
+	return fmt.Sprintf("%s,%s,%d,%s,%s,%s,%s,%s,%s", q.Direction, q.Interface, q.Priority,
+		q.ClassifierType, q.MatchType, q.MatchDirection, q.CIDR, q.Rate, q.Burst)
+}
+
+// EIPQoS is a bandwidth limit of an eip, the rate and burst are in Mbit
+type EIPQoS struct {
+	EIP      string `json:"eip"`
+	Priority int    `json:"priority"`
+	Rate     string `json:"rate"`
+	Burst    string `json:"burst"`
+}
+
+func (q EIPQoS) Rule() string {
+	return fmt.Sprintf("%s,%d,%s,%s", q.EIP, q.Priority, q.Rate, q.Burst)
+}
+
+// RuleSet is the full set of rules desired in a nat gateway
+type RuleSet struct {
+	ExternalRoutes []Route      `json:"externalRoutes,omitempty"`
+	Routes         []Route      `json:"routes,omitempty"`
+	EIPs           []EIP        `json:"eips,omitempty"`
+	FloatingIPs    []FloatingIP `json:"floatingIPs,omitempty"`
+	DNATs          []DNAT       `json:"dnats,omitempty"`
+	SNATs          []SNAT       `json:"snats,omitempty"`
+	QoS            []QoS        `json:"qos,omitempty"`
+	EIPIngressQoS  []EIPQoS     `json:"eipIngressQoS,omitempty"`
+	EIPEgressQoS   []EIPQoS     `json:"eipEgressQoS,omitempty"`
+}
+
+// Rules returns the rules of the rule set grouped by kind
+func (s *RuleSet) Rules() map[RuleKind][]string {
+	rules := make(map[RuleKind][]string, len(ruleKinds))
+	for _, route := range s.ExternalRoutes {
+		rules[RuleKindExternalRoute] = append(rules[RuleKindExternalRoute], route.Rule())
+	}
+	for _, route := range s.Routes {
+		rules[RuleKindRoute] = append(rules[RuleKindRoute], route.Rule())
+	}
+	for _, eip := range s.EIPs {
+		rules[RuleKindEIP] = append(rules[RuleKindEIP], eip.Rule())
+	}
+	for _, fip := range s.FloatingIPs {
+		rules[RuleKindFloatingIP] = append(rules[RuleKindFloatingIP], fip.Rule())
+	}
+	for _, dnat := range s.DNATs {
+		rules[RuleKindDNAT] = append(rules[RuleKindDNAT], dnat.Rule())
+	}
+	for _, snat := range s.SNATs {
+		rules[RuleKindSNAT] = append(rules[RuleKindSNAT], snat.Rule())
+	}
+	for _, qos := range s.QoS {
+		rules[RuleKindQoS] = append(rules[RuleKindQoS], qos.Rule())
+	}
+	for _, qos := range s.EIPIngressQoS {
+		rules[RuleKindEIPIngressQoS] = append(rules[RuleKindEIPIngressQoS], qos.Rule())
+	}
+	for _, qos := range s.EIPEgressQoS {
+		rules[RuleKindEIPEgressQoS] = append(rules[RuleKindEIPEgressQoS], qos.Rule())
+	}
+	return rules
+}
+
+// drainSeconds returns the drain periods of the rules in the rule set
+func (s *RuleSet) drainSeconds() map[RuleKind]map[string]int32 {
+	drains := map[RuleKind]map[string]int32{RuleKindFloatingIP: {}, RuleKindDNAT: {}}
+	if s == nil {
+		return drains
+	}
+	for _, fip := range s.FloatingIPs {
+		drains[RuleKindFloatingIP][fip.Rule()] = fip.DrainSeconds
+	}
+	for _, dnat := range s.DNATs {
+		drains[RuleKindDNAT][dnat.Rule()] = dnat.DrainSeconds
+	}
+	return drains
+}
+
+// SyncRequest carries the desired rule set of a nat gateway.
+// Requests with a generation lower than the applied one are rejected.
+// Deleted optionally lists the rules removed by the request with their drain periods,
+// the conntrack entries of other stale rules are flushed at once.
+type SyncRequest struct {
+	Generation int64    `json:"generation"`
+	Rules      RuleSet  `json:"rules"`
+	Deleted    *RuleSet `json:"deleted,omitempty"`
+}
+
+// RuleStatus is the result of applying a rule
+type RuleStatus struct {
+	Kind    RuleKind `json:"kind"`
+	Rule    string   `json:"rule"`
+	Applied bool     `json:"applied"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// DrainingRule is a deleted rule whose existing sessions keep working until the deadline
+type DrainingRule struct {
+	Kind     RuleKind  `json:"kind"`
+	Rule     string    `json:"rule"`
+	Deadline time.Time `json:"deadline"`
+}
+
+// State is the rule state reported by the agent
+type State struct {
+	Generation int64          `json:"generation"`
+	Rules      []RuleStatus   `json:"rules,omitempty"`
+	Draining   []DrainingRule `json:"draining,omitempty"`
+}
+
+// Find returns the status of the rule or nil if the rule is unknown to the agent
+func (s *State) Find(kind RuleKind, rule string) *RuleStatus {
+	for i := range s.Rules {
+		if s.Rules[i].Kind == kind && s.Rules[i].Rule == rule {
+			return &s.Rules[i]
+		}
+	}
+	return nil
+}
+
+// HAStatus is the state of the instance in active/standby mode,
+// the role is empty if the mode is disabled or vrrp has not started
+type HAStatus struct {
+	Role string `json:"role,omitempty"`
+}
+
+// RuleSessions is the number of active sessions translated by an applied or draining rule
+type RuleSessions struct {
+	Kind     RuleKind `json:"kind"`
+	Rule     string   `json:"rule"`
+	Sessions int64    `json:"sessions"`
+	Draining bool     `json:"draining,omitempty"`
+}
+
+type Sessions struct {
+	Rules []RuleSessions `json:"rules,omitempty"`
+}
+
+// Find returns the sessions of the rule which is not draining
+func (s *Sessions) Find(kind RuleKind, rule string) *RuleSessions {
+	for i := range s.Rules {
+		if s.Rules[i].Kind == kind && s.Rules[i].Rule == rule && !s.Rules[i].Draining {
+			return &s.Rules[i]
+		}
+	}
+	return nil
+}
+
+// Error is the body of a failed request
+type Error struct {
+	Code       int    `json:"code"`
+	Message    string `json:"message"`
+	Generation int64  `json:"generation,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("nat gateway agent error %d: %s", e.Code, e.Message)
+}
+
+// ExecError is returned when nat-gateway.sh exits with a non-zero code
+type ExecError struct {
+	Operation string
+	ExitCode  int
+	Output    string
+}
+
+func (e *ExecError) Error() string {
+	return fmt.Sprintf("failed to exec %s, exit code %d: %s", e.Operation, e.ExitCode, strings.TrimSpace(e.Output))
+}
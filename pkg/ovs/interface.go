@@ -2,6 +2,7 @@ package ovs
 
 import (
 	netv1 "k8s.io/api/networking/v1"
+	anpv1alpha1 "sigs.k8s.io/network-policy-api/apis/v1alpha1"
 
 	"github.com/ovn-org/libovsdb/ovsdb"
 
@@ -42,9 +43,16 @@ type LogicalRouterPort interface {
 	LogicalRouterPortExists(lrpName string) (bool, error)
 }
 
+type Meter interface {
+	CreateOrUpdateMeter(name string, unit ovnnb.MeterUnit, rate int, externalIDs map[string]string) error
+	DeleteMeter(name string) error
+	GetMeter(name string, ignoreNotFound bool) (*ovnnb.Meter, error)
+}
+
 type BFD interface {
 	CreateBFD(lrpName, dstIP string, minRx, minTx, detectMult int) (*ovnnb.BFD, error)
 	DeleteBFD(lrpName, dstIP string) error
+	MonitorBFD(onStatusChange func(bfd *ovnnb.BFD))
 }
 
 type LogicalSwitch interface {
@@ -85,7 +93,9 @@ type LogicalSwitchPort interface {
 type LoadBalancer interface {
 	CreateLoadBalancer(lbName, protocol, selectFields string) error
 	LoadBalancerAddVip(lbName, vip string, backends ...string) error
+	LoadBalancerAddVips(lbName string, vips map[string]string) error
 	LoadBalancerDeleteVip(lbName, vip string, ignoreHealthCheck bool) error
+	LoadBalancerDeleteVips(lbName string, vips []string) error
 	LoadBalancerAddIPPortMapping(lbName, vip string, ipPortMappings map[string]string) error
 	LoadBalancerUpdateIPPortMapping(lbName, vip string, ipPortMappings map[string]string) error
 	LoadBalancerDeleteIPPortMapping(lbName, vip string) error
@@ -120,9 +130,12 @@ type PortGroup interface {
 }
 
 type ACL interface {
-	UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol string, npp []netv1.NetworkPolicyPort, logEnable bool, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error)
-	UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol string, npp []netv1.NetworkPolicyPort, logEnable bool, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error)
+	UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol string, npp []netv1.NetworkPolicyPort, logConfig *ACLLogConfig, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error)
+	UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol string, npp []netv1.NetworkPolicyPort, logConfig *ACLLogConfig, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error)
+	UpdateEgressFqdnACLOps(pgName, protocol string, fqdns []string) ([]ovsdb.Operation, error)
+	UpdateAnpRuleACLOps(pgName, asName, protocol string, priority int, aclAction ovnnb.ACLAction, logEnable, isIngress bool, rulePorts []anpv1alpha1.AdminNetworkPolicyPort, namedPortMap map[string]*util.NamedPortInfo, excludes []string) ([]ovsdb.Operation, error)
 	CreateGatewayACL(lsName, pgName, gateway string) error
+	UpdateGatewayACLOps(pgName, gateway string) ([]ovsdb.Operation, error)
 	CreateNodeACL(pgName, nodeIPStr, joinIPStr string) error
 	CreateSgDenyAllACL(sgName string) error
 	CreateSgBaseACL(sgName, direction string) error
@@ -167,8 +180,10 @@ type NAT interface {
 	GetNATByUUID(uuid string) (*ovnnb.NAT, error)
 	AddNat(lrName, natType, externalIP, logicalIP, logicalMac, port string, options map[string]string) error
 	UpdateSnat(lrName, externalIP, logicalIP string) error
+	UpdateSnatPortRanges(lrName, externalIP string, portRanges map[string]string) error
 	UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, gatewayType string) error
 	DeleteNats(lrName, natType, logicalIP string) error
+	DeleteSnats(lrName, externalIP string, logicalIPs []string) error
 	DeleteNat(lrName, natType, externalIP, logicalIP string) error
 	NatExists(lrName, natType, externalIP, logicalIP string) (bool, error)
 	ListNats(lrName, natType, logicalIP string, externalIDs map[string]string) ([]*ovnnb.NAT, error)
@@ -185,6 +200,7 @@ type NbClient interface {
 	ACL
 	AddressSet
 	BFD
+	Meter
 	DHCPOptions
 	LoadBalancer
 	LoadBalancerHealthCheck
@@ -11,6 +11,7 @@ import (
 	netv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/klog/v2"
+	anpv1alpha1 "sigs.k8s.io/network-policy-api/apis/v1alpha1"
 
 	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
 	ovsclient "github.com/kubeovn/kube-ovn/pkg/ovsdb/client"
@@ -18,8 +19,78 @@ import (
 	"github.com/kubeovn/kube-ovn/pkg/util"
 )
 
-// UpdateIngressACLOps return operation that creates an ingress ACL
-func (c *OVNNbClient) UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol string, npp []netv1.NetworkPolicyPort, logEnable bool, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
+// ACLLogConfig describes how the hits of the acls created for a network policy rule are logged
+type ACLLogConfig struct {
+	// Policy is the "<namespace>/<name>" of the network policy
+	Policy string
+	// Rule is the index of the rule, or "all" if the policy has no rules
+	Rule     string
+	LogAllow bool
+	LogDrop  bool
+	// Meter is the name of the meter rate limiting the log messages
+	Meter string
+	// Audit makes the drop acls log the packets and let them through instead of dropping them,
+	// the acls are named with the rule "audit"
+	Audit bool
+}
+
+// aclLogNameMaxLength is the max length of the acl name defined by the nb schema
+const aclLogNameMaxLength = 63
+
+// ACLLogName returns the acl name which is logged by ovn-controller as name="<policy>/<direction>/<rule>",
+// the policy part is shortened with a hash suffix if the name exceeds the max length
+func ACLLogName(policy, direction, rule string) string {
+	name := fmt.Sprintf("%s/%s/%s", policy, direction, rule)
+	if len(name) <= aclLogNameMaxLength {
+		return name
+	}
+	hash := util.Sha256Hash([]byte(policy))[:8]
+	policy = policy[:aclLogNameMaxLength-len(direction)-len(rule)-len(hash)-3] + "~" + hash
+	return fmt.Sprintf("%s/%s/%s", policy, direction, rule)
+}
+
+// options returns the acl option setting the name, log and meter of the acl
+func (l *ACLLogConfig) options(direction ovnnb.ACLDirection, allow bool) func(acl *ovnnb.ACL) {
+	return func(acl *ovnnb.ACL) {
+		if l == nil {
+			return
+		}
+
+		dir, rule := "ingress", l.Rule
+		if direction == ovnnb.ACLDirectionFromLport {
+			dir = "egress"
+		}
+		if !allow {
+			rule = "drop"
+			if l.Audit {
+				rule = "audit"
+				acl.Action = ovnnb.ACLActionAllowRelated
+			}
+		}
+		name := ACLLogName(l.Policy, dir, rule)
+		acl.Name = &name
+
+		if (allow && l.LogAllow) || (!allow && (l.LogDrop || l.Audit)) {
+			acl.Log = true
+			if allow {
+				acl.Severity = &ovnnb.ACLSeverityInfo
+			} else {
+				acl.Severity = &ovnnb.ACLSeverityWarning
+			}
+			if l.Meter != "" {
+				acl.Meter = &l.Meter
+			}
+		}
+	}
+}
+
+// audit returns whether the drop acls only audit the packets
+func (l *ACLLogConfig) audit() bool {
+	return l != nil && l.Audit
+}
+
+// UpdateIngressACLOps return operation that creates an ingress ACL, the acls are not logged if logConfig is nil
+func (c *OVNNbClient) UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol string, npp []netv1.NetworkPolicyPort, logConfig *ACLLogConfig, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
 	acls := make([]*ovnnb.ACL, 0)
 
 	if strings.HasSuffix(asIngressName, ".0") || strings.HasSuffix(asIngressName, ".all") {
@@ -34,14 +105,12 @@ func (c *OVNNbClient) UpdateIngressACLOps(pgName, asIngressName, asExceptName, p
 			NewACLMatch("outport", "==", "@"+pgName, ""),
 			NewACLMatch(ipSuffix, "", "", ""),
 		)
-		options := func(acl *ovnnb.ACL) {
-			if logEnable {
-				acl.Log = true
-				acl.Severity = &ovnnb.ACLSeverityWarning
-			}
+		// the audit acl has a lower priority so that the drop acls of other policies still take effect
+		priority := util.IngressDefaultDrop
+		if logConfig.audit() {
+			priority = util.IngressAuditPriority
 		}
-
-		defaultDropACL, err := c.newACLWithoutCheck(pgName, ovnnb.ACLDirectionToLport, util.IngressDefaultDrop, allIPMatch.String(), ovnnb.ACLActionDrop, options)
+		defaultDropACL, err := c.newACLWithoutCheck(pgName, ovnnb.ACLDirectionToLport, priority, allIPMatch.String(), ovnnb.ACLActionDrop, logConfig.options(ovnnb.ACLDirectionToLport, false))
 		if err != nil {
 			return nil, fmt.Errorf("new default drop ingress acl for port group %s: %v", pgName, err)
 		}
@@ -52,7 +121,7 @@ func (c *OVNNbClient) UpdateIngressACLOps(pgName, asIngressName, asExceptName, p
 	/* allow acl */
 	matches := newNetworkPolicyACLMatch(pgName, asIngressName, asExceptName, protocol, ovnnb.ACLDirectionToLport, npp, namedPortMap)
 	for _, m := range matches {
-		allowACL, err := c.newACLWithoutCheck(pgName, ovnnb.ACLDirectionToLport, util.IngressAllowPriority, m, ovnnb.ACLActionAllowRelated)
+		allowACL, err := c.newACLWithoutCheck(pgName, ovnnb.ACLDirectionToLport, util.IngressAllowPriority, m, ovnnb.ACLActionAllowRelated, logConfig.options(ovnnb.ACLDirectionToLport, true))
 		if err != nil {
 			return nil, fmt.Errorf("new allow ingress acl for port group %s: %v", pgName, err)
 		}
@@ -68,8 +137,8 @@ func (c *OVNNbClient) UpdateIngressACLOps(pgName, asIngressName, asExceptName, p
 	return ops, nil
 }
 
-// UpdateEgressACLOps return operation that creates an egress ACL
-func (c *OVNNbClient) UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol string, npp []netv1.NetworkPolicyPort, logEnable bool, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
+// UpdateEgressACLOps return operation that creates an egress ACL, the acls are not logged if logConfig is nil
+func (c *OVNNbClient) UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol string, npp []netv1.NetworkPolicyPort, logConfig *ACLLogConfig, namedPortMap map[string]*util.NamedPortInfo) ([]ovsdb.Operation, error) {
 	acls := make([]*ovnnb.ACL, 0)
 
 	if strings.HasSuffix(asEgressName, ".0") || strings.HasSuffix(asEgressName, ".all") {
@@ -85,18 +154,17 @@ func (c *OVNNbClient) UpdateEgressACLOps(pgName, asEgressName, asExceptName, pro
 			NewACLMatch(ipSuffix, "", "", ""),
 		)
 		options := func(acl *ovnnb.ACL) {
-			if logEnable {
-				acl.Log = true
-				acl.Severity = &ovnnb.ACLSeverityWarning
-			}
-
 			if acl.Options == nil {
 				acl.Options = make(map[string]string)
 			}
 			acl.Options["apply-after-lb"] = "true"
 		}
 
-		defaultDropACL, err := c.newACLWithoutCheck(pgName, ovnnb.ACLDirectionFromLport, util.EgressDefaultDrop, allIPMatch.String(), ovnnb.ACLActionDrop, options)
+		priority := util.EgressDefaultDrop
+		if logConfig.audit() {
+			priority = util.EgressAuditPriority
+		}
+		defaultDropACL, err := c.newACLWithoutCheck(pgName, ovnnb.ACLDirectionFromLport, priority, allIPMatch.String(), ovnnb.ACLActionDrop, options, logConfig.options(ovnnb.ACLDirectionFromLport, false))
 		if err != nil {
 			klog.Error(err)
 			return nil, fmt.Errorf("new default drop egress acl for port group %s: %v", pgName, err)
@@ -113,7 +181,7 @@ func (c *OVNNbClient) UpdateEgressACLOps(pgName, asEgressName, asExceptName, pro
 				acl.Options = make(map[string]string)
 			}
 			acl.Options["apply-after-lb"] = "true"
-		})
+		}, logConfig.options(ovnnb.ACLDirectionFromLport, true))
 		if err != nil {
 			klog.Error(err)
 			return nil, fmt.Errorf("new allow egress acl for port group %s: %v", pgName, err)
@@ -131,10 +199,95 @@ func (c *OVNNbClient) UpdateEgressACLOps(pgName, asEgressName, asExceptName, pro
 	return ops, nil
 }
 
-// CreateGatewayACL create allow acl for subnet gateway
-func (c *OVNNbClient) CreateGatewayACL(lsName, pgName, gateway string) error {
+// UpdateEgressFqdnACLOps return operations that create the egress acls allowing traffic to the addresses of fqdns
+func (c *OVNNbClient) UpdateEgressFqdnACLOps(pgName, protocol string, fqdns []string) ([]ovsdb.Operation, error) {
+	ipSuffix := "ip4"
+	if protocol == kubeovnv1.ProtocolIPv6 {
+		ipSuffix = "ip6"
+	}
+
+	acls := make([]*ovnnb.ACL, 0, len(fqdns))
+	for _, fqdn := range fqdns {
+		asName := GetFqdnV4AddressSetName(fqdn)
+		if protocol == kubeovnv1.ProtocolIPv6 {
+			asName = GetFqdnV6AddressSetName(fqdn)
+		}
+
+		match := NewAndACLMatch(
+			NewACLMatch("inport", "==", "@"+pgName, ""),
+			NewACLMatch("ip", "", "", ""),
+			NewACLMatch(ipSuffix+".dst", "==", "$"+asName, ""),
+		)
+		acl, err := c.newACLWithoutCheck(pgName, ovnnb.ACLDirectionFromLport, util.EgressAllowPriority, match.String(), ovnnb.ACLActionAllowRelated, func(acl *ovnnb.ACL) {
+			if acl.Options == nil {
+				acl.Options = make(map[string]string)
+			}
+			acl.Options["apply-after-lb"] = "true"
+		})
+		if err != nil {
+			klog.Error(err)
+			return nil, fmt.Errorf("new fqdn egress acl for port group %s: %v", pgName, err)
+		}
+		acls = append(acls, acl)
+	}
+
+	ops, err := c.CreateAclsOps(pgName, portGroupKey, acls...)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+
+	return ops, nil
+}
+
+// UpdateAnpRuleACLOps return operations that create the acls of an admin network policy rule,
+// traffic matching any of the excludes has been passed to network policies by rules evaluated before
+func (c *OVNNbClient) UpdateAnpRuleACLOps(pgName, asName, protocol string, priority int, aclAction ovnnb.ACLAction, logEnable, isIngress bool, rulePorts []anpv1alpha1.AdminNetworkPolicyPort, namedPortMap map[string]*util.NamedPortInfo, excludes []string) ([]ovsdb.Operation, error) {
+	direction := ovnnb.ACLDirectionToLport
+	if !isIngress {
+		direction = ovnnb.ACLDirectionFromLport
+	}
+
+	options := func(acl *ovnnb.ACL) {
+		if logEnable {
+			acl.Log = true
+			if aclAction == ovnnb.ACLActionDrop {
+				acl.Severity = &ovnnb.ACLSeverityWarning
+			}
+		}
+
+		if !isIngress {
+			if acl.Options == nil {
+				acl.Options = make(map[string]string)
+			}
+			acl.Options["apply-after-lb"] = "true"
+		}
+	}
+
 	acls := make([]*ovnnb.ACL, 0)
+	for _, m := range NewAnpACLMatch(pgName, asName, protocol, direction, rulePorts, namedPortMap) {
+		for _, exclude := range excludes {
+			m = fmt.Sprintf("%s && !(%s)", m, exclude)
+		}
+		acl, err := c.newACLWithoutCheck(pgName, direction, strconv.Itoa(priority), m, aclAction, options)
+		if err != nil {
+			klog.Error(err)
+			return nil, fmt.Errorf("new admin network policy acl for port group %s: %v", pgName, err)
+		}
+		acls = append(acls, acl)
+	}
+
+	ops, err := c.CreateAclsOps(pgName, portGroupKey, acls...)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
 
+	return ops, nil
+}
+
+// CreateGatewayACL create allow acl for subnet gateway
+func (c *OVNNbClient) CreateGatewayACL(lsName, pgName, gateway string) error {
 	var parentName, parentType string
 	switch {
 	case len(pgName) != 0:
@@ -145,6 +298,40 @@ func (c *OVNNbClient) CreateGatewayACL(lsName, pgName, gateway string) error {
 		return fmt.Errorf("one of port group name and logical switch name must be specified")
 	}
 
+	acls, err := c.gatewayACLs(parentName, gateway, c.newACL)
+	if err != nil {
+		return err
+	}
+
+	if err := c.CreateAcls(parentName, parentType, acls...); err != nil {
+		return fmt.Errorf("add gateway acls to %s: %v", pgName, err)
+	}
+
+	return nil
+}
+
+// UpdateGatewayACLOps return operations that create the allow acls for subnet gateways of the port group,
+// the existing acls are not checked so the operations should be transacted along with the ones clearing the acls
+func (c *OVNNbClient) UpdateGatewayACLOps(pgName, gateway string) ([]ovsdb.Operation, error) {
+	acls, err := c.gatewayACLs(pgName, gateway, c.newACLWithoutCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	ops, err := c.CreateAclsOps(pgName, portGroupKey, acls...)
+	if err != nil {
+		klog.Error(err)
+		return nil, err
+	}
+
+	return ops, nil
+}
+
+// gatewayACLs returns the acls allowing the traffic from and to the gateways
+func (c *OVNNbClient) gatewayACLs(parentName, gateway string, newACL func(parent, direction, priority, match, action string, options ...func(acl *ovnnb.ACL)) (*ovnnb.ACL, error)) ([]*ovnnb.ACL, error) {
+	acls := make([]*ovnnb.ACL, 0)
+
+	var hasNdACL bool
 	for _, gw := range strings.Split(gateway, ",") {
 		protocol := util.CheckProtocol(gw)
 		ipSuffix := "ip4"
@@ -152,10 +339,10 @@ func (c *OVNNbClient) CreateGatewayACL(lsName, pgName, gateway string) error {
 			ipSuffix = "ip6"
 		}
 
-		allowIngressACL, err := c.newACL(parentName, ovnnb.ACLDirectionToLport, util.IngressAllowPriority, fmt.Sprintf("%s.src == %s", ipSuffix, gw), ovnnb.ACLActionAllowStateless)
+		allowIngressACL, err := newACL(parentName, ovnnb.ACLDirectionToLport, util.IngressAllowPriority, fmt.Sprintf("%s.src == %s", ipSuffix, gw), ovnnb.ACLActionAllowStateless)
 		if err != nil {
 			klog.Error(err)
-			return fmt.Errorf("new allow ingress acl for %s: %v", parentName, err)
+			return nil, fmt.Errorf("new allow ingress acl for %s: %v", parentName, err)
 		}
 
 		options := func(acl *ovnnb.ACL) {
@@ -165,31 +352,28 @@ func (c *OVNNbClient) CreateGatewayACL(lsName, pgName, gateway string) error {
 			acl.Options["apply-after-lb"] = "true"
 		}
 
-		allowEgressACL, err := c.newACL(parentName, ovnnb.ACLDirectionFromLport, util.EgressAllowPriority, fmt.Sprintf("%s.dst == %s", ipSuffix, gw), ovnnb.ACLActionAllowStateless, options)
+		allowEgressACL, err := newACL(parentName, ovnnb.ACLDirectionFromLport, util.EgressAllowPriority, fmt.Sprintf("%s.dst == %s", ipSuffix, gw), ovnnb.ACLActionAllowStateless, options)
 		if err != nil {
 			klog.Error(err)
-			return fmt.Errorf("new allow egress acl for %s: %v", parentName, err)
+			return nil, fmt.Errorf("new allow egress acl for %s: %v", parentName, err)
 		}
 
 		acls = append(acls, allowIngressACL, allowEgressACL)
 
-		if ipSuffix == "ip6" {
-			ndACL, err := c.newACL(parentName, ovnnb.ACLDirectionFromLport, util.EgressAllowPriority, "nd || nd_ra || nd_rs", ovnnb.ACLActionAllowStateless, options)
+		// the nd acl is shared by all the ipv6 gateways
+		if ipSuffix == "ip6" && !hasNdACL {
+			ndACL, err := newACL(parentName, ovnnb.ACLDirectionFromLport, util.EgressAllowPriority, "nd || nd_ra || nd_rs", ovnnb.ACLActionAllowStateless, options)
 			if err != nil {
 				klog.Error(err)
-				return fmt.Errorf("new nd acl for %s: %v", parentName, err)
+				return nil, fmt.Errorf("new nd acl for %s: %v", parentName, err)
 			}
 
 			acls = append(acls, ndACL)
+			hasNdACL = true
 		}
-
 	}
 
-	if err := c.CreateAcls(parentName, parentType, acls...); err != nil {
-		return fmt.Errorf("add gateway acls to %s: %v", pgName, err)
-	}
-
-	return nil
+	return acls, nil
 }
 
 // CreateNodeACL create allow acl for node join ip
@@ -363,10 +547,44 @@ func (c *OVNNbClient) UpdateSgACL(sg *kubeovnv1.SecurityGroup, direction string)
 	pgName := GetSgPortGroupName(sg.Name)
 
 	// clear acl
-	if err := c.DeleteAcls(pgName, portGroupKey, direction, nil); err != nil {
-		return fmt.Errorf("delete direction '%s' acls from port group %s: %v", direction, pgName, err)
+	ops, err := c.DeleteAclsOps(pgName, portGroupKey, direction, nil)
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("generate operations for deleting direction '%s' acls from port group %s: %v", direction, pgName, err)
+	}
+
+	directions := []string{direction}
+	if direction == "" {
+		directions = []string{ovnnb.ACLDirectionToLport, ovnnb.ACLDirectionFromLport}
+	}
+
+	acls := make([]*ovnnb.ACL, 0, 2)
+	for _, direction := range directions {
+		directionAcls, err := c.newSgACLs(sg, direction)
+		if err != nil {
+			klog.Error(err)
+			return err
+		}
+		acls = append(acls, directionAcls...)
+	}
+
+	createOps, err := c.CreateAclsOps(pgName, portGroupKey, acls...)
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("generate operations for adding acls to port group %s: %v", pgName, err)
+	}
+
+	// put clear and add acl in a single transaction so that switching between audit and enforce is atomic
+	if err = c.Transact("acls-update", append(ops, createOps...)); err != nil {
+		return fmt.Errorf("update acls of port group %s: %v", pgName, err)
 	}
 
+	return nil
+}
+
+// newSgACLs returns the acls of the security group in the direction
+func (c *OVNNbClient) newSgACLs(sg *kubeovnv1.SecurityGroup, direction string) ([]*ovnnb.ACL, error) {
+	pgName := GetSgPortGroupName(sg.Name)
 	acls := make([]*ovnnb.ACL, 0, 2)
 
 	// ingress rule
@@ -377,6 +595,15 @@ func (c *OVNNbClient) UpdateSgACL(sg *kubeovnv1.SecurityGroup, direction string)
 		sgRules = sg.Spec.EgressRules
 	}
 
+	// the ports of security groups in audit mode are not added to the deny all port group,
+	// and all the acls are placed below the drop acls of other policies
+	var logConfig *ACLLogConfig
+	highestPriority, allowPriority := util.SecurityGroupHighestPriority, util.SecurityGroupAllowPriority
+	if sg.Spec.Audit {
+		logConfig = &ACLLogConfig{Policy: sg.Name, Meter: GetACLLogMeterName(pgName), Audit: true}
+		highestPriority, allowPriority = util.SecurityGroupAuditHighestPriority, util.SecurityGroupAuditAllowPriority
+	}
+
 	/* create port_group associated acl */
 	if sg.Spec.AllowSameGroupTraffic {
 		asName := GetSgV4AssociatedName(sg.Name)
@@ -390,10 +617,10 @@ func (c *OVNNbClient) UpdateSgACL(sg *kubeovnv1.SecurityGroup, direction string)
 				NewACLMatch(ipSuffix, "", "", ""),
 				NewACLMatch(ipSuffix+"."+srcOrDst, "==", "$"+asName, ""),
 			)
-			acl, err := c.newACL(pgName, direction, util.SecurityGroupAllowPriority, match.String(), ovnnb.ACLActionAllowRelated)
+			acl, err := c.newACLWithoutCheck(pgName, direction, allowPriority, match.String(), ovnnb.ACLActionAllowRelated)
 			if err != nil {
 				klog.Error(err)
-				return fmt.Errorf("new allow acl for security group %s: %v", sg.Name, err)
+				return nil, fmt.Errorf("new allow acl for security group %s: %v", sg.Name, err)
 			}
 
 			acls = append(acls, acl)
@@ -402,19 +629,44 @@ func (c *OVNNbClient) UpdateSgACL(sg *kubeovnv1.SecurityGroup, direction string)
 
 	/* create rule acl */
 	for _, rule := range sgRules {
-		acl, err := c.newSgRuleACL(sg.Name, direction, rule)
+		acl, err := c.newSgRuleACL(sg.Name, direction, highestPriority, rule)
 		if err != nil {
 			klog.Error(err)
-			return fmt.Errorf("new rule acl for security group %s: %v", sg.Name, err)
+			return nil, fmt.Errorf("new rule acl for security group %s: %v", sg.Name, err)
+		}
+		if acl.Action == ovnnb.ACLActionDrop {
+			logConfig.options(direction, false)(acl)
 		}
 		acls = append(acls, acl)
 	}
 
-	if err := c.CreateAcls(pgName, portGroupKey, acls...); err != nil {
-		return fmt.Errorf("add acl to port group %s: %v", pgName, err)
+	/* create audit acl which logs the traffic to be dropped by the deny all security group */
+	if sg.Spec.Audit {
+		for _, ipSuffix := range []string{"ip4", "ip6"} {
+			match := NewAndACLMatch(
+				NewACLMatch(portDirection, "==", "@"+pgName, ""),
+				NewACLMatch(ipSuffix, "", "", ""),
+			)
+			if sg.Spec.AllowSameGroupTraffic {
+				asName := GetSgV4AssociatedName(sg.Name)
+				if ipSuffix == "ip6" {
+					asName = GetSgV6AssociatedName(sg.Name)
+				}
+				// exclude the traffic allowed by the port_group associated acl with the same priority
+				match = NewAndACLMatch(match, NewACLMatch(ipSuffix+"."+srcOrDst, "!=", "$"+asName, ""))
+			}
+
+			acl, err := c.newACLWithoutCheck(pgName, direction, allowPriority, match.String(), ovnnb.ACLActionAllowRelated, logConfig.options(direction, false))
+			if err != nil {
+				klog.Error(err)
+				return nil, fmt.Errorf("new audit acl for security group %s: %v", sg.Name, err)
+			}
+
+			acls = append(acls, acl)
+		}
 	}
 
-	return nil
+	return acls, nil
 }
 
 func (c *OVNNbClient) UpdateLogicalSwitchACL(lsName, cidrBlock string, subnetAcls []kubeovnv1.ACL, allowEWTraffic bool) error {
@@ -435,8 +687,9 @@ func (c *OVNNbClient) UpdateLogicalSwitchACL(lsName, cidrBlock string, subnetAcl
 	}
 
 	if allowEWTraffic {
-		for _, cidr := range strings.Split(cidrBlock, ",") {
-			protocol := util.CheckProtocol(cidr)
+		protocols, cidrs := cidrBlocksByProtocol(cidrBlock)
+		for _, protocol := range protocols {
+			cidr := cidrs[protocol]
 
 			ipSuffix := "ip4"
 			if protocol == kubeovnv1.ProtocolIPv6 {
@@ -577,8 +830,10 @@ func (c *OVNNbClient) SetLogicalSwitchPrivate(lsName, cidrBlock, nodeSwitchCIDR
 		return nil
 	}
 
-	for _, cidr := range strings.Split(cidrBlock, ",") {
-		protocol := util.CheckProtocol(cidr)
+	// the cidr blocks of the same protocol are matched together so that the traffic between them is allowed
+	protocols, cidrs := cidrBlocksByProtocol(cidrBlock)
+	for _, protocol := range protocols {
+		cidr := cidrs[protocol]
 
 		ipSuffix := "ip4"
 		if protocol == kubeovnv1.ProtocolIPv6 {
@@ -620,6 +875,32 @@ func (c *OVNNbClient) SetLogicalSwitchPrivate(lsName, cidrBlock, nodeSwitchCIDR
 	return nil
 }
 
+// cidrBlocksByProtocol groups the cidr blocks by protocol in the order they appear, the cidr blocks of
+// the same protocol are returned as a set like '{10.16.0.0/16, 10.17.0.0/16}' to be used in acl matches
+func cidrBlocksByProtocol(cidrBlock string) ([]string, map[string]string) {
+	var protocols []string
+	blocks := make(map[string][]string)
+	for _, cidr := range strings.Split(cidrBlock, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr == "" {
+			continue
+		}
+		protocol := util.CheckProtocol(cidr)
+		if _, ok := blocks[protocol]; !ok {
+			protocols = append(protocols, protocol)
+		}
+		blocks[protocol] = append(blocks[protocol], cidr)
+	}
+
+	cidrs := make(map[string]string, len(blocks))
+	for protocol, block := range blocks {
+		cidrs[protocol] = block[0]
+		if len(block) > 1 {
+			cidrs[protocol] = "{" + strings.Join(block, ", ") + "}"
+		}
+	}
+	return protocols, cidrs
+}
+
 func (c *OVNNbClient) SetACLLog(pgName, protocol string, logEnable, isIngress bool) error {
 	direction := ovnnb.ACLDirectionToLport
 	portDirection := "outport"
@@ -882,7 +1163,7 @@ func (c *OVNNbClient) newACLWithoutCheck(parent, direction, priority, match, act
 }
 
 // createSgRuleACL create security group rule acl
-func (c *OVNNbClient) newSgRuleACL(sgName, direction string, rule *kubeovnv1.SgRule) (*ovnnb.ACL, error) {
+func (c *OVNNbClient) newSgRuleACL(sgName, direction, highestPriority string, rule *kubeovnv1.SgRule) (*ovnnb.ACL, error) {
 	ipSuffix := "ip4"
 	if rule.IPVersion == "ipv6" {
 		ipSuffix = "ip6"
@@ -924,37 +1205,76 @@ func (c *OVNNbClient) newSgRuleACL(sgName, direction string, rule *kubeovnv1.SgR
 		)
 	}
 
+	// type fqdn
+	if rule.RemoteType == kubeovnv1.SgRemoteTypeFqdn {
+		fqdnAsName := GetFqdnV4AddressSetName(rule.RemoteAddress)
+		if rule.IPVersion == "ipv6" {
+			fqdnAsName = GetFqdnV6AddressSetName(rule.RemoteAddress)
+		}
+		allowedIPMatch = NewAndACLMatch(
+			allIPMatch,
+			NewACLMatch(ipKey, "==", "$"+fqdnAsName, ""),
+		)
+	}
+
+	// type addressGroup
+	if rule.RemoteType == kubeovnv1.SgRemoteTypeAddressGroup {
+		agAsName := GetAddressGroupV4AddressSetName(rule.RemoteAddressGroup)
+		if rule.IPVersion == "ipv6" {
+			agAsName = GetAddressGroupV6AddressSetName(rule.RemoteAddressGroup)
+		}
+		allowedIPMatch = NewAndACLMatch(
+			allIPMatch,
+			NewACLMatch(ipKey, "==", "$"+agAsName, ""),
+		)
+	}
+
 	/* allow layer 4 traffic */
 	// allow all layer 4 traffic
 	match := allowedIPMatch
 
 	switch rule.Protocol {
 	case kubeovnv1.ProtocolICMP:
-		match = NewAndACLMatch(
-			allowedIPMatch,
-			NewACLMatch("icmp4", "", "", ""),
-		)
+		icmpKey := "icmp4"
 		if ipSuffix == "ip6" {
-			match = NewAndACLMatch(
-				allowedIPMatch,
-				NewACLMatch("icmp6", "", "", ""),
-			)
+			icmpKey = "icmp6"
+		}
+		icmpMatches := []ACLMatch{allowedIPMatch, NewACLMatch(icmpKey, "", "", "")}
+		if rule.IcmpType != nil {
+			icmpMatches = append(icmpMatches, NewACLMatch(icmpKey+".type", "==", strconv.Itoa(*rule.IcmpType), ""))
+		}
+		if rule.IcmpCode != nil {
+			icmpMatches = append(icmpMatches, NewACLMatch(icmpKey+".code", "==", strconv.Itoa(*rule.IcmpCode), ""))
 		}
+		match = NewAndACLMatch(icmpMatches...)
 	case kubeovnv1.ProtocolTCP, kubeovnv1.ProtocolUDP:
-		match = NewAndACLMatch(
-			allowedIPMatch,
-			NewACLMatch(string(rule.Protocol)+".dst", "<=", strconv.Itoa(rule.PortRangeMin), strconv.Itoa(rule.PortRangeMax)),
-		)
+		portRanges := util.GetSgRulePortRanges(rule)
+		portMatches := make([]ACLMatch, 0, len(portRanges))
+		for _, portRange := range portRanges {
+			portMatches = append(portMatches, NewACLMatch(string(rule.Protocol)+".dst", "<=", strconv.Itoa(portRange.PortRangeMin), strconv.Itoa(portRange.PortRangeMax)))
+		}
+		portMatch := portMatches[0]
+		if len(portMatches) > 1 {
+			portMatch = NewOrACLMatch(portMatches...)
+		}
+		match = NewAndACLMatch(allowedIPMatch, portMatch)
 	}
 
 	action := ovnnb.ACLActionDrop
 	if rule.Policy == kubeovnv1.PolicyAllow {
 		action = ovnnb.ACLActionAllowRelated
+		if rule.Stateless {
+			action = ovnnb.ACLActionAllowStateless
+		}
 	}
 
-	highestPriority, _ := strconv.Atoi(util.SecurityGroupHighestPriority)
+	highest, _ := strconv.Atoi(highestPriority)
 
-	acl, err := c.newACL(pgName, direction, strconv.Itoa(highestPriority-rule.Priority), match.String(), action)
+	acl, err := c.newACLWithoutCheck(pgName, direction, strconv.Itoa(highest-rule.Priority), match.String(), action, func(acl *ovnnb.ACL) {
+		if rule.Description != "" {
+			acl.ExternalIDs["description"] = rule.Description
+		}
+	})
 	if err != nil {
 		klog.Error(err)
 		return nil, fmt.Errorf("new security group acl for port group %s: %v", pgName, err)
@@ -1051,6 +1371,68 @@ func newNetworkPolicyACLMatch(pgName, asAllowName, asExceptName, protocol, direc
 	return matches
 }
 
+// NewAnpACLMatch return the matches of an admin network policy rule, one for each port
+func NewAnpACLMatch(pgName, asName, protocol, direction string, rulePorts []anpv1alpha1.AdminNetworkPolicyPort, namedPortMap map[string]*util.NamedPortInfo) []string {
+	ipSuffix := "ip4"
+	if protocol == kubeovnv1.ProtocolIPv6 {
+		ipSuffix = "ip6"
+	}
+
+	// ingress rule
+	srcOrDst, portDirection := "src", "outport"
+	if direction == ovnnb.ACLDirectionFromLport { // egress rule
+		srcOrDst = "dst"
+		portDirection = "inport"
+	}
+
+	selectedIPMatch := NewAndACLMatch(
+		NewACLMatch(portDirection, "==", "@"+pgName, ""),
+		NewACLMatch("ip", "", "", ""),
+		NewACLMatch(ipSuffix+"."+srcOrDst, "==", "$"+asName, ""),
+	)
+
+	if len(rulePorts) == 0 {
+		return []string{selectedIPMatch.String()}
+	}
+
+	matches := make([]string, 0, len(rulePorts))
+	for _, port := range rulePorts {
+		switch {
+		case port.PortNumber != nil:
+			protocol := strings.ToLower(string(port.PortNumber.Protocol))
+			matches = append(matches, NewAndACLMatch(
+				selectedIPMatch,
+				NewACLMatch(protocol+".dst", "==", strconv.Itoa(int(port.PortNumber.Port)), ""),
+			).String())
+		case port.PortRange != nil:
+			protocol := strings.ToLower(string(port.PortRange.Protocol))
+			if protocol == "" {
+				protocol = "tcp"
+			}
+			matches = append(matches, NewAndACLMatch(
+				selectedIPMatch,
+				NewACLMatch(protocol+".dst", "<=", strconv.Itoa(int(port.PortRange.Start)), strconv.Itoa(int(port.PortRange.End))),
+			).String())
+		case port.NamedPort != nil:
+			namedPort, ok := namedPortMap[*port.NamedPort]
+			if !ok {
+				// the rule selects nothing if no pod exposes the named port
+				klog.Errorf("no named port with name %s found", *port.NamedPort)
+				continue
+			}
+			// the protocol of named ports is not recorded, match all of the layer 4 protocols
+			for _, protocol := range []string{"tcp", "udp", "sctp"} {
+				matches = append(matches, NewAndACLMatch(
+					selectedIPMatch,
+					NewACLMatch(protocol+".dst", "==", strconv.Itoa(int(namedPort.PortID)), ""),
+				).String())
+			}
+		}
+	}
+
+	return matches
+}
+
 // aclFilter filter acls which match the given externalIDs,
 // result should include all to-lport and from-lport acls when direction is empty,
 // result should include all acls when externalIDs is empty,
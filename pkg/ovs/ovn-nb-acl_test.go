@@ -12,6 +12,7 @@ import (
 	netv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	anpv1alpha1 "sigs.k8s.io/network-policy-api/apis/v1alpha1"
 
 	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
 	ovsclient "github.com/kubeovn/kube-ovn/pkg/ovsdb/client"
@@ -90,11 +91,17 @@ func (suite *OvnClientTestSuite) testUpdateIngressACLOps() {
 
 		npp := mockNetworkPolicyPort()
 
-		ops, err := ovnClient.UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol, npp, true, nil)
+		logConfig := &ACLLogConfig{Policy: "default/test", Rule: "0", LogDrop: true, Meter: "acl.log.test"}
+		ops, err := ovnClient.UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol, npp, logConfig, nil)
 		require.NoError(t, err)
 		require.Len(t, ops, 4)
 
 		expect(ops[0].Row, "drop", ovnnb.ACLDirectionToLport, fmt.Sprintf("outport == @%s && ip4", pgName), util.IngressDefaultDrop)
+		require.Equal(t, true, ops[0].Row["log"])
+		require.Equal(t, ovsdb.OvsSet{GoSet: []interface{}{"default/test/ingress/drop"}}, ops[0].Row["name"])
+		require.Equal(t, ovsdb.OvsSet{GoSet: []interface{}{"acl.log.test"}}, ops[0].Row["meter"])
+		require.Equal(t, false, ops[1].Row["log"])
+		require.Equal(t, ovsdb.OvsSet{GoSet: []interface{}{"default/test/ingress/0"}}, ops[1].Row["name"])
 
 		matches := newNetworkPolicyACLMatch(pgName, asIngressName, asExceptName, protocol, ovnnb.ACLDirectionToLport, npp, nil)
 		i := 1
@@ -116,9 +123,10 @@ func (suite *OvnClientTestSuite) testUpdateIngressACLOps() {
 		err := ovnClient.CreatePortGroup(pgName, nil)
 		require.NoError(t, err)
 
-		ops, err := ovnClient.UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol, nil, true, nil)
+		ops, err := ovnClient.UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol, nil, nil, nil)
 		require.NoError(t, err)
 		require.Len(t, ops, 3)
+		require.Equal(t, false, ops[0].Row["log"])
 
 		expect(ops[0].Row, "drop", ovnnb.ACLDirectionToLport, fmt.Sprintf("outport == @%s && ip6", pgName), util.IngressDefaultDrop)
 
@@ -130,6 +138,31 @@ func (suite *OvnClientTestSuite) testUpdateIngressACLOps() {
 			i++
 		}
 	})
+
+	t.Run("audit acl", func(t *testing.T) {
+		t.Parallel()
+
+		pgName := "test_create_audit_ingress_acl_pg"
+		asIngressName := "test.audit.ingress.allow.ipv4.0"
+		asExceptName := "test.audit.ingress.except.ipv4.0"
+		protocol := kubeovnv1.ProtocolIPv4
+
+		err := ovnClient.CreatePortGroup(pgName, nil)
+		require.NoError(t, err)
+
+		logConfig := &ACLLogConfig{Policy: "default/audit", Rule: "0", Meter: "acl.log.audit", Audit: true}
+		ops, err := ovnClient.UpdateIngressACLOps(pgName, asIngressName, asExceptName, protocol, nil, logConfig, nil)
+		require.NoError(t, err)
+		require.Len(t, ops, 3)
+
+		expect(ops[0].Row, ovnnb.ACLActionAllowRelated, ovnnb.ACLDirectionToLport, fmt.Sprintf("outport == @%s && ip4", pgName), util.IngressAuditPriority)
+		require.Equal(t, true, ops[0].Row["log"])
+		require.Equal(t, ovsdb.OvsSet{GoSet: []interface{}{"default/audit/ingress/audit"}}, ops[0].Row["name"])
+		require.Equal(t, ovsdb.OvsSet{GoSet: []interface{}{"acl.log.audit"}}, ops[0].Row["meter"])
+		require.Equal(t, ovsdb.OvsSet{GoSet: []interface{}{ovnnb.ACLSeverityWarning}}, ops[0].Row["severity"])
+		require.Equal(t, false, ops[1].Row["log"])
+		expect(ops[1].Row, ovnnb.ACLActionAllowRelated, ovnnb.ACLDirectionToLport, ops[1].Row["match"].(string), util.IngressAllowPriority)
+	})
 }
 
 func (suite *OvnClientTestSuite) testUpdateEgressACLOps() {
@@ -160,11 +193,17 @@ func (suite *OvnClientTestSuite) testUpdateEgressACLOps() {
 
 		npp := mockNetworkPolicyPort()
 
-		ops, err := ovnClient.UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol, npp, true, nil)
+		logConfig := &ACLLogConfig{Policy: "default/test", Rule: "0", LogAllow: true, LogDrop: true}
+		ops, err := ovnClient.UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol, npp, logConfig, nil)
 		require.NoError(t, err)
 		require.Len(t, ops, 4)
 
 		expect(ops[0].Row, "drop", ovnnb.ACLDirectionFromLport, fmt.Sprintf("inport == @%s && ip4", pgName), util.EgressDefaultDrop)
+		require.Equal(t, true, ops[0].Row["log"])
+		require.Equal(t, ovsdb.OvsSet{GoSet: []interface{}{"default/test/egress/drop"}}, ops[0].Row["name"])
+		require.Equal(t, true, ops[1].Row["log"])
+		require.Equal(t, ovsdb.OvsSet{GoSet: []interface{}{ovnnb.ACLSeverityInfo}}, ops[1].Row["severity"])
+		require.Equal(t, ovsdb.OvsSet{GoSet: []interface{}{"default/test/egress/0"}}, ops[1].Row["name"])
 
 		matches := newNetworkPolicyACLMatch(pgName, asEgressName, asExceptName, protocol, ovnnb.ACLDirectionFromLport, npp, nil)
 		i := 1
@@ -186,9 +225,10 @@ func (suite *OvnClientTestSuite) testUpdateEgressACLOps() {
 		err := ovnClient.CreatePortGroup(pgName, nil)
 		require.NoError(t, err)
 
-		ops, err := ovnClient.UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol, nil, true, nil)
+		ops, err := ovnClient.UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol, nil, nil, nil)
 		require.NoError(t, err)
 		require.Len(t, ops, 3)
+		require.Equal(t, false, ops[0].Row["log"])
 
 		expect(ops[0].Row, "drop", ovnnb.ACLDirectionFromLport, fmt.Sprintf("inport == @%s && ip6", pgName), util.EgressDefaultDrop)
 
@@ -200,6 +240,142 @@ func (suite *OvnClientTestSuite) testUpdateEgressACLOps() {
 			i++
 		}
 	})
+
+	t.Run("audit acl", func(t *testing.T) {
+		t.Parallel()
+
+		pgName := "test_create_audit_egress_acl_pg"
+		asEgressName := "test.audit.egress.allow.ipv4.0"
+		asExceptName := "test.audit.egress.except.ipv4.0"
+		protocol := kubeovnv1.ProtocolIPv4
+
+		err := ovnClient.CreatePortGroup(pgName, nil)
+		require.NoError(t, err)
+
+		logConfig := &ACLLogConfig{Policy: "default/audit", Rule: "0", Audit: true}
+		ops, err := ovnClient.UpdateEgressACLOps(pgName, asEgressName, asExceptName, protocol, nil, logConfig, nil)
+		require.NoError(t, err)
+		require.Len(t, ops, 3)
+
+		expect(ops[0].Row, ovnnb.ACLActionAllowRelated, ovnnb.ACLDirectionFromLport, fmt.Sprintf("inport == @%s && ip4", pgName), util.EgressAuditPriority)
+		require.Equal(t, true, ops[0].Row["log"])
+		require.Equal(t, ovsdb.OvsSet{GoSet: []interface{}{"default/audit/egress/audit"}}, ops[0].Row["name"])
+		require.Equal(t, false, ops[1].Row["log"])
+	})
+}
+
+func (suite *OvnClientTestSuite) testUpdateEgressFqdnACLOps() {
+	t := suite.T()
+	t.Parallel()
+
+	ovnClient := suite.ovnClient
+	pgName := "test_create_fqdn_egress_acl_pg"
+	fqdns := []string{"api.example.com", "*.example.org"}
+
+	err := ovnClient.CreatePortGroup(pgName, nil)
+	require.NoError(t, err)
+
+	t.Run("ipv4 acl", func(t *testing.T) {
+		t.Parallel()
+
+		ops, err := ovnClient.UpdateEgressFqdnACLOps(pgName, kubeovnv1.ProtocolIPv4, fqdns)
+		require.NoError(t, err)
+		require.Len(t, ops, 3)
+
+		for i, fqdn := range fqdns {
+			require.Equal(t, ovnnb.ACLActionAllowRelated, ops[i].Row["action"])
+			require.Equal(t, ovnnb.ACLDirectionFromLport, ops[i].Row["direction"])
+			require.Equal(t, fmt.Sprintf("inport == @%s && ip && ip4.dst == $%s", pgName, GetFqdnV4AddressSetName(fqdn)), ops[i].Row["match"])
+			require.Equal(t, ovsdb.OvsMap{GoMap: map[interface{}]interface{}{"apply-after-lb": "true"}}, ops[i].Row["options"])
+		}
+	})
+
+	t.Run("ipv6 acl", func(t *testing.T) {
+		t.Parallel()
+
+		ops, err := ovnClient.UpdateEgressFqdnACLOps(pgName, kubeovnv1.ProtocolIPv6, fqdns[:1])
+		require.NoError(t, err)
+		require.Len(t, ops, 2)
+		require.Equal(t, fmt.Sprintf("inport == @%s && ip && ip6.dst == $%s", pgName, GetFqdnV6AddressSetName(fqdns[0])), ops[0].Row["match"])
+	})
+}
+
+func (suite *OvnClientTestSuite) testUpdateAnpRuleACLOps() {
+	t := suite.T()
+	t.Parallel()
+
+	ovnClient := suite.ovnClient
+	pgName := "test_create_anp_rule_acl_pg"
+	asName := "anp.test.ingress.0.ipv4"
+
+	err := ovnClient.CreatePortGroup(pgName, nil)
+	require.NoError(t, err)
+
+	t.Run("ingress deny acl", func(t *testing.T) {
+		t.Parallel()
+
+		ops, err := ovnClient.UpdateAnpRuleACLOps(pgName, asName, kubeovnv1.ProtocolIPv4, 29900, ovnnb.ACLActionDrop, false, true, nil, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, ops, 2)
+
+		require.Equal(t, ovnnb.ACLActionDrop, ops[0].Row["action"])
+		require.Equal(t, ovnnb.ACLDirectionToLport, ops[0].Row["direction"])
+		require.Equal(t, 29900, ops[0].Row["priority"])
+		require.Equal(t, fmt.Sprintf("outport == @%s && ip && ip4.src == $%s", pgName, asName), ops[0].Row["match"])
+	})
+
+	t.Run("egress allow acl with excludes", func(t *testing.T) {
+		t.Parallel()
+
+		excludes := []string{"inport == @anp.pass && ip && ip4.dst == $anp.pass.egress.0.ipv4"}
+		ops, err := ovnClient.UpdateAnpRuleACLOps(pgName, asName, kubeovnv1.ProtocolIPv4, 29899, ovnnb.ACLActionAllowRelated, false, false, nil, nil, excludes)
+		require.NoError(t, err)
+		require.Len(t, ops, 2)
+
+		require.Equal(t, ovnnb.ACLActionAllowRelated, ops[0].Row["action"])
+		require.Equal(t, ovnnb.ACLDirectionFromLport, ops[0].Row["direction"])
+		require.Equal(t, fmt.Sprintf("inport == @%s && ip && ip4.dst == $%s && !(%s)", pgName, asName, excludes[0]), ops[0].Row["match"])
+		require.Equal(t, ovsdb.OvsMap{GoMap: map[interface{}]interface{}{"apply-after-lb": "true"}}, ops[0].Row["options"])
+	})
+}
+
+func (suite *OvnClientTestSuite) testNewAnpACLMatch() {
+	t := suite.T()
+	t.Parallel()
+
+	pgName := "test-new-anp-acl-m-pg"
+	asName := "anp.test.ingress.0.ipv6"
+
+	t.Run("rule without ports", func(t *testing.T) {
+		t.Parallel()
+
+		matches := NewAnpACLMatch(pgName, asName, kubeovnv1.ProtocolIPv6, ovnnb.ACLDirectionToLport, nil, nil)
+		require.Equal(t, []string{fmt.Sprintf("outport == @%s && ip && ip6.src == $%s", pgName, asName)}, matches)
+	})
+
+	t.Run("rule with ports", func(t *testing.T) {
+		t.Parallel()
+
+		namedPort := "http"
+		unknownPort := "unknown"
+		ports := []anpv1alpha1.AdminNetworkPolicyPort{
+			{PortNumber: &anpv1alpha1.Port{Protocol: v1.ProtocolUDP, Port: 53}},
+			{PortRange: &anpv1alpha1.PortRange{Start: 8000, End: 9000}},
+			{NamedPort: &namedPort},
+			{NamedPort: &unknownPort},
+		}
+		namedPortMap := map[string]*util.NamedPortInfo{namedPort: {PortID: 8080}}
+
+		matches := NewAnpACLMatch(pgName, asName, kubeovnv1.ProtocolIPv6, ovnnb.ACLDirectionFromLport, ports, namedPortMap)
+		prefix := fmt.Sprintf("inport == @%s && ip && ip6.dst == $%s", pgName, asName)
+		require.Equal(t, []string{
+			prefix + " && udp.dst == 53",
+			prefix + " && 8000 <= tcp.dst <= 9000",
+			prefix + " && tcp.dst == 8080",
+			prefix + " && udp.dst == 8080",
+			prefix + " && sctp.dst == 8080",
+		}, matches)
+	})
 }
 
 func (suite *OvnClientTestSuite) testCreateGatewayACL() {
@@ -317,6 +493,28 @@ func (suite *OvnClientTestSuite) testCreateGatewayACL() {
 
 			expect(pg, gateway)
 		})
+
+		t.Run("generate operations for several gateways", func(t *testing.T) {
+			t.Parallel()
+
+			pgName := "test_update_gw_acl_pg"
+			gateway := "10.244.0.1,fc00::0af4:01,fc00::0af5:01"
+
+			err := ovnClient.CreatePortGroup(pgName, nil)
+			require.NoError(t, err)
+
+			ops, err := ovnClient.UpdateGatewayACLOps(pgName, gateway)
+			require.NoError(t, err)
+			err = ovnClient.Transact("acls-update", ops)
+			require.NoError(t, err)
+
+			// the nd acl is shared by the ipv6 gateways
+			pg, err := ovnClient.GetPortGroup(pgName, false)
+			require.NoError(t, err)
+			require.Len(t, pg.ACLs, 7)
+
+			expect(pg, gateway)
+		})
 	})
 
 	t.Run("add acl to ls", func(t *testing.T) {
@@ -536,6 +734,40 @@ func (suite *OvnClientTestSuite) testCreateSgBaseACL() {
 	})
 }
 
+func Test_auditACLPriorities(t *testing.T) {
+	t.Parallel()
+
+	priority := func(p string) int {
+		v, err := strconv.Atoi(p)
+		require.NoError(t, err)
+		return v
+	}
+
+	// the audit acls let the packets through, so they must be placed below all the drop acls
+	drops := map[string]int{
+		"security group deny all":              priority(util.SecurityGroupDropPriority),
+		"network policy ingress drop":          priority(util.IngressDefaultDrop),
+		"network policy egress drop":           priority(util.EgressDefaultDrop),
+		"lowest baseline admin network policy": util.BanpACLMaxPriority - util.BanpMaxRules + 1,
+		"subnet default drop":                  priority(util.DefaultDropPriority),
+	}
+	audits := map[string]int{
+		"network policy ingress audit":      priority(util.IngressAuditPriority),
+		"network policy egress audit":       priority(util.EgressAuditPriority),
+		"highest security group audit rule": priority(util.SecurityGroupAuditHighestPriority) - 1,
+		"security group audit allow":        priority(util.SecurityGroupAuditAllowPriority),
+	}
+	for auditName, audit := range audits {
+		for dropName, drop := range drops {
+			require.Less(t, audit, drop, "%s must be lower than %s", auditName, dropName)
+		}
+	}
+
+	// the rules of security groups with priorities from 1 to 200 are placed above the allow acls
+	require.Greater(t, priority(util.SecurityGroupAuditHighestPriority)-200, priority(util.SecurityGroupAuditAllowPriority))
+	require.Greater(t, priority(util.SecurityGroupHighestPriority)-200, priority(util.SecurityGroupAllowPriority))
+}
+
 func (suite *OvnClientTestSuite) testUpdateSgACL() {
 	t := suite.T()
 	t.Parallel()
@@ -647,6 +879,67 @@ func (suite *OvnClientTestSuite) testUpdateSgACL() {
 		require.Equal(t, expect, rulACL)
 		require.Contains(t, pg.ACLs, rulACL.UUID)
 	})
+
+	t.Run("update securityGroup acls in audit mode", func(t *testing.T) {
+		auditSg := sg.DeepCopy()
+		auditSg.Spec.Audit = true
+		auditSg.Spec.IngressRules = append(auditSg.Spec.IngressRules, &kubeovnv1.SgRule{
+			IPVersion:     "ipv4",
+			RemoteType:    kubeovnv1.SgRemoteTypeAddress,
+			RemoteAddress: "10.0.0.0/8",
+			Protocol:      "all",
+			Priority:      20,
+			Policy:        "drop",
+		})
+
+		err = ovnClient.UpdateSgACL(auditSg, "")
+		require.NoError(t, err)
+
+		pg, err := ovnClient.GetPortGroup(pgName, false)
+		require.NoError(t, err)
+		// 2 same group acls, 2 audit acls and the rule acls of each direction
+		require.Len(t, pg.ACLs, 11)
+
+		meter := GetACLLogMeterName(pgName)
+		name := sgName + "/ingress/audit"
+
+		// the acls never override the drop acls of other policies and subnets
+		defaultDrop, _ := strconv.Atoi(util.DefaultDropPriority)
+		sgDrop, _ := strconv.Atoi(util.SecurityGroupDropPriority)
+		for _, uuid := range pg.ACLs {
+			acl := &ovnnb.ACL{UUID: uuid}
+			require.NoError(t, ovnClient.GetEntityInfo(acl))
+			require.Less(t, acl.Priority, defaultDrop)
+			require.Less(t, acl.Priority, sgDrop)
+			require.Less(t, acl.Priority, util.BanpACLMaxPriority-util.BanpMaxRules)
+		}
+
+		// drop rule acl
+		match := fmt.Sprintf("outport == @%s && ip4 && ip4.src == 10.0.0.0/8", pgName)
+		rulACL, err := ovnClient.GetACL(pgName, ovnnb.ACLDirectionToLport, "880", match, false)
+		require.NoError(t, err)
+		expect := newACL(pgName, ovnnb.ACLDirectionToLport, "880", match, ovnnb.ACLActionAllowRelated, func(acl *ovnnb.ACL) {
+			acl.Name = &name
+			acl.Log = true
+			acl.Severity = &ovnnb.ACLSeverityWarning
+			acl.Meter = &meter
+		})
+		expect.UUID = rulACL.UUID
+		require.Equal(t, expect, rulACL)
+
+		// audit acl
+		match = fmt.Sprintf("outport == @%s && ip4 && ip4.src != $%s", pgName, v4AsName)
+		auditACL, err := ovnClient.GetACL(pgName, ovnnb.ACLDirectionToLport, util.SecurityGroupAuditAllowPriority, match, false)
+		require.NoError(t, err)
+		require.True(t, auditACL.Log)
+		require.Equal(t, ovnnb.ACLActionAllowRelated, auditACL.Action)
+		require.Equal(t, name, *auditACL.Name)
+
+		match = fmt.Sprintf("inport == @%s && ip6 && ip6.dst != $%s", pgName, v6AsName)
+		auditACL, err = ovnClient.GetACL(pgName, ovnnb.ACLDirectionFromLport, util.SecurityGroupAuditAllowPriority, match, false)
+		require.NoError(t, err)
+		require.Equal(t, sgName+"/egress/audit", *auditACL.Name)
+	})
 }
 
 func (suite *OvnClientTestSuite) testUpdateLogicalSwitchACL() {
@@ -913,6 +1206,37 @@ func (suite *OvnClientTestSuite) testSetLogicalSwitchPrivate() {
 			}
 		}
 	})
+
+	t.Run("subnet has secondary cidr blocks", func(t *testing.T) {
+		t.Parallel()
+
+		lsName := "test_set_private_ls_secondary"
+		err := ovnClient.CreateBareLogicalSwitch(lsName)
+		require.NoError(t, err)
+
+		cidrBlock := "10.244.0.0/16,10.245.0.0/16,fc00::af4:0/112"
+		err = ovnClient.SetLogicalSwitchPrivate(lsName, cidrBlock, nodeSwitchCidrBlock, allowSubnets)
+		require.NoError(t, err)
+
+		ls, err := ovnClient.GetLogicalSwitch(lsName, false)
+		require.NoError(t, err)
+		require.Len(t, ls.ACLs, 9)
+
+		// the traffic between the cidr blocks of the same protocol is allowed
+		cidr := "{10.244.0.0/16, 10.245.0.0/16}"
+		acl, err := ovnClient.GetACL(lsName, direction, util.SubnetAllowPriority, fmt.Sprintf(`ip4.src == %s && ip4.dst == %s`, cidr, cidr), false)
+		require.NoError(t, err)
+		require.Contains(t, ls.ACLs, acl.UUID)
+
+		match := fmt.Sprintf("(ip4.src == %s && ip4.dst == %s) || (ip4.src == %s && ip4.dst == %s)", cidr, allowSubnets[0], allowSubnets[0], cidr)
+		acl, err = ovnClient.GetACL(lsName, direction, util.SubnetAllowPriority, match, false)
+		require.NoError(t, err)
+		require.Contains(t, ls.ACLs, acl.UUID)
+
+		acl, err = ovnClient.GetACL(lsName, direction, util.NodeAllowPriority, "ip4.src == 100.64.0.0/16", false)
+		require.NoError(t, err)
+		require.Contains(t, ls.ACLs, acl.UUID)
+	})
 }
 
 func (suite *OvnClientTestSuite) testNewSgRuleACL() {
@@ -937,7 +1261,7 @@ func (suite *OvnClientTestSuite) testNewSgRuleACL() {
 		}
 		priority := strconv.Itoa(highestPriority - sgRule.Priority)
 
-		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionToLport, sgRule)
+		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionToLport, util.SecurityGroupHighestPriority, sgRule)
 		require.NoError(t, err)
 
 		match := fmt.Sprintf("outport == @%s && ip4 && ip4.src == $%s && icmp4", pgName, GetSgV4AssociatedName(sgRule.RemoteSecurityGroup))
@@ -959,7 +1283,7 @@ func (suite *OvnClientTestSuite) testNewSgRuleACL() {
 		}
 		priority := strconv.Itoa(highestPriority - sgRule.Priority)
 
-		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionToLport, sgRule)
+		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionToLport, util.SecurityGroupHighestPriority, sgRule)
 		require.NoError(t, err)
 
 		match := fmt.Sprintf("outport == @%s && ip4 && ip4.src == %s && icmp4", pgName, sgRule.RemoteAddress)
@@ -968,6 +1292,30 @@ func (suite *OvnClientTestSuite) testNewSgRuleACL() {
 		require.Equal(t, expect, acl)
 	})
 
+	t.Run("create fqdn type sg acl", func(t *testing.T) {
+		t.Parallel()
+
+		sgRule := &kubeovnv1.SgRule{
+			IPVersion:     "ipv4",
+			RemoteType:    kubeovnv1.SgRemoteTypeFqdn,
+			RemoteAddress: "*.example.com",
+			Protocol:      "tcp",
+			PortRangeMin:  443,
+			PortRangeMax:  443,
+			Priority:      13,
+			Policy:        "allow",
+		}
+		priority := strconv.Itoa(highestPriority - sgRule.Priority)
+
+		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionFromLport, util.SecurityGroupHighestPriority, sgRule)
+		require.NoError(t, err)
+
+		match := fmt.Sprintf("inport == @%s && ip4 && ip4.dst == $%s && 443 <= tcp.dst <= 443", pgName, GetFqdnV4AddressSetName(sgRule.RemoteAddress))
+		expect := newACL(pgName, ovnnb.ACLDirectionFromLport, priority, match, ovnnb.ACLActionAllowRelated)
+		expect.UUID = acl.UUID
+		require.Equal(t, expect, acl)
+	})
+
 	t.Run("create ipv6 acl", func(t *testing.T) {
 		t.Parallel()
 
@@ -981,7 +1329,7 @@ func (suite *OvnClientTestSuite) testNewSgRuleACL() {
 		}
 		priority := strconv.Itoa(highestPriority - sgRule.Priority)
 
-		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionToLport, sgRule)
+		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionToLport, util.SecurityGroupHighestPriority, sgRule)
 		require.NoError(t, err)
 
 		match := fmt.Sprintf("outport == @%s && ip6 && ip6.src == %s && icmp6", pgName, sgRule.RemoteAddress)
@@ -1003,7 +1351,7 @@ func (suite *OvnClientTestSuite) testNewSgRuleACL() {
 		}
 		priority := strconv.Itoa(highestPriority - sgRule.Priority)
 
-		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionFromLport, sgRule)
+		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionFromLport, util.SecurityGroupHighestPriority, sgRule)
 		require.NoError(t, err)
 
 		match := fmt.Sprintf("inport == @%s && ip4 && ip4.dst == %s && icmp4", pgName, sgRule.RemoteAddress)
@@ -1025,7 +1373,7 @@ func (suite *OvnClientTestSuite) testNewSgRuleACL() {
 		}
 		priority := strconv.Itoa(highestPriority - sgRule.Priority)
 
-		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionToLport, sgRule)
+		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionToLport, util.SecurityGroupHighestPriority, sgRule)
 		require.NoError(t, err)
 
 		match := fmt.Sprintf("outport == @%s && ip4 && ip4.src == %s && icmp4", pgName, sgRule.RemoteAddress)
@@ -1049,7 +1397,7 @@ func (suite *OvnClientTestSuite) testNewSgRuleACL() {
 		}
 		priority := strconv.Itoa(highestPriority - sgRule.Priority)
 
-		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionToLport, sgRule)
+		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionToLport, util.SecurityGroupHighestPriority, sgRule)
 		require.NoError(t, err)
 
 		match := fmt.Sprintf("outport == @%s && ip4 && ip4.src == %s && %d <= tcp.dst <= %d", pgName, sgRule.RemoteAddress, sgRule.PortRangeMin, sgRule.PortRangeMax)
@@ -1057,6 +1405,82 @@ func (suite *OvnClientTestSuite) testNewSgRuleACL() {
 		expect.UUID = acl.UUID
 		require.Equal(t, expect, acl)
 	})
+
+	t.Run("create sg acl with port ranges", func(t *testing.T) {
+		t.Parallel()
+
+		sgRule := &kubeovnv1.SgRule{
+			IPVersion:     "ipv4",
+			RemoteType:    kubeovnv1.SgRemoteTypeAddress,
+			RemoteAddress: "10.10.10.12/24",
+			Protocol:      "udp",
+			Priority:      12,
+			Policy:        "allow",
+			PortRanges: []kubeovnv1.SgPortRange{
+				{PortRangeMin: 53, PortRangeMax: 53},
+				{PortRangeMin: 8000, PortRangeMax: 8080},
+			},
+		}
+		priority := strconv.Itoa(highestPriority - sgRule.Priority)
+
+		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionToLport, util.SecurityGroupHighestPriority, sgRule)
+		require.NoError(t, err)
+
+		match := fmt.Sprintf("outport == @%s && ip4 && ip4.src == %s && (53 <= udp.dst <= 53 || 8000 <= udp.dst <= 8080)", pgName, sgRule.RemoteAddress)
+		expect := newACL(pgName, ovnnb.ACLDirectionToLport, priority, match, ovnnb.ACLActionAllowRelated)
+		expect.UUID = acl.UUID
+		require.Equal(t, expect, acl)
+	})
+
+	t.Run("create sg acl with icmp type and code", func(t *testing.T) {
+		t.Parallel()
+
+		icmpType, icmpCode := 128, 0
+		sgRule := &kubeovnv1.SgRule{
+			IPVersion:     "ipv6",
+			RemoteType:    kubeovnv1.SgRemoteTypeAddress,
+			RemoteAddress: "fd00::/64",
+			Protocol:      "icmp",
+			IcmpType:      &icmpType,
+			IcmpCode:      &icmpCode,
+			Priority:      12,
+			Policy:        "allow",
+		}
+		priority := strconv.Itoa(highestPriority - sgRule.Priority)
+
+		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionToLport, util.SecurityGroupHighestPriority, sgRule)
+		require.NoError(t, err)
+
+		match := fmt.Sprintf("outport == @%s && ip6 && ip6.src == %s && icmp6 && icmp6.type == 128 && icmp6.code == 0", pgName, sgRule.RemoteAddress)
+		expect := newACL(pgName, ovnnb.ACLDirectionToLport, priority, match, ovnnb.ACLActionAllowRelated)
+		expect.UUID = acl.UUID
+		require.Equal(t, expect, acl)
+	})
+
+	t.Run("create stateless address group type sg acl", func(t *testing.T) {
+		t.Parallel()
+
+		sgRule := &kubeovnv1.SgRule{
+			IPVersion:          "ipv4",
+			RemoteType:         kubeovnv1.SgRemoteTypeAddressGroup,
+			RemoteAddressGroup: "office-network",
+			Protocol:           "all",
+			Priority:           12,
+			Policy:             "allow",
+			Stateless:          true,
+			Description:        "allow office network",
+		}
+		priority := strconv.Itoa(highestPriority - sgRule.Priority)
+
+		acl, err := ovnClient.newSgRuleACL(sgName, ovnnb.ACLDirectionFromLport, util.SecurityGroupHighestPriority, sgRule)
+		require.NoError(t, err)
+
+		match := fmt.Sprintf("inport == @%s && ip4 && ip4.dst == $%s", pgName, GetAddressGroupV4AddressSetName(sgRule.RemoteAddressGroup))
+		expect := newACL(pgName, ovnnb.ACLDirectionFromLport, priority, match, ovnnb.ACLActionAllowStateless)
+		expect.UUID = acl.UUID
+		expect.ExternalIDs["description"] = sgRule.Description
+		require.Equal(t, expect, acl)
+	})
 }
 
 func (suite *OvnClientTestSuite) testCreateAcls() {
@@ -1760,3 +2184,22 @@ func (suite *OvnClientTestSuite) testACLFilter() {
 		require.False(t, filterFunc(acl))
 	})
 }
+
+func Test_ACLLogName(t *testing.T) {
+	t.Parallel()
+
+	t.Run("short name", func(t *testing.T) {
+		t.Parallel()
+		require.Equal(t, "default/allow-web/ingress/0", ACLLogName("default/allow-web", "ingress", "0"))
+	})
+
+	t.Run("long name", func(t *testing.T) {
+		t.Parallel()
+		policy := "default/" + strings.Repeat("a", 60)
+		name := ACLLogName(policy, "egress", "drop")
+		require.Len(t, name, aclLogNameMaxLength)
+		require.True(t, strings.HasPrefix(name, "default/aaa"))
+		require.True(t, strings.HasSuffix(name, "/egress/drop"))
+		require.NotEqual(t, name, ACLLogName(policy+"b", "egress", "drop"))
+	})
+}
@@ -3,7 +3,10 @@ package ovs
 import (
 	"context"
 	"fmt"
+	"reflect"
 
+	"github.com/ovn-org/libovsdb/cache"
+	"github.com/ovn-org/libovsdb/model"
 	"k8s.io/klog/v2"
 
 	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
@@ -82,3 +85,18 @@ func (c *OVNNbClient) DeleteBFD(lrpName, dstIP string) error {
 
 	return nil
 }
+
+// MonitorBFD calls onStatusChange when the status of a bfd session is changed
+func (c *OVNNbClient) MonitorBFD(onStatusChange func(bfd *ovnnb.BFD)) {
+	c.Cache().AddEventHandler(&cache.EventHandlerFuncs{
+		UpdateFunc: func(table string, oldModel, newModel model.Model) {
+			if table != ovnnb.BFDTable {
+				return
+			}
+			oldBfd, newBfd := oldModel.(*ovnnb.BFD), newModel.(*ovnnb.BFD)
+			if !reflect.DeepEqual(oldBfd.Status, newBfd.Status) {
+				onStatusChange(newBfd)
+			}
+		},
+	})
+}
@@ -2,9 +2,12 @@ package ovs
 
 import (
 	"testing"
+	"time"
 
 	"github.com/scylladb/go-set/strset"
 	"github.com/stretchr/testify/require"
+
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
 )
 
 func (suite *OvnClientTestSuite) testCreateBFD() {
@@ -116,3 +119,38 @@ func (suite *OvnClientTestSuite) testDeleteBFD() {
 		require.Len(t, bfdList, 0)
 	})
 }
+
+func (suite *OvnClientTestSuite) testMonitorBFD() {
+	t := suite.T()
+	t.Parallel()
+
+	ovnClient := suite.ovnClient
+	lrpName := "test-monitor-bfd"
+	dstIP := "192.168.124.3"
+	minRx, minTx, detectMult := 101, 102, 19
+
+	changed := make(chan string, 10)
+	ovnClient.MonitorBFD(func(bfd *ovnnb.BFD) {
+		if bfd.LogicalPort == lrpName && bfd.Status != nil {
+			changed <- *bfd.Status
+		}
+	})
+
+	bfd, err := ovnClient.CreateBFD(lrpName, dstIP, minRx, minTx, detectMult)
+	require.NoError(t, err)
+
+	t.Run("update BFD status", func(t *testing.T) {
+		status := ovnnb.BFDStatusUp
+		bfd.Status = &status
+		ops, err := ovnClient.Where(bfd).Update(bfd, &bfd.Status)
+		require.NoError(t, err)
+		require.NoError(t, ovnClient.Transact("bfd-update", ops))
+
+		select {
+		case s := <-changed:
+			require.Equal(t, ovnnb.BFDStatusUp, s)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "status change of bfd is not notified")
+		}
+	})
+}
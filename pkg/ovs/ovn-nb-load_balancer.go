@@ -192,6 +192,107 @@ func (c *OVNNbClient) LoadBalancerDeleteVip(lbName, vipEndpoint string, ignoreHe
 	return nil
 }
 
+// LoadBalancerAddVips adds or updates the vips in one transaction, the key of vips is the vip
+// and the value is the comma separated backends
+func (c *OVNNbClient) LoadBalancerAddVips(lbName string, vips map[string]string) error {
+	var (
+		ops []ovsdb.Operation
+		err error
+	)
+
+	if _, err = c.GetLoadBalancer(lbName, false); err != nil {
+		klog.Errorf("failed to get lb: %v", err)
+		return err
+	}
+
+	if ops, err = c.LoadBalancerOp(
+		lbName,
+		func(lb *ovnnb.LoadBalancer) []model.Mutation {
+			stale := make(map[string]string, len(vips))
+			added := make(map[string]string, len(vips))
+			for vip, backends := range vips {
+				if value, ok := lb.Vips[vip]; ok {
+					if value == backends {
+						continue
+					}
+					stale[vip] = value
+				}
+				added[vip] = backends
+			}
+			if len(added) == 0 {
+				return nil
+			}
+
+			mutations := make([]model.Mutation, 0, 2)
+			if len(stale) != 0 {
+				mutations = append(mutations, model.Mutation{
+					Field:   &lb.Vips,
+					Value:   stale,
+					Mutator: ovsdb.MutateOperationDelete,
+				})
+			}
+			return append(mutations, model.Mutation{
+				Field:   &lb.Vips,
+				Value:   added,
+				Mutator: ovsdb.MutateOperationInsert,
+			})
+		},
+	); err != nil {
+		return fmt.Errorf("failed to generate operations when adding %d vips to load balancer %s: %v", len(vips), lbName, err)
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	if err = c.Transact("lb-add", ops); err != nil {
+		return fmt.Errorf("failed to add %d vips to load balancer %s: %v", len(vips), lbName, err)
+	}
+	return nil
+}
+
+// LoadBalancerDeleteVips deletes the vips in one transaction without touching the health checks
+func (c *OVNNbClient) LoadBalancerDeleteVips(lbName string, vips []string) error {
+	lb, err := c.GetLoadBalancer(lbName, true)
+	if err != nil {
+		klog.Errorf("failed to get lb: %v", err)
+		return err
+	}
+	if lb == nil || len(lb.Vips) == 0 {
+		return nil
+	}
+
+	ops, err := c.LoadBalancerOp(
+		lbName,
+		func(lb *ovnnb.LoadBalancer) []model.Mutation {
+			deleted := make(map[string]string, len(vips))
+			for _, vip := range vips {
+				if value, ok := lb.Vips[vip]; ok {
+					deleted[vip] = value
+				}
+			}
+			if len(deleted) == 0 {
+				return nil
+			}
+			return []model.Mutation{{
+				Field:   &lb.Vips,
+				Value:   deleted,
+				Mutator: ovsdb.MutateOperationDelete,
+			}}
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate operations when deleting %d vips from load balancer %s: %v", len(vips), lbName, err)
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	if err = c.Transact("lb-del", ops); err != nil {
+		return fmt.Errorf("failed to delete %d vips from load balancer %s: %v", len(vips), lbName, err)
+	}
+	return nil
+}
+
 // SetLoadBalancerAffinityTimeout sets the LB's affinity timeout in seconds
 func (c *OVNNbClient) SetLoadBalancerAffinityTimeout(lbName string, timeout int) error {
 	var (
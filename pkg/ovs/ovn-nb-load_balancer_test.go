@@ -436,6 +436,49 @@ func (suite *OvnClientTestSuite) testLoadBalancerDeleteVip() {
 	require.Equal(t, vips, lb.Vips)
 }
 
+func (suite *OvnClientTestSuite) testLoadBalancerAddDeleteVips() {
+	t := suite.T()
+	t.Parallel()
+
+	ovnClient := suite.ovnClient
+	lbName := "test-lb-add-del-vips"
+
+	err := ovnClient.CreateLoadBalancer(lbName, "tcp", "")
+	require.NoError(t, err)
+
+	err = ovnClient.LoadBalancerAddVip(lbName, "10.96.0.3:80", "192.168.20.3:8080")
+	require.NoError(t, err)
+
+	vips := map[string]string{
+		"10.96.0.3:80":   "192.168.20.4:8080",
+		"10.96.0.3:8000": "192.168.20.4:9000",
+		"10.96.0.3:8001": "192.168.20.4:9001",
+	}
+	err = ovnClient.LoadBalancerAddVips(lbName, vips)
+	require.NoError(t, err)
+
+	lb, err := ovnClient.GetLoadBalancer(lbName, false)
+	require.NoError(t, err)
+	require.Equal(t, vips, lb.Vips)
+
+	// adding the same vips again is a no-op
+	err = ovnClient.LoadBalancerAddVips(lbName, vips)
+	require.NoError(t, err)
+
+	err = ovnClient.LoadBalancerDeleteVips(lbName, []string{"10.96.0.3:8000", "10.96.0.3:8001", "10.96.0.100:1443"})
+	require.NoError(t, err)
+	delete(vips, "10.96.0.3:8000")
+	delete(vips, "10.96.0.3:8001")
+
+	lb, err = ovnClient.GetLoadBalancer(lbName, false)
+	require.NoError(t, err)
+	require.Equal(t, vips, lb.Vips)
+
+	// deleting vips of a non-existent load balancer is a no-op
+	err = ovnClient.LoadBalancerDeleteVips("test-lb-non-existent", []string{"10.96.0.3:80"})
+	require.NoError(t, err)
+}
+
 func (suite *OvnClientTestSuite) testLoadBalancerAddIPPortMapping() {
 	t := suite.T()
 	t.Parallel()
@@ -0,0 +1,132 @@
+package ovs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/ovsdb"
+	"k8s.io/klog/v2"
+
+	ovsclient "github.com/kubeovn/kube-ovn/pkg/ovsdb/client"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+)
+
+// CreateOrUpdateMeter create a meter with a single drop band, or update the rate of the band if the meter exists
+func (c *OVNNbClient) CreateOrUpdateMeter(name string, unit ovnnb.MeterUnit, rate int, externalIDs map[string]string) error {
+	meter, err := c.GetMeter(name, true)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+
+	var ops []ovsdb.Operation
+	if meter == nil {
+		band := &ovnnb.MeterBand{
+			UUID:   ovsclient.NamedUUID(),
+			Action: ovnnb.MeterBandActionDrop,
+			Rate:   rate,
+		}
+		fair := true
+		meter = &ovnnb.Meter{
+			UUID:        ovsclient.NamedUUID(),
+			Name:        name,
+			Unit:        unit,
+			Bands:       []string{band.UUID},
+			Fair:        &fair,
+			ExternalIDs: externalIDs,
+		}
+
+		bandOps, err := c.Create(band)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for creating band of meter %s: %v", name, err)
+		}
+		meterOps, err := c.Create(meter)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for creating meter %s: %v", name, err)
+		}
+		ops = append(bandOps, meterOps...)
+	} else {
+		if meter.Unit != unit || len(meter.Bands) != 1 {
+			return fmt.Errorf("meter %s with unit %s and %d bands can not be updated", name, meter.Unit, len(meter.Bands))
+		}
+
+		band, err := c.getMeterBand(meter.Bands[0])
+		if err != nil {
+			klog.Error(err)
+			return err
+		}
+		if band.Rate == rate {
+			return nil
+		}
+
+		band.Rate = rate
+		if ops, err = c.Where(band).Update(band, &band.Rate); err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for updating band of meter %s: %v", name, err)
+		}
+	}
+
+	if err = c.Transact("meter-update", ops); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("create or update meter %s: %v", name, err)
+	}
+
+	return nil
+}
+
+// DeleteMeter delete the meter and its bands
+func (c *OVNNbClient) DeleteMeter(name string) error {
+	meter, err := c.GetMeter(name, true)
+	if err != nil {
+		klog.Error(err)
+		return err
+	}
+	if meter == nil {
+		return nil // ignore non-existent object
+	}
+
+	ops, err := c.Where(meter).Delete()
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("generate operations for deleting meter %s: %v", name, err)
+	}
+	if err = c.Transact("meter-del", ops); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("delete meter %s: %v", name, err)
+	}
+
+	return nil
+}
+
+// GetMeter get meter by name
+func (c *OVNNbClient) GetMeter(name string, ignoreNotFound bool) (*ovnnb.Meter, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	meter := &ovnnb.Meter{Name: name}
+	if err := c.ovsDbClient.Get(ctx, meter); err != nil {
+		if ignoreNotFound && err == client.ErrNotFound {
+			return nil, nil
+		}
+		klog.Error(err)
+		return nil, fmt.Errorf("get meter %s: %v", name, err)
+	}
+
+	return meter, nil
+}
+
+func (c *OVNNbClient) getMeterBand(uuid string) (*ovnnb.MeterBand, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	band := &ovnnb.MeterBand{UUID: uuid}
+	if err := c.ovsDbClient.Get(ctx, band); err != nil {
+		klog.Error(err)
+		return nil, fmt.Errorf("get meter band %s: %v", uuid, err)
+	}
+
+	return band, nil
+}
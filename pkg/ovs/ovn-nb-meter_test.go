@@ -0,0 +1,74 @@
+package ovs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+)
+
+func (suite *OvnClientTestSuite) testCreateOrUpdateMeter() {
+	t := suite.T()
+	t.Parallel()
+
+	ovnClient := suite.ovnClient
+	name := "test-create-meter"
+	externalIDs := map[string]string{"key": "value"}
+
+	t.Run("create meter", func(t *testing.T) {
+		err := ovnClient.CreateOrUpdateMeter(name, ovnnb.MeterUnitPktps, 100, externalIDs)
+		require.NoError(t, err)
+
+		meter, err := ovnClient.GetMeter(name, false)
+		require.NoError(t, err)
+		require.Equal(t, ovnnb.MeterUnitPktps, meter.Unit)
+		require.Equal(t, externalIDs, meter.ExternalIDs)
+		require.Len(t, meter.Bands, 1)
+
+		band, err := ovnClient.getMeterBand(meter.Bands[0])
+		require.NoError(t, err)
+		require.Equal(t, ovnnb.MeterBandActionDrop, band.Action)
+		require.Equal(t, 100, band.Rate)
+	})
+
+	t.Run("update meter rate", func(t *testing.T) {
+		err := ovnClient.CreateOrUpdateMeter(name, ovnnb.MeterUnitPktps, 200, externalIDs)
+		require.NoError(t, err)
+
+		meter, err := ovnClient.GetMeter(name, false)
+		require.NoError(t, err)
+		require.Len(t, meter.Bands, 1)
+
+		band, err := ovnClient.getMeterBand(meter.Bands[0])
+		require.NoError(t, err)
+		require.Equal(t, 200, band.Rate)
+	})
+
+	t.Run("update meter unit", func(t *testing.T) {
+		err := ovnClient.CreateOrUpdateMeter(name, ovnnb.MeterUnitKbps, 200, externalIDs)
+		require.Error(t, err)
+	})
+}
+
+func (suite *OvnClientTestSuite) testDeleteMeter() {
+	t := suite.T()
+	t.Parallel()
+
+	ovnClient := suite.ovnClient
+	name := "test-delete-meter"
+
+	err := ovnClient.CreateOrUpdateMeter(name, ovnnb.MeterUnitPktps, 100, nil)
+	require.NoError(t, err)
+
+	err = ovnClient.DeleteMeter(name)
+	require.NoError(t, err)
+
+	meter, err := ovnClient.GetMeter(name, true)
+	require.NoError(t, err)
+	require.Nil(t, meter)
+
+	// delete non-existent meter
+	err = ovnClient.DeleteMeter(name)
+	require.NoError(t, err)
+}
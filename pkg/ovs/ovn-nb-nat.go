@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/ovn-org/libovsdb/client"
 	"github.com/ovn-org/libovsdb/model"
@@ -114,6 +115,85 @@ func (c *OVNNbClient) UpdateSnat(lrName, externalIP, logicalIP string) error {
 	return nil
 }
 
+// UpdateSnatPortRanges update the snat rules of the external ip with the port ranges of the logical ips
+// in a single transaction, the source ports of a logical ip are translated within its port range only.
+// The snat rules are matched by both the external ip and the logical ip,
+// so the snat rules of the logical ips with other external ips are left untouched.
+func (c *OVNNbClient) UpdateSnatPortRanges(lrName, externalIP string, portRanges map[string]string) error {
+	if len(portRanges) == 0 {
+		return nil
+	}
+
+	nats, err := c.listLogicalRouterNatByFilter(lrName, func(nat *ovnnb.NAT) bool {
+		_, ok := portRanges[nat.LogicalIP]
+		return ok && nat.Type == ovnnb.NATTypeSNAT && nat.ExternalIP == externalIP
+	})
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("list logical router %s snats of external ip %s: %v", lrName, externalIP, err)
+	}
+
+	existing := make(map[string]*ovnnb.NAT, len(nats))
+	for _, nat := range nats {
+		existing[nat.LogicalIP] = nat
+	}
+
+	var ops []ovsdb.Operation
+	var models []model.Model
+	var natUUIDs []string
+	for logicalIP, portRange := range portRanges {
+		// update port range when nat exists
+		if nat := existing[logicalIP]; nat != nil {
+			if nat.ExternalPortRange == portRange {
+				continue
+			}
+			nat.ExternalPortRange = portRange
+			updateOps, err := c.ovsDbClient.Where(nat).Update(nat, &nat.ExternalPortRange)
+			if err != nil {
+				klog.Error(err)
+				return fmt.Errorf("generate operations for updating nat 'type %s external ip %s logical ip %s': %v", nat.Type, externalIP, logicalIP, err)
+			}
+			ops = append(ops, updateOps...)
+			continue
+		}
+
+		nat := &ovnnb.NAT{
+			UUID:              ovsclient.NamedUUID(),
+			Type:              ovnnb.NATTypeSNAT,
+			ExternalIP:        externalIP,
+			ExternalPortRange: portRange,
+			LogicalIP:         logicalIP,
+		}
+		models = append(models, model.Model(nat))
+		natUUIDs = append(natUUIDs, nat.UUID)
+	}
+
+	if len(models) != 0 {
+		createNatsOp, err := c.ovsDbClient.Create(models...)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for creating nats: %v", err)
+		}
+		natAddOp, err := c.LogicalRouterUpdateNatOp(lrName, natUUIDs, ovsdb.MutateOperationInsert)
+		if err != nil {
+			klog.Error(err)
+			return fmt.Errorf("generate operations for adding nats to logical router %s: %v", lrName, err)
+		}
+		ops = append(ops, createNatsOp...)
+		ops = append(ops, natAddOp...)
+	}
+
+	if len(ops) == 0 {
+		return nil
+	}
+	if err = c.Transact("lr-snat-port-ranges-update", ops); err != nil {
+		klog.Error(err)
+		return fmt.Errorf("update snats of external ip %s in logical router %s: %v", externalIP, lrName, err)
+	}
+
+	return nil
+}
+
 // UpdateDnatAndSnat update dnat_and_snat rule
 func (c *OVNNbClient) UpdateDnatAndSnat(lrName, externalIP, logicalIP, lspName, externalMac, gatewayType string) error {
 	natType := ovnnb.NATTypeDNATAndSNAT
@@ -207,6 +287,36 @@ func (c *OVNNbClient) DeleteNats(lrName, natType, logicalIP string) error {
 	return nil
 }
 
+// DeleteSnats delete the snat rules of the external ip and the logical ips in a single transaction
+func (c *OVNNbClient) DeleteSnats(lrName, externalIP string, logicalIPs []string) error {
+	nats, err := c.listLogicalRouterNatByFilter(lrName, func(nat *ovnnb.NAT) bool {
+		return nat.Type == ovnnb.NATTypeSNAT && nat.ExternalIP == externalIP && slices.Contains(logicalIPs, nat.LogicalIP)
+	})
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("list logical router %s snats of external ip %s: %v", lrName, externalIP, err)
+	}
+	if len(nats) == 0 {
+		return nil
+	}
+
+	natsUUIDs := make([]string, 0, len(nats))
+	for _, nat := range nats {
+		natsUUIDs = append(natsUUIDs, nat.UUID)
+	}
+
+	ops, err := c.LogicalRouterUpdateNatOp(lrName, natsUUIDs, ovsdb.MutateOperationDelete)
+	if err != nil {
+		klog.Error(err)
+		return fmt.Errorf("generate operations for deleting nats from logical router %s: %v", lrName, err)
+	}
+	if err = c.Transact("lr-snats-del", ops); err != nil {
+		return fmt.Errorf("del snats of external ip %s from logical router %s: %v", externalIP, lrName, err)
+	}
+
+	return nil
+}
+
 // DeleteNat delete nat rule
 func (c *OVNNbClient) DeleteNat(lrName, natType, externalIP, logicalIP string) error {
 	nat, err := c.GetNat(lrName, natType, externalIP, logicalIP, false)
@@ -114,6 +114,97 @@ func (suite *OvnClientTestSuite) testUpdateSnat() {
 	})
 }
 
+func (suite *OvnClientTestSuite) testUpdateSnatPortRanges() {
+	t := suite.T()
+	t.Parallel()
+
+	ovnClient := suite.ovnClient
+	lrName := "test-update-snat-port-ranges-lr"
+	externalIP := "192.168.30.254"
+	logicalIPs := []string{"10.250.0.4", "10.250.0.5"}
+
+	err := ovnClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	t.Run("create snats", func(t *testing.T) {
+		err = ovnClient.UpdateSnatPortRanges(lrName, externalIP, map[string]string{logicalIPs[0]: "1024-2047", logicalIPs[1]: "2048-3071"})
+		require.NoError(t, err)
+
+		lr, err := ovnClient.GetLogicalRouter(lrName, false)
+		require.NoError(t, err)
+
+		nats, err := ovnClient.ListNats(lrName, ovnnb.NATTypeSNAT, "", nil)
+		require.NoError(t, err)
+		require.Len(t, nats, 2)
+		for _, nat := range nats {
+			require.Contains(t, lr.Nat, nat.UUID)
+			require.Equal(t, externalIP, nat.ExternalIP)
+		}
+	})
+
+	t.Run("update port range", func(t *testing.T) {
+		err = ovnClient.UpdateSnatPortRanges(lrName, externalIP, map[string]string{logicalIPs[0]: "3072-4095", logicalIPs[1]: "2048-3071"})
+		require.NoError(t, err)
+
+		nats, err := ovnClient.ListNats(lrName, ovnnb.NATTypeSNAT, logicalIPs[0], nil)
+		require.NoError(t, err)
+		require.Len(t, nats, 1)
+		require.Equal(t, "3072-4095", nats[0].ExternalPortRange)
+	})
+
+	t.Run("snats of other external ips are left untouched", func(t *testing.T) {
+		otherIP := "192.168.30.253"
+		err = ovnClient.UpdateSnatPortRanges(lrName, otherIP, map[string]string{logicalIPs[0]: "1024-2047"})
+		require.NoError(t, err)
+
+		nats, err := ovnClient.ListNats(lrName, ovnnb.NATTypeSNAT, logicalIPs[0], nil)
+		require.NoError(t, err)
+		require.Len(t, nats, 2)
+		for _, nat := range nats {
+			if nat.ExternalIP == externalIP {
+				require.Equal(t, "3072-4095", nat.ExternalPortRange)
+			} else {
+				require.Equal(t, otherIP, nat.ExternalIP)
+				require.Equal(t, "1024-2047", nat.ExternalPortRange)
+			}
+		}
+	})
+}
+
+func (suite *OvnClientTestSuite) testDeleteSnats() {
+	t := suite.T()
+	t.Parallel()
+
+	ovnClient := suite.ovnClient
+	lrName := "test-del-snats-lr"
+	externalIP, otherIP := "192.168.30.254", "192.168.30.253"
+	logicalIPs := []string{"10.250.0.4", "10.250.0.5"}
+
+	err := ovnClient.CreateLogicalRouter(lrName)
+	require.NoError(t, err)
+
+	err = ovnClient.UpdateSnatPortRanges(lrName, externalIP, map[string]string{logicalIPs[0]: "1024-2047", logicalIPs[1]: "2048-3071"})
+	require.NoError(t, err)
+	err = ovnClient.UpdateSnatPortRanges(lrName, otherIP, map[string]string{logicalIPs[0]: "1024-2047"})
+	require.NoError(t, err)
+
+	err = ovnClient.DeleteSnats(lrName, externalIP, logicalIPs)
+	require.NoError(t, err)
+
+	lr, err := ovnClient.GetLogicalRouter(lrName, false)
+	require.NoError(t, err)
+	require.Len(t, lr.Nat, 1)
+
+	nat, err := ovnClient.GetNATByUUID(lr.Nat[0])
+	require.NoError(t, err)
+	require.Equal(t, otherIP, nat.ExternalIP)
+	require.Equal(t, logicalIPs[0], nat.LogicalIP)
+
+	// deleting absent snats is a no-op
+	err = ovnClient.DeleteSnats(lrName, externalIP, logicalIPs)
+	require.NoError(t, err)
+}
+
 func (suite *OvnClientTestSuite) testUpdateDnatAndSnat() {
 	t := suite.T()
 	t.Parallel()
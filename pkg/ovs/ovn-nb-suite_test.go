@@ -300,6 +300,19 @@ func (suite *OvnClientTestSuite) Test_DeleteBFD() {
 	suite.testDeleteBFD()
 }
 
+func (suite *OvnClientTestSuite) Test_MonitorBFD() {
+	suite.testMonitorBFD()
+}
+
+/* meter unit test */
+func (suite *OvnClientTestSuite) Test_CreateOrUpdateMeter() {
+	suite.testCreateOrUpdateMeter()
+}
+
+func (suite *OvnClientTestSuite) Test_DeleteMeter() {
+	suite.testDeleteMeter()
+}
+
 /* gateway_chassis unit test */
 func (suite *OvnClientTestSuite) Test_CreateGatewayChassises() {
 	suite.testCreateGatewayChassises()
@@ -334,6 +347,10 @@ func (suite *OvnClientTestSuite) Test_LoadBalancerDeleteVip() {
 	suite.testLoadBalancerDeleteVip()
 }
 
+func (suite *OvnClientTestSuite) Test_LoadBalancerAddDeleteVips() {
+	suite.testLoadBalancerAddDeleteVips()
+}
+
 func (suite *OvnClientTestSuite) Test_GetLoadBalancer() {
 	suite.testGetLoadBalancer()
 }
@@ -466,6 +483,18 @@ func (suite *OvnClientTestSuite) Test_UpdateEgressAclOps() {
 	suite.testUpdateEgressACLOps()
 }
 
+func (suite *OvnClientTestSuite) Test_UpdateEgressFqdnACLOps() {
+	suite.testUpdateEgressFqdnACLOps()
+}
+
+func (suite *OvnClientTestSuite) Test_UpdateAnpRuleACLOps() {
+	suite.testUpdateAnpRuleACLOps()
+}
+
+func (suite *OvnClientTestSuite) Test_NewAnpACLMatch() {
+	suite.testNewAnpACLMatch()
+}
+
 func (suite *OvnClientTestSuite) Test_CreateGatewayAcl() {
 	suite.testCreateGatewayACL()
 }
@@ -576,6 +605,10 @@ func (suite *OvnClientTestSuite) Test_UpdateSnat() {
 	suite.testUpdateSnat()
 }
 
+func (suite *OvnClientTestSuite) Test_UpdateSnatPortRanges() {
+	suite.testUpdateSnatPortRanges()
+}
+
 func (suite *OvnClientTestSuite) Test_UpdateDnatAndSnat() {
 	suite.testUpdateDnatAndSnat()
 }
@@ -584,6 +617,10 @@ func (suite *OvnClientTestSuite) Test_DeleteNats() {
 	suite.testDeleteNats()
 }
 
+func (suite *OvnClientTestSuite) Test_DeleteSnats() {
+	suite.testDeleteSnats()
+}
+
 func (suite *OvnClientTestSuite) Test_DeleteNat() {
 	suite.testDeleteNat()
 }
@@ -789,6 +826,8 @@ func newNbClient(addr string, timeout int) (client.Client, error) {
 		client.WithTable(&ovnnb.LogicalRouter{}),
 		client.WithTable(&ovnnb.LogicalSwitchPort{}),
 		client.WithTable(&ovnnb.LogicalSwitch{}),
+		client.WithTable(&ovnnb.Meter{}),
+		client.WithTable(&ovnnb.MeterBand{}),
 		client.WithTable(&ovnnb.NAT{}),
 		client.WithTable(&ovnnb.NBGlobal{}),
 		client.WithTable(&ovnnb.PortGroup{}),
@@ -281,6 +281,24 @@ func CleanDuplicatePort(ifaceID, portName string) {
 	}
 }
 
+// GetInterfacesByIfaceID returns the names of the interfaces bound to the logical switch port
+func GetInterfacesByIfaceID(ifaceID string) ([]string, error) {
+	return ovsFind("Interface", "name", "external-ids:iface-id="+ifaceID)
+}
+
+// GetInterfaceOfPort returns the openflow port number of the interface, -1 means the interface failed to attach
+func GetInterfaceOfPort(name string) (int, error) {
+	output, err := ovsGet("Interface", name, "ofport", "")
+	if err != nil {
+		return 0, err
+	}
+	ofport, err := strconv.Atoi(strings.Trim(strings.TrimSpace(output), "[]"))
+	if err != nil {
+		return -1, nil
+	}
+	return ofport, nil
+}
+
 func SetPortTag(port, tag string) error {
 	return ovsSet("port", port, fmt.Sprintf("tag=%s", tag))
 }
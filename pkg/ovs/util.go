@@ -51,6 +51,37 @@ func GetSgV6AssociatedName(sgName string) string {
 	return strings.ReplaceAll(fmt.Sprintf("ovn.sg.%s.associated.v6", sgName), "-", ".")
 }
 
+// GetFqdnV4AddressSetName returns the name of the address set holding the ipv4 addresses of a fqdn,
+// fqdns may contain '*' and '-' which are not allowed in address set names, so the hash is used
+func GetFqdnV4AddressSetName(fqdn string) string {
+	return fmt.Sprintf("ovn.fqdn.%s.v4", util.Sha256Hash([]byte(util.NormalizeFqdn(fqdn)))[:16])
+}
+
+func GetFqdnV6AddressSetName(fqdn string) string {
+	return fmt.Sprintf("ovn.fqdn.%s.v6", util.Sha256Hash([]byte(util.NormalizeFqdn(fqdn)))[:16])
+}
+
+func GetAddressGroupV4AddressSetName(agName string) string {
+	return strings.ReplaceAll(fmt.Sprintf("ovn.ag.%s.v4", agName), "-", ".")
+}
+
+func GetAddressGroupV6AddressSetName(agName string) string {
+	return strings.ReplaceAll(fmt.Sprintf("ovn.ag.%s.v6", agName), "-", ".")
+}
+
+func GetVpcEgressGatewayV4AddressSetName(gwName string) string {
+	return strings.ReplaceAll(fmt.Sprintf("ovn.veg.%s.v4", gwName), "-", ".")
+}
+
+func GetVpcEgressGatewayV6AddressSetName(gwName string) string {
+	return strings.ReplaceAll(fmt.Sprintf("ovn.veg.%s.v6", gwName), "-", ".")
+}
+
+// GetACLLogMeterName returns the name of the meter rate limiting the acl log of a port group
+func GetACLLogMeterName(pgName string) string {
+	return fmt.Sprintf("acl.log.%s", pgName)
+}
+
 // parseIpv6RaConfigs parses the ipv6 ra config,
 // return default Ipv6RaConfigs when raw="",
 // the raw config's format is: address_mode=dhcpv6_stateful,max_interval=30,min_interval=5,send_periodic=true
@@ -146,6 +177,12 @@ func (m AndACLMatch) Match() (string, error) {
 		if err != nil {
 			return "", fmt.Errorf("generate match %s: %v", match, err)
 		}
+
+		// the precedence of '&&' is higher than '||'
+		if strings.Contains(match, "||") {
+			match = "(" + match + ")"
+		}
+
 		matches = append(matches, match)
 	}
 
@@ -1,12 +1,18 @@
 package request
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/containernetworking/cni/pkg/types"
 	"github.com/parnurzeal/gorequest"
 )
 
+// ErrNotReady is returned by Status when cniserver is running but not ready to configure container networks
+var ErrNotReady = errors.New("cniserver is not ready")
+
 // CniServerClient is the client to visit cniserver
 type CniServerClient struct {
 	*gorequest.SuperAgent
@@ -76,3 +82,39 @@ func (csc CniServerClient) Del(podRequest CniRequest) error {
 	}
 	return nil
 }
+
+// Check pod request
+func (csc CniServerClient) Check(podRequest CniRequest) error {
+	res, body, errs := csc.Post("http://dummy/api/v1/check").Send(podRequest).End()
+	if len(errs) != 0 {
+		return errs[0]
+	}
+	if res.StatusCode != 200 {
+		return fmt.Errorf("check return %d %s", res.StatusCode, responseError(body))
+	}
+	return nil
+}
+
+// Status request
+func (csc CniServerClient) Status() error {
+	res, body, errs := csc.Get("http://dummy/api/v1/status").End()
+	if len(errs) != 0 {
+		return errs[0]
+	}
+	if res.StatusCode == http.StatusServiceUnavailable {
+		return fmt.Errorf("%w: %s", ErrNotReady, responseError(body))
+	}
+	if res.StatusCode != 200 {
+		return fmt.Errorf("status return %d %s", res.StatusCode, responseError(body))
+	}
+	return nil
+}
+
+// responseError returns the error message carried by a response body, which is empty on success
+func responseError(body string) string {
+	var resp CniResponse
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		return body
+	}
+	return resp.Err
+}
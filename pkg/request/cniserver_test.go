@@ -0,0 +1,105 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/parnurzeal/gorequest"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCniServerClient(t *testing.T, handler http.HandlerFunc) CniServerClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	request := gorequest.New()
+	request.Transport = &http.Transport{DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+	}}
+	return CniServerClient{request}
+}
+
+func writeResponse(status int, errMsg string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		if errMsg == "" {
+			// the handlers reply with an empty body on success
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(CniResponse{Err: errMsg})
+	}
+}
+
+func Test_CniServerClientCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		status  int
+		errMsg  string
+		wantErr string
+	}{
+		{"success", http.StatusOK, "", ""},
+		{"nic mismatch", http.StatusConflict, "mac address mismatch", "check return 409 mac address mismatch"},
+		{"empty error body", http.StatusInternalServerError, "", "check return 500 "},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := newTestCniServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "/api/v1/check", r.URL.Path)
+				writeResponse(tt.status, tt.errMsg)(w, r)
+			})
+			err := client.Check(CniRequest{PodName: "pod", PodNamespace: "default"})
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_CniServerClientStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		status   int
+		errMsg   string
+		wantErr  string
+		notReady bool
+	}{
+		{"ready", http.StatusOK, "", "", false},
+		{"not ready", http.StatusServiceUnavailable, "ovs is not ready", "cniserver is not ready: ovs is not ready", true},
+		{"unexpected status", http.StatusNotFound, "", "status return 404 ", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := newTestCniServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(t, "/api/v1/status", r.URL.Path)
+				writeResponse(tt.status, tt.errMsg)(w, r)
+			})
+			err := client.Status()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+			require.Equal(t, tt.notReady, errors.Is(err, ErrNotReady))
+		})
+	}
+}
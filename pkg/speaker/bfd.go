@@ -0,0 +1,661 @@
+package speaker
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	bgpapi "github.com/osrg/gobgp/v3/api"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+	"golang.org/x/sys/unix"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+// The speaker runs asynchronous mode BFD sessions as described in RFC 5880,
+// over single hop (RFC 5881) or multihop (RFC 5883) UDP transport.
+// Demand mode, the echo function and authentication are not supported.
+
+type bfdState uint8
+
+const (
+	bfdStateAdminDown bfdState = iota
+	bfdStateDown
+	bfdStateInit
+	bfdStateUp
+)
+
+func (s bfdState) String() string {
+	return [...]string{"AdminDown", "Down", "Init", "Up"}[s&3]
+}
+
+// diagnostic codes, RFC 5880 4.1
+const (
+	bfdDiagNone                 uint8 = 0
+	bfdDiagDetectionTimeExpired uint8 = 1
+	bfdDiagNeighborSignaledDown uint8 = 3
+	bfdDiagAdministrativelyDown uint8 = 7
+)
+
+const (
+	DefaultBfdInterval         = 300 * time.Millisecond
+	DefaultBfdDetectMultiplier = 3
+
+	bfdVersion       uint8 = 1
+	bfdPacketLength        = 24
+	bfdSingleHopPort       = 3784
+	bfdMultihopPort        = 4784
+	bfdSourcePortMin       = 49152
+	bfdSourcePortMax       = 65535
+	bfdSingleHopTTL        = 255
+	// RFC 5880 6.8.3, the desired min tx interval is at least one second until the session is up
+	bfdSlowTxInterval = time.Second
+	bfdEventsLength   = 128
+)
+
+type bfdPacket struct {
+	Diag                      uint8
+	State                     bfdState
+	Poll                      bool
+	Final                     bool
+	DetectMult                uint8
+	MyDiscriminator           uint32
+	YourDiscriminator         uint32
+	DesiredMinTxInterval      uint32
+	RequiredMinRxInterval     uint32
+	RequiredMinEchoRxInterval uint32
+}
+
+func (p *bfdPacket) marshal() []byte {
+	b := make([]byte, bfdPacketLength)
+	b[0] = bfdVersion<<5 | p.Diag&0x1f
+	b[1] = uint8(p.State) << 6
+	if p.Poll {
+		b[1] |= 0x20
+	}
+	if p.Final {
+		b[1] |= 0x10
+	}
+	b[2] = p.DetectMult
+	b[3] = bfdPacketLength
+	binary.BigEndian.PutUint32(b[4:], p.MyDiscriminator)
+	binary.BigEndian.PutUint32(b[8:], p.YourDiscriminator)
+	binary.BigEndian.PutUint32(b[12:], p.DesiredMinTxInterval)
+	binary.BigEndian.PutUint32(b[16:], p.RequiredMinRxInterval)
+	binary.BigEndian.PutUint32(b[20:], p.RequiredMinEchoRxInterval)
+	return b
+}
+
+// parseBfdPacket parses a control packet and runs the checks of RFC 5880 6.8.6 not depending on the session.
+// The packets of single hop sessions must be received with a TTL or hop limit of 255 as required by RFC 5881 5,
+// ttl is negative if it is unknown.
+func parseBfdPacket(b []byte, ttl int, singleHop bool) (*bfdPacket, error) {
+	if singleHop && ttl != bfdSingleHopTTL {
+		return nil, fmt.Errorf("single hop bfd packet received with ttl %d", ttl)
+	}
+	if len(b) < bfdPacketLength {
+		return nil, fmt.Errorf("bfd packet too short: %d bytes", len(b))
+	}
+	if version := b[0] >> 5; version != bfdVersion {
+		return nil, fmt.Errorf("unsupported bfd version %d", version)
+	}
+	if length := int(b[3]); length < bfdPacketLength || length > len(b) {
+		return nil, fmt.Errorf("invalid bfd packet length %d", length)
+	}
+	if b[1]&0x04 != 0 {
+		return nil, errors.New("bfd authentication is not supported")
+	}
+	if b[1]&0x01 != 0 {
+		return nil, errors.New("bfd multipoint bit is set")
+	}
+	p := &bfdPacket{
+		Diag:                      b[0] & 0x1f,
+		State:                     bfdState(b[1] >> 6),
+		Poll:                      b[1]&0x20 != 0,
+		Final:                     b[1]&0x10 != 0,
+		DetectMult:                b[2],
+		MyDiscriminator:           binary.BigEndian.Uint32(b[4:]),
+		YourDiscriminator:         binary.BigEndian.Uint32(b[8:]),
+		DesiredMinTxInterval:      binary.BigEndian.Uint32(b[12:]),
+		RequiredMinRxInterval:     binary.BigEndian.Uint32(b[16:]),
+		RequiredMinEchoRxInterval: binary.BigEndian.Uint32(b[20:]),
+	}
+	if p.DetectMult == 0 {
+		return nil, errors.New("bfd detect multiplier is zero")
+	}
+	if p.MyDiscriminator == 0 {
+		return nil, errors.New("bfd my discriminator is zero")
+	}
+	if p.YourDiscriminator == 0 && p.State != bfdStateDown && p.State != bfdStateAdminDown {
+		return nil, fmt.Errorf("bfd your discriminator is zero in state %s", p.State)
+	}
+	return p, nil
+}
+
+func microseconds(d time.Duration) uint32 {
+	return uint32(d / time.Microsecond)
+}
+
+type bfdSessionConfig struct {
+	MinTxInterval    time.Duration
+	MinRxInterval    time.Duration
+	DetectMultiplier uint8
+	Multihop         bool
+}
+
+type bfdEvent struct {
+	Peer     string
+	OldState bfdState
+	NewState bfdState
+	// Removed is set once the session is removed
+	Removed bool
+}
+
+type bfdSession struct {
+	peer   string
+	config bfdSessionConfig
+	conn   *net.UDPConn
+	events chan<- bfdEvent
+	stopCh chan struct{}
+
+	mutex              sync.Mutex
+	state              bfdState
+	diag               uint8
+	localDiscriminator uint32
+	remoteState        bfdState
+	remoteDiscr        uint32
+	remoteMinRx        time.Duration
+	remoteMinTx        time.Duration
+	remoteDetectMult   uint8
+	// polling is set on the poll sequence started once the session is up to speed up the transmit interval
+	polling     bool
+	detectTimer *time.Timer
+}
+
+// desiredMinTx returns the advertised transmit interval, which is at least one second until the session is up
+func (s *bfdSession) desiredMinTx() time.Duration {
+	if s.state != bfdStateUp && s.config.MinTxInterval < bfdSlowTxInterval {
+		return bfdSlowTxInterval
+	}
+	return s.config.MinTxInterval
+}
+
+func (s *bfdSession) detectionTime() time.Duration {
+	interval := s.config.MinRxInterval
+	if s.remoteMinTx > interval {
+		interval = s.remoteMinTx
+	}
+	return time.Duration(s.remoteDetectMult) * interval
+}
+
+func (s *bfdSession) packet(final bool) *bfdPacket {
+	return &bfdPacket{
+		Diag:                  s.diag,
+		State:                 s.state,
+		Poll:                  s.polling && !final,
+		Final:                 final,
+		DetectMult:            s.config.DetectMultiplier,
+		MyDiscriminator:       s.localDiscriminator,
+		YourDiscriminator:     s.remoteDiscr,
+		DesiredMinTxInterval:  microseconds(s.desiredMinTx()),
+		RequiredMinRxInterval: microseconds(s.config.MinRxInterval),
+	}
+}
+
+func (s *bfdSession) port() int {
+	if s.config.Multihop {
+		return bfdMultihopPort
+	}
+	return bfdSingleHopPort
+}
+
+func (s *bfdSession) send(p *bfdPacket) {
+	addr := &net.UDPAddr{IP: net.ParseIP(s.peer), Port: s.port()}
+	if _, err := s.conn.WriteToUDP(p.marshal(), addr); err != nil {
+		klog.V(3).Infof("failed to send bfd packet to %s: %v", s.peer, err)
+		return
+	}
+	bfdPacketsCounter.WithLabelValues(s.peer, "tx").Inc()
+}
+
+// setState must be called with the mutex held
+func (s *bfdSession) setState(state bfdState, diag uint8) {
+	if s.state == state {
+		return
+	}
+	old := s.state
+	s.state, s.diag = state, diag
+	// a poll sequence speeds up the transmit interval advertised while the session was not up
+	s.polling = state == bfdStateUp && s.config.MinTxInterval < bfdSlowTxInterval
+	klog.Infof("bfd session with %s changed from %s to %s, diagnostic %d", s.peer, old, state, diag)
+	s.updateMetrics()
+	if old == bfdStateUp {
+		bfdSessionDownCounter.WithLabelValues(s.peer).Inc()
+	}
+	select {
+	case s.events <- bfdEvent{Peer: s.peer, OldState: old, NewState: state}:
+	default:
+		klog.Warningf("bfd event channel is full, drop state change of %s to %s", s.peer, state)
+	}
+}
+
+func (s *bfdSession) updateMetrics() {
+	bfdSessionStateGauge.WithLabelValues(s.peer).Set(float64(s.state))
+	bfdDesiredMinTxIntervalGauge.WithLabelValues(s.peer).Set(s.desiredMinTx().Seconds())
+	bfdRequiredMinRxIntervalGauge.WithLabelValues(s.peer).Set(s.config.MinRxInterval.Seconds())
+	bfdDetectMultiplierGauge.WithLabelValues(s.peer).Set(float64(s.config.DetectMultiplier))
+	bfdRemoteMinTxIntervalGauge.WithLabelValues(s.peer).Set(s.remoteMinTx.Seconds())
+	bfdRemoteMinRxIntervalGauge.WithLabelValues(s.peer).Set(s.remoteMinRx.Seconds())
+	bfdDetectionTimeGauge.WithLabelValues(s.peer).Set(s.detectionTime().Seconds())
+}
+
+func (s *bfdSession) deleteMetrics() {
+	for _, g := range bfdSessionGauges() {
+		g.DeleteLabelValues(s.peer)
+	}
+	bfdSessionDownCounter.DeleteLabelValues(s.peer)
+	bfdPacketsCounter.DeleteLabelValues(s.peer, "tx")
+	bfdPacketsCounter.DeleteLabelValues(s.peer, "rx")
+}
+
+func (s *bfdSession) detectionTimeExpired() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.remoteDiscr = 0
+	if s.state == bfdStateInit || s.state == bfdStateUp {
+		s.setState(bfdStateDown, bfdDiagDetectionTimeExpired)
+	}
+}
+
+// receive runs the session related part of the reception of control packets, RFC 5880 6.8.6
+func (s *bfdSession) receive(p *bfdPacket) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	select {
+	case <-s.stopCh:
+		return
+	default:
+	}
+
+	bfdPacketsCounter.WithLabelValues(s.peer, "rx").Inc()
+	s.remoteState = p.State
+	s.remoteDiscr = p.MyDiscriminator
+	s.remoteMinRx = time.Duration(p.RequiredMinRxInterval) * time.Microsecond
+	s.remoteMinTx = time.Duration(p.DesiredMinTxInterval) * time.Microsecond
+	s.remoteDetectMult = p.DetectMult
+	if p.Final {
+		s.polling = false
+	}
+
+	if s.detectTimer != nil {
+		s.detectTimer.Stop()
+	}
+	s.detectTimer = time.AfterFunc(s.detectionTime(), s.detectionTimeExpired)
+
+	switch {
+	case s.state == bfdStateAdminDown:
+		return
+	case p.State == bfdStateAdminDown:
+		if s.state != bfdStateDown {
+			s.setState(bfdStateDown, bfdDiagNeighborSignaledDown)
+		}
+	case s.state == bfdStateDown:
+		if p.State == bfdStateDown {
+			s.setState(bfdStateInit, bfdDiagNone)
+		} else if p.State == bfdStateInit {
+			s.setState(bfdStateUp, bfdDiagNone)
+		}
+	case s.state == bfdStateInit:
+		if p.State == bfdStateInit || p.State == bfdStateUp {
+			s.setState(bfdStateUp, bfdDiagNone)
+		}
+	case s.state == bfdStateUp:
+		if p.State == bfdStateDown {
+			s.setState(bfdStateDown, bfdDiagNeighborSignaledDown)
+		}
+	}
+	s.updateMetrics()
+
+	if p.Poll {
+		s.send(s.packet(true))
+	}
+}
+
+// run transmits the periodic control packets with a jitter of up to 25% until the session is stopped
+func (s *bfdSession) run() {
+	for {
+		s.mutex.Lock()
+		interval := s.desiredMinTx()
+		if s.remoteMinRx > interval {
+			interval = s.remoteMinRx
+		}
+		// the remote system does not want to receive packets if its required min rx interval is zero
+		silent := s.remoteDiscr != 0 && s.remoteMinRx == 0
+		s.mutex.Unlock()
+
+		jitter := 75 + rand.Intn(26) // #nosec G404
+		if s.config.DetectMultiplier == 1 {
+			jitter = 75 + rand.Intn(16) // #nosec G404
+		}
+		select {
+		case <-s.stopCh:
+			return
+		case <-time.After(interval * time.Duration(jitter) / 100):
+		}
+		if silent {
+			continue
+		}
+
+		s.mutex.Lock()
+		p := s.packet(false)
+		s.mutex.Unlock()
+		s.send(p)
+	}
+}
+
+func (s *bfdSession) stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	close(s.stopCh)
+	if s.detectTimer != nil {
+		s.detectTimer.Stop()
+	}
+	// tell the neighbor the session is going down administratively
+	old := s.state
+	s.state, s.diag = bfdStateAdminDown, bfdDiagAdministrativelyDown
+	s.send(s.packet(false))
+	s.conn.Close()
+	s.deleteMetrics()
+	select {
+	case s.events <- bfdEvent{Peer: s.peer, OldState: old, NewState: s.state, Removed: true}:
+	default:
+		klog.Warningf("bfd event channel is full, drop removal of the session with %s", s.peer)
+	}
+}
+
+// listenBfdSourcePort opens the socket sending the control packets of a session from a port in the range 49152 to 65535
+func listenBfdSourcePort(peer net.IP, multihop bool) (*net.UDPConn, error) {
+	network := "udp4"
+	if peer.To4() == nil {
+		network = "udp6"
+	}
+	start := bfdSourcePortMin + rand.Intn(bfdSourcePortMax-bfdSourcePortMin+1) // #nosec G404
+	for i := 0; i <= bfdSourcePortMax-bfdSourcePortMin; i++ {
+		port := bfdSourcePortMin + (start-bfdSourcePortMin+i)%(bfdSourcePortMax-bfdSourcePortMin+1)
+		conn, err := net.ListenUDP(network, &net.UDPAddr{Port: port})
+		if err != nil {
+			continue
+		}
+		if !multihop {
+			// RFC 5881 5, the packets are sent with a TTL or hop limit of 255
+			if err = setUDPConnTTL(conn, network, bfdSingleHopTTL); err != nil {
+				conn.Close()
+				return nil, err
+			}
+		}
+		return conn, nil
+	}
+	return nil, errors.New("no available bfd source port")
+}
+
+func setUDPConnTTL(conn *net.UDPConn, network string, ttl int) error {
+	rawConn, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var sockErr error
+	err = rawConn.Control(func(fd uintptr) {
+		if network == "udp4" {
+			sockErr = syscall.SetsockoptInt(int(fd), unix.IPPROTO_IP, unix.IP_TTL, ttl)
+		} else {
+			sockErr = syscall.SetsockoptInt(int(fd), unix.IPPROTO_IPV6, unix.IPV6_UNICAST_HOPS, ttl)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return sockErr
+}
+
+// bfdManager maintains the bfd sessions with the bgp neighbors,
+// the state changes of the sessions are sent to the events channel
+type bfdManager struct {
+	mutex          sync.Mutex
+	sessions       map[string]*bfdSession
+	discriminators map[uint32]*bfdSession
+	listening      map[string]bool
+	events         chan bfdEvent
+}
+
+func newBfdManager() *bfdManager {
+	return &bfdManager{
+		sessions:       make(map[string]*bfdSession),
+		discriminators: make(map[uint32]*bfdSession),
+		listening:      make(map[string]bool),
+		events:         make(chan bfdEvent, bfdEventsLength),
+	}
+}
+
+// listen starts receiving the control packets on the port of the family if not yet,
+// it must be called with the mutex held
+func (m *bfdManager) listen(network string, port int) error {
+	key := fmt.Sprintf("%s:%d", network, port)
+	if m.listening[key] {
+		return nil
+	}
+	conn, err := net.ListenUDP(network, &net.UDPAddr{Port: port})
+	if err != nil {
+		return fmt.Errorf("failed to listen on bfd port %s: %v", key, err)
+	}
+
+	// the ttl or hop limit of the received packets is required by the check of single hop sessions
+	var read bfdReadFunc
+	if network == "udp4" {
+		pc := ipv4.NewPacketConn(conn)
+		if err = pc.SetControlMessage(ipv4.FlagTTL, true); err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to receive ttl on bfd port %s: %v", key, err)
+		}
+		read = func(b []byte) (int, int, net.Addr, error) {
+			n, cm, addr, err := pc.ReadFrom(b)
+			if cm == nil {
+				return n, -1, addr, err
+			}
+			return n, cm.TTL, addr, err
+		}
+	} else {
+		pc := ipv6.NewPacketConn(conn)
+		if err = pc.SetControlMessage(ipv6.FlagHopLimit, true); err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to receive hop limit on bfd port %s: %v", key, err)
+		}
+		read = func(b []byte) (int, int, net.Addr, error) {
+			n, cm, addr, err := pc.ReadFrom(b)
+			if cm == nil {
+				return n, -1, addr, err
+			}
+			return n, cm.HopLimit, addr, err
+		}
+	}
+	m.listening[key] = true
+	go m.receive(key, read, port == bfdSingleHopPort)
+	return nil
+}
+
+// bfdReadFunc reads a packet and returns its length, ttl or hop limit and source address
+type bfdReadFunc func(b []byte) (int, int, net.Addr, error)
+
+func (m *bfdManager) receive(key string, read bfdReadFunc, singleHop bool) {
+	buf := make([]byte, 512)
+	for {
+		n, ttl, src, err := read(buf)
+		if err != nil {
+			klog.Errorf("failed to receive bfd packet on %s: %v", key, err)
+			time.Sleep(time.Second)
+			continue
+		}
+		addr, ok := src.(*net.UDPAddr)
+		if !ok {
+			continue
+		}
+		p, err := parseBfdPacket(buf[:n], ttl, singleHop)
+		if err != nil {
+			klog.V(3).Infof("drop bfd packet from %s: %v", addr, err)
+			continue
+		}
+
+		m.mutex.Lock()
+		var session *bfdSession
+		if p.YourDiscriminator != 0 {
+			session = m.discriminators[p.YourDiscriminator]
+		} else {
+			session = m.sessions[addr.IP.String()]
+		}
+		m.mutex.Unlock()
+		if session == nil || session.peer != addr.IP.String() {
+			klog.V(3).Infof("drop bfd packet from %s matching no session", addr)
+			continue
+		}
+		session.receive(p)
+	}
+}
+
+// sync adds, updates and removes the sessions to match the expected ones keyed by the neighbor address
+func (m *bfdManager) sync(expected map[string]bfdSessionConfig) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for peer, session := range m.sessions {
+		if config, ok := expected[peer]; ok && config == session.config {
+			continue
+		}
+		klog.Infof("stop bfd session with %s", peer)
+		session.stop()
+		delete(m.discriminators, session.localDiscriminator)
+		delete(m.sessions, peer)
+	}
+
+	for peer, config := range expected {
+		if m.sessions[peer] != nil {
+			continue
+		}
+		ip := net.ParseIP(peer)
+		network := "udp4"
+		if ip.To4() == nil {
+			network = "udp6"
+		}
+		session := &bfdSession{peer: peer, config: config, events: m.events, stopCh: make(chan struct{}), state: bfdStateDown}
+		if err := m.listen(network, session.port()); err != nil {
+			klog.Error(err)
+			continue
+		}
+		conn, err := listenBfdSourcePort(ip, config.Multihop)
+		if err != nil {
+			klog.Errorf("failed to start bfd session with %s: %v", peer, err)
+			continue
+		}
+		session.conn = conn
+		for session.localDiscriminator == 0 || m.discriminators[session.localDiscriminator] != nil {
+			session.localDiscriminator = rand.Uint32() // #nosec G404
+		}
+		klog.Infof("start bfd session with %s, min tx %s, min rx %s, detect multiplier %d",
+			peer, config.MinTxInterval, config.MinRxInterval, config.DetectMultiplier)
+		m.sessions[peer] = session
+		m.discriminators[session.localDiscriminator] = session
+		session.updateMetrics()
+		go session.run()
+	}
+}
+
+func bfdSessionConfigOf(bfd *kubeovnv1.BgpBfd) bfdSessionConfig {
+	config := bfdSessionConfig{
+		MinTxInterval:    bfd.MinTxInterval.Duration,
+		MinRxInterval:    bfd.MinRxInterval.Duration,
+		DetectMultiplier: bfd.DetectMultiplier,
+		Multihop:         bfd.Multihop,
+	}
+	if config.MinTxInterval == 0 {
+		config.MinTxInterval = DefaultBfdInterval
+	}
+	if config.MinRxInterval == 0 {
+		config.MinRxInterval = DefaultBfdInterval
+	}
+	if config.DetectMultiplier == 0 {
+		config.DetectMultiplier = DefaultBfdDetectMultiplier
+	}
+	return config
+}
+
+// syncBfdSessions reconciles the bfd sessions with the neighbors configured by flags with --enable-bfd
+// and with the BgpPeers enabling bfd
+func (c *Controller) syncBfdSessions(peers map[string]*kubeovnv1.BgpPeer) {
+	expected := make(map[string]bfdSessionConfig)
+	if c.config.EnableBfd {
+		config := bfdSessionConfig{
+			MinTxInterval:    c.config.BfdMinTxInterval,
+			MinRxInterval:    c.config.BfdMinRxInterval,
+			DetectMultiplier: c.config.BfdDetectMultiplier,
+			Multihop:         c.config.EbgpMultihopTTL > DefaultEbgpMultiHop,
+		}
+		for _, addr := range append(c.config.NeighborAddresses, c.config.NeighborIPv6Addresses...) {
+			expected[net.ParseIP(addr).String()] = config
+		}
+	}
+	for addr, peer := range peers {
+		if peer.Spec.Bfd != nil {
+			expected[addr] = bfdSessionConfigOf(peer.Spec.Bfd)
+		}
+	}
+	c.bfd.sync(expected)
+}
+
+// handleBfdEvents tears down the bgp session once the bfd session goes down,
+// and brings it back once the bfd session is up again
+func (c *Controller) handleBfdEvents(stopCh <-chan struct{}) {
+	disabled := make(map[string]bool)
+	for {
+		var event bfdEvent
+		select {
+		case <-stopCh:
+			return
+		case event = <-c.bfd.events:
+		}
+
+		ctx := context.Background()
+		switch {
+		case event.Removed:
+			if !disabled[event.Peer] {
+				continue
+			}
+			// the neighbor is no longer protected by bfd, or is deleted together with the session
+			delete(disabled, event.Peer)
+			klog.Infof("bfd session with %s is removed, bring up the bgp session", event.Peer)
+			if err := c.config.BgpServer.EnablePeer(ctx, &bgpapi.EnablePeerRequest{Address: event.Peer}); err != nil {
+				klog.Errorf("failed to enable neighbor %s: %v", event.Peer, err)
+			}
+		case event.OldState == bfdStateUp && event.NewState != bfdStateUp:
+			klog.Warningf("bfd session with %s is down, shut down the bgp session", event.Peer)
+			req := &bgpapi.DisablePeerRequest{Address: event.Peer, Communication: "BFD session down"}
+			if err := c.config.BgpServer.DisablePeer(ctx, req); err != nil {
+				klog.Errorf("failed to disable neighbor %s: %v", event.Peer, err)
+				continue
+			}
+			disabled[event.Peer] = true
+		case event.NewState == bfdStateUp && disabled[event.Peer]:
+			klog.Infof("bfd session with %s is up, bring up the bgp session", event.Peer)
+			if err := c.config.BgpServer.EnablePeer(ctx, &bgpapi.EnablePeerRequest{Address: event.Peer}); err != nil {
+				klog.Errorf("failed to enable neighbor %s: %v", event.Peer, err)
+				continue
+			}
+			delete(disabled, event.Peer)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package speaker
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseBfdPacket(t *testing.T) {
+	t.Parallel()
+
+	valid := &bfdPacket{
+		Diag:                  bfdDiagNeighborSignaledDown,
+		State:                 bfdStateUp,
+		Poll:                  true,
+		DetectMult:            3,
+		MyDiscriminator:       1,
+		YourDiscriminator:     2,
+		DesiredMinTxInterval:  300000,
+		RequiredMinRxInterval: 300000,
+	}
+	p, err := parseBfdPacket(valid.marshal(), bfdSingleHopTTL, true)
+	require.NoError(t, err)
+	require.Equal(t, valid, p)
+
+	// packets with the length exceeding 24 bytes, e.g. with padding
+	b := append(valid.marshal(), 0, 0, 0, 0)
+	_, err = parseBfdPacket(b, bfdSingleHopTTL, true)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		modify    func(b []byte) []byte
+		ttl       int
+		singleHop bool
+		wantErr   bool
+	}{
+		{"multihop with any ttl", nil, 64, false, false},
+		{"single hop with ttl 254", nil, 254, true, true},
+		{"single hop with unknown ttl", nil, -1, true, true},
+		{"too short", func(b []byte) []byte { return b[:20] }, bfdSingleHopTTL, true, true},
+		{"unsupported version", func(b []byte) []byte { b[0] = 2<<5 | b[0]&0x1f; return b }, bfdSingleHopTTL, true, true},
+		{"length larger than packet", func(b []byte) []byte { b[3] = 48; return b }, bfdSingleHopTTL, true, true},
+		{"length smaller than header", func(b []byte) []byte { b[3] = 20; return b }, bfdSingleHopTTL, true, true},
+		{"authentication", func(b []byte) []byte { b[1] |= 0x04; return b }, bfdSingleHopTTL, true, true},
+		{"multipoint", func(b []byte) []byte { b[1] |= 0x01; return b }, bfdSingleHopTTL, true, true},
+		{"zero detect multiplier", func(b []byte) []byte { b[2] = 0; return b }, bfdSingleHopTTL, true, true},
+		{"zero my discriminator", func(b []byte) []byte { copy(b[4:8], []byte{0, 0, 0, 0}); return b }, bfdSingleHopTTL, true, true},
+		{"zero your discriminator when up", func(b []byte) []byte { copy(b[8:12], []byte{0, 0, 0, 0}); return b }, bfdSingleHopTTL, true, true},
+		{"zero your discriminator when down", func(b []byte) []byte {
+			copy(b[8:12], []byte{0, 0, 0, 0})
+			b[1] = uint8(bfdStateDown) << 6
+			return b
+		}, bfdSingleHopTTL, true, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			b := valid.marshal()
+			if tt.modify != nil {
+				b = tt.modify(b)
+			}
+			_, err := parseBfdPacket(b, tt.ttl, tt.singleHop)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func newTestBfdSession(t *testing.T, peer string) (*bfdSession, chan bfdEvent) {
+	t.Helper()
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	events := make(chan bfdEvent, bfdEventsLength)
+	return &bfdSession{
+		peer: peer,
+		conn: conn,
+		config: bfdSessionConfig{
+			MinTxInterval:    time.Second,
+			MinRxInterval:    time.Second,
+			DetectMultiplier: DefaultBfdDetectMultiplier,
+		},
+		events:             events,
+		stopCh:             make(chan struct{}),
+		state:              bfdStateDown,
+		localDiscriminator: 1,
+	}, events
+}
+
+func remoteBfdPacket(state bfdState) *bfdPacket {
+	return &bfdPacket{
+		State:                 state,
+		DetectMult:            DefaultBfdDetectMultiplier,
+		MyDiscriminator:       2,
+		YourDiscriminator:     1,
+		DesiredMinTxInterval:  microseconds(time.Second),
+		RequiredMinRxInterval: microseconds(time.Second),
+	}
+}
+
+func Test_bfdSessionStateMachine(t *testing.T) {
+	t.Parallel()
+
+	s, events := newTestBfdSession(t, "127.0.0.1")
+	expectEvent := func(oldState, newState bfdState) {
+		t.Helper()
+		select {
+		case event := <-events:
+			require.Equal(t, bfdEvent{Peer: s.peer, OldState: oldState, NewState: newState}, event)
+		default:
+			t.Fatalf("no state change from %s to %s", oldState, newState)
+		}
+	}
+
+	// three way handshake
+	s.receive(remoteBfdPacket(bfdStateDown))
+	require.Equal(t, bfdStateInit, s.state)
+	expectEvent(bfdStateDown, bfdStateInit)
+	s.receive(remoteBfdPacket(bfdStateUp))
+	require.Equal(t, bfdStateUp, s.state)
+	expectEvent(bfdStateInit, bfdStateUp)
+	require.Equal(t, 3*time.Second, s.detectionTime())
+
+	// the neighbor restarts the session
+	s.receive(remoteBfdPacket(bfdStateDown))
+	require.Equal(t, bfdStateDown, s.state)
+	require.Equal(t, bfdDiagNeighborSignaledDown, s.diag)
+	expectEvent(bfdStateUp, bfdStateDown)
+
+	// the session comes up directly once the neighbor is in init state
+	s.receive(remoteBfdPacket(bfdStateInit))
+	require.Equal(t, bfdStateUp, s.state)
+	expectEvent(bfdStateDown, bfdStateUp)
+
+	// the neighbor goes down administratively
+	s.receive(remoteBfdPacket(bfdStateAdminDown))
+	require.Equal(t, bfdStateDown, s.state)
+	expectEvent(bfdStateUp, bfdStateDown)
+
+	s.receive(remoteBfdPacket(bfdStateDown))
+	expectEvent(bfdStateDown, bfdStateInit)
+	s.receive(remoteBfdPacket(bfdStateInit))
+	require.Equal(t, bfdStateUp, s.state)
+	expectEvent(bfdStateInit, bfdStateUp)
+
+	// no packets received within the detection time
+	s.detectionTimeExpired()
+	require.Equal(t, bfdStateDown, s.state)
+	require.Equal(t, bfdDiagDetectionTimeExpired, s.diag)
+	require.Zero(t, s.remoteDiscr)
+	expectEvent(bfdStateUp, bfdStateDown)
+
+	s.stop()
+	require.Equal(t, bfdEvent{Peer: s.peer, OldState: bfdStateDown, NewState: bfdStateAdminDown, Removed: true}, <-events)
+	// packets received after the session is stopped are ignored
+	s.receive(remoteBfdPacket(bfdStateDown))
+	require.Equal(t, bfdStateAdminDown, s.state)
+	require.Empty(t, events)
+}
+
+func Test_bfdSessionPoll(t *testing.T) {
+	t.Parallel()
+
+	s, _ := newTestBfdSession(t, "127.0.0.2")
+	s.config.MinTxInterval = 100 * time.Millisecond
+	defer s.stop()
+
+	// the slow transmit interval is advertised until the session is up
+	require.Equal(t, bfdSlowTxInterval, s.desiredMinTx())
+	s.receive(remoteBfdPacket(bfdStateInit))
+	require.Equal(t, bfdStateUp, s.state)
+	require.Equal(t, 100*time.Millisecond, s.desiredMinTx())
+	// a poll sequence is started for the faster transmit interval
+	require.True(t, s.polling)
+	require.True(t, s.packet(false).Poll)
+
+	p := remoteBfdPacket(bfdStateUp)
+	p.Final = true
+	s.receive(p)
+	require.False(t, s.polling)
+	require.False(t, s.packet(false).Poll)
+}
@@ -0,0 +1,439 @@
+package speaker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+
+	bgpapi "github.com/osrg/gobgp/v3/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// globalPolicyAssignment is the name gobgp uses for the policies applied to all non route server peers
+const globalPolicyAssignment = "global"
+
+// neighborAddresses returns the addresses of the neighbors exchanging routes of the protocol,
+// including the ones configured by flags and by BgpPeers
+func (c *Controller) neighborAddresses(protocol string) []string {
+	var addresses []string
+	switch protocol {
+	case kubeovnv1.ProtocolIPv4:
+		addresses = append(addresses, c.config.NeighborAddresses...)
+	case kubeovnv1.ProtocolIPv6:
+		addresses = append(addresses, c.config.NeighborIPv6Addresses...)
+	}
+
+	var peerAddresses []string
+	c.bgpPeersMutex.RLock()
+	for addr, peer := range c.bgpPeers {
+		if util.ContainsString(bgpPeerAddressFamilies(&peer.Spec), protocol) {
+			peerAddresses = append(peerAddresses, addr)
+		}
+	}
+	c.bgpPeersMutex.RUnlock()
+	sort.Strings(peerAddresses)
+	return append(addresses, peerAddresses...)
+}
+
+// neighborLocalAs returns the local AS of the session with the neighbor, which is the one prepended to the AS path
+func (c *Controller) neighborLocalAs(addr string) uint32 {
+	c.bgpPeersMutex.RLock()
+	defer c.bgpPeersMutex.RUnlock()
+	if peer := c.bgpPeers[addr]; peer != nil && peer.Spec.LocalAs != 0 {
+		return peer.Spec.LocalAs
+	}
+	return c.config.ClusterAs
+}
+
+func bgpPeerAddressFamilies(spec *kubeovnv1.BgpPeerSpec) []string {
+	if len(spec.AddressFamilies) != 0 {
+		return spec.AddressFamilies
+	}
+	return []string{util.CheckProtocol(spec.NeighborAddress)}
+}
+
+// expectedBgpPeers returns the valid BgpPeers selecting the node, keyed by neighbor address
+func (c *Controller) expectedBgpPeers() (map[string]*kubeovnv1.BgpPeer, error) {
+	node, err := c.nodesLister.Get(c.config.NodeName)
+	if err != nil {
+		klog.Errorf("failed to get node %s: %v", c.config.NodeName, err)
+		return nil, err
+	}
+	peers, err := c.bgpPeersLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list bgp peers: %v", err)
+		return nil, err
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].Name < peers[j].Name })
+
+	configured := make(map[string]bool)
+	for _, addr := range append(c.config.NeighborAddresses, c.config.NeighborIPv6Addresses...) {
+		configured[net.ParseIP(addr).String()] = true
+	}
+
+	expected := make(map[string]*kubeovnv1.BgpPeer, len(peers))
+	for _, peer := range peers {
+		if peer.Spec.NodeSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(peer.Spec.NodeSelector)
+			if err != nil {
+				klog.Errorf("invalid node selector of bgp peer %s: %v", peer.Name, err)
+				continue
+			}
+			if !selector.Matches(labels.Set(node.Labels)) {
+				continue
+			}
+		}
+		if err := util.ValidateBgpPeer(peer); err != nil {
+			klog.Errorf("invalid bgp peer %s: %v", peer.Name, err)
+			continue
+		}
+
+		addr := net.ParseIP(peer.Spec.NeighborAddress).String()
+		if configured[addr] {
+			klog.Warningf("neighbor %s of bgp peer %s is configured by flags, ignore it", addr, peer.Name)
+			continue
+		}
+		if p := expected[addr]; p != nil {
+			klog.Warningf("neighbor %s of bgp peer %s is already configured by bgp peer %s, ignore it", addr, peer.Name, p.Name)
+			continue
+		}
+		expected[addr] = peer
+	}
+	return expected, nil
+}
+
+// bgpPeerAuthPassword reads the TCP MD5 password of the session from the Secret referred by the BgpPeer
+func (c *Controller) bgpPeerAuthPassword(spec *kubeovnv1.BgpPeerSpec) (string, error) {
+	ref := spec.AuthPasswordSecretRef
+	if ref == nil {
+		return "", nil
+	}
+	secret, err := c.secretsLister.Secrets(ref.Namespace).Get(ref.Name)
+	if err != nil {
+		return "", err
+	}
+	password, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in secret %s/%s", ref.Key, ref.Namespace, ref.Name)
+	}
+	return string(password), nil
+}
+
+// bgpPeerSessionChanged reports whether the session has to be re-established to apply the new spec.
+// Changes of the node selector, policies, route import and BFD are applied to the established session.
+func bgpPeerSessionChanged(oldSpec, newSpec *kubeovnv1.BgpPeerSpec) bool {
+	return oldSpec.NeighborAs != newSpec.NeighborAs ||
+		oldSpec.LocalAs != newSpec.LocalAs ||
+		!reflect.DeepEqual(oldSpec.AuthPasswordSecretRef, newSpec.AuthPasswordSecretRef) ||
+		oldSpec.HoldTime != newSpec.HoldTime ||
+		oldSpec.KeepaliveInterval != newSpec.KeepaliveInterval ||
+		oldSpec.ConnectRetry != newSpec.ConnectRetry ||
+		oldSpec.EbgpMultihopTTL != newSpec.EbgpMultihopTTL ||
+		oldSpec.PassiveMode != newSpec.PassiveMode ||
+		oldSpec.GracefulRestart != newSpec.GracefulRestart ||
+		!reflect.DeepEqual(bgpPeerAddressFamilies(oldSpec), bgpPeerAddressFamilies(newSpec))
+}
+
+// syncBgpPeers reconciles the gobgp neighbors and their policies with the BgpPeers selecting the node
+func (c *Controller) syncBgpPeers() {
+	expected, err := c.expectedBgpPeers()
+	if err != nil {
+		return
+	}
+
+	c.bgpPeersMutex.RLock()
+	current := make(map[string]*kubeovnv1.BgpPeer, len(c.bgpPeers))
+	for addr, peer := range c.bgpPeers {
+		current[addr] = peer
+	}
+	c.bgpPeersMutex.RUnlock()
+
+	passwords := make(map[string]string, len(expected))
+	for addr, peer := range expected {
+		password, err := c.bgpPeerAuthPassword(&peer.Spec)
+		if err != nil {
+			klog.Errorf("failed to get auth password of bgp peer %s: %v", peer.Name, err)
+			// leave the neighbor as it is until the password is available
+			if current[addr] != nil {
+				expected[addr], passwords[addr] = current[addr], c.bgpPeerPasswords[addr]
+			} else {
+				delete(expected, addr)
+			}
+			continue
+		}
+		passwords[addr] = password
+	}
+
+	var policyChanged []string
+	for addr, peer := range current {
+		if p := expected[addr]; p != nil && passwords[addr] == c.bgpPeerPasswords[addr] && !bgpPeerSessionChanged(&peer.Spec, &p.Spec) {
+			if !reflect.DeepEqual(p.Spec.ImportPolicy, peer.Spec.ImportPolicy) || !reflect.DeepEqual(p.Spec.ExportPolicy, peer.Spec.ExportPolicy) {
+				policyChanged = append(policyChanged, addr)
+			}
+			if p != peer {
+				current[addr] = p.DeepCopy()
+			}
+			continue
+		}
+		klog.Infof("delete neighbor %s of bgp peer %s", addr, peer.Name)
+		if err = c.config.BgpServer.DeletePeer(context.Background(), &bgpapi.DeletePeerRequest{Address: addr}); err != nil {
+			klog.Errorf("failed to delete neighbor %s: %v", addr, err)
+			passwords[addr] = c.bgpPeerPasswords[addr]
+			continue
+		}
+		delete(current, addr)
+	}
+
+	for addr, peer := range expected {
+		if current[addr] != nil {
+			continue
+		}
+		klog.Infof("add neighbor %s of bgp peer %s", addr, peer.Name)
+		if err = c.config.BgpServer.AddPeer(context.Background(), &bgpapi.AddPeerRequest{Peer: c.newBgpPeer(&peer.Spec, passwords[addr])}); err != nil {
+			klog.Errorf("failed to add neighbor %s of bgp peer %s: %v", addr, peer.Name, err)
+			continue
+		}
+		current[addr] = peer.DeepCopy()
+	}
+	c.bgpPeerPasswords = passwords
+
+	c.bgpPeersMutex.Lock()
+	c.bgpPeers = current
+	c.bgpPeersMutex.Unlock()
+
+	if err = c.syncBgpPolicies(current); err != nil {
+		klog.Errorf("failed to sync bgp policies: %v", err)
+	} else {
+		// re-evaluate the routes exchanged with the neighbors against the new policies without resetting the sessions
+		for _, addr := range policyChanged {
+			klog.Infof("soft reset neighbor %s to apply the new policies", addr)
+			if err = c.config.BgpServer.ResetPeer(context.Background(), &bgpapi.ResetPeerRequest{
+				Address:   addr,
+				Soft:      true,
+				Direction: bgpapi.ResetPeerRequest_BOTH,
+			}); err != nil {
+				klog.Errorf("failed to soft reset neighbor %s: %v", addr, err)
+			}
+		}
+	}
+	c.syncBfdSessions(current)
+}
+
+func (c *Controller) newBgpPeer(spec *kubeovnv1.BgpPeerSpec, authPassword string) *bgpapi.Peer {
+	holdTime := spec.HoldTime.Seconds()
+	if holdTime == 0 {
+		holdTime = c.config.HoldTime
+	}
+	peer := &bgpapi.Peer{
+		Conf: &bgpapi.PeerConf{
+			NeighborAddress: spec.NeighborAddress,
+			PeerAsn:         spec.NeighborAs,
+			LocalAsn:        spec.LocalAs,
+			AuthPassword:    authPassword,
+		},
+		Timers: &bgpapi.Timers{Config: &bgpapi.TimersConfig{
+			HoldTime:          uint64(holdTime),
+			KeepaliveInterval: uint64(spec.KeepaliveInterval.Seconds()),
+			ConnectRetry:      uint64(spec.ConnectRetry.Seconds()),
+		}},
+		Transport: &bgpapi.Transport{
+			PassiveMode: spec.PassiveMode,
+		},
+	}
+	if spec.EbgpMultihopTTL > DefaultEbgpMultiHop {
+		peer.EbgpMultihop = &bgpapi.EbgpMultihop{
+			Enabled:     true,
+			MultihopTtl: uint32(spec.EbgpMultihopTTL),
+		}
+	}
+	if spec.GracefulRestart {
+		peer.GracefulRestart = &bgpapi.GracefulRestart{
+			Enabled:         true,
+			RestartTime:     uint32(c.config.GracefulRestartTime.Seconds()),
+			DeferralTime:    uint32(c.config.GracefulRestartDeferralTime.Seconds()),
+			LocalRestarting: true,
+		}
+	}
+
+	for _, af := range bgpPeerAddressFamilies(spec) {
+		afi := bgpapi.Family_AFI_IP
+		if af == kubeovnv1.ProtocolIPv6 {
+			afi = bgpapi.Family_AFI_IP6
+		}
+		afiSafi := &bgpapi.AfiSafi{
+			Config: &bgpapi.AfiSafiConfig{
+				Family:  &bgpapi.Family{Afi: afi, Safi: bgpapi.Family_SAFI_UNICAST},
+				Enabled: true,
+			},
+		}
+		if spec.GracefulRestart {
+			afiSafi.MpGracefulRestart = &bgpapi.MpGracefulRestart{
+				Config: &bgpapi.MpGracefulRestartConfig{Enabled: true},
+			}
+		}
+		peer.AfiSafis = append(peer.AfiSafis, afiSafi)
+	}
+	return peer
+}
+
+func bgpRouteAction(action kubeovnv1.BgpPolicyAction) bgpapi.RouteAction {
+	if action == kubeovnv1.BgpPolicyActionReject {
+		return bgpapi.RouteAction_REJECT
+	}
+	return bgpapi.RouteAction_ACCEPT
+}
+
+// bgpPeerPolicy translates the policy of a BgpPeer into a gobgp policy whose statements
+// only match the routes from or to the neighbor in the neighbor set
+func bgpPeerPolicy(name, neighborSet string, policy *kubeovnv1.BgpPolicy) ([]*bgpapi.DefinedSet, *bgpapi.Policy) {
+	var sets []*bgpapi.DefinedSet
+	p := &bgpapi.Policy{Name: name}
+	for i, rule := range policy.Rules {
+		_, cidr, _ := net.ParseCIDR(rule.Prefix)
+		ones, _ := cidr.Mask.Size()
+		minLen, maxLen := uint32(ones), uint32(ones)
+		if rule.MaskLengthMin != 0 {
+			minLen = rule.MaskLengthMin
+		}
+		if rule.MaskLengthMax != 0 {
+			maxLen = rule.MaskLengthMax
+		}
+
+		setName := fmt.Sprintf("%s-%d", name, i)
+		sets = append(sets, &bgpapi.DefinedSet{
+			DefinedType: bgpapi.DefinedType_PREFIX,
+			Name:        setName,
+			Prefixes:    []*bgpapi.Prefix{{IpPrefix: cidr.String(), MaskLengthMin: minLen, MaskLengthMax: maxLen}},
+		})
+		p.Statements = append(p.Statements, &bgpapi.Statement{
+			Name: setName,
+			Conditions: &bgpapi.Conditions{
+				PrefixSet:   &bgpapi.MatchSet{Type: bgpapi.MatchSet_ANY, Name: setName},
+				NeighborSet: &bgpapi.MatchSet{Type: bgpapi.MatchSet_ANY, Name: neighborSet},
+			},
+			Actions: &bgpapi.Actions{RouteAction: bgpRouteAction(rule.Action)},
+		})
+	}
+	p.Statements = append(p.Statements, &bgpapi.Statement{
+		Name:       name + "-default",
+		Conditions: &bgpapi.Conditions{NeighborSet: &bgpapi.MatchSet{Type: bgpapi.MatchSet_ANY, Name: neighborSet}},
+		Actions:    &bgpapi.Actions{RouteAction: bgpRouteAction(policy.DefaultAction)},
+	})
+	return sets, p
+}
+
+// syncBgpPolicies replaces the global import and export policies with the ones of the BgpPeers.
+// gobgp only supports per peer policies for route server clients, so the policy of each BgpPeer
+// is scoped to its neighbor by a neighbor set condition.
+func (c *Controller) syncBgpPolicies(peers map[string]*kubeovnv1.BgpPeer) error {
+	type peerPolicy struct {
+		Name           string
+		Address        string
+		Import, Export *kubeovnv1.BgpPolicy
+	}
+	var expected []peerPolicy
+	for addr, peer := range peers {
+		if peer.Spec.ImportPolicy != nil || peer.Spec.ExportPolicy != nil {
+			expected = append(expected, peerPolicy{peer.Name, addr, peer.Spec.ImportPolicy, peer.Spec.ExportPolicy})
+		}
+	}
+	sort.Slice(expected, func(i, j int) bool { return expected[i].Name < expected[j].Name })
+	key, err := json.Marshal(expected)
+	if err != nil {
+		return err
+	}
+	if string(key) == c.bgpPoliciesKey {
+		return nil
+	}
+
+	var sets []*bgpapi.DefinedSet
+	var imports, exports []*bgpapi.Policy
+	for _, p := range expected {
+		neighborSet := &bgpapi.DefinedSet{
+			DefinedType: bgpapi.DefinedType_NEIGHBOR,
+			Name:        "kube-ovn-peer-" + p.Name,
+			List:        []string{p.Address},
+		}
+		sets = append(sets, neighborSet)
+		if p.Import != nil {
+			s, policy := bgpPeerPolicy(neighborSet.Name+"-import", neighborSet.Name, p.Import)
+			sets, imports = append(sets, s...), append(imports, policy)
+		}
+		if p.Export != nil {
+			s, policy := bgpPeerPolicy(neighborSet.Name+"-export", neighborSet.Name, p.Export)
+			sets, exports = append(sets, s...), append(exports, policy)
+		}
+	}
+
+	ctx := context.Background()
+	s := c.config.BgpServer
+	// detach the policies before they are replaced
+	for _, dir := range []bgpapi.PolicyDirection{bgpapi.PolicyDirection_IMPORT, bgpapi.PolicyDirection_EXPORT} {
+		if err = s.SetPolicyAssignment(ctx, &bgpapi.SetPolicyAssignmentRequest{Assignment: &bgpapi.PolicyAssignment{
+			Name:          globalPolicyAssignment,
+			Direction:     dir,
+			DefaultAction: bgpapi.RouteAction_ACCEPT,
+		}}); err != nil {
+			klog.Errorf("failed to reset %s policy assignment: %v", dir, err)
+			return err
+		}
+	}
+	for _, policy := range c.bgpPolicies {
+		if err = s.DeletePolicy(ctx, &bgpapi.DeletePolicyRequest{Policy: &bgpapi.Policy{Name: policy.Name}, All: true}); err != nil {
+			klog.Errorf("failed to delete bgp policy %s: %v", policy.Name, err)
+			return err
+		}
+	}
+	c.bgpPolicies = nil
+	for _, set := range c.bgpDefinedSets {
+		if err = s.DeleteDefinedSet(ctx, &bgpapi.DeleteDefinedSetRequest{DefinedSet: set, All: true}); err != nil {
+			klog.Errorf("failed to delete bgp defined set %s: %v", set.Name, err)
+			return err
+		}
+	}
+	c.bgpDefinedSets = nil
+
+	for _, set := range sets {
+		if err = s.AddDefinedSet(ctx, &bgpapi.AddDefinedSetRequest{DefinedSet: set}); err != nil {
+			klog.Errorf("failed to add bgp defined set %s: %v", set.Name, err)
+			return err
+		}
+		c.bgpDefinedSets = append(c.bgpDefinedSets, set)
+	}
+	for _, policy := range append(imports, exports...) {
+		if err = s.AddPolicy(ctx, &bgpapi.AddPolicyRequest{Policy: policy}); err != nil {
+			klog.Errorf("failed to add bgp policy %s: %v", policy.Name, err)
+			return err
+		}
+		c.bgpPolicies = append(c.bgpPolicies, policy)
+	}
+	for dir, policies := range map[bgpapi.PolicyDirection][]*bgpapi.Policy{
+		bgpapi.PolicyDirection_IMPORT: imports,
+		bgpapi.PolicyDirection_EXPORT: exports,
+	} {
+		if len(policies) == 0 {
+			continue
+		}
+		if err = s.SetPolicyAssignment(ctx, &bgpapi.SetPolicyAssignmentRequest{Assignment: &bgpapi.PolicyAssignment{
+			Name:          globalPolicyAssignment,
+			Direction:     dir,
+			Policies:      policies,
+			DefaultAction: bgpapi.RouteAction_ACCEPT,
+		}}); err != nil {
+			klog.Errorf("failed to set %s policy assignment: %v", dir, err)
+			return err
+		}
+	}
+
+	klog.Infof("applied %d import and %d export bgp policies", len(imports), len(exports))
+	c.bgpPoliciesKey = string(key)
+	return nil
+}
@@ -0,0 +1,102 @@
+package speaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+func Test_bgpPeerSessionChanged(t *testing.T) {
+	t.Parallel()
+
+	base := kubeovnv1.BgpPeerSpec{
+		NeighborAddress: "192.168.1.1",
+		NeighborAs:      65001,
+		HoldTime:        metav1.Duration{Duration: 9 * time.Second},
+	}
+	tests := []struct {
+		name    string
+		update  func(spec *kubeovnv1.BgpPeerSpec)
+		changed bool
+	}{
+		{"unchanged", func(*kubeovnv1.BgpPeerSpec) {}, false},
+		{"neighbor as", func(spec *kubeovnv1.BgpPeerSpec) { spec.NeighborAs = 65002 }, true},
+		{"local as", func(spec *kubeovnv1.BgpPeerSpec) { spec.LocalAs = 65000 }, true},
+		{"hold time", func(spec *kubeovnv1.BgpPeerSpec) { spec.HoldTime.Duration = 30 * time.Second }, true},
+		{"passive mode", func(spec *kubeovnv1.BgpPeerSpec) { spec.PassiveMode = true }, true},
+		{"auth password", func(spec *kubeovnv1.BgpPeerSpec) {
+			spec.AuthPasswordSecretRef = &kubeovnv1.BgpSecretKeyRef{Namespace: "kube-system", Name: "bgp-auth", Key: "password"}
+		}, true},
+		{"address families", func(spec *kubeovnv1.BgpPeerSpec) {
+			spec.AddressFamilies = []string{kubeovnv1.ProtocolIPv4, kubeovnv1.ProtocolIPv6}
+		}, true},
+		{"default address family", func(spec *kubeovnv1.BgpPeerSpec) {
+			spec.AddressFamilies = []string{kubeovnv1.ProtocolIPv4}
+		}, false},
+		{"import policy", func(spec *kubeovnv1.BgpPeerSpec) {
+			spec.ImportPolicy = &kubeovnv1.BgpPolicy{DefaultAction: kubeovnv1.BgpPolicyActionReject}
+		}, false},
+		{"route import", func(spec *kubeovnv1.BgpPeerSpec) {
+			spec.RouteImport = &kubeovnv1.BgpRouteImport{Table: 100}
+		}, false},
+		{"bfd", func(spec *kubeovnv1.BgpPeerSpec) { spec.Bfd = &kubeovnv1.BgpBfd{} }, false},
+		{"node selector", func(spec *kubeovnv1.BgpPeerSpec) {
+			spec.NodeSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"bgp": "true"}}
+		}, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			spec := *base.DeepCopy()
+			tt.update(&spec)
+			require.Equal(t, tt.changed, bgpPeerSessionChanged(&base, &spec))
+		})
+	}
+}
+
+func Test_bgpPeerAuthPassword(t *testing.T) {
+	t.Parallel()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	require.NoError(t, indexer.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "bgp-auth"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}))
+	c := &Controller{secretsLister: listerv1.NewSecretLister(indexer)}
+
+	password, err := c.bgpPeerAuthPassword(&kubeovnv1.BgpPeerSpec{})
+	require.NoError(t, err)
+	require.Empty(t, password)
+
+	ref := &kubeovnv1.BgpSecretKeyRef{Namespace: "kube-system", Name: "bgp-auth", Key: "password"}
+	password, err = c.bgpPeerAuthPassword(&kubeovnv1.BgpPeerSpec{AuthPasswordSecretRef: ref})
+	require.NoError(t, err)
+	require.Equal(t, "secret", password)
+
+	ref.Key = "missing"
+	_, err = c.bgpPeerAuthPassword(&kubeovnv1.BgpPeerSpec{AuthPasswordSecretRef: ref})
+	require.ErrorContains(t, err, "key missing not found")
+}
+
+func Test_neighborLocalAs(t *testing.T) {
+	t.Parallel()
+
+	c := &Controller{
+		config: &Configuration{ClusterAs: 65000},
+		bgpPeers: map[string]*kubeovnv1.BgpPeer{
+			"192.168.1.1": {Spec: kubeovnv1.BgpPeerSpec{NeighborAddress: "192.168.1.1", LocalAs: 65100}},
+			"192.168.1.2": {Spec: kubeovnv1.BgpPeerSpec{NeighborAddress: "192.168.1.2"}},
+		},
+	}
+	require.Equal(t, uint32(65100), c.neighborLocalAs("192.168.1.1"))
+	require.Equal(t, uint32(65000), c.neighborLocalAs("192.168.1.2"))
+	require.Equal(t, uint32(65000), c.neighborLocalAs("192.168.1.3"))
+}
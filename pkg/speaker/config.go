@@ -21,6 +21,7 @@ import (
 	"k8s.io/klog/v2"
 
 	clientset "github.com/kubeovn/kube-ovn/pkg/client/clientset/versioned"
+	"github.com/kubeovn/kube-ovn/pkg/ovs"
 	"github.com/kubeovn/kube-ovn/pkg/util"
 )
 
@@ -53,6 +54,17 @@ type Configuration struct {
 	PassiveMode                 bool
 	EbgpMultihopTTL             uint8
 
+	// EnableBfd runs bfd sessions with the neighbors configured by flags
+	EnableBfd           bool
+	BfdMinTxInterval    time.Duration
+	BfdMinRxInterval    time.Duration
+	BfdDetectMultiplier uint8
+
+	// OvnNbClient is only initialized with --ovn-nb-addr to import routes into vpcs
+	OvnNbAddr   string
+	OvnTimeout  int
+	OvnNbClient *ovs.OVNNbClient
+
 	NodeName       string
 	KubeConfigFile string
 	KubeClient     kubernetes.Interface
@@ -81,6 +93,12 @@ func ParseFlags() (*Configuration, error) {
 		argKubeConfigFile              = pflag.String("kubeconfig", "", "Path to kubeconfig file with authorization and master location information. If not set use the inCluster token.")
 		argPassiveMode                 = pflag.BoolP("passivemode", "", false, "Set BGP Speaker to passive model,do not actively initiate connections to peers ")
 		argEbgpMultihopTTL             = pflag.Uint8("ebgp-multihop", DefaultEbgpMultiHop, "The TTL value of EBGP peer, default: 1")
+		argOvnNbAddr                   = pflag.String("ovn-nb-addr", "", "ovn-nb address, required to import routes learned from bgp peers into vpcs")
+		argOvnTimeout                  = pflag.Int("ovn-timeout", 60, "")
+		argEnableBfd                   = pflag.Bool("enable-bfd", false, "Run BFD sessions with the neighbors configured by flags, the BGP session is torn down once BFD detects the neighbor down")
+		argBfdMinTxInterval            = pflag.Duration("bfd-min-tx-interval", DefaultBfdInterval, "The desired minimum interval between the transmitted BFD control packets")
+		argBfdMinRxInterval            = pflag.Duration("bfd-min-rx-interval", DefaultBfdInterval, "The required minimum interval between the received BFD control packets")
+		argBfdDetectMultiplier         = pflag.Uint8("bfd-detect-multiplier", DefaultBfdDetectMultiplier, "The number of missed BFD control packets declaring the neighbor down")
 	)
 	klogFlags := flag.NewFlagSet("klog", flag.ExitOnError)
 	klog.InitFlags(klogFlags)
@@ -110,6 +128,14 @@ func ParseFlags() (*Configuration, error) {
 	if *argEbgpMultihopTTL < 1 || *argEbgpMultihopTTL > 255 {
 		return nil, errors.New("the bgp MultihopTtl must be in the range 1 to 255")
 	}
+	for _, interval := range []time.Duration{*argBfdMinTxInterval, *argBfdMinRxInterval} {
+		if interval < 10*time.Millisecond || interval > time.Minute {
+			return nil, errors.New("the bfd intervals must be in the range 10ms to 1m")
+		}
+	}
+	if *argBfdDetectMultiplier == 0 {
+		return nil, errors.New("the bfd detect multiplier must not be zero")
+	}
 
 	config := &Configuration{
 		AnnounceClusterIP:           *argAnnounceClusterIP,
@@ -128,6 +154,12 @@ func ParseFlags() (*Configuration, error) {
 		GracefulRestartTime:         *argDefaultGracefulTime,
 		PassiveMode:                 *argPassiveMode,
 		EbgpMultihopTTL:             *argEbgpMultihopTTL,
+		OvnNbAddr:                   *argOvnNbAddr,
+		OvnTimeout:                  *argOvnTimeout,
+		EnableBfd:                   *argEnableBfd,
+		BfdMinTxInterval:            *argBfdMinTxInterval,
+		BfdMinRxInterval:            *argBfdMinRxInterval,
+		BfdDetectMultiplier:         *argBfdDetectMultiplier,
 	}
 
 	if *argNeighborAddress != "" {
@@ -162,6 +194,13 @@ func ParseFlags() (*Configuration, error) {
 		return nil, fmt.Errorf("failed to init bgp server, %v", err)
 	}
 
+	if config.OvnNbAddr != "" {
+		var err error
+		if config.OvnNbClient, err = ovs.NewOvnNbClient(config.OvnNbAddr, config.OvnTimeout); err != nil {
+			return nil, fmt.Errorf("failed to init ovn nb client, %v", err)
+		}
+	}
+
 	return config, nil
 }
 
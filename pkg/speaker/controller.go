@@ -1,8 +1,10 @@
 package speaker
 
 import (
+	"sync"
 	"time"
 
+	bgpapi "github.com/osrg/gobgp/v3/api"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -26,12 +28,36 @@ const controllerAgentName = "ovn-speaker"
 type Controller struct {
 	config *Configuration
 
-	podsLister     listerv1.PodLister
-	podsSynced     cache.InformerSynced
-	subnetsLister  kubeovnlister.SubnetLister
-	subnetSynced   cache.InformerSynced
-	servicesLister listerv1.ServiceLister
-	servicesSynced cache.InformerSynced
+	podsLister          listerv1.PodLister
+	podsSynced          cache.InformerSynced
+	subnetsLister       kubeovnlister.SubnetLister
+	subnetSynced        cache.InformerSynced
+	servicesLister      listerv1.ServiceLister
+	servicesSynced      cache.InformerSynced
+	nodesLister         listerv1.NodeLister
+	nodesSynced         cache.InformerSynced
+	bgpPeersLister      kubeovnlister.BgpPeerLister
+	bgpPeersSynced      cache.InformerSynced
+	iptablesEipsLister  kubeovnlister.IptablesEIPLister
+	iptablesEipsSynced  cache.InformerSynced
+	vpcNatGatewayLister kubeovnlister.VpcNatGatewayLister
+	vpcNatGatewaySynced cache.InformerSynced
+	ovnEipsLister       kubeovnlister.OvnEipLister
+	ovnEipsSynced       cache.InformerSynced
+	secretsLister       listerv1.SecretLister
+	secretsSynced       cache.InformerSynced
+
+	// bgpPeers are the neighbors added by BgpPeers, keyed by neighbor address
+	bgpPeers      map[string]*kubeovnv1.BgpPeer
+	bgpPeersMutex sync.RWMutex
+	// bgpPeerPasswords are the auth passwords the neighbors are added with, only accessed by syncBgpPeers
+	bgpPeerPasswords map[string]string
+	bgpPolicies      []*bgpapi.Policy
+	bgpDefinedSets   []*bgpapi.DefinedSet
+	bgpPoliciesKey   string
+	// importVpcs are the vpcs with static routes imported by the speaker
+	importVpcs map[string]bool
+	bfd        *bfdManager
 
 	informerFactory        kubeinformers.SharedInformerFactory
 	kubeovnInformerFactory kubeovninformer.SharedInformerFactory
@@ -58,16 +84,36 @@ func NewController(config *Configuration) *Controller {
 	podInformer := informerFactory.Core().V1().Pods()
 	subnetInformer := kubeovnInformerFactory.Kubeovn().V1().Subnets()
 	serviceInformer := informerFactory.Core().V1().Services()
+	nodeInformer := informerFactory.Core().V1().Nodes()
+	bgpPeerInformer := kubeovnInformerFactory.Kubeovn().V1().BgpPeers()
+	iptablesEipInformer := kubeovnInformerFactory.Kubeovn().V1().IptablesEIPs()
+	vpcNatGatewayInformer := kubeovnInformerFactory.Kubeovn().V1().VpcNatGateways()
+	ovnEipInformer := kubeovnInformerFactory.Kubeovn().V1().OvnEips()
+	secretInformer := informerFactory.Core().V1().Secrets()
 
 	controller := &Controller{
 		config: config,
 
-		podsLister:     podInformer.Lister(),
-		podsSynced:     podInformer.Informer().HasSynced,
-		subnetsLister:  subnetInformer.Lister(),
-		subnetSynced:   subnetInformer.Informer().HasSynced,
-		servicesLister: serviceInformer.Lister(),
-		servicesSynced: serviceInformer.Informer().HasSynced,
+		podsLister:          podInformer.Lister(),
+		podsSynced:          podInformer.Informer().HasSynced,
+		subnetsLister:       subnetInformer.Lister(),
+		subnetSynced:        subnetInformer.Informer().HasSynced,
+		servicesLister:      serviceInformer.Lister(),
+		servicesSynced:      serviceInformer.Informer().HasSynced,
+		nodesLister:         nodeInformer.Lister(),
+		nodesSynced:         nodeInformer.Informer().HasSynced,
+		bgpPeersLister:      bgpPeerInformer.Lister(),
+		bgpPeersSynced:      bgpPeerInformer.Informer().HasSynced,
+		iptablesEipsLister:  iptablesEipInformer.Lister(),
+		iptablesEipsSynced:  iptablesEipInformer.Informer().HasSynced,
+		vpcNatGatewayLister: vpcNatGatewayInformer.Lister(),
+		vpcNatGatewaySynced: vpcNatGatewayInformer.Informer().HasSynced,
+		ovnEipsLister:       ovnEipInformer.Lister(),
+		ovnEipsSynced:       ovnEipInformer.Informer().HasSynced,
+		secretsLister:       secretInformer.Lister(),
+		secretsSynced:       secretInformer.Informer().HasSynced,
+		bgpPeers:            make(map[string]*kubeovnv1.BgpPeer),
+		importVpcs:          make(map[string]bool),
 
 		informerFactory:        informerFactory,
 		kubeovnInformerFactory: kubeovnInformerFactory,
@@ -82,13 +128,17 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 	c.informerFactory.Start(stopCh)
 	c.kubeovnInformerFactory.Start(stopCh)
 
-	if !cache.WaitForCacheSync(stopCh, c.podsSynced, c.subnetSynced, c.servicesSynced) {
+	if !cache.WaitForCacheSync(stopCh, c.podsSynced, c.subnetSynced, c.servicesSynced, c.nodesSynced, c.bgpPeersSynced,
+		c.iptablesEipsSynced, c.vpcNatGatewaySynced, c.ovnEipsSynced, c.secretsSynced) {
 		util.LogFatalAndExit(nil, "failed to wait for caches to sync")
 		return
 	}
 
 	klog.Info("Started workers")
+	go c.handleBfdEvents(stopCh)
+	go wait.Until(c.syncBgpPeers, 5*time.Second, stopCh)
 	go wait.Until(c.syncSubnetRoutes, 5*time.Second, stopCh)
+	go wait.Until(c.syncImportedRoutes, 5*time.Second, stopCh)
 
 	<-stopCh
 	klog.Info("Shutting down workers")
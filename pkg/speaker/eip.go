@@ -0,0 +1,244 @@
+package speaker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func isLoadBalancerService(svc *v1.Service) bool {
+	return svc.Spec.Type == v1.ServiceTypeLoadBalancer && len(svc.Status.LoadBalancer.Ingress) != 0
+}
+
+// isPodLocalAndRunning returns whether the pod is running on the node of the speaker
+func (c *Controller) isPodLocalAndRunning(pod *v1.Pod) bool {
+	return pod.Spec.NodeName == c.config.NodeName && pod.Status.Phase == v1.PodRunning && pod.DeletionTimestamp == nil
+}
+
+// hostsAnyPod returns whether any of the pods selected by the labels is running on the node of the speaker
+func (c *Controller) hostsAnyPod(namespace string, set labels.Set) (bool, error) {
+	pods, err := c.podsLister.Pods(namespace).List(labels.SelectorFromSet(set))
+	if err != nil {
+		return false, err
+	}
+	for _, pod := range pods {
+		if c.isPodLocalAndRunning(pod) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isActiveNatGwLocal returns whether the active instance of the vpc nat gateway is running on the node of the speaker
+func (c *Controller) isActiveNatGwLocal(gwName string) (bool, error) {
+	gw, err := c.vpcNatGatewayLister.Get(gwName)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if gw.Status.ActiveInstance == "" {
+		return false, nil
+	}
+	pods, err := c.podsLister.Pods(v1.NamespaceAll).List(labels.SelectorFromSet(labels.Set{"app": util.GenNatGwStsName(gwName), util.VpcNatGatewayLabel: "true"}))
+	if err != nil {
+		return false, err
+	}
+	for _, pod := range pods {
+		if pod.Name == gw.Status.ActiveInstance {
+			return c.isPodLocalAndRunning(pod), nil
+		}
+	}
+	return false, nil
+}
+
+// localGatewayRoutes returns the eips and loadbalancer ips to be announced by the speaker of the node,
+// keyed by route with the annotations of the object. An address is only announced by the node hosting
+// the active vpc nat gateway instance, the loadbalancer service pod or the active gateway chassis,
+// so the route is withdrawn and announced by another node once the gateway fails over.
+func (c *Controller) localGatewayRoutes() map[string]map[string]string {
+	routes := make(map[string]map[string]string)
+	addRoute := func(ip string, annotations map[string]string) {
+		if ip = strings.TrimSpace(ip); ip == "" {
+			return
+		}
+		if util.CheckProtocol(ip) == kubeovnv1.ProtocolIPv6 {
+			routes[ip+"/128"] = annotations
+		} else {
+			routes[ip+"/32"] = annotations
+		}
+	}
+
+	services, err := c.servicesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list services, %v", err)
+	}
+	for _, svc := range services {
+		if svc.Annotations[util.BgpAnnotation] != "true" || !isLoadBalancerService(svc) {
+			continue
+		}
+		// the pods of the loadbalancer service are created by the controller with --enable-lb-svc
+		local, err := c.hostsAnyPod(svc.Namespace, labels.Set{"namespace": svc.Namespace, "service": svc.Name})
+		if err != nil {
+			klog.Errorf("failed to list pods of loadbalancer service %s/%s, %v", svc.Namespace, svc.Name, err)
+			continue
+		}
+		if !local {
+			continue
+		}
+		for _, ingress := range svc.Status.LoadBalancer.Ingress {
+			addRoute(ingress.IP, svc.Annotations)
+		}
+	}
+
+	eips, err := c.iptablesEipsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list iptables eips, %v", err)
+	}
+	for _, eip := range eips {
+		if eip.Annotations[util.BgpAnnotation] != "true" || !eip.Status.Ready || eip.Spec.NatGwDp == "" {
+			continue
+		}
+		// the standby instances of the gateway are running as well, but only the active one holds the eips
+		local, err := c.isActiveNatGwLocal(eip.Spec.NatGwDp)
+		if err != nil {
+			klog.Errorf("failed to list pods of vpc nat gateway %s, %v", eip.Spec.NatGwDp, err)
+			continue
+		}
+		if !local {
+			continue
+		}
+		addRoute(eip.Status.IP, eip.Annotations)
+		addRoute(eip.Spec.V6ip, eip.Annotations)
+	}
+
+	ovnEips, err := c.ovnEipsLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list ovn eips, %v", err)
+	}
+	var annotated []*kubeovnv1.OvnEip
+	for _, eip := range ovnEips {
+		if eip.Annotations[util.BgpAnnotation] == "true" && eip.Status.Ready {
+			annotated = append(annotated, eip)
+		}
+	}
+	if len(annotated) != 0 {
+		if c.config.OvnNbClient == nil {
+			klog.Warning("--ovn-nb-addr is not set, skip announcing ovn eips")
+			return routes
+		}
+		lrps, err := c.ovnEipGatewayPorts()
+		if err != nil {
+			klog.Errorf("failed to get gateway ports of ovn eips, %v", err)
+			return routes
+		}
+		chassisNodes := c.readyChassisNodes()
+		for _, eip := range annotated {
+			var lrpName string
+			if eip.Spec.Type == util.Lrp {
+				lrpName = eip.Name
+			} else if router := lrps[eip.Spec.V4Ip]; router != "" {
+				lrpName = fmt.Sprintf("%s-%s", router, eip.Spec.ExternalSubnet)
+			}
+			if lrpName == "" {
+				continue
+			}
+			node, err := c.activeGatewayNode(lrpName, chassisNodes)
+			if err != nil {
+				klog.Errorf("failed to get active gateway chassis of ovn eip %s, %v", eip.Name, err)
+				continue
+			}
+			if node != c.config.NodeName {
+				continue
+			}
+			addRoute(eip.Spec.V4Ip, eip.Annotations)
+			addRoute(eip.Spec.V6Ip, eip.Annotations)
+		}
+	}
+
+	return routes
+}
+
+// ovnEipGatewayPorts returns the logical routers of the nat rules, keyed by the external ip
+func (c *Controller) ovnEipGatewayPorts() (map[string]string, error) {
+	nbClient := c.config.OvnNbClient
+	routers, err := nbClient.ListLogicalRouter(true, nil)
+	if err != nil {
+		return nil, err
+	}
+	natRouters := make(map[string]string)
+	for _, lr := range routers {
+		nats, err := nbClient.ListNats(lr.Name, "", "", nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, nat := range nats {
+			natRouters[nat.ExternalIP] = lr.Name
+		}
+	}
+	return natRouters, nil
+}
+
+// readyChassisNodes returns the ready nodes keyed by the chassis name
+func (c *Controller) readyChassisNodes() map[string]string {
+	nodes, err := c.nodesLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list nodes, %v", err)
+		return nil
+	}
+	chassisNodes := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		chassis := node.Annotations[util.ChassisAnnotation]
+		if chassis == "" {
+			continue
+		}
+		for _, cond := range node.Status.Conditions {
+			if cond.Type == v1.NodeReady && cond.Status == v1.ConditionTrue {
+				chassisNodes[chassis] = node.Name
+				break
+			}
+		}
+	}
+	return chassisNodes
+}
+
+// activeGatewayNode returns the node of the gateway chassis with the highest priority among the ready nodes,
+// which is the one ovn binds the distributed gateway port to
+func (c *Controller) activeGatewayNode(lrpName string, chassisNodes map[string]string) (string, error) {
+	nbClient := c.config.OvnNbClient
+	lrp, err := nbClient.GetLogicalRouterPort(lrpName, true)
+	if err != nil || lrp == nil {
+		return "", err
+	}
+
+	gwChassises := make([]*ovnnb.GatewayChassis, 0, len(lrp.GatewayChassis))
+	for _, uuid := range lrp.GatewayChassis {
+		ctx, cancel := context.WithTimeout(context.Background(), nbClient.Timeout)
+		gwChassis := &ovnnb.GatewayChassis{UUID: uuid}
+		err := nbClient.Get(ctx, gwChassis)
+		cancel()
+		if err != nil {
+			return "", fmt.Errorf("failed to get gateway chassis %s of logical router port %s: %v", uuid, lrpName, err)
+		}
+		gwChassises = append(gwChassises, gwChassis)
+	}
+	sort.Slice(gwChassises, func(i, j int) bool { return gwChassises[i].Priority > gwChassises[j].Priority })
+
+	for _, gwChassis := range gwChassises {
+		if node := chassisNodes[gwChassis.ChassisName]; node != "" {
+			return node, nil
+		}
+	}
+	return "", nil
+}
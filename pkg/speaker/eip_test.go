@@ -0,0 +1,154 @@
+package speaker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	kubeovnlister "github.com/kubeovn/kube-ovn/pkg/client/listers/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func newIndexer(objects ...interface{}) cache.Indexer {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, obj := range objects {
+		_ = indexer.Add(obj)
+	}
+	return indexer
+}
+
+func newPod(namespace, name, node string, phase v1.PodPhase, labels map[string]string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		Spec:       v1.PodSpec{NodeName: node},
+		Status:     v1.PodStatus{Phase: phase},
+	}
+}
+
+func Test_localGatewayRoutes(t *testing.T) {
+	t.Parallel()
+
+	const node = "node1"
+	bgpAnnotations := map[string]string{util.BgpAnnotation: "true", util.BgpMedAnnotation: "10"}
+	lbIngress := v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "172.18.0.10"}, {IP: "fd00::10"}}}
+
+	services := []interface{}{
+		// announced by the node hosting the pod of the loadbalancer service
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "local", Annotations: bgpAnnotations},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+			Status:     v1.ServiceStatus{LoadBalancer: lbIngress},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "remote", Annotations: bgpAnnotations},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+			Status:     v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "172.18.0.11"}}}},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "not-annotated"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+			Status:     v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "172.18.0.12"}}}},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pending", Annotations: bgpAnnotations},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+		},
+	}
+	natGwLabels := func(gw string) map[string]string {
+		return map[string]string{"app": util.GenNatGwStsName(gw), util.VpcNatGatewayLabel: "true"}
+	}
+	pods := []interface{}{
+		newPod("default", "lb-svc-local", node, v1.PodRunning, map[string]string{"namespace": "default", "service": "local"}),
+		newPod("default", "lb-svc-remote", "node2", v1.PodRunning, map[string]string{"namespace": "default", "service": "remote"}),
+		newPod("kube-system", "vpc-nat-gw-gw1-0", node, v1.PodRunning, natGwLabels("gw1")),
+		newPod("kube-system", "vpc-nat-gw-gw2-0", node, v1.PodPending, natGwLabels("gw2")),
+		// the standby instance of gw3 is running on the node
+		newPod("kube-system", "vpc-nat-gw-gw3-0", node, v1.PodRunning, natGwLabels("gw3")),
+		newPod("kube-system", "vpc-nat-gw-gw3-1", "node2", v1.PodRunning, natGwLabels("gw3")),
+	}
+	gws := []interface{}{
+		&kubeovnv1.VpcNatGateway{ObjectMeta: metav1.ObjectMeta{Name: "gw1"}, Status: kubeovnv1.VpcNatStatus{ActiveInstance: "vpc-nat-gw-gw1-0"}},
+		&kubeovnv1.VpcNatGateway{ObjectMeta: metav1.ObjectMeta{Name: "gw2"}, Status: kubeovnv1.VpcNatStatus{ActiveInstance: "vpc-nat-gw-gw2-0"}},
+		&kubeovnv1.VpcNatGateway{ObjectMeta: metav1.ObjectMeta{Name: "gw3"}, Status: kubeovnv1.VpcNatStatus{ActiveInstance: "vpc-nat-gw-gw3-1"}},
+	}
+	eips := []interface{}{
+		&kubeovnv1.IptablesEIP{
+			ObjectMeta: metav1.ObjectMeta{Name: "eip1", Annotations: map[string]string{util.BgpAnnotation: "true"}},
+			Spec:       kubeovnv1.IptablesEipSpec{NatGwDp: "gw1"},
+			Status:     kubeovnv1.IptablesEipStatus{Ready: true, IP: "10.10.0.1"},
+		},
+		// the nat gateway pod is not running
+		&kubeovnv1.IptablesEIP{
+			ObjectMeta: metav1.ObjectMeta{Name: "eip2", Annotations: map[string]string{util.BgpAnnotation: "true"}},
+			Spec:       kubeovnv1.IptablesEipSpec{NatGwDp: "gw2"},
+			Status:     kubeovnv1.IptablesEipStatus{Ready: true, IP: "10.10.0.2"},
+		},
+		&kubeovnv1.IptablesEIP{
+			ObjectMeta: metav1.ObjectMeta{Name: "eip3", Annotations: map[string]string{util.BgpAnnotation: "true"}},
+			Spec:       kubeovnv1.IptablesEipSpec{NatGwDp: "gw1"},
+			Status:     kubeovnv1.IptablesEipStatus{IP: "10.10.0.3"},
+		},
+		&kubeovnv1.IptablesEIP{
+			ObjectMeta: metav1.ObjectMeta{Name: "eip4", Annotations: map[string]string{util.BgpAnnotation: "true"}},
+			Spec:       kubeovnv1.IptablesEipSpec{NatGwDp: "gw3"},
+			Status:     kubeovnv1.IptablesEipStatus{Ready: true, IP: "10.10.0.4"},
+		},
+	}
+	// ovn eips are skipped without an ovn nb client
+	ovnEips := []interface{}{
+		&kubeovnv1.OvnEip{
+			ObjectMeta: metav1.ObjectMeta{Name: "ovn-eip1", Annotations: map[string]string{util.BgpAnnotation: "true"}},
+			Spec:       kubeovnv1.OvnEipSpec{V4Ip: "10.10.1.1", Type: util.Lrp},
+			Status:     kubeovnv1.OvnEipStatus{Ready: true},
+		},
+	}
+
+	gwIndexer := newIndexer(gws...)
+	c := &Controller{
+		config:              &Configuration{NodeName: node},
+		podsLister:          listerv1.NewPodLister(newIndexer(pods...)),
+		servicesLister:      listerv1.NewServiceLister(newIndexer(services...)),
+		iptablesEipsLister:  kubeovnlister.NewIptablesEIPLister(newIndexer(eips...)),
+		vpcNatGatewayLister: kubeovnlister.NewVpcNatGatewayLister(gwIndexer),
+		ovnEipsLister:       kubeovnlister.NewOvnEipLister(newIndexer(ovnEips...)),
+	}
+	require.Equal(t, map[string]map[string]string{
+		"172.18.0.10/32": bgpAnnotations,
+		"fd00::10/128":   bgpAnnotations,
+		"10.10.0.1/32":   {util.BgpAnnotation: "true"},
+	}, c.localGatewayRoutes())
+
+	// gw3 fails over to the instance on the node and gw1 to another node
+	_ = gwIndexer.Update(&kubeovnv1.VpcNatGateway{ObjectMeta: metav1.ObjectMeta{Name: "gw3"}, Status: kubeovnv1.VpcNatStatus{ActiveInstance: "vpc-nat-gw-gw3-0"}})
+	_ = gwIndexer.Update(&kubeovnv1.VpcNatGateway{ObjectMeta: metav1.ObjectMeta{Name: "gw1"}, Status: kubeovnv1.VpcNatStatus{ActiveInstance: "vpc-nat-gw-gw1-1"}})
+	require.Equal(t, map[string]map[string]string{
+		"172.18.0.10/32": bgpAnnotations,
+		"fd00::10/128":   bgpAnnotations,
+		"10.10.0.4/32":   {util.BgpAnnotation: "true"},
+	}, c.localGatewayRoutes())
+}
+
+func Test_readyChassisNodes(t *testing.T) {
+	t.Parallel()
+
+	newNode := func(name, chassis string, ready v1.ConditionStatus) *v1.Node {
+		node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if chassis != "" {
+			node.Annotations = map[string]string{util.ChassisAnnotation: chassis}
+		}
+		node.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, Status: ready}}
+		return node
+	}
+
+	c := &Controller{nodesLister: listerv1.NewNodeLister(newIndexer(
+		newNode("node1", "chassis1", v1.ConditionTrue),
+		newNode("node2", "chassis2", v1.ConditionFalse),
+		newNode("node3", "", v1.ConditionTrue),
+	))}
+	require.Equal(t, map[string]string{"chassis1": "node1"}, c.readyChassisNodes())
+}
@@ -0,0 +1,99 @@
+package speaker
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	bfdSessionStateGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "speaker_bfd_session_state",
+			Help: "The state of the bfd session with the bgp neighbor, 0 for AdminDown, 1 for Down, 2 for Init and 3 for Up",
+		},
+		[]string{
+			"peer",
+		})
+	bfdDesiredMinTxIntervalGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "speaker_bfd_desired_min_tx_interval_seconds",
+			Help: "The desired minimum transmit interval advertised to the bgp neighbor",
+		},
+		[]string{
+			"peer",
+		})
+	bfdRequiredMinRxIntervalGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "speaker_bfd_required_min_rx_interval_seconds",
+			Help: "The required minimum receive interval advertised to the bgp neighbor",
+		},
+		[]string{
+			"peer",
+		})
+	bfdDetectMultiplierGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "speaker_bfd_detect_multiplier",
+			Help: "The detect multiplier advertised to the bgp neighbor",
+		},
+		[]string{
+			"peer",
+		})
+	bfdRemoteMinTxIntervalGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "speaker_bfd_remote_min_tx_interval_seconds",
+			Help: "The desired minimum transmit interval advertised by the bgp neighbor",
+		},
+		[]string{
+			"peer",
+		})
+	bfdRemoteMinRxIntervalGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "speaker_bfd_remote_min_rx_interval_seconds",
+			Help: "The required minimum receive interval advertised by the bgp neighbor",
+		},
+		[]string{
+			"peer",
+		})
+	bfdDetectionTimeGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "speaker_bfd_detection_time_seconds",
+			Help: "The time without control packets from the bgp neighbor after which the session is declared down",
+		},
+		[]string{
+			"peer",
+		})
+	bfdSessionDownCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "speaker_bfd_session_down_total",
+			Help: "The number of times the bfd session with the bgp neighbor went down",
+		},
+		[]string{
+			"peer",
+		})
+	bfdPacketsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "speaker_bfd_packets_total",
+			Help: "The number of bfd control packets exchanged with the bgp neighbor",
+		},
+		[]string{
+			"peer",
+			"direction",
+		})
+)
+
+func bfdSessionGauges() []*prometheus.GaugeVec {
+	return []*prometheus.GaugeVec{
+		bfdSessionStateGauge,
+		bfdDesiredMinTxIntervalGauge,
+		bfdRequiredMinRxIntervalGauge,
+		bfdDetectMultiplierGauge,
+		bfdRemoteMinTxIntervalGauge,
+		bfdRemoteMinRxIntervalGauge,
+		bfdDetectionTimeGauge,
+	}
+}
+
+func InitMetrics() {
+	for _, g := range bfdSessionGauges() {
+		prometheus.MustRegister(g)
+	}
+	prometheus.MustRegister(bfdSessionDownCounter)
+	prometheus.MustRegister(bfdPacketsCounter)
+}
@@ -0,0 +1,179 @@
+package speaker
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	bgpapi "github.com/osrg/gobgp/v3/api"
+	"github.com/osrg/gobgp/v3/pkg/packet/bgp"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// routeAttributes are the optional path attributes of an announced prefix,
+// set by annotations of the subnet, service or pod
+type routeAttributes struct {
+	Communities      []uint32
+	LargeCommunities []bgp.LargeCommunity
+	Med              *uint32
+	LocalPref        *uint32
+	// AsPathPrepend is the number of times the cluster AS is prepended to the AS path
+	AsPathPrepend int
+}
+
+func (a *routeAttributes) Equal(b *routeAttributes) bool {
+	if a == nil || b == nil {
+		return a.isEmpty() && b.isEmpty()
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func (a *routeAttributes) isEmpty() bool {
+	return a == nil || (len(a.Communities) == 0 && len(a.LargeCommunities) == 0 &&
+		a.Med == nil && a.LocalPref == nil && a.AsPathPrepend == 0)
+}
+
+func parseCommunity(s string) (uint32, error) {
+	if v, ok := bgp.WellKnownCommunityValueMap[s]; ok {
+		return uint32(v), nil
+	}
+	if v, err := strconv.ParseUint(s, 10, 32); err == nil {
+		return uint32(v), nil
+	}
+	fields := strings.Split(s, ":")
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("invalid community %q", s)
+	}
+	asn, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid community %q", s)
+	}
+	value, err := strconv.ParseUint(fields[1], 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid community %q", s)
+	}
+	return uint32(asn<<16 | value), nil
+}
+
+// parseRouteAttributes parses the path attribute annotations, it returns nil if none is set
+func parseRouteAttributes(annotations map[string]string) (*routeAttributes, error) {
+	attrs := &routeAttributes{}
+	if v := annotations[util.BgpCommunityAnnotation]; v != "" {
+		for _, s := range strings.Split(v, ",") {
+			community, err := parseCommunity(strings.TrimSpace(s))
+			if err != nil {
+				return nil, err
+			}
+			attrs.Communities = append(attrs.Communities, community)
+		}
+	}
+	if v := annotations[util.BgpLargeCommunityAnnotation]; v != "" {
+		for _, s := range strings.Split(v, ",") {
+			community, err := bgp.ParseLargeCommunity(strings.TrimSpace(s))
+			if err != nil {
+				return nil, fmt.Errorf("invalid large community %q: %w", s, err)
+			}
+			attrs.LargeCommunities = append(attrs.LargeCommunities, *community)
+		}
+	}
+	if v := annotations[util.BgpMedAnnotation]; v != "" {
+		med, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid med %q: %w", v, err)
+		}
+		attrs.Med = new(uint32)
+		*attrs.Med = uint32(med)
+	}
+	if v := annotations[util.BgpLocalPrefAnnotation]; v != "" {
+		localPref, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid local preference %q: %w", v, err)
+		}
+		attrs.LocalPref = new(uint32)
+		*attrs.LocalPref = uint32(localPref)
+	}
+	if v := annotations[util.BgpAsPathPrependAnnotation]; v != "" {
+		n, err := strconv.ParseUint(v, 10, 8)
+		if err != nil || n > 32 {
+			return nil, fmt.Errorf("invalid as path prepend count %q, must be in the range 0 to 32", v)
+		}
+		attrs.AsPathPrepend = int(n)
+	}
+
+	if attrs.isEmpty() {
+		return nil, nil
+	}
+	return attrs, nil
+}
+
+// routeAttributesFromPath returns the optional attributes of a path announced by the speaker
+func routeAttributesFromPath(pattrs []bgp.PathAttributeInterface) *routeAttributes {
+	attrs := &routeAttributes{}
+	for _, attr := range pattrs {
+		switch a := attr.(type) {
+		case *bgp.PathAttributeCommunities:
+			attrs.Communities = append(attrs.Communities, a.Value...)
+		case *bgp.PathAttributeLargeCommunities:
+			for _, c := range a.Values {
+				attrs.LargeCommunities = append(attrs.LargeCommunities, *c)
+			}
+		case *bgp.PathAttributeMultiExitDisc:
+			attrs.Med = new(uint32)
+			*attrs.Med = a.Value
+		case *bgp.PathAttributeLocalPref:
+			attrs.LocalPref = new(uint32)
+			*attrs.LocalPref = a.Value
+		case *bgp.PathAttributeAsPath:
+			for _, param := range a.Value {
+				attrs.AsPathPrepend += len(param.GetAS())
+			}
+		}
+	}
+	if attrs.isEmpty() {
+		return nil
+	}
+	return attrs
+}
+
+// apiAttributes converts the attributes to the ones added to the gobgp path
+func (a *routeAttributes) apiAttributes(localAs uint32) []*anypb.Any {
+	if a == nil {
+		return nil
+	}
+
+	var attrs []*anypb.Any
+	if len(a.Communities) != 0 {
+		attr, _ := anypb.New(&bgpapi.CommunitiesAttribute{Communities: a.Communities})
+		attrs = append(attrs, attr)
+	}
+	if len(a.LargeCommunities) != 0 {
+		communities := make([]*bgpapi.LargeCommunity, 0, len(a.LargeCommunities))
+		for _, c := range a.LargeCommunities {
+			communities = append(communities, &bgpapi.LargeCommunity{GlobalAdmin: c.ASN, LocalData1: c.LocalData1, LocalData2: c.LocalData2})
+		}
+		attr, _ := anypb.New(&bgpapi.LargeCommunitiesAttribute{Communities: communities})
+		attrs = append(attrs, attr)
+	}
+	if a.Med != nil {
+		attr, _ := anypb.New(&bgpapi.MultiExitDiscAttribute{Med: *a.Med})
+		attrs = append(attrs, attr)
+	}
+	if a.LocalPref != nil {
+		attr, _ := anypb.New(&bgpapi.LocalPrefAttribute{LocalPref: *a.LocalPref})
+		attrs = append(attrs, attr)
+	}
+	if a.AsPathPrepend != 0 {
+		numbers := make([]uint32, a.AsPathPrepend)
+		for i := range numbers {
+			numbers[i] = localAs
+		}
+		attr, _ := anypb.New(&bgpapi.AsPathAttribute{
+			Segments: []*bgpapi.AsSegment{{Type: bgpapi.AsSegment_AS_SEQUENCE, Numbers: numbers}},
+		})
+		attrs = append(attrs, attr)
+	}
+	return attrs
+}
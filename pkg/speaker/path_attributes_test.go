@@ -0,0 +1,169 @@
+package speaker
+
+import (
+	"testing"
+
+	bgpapi "github.com/osrg/gobgp/v3/api"
+	"github.com/osrg/gobgp/v3/pkg/packet/bgp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func Test_parseCommunity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		community string
+		want      uint32
+		wantErr   bool
+	}{
+		{"well known", "no-export", uint32(bgp.COMMUNITY_NO_EXPORT), false},
+		{"decimal", "4259840100", 4259840100, false},
+		{"asn and value", "65000:100", 65000<<16 | 100, false},
+		{"asn out of range", "65536:100", 0, true},
+		{"value out of range", "65000:65536", 0, true},
+		{"too many fields", "65000:100:1", 0, true},
+		{"unknown name", "no-such-community", 0, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseCommunity(tt.community)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseRouteAttributes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *routeAttributes
+		wantErr     bool
+	}{
+		{
+			name:        "no attributes",
+			annotations: map[string]string{util.BgpAnnotation: "true"},
+			want:        nil,
+		},
+		{
+			name: "all attributes",
+			annotations: map[string]string{
+				util.BgpCommunityAnnotation:      "65000:100, no-advertise",
+				util.BgpLargeCommunityAnnotation: "65000:1:2",
+				util.BgpMedAnnotation:            "10",
+				util.BgpLocalPrefAnnotation:      "200",
+				util.BgpAsPathPrependAnnotation:  "3",
+			},
+			want: &routeAttributes{
+				Communities:      []uint32{65000<<16 | 100, uint32(bgp.COMMUNITY_NO_ADVERTISE)},
+				LargeCommunities: []bgp.LargeCommunity{{ASN: 65000, LocalData1: 1, LocalData2: 2}},
+				Med:              uint32Ptr(10),
+				LocalPref:        uint32Ptr(200),
+				AsPathPrepend:    3,
+			},
+		},
+		{
+			name:        "zero as path prepend",
+			annotations: map[string]string{util.BgpAsPathPrependAnnotation: "0"},
+			want:        nil,
+		},
+		{
+			name:        "decimal community",
+			annotations: map[string]string{util.BgpCommunityAnnotation: "65000"},
+			want:        &routeAttributes{Communities: []uint32{65000}},
+		},
+		{
+			name:        "invalid large community",
+			annotations: map[string]string{util.BgpLargeCommunityAnnotation: "65000:1"},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid med",
+			annotations: map[string]string{util.BgpMedAnnotation: "-1"},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid local preference",
+			annotations: map[string]string{util.BgpLocalPrefAnnotation: "high"},
+			wantErr:     true,
+		},
+		{
+			name:        "as path prepend out of range",
+			annotations: map[string]string{util.BgpAsPathPrependAnnotation: "33"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseRouteAttributes(tt.annotations)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_routeAttributesEqual(t *testing.T) {
+	t.Parallel()
+
+	var nilAttrs *routeAttributes
+	require.True(t, nilAttrs.Equal(nil))
+	require.True(t, nilAttrs.Equal(&routeAttributes{}))
+	require.True(t, (&routeAttributes{Med: uint32Ptr(10)}).Equal(&routeAttributes{Med: uint32Ptr(10)}))
+	require.False(t, (&routeAttributes{Med: uint32Ptr(10)}).Equal(&routeAttributes{Med: uint32Ptr(20)}))
+	require.False(t, (&routeAttributes{AsPathPrepend: 1}).Equal(nil))
+}
+
+func Test_routeAttributesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	const localAs = 65000
+	attrs := &routeAttributes{
+		Communities:      []uint32{65000<<16 | 100},
+		LargeCommunities: []bgp.LargeCommunity{{ASN: 65000, LocalData1: 1, LocalData2: 2}},
+		Med:              uint32Ptr(10),
+		LocalPref:        uint32Ptr(200),
+		AsPathPrepend:    2,
+	}
+
+	apiAttrs := attrs.apiAttributes(localAs)
+	require.Len(t, apiAttrs, 5)
+	asPath := &bgpapi.AsPathAttribute{}
+	require.NoError(t, apiAttrs[4].UnmarshalTo(asPath))
+	require.Equal(t, []uint32{localAs, localAs}, asPath.Segments[0].Numbers)
+
+	pattrs := []bgp.PathAttributeInterface{
+		bgp.NewPathAttributeOrigin(bgp.BGP_ORIGIN_ATTR_TYPE_IGP),
+		bgp.NewPathAttributeNextHop("192.168.0.1"),
+		bgp.NewPathAttributeCommunities(attrs.Communities),
+		bgp.NewPathAttributeLargeCommunities([]*bgp.LargeCommunity{&attrs.LargeCommunities[0]}),
+		bgp.NewPathAttributeMultiExitDisc(*attrs.Med),
+		bgp.NewPathAttributeLocalPref(*attrs.LocalPref),
+		bgp.NewPathAttributeAsPath([]bgp.AsPathParamInterface{bgp.NewAs4PathParam(bgp.BGP_ASPATH_ATTR_TYPE_SEQ, []uint32{localAs, localAs})}),
+	}
+	require.Equal(t, attrs, routeAttributesFromPath(pattrs))
+
+	// paths without optional attributes
+	require.Nil(t, routeAttributesFromPath(pattrs[:2]))
+	require.Nil(t, (*routeAttributes)(nil).apiAttributes(localAs))
+}
@@ -0,0 +1,267 @@
+package speaker
+
+import (
+	"context"
+	"net"
+
+	bgpapi "github.com/osrg/gobgp/v3/api"
+	bgpapiutil "github.com/osrg/gobgp/v3/pkg/apiutil"
+	"github.com/ovn-org/libovsdb/ovsdb"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"k8s.io/klog/v2"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	ovsclient "github.com/kubeovn/kube-ovn/pkg/ovsdb/client"
+	"github.com/kubeovn/kube-ovn/pkg/ovsdb/ovnnb"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+// rtProtoBgpImport marks the node routes installed from the routes learned by the speaker,
+// so they are not confused with the ones of other routing daemons using RTPROT_BGP
+const rtProtoBgpImport netlink.RouteProtocol = 196
+
+type nodeRouteKey struct {
+	table  int
+	prefix string
+}
+
+// prefixAllowed returns whether the route is within any of the prefixes, an empty list allows all routes
+func prefixAllowed(prefixes []string, route *net.IPNet) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	ones, _ := route.Mask.Size()
+	for _, p := range prefixes {
+		_, cidr, err := net.ParseCIDR(p)
+		if err != nil {
+			continue
+		}
+		if n, _ := cidr.Mask.Size(); n <= ones && cidr.Contains(route.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// importedRoutes returns the next hops of the routes learned from the BgpPeers with route import,
+// both for the node routing tables and for the vpcs. If several neighbors advertise the same prefix,
+// the path preferred by the best path selection is used.
+func (c *Controller) importedRoutes() (map[nodeRouteKey]string, map[string]map[string]string, error) {
+	peers := make(map[string]*kubeovnv1.BgpPeer)
+	c.bgpPeersMutex.RLock()
+	for addr, peer := range c.bgpPeers {
+		if peer.Spec.RouteImport != nil {
+			peers[addr] = peer
+		}
+	}
+	c.bgpPeersMutex.RUnlock()
+
+	nodeRoutes := make(map[nodeRouteKey]string)
+	vpcRoutes := make(map[string]map[string]string)
+	if len(peers) == 0 {
+		return nodeRoutes, vpcRoutes, nil
+	}
+
+	fn := func(d *bgpapi.Destination) {
+		_, dst, err := net.ParseCIDR(d.Prefix)
+		if err != nil {
+			return
+		}
+		// gobgp lists the best path first
+		for _, path := range d.Paths {
+			if path.IsWithdraw {
+				continue
+			}
+			neighbor := net.ParseIP(path.NeighborIp)
+			if neighbor == nil {
+				// locally originated
+				continue
+			}
+			peer := peers[neighbor.String()]
+			if peer == nil || !prefixAllowed(peer.Spec.RouteImport.Prefixes, dst) {
+				continue
+			}
+			attrs, err := bgpapiutil.UnmarshalPathAttributes(path.Pattrs)
+			if err != nil {
+				klog.Errorf("failed to unmarshal attributes of path %s from %s: %v", d.Prefix, path.NeighborIp, err)
+				continue
+			}
+			nextHop := getNextHopFromPathAttributes(attrs)
+			if nextHop == nil || nextHop.IsUnspecified() {
+				continue
+			}
+
+			table := peer.Spec.RouteImport.Table
+			if table == 0 {
+				table = unix.RT_TABLE_MAIN
+			}
+			key := nodeRouteKey{table: table, prefix: dst.String()}
+			if _, ok := nodeRoutes[key]; !ok {
+				nodeRoutes[key] = nextHop.String()
+			}
+			if vpc := peer.Spec.RouteImport.Vpc; vpc != "" {
+				if vpcRoutes[vpc] == nil {
+					vpcRoutes[vpc] = make(map[string]string)
+				}
+				if _, ok := vpcRoutes[vpc][dst.String()]; !ok {
+					vpcRoutes[vpc][dst.String()] = nextHop.String()
+				}
+			}
+		}
+	}
+
+	for _, afi := range []bgpapi.Family_Afi{bgpapi.Family_AFI_IP, bgpapi.Family_AFI_IP6} {
+		req := &bgpapi.ListPathRequest{
+			TableType: bgpapi.TableType_GLOBAL,
+			Family:    &bgpapi.Family{Afi: afi, Safi: bgpapi.Family_SAFI_UNICAST},
+		}
+		if err := c.config.BgpServer.ListPath(context.Background(), req, fn); err != nil {
+			klog.Errorf("failed to list learned routes: %v", err)
+			return nil, nil, err
+		}
+	}
+	return nodeRoutes, vpcRoutes, nil
+}
+
+// syncImportedRoutes installs the routes learned from the BgpPeers with route import into
+// the node routing tables and vpcs, and removes the ones no longer learned
+func (c *Controller) syncImportedRoutes() {
+	nodeRoutes, vpcRoutes, err := c.importedRoutes()
+	if err != nil {
+		return
+	}
+	if err = c.syncNodeImportedRoutes(nodeRoutes); err != nil {
+		klog.Errorf("failed to sync imported routes of node: %v", err)
+	}
+	c.syncVpcImportedRoutes(vpcRoutes)
+}
+
+func (c *Controller) syncNodeImportedRoutes(expected map[nodeRouteKey]string) error {
+	filter := &netlink.Route{Protocol: rtProtoBgpImport, Table: unix.RT_TABLE_UNSPEC}
+	routes, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, filter, netlink.RT_FILTER_PROTOCOL|netlink.RT_FILTER_TABLE)
+	if err != nil {
+		klog.Errorf("failed to list imported routes: %v", err)
+		return err
+	}
+
+	installed := make(map[nodeRouteKey]bool, len(routes))
+	for _, route := range routes {
+		if route.Dst == nil {
+			continue
+		}
+		key := nodeRouteKey{table: route.Table, prefix: route.Dst.String()}
+		if route.Gw != nil && expected[key] == route.Gw.String() {
+			installed[key] = true
+			continue
+		}
+		klog.Infof("delete imported route %s via %v in table %d", key.prefix, route.Gw, key.table)
+		if err = netlink.RouteDel(&route); err != nil {
+			klog.Errorf("failed to delete imported route %s in table %d: %v", key.prefix, key.table, err)
+		}
+	}
+
+	for key, nextHop := range expected {
+		if installed[key] {
+			continue
+		}
+		_, dst, _ := net.ParseCIDR(key.prefix)
+		route := &netlink.Route{
+			Dst:      dst,
+			Gw:       net.ParseIP(nextHop),
+			Table:    key.table,
+			Protocol: rtProtoBgpImport,
+		}
+		klog.Infof("add imported route %s via %s in table %d", key.prefix, nextHop, key.table)
+		if err = netlink.RouteReplace(route); err != nil {
+			klog.Errorf("failed to add imported route %s via %s in table %d: %v", key.prefix, nextHop, key.table, err)
+		}
+	}
+	return nil
+}
+
+// syncVpcImportedRoutes reconciles the static routes installed by the speaker of the node.
+// A route already present in the vpc, e.g. installed by the speaker of another node, is not added again.
+func (c *Controller) syncVpcImportedRoutes(expected map[string]map[string]string) {
+	if c.config.OvnNbClient == nil {
+		if len(expected) != 0 {
+			klog.Warning("--ovn-nb-addr is not set, skip importing routes into vpcs")
+		}
+		return
+	}
+
+	vpcs := make(map[string]bool, len(expected)+len(c.importVpcs))
+	for vpc := range expected {
+		vpcs[vpc] = true
+	}
+	for vpc := range c.importVpcs {
+		vpcs[vpc] = true
+	}
+
+	externalIDs := map[string]string{"vendor": util.CniTypeName, util.BgpImportNodeKey: c.config.NodeName}
+	policy := ovnnb.LogicalRouterStaticRoutePolicyDstIP
+	nbClient := c.config.OvnNbClient
+	for vpc := range vpcs {
+		routes, err := nbClient.ListLogicalRouterStaticRoutes(vpc, nil, nil, "", externalIDs)
+		if err != nil {
+			klog.Errorf("failed to list imported static routes of vpc %s: %v", vpc, err)
+			continue
+		}
+
+		var toDel []string
+		installed := make(map[string]bool, len(routes))
+		for _, route := range routes {
+			if expected[vpc][route.IPPrefix] == route.Nexthop && route.RouteTable == util.MainRouteTable {
+				installed[route.IPPrefix] = true
+				continue
+			}
+			klog.Infof("delete imported static route %s via %s of vpc %s", route.IPPrefix, route.Nexthop, vpc)
+			toDel = append(toDel, route.UUID)
+		}
+		if len(toDel) != 0 {
+			ops, err := nbClient.LogicalRouterUpdateStaticRouteOp(vpc, toDel, ovsdb.MutateOperationDelete)
+			if err != nil {
+				klog.Errorf("failed to generate operations for deleting imported static routes of vpc %s: %v", vpc, err)
+				continue
+			}
+			if err = nbClient.Transact("lr-route-del", ops); err != nil {
+				klog.Errorf("failed to delete imported static routes of vpc %s: %v", vpc, err)
+				continue
+			}
+		}
+
+		var toAdd []*ovnnb.LogicalRouterStaticRoute
+		for prefix, nextHop := range expected[vpc] {
+			if installed[prefix] {
+				continue
+			}
+			exists, err := nbClient.LogicalRouterStaticRouteExists(vpc, util.MainRouteTable, policy, prefix, nextHop)
+			if err != nil {
+				klog.Errorf("failed to get static route %s via %s of vpc %s: %v", prefix, nextHop, vpc, err)
+				continue
+			}
+			if exists {
+				continue
+			}
+			klog.Infof("add imported static route %s via %s of vpc %s", prefix, nextHop, vpc)
+			toAdd = append(toAdd, &ovnnb.LogicalRouterStaticRoute{
+				UUID:        ovsclient.NamedUUID(),
+				Policy:      &policy,
+				IPPrefix:    prefix,
+				Nexthop:     nextHop,
+				RouteTable:  util.MainRouteTable,
+				ExternalIDs: map[string]string{"vendor": util.CniTypeName, util.BgpImportNodeKey: c.config.NodeName},
+			})
+		}
+		if err = nbClient.CreateLogicalRouterStaticRoutes(vpc, toAdd...); err != nil {
+			klog.Errorf("failed to add imported static routes to vpc %s: %v", vpc, err)
+			continue
+		}
+
+		if len(expected[vpc]) == 0 {
+			delete(c.importVpcs, vpc)
+		} else {
+			c.importVpcs[vpc] = true
+		}
+	}
+}
@@ -52,6 +52,17 @@ func isClusterIPService(svc *v1.Service) bool {
 func (c *Controller) syncSubnetRoutes() {
 	maskMap := map[string]int{kubeovnv1.ProtocolIPv4: 32, kubeovnv1.ProtocolIPv6: 128}
 	bgpExpected, bgpExists := make(map[string][]string), make(map[string][]string)
+	expectedAttrs, existingAttrs := make(map[string]*routeAttributes), make(map[string]*routeAttributes)
+	setExpectedAttrs := func(route, kind, name string, annotations map[string]string) {
+		if _, ok := expectedAttrs[route]; ok {
+			return
+		}
+		attrs, err := parseRouteAttributes(annotations)
+		if err != nil {
+			klog.Errorf("invalid bgp path attributes of %s %s: %v", kind, name, err)
+		}
+		expectedAttrs[route] = attrs
+	}
 
 	subnets, err := c.subnetsLister.List(labels.Everything())
 	if err != nil {
@@ -74,13 +85,15 @@ func (c *Controller) syncSubnetRoutes() {
 			if svc.Annotations != nil && svc.Annotations[util.BgpAnnotation] == "true" && isClusterIPService(svc) {
 				for _, clusterIP := range svc.Spec.ClusterIPs {
 					ipFamily := util.CheckProtocol(clusterIP)
-					bgpExpected[ipFamily] = append(bgpExpected[ipFamily], fmt.Sprintf("%s/%d", clusterIP, maskMap[ipFamily]))
+					route := fmt.Sprintf("%s/%d", clusterIP, maskMap[ipFamily])
+					bgpExpected[ipFamily] = append(bgpExpected[ipFamily], route)
+					setExpectedAttrs(route, "service", svc.Namespace+"/"+svc.Name, svc.Annotations)
 				}
 			}
 		}
 	}
 
-	localSubnets := make(map[string]string, 2)
+	localSubnets := make(map[string]*kubeovnv1.Subnet, 2)
 	for _, subnet := range subnets {
 		if subnet.Status.IsReady() && subnet.Annotations != nil {
 			ips := strings.Split(subnet.Spec.CIDRBlock, ",")
@@ -96,9 +109,10 @@ func (c *Controller) syncSubnetRoutes() {
 				for _, cidr := range ips {
 					ipFamily := util.CheckProtocol(cidr)
 					bgpExpected[ipFamily] = append(bgpExpected[ipFamily], cidr)
+					setExpectedAttrs(cidr, "subnet", subnet.Name, subnet.Annotations)
 				}
 			case announcePolicyLocal:
-				localSubnets[subnet.Name] = subnet.Spec.CIDRBlock
+				localSubnets[subnet.Name] = subnet
 			default:
 				klog.Warningf("invalid subnet annotation %s=%s", util.BgpAnnotation, policy)
 			}
@@ -111,6 +125,7 @@ func (c *Controller) syncSubnetRoutes() {
 		}
 
 		ips := make(map[string]string, 2)
+		annotations := pod.Annotations
 		if policy := pod.Annotations[util.BgpAnnotation]; policy != "" {
 			switch policy {
 			case "true":
@@ -129,10 +144,11 @@ func (c *Controller) syncSubnetRoutes() {
 				klog.Warningf("invalid pod annotation %s=%s", util.BgpAnnotation, policy)
 			}
 		} else if pod.Spec.NodeName == c.config.NodeName {
-			cidrBlock := localSubnets[pod.Annotations[util.LogicalSwitchAnnotation]]
-			if cidrBlock != "" {
+			if subnet := localSubnets[pod.Annotations[util.LogicalSwitchAnnotation]]; subnet != nil {
+				// the pod addresses are announced with the path attributes of the subnet
+				annotations = subnet.Annotations
 				for _, podIP := range pod.Status.PodIPs {
-					if util.CIDRContainIP(cidrBlock, podIP.IP) {
+					if util.CIDRContainIP(subnet.Spec.CIDRBlock, podIP.IP) {
 						ips[util.CheckProtocol(podIP.IP)] = podIP.IP
 					}
 				}
@@ -140,10 +156,18 @@ func (c *Controller) syncSubnetRoutes() {
 		}
 
 		for ipFamily, ip := range ips {
-			bgpExpected[ipFamily] = append(bgpExpected[ipFamily], fmt.Sprintf("%s/%d", ip, maskMap[ipFamily]))
+			route := fmt.Sprintf("%s/%d", ip, maskMap[ipFamily])
+			bgpExpected[ipFamily] = append(bgpExpected[ipFamily], route)
+			setExpectedAttrs(route, "pod", pod.Namespace+"/"+pod.Name, annotations)
 		}
 	}
 
+	for route, annotations := range c.localGatewayRoutes() {
+		ipFamily := util.CheckProtocol(route)
+		bgpExpected[ipFamily] = append(bgpExpected[ipFamily], route)
+		setExpectedAttrs(route, "eip", route, annotations)
+	}
+
 	klog.V(5).Infof("expected announce ipv4 routes: %v, ipv6 routes: %v", bgpExpected[kubeovnv1.ProtocolIPv4], bgpExpected[kubeovnv1.ProtocolIPv6])
 
 	fn := func(d *bgpapi.Destination) {
@@ -155,12 +179,13 @@ func (c *Controller) syncSubnetRoutes() {
 			route, _ := netlink.RouteGet(nextHop)
 			if len(route) == 1 && route[0].Type == unix.RTN_LOCAL || nextHop.String() == c.config.RouterID {
 				bgpExists[ipFamily] = append(bgpExists[ipFamily], d.Prefix)
+				existingAttrs[d.Prefix] = routeAttributesFromPath(attrInterfaces)
 				return
 			}
 		}
 	}
 
-	if len(c.config.NeighborAddresses) != 0 {
+	if len(c.neighborAddresses(kubeovnv1.ProtocolIPv4)) != 0 {
 		listPathRequest := &bgpapi.ListPathRequest{
 			TableType: bgpapi.TableType_GLOBAL,
 			Family:    &bgpapi.Family{Afi: bgpapi.Family_AFI_IP, Safi: bgpapi.Family_SAFI_UNICAST},
@@ -172,9 +197,11 @@ func (c *Controller) syncSubnetRoutes() {
 
 		klog.V(5).Infof("exists ipv4 routes %v", bgpExists[kubeovnv1.ProtocolIPv4])
 		toAdd, toDel := routeDiff(bgpExpected[kubeovnv1.ProtocolIPv4], bgpExists[kubeovnv1.ProtocolIPv4])
+		// re-adding a route replaces the path, which updates its attributes in place
+		toAdd = append(toAdd, attrsChanged(bgpExpected[kubeovnv1.ProtocolIPv4], expectedAttrs, existingAttrs)...)
 		klog.V(5).Infof("toAdd ipv4 routes %v", toAdd)
 		for _, route := range toAdd {
-			if err := c.addRoute(route); err != nil {
+			if err := c.addRoute(route, expectedAttrs[route]); err != nil {
 				klog.Error(err)
 			}
 		}
@@ -187,7 +214,7 @@ func (c *Controller) syncSubnetRoutes() {
 		}
 	}
 
-	if len(c.config.NeighborIPv6Addresses) != 0 {
+	if len(c.neighborAddresses(kubeovnv1.ProtocolIPv6)) != 0 {
 		listIPv6PathRequest := &bgpapi.ListPathRequest{
 			TableType: bgpapi.TableType_GLOBAL,
 			Family:    &bgpapi.Family{Afi: bgpapi.Family_AFI_IP6, Safi: bgpapi.Family_SAFI_UNICAST},
@@ -200,10 +227,12 @@ func (c *Controller) syncSubnetRoutes() {
 
 		klog.V(5).Infof("exists ipv6 routes %v", bgpExists[kubeovnv1.ProtocolIPv6])
 		toAdd, toDel := routeDiff(bgpExpected[kubeovnv1.ProtocolIPv6], bgpExists[kubeovnv1.ProtocolIPv6])
+		// re-adding a route replaces the path, which updates its attributes in place
+		toAdd = append(toAdd, attrsChanged(bgpExpected[kubeovnv1.ProtocolIPv6], expectedAttrs, existingAttrs)...)
 		klog.V(5).Infof("toAdd ipv6 routes %v", toAdd)
 
 		for _, route := range toAdd {
-			if err := c.addRoute(route); err != nil {
+			if err := c.addRoute(route, expectedAttrs[route]); err != nil {
 				klog.Error(err)
 			}
 		}
@@ -217,6 +246,17 @@ func (c *Controller) syncSubnetRoutes() {
 	}
 }
 
+// attrsChanged returns the announced routes whose path attributes are to be updated in place
+func attrsChanged(expected []string, expectedAttrs, existingAttrs map[string]*routeAttributes) []string {
+	var routes []string
+	for _, route := range expected {
+		if existing, ok := existingAttrs[route]; ok && !existing.Equal(expectedAttrs[route]) {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
 func routeDiff(expected, exists []string) (toAdd, toDel []string) {
 	expectedMap, existsMap := map[string]bool{}, map[string]bool{}
 	for _, e := range expected {
@@ -255,13 +295,13 @@ func parseRoute(route string) (string, uint32, error) {
 	return prefix, prefixLen, nil
 }
 
-func (c *Controller) addRoute(route string) error {
+func (c *Controller) addRoute(route string, routeAttrs *routeAttributes) error {
 	routeAfi := bgpapi.Family_AFI_IP
 	if util.CheckProtocol(route) == kubeovnv1.ProtocolIPv6 {
 		routeAfi = bgpapi.Family_AFI_IP6
 	}
 
-	nlri, attrs, err := c.getNlriAndAttrs(route)
+	nlri, attrs, err := c.getNlriAndAttrs(route, routeAttrs)
 	if err != nil {
 		return err
 	}
@@ -281,11 +321,8 @@ func (c *Controller) addRoute(route string) error {
 	return nil
 }
 
-func (c *Controller) getNlriAndAttrs(route string) (*anypb.Any, [][]*anypb.Any, error) {
-	neighborAddresses := c.config.NeighborAddresses
-	if util.CheckProtocol(route) == kubeovnv1.ProtocolIPv6 {
-		neighborAddresses = c.config.NeighborIPv6Addresses
-	}
+func (c *Controller) getNlriAndAttrs(route string, routeAttrs *routeAttributes) (*anypb.Any, [][]*anypb.Any, error) {
+	neighborAddresses := c.neighborAddresses(util.CheckProtocol(route))
 
 	prefix, prefixLen, err := parseRoute(route)
 	if err != nil {
@@ -304,7 +341,7 @@ func (c *Controller) getNlriAndAttrs(route string) (*anypb.Any, [][]*anypb.Any,
 		a2, _ := anypb.New(&bgpapi.NextHopAttribute{
 			NextHop: getNextHopAttribute(addr, c.config.RouterID),
 		})
-		attrs = append(attrs, []*anypb.Any{a1, a2})
+		attrs = append(attrs, append([]*anypb.Any{a1, a2}, routeAttrs.apiAttributes(c.neighborLocalAs(addr))...))
 	}
 
 	return nlri, attrs, err
@@ -316,7 +353,7 @@ func (c *Controller) delRoute(route string) error {
 		routeAfi = bgpapi.Family_AFI_IP6
 	}
 
-	nlri, attrs, err := c.getNlriAndAttrs(route)
+	nlri, attrs, err := c.getNlriAndAttrs(route, nil)
 	if err != nil {
 		return err
 	}
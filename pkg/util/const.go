@@ -24,6 +24,12 @@ const (
 	AAPsAnnotation       = "ovn.kubernetes.io/aaps"
 	ChassisAnnotation    = "ovn.kubernetes.io/chassis"
 
+	BgpCommunityAnnotation      = "ovn.kubernetes.io/bgp_community"
+	BgpLargeCommunityAnnotation = "ovn.kubernetes.io/bgp_large_community"
+	BgpMedAnnotation            = "ovn.kubernetes.io/bgp_med"
+	BgpLocalPrefAnnotation      = "ovn.kubernetes.io/bgp_local_pref"
+	BgpAsPathPrependAnnotation  = "ovn.kubernetes.io/bgp_as_path_prepend"
+
 	ExternalIPAnnotation         = "ovn.kubernetes.io/external_ip"
 	ExternalMacAnnotation        = "ovn.kubernetes.io/external_mac"
 	ExternalCidrAnnotation       = "ovn.kubernetes.io/external_cidr"
@@ -45,6 +51,7 @@ const (
 	VpcNatAnnotation            = "ovn.kubernetes.io/vpc_nat"
 	OvnEipTypeLabel             = "ovn.kubernetes.io/ovn_eip_type"
 	EipV4IpLabel                = "ovn.kubernetes.io/eip_v4_ip"
+	SnatPortBlockLabel          = "ovn.kubernetes.io/snat_port_block"
 
 	SwitchLBRuleVipsAnnotation = "ovn.kubernetes.io/switch_lb_vip"
 	SwitchLBRuleVip            = "switch_lb_vip"
@@ -109,11 +116,21 @@ const (
 	VpcNatGatewayLabel         = "ovn.kubernetes.io/vpc-nat-gw"
 	IPReservedLabel            = "ovn.kubernetes.io/ip_reserved"
 	VpcNatGatewayNameLabel     = "ovn.kubernetes.io/vpc-nat-gw-name"
+	VpcEgressGatewayLabel      = "ovn.kubernetes.io/vpc-egress-gateway"
 	VpcLbLabel                 = "ovn.kubernetes.io/vpc_lb"
 	VpcDNSNameLabel            = "ovn.kubernetes.io/vpc-dns"
 	QoSLabel                   = "ovn.kubernetes.io/qos"
 	NodeNameLabel              = "ovn.kubernetes.io/node-name"
 	NetworkPolicyLogAnnotation = "ovn.kubernetes.io/enable_log"
+	// comma separated domain names the pods selected by a network policy are allowed to access,
+	// the addresses of the domain names are learned by snooping dns responses
+	NetworkPolicyEgressFqdnsAnnotation = "ovn.kubernetes.io/egress_fqdns"
+	// comma separated acl actions, allow and/or drop, whose hits are logged when acl log is enabled, default drop
+	NetworkPolicyLogActionsAnnotation = "ovn.kubernetes.io/log_acl_actions"
+	// max number of acl log messages per second of a network policy
+	NetworkPolicyLogRateAnnotation = "ovn.kubernetes.io/log_acl_rate"
+	// the traffic to be denied by a network policy in audit mode is logged instead of being dropped
+	NetworkPolicyAuditAnnotation = "ovn.kubernetes.io/policy_audit"
 
 	VpcLastName     = "ovn.kubernetes.io/last_vpc_name"
 	VpcLastPolicies = "ovn.kubernetes.io/last_policies"
@@ -147,13 +164,33 @@ const (
 	EgressAllowPriority = "2001"
 	EgressDefaultDrop   = "2000"
 
+	// the acls of network policies and security groups in audit mode log the packets and let them through,
+	// they are placed below all the drop acls so that the packets dropped by other policies are never allowed.
+	// As a result the packets allowed by acls with higher priorities, such as the node traffic, the east-west
+	// traffic of subnets with allowEWTraffic and the same subnet or allowed subnet traffic of private subnets,
+	// never reach the audit acls and are not logged, although they would be dropped once the policy is enforced.
+	IngressAuditPriority              = "999"
+	EgressAuditPriority               = "999"
+	SecurityGroupAuditHighestPriority = "900"
+	SecurityGroupAuditAllowPriority   = "600"
+
 	AllowEWTrafficPriority = "1900"
 
 	SubnetAllowPriority = "1001"
 	DefaultDropPriority = "1000"
 
+	// acls of admin network policies are placed above all the other acls, and the ones of
+	// baseline admin network policies are placed between network policies and subnet acls
+	AnpACLMaxPriority  = 30000
+	AnpMaxPriority     = 99
+	AnpMaxRules        = 100
+	BanpACLMaxPriority = 1999
+	BanpMaxRules       = 99
+
 	DefaultMTU = 1500
 
+	DefaultACLLogRate = 100
+
 	GeneveHeaderLength = 100
 	VxlanHeaderLength  = 50
 	SttHeaderLength    = 72
@@ -197,6 +234,7 @@ const (
 	IptablesFip = "iptables"
 
 	U2OSubnetPolicyPriority     = 29400
+	EgressGatewayPolicyPriority = 29300
 	GatewayRouterPolicyPriority = 29000
 	OvnICPolicyPriority         = 29500
 	NodeRouterPolicyPriority    = 30000
@@ -257,6 +295,9 @@ const (
 
 	MainRouteTable = ""
 
+	// BgpImportNodeKey is the external id of the vpc static routes imported by the speaker of the node
+	BgpImportNodeKey = "bgp-import-node"
+
 	NatPolicyRuleActionNat     = "nat"
 	NatPolicyRuleActionForward = "forward"
 	NatPolicyRuleIDLength      = 12
@@ -0,0 +1,31 @@
+package util
+
+import (
+	"strings"
+)
+
+// NormalizeFqdn returns the lower case form of a domain name without the trailing dot
+func NormalizeFqdn(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+}
+
+// MatchFqdn reports whether the domain name matches the fqdn, a fqdn starting with "*."
+// matches all the subdomains of the rest part but not the rest part itself
+func MatchFqdn(fqdn, name string) bool {
+	fqdn, name = NormalizeFqdn(fqdn), NormalizeFqdn(name)
+	if suffix, ok := strings.CutPrefix(fqdn, "*"); ok {
+		return len(name) > len(suffix) && strings.HasSuffix(name, suffix)
+	}
+	return fqdn == name
+}
+
+// ParseFqdns parses a comma separated list of fqdns
+func ParseFqdns(s string) []string {
+	var fqdns []string
+	for _, fqdn := range strings.Split(s, ",") {
+		if fqdn = NormalizeFqdn(fqdn); fqdn != "" {
+			fqdns = append(fqdns, fqdn)
+		}
+	}
+	return UniqString(fqdns)
+}
@@ -0,0 +1,38 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchFqdn(t *testing.T) {
+	tests := []struct {
+		name   string
+		fqdn   string
+		domain string
+		want   bool
+	}{
+		{"exact", "api.example.com", "api.example.com", true},
+		{"caseAndTrailingDot", "API.example.com", "api.example.com.", true},
+		{"different", "api.example.com", "www.example.com", false},
+		{"wildcardSubdomain", "*.example.com", "api.example.com", true},
+		{"wildcardMultiLevel", "*.example.com", "a.b.example.com", true},
+		{"wildcardSelf", "*.example.com", "example.com", false},
+		{"wildcardSuffixOnly", "*.example.com", "badexample.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchFqdn(tt.fqdn, tt.domain); got != tt.want {
+				t.Errorf("MatchFqdn(%q, %q) = %v, want %v", tt.fqdn, tt.domain, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFqdns(t *testing.T) {
+	got := ParseFqdns(" api.example.com, *.Example.com.,,api.example.com")
+	want := []string{"api.example.com", "*.example.com"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseFqdns() = %v, want %v", got, want)
+	}
+}
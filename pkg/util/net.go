@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"net"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -308,12 +309,79 @@ func GetIPAddrWithMask(ip, cidr string) string {
 			v6IP := fmt.Sprintf("%s/%s", ips[1], strings.Split(cidrBlocks[1], "/")[1])
 			ipAddr = v4IP + "," + v6IP
 		}
+	} else if cidrBlocks, ips := strings.Split(cidr, ","), strings.Split(ip, ","); len(cidrBlocks) > 1 && len(cidrBlocks) == len(ips) {
+		// secondary cidr blocks of a subnet are paired with their addresses in order
+		addrs := make([]string, 0, len(ips))
+		for i := range ips {
+			addrs = append(addrs, fmt.Sprintf("%s/%s", ips[i], strings.Split(cidrBlocks[i], "/")[1]))
+		}
+		ipAddr = strings.Join(addrs, ",")
 	} else {
 		ipAddr = fmt.Sprintf("%s/%s", ip, strings.Split(cidr, "/")[1])
 	}
 	return ipAddr
 }
 
+// GetSubnetCIDRBlocks returns the primary cidr blocks of the subnet followed by the secondary ones
+func GetSubnetCIDRBlocks(subnet *kubeovnv1.Subnet) string {
+	return strings.Join(append([]string{subnet.Spec.CIDRBlock}, subnet.Spec.SecondaryCIDRBlocks...), ",")
+}
+
+// GetSubnetCIDRAndGateway returns the cidr blocks and gateways that the addresses allocated from the subnet
+// belong to. Addresses in a secondary cidr block use the first address of the block as gateway.
+func GetSubnetCIDRAndGateway(subnet *kubeovnv1.Subnet, ipStr string) (string, string) {
+	if len(subnet.Spec.SecondaryCIDRBlocks) == 0 {
+		return subnet.Spec.CIDRBlock, subnet.Spec.Gateway
+	}
+
+	cidrBlocks := strings.Split(subnet.Spec.CIDRBlock, ",")
+	gateways := strings.Split(subnet.Spec.Gateway, ",")
+	for _, ip := range strings.Split(ipStr, ",") {
+		for _, cidr := range subnet.Spec.SecondaryCIDRBlocks {
+			if !CIDRContainIP(cidr, ip) {
+				continue
+			}
+			gw, err := FirstIP(cidr)
+			if err != nil {
+				klog.Error(err)
+				break
+			}
+			for i := range cidrBlocks {
+				if CheckProtocol(cidrBlocks[i]) == CheckProtocol(cidr) {
+					cidrBlocks[i] = cidr
+				}
+			}
+			for i := range gateways {
+				if CheckProtocol(gateways[i]) == CheckProtocol(gw) {
+					gateways[i] = gw
+				}
+			}
+			break
+		}
+	}
+	return strings.Join(cidrBlocks, ","), strings.Join(gateways, ",")
+}
+
+// GetSubnetCIDRBlockGateway returns the gateway of a single cidr block of the subnet. A secondary cidr block
+// uses its first address as gateway.
+func GetSubnetCIDRBlockGateway(subnet *kubeovnv1.Subnet, cidr string) (string, error) {
+	if slices.Contains(subnet.Spec.SecondaryCIDRBlocks, cidr) {
+		return FirstIP(cidr)
+	}
+	for _, gw := range strings.Split(subnet.Spec.Gateway, ",") {
+		if CheckProtocol(gw) == CheckProtocol(cidr) {
+			return gw, nil
+		}
+	}
+	return "", fmt.Errorf("no gateway of cidr %s found in subnet %s", cidr, subnet.Name)
+}
+
+// SubnetContainIP checks whether the addresses belong to the primary or secondary cidr blocks of the subnet
+func SubnetContainIP(subnet *kubeovnv1.Subnet, ipStr string) bool {
+	cidr, _ := GetSubnetCIDRAndGateway(subnet, ipStr)
+	return CIDRContainIP(cidr, ipStr)
+}
+
 func GetIPWithoutMask(ipStr string) string {
 	var ips []string
 	for _, ip := range strings.Split(ipStr, ",") {
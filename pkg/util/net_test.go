@@ -726,6 +726,12 @@ func TestGetIPAddrWithMask(t *testing.T) {
 			cidr: "10.16.0.0/24,ffff:ffff:ffff:ffff:ffff:0:ffff:0/96",
 			want: "10.16.0.23/24,ffff:ffff:ffff:ffff:ffff::23/96",
 		},
+		{
+			name: "secondary",
+			ip:   "10.16.0.1,10.17.0.1,10.18.0.1",
+			cidr: "10.16.0.0/24,10.17.0.0/16,10.18.0.0/20",
+			want: "10.16.0.1/24,10.17.0.1/16,10.18.0.1/20",
+		},
 	}
 	for _, c := range tests {
 		t.Run(c.name, func(t *testing.T) {
@@ -738,6 +744,79 @@ func TestGetIPAddrWithMask(t *testing.T) {
 	}
 }
 
+func TestGetSubnetCIDRAndGateway(t *testing.T) {
+	subnet := &kubeovnv1.Subnet{
+		Spec: kubeovnv1.SubnetSpec{
+			CIDRBlock:           "10.16.0.0/24,fd00::/120",
+			Gateway:             "10.16.0.1,fd00::1",
+			SecondaryCIDRBlocks: []string{"10.17.0.0/16", "fd01::/120"},
+		},
+	}
+	tests := []struct {
+		name string
+		ip   string
+		cidr string
+		gw   string
+	}{
+		{
+			name: "primary",
+			ip:   "10.16.0.2,fd00::2",
+			cidr: "10.16.0.0/24,fd00::/120",
+			gw:   "10.16.0.1,fd00::1",
+		},
+		{
+			name: "secondary",
+			ip:   "10.17.0.2,fd01::2",
+			cidr: "10.17.0.0/16,fd01::/120",
+			gw:   "10.17.0.1,fd01::1",
+		},
+		{
+			name: "mixed",
+			ip:   "10.17.0.2,fd00::2",
+			cidr: "10.17.0.0/16,fd00::/120",
+			gw:   "10.17.0.1,fd00::1",
+		},
+	}
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			cidr, gw := GetSubnetCIDRAndGateway(subnet, c.ip)
+			if cidr != c.cidr || gw != c.gw {
+				t.Errorf("%v expected %v %v, but %v %v got", c.ip, c.cidr, c.gw, cidr, gw)
+			}
+		})
+	}
+}
+
+func TestGetSubnetCIDRBlockGateway(t *testing.T) {
+	subnet := &kubeovnv1.Subnet{
+		Spec: kubeovnv1.SubnetSpec{
+			CIDRBlock:           "10.16.0.0/24,fd00::/120",
+			Gateway:             "10.16.0.254,fd00::1",
+			SecondaryCIDRBlocks: []string{"10.17.0.0/16"},
+		},
+	}
+	tests := []struct {
+		cidr string
+		gw   string
+	}{
+		{cidr: "10.16.0.0/24", gw: "10.16.0.254"},
+		{cidr: "fd00::/120", gw: "fd00::1"},
+		{cidr: "10.17.0.0/16", gw: "10.17.0.1"},
+	}
+	for _, c := range tests {
+		t.Run(c.cidr, func(t *testing.T) {
+			gw, err := GetSubnetCIDRBlockGateway(subnet, c.cidr)
+			if err != nil || gw != c.gw {
+				t.Errorf("%v expected %v, but %v %v got", c.cidr, c.gw, gw, err)
+			}
+		})
+	}
+
+	if !SubnetContainIP(subnet, "10.17.3.4") || SubnetContainIP(subnet, "10.18.0.1") {
+		t.Errorf("unexpected result of SubnetContainIP")
+	}
+}
+
 func TestGetIPWithoutMask(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,90 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"k8s.io/klog/v2"
+)
+
+// PortForwarder forwards a local port on the loopback address to a port of a pod through the API server
+type PortForwarder struct {
+	LocalPort uint16
+
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// PortForward starts forwarding a random local port to the port of the pod
+func PortForward(client kubernetes.Interface, cfg *rest.Config, namespace, podName string, port uint16, timeout time.Duration) (*PortForwarder, error) {
+	req := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("portforward")
+
+	transport, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		klog.Errorf("failed to create round tripper: %v", err)
+		return nil, err
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	f := &PortForwarder{stopCh: make(chan struct{}), doneCh: make(chan struct{})}
+	readyCh := make(chan struct{})
+	fw, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{fmt.Sprintf("0:%d", port)}, f.stopCh, readyCh, io.Discard, io.Discard)
+	if err != nil {
+		klog.Errorf("failed to create port forwarder for pod %s/%s: %v", namespace, podName, err)
+		return nil, err
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(f.doneCh)
+		errCh <- fw.ForwardPorts()
+	}()
+
+	select {
+	case <-readyCh:
+	case err = <-errCh:
+		klog.Errorf("failed to forward port %d of pod %s/%s: %v", port, namespace, podName, err)
+		return nil, err
+	case <-time.After(timeout):
+		f.Stop()
+		err = fmt.Errorf("timed out forwarding port %d of pod %s/%s", port, namespace, podName)
+		klog.Error(err)
+		return nil, err
+	}
+
+	ports, err := fw.GetPorts()
+	if err != nil {
+		f.Stop()
+		klog.Errorf("failed to get forwarded ports of pod %s/%s: %v", namespace, podName, err)
+		return nil, err
+	}
+	f.LocalPort = ports[0].Local
+	return f, nil
+}
+
+// Stop stops forwarding
+func (f *PortForwarder) Stop() {
+	f.stopOnce.Do(func() { close(f.stopCh) })
+}
+
+// Closed returns whether forwarding has stopped, e.g. the pod is gone or the connection is lost
+func (f *PortForwarder) Closed() bool {
+	select {
+	case <-f.doneCh:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,131 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// MaxPortMappingPorts is the max number of external ports of a dnat rule, each of which
+// becomes a load balancer vip of the ovn dnat or a conntrack entry of the iptables dnat
+const MaxPortMappingPorts = 1024
+
+// PortMapping maps the external port range to the internal port range of the same size one to one
+type PortMapping struct {
+	ExternalStart, ExternalEnd int
+	InternalStart, InternalEnd int
+}
+
+func formatPortRange(start, end int) string {
+	if start == end {
+		return strconv.Itoa(start)
+	}
+	return fmt.Sprintf("%d-%d", start, end)
+}
+
+// External returns the external port or port range, e.g. 80 or 8000-8100
+func (m PortMapping) External() string {
+	return formatPortRange(m.ExternalStart, m.ExternalEnd)
+}
+
+// Internal returns the internal port or port range
+func (m PortMapping) Internal() string {
+	return formatPortRange(m.InternalStart, m.InternalEnd)
+}
+
+// IsRange returns whether the mapping has more than one port
+func (m PortMapping) IsRange() bool {
+	return m.ExternalStart != m.ExternalEnd
+}
+
+// InternalPort returns the internal port which the external port is mapped to
+func (m PortMapping) InternalPort(externalPort int) int {
+	return m.InternalStart + externalPort - m.ExternalStart
+}
+
+func parsePortRange(s string) (int, int, error) {
+	first, last, isRange := strings.Cut(s, "-")
+	start, err := strconv.Atoi(strings.TrimSpace(first))
+	if err != nil || start < 0 || start > 65535 {
+		return 0, 0, fmt.Errorf("%q is not a valid port", first)
+	}
+	end := start
+	if isRange {
+		if end, err = strconv.Atoi(strings.TrimSpace(last)); err != nil || end < 0 || end > 65535 {
+			return 0, 0, fmt.Errorf("%q is not a valid port", last)
+		}
+		if end < start {
+			return 0, 0, fmt.Errorf("invalid port range %s", s)
+		}
+	}
+	return start, end, nil
+}
+
+// ParsePortMappings parses the external and internal ports of a dnat rule. Both of them are a port,
+// a port range like 8000-8100 or a comma separated list of them, the ports are mapped one to one
+// in order, e.g. 80,8000-8100 and 8080,9000-9100 map 80 to 8080 and 8000-8100 to 9000-9100.
+func ParsePortMappings(externalPorts, internalPorts string) ([]PortMapping, error) {
+	externals := strings.Split(externalPorts, ",")
+	internals := strings.Split(internalPorts, ",")
+	if len(externals) != len(internals) {
+		return nil, fmt.Errorf("external ports %q and internal ports %q have different number of items", externalPorts, internalPorts)
+	}
+
+	ports := 0
+	mappings := make([]PortMapping, 0, len(externals))
+	for i := range externals {
+		var m PortMapping
+		var err error
+		if m.ExternalStart, m.ExternalEnd, err = parsePortRange(externals[i]); err != nil {
+			return nil, fmt.Errorf("invalid external ports %q: %v", externalPorts, err)
+		}
+		if m.InternalStart, m.InternalEnd, err = parsePortRange(internals[i]); err != nil {
+			return nil, fmt.Errorf("invalid internal ports %q: %v", internalPorts, err)
+		}
+		if m.ExternalEnd-m.ExternalStart != m.InternalEnd-m.InternalStart {
+			return nil, fmt.Errorf("external ports %s and internal ports %s have different size", m.External(), m.Internal())
+		}
+		if ports += m.ExternalEnd - m.ExternalStart + 1; ports > MaxPortMappingPorts {
+			return nil, fmt.Errorf("external ports %q have more than %d ports", externalPorts, MaxPortMappingPorts)
+		}
+		for _, prev := range mappings {
+			if PortMappingsOverlap([]PortMapping{prev}, []PortMapping{m}) {
+				return nil, fmt.Errorf("external ports %s overlap with %s", m.External(), prev.External())
+			}
+		}
+		mappings = append(mappings, m)
+	}
+	return mappings, nil
+}
+
+// PortMappingsOverlap returns whether any external ports of the two lists overlap
+func PortMappingsOverlap(a, b []PortMapping) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x.ExternalStart <= y.ExternalEnd && y.ExternalStart <= x.ExternalEnd {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DnatProtocol returns the protocol of a dnat rule in lower case, tcp is used if it's empty
+func DnatProtocol(protocol string) string {
+	if protocol == "" {
+		return ProtocolTCP
+	}
+	return strings.ToLower(protocol)
+}
+
+// DnatPortsLabelValue returns the value of label VpcDnatEPortLabel for the external ports,
+// which is empty if the ports are too long for a label
+func DnatPortsLabelValue(ports string) string {
+	value := strings.ReplaceAll(strings.ReplaceAll(ports, " ", ""), ",", "_")
+	if len(validation.IsValidLabelValue(value)) != 0 {
+		return ""
+	}
+	return value
+}
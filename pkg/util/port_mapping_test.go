@@ -0,0 +1,123 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePortMappings(t *testing.T) {
+	tests := []struct {
+		name     string
+		external string
+		internal string
+		want     []PortMapping
+		err      bool
+	}{
+		{
+			name:     "single port",
+			external: "80",
+			internal: "8080",
+			want:     []PortMapping{{80, 80, 8080, 8080}},
+		},
+		{
+			name:     "range",
+			external: "8000-8100",
+			internal: "9000-9100",
+			want:     []PortMapping{{8000, 8100, 9000, 9100}},
+		},
+		{
+			name:     "list",
+			external: "80, 8000-8100",
+			internal: "8080,8000-8100",
+			want:     []PortMapping{{80, 80, 8080, 8080}, {8000, 8100, 8000, 8100}},
+		},
+		{
+			name:     "different number of items",
+			external: "80,443",
+			internal: "8080",
+			err:      true,
+		},
+		{
+			name:     "different range size",
+			external: "8000-8100",
+			internal: "9000-9010",
+			err:      true,
+		},
+		{
+			name:     "reversed range",
+			external: "8100-8000",
+			internal: "9100-9000",
+			err:      true,
+		},
+		{
+			name:     "invalid port",
+			external: "65536",
+			internal: "80",
+			err:      true,
+		},
+		{
+			name:     "overlapping items",
+			external: "8000-8100,8050",
+			internal: "9000-9100,80",
+			err:      true,
+		},
+		{
+			name:     "max ports",
+			external: "80,10000-11022",
+			internal: "80,20000-21022",
+			want:     []PortMapping{{80, 80, 80, 80}, {10000, 11022, 20000, 21022}},
+		},
+		{
+			name:     "too many ports",
+			external: "80,10000-11023",
+			internal: "80,20000-21023",
+			err:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePortMappings(tt.external, tt.internal)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPortMapping(t *testing.T) {
+	m := PortMapping{8000, 8100, 9000, 9100}
+	require.True(t, m.IsRange())
+	require.Equal(t, "8000-8100", m.External())
+	require.Equal(t, "9000-9100", m.Internal())
+	require.Equal(t, 9050, m.InternalPort(8050))
+
+	m = PortMapping{80, 80, 8080, 8080}
+	require.False(t, m.IsRange())
+	require.Equal(t, "80", m.External())
+	require.Equal(t, "8080", m.Internal())
+}
+
+func TestPortMappingsOverlap(t *testing.T) {
+	a := []PortMapping{{80, 80, 80, 80}, {8000, 8100, 8000, 8100}}
+	require.True(t, PortMappingsOverlap(a, []PortMapping{{8100, 8200, 8100, 8200}}))
+	require.True(t, PortMappingsOverlap(a, []PortMapping{{80, 80, 8080, 8080}}))
+	require.False(t, PortMappingsOverlap(a, []PortMapping{{81, 7999, 81, 7999}}))
+	require.False(t, PortMappingsOverlap(a, nil))
+}
+
+func TestDnatProtocol(t *testing.T) {
+	require.Equal(t, "tcp", DnatProtocol(""))
+	require.Equal(t, "tcp", DnatProtocol("TCP"))
+	require.Equal(t, "udp", DnatProtocol("udp"))
+}
+
+func TestDnatPortsLabelValue(t *testing.T) {
+	require.Equal(t, "80", DnatPortsLabelValue("80"))
+	require.Equal(t, "80_8000-8100", DnatPortsLabelValue("80, 8000-8100"))
+	require.Equal(t, "", DnatPortsLabelValue(strings.Repeat("80,", 30)+"80"))
+}
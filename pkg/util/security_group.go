@@ -0,0 +1,14 @@
+package util
+
+import (
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+// GetSgRulePortRanges returns the port ranges of a tcp/udp security group rule,
+// portRangeMin and portRangeMax are used if portRanges is not specified
+func GetSgRulePortRanges(rule *kubeovnv1.SgRule) []kubeovnv1.SgPortRange {
+	if len(rule.PortRanges) != 0 {
+		return rule.PortRanges
+	}
+	return []kubeovnv1.SgPortRange{{PortRangeMin: rule.PortRangeMin, PortRangeMax: rule.PortRangeMax}}
+}
@@ -0,0 +1,17 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+func TestGetSgRulePortRanges(t *testing.T) {
+	rule := &kubeovnv1.SgRule{PortRangeMin: 80, PortRangeMax: 90}
+	require.Equal(t, []kubeovnv1.SgPortRange{{PortRangeMin: 80, PortRangeMax: 90}}, GetSgRulePortRanges(rule))
+
+	rule.PortRanges = []kubeovnv1.SgPortRange{{PortRangeMin: 53, PortRangeMax: 53}, {PortRangeMin: 443, PortRangeMax: 443}}
+	require.Equal(t, rule.PortRanges, GetSgRulePortRanges(rule))
+}
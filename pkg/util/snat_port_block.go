@@ -0,0 +1,88 @@
+package util
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+)
+
+const (
+	// SnatPortBlockMinPort is the first port allocated to the port blocks, well known ports are not used
+	SnatPortBlockMinPort = 1024
+	SnatPortBlockMaxPort = 65535
+	// SnatPortBlockMaxBlocks is the max number of port blocks of a snat, which keeps the allocation table
+	// recorded in a config map far below the size limit of 1MiB
+	SnatPortBlockMaxBlocks = 16384
+)
+
+// SnatPortBlock is the port range of the eip allocated to an internal ip in port block mode
+type SnatPortBlock struct {
+	InternalIP string
+	PortStart  int
+	PortEnd    int
+}
+
+// PortRange returns the port range in the format of start-end
+func (b SnatPortBlock) PortRange() string {
+	return fmt.Sprintf("%d-%d", b.PortStart, b.PortEnd)
+}
+
+func parseSnatPortBlockCIDR(cidr string, blockSize int) (*net.IPNet, int, error) {
+	if blockSize <= 0 {
+		return nil, 0, fmt.Errorf("invalid port block size %d", blockSize)
+	}
+	if !strings.Contains(cidr, "/") {
+		cidr += "/32"
+	}
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse cidr %s: %v", cidr, err)
+	}
+	if ipNet.IP.To4() == nil {
+		return nil, 0, fmt.Errorf("port block allocation only supports ipv4 cidr, but got %s", cidr)
+	}
+
+	ones, bits := ipNet.Mask.Size()
+	count := 1 << (bits - ones)
+	if count > SnatPortBlockMaxBlocks {
+		return nil, 0, fmt.Errorf("cidr %s has %d addresses, but at most %d port blocks are allowed", cidr, count, SnatPortBlockMaxBlocks)
+	}
+	if available := (SnatPortBlockMaxPort - SnatPortBlockMinPort + 1) / blockSize; count > available {
+		return nil, 0, fmt.Errorf("cidr %s has %d addresses, but only %d port blocks of size %d are available", cidr, count, available, blockSize)
+	}
+	return ipNet, count, nil
+}
+
+// SnatPortBlockPorts returns the number of eip ports used by the port blocks of the ipv4 cidr
+func SnatPortBlockPorts(cidr string, blockSize int) (int, error) {
+	_, count, err := parseSnatPortBlockCIDR(cidr, blockSize)
+	if err != nil {
+		return 0, err
+	}
+	return count * blockSize, nil
+}
+
+// AllocateSnatPortBlocks allocates a port block of blockSize ports to each address of the ipv4 cidr from
+// the eip ports starting at portStart, which are shared with the other snats on the eip. The allocation is
+// deterministic, the n-th address of the cidr always gets the n-th port block, so that the mapping from
+// public ip and port to internal ip can be recovered at any time.
+func AllocateSnatPortBlocks(cidr string, blockSize, portStart int) ([]SnatPortBlock, error) {
+	ipNet, count, err := parseSnatPortBlockCIDR(cidr, blockSize)
+	if err != nil {
+		return nil, err
+	}
+	if portStart < SnatPortBlockMinPort || portStart+count*blockSize-1 > SnatPortBlockMaxPort {
+		return nil, fmt.Errorf("%d port blocks of size %d starting at port %d exceed the port range %d-%d",
+			count, blockSize, portStart, SnatPortBlockMinPort, SnatPortBlockMaxPort)
+	}
+
+	blocks := make([]SnatPortBlock, 0, count)
+	base := big.NewInt(0).SetBytes(ipNet.IP.To4())
+	for i := 0; i < count; i++ {
+		ip := BigInt2Ip(big.NewInt(0).Add(base, big.NewInt(int64(i))))
+		start := portStart + i*blockSize
+		blocks = append(blocks, SnatPortBlock{InternalIP: ip, PortStart: start, PortEnd: start + blockSize - 1})
+	}
+	return blocks, nil
+}
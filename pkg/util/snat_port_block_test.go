@@ -0,0 +1,64 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocateSnatPortBlocks(t *testing.T) {
+	blocks, err := AllocateSnatPortBlocks("10.0.1.0/30", 1000, SnatPortBlockMinPort)
+	require.NoError(t, err)
+	require.Equal(t, []SnatPortBlock{
+		{InternalIP: "10.0.1.0", PortStart: 1024, PortEnd: 2023},
+		{InternalIP: "10.0.1.1", PortStart: 2024, PortEnd: 3023},
+		{InternalIP: "10.0.1.2", PortStart: 3024, PortEnd: 4023},
+		{InternalIP: "10.0.1.3", PortStart: 4024, PortEnd: 5023},
+	}, blocks)
+	require.Equal(t, "2024-3023", blocks[1].PortRange())
+
+	blocks, err = AllocateSnatPortBlocks("10.0.1.5", 64512, SnatPortBlockMinPort)
+	require.NoError(t, err)
+	require.Equal(t, []SnatPortBlock{{InternalIP: "10.0.1.5", PortStart: 1024, PortEnd: 65535}}, blocks)
+
+	// 256 addresses with 252 ports each use all ports
+	blocks, err = AllocateSnatPortBlocks("10.0.1.0/24", 252, SnatPortBlockMinPort)
+	require.NoError(t, err)
+	require.Len(t, blocks, 256)
+	require.Equal(t, SnatPortBlock{InternalIP: "10.0.1.255", PortStart: 65284, PortEnd: 65535}, blocks[255])
+
+	_, err = AllocateSnatPortBlocks("10.0.1.0/24", 253, SnatPortBlockMinPort)
+	require.Error(t, err)
+	_, err = AllocateSnatPortBlocks("10.0.1.0/24", 0, SnatPortBlockMinPort)
+	require.Error(t, err)
+	_, err = AllocateSnatPortBlocks("fd00::/120", 100, SnatPortBlockMinPort)
+	require.Error(t, err)
+	_, err = AllocateSnatPortBlocks("10.0.1.0/33", 100, SnatPortBlockMinPort)
+	require.Error(t, err)
+
+	// the port blocks start at the given port of the eip
+	blocks, err = AllocateSnatPortBlocks("10.0.1.0/31", 100, 2000)
+	require.NoError(t, err)
+	require.Equal(t, []SnatPortBlock{
+		{InternalIP: "10.0.1.0", PortStart: 2000, PortEnd: 2099},
+		{InternalIP: "10.0.1.1", PortStart: 2100, PortEnd: 2199},
+	}, blocks)
+	_, err = AllocateSnatPortBlocks("10.0.1.0/31", 100, 65400)
+	require.Error(t, err)
+	_, err = AllocateSnatPortBlocks("10.0.1.0/31", 100, 80)
+	require.Error(t, err)
+	// the allocation table is too large
+	_, err = AllocateSnatPortBlocks("10.0.0.0/17", 1, SnatPortBlockMinPort)
+	require.Error(t, err)
+}
+
+func TestSnatPortBlockPorts(t *testing.T) {
+	ports, err := SnatPortBlockPorts("10.0.1.0/30", 1000)
+	require.NoError(t, err)
+	require.Equal(t, 4000, ports)
+	ports, err = SnatPortBlockPorts("10.0.1.5", 100)
+	require.NoError(t, err)
+	require.Equal(t, 100, ports)
+	_, err = SnatPortBlockPorts("10.0.1.0/24", 253)
+	require.Error(t, err)
+}
@@ -1,13 +1,17 @@
 package util
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	anpv1alpha1 "sigs.k8s.io/network-policy-api/apis/v1alpha1"
 
 	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
 )
@@ -52,6 +56,9 @@ func ValidateSubnet(subnet kubeovnv1.Subnet) error {
 			return fmt.Errorf("subnet %s cidr %s is invalid", subnet.Name, cidr)
 		}
 	}
+	if err := validateSecondaryCIDRBlocks(subnet); err != nil {
+		return err
+	}
 
 	allow := subnet.Spec.AllowSubnets
 	for _, cidr := range allow {
@@ -65,6 +72,15 @@ func ValidateSubnet(subnet kubeovnv1.Subnet) error {
 		return fmt.Errorf("%s is not a valid gateway type", gwType)
 	}
 
+	switch subnet.Spec.IPAllocationStrategy {
+	case "", kubeovnv1.IPAllocationStrategySequential, kubeovnv1.IPAllocationStrategyRandom, kubeovnv1.IPAllocationStrategyLeastRecentlyReleased:
+	default:
+		return fmt.Errorf("%s is not a valid ip allocation strategy", subnet.Spec.IPAllocationStrategy)
+	}
+	if subnet.Spec.IPReleaseCooldown < 0 {
+		return fmt.Errorf("ipReleaseCooldown %d must not be negative", subnet.Spec.IPReleaseCooldown)
+	}
+
 	protocol := subnet.Spec.Protocol
 	if protocol != "" && protocol != kubeovnv1.ProtocolIPv4 &&
 		protocol != kubeovnv1.ProtocolIPv6 &&
@@ -127,6 +143,30 @@ func ValidateSubnet(subnet kubeovnv1.Subnet) error {
 	return nil
 }
 
+func validateSecondaryCIDRBlocks(subnet kubeovnv1.Subnet) error {
+	protocol := CheckProtocol(subnet.Spec.CIDRBlock)
+	for i, cidr := range subnet.Spec.SecondaryCIDRBlocks {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("subnet %s secondary cidr %s is invalid", subnet.Name, cidr)
+		}
+		if protocol != kubeovnv1.ProtocolDual && CheckProtocol(cidr) != protocol {
+			return fmt.Errorf("secondary cidr %s does not match the protocol of cidr %s", cidr, subnet.Spec.CIDRBlock)
+		}
+		if err := CIDRGlobalUnicast(cidr); err != nil {
+			return err
+		}
+		if CIDROverlap(cidr, subnet.Spec.CIDRBlock) {
+			return fmt.Errorf("secondary cidr %s is conflict with cidr %s", cidr, subnet.Spec.CIDRBlock)
+		}
+		for _, c := range subnet.Spec.SecondaryCIDRBlocks[:i] {
+			if CIDROverlap(cidr, c) {
+				return fmt.Errorf("secondary cidr %s is conflict with secondary cidr %s", cidr, c)
+			}
+		}
+	}
+	return nil
+}
+
 func validateNatOutgoingPolicyRules(subnet kubeovnv1.Subnet) error {
 	for _, rule := range subnet.Spec.NatOutgoingPolicyRules {
 		var srcProtocol, dstProtocol string
@@ -299,8 +339,9 @@ func ValidateCidrConflict(subnet kubeovnv1.Subnet, subnetList []kubeovnv1.Subnet
 			continue
 		}
 
-		if CIDROverlap(sub.Spec.CIDRBlock, subnet.Spec.CIDRBlock) {
-			err := fmt.Errorf("subnet %s cidr %s is conflict with subnet %s cidr %s", subnet.Name, subnet.Spec.CIDRBlock, sub.Name, sub.Spec.CIDRBlock)
+		cidrBlocks, subCIDRBlocks := GetSubnetCIDRBlocks(&subnet), GetSubnetCIDRBlocks(&sub)
+		if CIDROverlap(subCIDRBlocks, cidrBlocks) {
+			err := fmt.Errorf("subnet %s cidr %s is conflict with subnet %s cidr %s", subnet.Name, cidrBlocks, sub.Name, subCIDRBlocks)
 			return err
 		}
 
@@ -355,5 +396,143 @@ func ValidateVpc(vpc *kubeovnv1.Vpc) error {
 		}
 	}
 
+	switch vpc.Spec.NatBackend {
+	case "", kubeovnv1.NatBackendIptables, kubeovnv1.NatBackendOvn:
+	default:
+		return fmt.Errorf("unsupported nat backend %q, must be %s or %s", vpc.Spec.NatBackend, kubeovnv1.NatBackendIptables, kubeovnv1.NatBackendOvn)
+	}
+
+	return nil
+}
+
+func ValidateBgpPeer(peer *kubeovnv1.BgpPeer) error {
+	spec := peer.Spec
+	if net.ParseIP(spec.NeighborAddress) == nil {
+		return fmt.Errorf("invalid neighbor address %q", spec.NeighborAddress)
+	}
+	if spec.NeighborAs == 0 {
+		return errors.New("neighbor AS number must be specified")
+	}
+	if ht := spec.HoldTime.Seconds(); ht != 0 && (ht < 3 || ht > 65535) {
+		return fmt.Errorf("hold time %s is not in the range 3s to 65535s", spec.HoldTime.Duration)
+	}
+	if spec.KeepaliveInterval.Duration < 0 || spec.ConnectRetry.Duration < 0 {
+		return errors.New("keepalive interval and connect retry must not be negative")
+	}
+	if ref := spec.AuthPasswordSecretRef; ref != nil && (ref.Namespace == "" || ref.Name == "" || ref.Key == "") {
+		return errors.New("namespace, name and key of the auth password secret must be specified")
+	}
+	for _, af := range spec.AddressFamilies {
+		if af != kubeovnv1.ProtocolIPv4 && af != kubeovnv1.ProtocolIPv6 {
+			return fmt.Errorf("unsupported address family %q, must be %s or %s", af, kubeovnv1.ProtocolIPv4, kubeovnv1.ProtocolIPv6)
+		}
+	}
+
+	for i, policy := range []*kubeovnv1.BgpPolicy{spec.ImportPolicy, spec.ExportPolicy} {
+		direction := [...]string{"import", "export"}[i]
+		if policy == nil {
+			continue
+		}
+		if policy.DefaultAction != "" && policy.DefaultAction != kubeovnv1.BgpPolicyActionAccept && policy.DefaultAction != kubeovnv1.BgpPolicyActionReject {
+			return fmt.Errorf("invalid default action %q of %s policy", policy.DefaultAction, direction)
+		}
+		for _, rule := range policy.Rules {
+			if rule.Action != kubeovnv1.BgpPolicyActionAccept && rule.Action != kubeovnv1.BgpPolicyActionReject {
+				return fmt.Errorf("invalid action %q of %s policy rule %s", rule.Action, direction, rule.Prefix)
+			}
+			_, cidr, err := net.ParseCIDR(rule.Prefix)
+			if err != nil {
+				return fmt.Errorf("invalid prefix %q of %s policy rule: %w", rule.Prefix, direction, err)
+			}
+			ones, bits := cidr.Mask.Size()
+			minLen, maxLen := uint32(ones), uint32(ones)
+			if rule.MaskLengthMin != 0 {
+				minLen = rule.MaskLengthMin
+			}
+			if rule.MaskLengthMax != 0 {
+				maxLen = rule.MaskLengthMax
+			}
+			if minLen < uint32(ones) || minLen > maxLen || maxLen > uint32(bits) {
+				return fmt.Errorf("invalid mask length range %d..%d of %s policy rule %s", minLen, maxLen, direction, rule.Prefix)
+			}
+		}
+	}
+
+	if ri := spec.RouteImport; ri != nil {
+		for _, prefix := range ri.Prefixes {
+			if _, _, err := net.ParseCIDR(prefix); err != nil {
+				return fmt.Errorf("invalid route import prefix %q: %w", prefix, err)
+			}
+		}
+		if ri.Table < 0 {
+			return fmt.Errorf("invalid route import table %d", ri.Table)
+		}
+	}
+
+	if bfd := spec.Bfd; bfd != nil {
+		for i, interval := range []time.Duration{bfd.MinTxInterval.Duration, bfd.MinRxInterval.Duration} {
+			if interval != 0 && (interval < 10*time.Millisecond || interval > time.Minute) {
+				return fmt.Errorf("bfd %s interval %s is not in the range 10ms to 1m", [...]string{"min tx", "min rx"}[i], interval)
+			}
+		}
+	}
+	return nil
+}
+
+func ValidateAdminNetworkPolicy(anp *anpv1alpha1.AdminNetworkPolicy) error {
+	if anp.Spec.Priority < 0 || anp.Spec.Priority > AnpMaxPriority {
+		return fmt.Errorf("priority %d is not in the range 0 to %d", anp.Spec.Priority, AnpMaxPriority)
+	}
+	if len(anp.Spec.Ingress) > AnpMaxRules || len(anp.Spec.Egress) > AnpMaxRules {
+		return fmt.Errorf("at most %d ingress rules and %d egress rules are supported", AnpMaxRules, AnpMaxRules)
+	}
+
+	var peers []anpv1alpha1.AdminNetworkPolicyPeer
+	for _, rule := range anp.Spec.Ingress {
+		peers = append(peers, rule.From...)
+	}
+	for _, rule := range anp.Spec.Egress {
+		peers = append(peers, rule.To...)
+	}
+	return validateAdminNetworkPolicyPeers(peers)
+}
+
+func ValidateBaselineAdminNetworkPolicy(banp *anpv1alpha1.BaselineAdminNetworkPolicy) error {
+	if len(banp.Spec.Ingress) > BanpMaxRules || len(banp.Spec.Egress) > BanpMaxRules {
+		return fmt.Errorf("at most %d ingress rules and %d egress rules are supported", BanpMaxRules, BanpMaxRules)
+	}
+
+	var peers []anpv1alpha1.AdminNetworkPolicyPeer
+	for _, rule := range banp.Spec.Ingress {
+		peers = append(peers, rule.From...)
+	}
+	for _, rule := range banp.Spec.Egress {
+		peers = append(peers, rule.To...)
+	}
+	return validateAdminNetworkPolicyPeers(peers)
+}
+
+func validateAdminNetworkPolicyPeers(peers []anpv1alpha1.AdminNetworkPolicyPeer) error {
+	for _, peer := range peers {
+		nsPeer := peer.Namespaces
+		if peer.Pods != nil {
+			nsPeer = &peer.Pods.Namespaces
+		}
+		if nsPeer == nil {
+			return errors.New("either namespaces or pods of a peer must be specified")
+		}
+		if len(nsPeer.SameLabels) != 0 || len(nsPeer.NotSameLabels) != 0 {
+			return errors.New("sameLabels and notSameLabels of peers are not supported")
+		}
+	}
+	return nil
+}
+
+// ValidateFqdn validates a domain name, a leading "*." matches all the subdomains
+func ValidateFqdn(fqdn string) error {
+	name, _ := strings.CutPrefix(NormalizeFqdn(fqdn), "*.")
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) != 0 {
+		return fmt.Errorf("invalid fqdn %q: %s", fqdn, strings.Join(errs, ", "))
+	}
 	return nil
 }
@@ -3,8 +3,10 @@ package util
 import (
 	"os"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	anpv1alpha1 "sigs.k8s.io/network-policy-api/apis/v1alpha1"
 
 	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
 )
@@ -476,6 +478,75 @@ func TestValidateSubnet(t *testing.T) {
 			},
 			err: "ip 10.16.1 in exclude_ips is not a valid address",
 		},
+		{
+			name: "IPAllocationStrategyErr",
+			asubnet: kubeovnv1.Subnet{
+				TypeMeta: metav1.TypeMeta{Kind: "Subnet", APIVersion: "kubeovn.io/v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "utest",
+				},
+				Spec: kubeovnv1.SubnetSpec{
+					Default:              true,
+					Vpc:                  "ovn-cluster",
+					Protocol:             "IPv4",
+					Namespaces:           nil,
+					CIDRBlock:            "10.16.0.0/16",
+					Gateway:              "10.16.0.1",
+					ExcludeIps:           []string{"10.16.0.1"},
+					Provider:             "ovn",
+					GatewayType:          "distributed",
+					IPAllocationStrategy: "lowest",
+				},
+				Status: kubeovnv1.SubnetStatus{},
+			},
+			err: "lowest is not a valid ip allocation strategy",
+		},
+		{
+			name: "SecondaryCIDRConflictErr",
+			asubnet: kubeovnv1.Subnet{
+				TypeMeta: metav1.TypeMeta{Kind: "Subnet", APIVersion: "kubeovn.io/v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "utest",
+				},
+				Spec: kubeovnv1.SubnetSpec{
+					Default:             true,
+					Vpc:                 "ovn-cluster",
+					Protocol:            "IPv4",
+					Namespaces:          nil,
+					CIDRBlock:           "10.16.0.0/16",
+					Gateway:             "10.16.0.1",
+					ExcludeIps:          []string{"10.16.0.1"},
+					Provider:            "ovn",
+					GatewayType:         "distributed",
+					SecondaryCIDRBlocks: []string{"10.16.1.0/24"},
+				},
+				Status: kubeovnv1.SubnetStatus{},
+			},
+			err: "secondary cidr 10.16.1.0/24 is conflict with cidr 10.16.0.0/16",
+		},
+		{
+			name: "SecondaryCIDRProtocolErr",
+			asubnet: kubeovnv1.Subnet{
+				TypeMeta: metav1.TypeMeta{Kind: "Subnet", APIVersion: "kubeovn.io/v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "utest",
+				},
+				Spec: kubeovnv1.SubnetSpec{
+					Default:             true,
+					Vpc:                 "ovn-cluster",
+					Protocol:            "IPv4",
+					Namespaces:          nil,
+					CIDRBlock:           "10.16.0.0/16",
+					Gateway:             "10.16.0.1",
+					ExcludeIps:          []string{"10.16.0.1"},
+					Provider:            "ovn",
+					GatewayType:         "distributed",
+					SecondaryCIDRBlocks: []string{"fd00::/120"},
+				},
+				Status: kubeovnv1.SubnetStatus{},
+			},
+			err: "secondary cidr fd00::/120 does not match the protocol of cidr 10.16.0.0/16",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -817,3 +888,233 @@ func TestValidateCidrConflict(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateBgpPeer(t *testing.T) {
+	tests := []struct {
+		name string
+		spec kubeovnv1.BgpPeerSpec
+		err  string
+	}{
+		{
+			name: "correct",
+			spec: kubeovnv1.BgpPeerSpec{
+				NeighborAddress: "192.168.1.1",
+				NeighborAs:      65001,
+				HoldTime:        metav1.Duration{Duration: 9 * time.Second},
+				AddressFamilies: []string{kubeovnv1.ProtocolIPv4, kubeovnv1.ProtocolIPv6},
+				ExportPolicy: &kubeovnv1.BgpPolicy{
+					Rules:         []kubeovnv1.BgpPolicyRule{{Prefix: "10.16.0.0/16", MaskLengthMax: 32, Action: kubeovnv1.BgpPolicyActionAccept}},
+					DefaultAction: kubeovnv1.BgpPolicyActionReject,
+				},
+			},
+			err: "",
+		},
+		{
+			name: "neighborAddressErr",
+			spec: kubeovnv1.BgpPeerSpec{NeighborAddress: "192.168.1", NeighborAs: 65001},
+			err:  `invalid neighbor address "192.168.1"`,
+		},
+		{
+			name: "neighborAsErr",
+			spec: kubeovnv1.BgpPeerSpec{NeighborAddress: "fd00::1"},
+			err:  "neighbor AS number must be specified",
+		},
+		{
+			name: "holdTimeErr",
+			spec: kubeovnv1.BgpPeerSpec{NeighborAddress: "192.168.1.1", NeighborAs: 65001, HoldTime: metav1.Duration{Duration: time.Second}},
+			err:  "hold time 1s is not in the range 3s to 65535s",
+		},
+		{
+			name: "authPasswordSecretRefErr",
+			spec: kubeovnv1.BgpPeerSpec{NeighborAddress: "192.168.1.1", NeighborAs: 65001, AuthPasswordSecretRef: &kubeovnv1.BgpSecretKeyRef{Namespace: "kube-system", Name: "bgp-auth"}},
+			err:  "namespace, name and key of the auth password secret must be specified",
+		},
+		{
+			name: "addressFamilyErr",
+			spec: kubeovnv1.BgpPeerSpec{NeighborAddress: "192.168.1.1", NeighborAs: 65001, AddressFamilies: []string{"ipv4-unicast"}},
+			err:  `unsupported address family "ipv4-unicast"`,
+		},
+		{
+			name: "policyActionErr",
+			spec: kubeovnv1.BgpPeerSpec{
+				NeighborAddress: "192.168.1.1",
+				NeighborAs:      65001,
+				ImportPolicy:    &kubeovnv1.BgpPolicy{Rules: []kubeovnv1.BgpPolicyRule{{Prefix: "0.0.0.0/0", Action: "deny"}}},
+			},
+			err: `invalid action "deny" of import policy rule 0.0.0.0/0`,
+		},
+		{
+			name: "maskLengthErr",
+			spec: kubeovnv1.BgpPeerSpec{
+				NeighborAddress: "192.168.1.1",
+				NeighborAs:      65001,
+				ExportPolicy:    &kubeovnv1.BgpPolicy{Rules: []kubeovnv1.BgpPolicyRule{{Prefix: "10.16.0.0/16", MaskLengthMin: 8, Action: kubeovnv1.BgpPolicyActionReject}}},
+			},
+			err: "invalid mask length range 8..16 of export policy rule 10.16.0.0/16",
+		},
+		{
+			name: "routeImportPrefixErr",
+			spec: kubeovnv1.BgpPeerSpec{
+				NeighborAddress: "192.168.1.1",
+				NeighborAs:      65001,
+				RouteImport:     &kubeovnv1.BgpRouteImport{Prefixes: []string{"10.0.0.0/33"}, Vpc: "vpc1"},
+			},
+			err: `invalid route import prefix "10.0.0.0/33"`,
+		},
+		{
+			name: "bfdIntervalErr",
+			spec: kubeovnv1.BgpPeerSpec{
+				NeighborAddress: "192.168.1.1",
+				NeighborAs:      65001,
+				Bfd:             &kubeovnv1.BgpBfd{MinTxInterval: metav1.Duration{Duration: 100 * time.Millisecond}, MinRxInterval: metav1.Duration{Duration: time.Millisecond}},
+			},
+			err: "bfd min rx interval 1ms is not in the range 10ms to 1m",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ret := ValidateBgpPeer(&kubeovnv1.BgpPeer{Spec: tt.spec})
+			if !ErrorContains(ret, tt.err) {
+				t.Errorf("got %v, want a error %v", ret, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateAdminNetworkPolicy(t *testing.T) {
+	peer := anpv1alpha1.AdminNetworkPolicyPeer{
+		Namespaces: &anpv1alpha1.NamespacedPeer{NamespaceSelector: &metav1.LabelSelector{}},
+	}
+	tests := []struct {
+		name string
+		spec anpv1alpha1.AdminNetworkPolicySpec
+		err  string
+	}{
+		{
+			name: "correct",
+			spec: anpv1alpha1.AdminNetworkPolicySpec{
+				Priority: 10,
+				Ingress:  []anpv1alpha1.AdminNetworkPolicyIngressRule{{Action: anpv1alpha1.AdminNetworkPolicyRuleActionPass, From: []anpv1alpha1.AdminNetworkPolicyPeer{peer}}},
+				Egress:   []anpv1alpha1.AdminNetworkPolicyEgressRule{{Action: anpv1alpha1.AdminNetworkPolicyRuleActionDeny, To: []anpv1alpha1.AdminNetworkPolicyPeer{peer}}},
+			},
+			err: "",
+		},
+		{
+			name: "priorityErr",
+			spec: anpv1alpha1.AdminNetworkPolicySpec{Priority: 100},
+			err:  "priority 100 is not in the range 0 to 99",
+		},
+		{
+			name: "rulesErr",
+			spec: anpv1alpha1.AdminNetworkPolicySpec{Ingress: make([]anpv1alpha1.AdminNetworkPolicyIngressRule, 101)},
+			err:  "at most 100 ingress rules and 100 egress rules are supported",
+		},
+		{
+			name: "emptyPeerErr",
+			spec: anpv1alpha1.AdminNetworkPolicySpec{
+				Egress: []anpv1alpha1.AdminNetworkPolicyEgressRule{{To: []anpv1alpha1.AdminNetworkPolicyPeer{{}}}},
+			},
+			err: "either namespaces or pods of a peer must be specified",
+		},
+		{
+			name: "sameLabelsErr",
+			spec: anpv1alpha1.AdminNetworkPolicySpec{
+				Ingress: []anpv1alpha1.AdminNetworkPolicyIngressRule{{From: []anpv1alpha1.AdminNetworkPolicyPeer{{
+					Pods: &anpv1alpha1.NamespacedPodPeer{Namespaces: anpv1alpha1.NamespacedPeer{SameLabels: []string{"tenant"}}},
+				}}}},
+			},
+			err: "sameLabels and notSameLabels of peers are not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ret := ValidateAdminNetworkPolicy(&anpv1alpha1.AdminNetworkPolicy{Spec: tt.spec})
+			if !ErrorContains(ret, tt.err) {
+				t.Errorf("got %v, want a error %v", ret, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateBaselineAdminNetworkPolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		spec anpv1alpha1.BaselineAdminNetworkPolicySpec
+		err  string
+	}{
+		{
+			name: "correct",
+			spec: anpv1alpha1.BaselineAdminNetworkPolicySpec{
+				Ingress: []anpv1alpha1.BaselineAdminNetworkPolicyIngressRule{{
+					Action: anpv1alpha1.BaselineAdminNetworkPolicyRuleActionDeny,
+					From:   []anpv1alpha1.AdminNetworkPolicyPeer{{Namespaces: &anpv1alpha1.NamespacedPeer{NamespaceSelector: &metav1.LabelSelector{}}}},
+				}},
+			},
+			err: "",
+		},
+		{
+			name: "rulesErr",
+			spec: anpv1alpha1.BaselineAdminNetworkPolicySpec{Egress: make([]anpv1alpha1.BaselineAdminNetworkPolicyEgressRule, 100)},
+			err:  "at most 99 ingress rules and 99 egress rules are supported",
+		},
+		{
+			name: "notSameLabelsErr",
+			spec: anpv1alpha1.BaselineAdminNetworkPolicySpec{
+				Egress: []anpv1alpha1.BaselineAdminNetworkPolicyEgressRule{{To: []anpv1alpha1.AdminNetworkPolicyPeer{{
+					Namespaces: &anpv1alpha1.NamespacedPeer{NotSameLabels: []string{"tenant"}},
+				}}}},
+			},
+			err: "sameLabels and notSameLabels of peers are not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ret := ValidateBaselineAdminNetworkPolicy(&anpv1alpha1.BaselineAdminNetworkPolicy{Spec: tt.spec})
+			if !ErrorContains(ret, tt.err) {
+				t.Errorf("got %v, want a error %v", ret, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateFqdn(t *testing.T) {
+	tests := []struct {
+		name string
+		fqdn string
+		err  string
+	}{
+		{
+			name: "correct",
+			fqdn: "api.example.com.",
+			err:  "",
+		},
+		{
+			name: "wildcard",
+			fqdn: "*.Example.com",
+			err:  "",
+		},
+		{
+			name: "wildcardOnlyErr",
+			fqdn: "*",
+			err:  `invalid fqdn "*"`,
+		},
+		{
+			name: "innerWildcardErr",
+			fqdn: "api.*.example.com",
+			err:  `invalid fqdn "api.*.example.com"`,
+		},
+		{
+			name: "emptyErr",
+			fqdn: "",
+			err:  `invalid fqdn ""`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ret := ValidateFqdn(tt.fqdn)
+			if !ErrorContains(ret, tt.err) {
+				t.Errorf("got %v, want a error %v", ret, tt.err)
+			}
+		})
+	}
+}
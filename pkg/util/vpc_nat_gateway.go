@@ -9,3 +9,12 @@ func GenNatGwStsName(name string) string {
 func GenNatGwPodName(name string) string {
 	return fmt.Sprintf("vpc-nat-gw-%s-0", name)
 }
+
+// GenNatGwHAVipName returns the name of the vip holding the lan ip of the nat gateway in active/standby mode
+func GenNatGwHAVipName(name string) string {
+	return fmt.Sprintf("vpc-nat-gw-%s-lan", name)
+}
+
+func GenVpcEgressGatewayStsName(name string) string {
+	return fmt.Sprintf("vpc-egress-gw-%s", name)
+}
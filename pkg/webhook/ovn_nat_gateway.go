@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net"
 	"net/http"
-	"strconv"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -288,20 +287,9 @@ func (v *ValidatingHook) ValidateOvnDnat(ctx context.Context, dnat *ovnv1.OvnDna
 		return err
 	}
 
-	if port, err := strconv.Atoi(dnat.Spec.ExternalPort); err != nil {
-		errMsg := fmt.Errorf("failed to parse spec externalPort %s: %v", dnat.Spec.ExternalPort, err)
-		return errMsg
-	} else if port < 0 || port > 65535 {
-		err := fmt.Errorf("spec externalPort %s is not a valid port", dnat.Spec.ExternalPort)
-		return err
-	}
-
-	if port, err := strconv.Atoi(dnat.Spec.InternalPort); err != nil {
-		errMsg := fmt.Errorf("failed to parse spec internalIP %s: %v", dnat.Spec.InternalPort, err)
-		return errMsg
-	} else if port < 0 || port > 65535 {
-		err := fmt.Errorf("spec internalIP %s is not a valid port", dnat.Spec.InternalPort)
-		return err
+	mappings, err := util.ParsePortMappings(dnat.Spec.ExternalPort, dnat.Spec.InternalPort)
+	if err != nil {
+		return fmt.Errorf("invalid spec ports: %v", err)
 	}
 
 	if !strings.EqualFold(dnat.Spec.Protocol, "tcp") &&
@@ -310,6 +298,23 @@ func (v *ValidatingHook) ValidateOvnDnat(ctx context.Context, dnat *ovnv1.OvnDna
 		return err
 	}
 
+	dnatList := ovnv1.OvnDnatRuleList{}
+	if err := v.cache.List(ctx, &dnatList); err != nil {
+		return err
+	}
+	for _, d := range dnatList.Items {
+		if d.Name == dnat.Name || d.Spec.OvnEip != dnat.Spec.OvnEip || util.DnatProtocol(d.Spec.Protocol) != util.DnatProtocol(dnat.Spec.Protocol) {
+			continue
+		}
+		others, err := util.ParsePortMappings(d.Spec.ExternalPort, d.Spec.InternalPort)
+		if err != nil {
+			continue
+		}
+		if util.PortMappingsOverlap(mappings, others) {
+			return fmt.Errorf("spec externalPort %s overlaps with externalPort %s of dnat %s on eip %s", dnat.Spec.ExternalPort, d.Spec.ExternalPort, d.Name, dnat.Spec.OvnEip)
+		}
+	}
+
 	return nil
 }
 
@@ -339,6 +344,33 @@ func (v *ValidatingHook) ValidateOvnSnat(ctx context.Context, snat *ovnv1.OvnSna
 		return err
 	}
 
+	if snat.Spec.PortBlockSize != 0 {
+		// the internal ip of ipName always has a port block
+		cidr := snat.Spec.V4IpCidr
+		if cidr == "" && snat.Spec.VpcSubnet != "" {
+			subnet := &ovnv1.Subnet{}
+			if err := v.cache.Get(ctx, types.NamespacedName{Name: snat.Spec.VpcSubnet}, subnet); err != nil {
+				return err
+			}
+			cidr = subnet.Spec.CIDRBlock
+		}
+		if cidr != "" {
+			if _, err := util.SnatPortBlockPorts(cidr, int(snat.Spec.PortBlockSize)); err != nil {
+				return fmt.Errorf("invalid port block size %d: %v", snat.Spec.PortBlockSize, err)
+			}
+		}
+	}
+
+	snatList := ovnv1.OvnSnatRuleList{}
+	if err := v.cache.List(ctx, &snatList); err != nil {
+		return err
+	}
+	for _, s := range snatList.Items {
+		if s.Name != snat.Name && s.Spec.OvnEip == snat.Spec.OvnEip && (s.Spec.PortBlockSize == 0) != (snat.Spec.PortBlockSize == 0) {
+			return fmt.Errorf("snat %s on eip %s must be both in or both not in port block mode with snat %s", snat.Name, snat.Spec.OvnEip, s.Name)
+		}
+	}
+
 	eip := &ovnv1.OvnEip{}
 	key := types.NamespacedName{Name: snat.Spec.OvnEip}
 	return v.cache.Get(ctx, key, eip)
@@ -3,7 +3,9 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
 
 	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -67,6 +69,11 @@ func (v *ValidatingHook) SubnetUpdateHook(ctx context.Context, req admission.Req
 		err := fmt.Errorf("can't update gateway of cidr when any IPs in Using")
 		return ctrlwebhook.Denied(err.Error())
 	}
+	if o.Status.V4UsingIPs != 0 || o.Status.V6UsingIPs != 0 {
+		if err := checkSubnetCIDRUpdate(oldSubnet, o); err != nil {
+			return ctrlwebhook.Denied(err.Error())
+		}
+	}
 
 	if err := util.ValidateSubnet(o); err != nil {
 		return ctrlwebhook.Denied(err.Error())
@@ -94,3 +101,40 @@ func (v *ValidatingHook) SubnetDeleteHook(_ context.Context, req admission.Reque
 	}
 	return ctrlwebhook.Allowed("by pass")
 }
+
+// checkSubnetCIDRUpdate checks that cidr blocks in use are only expanded or kept unchanged
+func checkSubnetCIDRUpdate(oldSubnet, newSubnet ovnv1.Subnet) error {
+	newCIDRBlocks := strings.Split(newSubnet.Spec.CIDRBlock, ",")
+	for _, cidr := range strings.Split(oldSubnet.Spec.CIDRBlock, ",") {
+		if !cidrsContain(newCIDRBlocks, cidr) {
+			return fmt.Errorf("can't update cidr %s to %s when any IPs in Using, only expanding the prefix is allowed", oldSubnet.Spec.CIDRBlock, newSubnet.Spec.CIDRBlock)
+		}
+	}
+
+	newCIDRBlocks = append(newCIDRBlocks, newSubnet.Spec.SecondaryCIDRBlocks...)
+	for _, status := range oldSubnet.Status.CIDRs {
+		if status.Secondary && status.UsingIPs != 0 && !cidrsContain(newCIDRBlocks, status.CIDR) {
+			return fmt.Errorf("can't remove or shrink secondary cidr %s when any IPs in Using", status.CIDR)
+		}
+	}
+	return nil
+}
+
+// cidrsContain returns whether any of the cidr blocks contains the whole cidr
+func cidrsContain(cidrBlocks []string, cidr string) bool {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	ones, _ := ipNet.Mask.Size()
+	for _, cidrBlock := range cidrBlocks {
+		_, n, err := net.ParseCIDR(cidrBlock)
+		if err != nil {
+			continue
+		}
+		if o, _ := n.Mask.Size(); o <= ones && n.Contains(ipNet.IP) {
+			return true
+		}
+	}
+	return false
+}
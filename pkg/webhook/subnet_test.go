@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+func Test_checkSubnetCIDRUpdate(t *testing.T) {
+	t.Parallel()
+
+	newSubnet := func(cidr string, secondary []string, status ...ovnv1.SubnetCIDRStatus) ovnv1.Subnet {
+		subnet := ovnv1.Subnet{}
+		subnet.Spec.CIDRBlock = cidr
+		subnet.Spec.SecondaryCIDRBlocks = secondary
+		subnet.Status.CIDRs = status
+		return subnet
+	}
+
+	tests := []struct {
+		name      string
+		oldSubnet ovnv1.Subnet
+		newSubnet ovnv1.Subnet
+		wantErr   bool
+	}{
+		{
+			name:      "unchanged",
+			oldSubnet: newSubnet("10.16.0.0/24", nil),
+			newSubnet: newSubnet("10.16.0.0/24", nil),
+		},
+		{
+			name:      "expand prefix",
+			oldSubnet: newSubnet("10.16.0.0/24", nil),
+			newSubnet: newSubnet("10.16.0.0/16", nil),
+		},
+		{
+			name:      "expand dual stack prefixes",
+			oldSubnet: newSubnet("10.16.0.0/24,fd00:10:16::/120", nil),
+			newSubnet: newSubnet("10.16.0.0/16,fd00:10:16::/112", nil),
+		},
+		{
+			name:      "shrink prefix",
+			oldSubnet: newSubnet("10.16.0.0/16", nil),
+			newSubnet: newSubnet("10.16.0.0/24", nil),
+			wantErr:   true,
+		},
+		{
+			name:      "move to another cidr",
+			oldSubnet: newSubnet("10.16.0.0/24", nil),
+			newSubnet: newSubnet("10.17.0.0/24", nil),
+			wantErr:   true,
+		},
+		{
+			name:      "add ipv6 to ipv4",
+			oldSubnet: newSubnet("10.16.0.0/24", nil),
+			newSubnet: newSubnet("10.16.0.0/24,fd00:10:16::/120", nil),
+		},
+		{
+			name:      "change ipv4 to ipv6",
+			oldSubnet: newSubnet("10.16.0.0/24", nil),
+			newSubnet: newSubnet("fd00:10:16::/120", nil),
+			wantErr:   true,
+		},
+		{
+			name:      "change dual stack to ipv4",
+			oldSubnet: newSubnet("10.16.0.0/24,fd00:10:16::/120", nil),
+			newSubnet: newSubnet("10.16.0.0/24", nil),
+			wantErr:   true,
+		},
+		{
+			name:      "remove unused secondary cidr",
+			oldSubnet: newSubnet("10.16.0.0/24", []string{"10.17.0.0/24"}, ovnv1.SubnetCIDRStatus{CIDR: "10.17.0.0/24", Secondary: true}),
+			newSubnet: newSubnet("10.16.0.0/24", nil),
+		},
+		{
+			name:      "remove secondary cidr in use",
+			oldSubnet: newSubnet("10.16.0.0/24", []string{"10.17.0.0/24"}, ovnv1.SubnetCIDRStatus{CIDR: "10.17.0.0/24", Secondary: true, UsingIPs: 1}),
+			newSubnet: newSubnet("10.16.0.0/24", nil),
+			wantErr:   true,
+		},
+		{
+			name:      "shrink secondary cidr in use",
+			oldSubnet: newSubnet("10.16.0.0/24", []string{"10.17.0.0/24"}, ovnv1.SubnetCIDRStatus{CIDR: "10.17.0.0/24", Secondary: true, UsingIPs: 1}),
+			newSubnet: newSubnet("10.16.0.0/24", []string{"10.17.0.0/25"}),
+			wantErr:   true,
+		},
+		{
+			name:      "expand secondary cidr in use",
+			oldSubnet: newSubnet("10.16.0.0/24", []string{"10.17.0.0/24"}, ovnv1.SubnetCIDRStatus{CIDR: "10.17.0.0/24", Secondary: true, UsingIPs: 1}),
+			newSubnet: newSubnet("10.16.0.0/24", []string{"10.17.0.0/16"}),
+		},
+		{
+			name:      "primary cidr expanded to cover secondary cidr in use",
+			oldSubnet: newSubnet("10.16.0.0/24", []string{"10.16.1.0/24"}, ovnv1.SubnetCIDRStatus{CIDR: "10.16.1.0/24", Secondary: true, UsingIPs: 1}),
+			newSubnet: newSubnet("10.16.0.0/16", nil),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := checkSubnetCIDRUpdate(tt.oldSubnet, tt.newSubnet)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/util"
+)
+
+var (
+	vpcEipGVK      = metav1.GroupVersionKind{Group: ovnv1.SchemeGroupVersion.Group, Version: ovnv1.SchemeGroupVersion.Version, Kind: "VpcEip"}
+	vpcFipGVK      = metav1.GroupVersionKind{Group: ovnv1.SchemeGroupVersion.Group, Version: ovnv1.SchemeGroupVersion.Version, Kind: "VpcFip"}
+	vpcDnatRuleGVK = metav1.GroupVersionKind{Group: ovnv1.SchemeGroupVersion.Group, Version: ovnv1.SchemeGroupVersion.Version, Kind: "VpcDnatRule"}
+	vpcSnatRuleGVK = metav1.GroupVersionKind{Group: ovnv1.SchemeGroupVersion.Group, Version: ovnv1.SchemeGroupVersion.Version, Kind: "VpcSnatRule"}
+)
+
+func (v *ValidatingHook) vpcEipCreateHook(ctx context.Context, req admission.Request) admission.Response {
+	eip := ovnv1.VpcEip{}
+	if err := v.decoder.DecodeRaw(req.Object, &eip); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := v.ValidateVpcEip(ctx, &eip); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return ctrlwebhook.Allowed("by pass")
+}
+
+func (v *ValidatingHook) vpcEipUpdateHook(_ context.Context, req admission.Request) admission.Response {
+	eipNew := ovnv1.VpcEip{}
+	if err := v.decoder.DecodeRaw(req.Object, &eipNew); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	eipOld := ovnv1.VpcEip{}
+	if err := v.decoder.DecodeRaw(req.OldObject, &eipOld); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	if eipOld.Spec != eipNew.Spec && eipOld.Status.Backend != "" {
+		// the backend is changed by the nat backend of the vpc rather than the eip
+		err := fmt.Errorf("VpcEip \"%s\" is implemented by the %s backend, not support change", eipNew.Name, eipOld.Status.Backend)
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return ctrlwebhook.Allowed("by pass")
+}
+
+func (v *ValidatingHook) vpcEipDeleteHook(ctx context.Context, req admission.Request) admission.Response {
+	eip := ovnv1.VpcEip{}
+	if err := v.decoder.DecodeRaw(req.OldObject, &eip); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var users []string
+	fipList := ovnv1.VpcFipList{}
+	if err := v.cache.List(ctx, &fipList); err != nil {
+		return ctrlwebhook.Errored(http.StatusInternalServerError, err)
+	}
+	for _, fip := range fipList.Items {
+		if fip.Spec.Eip == eip.Name {
+			users = append(users, "VpcFip "+fip.Name)
+		}
+	}
+	dnatList := ovnv1.VpcDnatRuleList{}
+	if err := v.cache.List(ctx, &dnatList); err != nil {
+		return ctrlwebhook.Errored(http.StatusInternalServerError, err)
+	}
+	for _, dnat := range dnatList.Items {
+		if dnat.Spec.Eip == eip.Name {
+			users = append(users, "VpcDnatRule "+dnat.Name)
+		}
+	}
+	snatList := ovnv1.VpcSnatRuleList{}
+	if err := v.cache.List(ctx, &snatList); err != nil {
+		return ctrlwebhook.Errored(http.StatusInternalServerError, err)
+	}
+	for _, snat := range snatList.Items {
+		if snat.Spec.Eip == eip.Name {
+			users = append(users, "VpcSnatRule "+snat.Name)
+		}
+	}
+	if len(users) != 0 {
+		err := fmt.Errorf("VpcEip \"%s\" is still used by %s", eip.Name, strings.Join(users, ", "))
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return ctrlwebhook.Allowed("by pass")
+}
+
+func (v *ValidatingHook) vpcFipCreateOrUpdateHook(ctx context.Context, req admission.Request) admission.Response {
+	fip := ovnv1.VpcFip{}
+	if err := v.decoder.DecodeRaw(req.Object, &fip); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := v.validateVpcNatRuleEip(ctx, fip.Spec.Eip); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	if net.ParseIP(fip.Spec.InternalIP) == nil {
+		err := fmt.Errorf("internalIP %s is not a valid ip", fip.Spec.InternalIP)
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return ctrlwebhook.Allowed("by pass")
+}
+
+func (v *ValidatingHook) vpcDnatRuleCreateOrUpdateHook(ctx context.Context, req admission.Request) admission.Response {
+	dnat := ovnv1.VpcDnatRule{}
+	if err := v.decoder.DecodeRaw(req.Object, &dnat); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := v.ValidateVpcDnatRule(ctx, &dnat); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return ctrlwebhook.Allowed("by pass")
+}
+
+func (v *ValidatingHook) vpcSnatRuleCreateOrUpdateHook(ctx context.Context, req admission.Request) admission.Response {
+	snat := ovnv1.VpcSnatRule{}
+	if err := v.decoder.DecodeRaw(req.Object, &snat); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := v.validateVpcNatRuleEip(ctx, snat.Spec.Eip); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	if err := util.CheckCidrs(snat.Spec.InternalCIDR); err != nil {
+		err = fmt.Errorf("invalid cidr %s", snat.Spec.InternalCIDR)
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return ctrlwebhook.Allowed("by pass")
+}
+
+func (v *ValidatingHook) ValidateVpcEip(ctx context.Context, eip *ovnv1.VpcEip) error {
+	if eip.Spec.Vpc == "" {
+		err := fmt.Errorf("parameter \"vpc\" cannot be empty")
+		return err
+	}
+	vpc := &ovnv1.Vpc{}
+	if err := v.cache.Get(ctx, types.NamespacedName{Name: eip.Spec.Vpc}, vpc); err != nil {
+		return err
+	}
+
+	if eip.Spec.V4Ip != "" {
+		if ip := net.ParseIP(eip.Spec.V4Ip); ip == nil || ip.To4() == nil {
+			err := fmt.Errorf("v4Ip %s is not a valid ipv4 address", eip.Spec.V4Ip)
+			return err
+		}
+	}
+	if eip.Spec.ExternalSubnet != "" {
+		subnet := &ovnv1.Subnet{}
+		if err := v.cache.Get(ctx, types.NamespacedName{Name: eip.Spec.ExternalSubnet}, subnet); err != nil {
+			return err
+		}
+		if eip.Spec.V4Ip != "" && !util.CIDRContainIP(subnet.Spec.CIDRBlock, eip.Spec.V4Ip) {
+			err := fmt.Errorf("v4Ip %s is not in the range of subnet \"%s\", cidr %v",
+				eip.Spec.V4Ip, subnet.Name, subnet.Spec.CIDRBlock)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *ValidatingHook) ValidateVpcDnatRule(ctx context.Context, dnat *ovnv1.VpcDnatRule) error {
+	if err := v.validateVpcNatRuleEip(ctx, dnat.Spec.Eip); err != nil {
+		return err
+	}
+
+	if dnat.Spec.ExternalPort == "" {
+		err := fmt.Errorf("parameter \"externalPort\" cannot be empty")
+		return err
+	}
+	if dnat.Spec.InternalPort == "" {
+		err := fmt.Errorf("parameter \"internalPort\" cannot be empty")
+		return err
+	}
+	mappings, err := util.ParsePortMappings(dnat.Spec.ExternalPort, dnat.Spec.InternalPort)
+	if err != nil {
+		return err
+	}
+	if net.ParseIP(dnat.Spec.InternalIP) == nil {
+		err := fmt.Errorf("internalIP %s is not a valid ip", dnat.Spec.InternalIP)
+		return err
+	}
+	if !strings.EqualFold(dnat.Spec.Protocol, "tcp") &&
+		!strings.EqualFold(dnat.Spec.Protocol, "udp") {
+		err := fmt.Errorf("invalid protocol: %s, supported params: \"tcp\", \"udp\"", dnat.Spec.Protocol)
+		return err
+	}
+
+	dnatList := ovnv1.VpcDnatRuleList{}
+	if err := v.cache.List(ctx, &dnatList); err != nil {
+		return err
+	}
+	for _, d := range dnatList.Items {
+		if d.Name == dnat.Name || d.Spec.Eip != dnat.Spec.Eip || util.DnatProtocol(d.Spec.Protocol) != util.DnatProtocol(dnat.Spec.Protocol) {
+			continue
+		}
+		others, err := util.ParsePortMappings(d.Spec.ExternalPort, d.Spec.InternalPort)
+		if err != nil {
+			continue
+		}
+		if util.PortMappingsOverlap(mappings, others) {
+			return fmt.Errorf("externalPort %s overlaps with externalPort %s of dnat %s on eip %s", dnat.Spec.ExternalPort, d.Spec.ExternalPort, d.Name, dnat.Spec.Eip)
+		}
+	}
+
+	return nil
+}
+
+func (v *ValidatingHook) validateVpcNatRuleEip(ctx context.Context, eipName string) error {
+	if eipName == "" {
+		err := fmt.Errorf("parameter \"eip\" cannot be empty")
+		return err
+	}
+	eip := &ovnv1.VpcEip{}
+	return v.cache.Get(ctx, types.NamespacedName{Name: eipName}, eip)
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net"
 	"net/http"
-	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -458,19 +457,8 @@ func (v *ValidatingHook) ValidateIptablesDnat(ctx context.Context, dnat *ovnv1.I
 		return err
 	}
 
-	if port, err := strconv.Atoi(dnat.Spec.ExternalPort); err != nil {
-		errMsg := fmt.Errorf("failed to parse externalPort %s: %v", dnat.Spec.ExternalPort, err)
-		return errMsg
-	} else if port < 0 || port > 65535 {
-		err := fmt.Errorf("externalPort %s is not a valid port", dnat.Spec.ExternalPort)
-		return err
-	}
-
-	if port, err := strconv.Atoi(dnat.Spec.InternalPort); err != nil {
-		errMsg := fmt.Errorf("failed to parse internalIP %s: %v", dnat.Spec.InternalPort, err)
-		return errMsg
-	} else if port < 0 || port > 65535 {
-		err := fmt.Errorf("internalIP %s is not a valid port", dnat.Spec.InternalPort)
+	mappings, err := util.ParsePortMappings(dnat.Spec.ExternalPort, dnat.Spec.InternalPort)
+	if err != nil {
 		return err
 	}
 
@@ -485,6 +473,23 @@ func (v *ValidatingHook) ValidateIptablesDnat(ctx context.Context, dnat *ovnv1.I
 		return err
 	}
 
+	dnatList := ovnv1.IptablesDnatRuleList{}
+	if err := v.cache.List(ctx, &dnatList); err != nil {
+		return err
+	}
+	for _, d := range dnatList.Items {
+		if d.Name == dnat.Name || d.Spec.EIP != dnat.Spec.EIP || util.DnatProtocol(d.Spec.Protocol) != util.DnatProtocol(dnat.Spec.Protocol) {
+			continue
+		}
+		others, err := util.ParsePortMappings(d.Spec.ExternalPort, d.Spec.InternalPort)
+		if err != nil {
+			continue
+		}
+		if util.PortMappingsOverlap(mappings, others) {
+			return fmt.Errorf("externalPort %s overlaps with externalPort %s of dnat %s on eip %s", dnat.Spec.ExternalPort, d.Spec.ExternalPort, d.Name, dnat.Spec.EIP)
+		}
+	}
+
 	return nil
 }
 
@@ -503,6 +508,23 @@ func (v *ValidatingHook) ValidateIptablesSnat(ctx context.Context, snat *ovnv1.I
 		return fmt.Errorf("invalid cidr %s", snat.Spec.InternalCIDR)
 	}
 
+	if snat.Spec.PortBlockSize != 0 {
+		v4Cidr, _ := util.SplitStringIP(snat.Spec.InternalCIDR)
+		if _, err := util.SnatPortBlockPorts(v4Cidr, int(snat.Spec.PortBlockSize)); err != nil {
+			return fmt.Errorf("invalid port block size %d: %v", snat.Spec.PortBlockSize, err)
+		}
+	}
+
+	snatList := ovnv1.IptablesSnatRuleList{}
+	if err := v.cache.List(ctx, &snatList); err != nil {
+		return err
+	}
+	for _, s := range snatList.Items {
+		if s.Name != snat.Name && s.Spec.EIP == snat.Spec.EIP && (s.Spec.PortBlockSize == 0) != (snat.Spec.PortBlockSize == 0) {
+			return fmt.Errorf("snat %s on eip %s must be both in or both not in port block mode with snat %s", snat.Name, snat.Spec.EIP, s.Name)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+var vpcPeeringConnectionGVK = metav1.GroupVersionKind{Group: ovnv1.SchemeGroupVersion.Group, Version: ovnv1.SchemeGroupVersion.Version, Kind: "VpcPeeringConnection"}
+
+func (v *ValidatingHook) vpcPeeringConnectionCreateHook(ctx context.Context, req admission.Request) admission.Response {
+	conn := ovnv1.VpcPeeringConnection{}
+	if err := v.decoder.DecodeRaw(req.Object, &conn); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := v.ValidateVpcPeeringConnection(ctx, &conn); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return ctrlwebhook.Allowed("by pass")
+}
+
+func (v *ValidatingHook) vpcPeeringConnectionUpdateHook(ctx context.Context, req admission.Request) admission.Response {
+	connNew := ovnv1.VpcPeeringConnection{}
+	if err := v.decoder.DecodeRaw(req.Object, &connNew); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	connOld := ovnv1.VpcPeeringConnection{}
+	if err := v.decoder.DecodeRaw(req.OldObject, &connOld); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	if !connNew.DeletionTimestamp.IsZero() || reflect.DeepEqual(connOld.Spec, connNew.Spec) {
+		return ctrlwebhook.Allowed("by pass")
+	}
+
+	// only the propagated subnets can be changed
+	if connOld.Spec.Vpc != connNew.Spec.Vpc || connOld.Spec.RemoteVpc != connNew.Spec.RemoteVpc ||
+		!reflect.DeepEqual(connOld.Spec.Requester, connNew.Spec.Requester) {
+		err := fmt.Errorf("VpcPeeringConnection \"%s\" only supports changing subnets", connNew.Name)
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	if err := v.ValidateVpcPeeringConnection(ctx, &connNew); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return ctrlwebhook.Allowed("by pass")
+}
+
+func (v *ValidatingHook) ValidateVpcPeeringConnection(ctx context.Context, conn *ovnv1.VpcPeeringConnection) error {
+	if conn.Spec.Vpc == "" {
+		return fmt.Errorf("parameter \"vpc\" cannot be empty")
+	}
+	if (conn.Spec.RemoteVpc == "") == (conn.Spec.Requester == nil) {
+		return fmt.Errorf("exactly one of \"remoteVpc\" of the requester and \"requester\" of the accepter must be set")
+	}
+	if conn.Spec.RemoteVpc == conn.Spec.Vpc {
+		return fmt.Errorf("vpc %s cannot peer with itself", conn.Spec.Vpc)
+	}
+	if conn.Spec.Requester != nil && (conn.Spec.Requester.Namespace == "" || conn.Spec.Requester.Name == "") {
+		return fmt.Errorf("namespace and name of the requester cannot be empty")
+	}
+
+	// tenants can only peer the vpcs owned by their namespaces
+	vpc := &ovnv1.Vpc{}
+	if err := v.cache.Get(ctx, types.NamespacedName{Name: conn.Spec.Vpc}, vpc); err != nil {
+		return err
+	}
+	if !slices.Contains(vpc.Spec.Namespaces, conn.Namespace) {
+		return fmt.Errorf("vpc %s is not owned by namespace %s", vpc.Name, conn.Namespace)
+	}
+	if conn.Spec.RemoteVpc != "" {
+		for _, peering := range vpc.Spec.VpcPeerings {
+			if peering.RemoteVpc == conn.Spec.RemoteVpc {
+				return fmt.Errorf("vpc %s has peered with vpc %s by vpcPeerings", vpc.Name, conn.Spec.RemoteVpc)
+			}
+		}
+	}
+
+	for _, name := range conn.Spec.Subnets {
+		subnet := &ovnv1.Subnet{}
+		if err := v.cache.Get(ctx, types.NamespacedName{Name: name}, subnet); err != nil {
+			return err
+		}
+		if subnet.Spec.Vpc != conn.Spec.Vpc {
+			return fmt.Errorf("subnet %s does not belong to vpc %s", name, conn.Spec.Vpc)
+		}
+	}
+
+	connList := ovnv1.VpcPeeringConnectionList{}
+	if err := v.cache.List(ctx, &connList); err != nil {
+		return err
+	}
+	for _, item := range connList.Items {
+		if item.Namespace == conn.Namespace && item.Name == conn.Name {
+			continue
+		}
+		if conn.Spec.RemoteVpc != "" && item.Spec.RemoteVpc != "" &&
+			((item.Spec.Vpc == conn.Spec.Vpc && item.Spec.RemoteVpc == conn.Spec.RemoteVpc) ||
+				(item.Spec.Vpc == conn.Spec.RemoteVpc && item.Spec.RemoteVpc == conn.Spec.Vpc)) {
+			return fmt.Errorf("vpc %s and vpc %s have been requested to peer by %s/%s", conn.Spec.Vpc, conn.Spec.RemoteVpc, item.Namespace, item.Name)
+		}
+		if conn.Spec.Requester != nil && reflect.DeepEqual(item.Spec.Requester, conn.Spec.Requester) {
+			return fmt.Errorf("requester %s has been accepted by %s/%s", conn.Spec.Requester, item.Namespace, item.Name)
+		}
+	}
+
+	// the vpcs attached by a transit hub share the router ports with the peering
+	if conn.Spec.RemoteVpc != "" {
+		hubList := ovnv1.VpcTransitHubList{}
+		if err := v.cache.List(ctx, &hubList); err != nil {
+			return err
+		}
+		for _, hub := range hubList.Items {
+			for _, attachment := range hub.Spec.Attachments {
+				if (hub.Spec.Vpc == conn.Spec.Vpc && attachment.Vpc == conn.Spec.RemoteVpc) ||
+					(hub.Spec.Vpc == conn.Spec.RemoteVpc && attachment.Vpc == conn.Spec.Vpc) {
+					return fmt.Errorf("vpc %s is attached to vpc %s by vpc transit hub %s", attachment.Vpc, hub.Spec.Vpc, hub.Name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+)
+
+var vpcTransitHubGVK = metav1.GroupVersionKind{Group: ovnv1.SchemeGroupVersion.Group, Version: ovnv1.SchemeGroupVersion.Version, Kind: "VpcTransitHub"}
+
+func (v *ValidatingHook) vpcTransitHubCreateHook(ctx context.Context, req admission.Request) admission.Response {
+	hub := ovnv1.VpcTransitHub{}
+	if err := v.decoder.DecodeRaw(req.Object, &hub); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := v.ValidateVpcTransitHub(ctx, &hub); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return ctrlwebhook.Allowed("by pass")
+}
+
+func (v *ValidatingHook) vpcTransitHubUpdateHook(ctx context.Context, req admission.Request) admission.Response {
+	hubNew := ovnv1.VpcTransitHub{}
+	if err := v.decoder.DecodeRaw(req.Object, &hubNew); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	hubOld := ovnv1.VpcTransitHub{}
+	if err := v.decoder.DecodeRaw(req.OldObject, &hubOld); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	if !hubNew.DeletionTimestamp.IsZero() || reflect.DeepEqual(hubOld.Spec, hubNew.Spec) {
+		return ctrlwebhook.Allowed("by pass")
+	}
+
+	if hubOld.Spec.Vpc != hubNew.Spec.Vpc {
+		err := fmt.Errorf("the hub vpc of VpcTransitHub \"%s\" can not be changed", hubNew.Name)
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+	if err := v.ValidateVpcTransitHub(ctx, &hubNew); err != nil {
+		return ctrlwebhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return ctrlwebhook.Allowed("by pass")
+}
+
+func (v *ValidatingHook) ValidateVpcTransitHub(ctx context.Context, hub *ovnv1.VpcTransitHub) error {
+	if hub.Spec.Vpc == "" {
+		return fmt.Errorf("parameter \"vpc\" cannot be empty")
+	}
+	hubVpc := &ovnv1.Vpc{}
+	if err := v.cache.Get(ctx, types.NamespacedName{Name: hub.Spec.Vpc}, hubVpc); err != nil {
+		return err
+	}
+
+	attached := make(map[string]bool, len(hub.Spec.Attachments))
+	for _, attachment := range hub.Spec.Attachments {
+		if attachment.Vpc == "" {
+			return fmt.Errorf("vpc of the attachments cannot be empty")
+		}
+		if attachment.Vpc == hub.Spec.Vpc {
+			return fmt.Errorf("vpc %s cannot be attached to itself", attachment.Vpc)
+		}
+		if attached[attachment.Vpc] {
+			return fmt.Errorf("vpc %s is attached more than once", attachment.Vpc)
+		}
+		attached[attachment.Vpc] = true
+		for _, routeTable := range attachment.Propagations {
+			if routeTable == "" {
+				return fmt.Errorf("route tables propagated by vpc %s cannot be empty", attachment.Vpc)
+			}
+		}
+		// the route tables of the hub share the ovn route tables with the static routes of the hub vpc
+		for _, routeTable := range append([]string{attachment.RouteTable}, attachment.Propagations...) {
+			if routeTable == "" || routeTable == ovnv1.VpcTransitHubMainRouteTable {
+				continue
+			}
+			for _, route := range hubVpc.Spec.StaticRoutes {
+				if route.RouteTable == routeTable {
+					return fmt.Errorf("route table %s has been used by the static routes of vpc %s", routeTable, hubVpc.Name)
+				}
+			}
+		}
+
+		vpc := &ovnv1.Vpc{}
+		if err := v.cache.Get(ctx, types.NamespacedName{Name: attachment.Vpc}, vpc); err != nil {
+			return err
+		}
+		// the interconnect shares the router ports with the peerings between the hub and the spoke
+		for _, peering := range hubVpc.Spec.VpcPeerings {
+			if peering.RemoteVpc == attachment.Vpc {
+				return fmt.Errorf("vpc %s has peered with vpc %s by vpcPeerings", hubVpc.Name, attachment.Vpc)
+			}
+		}
+		for _, peering := range vpc.Spec.VpcPeerings {
+			if peering.RemoteVpc == hubVpc.Name {
+				return fmt.Errorf("vpc %s has peered with vpc %s by vpcPeerings", vpc.Name, hubVpc.Name)
+			}
+		}
+		for _, name := range attachment.Subnets {
+			subnet := &ovnv1.Subnet{}
+			if err := v.cache.Get(ctx, types.NamespacedName{Name: name}, subnet); err != nil {
+				return err
+			}
+			if subnet.Spec.Vpc != attachment.Vpc {
+				return fmt.Errorf("subnet %s does not belong to vpc %s", name, attachment.Vpc)
+			}
+		}
+	}
+
+	// a vpc can only be used by one hub, either as the hub or as a spoke
+	hubList := ovnv1.VpcTransitHubList{}
+	if err := v.cache.List(ctx, &hubList); err != nil {
+		return err
+	}
+	for _, item := range hubList.Items {
+		if item.Name == hub.Name {
+			continue
+		}
+		used := map[string]bool{item.Spec.Vpc: true}
+		for _, attachment := range item.Spec.Attachments {
+			used[attachment.Vpc] = true
+		}
+		if used[hub.Spec.Vpc] {
+			return fmt.Errorf("vpc %s has been used by vpc transit hub %s", hub.Spec.Vpc, item.Name)
+		}
+		for _, attachment := range hub.Spec.Attachments {
+			if used[attachment.Vpc] {
+				return fmt.Errorf("vpc %s has been used by vpc transit hub %s", attachment.Vpc, item.Name)
+			}
+		}
+	}
+
+	connList := ovnv1.VpcPeeringConnectionList{}
+	if err := v.cache.List(ctx, &connList); err != nil {
+		return err
+	}
+	for _, conn := range connList.Items {
+		if (conn.Spec.Vpc == hub.Spec.Vpc && attached[conn.Spec.RemoteVpc]) ||
+			(conn.Spec.RemoteVpc == hub.Spec.Vpc && attached[conn.Spec.Vpc]) {
+			return fmt.Errorf("vpc %s and vpc %s have been requested to peer by %s/%s", conn.Spec.Vpc, conn.Spec.RemoteVpc, conn.Namespace, conn.Name)
+		}
+	}
+
+	return nil
+}
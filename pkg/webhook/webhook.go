@@ -73,6 +73,20 @@ func NewValidatingHook(client client.Client, scheme *runtime.Scheme, cache cache
 	updateHooks[ovnSnat] = v.ovnSnatUpdateHook
 	createHooks[ovnDnat] = v.ovnDnatCreateHook
 	updateHooks[ovnDnat] = v.ovnDnatUpdateHook
+
+	createHooks[vpcEipGVK] = v.vpcEipCreateHook
+	updateHooks[vpcEipGVK] = v.vpcEipUpdateHook
+	deleteHooks[vpcEipGVK] = v.vpcEipDeleteHook
+	createHooks[vpcFipGVK] = v.vpcFipCreateOrUpdateHook
+	updateHooks[vpcFipGVK] = v.vpcFipCreateOrUpdateHook
+	createHooks[vpcDnatRuleGVK] = v.vpcDnatRuleCreateOrUpdateHook
+	updateHooks[vpcDnatRuleGVK] = v.vpcDnatRuleCreateOrUpdateHook
+	createHooks[vpcSnatRuleGVK] = v.vpcSnatRuleCreateOrUpdateHook
+	updateHooks[vpcSnatRuleGVK] = v.vpcSnatRuleCreateOrUpdateHook
+	createHooks[vpcPeeringConnectionGVK] = v.vpcPeeringConnectionCreateHook
+	updateHooks[vpcPeeringConnectionGVK] = v.vpcPeeringConnectionUpdateHook
+	createHooks[vpcTransitHubGVK] = v.vpcTransitHubCreateHook
+	updateHooks[vpcTransitHubGVK] = v.vpcTransitHubUpdateHook
 	return v, nil
 }
 
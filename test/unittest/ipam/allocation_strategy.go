@@ -0,0 +1,153 @@
+package ipam
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	kubeovnv1 "github.com/kubeovn/kube-ovn/pkg/apis/kubeovn/v1"
+	"github.com/kubeovn/kube-ovn/pkg/ipam"
+)
+
+var _ = Describe("[IPAM Allocation Strategy]", func() {
+	subnetName := "test"
+	cidr := "10.16.0.0/29"
+	gw := "10.16.0.1"
+
+	newIPAM := func(strategy string, cooldown time.Duration) *ipam.IPAM {
+		im := ipam.NewIPAM()
+		Expect(im.AddOrUpdateSubnet(subnetName, cidr, gw, []string{gw})).ShouldNot(HaveOccurred())
+		Expect(im.SetSubnetAllocationStrategy(subnetName, strategy, cooldown)).ShouldNot(HaveOccurred())
+		return im
+	}
+
+	allocate := func(im *ipam.IPAM, pod string) (string, error) {
+		ip, _, _, err := im.GetRandomAddress(pod, pod, nil, subnetName, "", nil, true)
+		return ip, err
+	}
+
+	// allocateAll allocates all the 5 available addresses to pod1..pod5
+	allocateAll := func(im *ipam.IPAM) {
+		for i := 1; i <= 5; i++ {
+			_, err := allocate(im, fmt.Sprintf("pod%d", i))
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+	}
+
+	It("sequential", func() {
+		im := newIPAM(kubeovnv1.IPAllocationStrategySequential, 0)
+		ip, err := allocate(im, "pod1")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ip).To(Equal("10.16.0.2"))
+		ip, err = allocate(im, "pod2")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ip).To(Equal("10.16.0.3"))
+
+		By("reuse the lowest released address once the free list is exhausted")
+		_, err = allocate(im, "pod3")
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = allocate(im, "pod4")
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = allocate(im, "pod5")
+		Expect(err).ShouldNot(HaveOccurred())
+		im.ReleaseAddressByPod("pod4")
+		im.ReleaseAddressByPod("pod2")
+		ip, err = allocate(im, "pod6")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ip).To(Equal("10.16.0.3"))
+	})
+
+	It("random", func() {
+		im := newIPAM(kubeovnv1.IPAllocationStrategyRandom, 0)
+		_, ipNet, _ := net.ParseCIDR(cidr)
+		allocated := map[string]bool{}
+		for i := 1; i <= 5; i++ {
+			ip, err := allocate(im, fmt.Sprintf("pod%d", i))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ipNet.Contains(net.ParseIP(ip))).To(BeTrue())
+			Expect(ip).NotTo(Equal(gw))
+			Expect(allocated).NotTo(HaveKey(ip))
+			allocated[ip] = true
+		}
+
+		_, err := allocate(im, "pod6")
+		Expect(err).Should(MatchError(ipam.ErrNoAvailable))
+	})
+
+	It("least recently released", func() {
+		im := newIPAM(kubeovnv1.IPAllocationStrategyLeastRecentlyReleased, 0)
+		allocateAll(im)
+		im.ReleaseAddressByPod("pod3")
+		im.ReleaseAddressByPod("pod1")
+		now := time.Now()
+		im.Subnets[subnetName].ReleaseTime["10.16.0.4"] = now.Add(-time.Minute)
+		im.Subnets[subnetName].ReleaseTime["10.16.0.2"] = now
+
+		ip, err := allocate(im, "pod6")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ip).To(Equal("10.16.0.4"))
+		Expect(im.Subnets[subnetName].ReleaseTime).NotTo(HaveKey("10.16.0.4"))
+		ip, err = allocate(im, "pod7")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ip).To(Equal("10.16.0.2"))
+	})
+
+	It("release cooldown", func() {
+		im := newIPAM(kubeovnv1.IPAllocationStrategySequential, time.Hour)
+		allocateAll(im)
+		im.ReleaseAddressByPod("pod1")
+
+		_, err := allocate(im, "pod6")
+		Expect(err).Should(MatchError(ipam.ErrNoAvailable))
+
+		By("static allocation is not affected by the cooldown")
+		_, _, _, err = im.GetStaticAddress("pod7", "pod7", "10.16.0.2", nil, subnetName, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		im.ReleaseAddressByPod("pod7")
+
+		Expect(im.SetSubnetAllocationStrategy(subnetName, kubeovnv1.IPAllocationStrategySequential, 0)).ShouldNot(HaveOccurred())
+		ip, err := allocate(im, "pod6")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ip).To(Equal("10.16.0.2"))
+	})
+
+	It("prune release times after the cooldown", func() {
+		cooldown := 100 * time.Millisecond
+		im := newIPAM(kubeovnv1.IPAllocationStrategySequential, cooldown)
+		allocateAll(im)
+		im.ReleaseAddressByPod("pod1")
+		im.ReleaseAddressByPod("pod2")
+		Expect(im.Subnets[subnetName].ReleaseTime).To(HaveLen(2))
+
+		_, err := allocate(im, "pod6")
+		Expect(err).Should(MatchError(ipam.ErrNoAvailable))
+
+		time.Sleep(cooldown)
+		ip, err := allocate(im, "pod6")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ip).To(Equal("10.16.0.2"))
+		Expect(im.Subnets[subnetName].ReleaseTime).To(BeEmpty())
+
+		By("release times are not recorded without a cooldown")
+		Expect(im.SetSubnetAllocationStrategy(subnetName, kubeovnv1.IPAllocationStrategySequential, 0)).ShouldNot(HaveOccurred())
+		im.ReleaseAddressByPod("pod3")
+		Expect(im.Subnets[subnetName].ReleaseTime).To(BeEmpty())
+	})
+
+	It("keep release times for least recently released", func() {
+		cooldown := 100 * time.Millisecond
+		im := newIPAM(kubeovnv1.IPAllocationStrategyLeastRecentlyReleased, cooldown)
+		allocateAll(im)
+		im.ReleaseAddressByPod("pod3")
+		im.ReleaseAddressByPod("pod1")
+
+		time.Sleep(cooldown)
+		ip, err := allocate(im, "pod6")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ip).To(Equal("10.16.0.4"))
+		Expect(im.Subnets[subnetName].ReleaseTime).To(HaveKey("10.16.0.2"))
+	})
+})
@@ -0,0 +1,93 @@
+package ipam
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/kubeovn/kube-ovn/pkg/ipam"
+)
+
+var _ = Describe("[IPAM Checkpoint]", func() {
+	subnetName := "test"
+	dualCIDR := "10.16.0.0/16,fd00::/112"
+	dualGw := "10.16.0.1,fd00::1"
+	excludeIPs := []string{"10.16.0.1", "10.16.0.10..10.16.0.20", "fd00::1"}
+
+	newIPAM := func() *ipam.IPAM {
+		im := ipam.NewIPAM()
+		Expect(im.AddOrUpdateSubnet(subnetName, dualCIDR, dualGw, excludeIPs)).ShouldNot(HaveOccurred())
+		Expect(im.AddOrUpdateIPPool(subnetName, "pool1", []string{"10.16.1.0/24", "fd00::100..fd00::1ff"})).ShouldNot(HaveOccurred())
+		return im
+	}
+
+	It("save and restore", func() {
+		im := newIPAM()
+		mac := "00:00:00:11:22:33"
+		_, _, _, err := im.GetStaticAddress("pod1", "pod1", "10.16.0.2,fd00::2", &mac, subnetName, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, _, _, err = im.GetRandomAddress("pod2", "pod2", nil, subnetName, "pool1", nil, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, _, _, err = im.GetRandomAddress("pod3", "pod3", nil, subnetName, "", nil, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		im.ReleaseAddressByPod("pod3")
+
+		path := filepath.Join(GinkgoT().TempDir(), "ipam.json")
+		Expect(ipam.SaveCheckpoint(path, im.Checkpoint())).ShouldNot(HaveOccurred())
+
+		cp, err := ipam.LoadCheckpoint(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		restored := ipam.NewIPAM()
+		Expect(restored.Restore(cp)).ShouldNot(HaveOccurred())
+		Expect(restored.Checkpoint()).To(Equal(im.Checkpoint()))
+
+		v4Using, v6Using, v4Available, v6Available := im.GetSubnetIPRangeString(subnetName)
+		rv4Using, rv6Using, rv4Available, rv6Available := restored.GetSubnetIPRangeString(subnetName)
+		Expect(rv4Using).To(Equal(v4Using))
+		Expect(rv6Using).To(Equal(v6Using))
+		Expect(rv4Available).To(Equal(v4Available))
+		Expect(rv6Available).To(Equal(v6Available))
+
+		Expect(restored.HasAddress("pod1", "pod1", "10.16.0.2,fd00::2", mac, subnetName)).To(BeTrue())
+		Expect(restored.HasAddress("pod1", "pod1", "10.16.0.3,fd00::2", mac, subnetName)).To(BeFalse())
+		Expect(restored.HasAddress("pod1", "pod1", "10.16.0.2,fd00::2", "00:00:00:11:22:44", subnetName)).To(BeFalse())
+		Expect(restored.HasAddress("pod4", "pod4", "10.16.0.2", "", subnetName)).To(BeFalse())
+
+		By("allocate from restored ipam")
+		v4, v6, _, err := restored.GetRandomAddress("pod4", "pod4", nil, subnetName, "", nil, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v4).NotTo(Equal("10.16.0.2"))
+		Expect(v6).NotTo(Equal("fd00::2"))
+	})
+
+	It("prune stale state", func() {
+		im := newIPAM()
+		_, _, _, err := im.GetStaticAddress("pod1", "pod1", "10.16.0.2,fd00::2", nil, subnetName, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, _, _, err = im.GetStaticAddress("pod2", "pod2", "10.16.0.3,fd00::3", nil, subnetName, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(im.AddOrUpdateSubnet("stale", "192.168.0.0/24", "192.168.0.1", nil)).ShouldNot(HaveOccurred())
+
+		im.PruneCheckpoint(map[string][]string{subnetName: {}}, map[string]map[string]bool{subnetName: {"pod1": true}})
+		Expect(im.Subnets).NotTo(HaveKey("stale"))
+		Expect(im.Subnets[subnetName].IPPools).NotTo(HaveKey("pool1"))
+		Expect(im.ContainAddress("10.16.0.2")).To(BeTrue())
+		Expect(im.ContainAddress("10.16.0.3")).To(BeFalse())
+		Expect(im.ContainAddress("fd00::3")).To(BeFalse())
+	})
+
+	It("reject corrupted checkpoint", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "ipam.json")
+		Expect(ipam.SaveCheckpoint(path, newIPAM().Checkpoint())).ShouldNot(HaveOccurred())
+
+		content, err := os.ReadFile(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		content[len(content)/2] ^= 0xff
+		Expect(os.WriteFile(path, content, 0o600)).ShouldNot(HaveOccurred())
+
+		_, err = ipam.LoadCheckpoint(path)
+		Expect(err).Should(MatchError(ipam.ErrCheckpointCorrupted))
+	})
+})
@@ -0,0 +1,122 @@
+package ipam
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/kubeovn/kube-ovn/pkg/ipam"
+)
+
+var _ = Describe("[IPAM Quota]", func() {
+	subnetName := "test"
+
+	inNamespace := func(namespace string) func(string) bool {
+		return func(podName string) bool {
+			return strings.HasPrefix(podName, namespace+"/")
+		}
+	}
+
+	newIPAM := func() *ipam.IPAM {
+		im := ipam.NewIPAM()
+		Expect(im.AddOrUpdateSubnet(subnetName, "10.16.0.0/24,fd00::/120", "10.16.0.1,fd00::1", []string{"10.16.0.1", "fd00::1"})).ShouldNot(HaveOccurred())
+		return im
+	}
+
+	allocate := func(im *ipam.IPAM, pod string) error {
+		_, _, _, err := im.GetRandomAddress(pod, pod, nil, subnetName, "", nil, true)
+		return err
+	}
+
+	It("reject random allocation over quota", func() {
+		im := newIPAM()
+		im.AddOrUpdateQuota("ns1", subnetName, 2, 0, inNamespace("ns1"))
+
+		Expect(allocate(im, "ns1/pod1")).ShouldNot(HaveOccurred())
+		Expect(allocate(im, "ns1/pod2")).ShouldNot(HaveOccurred())
+		Expect(allocate(im, "ns1/pod3")).Should(MatchError(ipam.ErrQuotaExceeded))
+		Expect(allocate(im, "ns2/pod1")).ShouldNot(HaveOccurred())
+
+		v4, v6 := im.QuotaUsage("ns1")
+		Expect(v4).To(Equal(2))
+		Expect(v6).To(Equal(2))
+
+		By("allocate again for a nic already holding addresses")
+		Expect(allocate(im, "ns1/pod1")).ShouldNot(HaveOccurred())
+
+		By("allocate after an address is released")
+		im.ReleaseAddressByPod("ns1/pod1")
+		Expect(allocate(im, "ns1/pod3")).ShouldNot(HaveOccurred())
+	})
+
+	It("reject static allocation over quota", func() {
+		im := newIPAM()
+		im.AddOrUpdateQuota("ns1", subnetName, 0, 1, inNamespace("ns1"))
+
+		_, _, _, err := im.GetStaticAddress("ns1/pod1", "ns1/pod1", "10.16.0.10,fd00::10", nil, subnetName, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, _, _, err = im.GetStaticAddress("ns1/pod2", "ns1/pod2", "10.16.0.11,fd00::11", nil, subnetName, true)
+		Expect(err).Should(MatchError(ipam.ErrQuotaExceeded))
+		Expect(im.ContainAddress("10.16.0.11")).To(BeFalse())
+	})
+
+	It("update and delete quota", func() {
+		im := newIPAM()
+		im.AddOrUpdateQuota("ns1", subnetName, 1, 0, inNamespace("ns1"))
+		Expect(allocate(im, "ns1/pod1")).ShouldNot(HaveOccurred())
+		Expect(allocate(im, "ns1/pod2")).Should(MatchError(ipam.ErrQuotaExceeded))
+
+		im.AddOrUpdateQuota("ns1", subnetName, 2, 0, inNamespace("ns1"))
+		Expect(allocate(im, "ns1/pod2")).ShouldNot(HaveOccurred())
+		Expect(allocate(im, "ns1/pod3")).Should(MatchError(ipam.ErrQuotaExceeded))
+
+		im.DeleteQuota("ns1")
+		Expect(allocate(im, "ns1/pod3")).ShouldNot(HaveOccurred())
+		v4, v6 := im.QuotaUsage("ns1")
+		Expect(v4).To(BeZero())
+		Expect(v6).To(BeZero())
+	})
+
+	It("track usage on allocation and release", func() {
+		im := newIPAM()
+		Expect(allocate(im, "ns1/pod1")).ShouldNot(HaveOccurred())
+		Expect(allocate(im, "ns2/pod1")).ShouldNot(HaveOccurred())
+
+		By("count the addresses allocated before the quota is added")
+		im.AddOrUpdateQuota("ns1", subnetName, 0, 0, inNamespace("ns1"))
+		v4, v6 := im.QuotaUsage("ns1")
+		Expect(v4).To(Equal(1))
+		Expect(v6).To(Equal(1))
+
+		_, _, _, err := im.GetStaticAddress("ns1/pod2", "ns1/pod2", "10.16.0.10", nil, subnetName, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		v4, v6 = im.QuotaUsage("ns1")
+		Expect(v4).To(Equal(2))
+		Expect(v6).To(Equal(2))
+
+		im.ReleaseAddressByNic("ns1/pod2", "ns1/pod2", subnetName)
+		v4, v6 = im.QuotaUsage("ns1")
+		Expect(v4).To(Equal(1))
+		Expect(v6).To(Equal(1))
+
+		im.ReleaseAddressByPod("ns1/pod1")
+		v4, v6 = im.QuotaUsage("ns1")
+		Expect(v4).To(BeZero())
+		Expect(v6).To(BeZero())
+
+		By("recount after the subnet is deleted")
+		Expect(allocate(im, "ns1/pod3")).ShouldNot(HaveOccurred())
+		im.DeleteSubnet(subnetName)
+		v4, v6 = im.QuotaUsage("ns1")
+		Expect(v4).To(BeZero())
+		Expect(v6).To(BeZero())
+	})
+
+	It("quota of another subnet", func() {
+		im := newIPAM()
+		im.AddOrUpdateQuota("ns1", "other", 1, 1, inNamespace("ns1"))
+		Expect(allocate(im, "ns1/pod1")).ShouldNot(HaveOccurred())
+		Expect(allocate(im, "ns1/pod2")).ShouldNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,97 @@
+package ipam
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/kubeovn/kube-ovn/pkg/ipam"
+)
+
+var _ = Describe("[IPAM Secondary CIDR]", func() {
+	subnetName := "test"
+
+	allocate := func(im *ipam.IPAM, pod string) (string, error) {
+		ip, _, _, err := im.GetRandomAddress(pod, pod, nil, subnetName, "", nil, true)
+		return ip, err
+	}
+
+	It("allocate from secondary cidr", func() {
+		im := ipam.NewIPAM()
+		Expect(im.AddOrUpdateSubnet(subnetName, "10.16.0.0/30", "10.16.0.1", []string{"10.16.0.1", "10.17.0.1"}, "10.17.0.0/30")).ShouldNot(HaveOccurred())
+
+		ip, err := allocate(im, "pod1")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ip).To(Equal("10.16.0.2"))
+		ip, err = allocate(im, "pod2")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ip).To(Equal("10.17.0.2"))
+		_, err = allocate(im, "pod3")
+		Expect(err).Should(MatchError(ipam.ErrNoAvailable))
+
+		By("static address in secondary cidr")
+		im.ReleaseAddressByPod("pod2")
+		_, _, _, err = im.GetStaticAddress("pod3", "pod3", "10.17.0.2", nil, subnetName, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, _, _, err = im.GetStaticAddress("pod4", "pod4", "10.18.0.2", nil, subnetName, true)
+		Expect(err).Should(MatchError(ipam.ErrOutOfRange))
+
+		stats := im.CIDRStatistics(subnetName)
+		Expect(stats).To(HaveLen(2))
+		Expect(stats[0].CIDR).To(Equal("10.16.0.0/30"))
+		Expect(stats[0].Gateway).To(Equal("10.16.0.1"))
+		Expect(stats[0].Secondary).To(BeFalse())
+		Expect(stats[0].Using.EqualInt64(1)).To(BeTrue())
+		Expect(stats[0].Available.EqualInt64(0)).To(BeTrue())
+		Expect(stats[1].CIDR).To(Equal("10.17.0.0/30"))
+		Expect(stats[1].Gateway).To(Equal("10.17.0.1"))
+		Expect(stats[1].Secondary).To(BeTrue())
+		Expect(stats[1].Using.EqualInt64(1)).To(BeTrue())
+	})
+
+	It("add and remove secondary cidr", func() {
+		im := ipam.NewIPAM()
+		Expect(im.AddOrUpdateSubnet(subnetName, "10.16.0.0/30", "10.16.0.1", []string{"10.16.0.1"})).ShouldNot(HaveOccurred())
+		_, err := allocate(im, "pod1")
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = allocate(im, "pod2")
+		Expect(err).Should(MatchError(ipam.ErrNoAvailable))
+
+		Expect(im.AddOrUpdateSubnet(subnetName, "10.16.0.0/30", "10.16.0.1", []string{"10.16.0.1", "10.17.0.1"}, "10.17.0.0/30")).ShouldNot(HaveOccurred())
+		Expect(im.ContainAddress("10.16.0.2")).To(BeTrue())
+		ip, err := allocate(im, "pod2")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(ip).To(Equal("10.17.0.2"))
+
+		Expect(im.AddOrUpdateSubnet(subnetName, "10.16.0.0/30", "10.16.0.1", []string{"10.16.0.1"})).ShouldNot(HaveOccurred())
+		Expect(im.ContainAddress("10.16.0.2")).To(BeTrue())
+		Expect(im.ContainAddress("10.17.0.2")).To(BeFalse())
+		Expect(im.CIDRStatistics(subnetName)).To(HaveLen(1))
+	})
+
+	It("expand cidr prefix", func() {
+		im := ipam.NewIPAM()
+		Expect(im.AddOrUpdateSubnet(subnetName, "10.16.0.0/30,fd00::/126", "10.16.0.1,fd00::1", []string{"10.16.0.1", "fd00::1"})).ShouldNot(HaveOccurred())
+		v4, v6, _, err := im.GetRandomAddress("pod1", "pod1", nil, subnetName, "", nil, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v4).To(Equal("10.16.0.2"))
+		Expect(v6).To(Equal("fd00::2"))
+
+		Expect(im.AddOrUpdateSubnet(subnetName, "10.16.0.0/29,fd00::/125", "10.16.0.1,fd00::1", []string{"10.16.0.1", "fd00::1"})).ShouldNot(HaveOccurred())
+		Expect(im.ContainAddress("10.16.0.2")).To(BeTrue())
+		Expect(im.ContainAddress("fd00::2")).To(BeTrue())
+
+		v4, v6, _, err = im.GetRandomAddress("pod2", "pod2", nil, subnetName, "", nil, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v4).To(Equal("10.16.0.3"))
+		Expect(v6).To(Equal("fd00::3"))
+		v4, v6, _, err = im.GetRandomAddress("pod3", "pod3", nil, subnetName, "", nil, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v4).To(Equal("10.16.0.4"))
+		Expect(v6).To(Equal("fd00::4"))
+	})
+
+	It("reject secondary cidr of another protocol", func() {
+		im := ipam.NewIPAM()
+		Expect(im.AddOrUpdateSubnet(subnetName, "10.16.0.0/30", "10.16.0.1", nil, "fd00::/120")).Should(HaveOccurred())
+	})
+})